@@ -0,0 +1,79 @@
+package clip
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"toolbox/pkg/clipboard"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"unicode/utf8"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// ClipCmd 表示 clip 命令
+var ClipCmd = &cobra.Command{
+	Use:   "clip [文本...]",
+	Short: "复制到剪贴板或从剪贴板粘贴",
+	Long: `将标准输入或参数中的文本复制到系统剪贴板，或使用 --paste 将剪贴板内容输出到标准输出。
+
+Windows和macOS无需额外安装；Linux需要 xclip、xsel 或 wl-clipboard（Wayland）。
+通过SSH登录等没有剪贴板工具的环境下，复制会改用OSC 52控制序列，由支持该序列的
+终端（iTerm2、Windows Terminal、kitty、tmux等）写入本地剪贴板。
+
+其他命令可以使用全局 --clipboard 标志，将输出同时复制到剪贴板。
+
+示例:
+  echo hello | %[1]s clip              # 复制标准输入
+  %[1]s clip "some text"               # 复制参数
+  %[1]s clip --paste > note.txt        # 粘贴到文件
+  %[1]s clip --paste | %[1]s text grep error   # 在剪贴板内容中搜索
+  %[1]s network ipinfo --clipboard     # 输出并复制到剪贴板`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paste, _ := cmd.Flags().GetBool("paste")
+		trim, _ := cmd.Flags().GetBool("trim")
+
+		if paste {
+			if len(args) > 0 {
+				return errs.InvalidInput("--paste 不能与文本参数同时使用")
+			}
+			text, err := clipboard.Paste()
+			if err != nil {
+				return err
+			}
+			fmt.Print(text)
+			return nil
+		}
+
+		var text string
+		if len(args) > 0 {
+			text = strings.Join(args, " ")
+		} else {
+			if isatty.IsTerminal(os.Stdin.Fd()) {
+				return errs.InvalidInput("请通过管道输入要复制的内容，或直接指定文本")
+			}
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return i18n.Errorf("读取标准输入失败: %v", err)
+			}
+			text = string(data)
+		}
+		if trim {
+			text = strings.TrimRight(text, "\r\n")
+		}
+
+		if err := clipboard.Copy(text); err != nil {
+			return err
+		}
+		fmt.Fprint(os.Stderr, i18n.Tf("已复制 %d 个字符到剪贴板\n", utf8.RuneCountInString(text)))
+		return nil
+	},
+}
+
+func init() {
+	ClipCmd.Flags().BoolP("paste", "p", false, "将剪贴板内容输出到标准输出")
+	ClipCmd.Flags().Bool("trim", false, "去掉末尾的换行符")
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"toolbox/pkg/clipboard"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// noClipboard 不支持 --clipboard 的顶层命令：交互式界面、持续刷新的命令和 clip 本身
+var noClipboard = map[string]bool{
+	"tui":   true,
+	"watch": true,
+	"clip":  true,
+}
+
+// stdoutCapture 复制到剪贴板前捕获的标准输出
+type stdoutCapture struct {
+	stdout      *os.File
+	colorOutput io.Writer
+	writer      *os.File
+	buf         bytes.Buffer
+	done        chan struct{}
+}
+
+// capture 当前的捕获，未指定 --clipboard 时为nil
+var capture *stdoutCapture
+
+// addClipboardFlag 注册全局 --clipboard 标志
+func addClipboardFlag(root *cobra.Command) {
+	root.PersistentFlags().Bool("clipboard", false, "将命令的输出同时复制到剪贴板")
+}
+
+// setupClipboard 指定 --clipboard 时把标准输出替换为管道，输出照常显示的同时保存一份副本
+func setupClipboard(cmd *cobra.Command) error {
+	enabled, _ := cmd.Root().PersistentFlags().GetBool("clipboard")
+	if !enabled {
+		return nil
+	}
+
+	top := cmd
+	for top.HasParent() && top.Parent() != cmd.Root() {
+		top = top.Parent()
+	}
+	if noClipboard[top.Name()] {
+		return errs.InvalidInput("%s 不支持 --clipboard", cmd.CommandPath())
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return i18n.Errorf("创建管道失败: %v", err)
+	}
+	capture = &stdoutCapture{
+		stdout:      os.Stdout,
+		colorOutput: color.Output,
+		writer:      writer,
+		done:        make(chan struct{}),
+	}
+	go func(c *stdoutCapture) {
+		io.Copy(io.MultiWriter(c.stdout, &c.buf), reader)
+		reader.Close()
+		close(c.done)
+	}(capture)
+
+	os.Stdout = writer
+	color.Output = writer
+	return nil
+}
+
+// finishClipboard 恢复标准输出，并将捕获的输出去掉颜色后复制到剪贴板
+func finishClipboard() error {
+	if capture == nil {
+		return nil
+	}
+	c := capture
+	capture = nil
+
+	c.writer.Close()
+	<-c.done
+	os.Stdout = c.stdout
+	color.Output = c.colorOutput
+
+	text := clipboard.StripANSI(c.buf.String())
+	if text == "" {
+		return nil
+	}
+	if err := clipboard.Copy(text); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("已复制输出到剪贴板"))
+	return nil
+}
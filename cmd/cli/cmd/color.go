@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"toolbox/pkg/termcolor"
+
+	"github.com/spf13/cobra"
+)
+
+// addColorFlag 注册全局 --no-color 标志
+func addColorFlag(root *cobra.Command) {
+	root.PersistentFlags().Bool("no-color", false, "禁用彩色输出（也可设置NO_COLOR环境变量）")
+}
+
+// setupColor 根据环境变量和 --no-color 标志设置是否输出颜色
+func setupColor(cmd *cobra.Command) {
+	noColor, _ := cmd.Root().PersistentFlags().GetBool("no-color")
+	termcolor.SetEnabled(!noColor && termcolor.FromEnv())
+}
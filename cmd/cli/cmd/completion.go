@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCmd 生成shell自动补全脚本
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "生成shell自动补全脚本",
+	Long: `为指定的shell生成自动补全脚本。
+
+除命令和参数名称外，部分参数支持动态补全：
+  - network sniff 补全可用的网络接口名称
+  - process info/kill/children/tree 补全进程PID（附带进程名称）
+  - fs compress --type、fs split --format 补全支持的压缩格式
+
+Bash:
+  # 当前会话生效
+  source <(%[1]s completion bash)
+
+  # 永久生效（Linux）
+  %[1]s completion bash > /etc/bash_completion.d/%[1]s
+
+  # 永久生效（macOS，需要安装bash-completion）
+  %[1]s completion bash > $(brew --prefix)/etc/bash_completion.d/%[1]s
+
+Zsh:
+  # 如果尚未启用补全，需要先在 ~/.zshrc 中加入
+  autoload -U compinit; compinit
+
+  %[1]s completion zsh > "${fpath[1]}/_%[1]s"
+
+Fish:
+  %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+
+PowerShell:
+  %[1]s completion powershell | Out-String | Invoke-Expression
+
+  # 永久生效，将上面的命令加入PowerShell配置文件（$PROFILE）`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := cmd.Root()
+		switch args[0] {
+		case "bash":
+			return root.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return root.GenZshCompletion(os.Stdout)
+		case "fish":
+			return root.GenFishCompletion(os.Stdout, true)
+		default:
+			return root.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
@@ -0,0 +1,73 @@
+package conv
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/convert"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+// baseCmd 表示 conv base 命令
+var baseCmd = &cobra.Command{
+	Use:   "base <整数...>",
+	Short: "在十进制、十六进制、八进制和二进制之间转换",
+	Long: `将整数转换为十进制、十六进制、八进制和二进制表示，数值为可打印字符的码点时同时显示该字符。
+
+默认按前缀识别进制：0x 为十六进制，0o 或 0 开头为八进制，0b 为二进制，其余为十进制；
+使用 --from 指定进制时可以省略前缀。数字中可以用 _ 分隔，支持任意大小的整数和负数，
+负数需要放在 -- 之后，以免被当作选项。
+
+示例:
+  %[1]s conv base 255 0xff 0755 0b1010
+  %[1]s conv base ff 7fffffff --from 16
+  %[1]s conv base 0x4e2d --output json
+  %[1]s conv base -- -42`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetInt("from")
+		if from != 0 && (from < 2 || from > 36) {
+			return errs.InvalidInput("无效的进制: %d，应为2到36之间的整数", from)
+		}
+
+		results := make([]convert.BaseResult, 0, len(args))
+		for _, arg := range args {
+			n, err := convert.ParseInteger(arg, from)
+			if err != nil {
+				return err
+			}
+			results = append(results, convert.FormatBases(arg, n))
+		}
+
+		return output.Render(cmd, results, func() {
+			table := output.NewTable(os.Stdout, []string{i18n.T("输入"), i18n.T("十进制"), i18n.T("十六进制"), i18n.T("八进制"), i18n.T("二进制"), i18n.T("位数"), i18n.T("字符")})
+			for _, r := range results {
+				table.Append([]string{r.Input, r.Decimal, r.Hex, r.Octal, groupBinary(r.Binary), strconv.Itoa(r.Bits), r.Char})
+			}
+			table.Render()
+		})
+	},
+}
+
+// groupBinary 将二进制数字每4位以 _ 分隔，便于阅读，分隔后的写法仍可作为输入
+func groupBinary(binary string) string {
+	prefix := binary[:strings.Index(binary, "b")+1]
+	digits := binary[len(prefix):]
+	var b strings.Builder
+	b.WriteString(prefix)
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%4 == 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func init() {
+	baseCmd.Flags().Int("from", 0, "输入的进制（2-36），0 表示按前缀识别")
+}
@@ -0,0 +1,169 @@
+package conv
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/convert"
+	"toolbox/pkg/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+// ConvValue 换算到某个单位的结果
+type ConvValue struct {
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+	Text  string  `json:"text"` // 去掉浮点误差后的数值
+}
+
+// ConvResult 单位换算的结果
+type ConvResult struct {
+	Input    string           `json:"input"`
+	Value    float64          `json:"value"`
+	Unit     string           `json:"unit"`
+	Category convert.Category `json:"category"`
+	Results  []ConvValue      `json:"results"`
+}
+
+// ConvCmd 表示单位和进制换算命令组，直接带参数时换算单位
+var ConvCmd = &cobra.Command{
+	Use:   "conv <数值> [to <单位>]",
+	Short: "换算单位、进制和位标志",
+	Long: `换算数据大小、时长和传输速率的单位，转换整数的进制，并分解文件权限、TCP标志等位标志。
+
+直接带参数时换算单位，写法为 "<数值><单位> to <单位>"，to 也可以写成 in，或省略；
+多个目标单位以逗号分隔，不指定目标单位时列出同类的全部单位。
+KB、MB、GB 按1000进制，KiB、MiB、GiB 按1024进制，单独的 K、M、G 与其他命令的大小参数一致按1024进制；
+Mb、Mbit 为兆比特，MB 为兆字节。
+
+包含以下子命令:
+  base - 在十进制、十六进制、八进制和二进制之间转换
+  flags - 分解或组合位标志
+  units - 列出支持的单位
+
+示例:
+  %[1]s conv 1.5GiB to MB
+  %[1]s conv 100Mbps in MB/s
+  %[1]s conv 90min to h,s
+  %[1]s conv 4096 KiB
+  %[1]s conv base 0xff 0755 0b1010
+  %[1]s conv flags 0755 -t mode`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+
+		input := strings.Join(args, " ")
+		quantityText, targets := splitQuery(input)
+		quantity, err := convert.ParseQuantity(quantityText)
+		if err != nil {
+			return err
+		}
+
+		var units []convert.Unit
+		if targets == "" {
+			units = convert.Units(quantity.Unit.Category)
+		} else {
+			for _, name := range strings.Split(targets, ",") {
+				unit, err := convert.LookupUnit(strings.TrimSpace(name))
+				if err != nil {
+					return err
+				}
+				units = append(units, unit)
+			}
+		}
+
+		result := ConvResult{
+			Input:    input,
+			Value:    quantity.Value,
+			Unit:     quantity.Unit.Name,
+			Category: quantity.Unit.Category,
+			Results:  make([]ConvValue, 0, len(units)),
+		}
+		for _, unit := range units {
+			value, err := convert.Convert(quantity, unit)
+			if err != nil {
+				return err
+			}
+			result.Results = append(result.Results, ConvValue{
+				Unit:  unit.Name,
+				Value: value,
+				Text:  convert.FormatNumber(value),
+			})
+		}
+
+		return output.Render(cmd, result, func() {
+			source := convert.FormatNumber(result.Value) + " " + result.Unit
+			if targets != "" {
+				for _, r := range result.Results {
+					fmt.Printf("%s = %s %s\n", source, r.Text, r.Unit)
+				}
+				return
+			}
+			fmt.Printf("%s =\n", source)
+			table := output.NewTable(os.Stdout, []string{i18n.T("单位"), i18n.T("数值")})
+			for _, r := range result.Results {
+				table.Append([]string{r.Unit, r.Text})
+			}
+			table.Render()
+		})
+	},
+}
+
+// splitQuery 将 "1.5GiB to MB" 拆分为数值和目标单位，省略 to 时最后一个参数若是单位则作为目标
+func splitQuery(input string) (string, string) {
+	fields := strings.Fields(input)
+	for i, field := range fields {
+		if i > 0 && (strings.EqualFold(field, "to") || strings.EqualFold(field, "in") || field == "->") {
+			return strings.Join(fields[:i], " "), strings.Join(fields[i+1:], "")
+		}
+	}
+	if len(fields) > 1 {
+		last := fields[len(fields)-1]
+		if _, err := convert.ParseQuantity(strings.Join(fields[:len(fields)-1], " ")); err == nil {
+			return strings.Join(fields[:len(fields)-1], " "), last
+		}
+	}
+	return input, ""
+}
+
+// unitsCmd 表示 conv units 命令
+var unitsCmd = &cobra.Command{
+	Use:   "units",
+	Short: "列出支持的单位",
+	Long: `列出单位换算支持的全部单位及其换算系数，同类单位之间才能换算。
+数据大小以字节为基准，时长以秒为基准，传输速率以字节/秒为基准。`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		categories := []convert.Category{convert.CategorySize, convert.CategoryDuration, convert.CategoryRate}
+		var units []convert.Unit
+		for _, category := range categories {
+			units = append(units, convert.Units(category)...)
+		}
+
+		return output.Render(cmd, units, func() {
+			table := output.NewTable(os.Stdout, []string{i18n.T("单位"), i18n.T("类别"), i18n.T("换算系数"), i18n.T("其他写法")})
+			for _, u := range units {
+				table.Append([]string{u.Name, i18n.T(categoryNames[u.Category]), convert.FormatNumber(u.Factor), strings.Join(u.Aliases, ", ")})
+			}
+			table.Render()
+		})
+	},
+}
+
+// categoryNames 单位类别的中文名称
+var categoryNames = map[convert.Category]string{
+	convert.CategorySize:     "数据大小",
+	convert.CategoryDuration: "时长",
+	convert.CategoryRate:     "传输速率",
+}
+
+func init() {
+	// 添加子命令
+	ConvCmd.AddCommand(baseCmd)
+	ConvCmd.AddCommand(flagsCmd)
+	ConvCmd.AddCommand(unitsCmd)
+}
@@ -0,0 +1,100 @@
+package conv
+
+import (
+	"fmt"
+	"os"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/convert"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// flagsCmd 表示 conv flags 命令
+var flagsCmd = &cobra.Command{
+	Use:   "flags <值>",
+	Short: "分解或组合位标志",
+	Long: `将整数分解为已设置的标志位，或将标志名组合为整数。
+
+--type 指定标志类型:
+  mode - Unix文件权限，不带前缀的数值按八进制解析，如 755、4755，也可以写成 rwxr-xr-x
+  tcp - TCP报文头中的标志位，如 0x12，也可以写成 SYN|ACK 或 syn,ack
+  bits - 任意整数，列出已设置的位号，也可以写成以逗号分隔的位号，如 0,4,31
+
+示例:
+  %[1]s conv flags 0755 -t mode
+  %[1]s conv flags rwsr-x--- -t mode
+  %[1]s conv flags 0x12 -t tcp
+  %[1]s conv flags SYN,ACK -t tcp --all
+  %[1]s conv flags 0x80000011`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, _ := cmd.Flags().GetString("type")
+		all, _ := cmd.Flags().GetBool("all")
+
+		value, err := convert.ParseFlags(kind, args[0])
+		if err != nil {
+			return err
+		}
+		result, err := convert.DecodeFlags(kind, value)
+		if err != nil {
+			return err
+		}
+
+		return output.Render(cmd, result, func() { printFlags(result, all) })
+	},
+}
+
+// printFlags 输出组合写法和标志位表格，all 为真时同时列出未设置的标志
+func printFlags(result convert.FlagsResult, all bool) {
+	text := result.Text
+	if text == "" {
+		text = i18n.T("(无)")
+	}
+	fmt.Print(i18n.Tf("%s  十进制 %d，十六进制 %s，八进制 %s\n", text, result.Value, result.Hex, result.Octal))
+
+	flags := result.Set
+	if all {
+		for _, kind := range convert.FlagKinds() {
+			if kind.Name == result.Kind && len(kind.Flags) > 0 {
+				flags = kind.Flags
+			}
+		}
+	}
+	if len(flags) > 0 {
+		table := output.NewTable(os.Stdout, []string{i18n.T("标志"), i18n.T("值"), i18n.T("已设置"), i18n.T("说明")})
+		for _, flag := range flags {
+			set := i18n.T("否")
+			if result.Value&flag.Value != 0 {
+				set = i18n.T("是")
+			}
+			table.Append([]string{flag.Name, formatFlagValue(result.Kind, flag.Value), set, flag.Description})
+		}
+		table.Render()
+	}
+
+	if result.Unknown != 0 {
+		color.New(color.FgYellow).Printf(i18n.T("未知的位: %s\n"), formatFlagValue(result.Kind, result.Unknown))
+	}
+}
+
+// formatFlagValue 按标志类型习惯的进制显示数值，权限用八进制，其他用十六进制
+func formatFlagValue(kind string, value uint64) string {
+	if kind == "mode" {
+		return fmt.Sprintf("0%o", value)
+	}
+	return fmt.Sprintf("0x%x", value)
+}
+
+func init() {
+	flagsCmd.Flags().StringP("type", "t", "bits", "标志类型: mode、tcp、bits")
+	flagsCmd.Flags().BoolP("all", "a", false, "列出该类型的全部标志位，而不只是已设置的")
+	flagsCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for _, kind := range convert.FlagKinds() {
+			names = append(names, kind.Name+"\t"+kind.Description)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,24 @@
+package cron
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// CronCmd 表示cron表达式命令组
+var CronCmd = &cobra.Command{
+	Use:   "cron",
+	Short: "解析cron表达式",
+	Long: `解析标准的5字段cron表达式（分钟 小时 日 月 星期），用于在写入crontab或定时任务配置前确认执行时间。
+
+包含以下子命令:
+  explain - 说明cron表达式并列出接下来的执行时间
+
+示例:
+  %[1]s cron explain "*/15 2 * * 1-5"
+  %[1]s cron explain "0 9 1 * *" -n 10 --tz Asia/Shanghai`,
+}
+
+func init() {
+	// 添加子命令
+	CronCmd.AddCommand(explainCmd)
+}
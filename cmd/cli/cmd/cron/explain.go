@@ -0,0 +1,127 @@
+package cron
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/cronexpr"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/timeconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// CronResult cron表达式的说明和执行时间
+type CronResult struct {
+	Expression  string      `json:"expression"`
+	Description string      `json:"description"`
+	Timezone    string      `json:"timezone"`
+	Next        []time.Time `json:"next"`
+}
+
+// weekdays 星期的中文名称
+var weekdays = []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+// explainCmd 表示 cron explain 命令
+var explainCmd = &cobra.Command{
+	Use:   "explain <表达式>",
+	Short: "说明cron表达式并列出接下来的执行时间",
+	Long: `校验cron表达式，输出中文说明和接下来的执行时间；表达式无效时指出出错的字段并给出修改提示。
+
+字段依次为分钟（0-59）、小时（0-23）、日（1-31）、月（1-12 或 JAN-DEC）、星期（0-7 或 SUN-SAT，0和7都是周日），
+支持 *、列表 1,3、范围 1-5、步长 */15 和 1-30/5，以及 @hourly、@daily、@weekly、@monthly、@yearly。
+与Vixie cron一致，日和星期都不是 * 时，满足其中之一即执行。表达式也可以拆成多个参数传入。
+
+示例:
+  %[1]s cron explain "*/15 2 * * 1-5"
+  %[1]s cron explain "0 9 1,15 * *" -n 10
+  %[1]s cron explain @weekly --tz America/New_York
+  %[1]s cron explain "30 8 * * MON-FRI" --from "2025-01-01 00:00:00" --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		count, _ := cmd.Flags().GetInt("count")
+		tz, _ := cmd.Flags().GetString("tz")
+		from, _ := cmd.Flags().GetString("from")
+		if count < 0 {
+			return errs.InvalidInput("--count 不能为负数")
+		}
+
+		loc, err := timeconv.LoadLocation(tz)
+		if err != nil {
+			return err
+		}
+		start := time.Now().In(loc)
+		if from != "" {
+			if start, _, err = timeconv.Parse(from, timeconv.UnitAuto, loc); err != nil {
+				return err
+			}
+		}
+
+		schedule, err := cronexpr.Parse(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		result := CronResult{
+			Expression:  schedule.Expr,
+			Description: schedule.Describe(),
+			Timezone:    loc.String(),
+			Next:        schedule.NextN(start, count),
+		}
+
+		return output.Render(cmd, result, func() {
+			color.New(color.Bold).Println(result.Description)
+			if count == 0 {
+				return
+			}
+			if len(result.Next) == 0 {
+				color.New(color.FgYellow).Println(i18n.T("该表达式永远不会执行，检查日期和月份是否存在（如2月30日）"))
+				return
+			}
+			fmt.Print(i18n.Tf("接下来的执行时间（%s）:\n", result.Timezone))
+			table := output.NewTable(os.Stdout, []string{"#", i18n.T("时间"), i18n.T("星期"), i18n.T("距现在")})
+			for i, t := range result.Next {
+				table.Append([]string{
+					strconv.Itoa(i + 1),
+					t.Format("2006-01-02 15:04 -07:00"),
+					i18n.T(weekdays[t.Weekday()]),
+					formatUntil(time.Until(t)),
+				})
+			}
+			table.Render()
+		})
+	},
+}
+
+// formatUntil 格式化距离执行的时间，已过去的时间显示为“前”
+func formatUntil(d time.Duration) string {
+	suffix := i18n.T("后")
+	if d < 0 {
+		d, suffix = -d, i18n.T("前")
+	}
+	d = d.Round(time.Minute)
+
+	days := int(d.Hours() / 24)
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return i18n.Tf("%d天%d小时%s", days, hours, suffix)
+	case hours > 0:
+		return i18n.Tf("%d小时%d分钟%s", hours, minutes, suffix)
+	case minutes > 0:
+		return i18n.Tf("%d分钟%s", minutes, suffix)
+	}
+	return i18n.T("不到1分钟") + suffix
+}
+
+func init() {
+	explainCmd.Flags().IntP("count", "n", 5, "列出的执行次数")
+	explainCmd.Flags().String("tz", "local", "计算执行时间使用的时区，如 UTC、Asia/Shanghai、+08:00")
+	explainCmd.Flags().String("from", "", "从该时间之后开始计算，默认为当前时间")
+}
@@ -0,0 +1,30 @@
+package crypt
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// CryptCmd 表示文件加密命令组
+var CryptCmd = &cobra.Command{
+	Use:   "crypt",
+	Short: "使用口令加密和解密文件",
+	Long: `使用口令加密和解密文件。密钥由口令经 scrypt（默认）或 argon2id 派生，
+数据按块使用 AES-256-GCM 流式加密，大文件不会整体读入内存，
+块被篡改、调换或文件被截断时解密会失败。
+
+可以与 fs split 配合，对分片逐个加密后传输，在对端解密并合并。
+
+包含以下子命令:
+  encrypt - 加密文件
+  decrypt - 解密文件
+
+示例:
+  %[1]s crypt encrypt backup.tar.gz
+  %[1]s crypt decrypt backup.tar.gz.enc`,
+}
+
+func init() {
+	// 添加子命令
+	CryptCmd.AddCommand(encryptCmd)
+	CryptCmd.AddCommand(decryptCmd)
+}
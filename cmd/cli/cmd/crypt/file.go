@@ -0,0 +1,323 @@
+package crypt
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/crypt"
+	"toolbox/pkg/digest"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// encryptedExt 加密文件默认的扩展名
+const encryptedExt = ".enc"
+
+// progressThreshold 超过该大小的文件在终端上显示进度
+const progressThreshold = 16 * 1024 * 1024
+
+// CryptResult 单个文件的处理结果
+type CryptResult struct {
+	Source     string `json:"source"`      // 输入文件，标准输入为 "-"
+	Target     string `json:"target"`      // 输出文件，标准输出为 "-"
+	Bytes      int64  `json:"bytes"`       // 明文字节数
+	DurationMs int64  `json:"duration_ms"` // 耗时（毫秒）
+}
+
+// encryptCmd 表示 crypt encrypt 命令
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt [文件...]",
+	Short: "加密文件",
+	Long: `使用口令加密文件，默认输出为原文件名加 .enc。未指定文件时从标准输入读取并写到标准输出。
+
+口令的写法与 hash hmac 的密钥相同：@文件路径、env:变量名 或原文；
+未指定 --passphrase 时在终端中提示输入（不回显）。
+
+示例:
+  %[1]s crypt encrypt backup.tar.gz
+  %[1]s crypt encrypt mydir_chunks/* -p env:BACKUP_PASS --remove
+  %[1]s crypt encrypt secret.txt -o secret.bin --kdf argon2id
+  tar czf - ./data | %[1]s crypt encrypt -p @pass.txt > data.tgz.enc`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kdfName, _ := cmd.Flags().GetString("kdf")
+		kdf, err := crypt.ParseKDF(kdfName)
+		if err != nil {
+			return err
+		}
+		return processFiles(cmd, args, true, func(dst io.Writer, src io.Reader, passphrase []byte, onProgress func(int64)) (int64, error) {
+			return crypt.Encrypt(dst, src, passphrase, crypt.Options{KDF: kdf, OnProgress: onProgress})
+		})
+	},
+}
+
+// decryptCmd 表示 crypt decrypt 命令
+var decryptCmd = &cobra.Command{
+	Use:   "decrypt [文件...]",
+	Short: "解密文件",
+	Long: `解密 crypt encrypt 加密的文件，默认输出为去掉 .enc 后的文件名（没有该扩展名时加 .dec）。
+未指定文件时从标准输入读取并写到标准输出。
+
+解密先写入临时文件，全部数据校验通过后才替换为目标文件，口令错误或文件损坏时不会留下不完整的结果；
+从标准输入解密到标准输出时，校验失败前已输出的数据应当丢弃。
+
+示例:
+  %[1]s crypt decrypt backup.tar.gz.enc
+  %[1]s crypt decrypt mydir_chunks/*.enc -p env:BACKUP_PASS --remove
+  %[1]s fs split mydir_chunks --merge -o mydir.zip
+  %[1]s crypt decrypt data.tgz.enc -p @pass.txt -o - | tar xzf -`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return processFiles(cmd, args, false, func(dst io.Writer, src io.Reader, passphrase []byte, onProgress func(int64)) (int64, error) {
+			return crypt.Decrypt(dst, src, passphrase, onProgress)
+		})
+	},
+}
+
+// transform 加密或解密的处理函数
+type transform func(dst io.Writer, src io.Reader, passphrase []byte, onProgress func(int64)) (int64, error)
+
+// processFiles 对每个输入执行加密或解密
+func processFiles(cmd *cobra.Command, args []string, encrypt bool, fn transform) error {
+	outPath, _ := cmd.Flags().GetString("out")
+	remove, _ := cmd.Flags().GetBool("remove")
+	force, _ := cmd.Flags().GetBool("force")
+
+	sources := args
+	if len(sources) == 0 {
+		if isatty.IsTerminal(os.Stdin.Fd()) {
+			return errs.InvalidInput("请指定文件或通过管道输入内容")
+		}
+		sources = []string{"-"}
+	}
+	if outPath != "" && len(sources) > 1 {
+		return errs.InvalidInput("--out 只能用于单个输入文件")
+	}
+
+	passphrase, err := readPassphrase(cmd, encrypt, sources)
+	if err != nil {
+		return err
+	}
+
+	results := make([]CryptResult, 0, len(sources))
+	toStdout := false
+	for _, source := range sources {
+		target := outPath
+		if target == "" {
+			target = defaultTarget(source, encrypt)
+		}
+		if target == "-" {
+			toStdout = true
+		} else if source != "-" && sameFile(source, target) {
+			return errs.InvalidInput("输出文件不能与输入文件相同: %s", target)
+		}
+
+		result, err := processFile(source, target, passphrase, force, fn)
+		if err != nil {
+			return errs.Wrap(err, "处理 %s 失败: %v", source, err)
+		}
+		results = append(results, result)
+
+		if remove && source != "-" {
+			if err := os.Remove(source); err != nil {
+				return errs.Wrap(err, "删除源文件失败: %v", err)
+			}
+		}
+	}
+
+	// 数据写到标准输出时不再输出汇总，避免混入结果
+	if toStdout {
+		return nil
+	}
+	return output.Render(cmd, results, func() {
+		table := output.NewTable(os.Stdout, []string{i18n.T("输入"), i18n.T("输出"), i18n.T("大小"), i18n.T("耗时")})
+		for _, r := range results {
+			table.Append([]string{r.Source, r.Target, fsutils.FormatSize(r.Bytes), units.FormatDuration(time.Duration(r.DurationMs) * time.Millisecond)})
+		}
+		table.Render()
+	})
+}
+
+// processFile 处理单个输入，写入文件时先写临时文件，成功后再重命名
+func processFile(source, target string, passphrase []byte, force bool, fn transform) (CryptResult, error) {
+	result := CryptResult{Source: source, Target: target}
+	start := time.Now()
+
+	var src io.Reader = os.Stdin
+	var size int64
+	if source != "-" {
+		file, err := os.Open(source)
+		if err != nil {
+			return result, err
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			return result, err
+		}
+		if info.IsDir() {
+			return result, errs.InvalidInput("%s 是目录", source)
+		}
+		src = file
+		size = info.Size()
+	}
+
+	onProgress := progressPrinter(source, size)
+	if target == "-" {
+		n, err := fn(os.Stdout, src, passphrase, onProgress)
+		finishProgress(onProgress)
+		result.Bytes = n
+		return result, err
+	}
+
+	if !force {
+		if _, err := os.Stat(target); err == nil {
+			return result, errs.InvalidInput("输出文件已存在: %s（使用 --force 覆盖）", target)
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*.tmp")
+	if err != nil {
+		return result, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := fn(tmp, src, passphrase, onProgress)
+	finishProgress(onProgress)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return result, err
+	}
+	if source != "-" {
+		if info, statErr := os.Stat(source); statErr == nil {
+			os.Chmod(tmp.Name(), info.Mode().Perm())
+		}
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return result, err
+	}
+
+	result.Bytes = n
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// readPassphrase 读取口令：优先使用 --passphrase，否则在终端中提示输入
+func readPassphrase(cmd *cobra.Command, confirm bool, sources []string) ([]byte, error) {
+	if cmd.Flags().Changed("passphrase") {
+		spec, _ := cmd.Flags().GetString("passphrase")
+		passphrase, err := digest.ParseKey(spec)
+		if err != nil {
+			return nil, err
+		}
+		if len(passphrase) == 0 {
+			return nil, errs.InvalidInput("口令不能为空")
+		}
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	for _, source := range sources {
+		if source == "-" {
+			fd = -1
+		}
+	}
+	if fd < 0 || !term.IsTerminal(fd) {
+		return nil, errs.InvalidInput("非交互模式下必须使用 --passphrase 指定口令")
+	}
+
+	fmt.Fprint(os.Stderr, i18n.T("请输入口令: "))
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取口令失败: %v", err)
+	}
+	if len(passphrase) == 0 {
+		return nil, errs.InvalidInput("口令不能为空")
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, i18n.T("请再次输入口令: "))
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, errs.Wrap(err, "读取口令失败: %v", err)
+		}
+		if string(again) != string(passphrase) {
+			return nil, errs.InvalidInput("两次输入的口令不一致")
+		}
+	}
+	return passphrase, nil
+}
+
+// defaultTarget 返回默认的输出路径
+func defaultTarget(source string, encrypt bool) string {
+	if source == "-" {
+		return "-"
+	}
+	if encrypt {
+		return source + encryptedExt
+	}
+	if strings.HasSuffix(source, encryptedExt) && len(source) > len(encryptedExt) {
+		return strings.TrimSuffix(source, encryptedExt)
+	}
+	return source + ".dec"
+}
+
+// sameFile 判断两个路径是否指向同一个已存在的文件
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(infoA, infoB)
+}
+
+// progressPrinter 为大文件返回在标准错误上显示进度的回调，其他情况返回nil
+func progressPrinter(source string, size int64) func(int64) {
+	if size < progressThreshold || !isatty.IsTerminal(os.Stderr.Fd()) {
+		return nil
+	}
+	last := time.Time{}
+	return func(done int64) {
+		if time.Since(last) < 200*time.Millisecond && done < size {
+			return
+		}
+		last = time.Now()
+		fmt.Fprintf(os.Stderr, "\r\033[K%s: %.1f%% (%s / %s)", source, float64(done)*100/float64(size),
+			fsutils.FormatSize(done), fsutils.FormatSize(size))
+	}
+}
+
+// finishProgress 清除进度行
+func finishProgress(onProgress func(int64)) {
+	if onProgress != nil {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{encryptCmd, decryptCmd} {
+		c.Flags().StringP("passphrase", "p", "", "口令，支持 @文件路径、env:变量名 或原文")
+		c.Flags().StringP("out", "o", "", "输出文件，- 表示标准输出（仅单个输入时可用）")
+		c.Flags().Bool("remove", false, "成功后删除源文件")
+		c.Flags().BoolP("force", "f", false, "覆盖已存在的输出文件")
+	}
+	encryptCmd.Flags().String("kdf", string(crypt.KDFScrypt), "密钥派生算法: scrypt 或 argon2id")
+	encryptCmd.RegisterFlagCompletionFunc("kdf", cobra.FixedCompletions(
+		[]string{string(crypt.KDFScrypt), string(crypt.KDFArgon2id)},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
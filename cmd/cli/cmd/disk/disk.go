@@ -0,0 +1,24 @@
+package disk
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// DiskCmd 表示磁盘检查命令组
+var DiskCmd = &cobra.Command{
+	Use:   "disk",
+	Short: "检查磁盘健康状态",
+	Long: `检查本机磁盘的健康状态。
+
+包含以下子命令:
+  smart - 显示磁盘的SMART健康信息
+
+示例:
+  %[1]s disk smart
+  %[1]s disk smart /dev/sda --attributes`,
+}
+
+func init() {
+	// 添加子命令
+	DiskCmd.AddCommand(smartCmd)
+}
@@ -0,0 +1,191 @@
+package disk
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/smart"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// smartCmd 表示 disk smart 命令
+var smartCmd = &cobra.Command{
+	Use:   "smart [设备...]",
+	Short: "显示磁盘的SMART健康信息",
+	Long: `列出磁盘的型号、容量、SMART整体评估、温度和通电时间，并对以下情况给出警告:
+  - SMART整体评估未通过，或有属性曾低于阈值
+  - 存在已重映射、待重映射或无法修复的扇区
+  - NVMe严重警告、备用空间不足、寿命将尽或介质错误
+  - 温度达到 --temp-warn（默认55°C）
+
+不指定设备时检查全部磁盘。完整信息需要安装 smartmontools 7.0 及以上版本的 smartctl，
+并且通常需要root或管理员权限；未安装时在Linux上只能从 /sys/block 读取型号、容量和部分温度。
+任一磁盘有警告或读取失败时以退出码1退出，便于在脚本和定时任务中使用。
+
+示例:
+  sudo %[1]s disk smart
+  sudo %[1]s disk smart /dev/sda --attributes
+  sudo %[1]s disk smart /dev/sdb -d sat --temp-warn 50
+  sudo %[1]s disk smart --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deviceType, _ := cmd.Flags().GetString("device-type")
+		tempWarn, _ := cmd.Flags().GetInt("temp-warn")
+		showAttributes, _ := cmd.Flags().GetBool("attributes")
+
+		var devices []smart.Device
+		if len(args) > 0 {
+			for _, arg := range args {
+				devices = append(devices, smart.Device{Name: arg})
+			}
+		} else {
+			scanned, err := smart.Scan()
+			if err != nil {
+				return err
+			}
+			if len(scanned) == 0 {
+				return errs.NotFound("没有找到可检查的磁盘")
+			}
+			devices = scanned
+		}
+
+		drives := make([]smart.Drive, 0, len(devices))
+		healthy := true
+		for _, device := range devices {
+			drive := smart.Check(device, smart.Options{DeviceType: deviceType, TempWarn: tempWarn})
+			drives = append(drives, drive)
+			healthy = healthy && drive.Healthy()
+		}
+
+		if err := output.Render(cmd, drives, func() { printDrives(drives, showAttributes) }); err != nil {
+			return err
+		}
+		if !healthy {
+			return errs.Exit(1)
+		}
+		return nil
+	},
+}
+
+// printDrives 输出磁盘概况表格、警告和SMART属性
+func printDrives(drives []smart.Drive, showAttributes bool) {
+	table := output.NewTable(os.Stdout, []string{i18n.T("设备"), i18n.T("类型"), i18n.T("型号"), i18n.T("容量"), i18n.T("健康"), i18n.T("温度"), i18n.T("通电时间")})
+	for _, d := range drives {
+		health := i18n.T("未知")
+		switch {
+		case d.Error != "":
+			health = i18n.T("读取失败")
+		case d.Passed != nil && *d.Passed:
+			health = i18n.T("通过")
+		case d.Passed != nil:
+			health = i18n.T("未通过")
+		}
+		table.Append([]string{
+			d.Device,
+			d.Type,
+			d.Model,
+			formatCapacity(d.Capacity),
+			health,
+			formatTemperature(d.Temperature),
+			formatPowerOn(d.PowerOnHours),
+		})
+	}
+	table.Render()
+
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+	for _, d := range drives {
+		if d.Error != "" {
+			red.Printf("%s: %s\n", d.Device, d.Error)
+		}
+		for _, warning := range d.Warnings {
+			yellow.Printf("%s: %s\n", d.Device, warning)
+		}
+	}
+	for _, d := range drives {
+		if d.Source == smart.SourceSysfs && d.Error == "" {
+			color.New(color.Faint).Println(i18n.T("未找到smartctl，只显示了从 /sys/block 读取的信息，安装 smartmontools 后可查看完整的SMART信息"))
+			break
+		}
+	}
+
+	if !showAttributes {
+		return
+	}
+	for _, d := range drives {
+		if len(d.Attributes) == 0 && d.NVMe == nil {
+			continue
+		}
+		fmt.Println()
+		color.New(color.Bold).Println(d.Device)
+		if len(d.Attributes) > 0 {
+			attrs := output.NewTable(os.Stdout, []string{"ID", i18n.T("属性"), i18n.T("当前值"), i18n.T("最差值"), i18n.T("阈值"), i18n.T("原始值")})
+			for _, a := range d.Attributes {
+				name := a.Name
+				if a.Failing {
+					name += " (!)"
+				}
+				attrs.Append([]string{
+					strconv.Itoa(a.ID),
+					name,
+					strconv.Itoa(a.Value),
+					strconv.Itoa(a.Worst),
+					strconv.Itoa(a.Threshold),
+					a.RawString,
+				})
+			}
+			attrs.Render()
+		}
+		if n := d.NVMe; n != nil {
+			fmt.Print(i18n.Tf("  严重警告: 0x%02x\n", n.CriticalWarning))
+			fmt.Print(i18n.Tf("  备用空间: %d%%（阈值 %d%%）\n", n.AvailableSpare, n.AvailableSpareThreshold))
+			fmt.Print(i18n.Tf("  已用寿命: %d%%\n", n.PercentageUsed))
+			fmt.Print(i18n.Tf("  介质错误: %d\n", n.MediaErrors))
+			fmt.Print(i18n.Tf("  异常断电: %d\n", n.UnsafeShutdowns))
+		}
+	}
+}
+
+// formatCapacity 按磁盘厂商的习惯以1000进制显示容量
+func formatCapacity(bytes uint64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	value, units := float64(bytes), []string{"B", "KB", "MB", "GB", "TB", "PB"}
+	i := 0
+	for value >= 1000 && i < len(units)-1 {
+		value /= 1000
+		i++
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + " " + units[i]
+}
+
+// formatTemperature 格式化温度，0 表示未知
+func formatTemperature(celsius int) string {
+	if celsius == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d°C", celsius)
+}
+
+// formatPowerOn 格式化通电时间，超过一天时同时显示天数
+func formatPowerOn(hours int64) string {
+	if hours < 0 {
+		return "-"
+	}
+	if hours < 24 {
+		return i18n.Tf("%d小时", hours)
+	}
+	return i18n.Tf("%d小时（%d天）", hours, hours/24)
+}
+
+func init() {
+	smartCmd.Flags().StringP("device-type", "d", "", "传给 smartctl -d 的设备类型，如 sat、nvme、megaraid,0")
+	smartCmd.Flags().Int("temp-warn", 55, "温度达到该值（摄氏度）时给出警告，0 表示不检查")
+	smartCmd.Flags().BoolP("attributes", "a", false, "显示每块磁盘的SMART属性")
+}
@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"toolbox/pkg/docker"
+
+	"github.com/spf13/cobra"
+)
+
+// DockerCmd 表示Docker容器命令组
+var DockerCmd = &cobra.Command{
+	Use:   "docker",
+	Short: "查看Docker容器及其在宿主机上的进程",
+	Long: `通过Docker Engine API查看本机的容器，不需要安装docker命令行；也兼容Podman的Docker兼容socket。
+容器的主进程和容器内进程显示为宿主机上的PID，可以直接用于 process info/kill/tree 等命令；
+反过来，process info 会显示进程所在的容器。
+
+默认连接 DOCKER_HOST 指定的地址，未设置时依次尝试 /var/run/docker.sock、rootless Docker 和 Podman 的socket，
+Windows 上使用 npipe:////./pipe/docker_engine。Docker Desktop 在虚拟机中运行容器，其PID无法与宿主机进程对应。
+
+包含以下子命令:
+  ps    - 列出容器及主进程PID，可以查找进程所在的容器
+  stats - 显示容器的CPU、内存、网络和磁盘IO使用情况
+  logs  - 输出容器日志
+
+示例:
+  %[1]s docker ps
+  %[1]s docker ps --pid 4321
+  %[1]s docker stats --sort memory
+  %[1]s docker logs web -f -n 100`,
+}
+
+// newClient 根据 --docker-host 创建客户端
+func newClient(cmd *cobra.Command) (*docker.Client, error) {
+	host, _ := cmd.Flags().GetString("docker-host")
+	return docker.NewClient(host)
+}
+
+func init() {
+	// 添加子命令
+	DockerCmd.AddCommand(psCmd)
+	DockerCmd.AddCommand(statsCmd)
+	DockerCmd.AddCommand(logsCmd)
+
+	DockerCmd.PersistentFlags().String("docker-host", "", "Docker守护进程地址，如 unix:///var/run/docker.sock、tcp://10.0.0.5:2375，默认使用 DOCKER_HOST")
+}
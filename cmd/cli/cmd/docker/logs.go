@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/docker"
+
+	"github.com/spf13/cobra"
+)
+
+// logsCmd 表示 docker logs 命令
+var logsCmd = &cobra.Command{
+	Use:   "logs <容器>",
+	Short: "输出容器日志",
+	Long: `输出容器的标准输出和标准错误，分别写入本命令的标准输出和标准错误，可以用管道进一步过滤。
+容器可以是名称、完整ID或ID前缀。--follow 时持续输出新的日志，直到容器退出或按 Ctrl+C。
+
+示例:
+  %[1]s docker logs web
+  %[1]s docker logs web -f -n 100
+  %[1]s docker logs 3f2a --since 30m --timestamps
+  %[1]s docker logs api 2>&1 | %[1]s text grep ERROR`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		follow, _ := cmd.Flags().GetBool("follow")
+		tail, _ := cmd.Flags().GetInt("tail")
+		timestamps, _ := cmd.Flags().GetBool("timestamps")
+		since := flagtype.GetDuration(cmd.Flags(), "since")
+
+		client, err := newClient(cmd)
+		if err != nil {
+			return err
+		}
+		options := docker.LogOptions{Follow: follow, Tail: tail, Timestamps: timestamps}
+		if since > 0 {
+			options.Since = time.Now().Add(-since)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return client.Logs(ctx, args[0], options, os.Stdout, os.Stderr)
+	},
+}
+
+func init() {
+	logsCmd.Flags().BoolP("follow", "f", false, "持续输出新的日志")
+	logsCmd.Flags().IntP("tail", "n", -1, "只输出最后几行，-1 表示全部")
+	logsCmd.Flags().BoolP("timestamps", "t", false, "每行前显示时间戳")
+	flagtype.Duration(logsCmd.Flags(), "since", 0, time.Minute, "只输出该时长之内的日志，如 10m、2h，纯数字表示分钟")
+}
@@ -0,0 +1,217 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/docker"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/process"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// ContainerEntry 容器及其在宿主机上的进程
+type ContainerEntry struct {
+	docker.Container
+	ProcessName string             `json:"process_name,omitempty"` // 主进程在宿主机上的进程名
+	Processes   []ContainerProcess `json:"processes,omitempty"`
+}
+
+// ContainerProcess 容器内的一个进程，PID为宿主机上的PID
+type ContainerProcess struct {
+	PID     int32   `json:"pid"`
+	PPID    int32   `json:"ppid"`
+	Name    string  `json:"name"`
+	CPU     float64 `json:"cpu"`
+	RSS     uint64  `json:"rss"`
+	Command string  `json:"command"`
+}
+
+// psCmd 表示 docker ps 命令
+var psCmd = &cobra.Command{
+	Use:   "ps",
+	Short: "列出容器及主进程PID，可以查找进程所在的容器",
+	Long: `列出运行中的容器（--all 包括已停止的），PID 列为容器主进程在宿主机上的PID，进程列为宿主机上看到的进程名。
+--processes 同时列出每个容器内的所有进程及其CPU和内存使用情况；
+--pid 查找宿主机上的某个进程属于哪个容器（例如 process list 中发现某个进程占用过高时），并列出该容器内的进程。
+
+示例:
+  %[1]s docker ps
+  %[1]s docker ps -a --filter nginx
+  %[1]s docker ps --processes
+  %[1]s docker ps --pid 4321
+  %[1]s docker ps --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		filter, _ := cmd.Flags().GetString("filter")
+		withProcesses, _ := cmd.Flags().GetBool("processes")
+		pid, _ := cmd.Flags().GetInt32("pid")
+
+		client, err := newClient(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		var containers []docker.Container
+		if cmd.Flags().Changed("pid") {
+			container, ok, err := client.FindByPID(ctx, pid)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errs.NotFound("进程 %d 不在任何运行中的容器内", pid)
+			}
+			containers = []docker.Container{container}
+			withProcesses = true
+		} else {
+			if containers, err = client.ListContainers(ctx, all); err != nil {
+				return err
+			}
+			containers = filterContainers(containers, filter)
+		}
+
+		entries := make([]ContainerEntry, 0, len(containers))
+		for _, container := range containers {
+			entry := ContainerEntry{Container: container}
+			if container.PID > 0 {
+				if info, err := process.GetProcessByPID(container.PID); err == nil {
+					entry.ProcessName = info.Name
+				}
+			}
+			if withProcesses && container.State == "running" {
+				if entry.Processes, err = containerProcesses(ctx, client, container.ID); err != nil {
+					return err
+				}
+			}
+			entries = append(entries, entry)
+		}
+
+		return output.Render(cmd, entries, func() {
+			if len(entries) == 0 {
+				output.Infof(cmd, "没有找到容器\n")
+				return
+			}
+			printContainers(entries)
+			if withProcesses {
+				for _, entry := range entries {
+					if len(entry.Processes) > 0 {
+						fmt.Println()
+						printProcesses(entry, pid)
+					}
+				}
+			}
+		})
+	},
+}
+
+// filterContainers 只保留名称、镜像或ID包含filter的容器，不区分大小写
+func filterContainers(containers []docker.Container, filter string) []docker.Container {
+	if filter == "" {
+		return containers
+	}
+	filter = strings.ToLower(filter)
+	var filtered []docker.Container
+	for _, c := range containers {
+		if strings.Contains(strings.ToLower(c.Name), filter) ||
+			strings.Contains(strings.ToLower(c.Image), filter) ||
+			strings.HasPrefix(c.ID, filter) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// containerProcesses 获取容器内的进程，并从宿主机上读取它们的资源使用情况
+func containerProcesses(ctx context.Context, client *docker.Client, id string) ([]ContainerProcess, error) {
+	pids, err := client.Processes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	processes := make([]ContainerProcess, 0, len(pids))
+	for _, pid := range pids {
+		entry := ContainerProcess{PID: pid}
+		// 宿主机上可能看不到容器进程（如Docker Desktop的虚拟机中），只保留PID
+		if info, err := process.GetProcessByPID(pid); err == nil {
+			entry.PPID = info.PPID
+			entry.Name = info.Name
+			entry.CPU = info.CPU
+			entry.RSS = info.MemoryInfo.RSS
+			entry.Command = strings.Join(info.CmdLine, " ")
+		}
+		processes = append(processes, entry)
+	}
+	return processes, nil
+}
+
+// printContainers 以表格输出容器列表
+func printContainers(entries []ContainerEntry) {
+	table := output.NewTable(os.Stdout, []string{i18n.T("容器ID"), i18n.T("名称"), i18n.T("镜像"), i18n.T("状态"), "PID", i18n.T("进程"), i18n.T("端口")})
+	for _, entry := range entries {
+		status := entry.Status
+		switch entry.State {
+		case "running":
+			status = color.GreenString(status)
+		case "exited", "dead":
+			status = color.New(color.Faint).Sprint(status)
+		default:
+			status = color.YellowString(status)
+		}
+		pid := "-"
+		if entry.PID > 0 {
+			pid = strconv.Itoa(int(entry.PID))
+		}
+		table.Append([]string{
+			entry.ShortID(),
+			color.CyanString(entry.Name),
+			entry.Image,
+			status,
+			pid,
+			entry.ProcessName,
+			strings.Join(entry.Ports, ", "),
+		})
+	}
+	table.Render()
+}
+
+// printProcesses 输出一个容器内的进程，高亮 --pid 指定的进程
+func printProcesses(entry ContainerEntry, highlight int32) {
+	color.New(color.FgCyan, color.Bold).Printf("==> %s (%s) <==\n", entry.Name, entry.ShortID())
+	table := output.NewTable(os.Stdout, []string{"PID", "PPID", i18n.T("名称"), "CPU%", i18n.T("内存"), i18n.T("命令")})
+	for _, p := range entry.Processes {
+		pid := strconv.Itoa(int(p.PID))
+		if p.PID == highlight {
+			pid = color.New(color.FgYellow, color.Bold).Sprint(pid)
+		}
+		command := p.Command
+		if runes := []rune(command); len(runes) > 80 {
+			command = string(runes[:77]) + "..."
+		}
+		table.Append([]string{
+			pid,
+			strconv.Itoa(int(p.PPID)),
+			p.Name,
+			fmt.Sprintf("%.1f", p.CPU),
+			fsutils.FormatSize(int64(p.RSS)),
+			command,
+		})
+	}
+	table.Render()
+}
+
+func init() {
+	psCmd.Flags().BoolP("all", "a", false, "同时列出已停止的容器")
+	psCmd.Flags().StringP("filter", "f", "", "按名称、镜像或ID过滤")
+	psCmd.Flags().BoolP("processes", "p", false, "列出每个容器内的进程")
+	psCmd.Flags().Int32("pid", 0, "查找宿主机上该PID的进程所在的容器")
+}
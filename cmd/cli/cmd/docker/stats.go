@@ -0,0 +1,163 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/docker"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// StatsEntry 容器的资源使用情况和主进程PID
+type StatsEntry struct {
+	docker.Stats
+	PID int32 `json:"pid"`
+}
+
+// statsCmd 表示 docker stats 命令
+var statsCmd = &cobra.Command{
+	Use:   "stats [容器...]",
+	Short: "显示容器的CPU、内存、网络和磁盘IO使用情况",
+	Long: `显示运行中容器的资源使用情况，默认显示所有运行中的容器，也可以指定容器名称或ID前缀。
+CPU%% 相对于单个CPU，多核时可能超过100%%；内存不包括可回收的页缓存，与 docker stats 一致。
+PID 为容器主进程在宿主机上的PID。采样CPU使用率需要约1秒，持续刷新可以配合 watch 命令使用。
+
+示例:
+  %[1]s docker stats
+  %[1]s docker stats web db --sort memory
+  %[1]s watch -n 5 -- docker stats --sort cpu`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sortBy, _ := cmd.Flags().GetString("sort")
+		switch sortBy {
+		case "name", "cpu", "memory":
+		default:
+			return errs.InvalidInput("无效的排序方式: %s（可选: name, cpu, memory）", sortBy)
+		}
+
+		client, err := newClient(cmd)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		containers, err := client.ListContainers(ctx, false)
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 {
+			if containers, err = selectContainers(containers, args); err != nil {
+				return err
+			}
+		}
+
+		ids := make([]string, len(containers))
+		for i, container := range containers {
+			ids[i] = container.ID
+		}
+		stats, failures := client.StatsAll(ctx, ids)
+
+		var entries []StatsEntry
+		for i, container := range containers {
+			// 容器可能在采样期间退出，跳过并在表格下方提示
+			if failures[i] != nil {
+				output.Infof(cmd, "%s: %v\n", container.Name, failures[i])
+				continue
+			}
+			entries = append(entries, StatsEntry{Stats: stats[i], PID: container.PID})
+		}
+		sortStats(entries, sortBy)
+
+		return output.Render(cmd, entries, func() {
+			if len(entries) == 0 {
+				output.Infof(cmd, "没有运行中的容器\n")
+				return
+			}
+			printStats(entries)
+		})
+	},
+}
+
+// selectContainers 按名称、完整ID或ID前缀选择容器，找不到时返回错误
+func selectContainers(containers []docker.Container, refs []string) ([]docker.Container, error) {
+	var selected []docker.Container
+	for _, ref := range refs {
+		found := false
+		for _, container := range containers {
+			if container.Name == ref || strings.HasPrefix(container.ID, ref) {
+				selected = append(selected, container)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errs.NotFound("容器 %s 不存在或未运行", ref)
+		}
+	}
+	return selected, nil
+}
+
+// sortStats 按名称升序，或按CPU、内存使用量降序排序
+func sortStats(entries []StatsEntry, sortBy string) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		switch sortBy {
+		case "cpu":
+			return entries[i].CPUPercent > entries[j].CPUPercent
+		case "memory":
+			return entries[i].MemoryUsage > entries[j].MemoryUsage
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+// printStats 以表格输出资源使用情况，CPU和内存占用较高时着色
+func printStats(entries []StatsEntry) {
+	table := output.NewTable(os.Stdout, []string{i18n.T("名称"), "PID", "CPU%", i18n.T("内存"), i18n.T("内存%"), i18n.T("网络 收/发"), i18n.T("磁盘 读/写"), i18n.T("进程数")})
+	for _, entry := range entries {
+		limit := "-"
+		if entry.MemoryLimit > 0 {
+			limit = fsutils.FormatSize(int64(entry.MemoryLimit))
+		}
+		table.Append([]string{
+			color.CyanString(entry.Name),
+			strconv.Itoa(int(entry.PID)),
+			colorPercent(entry.CPUPercent),
+			fsutils.FormatSize(int64(entry.MemoryUsage)) + " / " + limit,
+			colorPercent(entry.MemoryPercent),
+			fsutils.FormatSize(int64(entry.NetRx)) + " / " + fsutils.FormatSize(int64(entry.NetTx)),
+			fsutils.FormatSize(int64(entry.BlockRead)) + " / " + fsutils.FormatSize(int64(entry.BlockWrite)),
+			strconv.FormatUint(entry.PIDs, 10),
+		})
+	}
+	table.Render()
+}
+
+// colorPercent 格式化百分比，超过80%显示为红色，超过50%显示为黄色
+func colorPercent(percent float64) string {
+	text := fmt.Sprintf("%.1f%%", percent)
+	switch {
+	case percent >= 80:
+		return color.RedString(text)
+	case percent >= 50:
+		return color.YellowString(text)
+	}
+	return text
+}
+
+func init() {
+	statsCmd.Flags().StringP("sort", "s", "name", "排序方式（name, cpu, memory）")
+	statsCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(
+		[]string{"name", "cpu", "memory"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
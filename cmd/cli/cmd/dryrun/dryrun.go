@@ -0,0 +1,103 @@
+// Package dryrun 为会修改、删除文件，终止进程或启停服务的命令提供统一的 --dry-run 预演模式
+//
+// 命令在预演模式下不执行任何修改，而是把计划执行的操作记录到 Plan 中，
+// 最后按全局输出格式统一输出，使各命令的预演结果格式一致。
+package dryrun
+
+import (
+	"fmt"
+	"os"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// FlagName 预演标志的名称
+const FlagName = "dry-run"
+
+// 操作类型
+const (
+	ActionCreate  = "create"  // 创建文件或目录
+	ActionModify  = "modify"  // 修改文件
+	ActionDelete  = "delete"  // 删除文件或目录
+	ActionKill    = "kill"    // 终止进程
+	ActionStart   = "start"   // 启动服务
+	ActionStop    = "stop"    // 停止服务
+	ActionRestart = "restart" // 重启服务
+)
+
+// actionNames 操作类型的显示名称
+var actionNames = map[string]string{
+	ActionCreate:  "创建",
+	ActionModify:  "修改",
+	ActionDelete:  "删除",
+	ActionKill:    "终止",
+	ActionStart:   "启动",
+	ActionStop:    "停止",
+	ActionRestart: "重启",
+}
+
+// AddFlag 为命令注册 --dry-run 标志
+func AddFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(FlagName, false, "只显示将要执行的操作，不做实际修改")
+}
+
+// Enabled 命令是否处于预演模式
+func Enabled(cmd *cobra.Command) bool {
+	enabled, _ := cmd.Flags().GetBool(FlagName)
+	return enabled
+}
+
+// Action 计划执行的一项操作
+type Action struct {
+	Action string `json:"action"`           // 操作类型
+	Target string `json:"target"`           // 操作对象，如文件路径或进程
+	Detail string `json:"detail,omitempty"` // 补充说明
+}
+
+// Plan 预演模式下收集的操作列表
+type Plan struct {
+	Actions []Action `json:"actions"`
+}
+
+// Add 记录一项计划执行的操作，补充说明按当前界面语言翻译
+func (p *Plan) Add(action, target, detail string) {
+	p.Actions = append(p.Actions, Action{Action: action, Target: target, Detail: i18n.T(detail)})
+}
+
+// Addf 记录一项操作，补充说明使用格式化字符串
+func (p *Plan) Addf(action, target, format string, args ...interface{}) {
+	p.Actions = append(p.Actions, Action{Action: action, Target: target, Detail: i18n.Tf(format, args...)})
+}
+
+// Render 按当前输出格式输出计划执行的操作
+func Render(cmd *cobra.Command, plan *Plan) error {
+	return output.Render(cmd, plan, func() {
+		printPlan(plan)
+	})
+}
+
+// printPlan 以文本形式输出计划执行的操作
+func printPlan(plan *Plan) {
+	color.New(color.FgYellow, color.Bold).Println(i18n.T("预演模式，以下操作不会实际执行："))
+	if len(plan.Actions) == 0 {
+		fmt.Println(i18n.T("  没有需要执行的操作"))
+		return
+	}
+
+	table := output.NewTable(os.Stdout, []string{i18n.T("操作"), i18n.T("对象"), i18n.T("说明")})
+	for _, action := range plan.Actions {
+		name := i18n.T(actionNames[action.Action])
+		if name == "" {
+			name = action.Action
+		}
+		if action.Action == ActionDelete || action.Action == ActionKill || action.Action == ActionStop {
+			name = color.RedString(name)
+		}
+		table.Append([]string{name, action.Target, action.Detail})
+	}
+	table.Render()
+	fmt.Print(i18n.Tf("\n共 %d 项操作，去掉 --dry-run 后执行\n", len(plan.Actions)))
+}
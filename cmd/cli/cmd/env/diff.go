@@ -0,0 +1,122 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/envvars"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd 表示 env diff 命令
+var diffCmd = &cobra.Command{
+	Use:   "diff <.env文件>",
+	Short: "比较当前环境与 .env 文件",
+	Long: `检查 .env 文件中的变量在当前环境中是否存在、取值是否相同，用于确认配置是否已加载、
+部署环境与本地配置是否一致。默认只列出不同和缺失的变量，--all 同时列出相同的变量，
+--extra 同时列出只存在于当前环境中的变量。
+
+.env 文件支持 # 注释、export 前缀、单引号和双引号（可跨行），不展开 ${VAR} 引用。
+存在不同或缺失的变量时退出码为 1。
+
+示例:
+  %[1]s env diff .env
+  %[1]s env diff .env.production --all
+  %[1]s env diff .env --show-secrets --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		extra, _ := cmd.Flags().GetBool("extra")
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+
+		fileVars, err := readDotenv(args[0])
+		if err != nil {
+			return err
+		}
+		entries := envvars.Diff(envvars.Current(), fileVars, extra)
+
+		counts := make(map[string]int)
+		var shown []envvars.DiffEntry
+		for _, entry := range entries {
+			counts[entry.Status]++
+			if entry.Status == envvars.DiffSame && !all {
+				continue
+			}
+			if entry.Secret && !showSecrets {
+				entry.Current = envvars.Mask(entry.Current)
+				entry.File = envvars.Mask(entry.File)
+			}
+			shown = append(shown, entry)
+		}
+
+		err = output.Render(cmd, shown, func() {
+			printDiff(shown)
+			fmt.Print(i18n.Tf("\n%d 个相同，%d 个不同，%d 个缺失", counts[envvars.DiffSame], counts[envvars.DiffChanged], counts[envvars.DiffMissing]))
+			if extra {
+				fmt.Print(i18n.Tf("，%d 个只在当前环境中", counts[envvars.DiffExtra]))
+			}
+			fmt.Println()
+		})
+		if err != nil {
+			return err
+		}
+		if counts[envvars.DiffChanged]+counts[envvars.DiffMissing]+counts[envvars.DiffExtra] > 0 {
+			return errs.Exit(errs.ExitFailure)
+		}
+		return nil
+	},
+}
+
+// readDotenv 读取并解析 .env 文件
+func readDotenv(path string) ([]envvars.Var, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取 %s 失败: %v", path, err)
+	}
+	vars, err := envvars.ParseDotenv(string(data))
+	if err != nil {
+		return nil, errs.Wrap(err, "%s: %v", path, err)
+	}
+	return vars, nil
+}
+
+// printDiff 逐行输出比较结果，不同的变量显示两边的取值
+func printDiff(entries []envvars.DiffEntry) {
+	for _, entry := range entries {
+		switch entry.Status {
+		case envvars.DiffSame:
+			fmt.Printf("%s %s=%s\n", color.New(color.Faint).Sprint("="), entry.Name, displayValue(entry.File))
+		case envvars.DiffChanged:
+			color.New(color.FgYellow).Printf("~ %s\n", entry.Name)
+			fmt.Print(i18n.Tf("    当前: %s\n", displayValue(entry.Current)))
+			fmt.Print(i18n.Tf("    文件: %s\n", displayValue(entry.File)))
+		case envvars.DiffMissing:
+			color.New(color.FgRed).Printf("- %s", entry.Name)
+			fmt.Print(i18n.Tf("  当前环境中未设置，文件中为 %s\n", displayValue(entry.File)))
+		case envvars.DiffExtra:
+			color.New(color.FgGreen).Printf("+ %s", entry.Name)
+			fmt.Print(i18n.Tf("  只在当前环境中，取值为 %s\n", displayValue(entry.Current)))
+		}
+	}
+}
+
+// displayValue 空值和含有换行等控制字符的取值加引号显示，使每个变量占一行
+func displayValue(value string) string {
+	if value == "" || strings.IndexFunc(value, unicode.IsControl) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func init() {
+	diffCmd.Flags().Bool("all", false, "同时列出取值相同的变量")
+	diffCmd.Flags().Bool("extra", false, "同时列出只存在于当前环境中的变量")
+	diffCmd.Flags().Bool("show-secrets", false, "显示敏感变量的原值")
+}
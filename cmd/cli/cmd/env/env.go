@@ -0,0 +1,34 @@
+package env
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// EnvCmd 表示环境变量命令组
+var EnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "查看、检查和比较环境变量",
+	Long: `查看当前进程的环境变量，检查PATH等路径列表中的问题，与 .env 文件比较或导出为 .env/JSON。
+名称或取值看起来是密码、令牌、密钥的变量（如 *_TOKEN、*_SECRET、带密码的URL）默认遮盖取值，
+使用 --show-secrets 显示原值。
+
+包含以下子命令:
+  list   - 列出环境变量，可按名称过滤
+  path   - 检查PATH等路径列表中重复、不存在的目录和被覆盖的命令
+  diff   - 比较当前环境与 .env 文件
+  export - 以 .env 或JSON格式导出环境变量
+
+示例:
+  %[1]s env list AWS_
+  %[1]s env path
+  %[1]s env diff .env
+  %[1]s env export 'APP_*' --format json -o env.json`,
+}
+
+func init() {
+	// 添加子命令
+	EnvCmd.AddCommand(listCmd)
+	EnvCmd.AddCommand(pathCmd)
+	EnvCmd.AddCommand(diffCmd)
+	EnvCmd.AddCommand(exportCmd)
+}
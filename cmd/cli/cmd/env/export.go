@@ -0,0 +1,90 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/envvars"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+// exportCmd 表示 env export 命令
+var exportCmd = &cobra.Command{
+	Use:   "export [模式]",
+	Short: "以 .env 或JSON格式导出环境变量",
+	Long: `将当前环境变量导出为 .env 格式（默认）或JSON对象，模式的匹配规则与 env list 相同。
+敏感变量的取值默认遮盖，需要导出原值时使用 --show-secrets；写入文件时文件权限为 0600。
+
+示例:
+  %[1]s env export > env.txt
+  %[1]s env export 'APP_*' -o .env.local --show-secrets
+  %[1]s env export --format json | jq .PATH`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pattern := ""
+		if len(args) > 0 {
+			pattern = args[0]
+		}
+		format, _ := cmd.Flags().GetString("format")
+		outPath, _ := cmd.Flags().GetString("out")
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+
+		vars := envvars.Filter(envvars.Current(), pattern, false)
+		hidden := 0
+		if !showSecrets {
+			for _, v := range vars {
+				if v.Secret {
+					hidden++
+				}
+			}
+			vars = envvars.Masked(vars)
+		}
+
+		var data []byte
+		switch strings.ToLower(format) {
+		case "dotenv", "env":
+			data = []byte(envvars.FormatDotenv(vars))
+		case "json":
+			values := make(map[string]string, len(vars))
+			for _, v := range vars {
+				values[v.Name] = v.Value
+			}
+			encoded, err := json.MarshalIndent(values, "", "  ")
+			if err != nil {
+				return i18n.Errorf("编码JSON失败: %v", err)
+			}
+			data = append(encoded, '\n')
+		default:
+			return errs.InvalidInput("不支持的导出格式: %s（可选: dotenv, json）", format)
+		}
+
+		if hidden > 0 {
+			fmt.Fprint(os.Stderr, i18n.Tf("已遮盖 %d 个敏感变量的取值，使用 --show-secrets 导出原值\n", hidden))
+		}
+		if outPath == "" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(outPath, data, 0600); err != nil {
+			return errs.Wrap(err, "写入 %s 失败: %v", outPath, err)
+		}
+		output.Infof(cmd, "已导出 %d 个变量到 %s\n", len(vars), outPath)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringP("format", "f", "dotenv", "导出格式（dotenv, json）")
+	exportCmd.Flags().StringP("out", "o", "", "输出到文件而非标准输出")
+	exportCmd.Flags().Bool("show-secrets", false, "导出敏感变量的原值")
+
+	exportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{"dotenv", "json"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
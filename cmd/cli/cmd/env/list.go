@@ -0,0 +1,80 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/envvars"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// listCmd 表示 env list 命令
+var listCmd = &cobra.Command{
+	Use:   "list [模式]",
+	Short: "列出环境变量，可按名称过滤",
+	Long: `按名称排序列出当前进程的环境变量，敏感变量的取值默认遮盖。
+
+模式不区分大小写，默认匹配名称中的子串；含有 * 或 ? 时按通配符匹配整个名称。
+--values 时取值中包含该模式的变量也会列出。
+
+示例:
+  %[1]s env list
+  %[1]s env list proxy
+  %[1]s env list 'GO*' --names
+  %[1]s env list /opt --values
+  %[1]s env list AWS_ --show-secrets --output json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pattern := ""
+		if len(args) > 0 {
+			pattern = args[0]
+		}
+		namesOnly, _ := cmd.Flags().GetBool("names")
+		searchValues, _ := cmd.Flags().GetBool("values")
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+
+		vars := envvars.Filter(envvars.Current(), pattern, searchValues)
+		if !showSecrets {
+			vars = envvars.Masked(vars)
+		}
+
+		return output.Render(cmd, vars, func() {
+			if len(vars) == 0 {
+				output.Infof(cmd, "没有匹配 '%s' 的环境变量\n", pattern)
+				return
+			}
+			printVars(vars, namesOnly, showSecrets)
+		})
+	},
+}
+
+// printVars 以 NAME=value 的形式逐行输出变量，遮盖的取值以黄色显示
+func printVars(vars []envvars.Var, namesOnly, showSecrets bool) {
+	name := color.New(color.FgCyan)
+	masked := color.New(color.FgYellow)
+	hidden := 0
+	for _, v := range vars {
+		if namesOnly {
+			fmt.Println(v.Name)
+			continue
+		}
+		value := v.Value
+		if v.Secret && !showSecrets {
+			value = masked.Sprint(value)
+			hidden++
+		}
+		fmt.Printf("%s=%s\n", name.Sprint(v.Name), value)
+	}
+	if hidden > 0 {
+		fmt.Fprint(os.Stderr, i18n.Tf("\n已遮盖 %d 个敏感变量的取值，使用 --show-secrets 显示原值\n", hidden))
+	}
+}
+
+func init() {
+	listCmd.Flags().Bool("names", false, "只输出变量名")
+	listCmd.Flags().Bool("values", false, "取值中包含模式的变量也列出")
+	listCmd.Flags().Bool("show-secrets", false, "显示敏感变量的原值")
+}
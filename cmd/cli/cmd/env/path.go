@@ -0,0 +1,138 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/envvars"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// PathResult 一个路径列表变量的检查结果
+type PathResult struct {
+	Name     string              `json:"name"`
+	Entries  []envvars.PathEntry `json:"entries"`
+	Shadowed []envvars.Shadow    `json:"shadowed,omitempty"`
+}
+
+// pathCmd 表示 env path 命令
+var pathCmd = &cobra.Command{
+	Use:   "path [变量名...]",
+	Short: "检查PATH等路径列表中重复、不存在的目录和被覆盖的命令",
+	Long: `逐项列出路径列表变量中的目录并指出问题，默认检查 PATH：
+  - 重复：与前面的某一项相同，或通过符号链接指向同一目录
+  - 不存在、不是目录
+  - 相对路径和空项：随当前目录变化，空项在Unix上表示当前目录，存在安全隐患
+
+检查 PATH 时还会列出被覆盖的命令，即多个目录中都有的同名可执行文件，只有最前面的会被执行，
+可用于排查“安装了新版本却仍在运行旧版本”的问题。
+--all 检查所有名称以 PATH 或 _DIRS 结尾且取值为目录列表的变量，如 PYTHONPATH、LD_LIBRARY_PATH、XDG_DATA_DIRS。
+
+示例:
+  %[1]s env path
+  %[1]s env path PYTHONPATH LD_LIBRARY_PATH
+  %[1]s env path --all
+  %[1]s env path --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		current := envvars.Current()
+
+		var targets []envvars.Var
+		switch {
+		case all:
+			for _, v := range current {
+				if envvars.IsPathList(v.Name, v.Value) {
+					targets = append(targets, v)
+				}
+			}
+		case len(args) == 0:
+			args = []string{"PATH"}
+			fallthrough
+		default:
+			for _, name := range args {
+				v, ok := envvars.Lookup(current, name)
+				if !ok {
+					return errs.NotFound("环境变量 %s 未设置", name)
+				}
+				targets = append(targets, v)
+			}
+		}
+
+		results := make([]PathResult, 0, len(targets))
+		for _, v := range targets {
+			result := PathResult{Name: v.Name, Entries: envvars.AnalyzePathList(v.Value)}
+			if strings.EqualFold(v.Name, "PATH") {
+				result.Shadowed = envvars.FindShadowed(result.Entries)
+			}
+			results = append(results, result)
+		}
+
+		return output.Render(cmd, results, func() {
+			for i, result := range results {
+				if i > 0 {
+					fmt.Println()
+				}
+				printPathResult(result)
+			}
+		})
+	},
+}
+
+// printPathResult 输出一个变量的检查结果
+func printPathResult(result PathResult) {
+	color.New(color.FgCyan, color.Bold).Printf(i18n.T("%s（%d 项）\n"), result.Name, len(result.Entries))
+
+	problems := 0
+	table := output.NewTable(os.Stdout, []string{"#", i18n.T("目录"), i18n.T("问题")})
+	for _, entry := range result.Entries {
+		problem := describeProblem(entry)
+		if problem != "" {
+			problems++
+		}
+		table.Append([]string{strconv.Itoa(entry.Index), entry.Dir, problem})
+	}
+	table.Render()
+
+	if problems == 0 {
+		color.Green(i18n.T("没有发现问题"))
+	} else {
+		color.Yellow(i18n.T("%d 项有问题"), problems)
+	}
+
+	if len(result.Shadowed) > 0 {
+		fmt.Print(i18n.Tf("\n被覆盖的命令（%d 个，只会执行第一个）:\n", len(result.Shadowed)))
+		for _, shadow := range result.Shadowed {
+			fmt.Printf("  %s  %s\n", color.New(color.Bold).Sprint(shadow.Name), shadow.Used)
+			for _, path := range shadow.Shadowed {
+				fmt.Printf("    %s\n", color.New(color.Faint).Sprint(i18n.T("覆盖 ")+path))
+			}
+		}
+	}
+}
+
+// describeProblem 返回一项问题的说明，没有问题时返回空字符串
+func describeProblem(entry envvars.PathEntry) string {
+	switch entry.Problem {
+	case envvars.ProblemDuplicate:
+		return color.YellowString(i18n.T("与第 %d 项重复"), entry.Duplicate)
+	case envvars.ProblemMissing:
+		return color.RedString(i18n.T("不存在"))
+	case envvars.ProblemNotDir:
+		return color.RedString(i18n.T("不是目录"))
+	case envvars.ProblemRelative:
+		return color.YellowString(i18n.T("相对路径"))
+	case envvars.ProblemEmpty:
+		return color.YellowString(i18n.T("空项（当前目录）"))
+	}
+	return ""
+}
+
+func init() {
+	pathCmd.Flags().Bool("all", false, "检查所有名称以 PATH 或 _DIRS 结尾且取值为目录列表的变量")
+}
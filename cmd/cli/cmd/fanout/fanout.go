@@ -0,0 +1,182 @@
+package fanout
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fanout"
+	"toolbox/pkg/history"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// inheritedFlags 传递给每个目标命令的全局标志
+var inheritedFlags = []string{"lang", "no-color", "verbose", "quiet", "log-file"}
+
+// MapCmd 表示 map 命令
+var MapCmd = &cobra.Command{
+	Use:   "map --targets <文件> [选项] -- <子命令> [参数...]",
+	Short: "对多个目标并发执行同一条命令",
+	Long: `对目标列表中的每个目标并发执行同一个工具箱子命令，并汇总每个目标的状态、退出码和耗时。
+
+命令参数中的 {} 替换为目标，没有 {} 时目标作为最后一个参数追加。
+目标文件每行一个目标，忽略空行和 # 开头的注释，文件名为 - 时从标准输入读取；
+也可以用 --target 直接指定。
+每个目标完成后输出该目标的结果，最后输出汇总；使用 --output json/yaml 时只输出
+包含每个目标输出内容的汇总，便于脚本处理。
+只要有目标失败，命令就以第一个失败目标（按列表顺序）的退出码退出。
+
+示例:
+  %[1]s map --targets hosts.txt -- network ping {} -c 2
+  %[1]s map --targets hosts.txt -j 20 --timeout 30s -- network portscan {} -p 22,443
+  %[1]s map -t 8.8.8.8,1.1.1.1 -- network traceroute
+  %[1]s map --targets hosts.txt --summary-only -- network ping {} -c 1
+  cat domains.txt | %[1]s map --targets - --output json -- network dns {}`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetsFile, _ := cmd.Flags().GetString("targets")
+		inline, _ := cmd.Flags().GetStringSlice("target")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
+		summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+
+		if parallel < 1 {
+			return errs.InvalidInput("并发数必须大于0")
+		}
+		if args[0] == cmd.Name() {
+			return errs.InvalidInput("不能嵌套执行 map 命令")
+		}
+
+		targets := inline
+		if targetsFile != "" {
+			loaded, err := fanout.LoadTargets(targetsFile)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, loaded...)
+		}
+		if len(targets) == 0 {
+			return errs.InvalidInput("请使用 --targets 或 --target 指定目标")
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return i18n.Errorf("获取程序路径失败: %v", err)
+		}
+
+		structured := output.IsStructured(cmd)
+		options := fanout.Options{
+			Executable: executable,
+			Args:       append(globalArgs(cmd), args...),
+			Targets:    targets,
+			Parallel:   parallel,
+			Timeout:    timeout,
+			Env:        []string{history.DisableEnv + "=1"},
+		}
+		if !structured {
+			options.OnDone = func(result fanout.Result) {
+				printResult(result, summaryOnly)
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		summary, err := fanout.Run(ctx, options)
+		if err != nil {
+			return err
+		}
+
+		if err := output.Render(cmd, summary, func() {
+			printSummary(summary)
+		}); err != nil {
+			return err
+		}
+
+		// 汇总已经输出，有目标失败时只设置退出码
+		if failure := summary.FirstFailure(); failure != nil {
+			return errs.Exit(failure.ExitCode)
+		}
+		return nil
+	},
+}
+
+// printResult 输出单个目标的结果
+func printResult(result fanout.Result, summaryOnly bool) {
+	status := color.GreenString(i18n.T("成功"))
+	if result.Status != fanout.StatusOK {
+		status = color.RedString(i18n.T("失败: %s"), result.Error)
+	}
+
+	if summaryOnly {
+		fmt.Printf("[%d] %s  %s (%s)\n", result.Index, result.Target, status, units.FormatDuration(time.Duration(result.DurationMs)*time.Millisecond))
+		return
+	}
+	color.New(color.FgCyan, color.Bold).Printf("==> [%d] %s <==", result.Index, result.Target)
+	fmt.Printf("  %s (%s)\n", status, units.FormatDuration(time.Duration(result.DurationMs)*time.Millisecond))
+	fmt.Print(result.Output)
+	if result.Output != "" && !strings.HasSuffix(result.Output, "\n") {
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
+// printSummary 以表格形式输出执行汇总
+func printSummary(summary *fanout.Summary) {
+	fmt.Println()
+	color.New(color.Bold).Println(i18n.T("执行汇总"))
+
+	table := output.NewTable(os.Stdout, []string{i18n.T("序号"), i18n.T("目标"), i18n.T("状态"), i18n.T("退出码"), i18n.T("耗时")})
+	for _, result := range summary.Results {
+		var status string
+		switch result.Status {
+		case fanout.StatusOK:
+			status = color.GreenString(i18n.T("成功"))
+		case fanout.StatusTimeout:
+			status = color.RedString(i18n.T("超时"))
+		default:
+			status = color.RedString(i18n.T("失败"))
+		}
+		table.Append([]string{
+			fmt.Sprintf("%d", result.Index),
+			result.Target,
+			status,
+			fmt.Sprintf("%d", result.ExitCode),
+			units.FormatDuration(time.Duration(result.DurationMs) * time.Millisecond),
+		})
+	}
+	table.Render()
+
+	fmt.Print(i18n.Tf("\n共 %d 个目标：成功 %d，失败 %d，总耗时 %s\n",
+		summary.Total, summary.Succeeded, summary.Failed, units.FormatDuration(time.Duration(summary.DurationMs)*time.Millisecond)))
+}
+
+// globalArgs 返回需要传递给目标命令的全局标志
+func globalArgs(cmd *cobra.Command) []string {
+	var args []string
+	flags := cmd.Root().PersistentFlags()
+	for _, name := range inheritedFlags {
+		if flag := flags.Lookup(name); flag != nil && flag.Changed {
+			args = append(args, fmt.Sprintf("--%s=%s", name, flag.Value.String()))
+		}
+	}
+	return args
+}
+
+func init() {
+	MapCmd.Flags().SetInterspersed(false)
+	MapCmd.Flags().StringP("targets", "f", "", "目标列表文件，每行一个目标，- 表示标准输入")
+	MapCmd.Flags().StringSliceP("target", "t", nil, "直接指定目标，多个用逗号分隔")
+	MapCmd.Flags().IntP("parallel", "j", 8, "最大并发数")
+	flagtype.Duration(MapCmd.Flags(), "timeout", 0, time.Second, "每个目标的超时时间，如 30s，0表示不限制")
+	MapCmd.Flags().Bool("summary-only", false, "不输出每个目标的内容，只显示状态")
+}
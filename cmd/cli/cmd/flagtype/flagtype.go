@@ -0,0 +1,98 @@
+// Package flagtype 提供大小和时长类型的命令行标志，各命令使用统一的解析规则和错误提示
+//
+// 解析规则见 pkg/units：大小如 512K、10M、1.5G，时长如 300ms、30s、1h30m、2d。
+// 纯数字按注册标志时指定的默认单位解释，以兼容原来只接受数字的参数。
+package flagtype
+
+import (
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/units"
+
+	"github.com/spf13/pflag"
+)
+
+// sizeValue 大小标志的值
+type sizeValue struct {
+	bytes int64
+	unit  int64
+}
+
+func (v *sizeValue) Set(text string) error {
+	size, err := units.ParseSize(text, v.unit)
+	if err != nil {
+		return err
+	}
+	v.bytes = size
+	return nil
+}
+
+func (v *sizeValue) String() string { return units.FormatSize(v.bytes) }
+func (v *sizeValue) Type() string   { return "size" }
+
+// durationValue 时长标志的值
+type durationValue struct {
+	duration time.Duration
+	unit     time.Duration
+	signed   bool
+}
+
+func (v *durationValue) Set(text string) error {
+	d, err := units.ParseDuration(text, v.unit)
+	if err != nil {
+		return err
+	}
+	if d < 0 && !v.signed {
+		return errs.InvalidInput("时长不能为负数: %s", text)
+	}
+	v.duration = d
+	return nil
+}
+
+func (v *durationValue) String() string { return units.FormatDuration(v.duration) }
+func (v *durationValue) Type() string   { return "duration" }
+
+// Size 注册大小标志，unit为纯数字时使用的单位
+func Size(flags *pflag.FlagSet, name string, value, unit int64, usage string) {
+	SizeP(flags, name, "", value, unit, usage)
+}
+
+// SizeP 注册带短选项的大小标志
+func SizeP(flags *pflag.FlagSet, name, shorthand string, value, unit int64, usage string) {
+	flags.VarP(&sizeValue{bytes: value, unit: unit}, name, shorthand, usage)
+}
+
+// GetSize 返回大小标志的字节数，标志不存在时返回0
+func GetSize(flags *pflag.FlagSet, name string) int64 {
+	if flag := flags.Lookup(name); flag != nil {
+		if v, ok := flag.Value.(*sizeValue); ok {
+			return v.bytes
+		}
+	}
+	return 0
+}
+
+// Duration 注册时长标志，unit为纯数字时使用的单位
+func Duration(flags *pflag.FlagSet, name string, value, unit time.Duration, usage string) {
+	DurationP(flags, name, "", value, unit, usage)
+}
+
+// DurationP 注册带短选项的时长标志
+func DurationP(flags *pflag.FlagSet, name, shorthand string, value, unit time.Duration, usage string) {
+	flags.VarP(&durationValue{duration: value, unit: unit}, name, shorthand, usage)
+}
+
+// SignedDurationP 注册允许负数的时长标志，符号的含义由命令决定
+func SignedDurationP(flags *pflag.FlagSet, name, shorthand string, value, unit time.Duration, usage string) {
+	flags.VarP(&durationValue{duration: value, unit: unit, signed: true}, name, shorthand, usage)
+}
+
+// GetDuration 返回时长标志的值，标志不存在时返回0
+func GetDuration(flags *pflag.FlagSet, name string) time.Duration {
+	if flag := flags.Lookup(name); flag != nil {
+		if v, ok := flag.Value.(*durationValue); ok {
+			return v.duration
+		}
+	}
+	return 0
+}
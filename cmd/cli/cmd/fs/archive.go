@@ -0,0 +1,187 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+// archiveCmd 表示 fs archive 命令组
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "查看和转换压缩文件",
+	Long: `查看压缩文件的内容或转换为另一种格式，不需要解压到磁盘。支持 fs compress 能解压的所有格式。
+
+包含以下子命令:
+  list    - 列出压缩文件中的文件和目录
+  convert - 将压缩文件转换为另一种格式
+
+示例:
+  %[1]s fs archive list backup.tar.gz
+  %[1]s fs archive list release.zip --output json
+  %[1]s fs archive convert release.zip release.tar.zst`,
+}
+
+// archiveListCmd 表示 fs archive list 命令
+var archiveListCmd = &cobra.Command{
+	Use:   "list <压缩文件>",
+	Short: "列出压缩文件中的文件和目录",
+	Long: `列出压缩文件中的文件和目录，包括大小、压缩后的大小、权限和修改时间。
+
+zip和rar直接读取文件头，速度很快；tar格式需要解压缩整个数据流（不写入磁盘）；
+7z和单文件格式（gz、bz2、xz、zst、lz4、br）需要完整解压缩才能得到解压后的大小。
+tar和7z等整体压缩的格式无法得知单个文件压缩后的大小，显示为 -。
+
+示例:
+  %[1]s fs archive list backup.tar.gz
+  %[1]s fs archive list release.zip --output json
+  %[1]s fs archive list app.log.zst`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := fsutils.ListArchive(args[0])
+		if err != nil {
+			return errs.Wrap(err, "读取压缩文件失败: %v", err)
+		}
+
+		results := make([]archiveEntry, 0, len(entries))
+		for _, entry := range entries {
+			results = append(results, newArchiveEntry(entry))
+		}
+		return output.Render(cmd, results, func() {
+			printArchiveEntries(entries)
+		})
+	},
+}
+
+// archiveConvertCmd 表示 fs archive convert 命令
+var archiveConvertCmd = &cobra.Command{
+	Use:   "convert <源压缩文件> <目标压缩文件>",
+	Short: "将压缩文件转换为另一种格式",
+	Long: `将压缩文件转换为另一种格式，逐个读取源压缩文件中的文件直接写入目标压缩文件，不需要先解压到磁盘，
+保留路径、权限、修改时间和符号链接。
+
+源压缩文件支持 fs compress 能解压的所有格式，目标格式根据扩展名判断或用 --type 指定，支持zip、tar.gz等tar格式，
+以及只包含一个文件时的gz、bz2、xz、zst、lz4、br。zip不支持硬链接，包含硬链接的tar只能转换为tar格式。
+7z中的文件没有记录解压后的大小，转换为tar格式时逐个写入临时文件。
+
+示例:
+  %[1]s fs archive convert release.zip release.tar.zst
+  %[1]s fs archive convert backup.tar.gz backup.tar.xz -l 9
+  %[1]s fs archive convert photos.7z photos.zip
+  %[1]s fs archive convert secret.zip data.tar.gz --password env:ZIP_PASSWORD
+  %[1]s fs archive convert site.tar.gz html.zip --include '*.html'`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var to fsutils.CompressOptions
+		if compressionType, _ := cmd.Flags().GetString("type"); compressionType != "" {
+			format, err := parseFormat(compressionType)
+			if err != nil {
+				return err
+			}
+			to.Format = format
+		}
+		to.Level, _ = cmd.Flags().GetInt("level")
+		to.Parallel, _ = cmd.Flags().GetInt("parallel")
+
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+		include, _ := cmd.Flags().GetStringSlice("include")
+		onProgress, finish := progressPrinter(i18n.T("转换"))
+		defer finish()
+		from := fsutils.DecompressOptions{
+			Progress: onProgress,
+			Password: password,
+			Include:  include,
+		}
+
+		// Ctrl+C 时停止并删除未完成的目标压缩文件
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if err := fsutils.ConvertArchiveContext(ctx, args[0], args[1], from, to); err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf("转换已取消")
+			}
+			return err
+		}
+		return nil
+	},
+}
+
+// archiveEntry archive list 的结构化输出
+type archiveEntry struct {
+	Name           string    `json:"name"`
+	Type           string    `json:"type"` // file, dir, symlink, hardlink
+	Size           int64     `json:"size"`
+	CompressedSize int64     `json:"compressed_size"` // 无法得知时为-1
+	Mode           string    `json:"mode"`
+	ModTime        time.Time `json:"mod_time"`
+}
+
+// newArchiveEntry 根据压缩文件中的条目创建输出条目
+func newArchiveEntry(entry fsutils.ArchiveEntry) archiveEntry {
+	entryType := "file"
+	switch {
+	case entry.Mode&os.ModeSymlink != 0:
+		entryType = "symlink"
+	case entry.IsHardLink:
+		entryType = "hardlink"
+	case entry.IsDir:
+		entryType = "dir"
+	}
+	return archiveEntry{
+		Name:           entry.Name,
+		Type:           entryType,
+		Size:           entry.Size,
+		CompressedSize: entry.CompressedSize,
+		Mode:           entry.Mode.String(),
+		ModTime:        entry.ModTime,
+	}
+}
+
+// printArchiveEntries 以表格输出条目，最后汇总文件数和总大小
+func printArchiveEntries(entries []fsutils.ArchiveEntry) {
+	table := output.NewTable(os.Stdout, []string{i18n.T("权限"), i18n.T("大小"), i18n.T("压缩后"), i18n.T("修改时间"), i18n.T("名称")})
+	var files, dirs int
+	var total int64
+	for _, entry := range entries {
+		size, compressed, modTime := "-", "-", "-"
+		if entry.IsDir {
+			dirs++
+		} else {
+			files++
+			total += entry.Size
+			size = fsutils.FormatSize(entry.Size)
+			if entry.CompressedSize >= 0 {
+				compressed = fsutils.FormatSize(entry.CompressedSize)
+			}
+		}
+		if !entry.ModTime.IsZero() {
+			modTime = entry.ModTime.Local().Format("2006-01-02 15:04:05")
+		}
+		table.Append([]string{entry.Mode.String(), size, compressed, modTime, entry.Name})
+	}
+	table.Render()
+	fmt.Print(i18n.Tf("\n共 %d 个文件，%d 个目录，解压后 %s\n", files, dirs, fsutils.FormatSize(total)))
+}
+
+func init() {
+	archiveConvertCmd.Flags().StringP("type", "t", "", "目标压缩格式，不指定时根据目标文件的扩展名判断")
+	archiveConvertCmd.Flags().IntP("level", "l", 6, "压缩级别（1-9）")
+	archiveConvertCmd.Flags().IntP("parallel", "j", 0, "tar.gz和gz并行压缩的线程数，-1 表示使用全部CPU核心，默认不并行")
+	archiveConvertCmd.Flags().String("password", "", "源压缩文件（zip、rar、7z）的密码，支持 @文件路径、env:变量名 或原文")
+	archiveConvertCmd.Flags().StringSlice("include", nil, "只转换匹配的文件（支持通配符和 **），可以多次指定")
+
+	archiveCmd.AddCommand(archiveListCmd)
+	archiveCmd.AddCommand(archiveConvertCmd)
+	FsCmd.AddCommand(archiveCmd)
+}
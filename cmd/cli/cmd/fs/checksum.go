@@ -0,0 +1,174 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"toolbox/pkg/i18n"
+
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fsutils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// checksumCmd 表示 checksum 命令组
+var checksumCmd = &cobra.Command{
+	Use:   "checksum",
+	Short: "生成和校验目录的校验和清单",
+	Long: `为整个目录生成 SHA256SUMS 格式的校验和清单，之后按清单检查文件是否被修改或损坏，
+适合定期检查归档和备份中的数据是否完好。
+
+清单中的文件名为相对于目录的路径，与 sha256sum 的输出格式相同，也可以在该目录中用 sha256sum -c 检查。
+
+示例:
+  %[1]s fs checksum create /mnt/backup        # 生成 /mnt/backup/SHA256SUMS
+  %[1]s fs checksum verify /mnt/backup        # 按清单检查`,
+}
+
+// checksumCreateCmd 表示 checksum create 命令
+var checksumCreateCmd = &cobra.Command{
+	Use:   "create [目录路径]",
+	Short: "为目录中的所有文件生成校验和清单",
+	Long: `并行计算目录中所有文件的SHA-256校验和，按路径排序写入清单，默认为目录中的 SHA256SUMS。
+不跟随符号链接，清单文件本身不计算在内；无法读取的文件给出警告，不写入清单。
+
+示例:
+  %[1]s fs checksum create /mnt/backup                           # 生成 /mnt/backup/SHA256SUMS
+  %[1]s fs checksum create ./release -m release.sha256           # 指定清单文件
+  %[1]s fs checksum create ~/photos --exclude .cache -e "*.tmp"  # 排除目录和文件`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) > 0 {
+			root = args[0]
+		}
+		manifest, _ := cmd.Flags().GetString("manifest")
+		options := checksumOptions(cmd)
+		onProgress, finish := progressPrinter(i18n.T("计算校验和"))
+		options.Progress = onProgress
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		result, err := fsutils.CreateChecksumsContext(ctx, root, manifest, options)
+		finish()
+		if err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf("已取消，没有写入清单")
+			}
+			return err
+		}
+
+		if err := output.Render(cmd, result, func() {
+			fmt.Print(i18n.Tf("已将 %d 个文件（%s）的校验和写入 %s\n", result.Files, fsutils.FormatSize(result.Size), result.Manifest))
+		}); err != nil {
+			return err
+		}
+		if result.Errors > 0 {
+			return i18n.Errorf("%d 个文件或目录无法读取，没有写入清单", result.Errors)
+		}
+		return nil
+	},
+}
+
+// checksumVerifyCmd 表示 checksum verify 命令
+var checksumVerifyCmd = &cobra.Command{
+	Use:   "verify [目录路径]",
+	Short: "按校验和清单检查目录中的文件",
+	Long: `按清单（默认为目录中的 SHA256SUMS）并行计算每个文件的校验和，报告内容不一致、已经不存在和无法读取的文件，
+以及目录中新增、清单中没有的文件。清单可以是 fs checksum create 或 sha256sum 生成的。
+
+有文件不一致、不存在或无法读取时以退出码1退出；新增的文件默认只列出，--strict 时也视为失败。
+
+示例:
+  %[1]s fs checksum verify /mnt/backup                    # 按 /mnt/backup/SHA256SUMS 检查
+  %[1]s fs checksum verify ./release -m release.sha256    # 指定清单文件
+  %[1]s fs checksum verify /data --strict --output json   # 新增的文件也视为失败，以JSON格式输出`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) > 0 {
+			root = args[0]
+		}
+		manifest, _ := cmd.Flags().GetString("manifest")
+		strict, _ := cmd.Flags().GetBool("strict")
+		options := checksumOptions(cmd)
+		onProgress, finish := progressPrinter(i18n.T("校验"))
+		options.Progress = onProgress
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		report, err := fsutils.VerifyChecksumsContext(ctx, root, manifest, options)
+		finish()
+		if err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf("校验已取消")
+			}
+			return err
+		}
+
+		if err := output.Render(cmd, report, func() {
+			printChecksumReport(report)
+		}); err != nil {
+			return err
+		}
+		// 报告已经输出，只设置退出码
+		if !report.Passed() || (strict && len(report.Extra) > 0) {
+			return errs.Exit(errs.ExitFailure)
+		}
+		return nil
+	},
+}
+
+// checksumOptions 读取 create 和 verify 共用的选项
+func checksumOptions(cmd *cobra.Command) fsutils.ChecksumOptions {
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	threads, _ := cmd.Flags().GetInt("threads")
+	return fsutils.ChecksumOptions{
+		Exclude: exclude,
+		Threads: threads,
+		OnError: func(path string, err error) {
+			fmt.Fprint(os.Stderr, i18n.Tf("警告: %s: %v\n", path, err))
+		},
+	}
+}
+
+// printChecksumReport 列出有问题的文件和汇总
+func printChecksumReport(report *fsutils.ChecksumReport) {
+	for _, name := range report.Mismatched {
+		fmt.Printf("%s %s\n", color.RedString(i18n.T("不一致")), name)
+	}
+	for _, name := range report.Missing {
+		fmt.Printf("%s %s\n", color.RedString(i18n.T("不存在")), name)
+	}
+	for _, name := range report.Unreadable {
+		fmt.Printf("%s %s\n", color.RedString(i18n.T("无法读取")), name)
+	}
+	for _, name := range report.Extra {
+		fmt.Printf("%s %s\n", color.YellowString(i18n.T("新增")), name)
+	}
+
+	summary := i18n.Tf("清单中 %d 个文件，%d 个一致，%d 个不一致，%d 个不存在，%d 个无法读取；新增 %d 个文件",
+		report.Files, report.OK, len(report.Mismatched), len(report.Missing), len(report.Unreadable), len(report.Extra))
+	if report.Passed() {
+		color.Green("%s\n", summary)
+	} else {
+		color.Red("%s\n", summary)
+	}
+}
+
+func init() {
+	FsCmd.AddCommand(checksumCmd)
+	checksumCmd.AddCommand(checksumCreateCmd)
+	checksumCmd.AddCommand(checksumVerifyCmd)
+
+	for _, cmd := range []*cobra.Command{checksumCreateCmd, checksumVerifyCmd} {
+		cmd.Flags().StringP("manifest", "m", "", "清单文件的路径（默认为目录中的 SHA256SUMS）")
+		cmd.Flags().StringSliceP("exclude", "e", nil, "排除匹配的文件和目录（支持通配符，可多次使用）")
+		cmd.Flags().IntP("threads", "t", 0, "同时计算校验和的文件数量（默认为CPU核心数）")
+	}
+	checksumVerifyCmd.Flags().Bool("strict", false, "目录中有清单中没有的文件时也视为失败")
+}
@@ -1,14 +1,27 @@
 package fs
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/digest"
+	"toolbox/pkg/errs"
 	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
+// progressThreshold 超过该大小时在终端上显示进度
+const progressThreshold = 16 * 1024 * 1024
+
 var compressCmd = &cobra.Command{
 	Use:   "compress [源文件/目录] [目标路径]",
 	Short: "压缩或解压缩文件",
@@ -17,66 +30,210 @@ var compressCmd = &cobra.Command{
 模式：
   - compress:   压缩模式（默认）
   - decompress: 解压缩模式
+  - verify:     校验模式，解压每个文件但不写入磁盘，检查CRC等校验值，只需要指定压缩文件
 
 支持的压缩格式：
   - zip:     ZIP压缩文件（支持目录）
   - tar.gz:  TAR+GZIP压缩文件（支持目录，或 .tgz）
   - tar.bz2: TAR+BZIP2压缩文件（支持目录，或 .tbz2）
   - tar.xz:  TAR+XZ压缩文件（支持目录，或 .txz）
+  - tar.zst: TAR+Zstandard压缩文件（支持目录，或 .tzst）
+  - tar.lz4: TAR+LZ4压缩文件（支持目录）
+  - tar.br:  TAR+Brotli压缩文件（支持目录）
   - gz:      GZIP压缩文件（仅支持单文件）
   - bz2:     BZIP2压缩文件（仅支持单文件）
   - xz:      XZ压缩文件（仅支持单文件）
+  - zst:     Zstandard压缩文件（仅支持单文件，速度快）
+  - lz4:     LZ4压缩文件（仅支持单文件，速度最快，压缩率较低，忽略压缩级别）
+  - br:      Brotli压缩文件（仅支持单文件，适合网页资源，-l 9 为最高压缩率）
   - 7z:      7-Zip压缩文件（支持目录）
   - rar:     RAR压缩文件（仅支持解压缩）
 
+--password 压缩时使用AES-256加密zip中的文件内容（WinZip AES格式，7-Zip、WinRAR、macOS归档实用工具等都可以解压，
+系统自带的 unzip 命令不支持），文件名不会加密。解压缩时用于加密的zip（AES或传统加密）、rar和7z文件，
+不支持文件列表也加密的7z文件。
+
+tar格式保留符号链接和硬链接；解压时链接目标必须在目标目录内，指向目录外的链接会被跳过并报告。
+解压缩时默认按压缩文件中的权限创建文件（受umask影响），修改时间为解压的时间；--preserve 恢复权限和修改时间，
+以root运行时还恢复tar中记录的所有者。
+
+--include 只压缩或解压匹配的文件，--exclude 压缩时跳过匹配的文件和目录，都可以多次指定或用逗号分隔。
+模式匹配文件在压缩文件中的路径（压缩时相对于源目录），语法与shell通配符相同，** 匹配任意层目录；
+不含 / 的模式匹配任意层级的文件名（如 *.log、node_modules），匹配目录时包括其下的所有文件。
+解压缩时zip只读取匹配的文件，速度很快；tar等格式仍需解压缩整个数据流，但只有匹配的文件写入磁盘。
+解压缩时有模式没有匹配任何文件时返回错误。
+
+压缩目录时还可以按大小（--min-size、--max-size）和修改时间（--since 最近修改、--before 较早修改）选择文件，
+这些条件同时满足的文件才会压缩。
+
+--volume-size 将zip分卷：除最后一个分卷外每个分卷为指定大小，依次写入 name.z01、name.z02……，最后一个分卷为 name.zip，
+与7-Zip、WinRAR、zip -s 创建的分卷格式相同，可以互相解压。解压缩、校验和查看分卷zip时指定 .zip 文件，
+其余分卷需要在同一目录下。
+
+--manifest 压缩时生成SHA-256校验和清单（与 sha256sum 的输出格式相同），记录每个源文件在压缩文件中的路径，
+最后是压缩文件本身（分卷zip为每个分卷），可以在压缩文件所在的目录用 sha256sum -c 检查压缩文件是否完好。
+解压缩和校验时指定清单则检查每个文件的内容与压缩前是否一致，并报告清单中有但压缩文件中没有的文件，适合长期保存的备份。
+
+--dedupe 压缩为tar格式时计算文件的校验和，内容和权限都相同的文件只保存第一个，其余写为指向它的硬链接，
+适合包含大量重复文件的构建输出目录。解压后这些文件是同一个文件的硬链接，修改其中一个会影响其他文件。
+
+-j/--parallel 压缩时指定tar.gz和gz并行压缩的线程数；解压缩zip时指定同时解压的文件数，
+大量小文件解压到SSD时可以明显加快速度，其他格式只能按顺序解压。
+
+解压缩时某个文件失败（如无法写入）会跳过该文件继续解压其余文件，最后汇总失败的数量；密码错误时直接停止。
+校验模式同样报告所有损坏的文件，但tar、7z等整体压缩的格式损坏后通常无法继续读取之后的文件。
+
+解压缩时根据文件开头的特征字节识别格式，扩展名不标准（如 backup.bak）也可以解压，无法识别时再根据扩展名判断，
+也可以用 --type 指定。Brotli没有特征字节，只能根据扩展名（.br、.tar.br）识别，从标准输入解压时需要用 --type 指定。
+
+目标路径为 - 时将压缩数据写入标准输出（必须用 --type 指定格式），解压缩时源文件为 - 表示从标准输入读取，
+可以配合 ssh、nc 等通过管道传输而不需要临时文件。从标准输入解压zip和7z时需要先写入临时文件（这两种格式需要随机访问）。
+
+处理的数据超过16MB时，在终端上显示进度和正在处理的文件。按 Ctrl+C 取消时删除未完成的压缩文件或正在解压的文件。
+
 示例:
   # 压缩（默认模式）
   %[1]s fs compress myfile.txt myfile.txt.gz
   %[1]s fs compress mydir mydir.zip --type zip
   %[1]s fs compress mydir output.7z --type 7z
   %[1]s fs compress mydir output --type tar.gz -l 9 -k
+  %[1]s fs compress /data data.tar.gz -j -1        # 使用全部CPU核心并行压缩
+  %[1]s fs compress logs logs.zip --password env:ZIP_PASSWORD
+  %[1]s fs compress mydir mydir.tar.zst
+  %[1]s fs compress app.log app.log.lz4
+  %[1]s fs compress dist/app.js dist/app.js.br -l 9
+  %[1]s fs compress project project.tar.zst --exclude node_modules,.git,'*.log'
+  %[1]s fs compress /var/log recent-logs.tar.gz --include '*.log' --since 7d --max-size 100M
+  %[1]s fs compress photos photos.zip --volume-size 4G   # 分卷为 photos.z01、photos.z02……和 photos.zip
+  %[1]s fs compress /data backup.tar.zst --manifest backup.sha256
+  %[1]s fs compress build build.tar.zst --dedupe
 
   # 解压缩
   %[1]s fs compress myfile.txt.gz myfile.txt --mode decompress
   %[1]s fs compress mydir.zip extracted/ --mode decompress
-  %[1]s fs compress mydir.7z extracted/ --mode decompress`,
-	Args: cobra.ExactArgs(2),
+  %[1]s fs compress mydir.tar.zst extracted/ --mode decompress
+  %[1]s fs compress mydir.tar.lz4 extracted/ --mode decompress
+  %[1]s fs compress app.js.br app.js --mode decompress
+  %[1]s fs compress mydir.7z extracted/ --mode decompress
+  %[1]s fs compress secret.zip extracted/ --mode decompress --password env:ZIP_PASSWORD
+  %[1]s fs compress backup.tar.gz restore/ --mode decompress --include etc/nginx/nginx.conf
+  sudo %[1]s fs compress rootfs.tar.xz /srv/rootfs --mode decompress --preserve
+  %[1]s fs compress site.zip out/ --mode decompress --include '*.html,assets/**/*.css'
+  %[1]s fs compress backup.bak restore/ --mode decompress
+  %[1]s fs compress node_modules.zip out/ --mode decompress -j -1   # 使用全部CPU核心并行解压
+
+  # 校验压缩文件是否完整
+  %[1]s fs compress backup.tar.zst --mode verify
+  %[1]s fs compress secret.zip --mode verify --password env:ZIP_PASSWORD
+  %[1]s fs compress backup.tar.zst --mode verify --manifest backup.sha256
+
+  # 通过管道传输
+  %[1]s fs compress /data - --type tar.zst | ssh backup-host 'cat > data.tar.zst'
+  ssh backup-host 'cat data.tar.zst' | %[1]s fs compress - restore/ --mode decompress`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// 获取操作模式和压缩类型
+		mode, _ := cmd.Flags().GetString("mode")
+		compressionType, _ := cmd.Flags().GetString("type")
+		if mode == "verify" {
+			if len(args) != 1 {
+				return errs.InvalidInput("校验模式只需要指定压缩文件")
+			}
+		} else if len(args) != 2 {
+			return errs.InvalidInput("需要指定源路径和目标路径")
+		}
 		src := args[0]
-		dst := args[1]
 
-		// 获取操作模式
-		mode, _ := cmd.Flags().GetString("mode")
-		if mode == "decompress" {
-			return fsutils.Decompress(src, dst)
+		// Ctrl+C 时停止并删除未完成的文件
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if mode == "decompress" || mode == "verify" {
+			// 不指定压缩类型时根据文件内容和扩展名判断
+			var format fsutils.CompressFormat
+			if compressionType != "" {
+				var err error
+				if format, err = parseFormat(compressionType); err != nil {
+					return err
+				}
+			}
+			password, err := readPassword(cmd)
+			if err != nil {
+				return err
+			}
+			include, _ := cmd.Flags().GetStringSlice("include")
+			preserve, _ := cmd.Flags().GetBool("preserve")
+			manifest, _ := cmd.Flags().GetString("manifest")
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			action, verb := i18n.T("解压缩"), i18n.T("解压")
+			if mode == "verify" {
+				action, verb = i18n.T("校验"), i18n.T("校验")
+			}
+			onProgress, finish := progressPrinter(action)
+			defer finish()
+
+			// 某个文件失败时继续处理其余文件，最后汇总；密码错误时所有加密的文件都会失败，直接停止
+			failed := 0
+			options := fsutils.DecompressOptions{
+				Format:        format,
+				Progress:      onProgress,
+				Password:      password,
+				Include:       include,
+				PreserveAttrs: preserve,
+				Manifest:      manifest,
+				Concurrency:   parallel,
+				OnError: func(err *fsutils.EntryError) error {
+					if errors.Is(err, fsutils.ErrWrongPassword) || errors.Is(err, fsutils.ErrPasswordRequired) {
+						return err
+					}
+					failed++
+					finish()
+					fmt.Fprint(os.Stderr, i18n.Tf("%s失败: %v\n", verb, err))
+					return nil
+				},
+			}
+
+			var result fsutils.VerifyResult
+			var extracted fsutils.DecompressResult
+			switch {
+			case mode == "verify":
+				result, err = fsutils.VerifyArchiveContext(ctx, src, options)
+			case src == "-":
+				extracted, err = fsutils.DecompressFromContext(ctx, os.Stdin, args[1], options)
+			default:
+				extracted, err = fsutils.DecompressContext(ctx, src, args[1], options)
+			}
+			// 路径指向目标目录之外的文件可能是恶意构造的压缩文件，提示用户
+			if len(extracted.Skipped) > 0 {
+				finish()
+				for _, name := range extracted.Skipped {
+					fmt.Fprint(os.Stderr, i18n.Tf("跳过可疑路径: %s\n", name))
+				}
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return i18n.Errorf("%s已取消", action)
+				}
+				return err
+			}
+			if failed > 0 {
+				return i18n.Errorf("%d 个文件%s失败", failed, verb)
+			}
+			if mode == "verify" {
+				fmt.Print(i18n.Tf("校验通过: %d 个文件，解压后 %s\n", result.Files, fsutils.FormatSize(result.Size)))
+			}
+			return nil
 		}
 
+		dst := args[1]
 		// 压缩模式
-		// 获取压缩类型
-		compressionType, _ := cmd.Flags().GetString("type")
 		var format fsutils.CompressFormat
 
 		// 如果指定了压缩类型，使用指定的类型
 		if compressionType != "" {
-			switch strings.ToLower(compressionType) {
-			case "zip":
-				format = fsutils.ZIP
-			case "tar.gz", "tgz":
-				format = fsutils.TARGZ
-			case "tar.bz2", "tbz2":
-				format = fsutils.TARBZ2
-			case "tar.xz", "txz":
-				format = fsutils.TARXZ
-			case "gz":
-				format = fsutils.GZ
-			case "bz2":
-				format = fsutils.BZ2
-			case "xz":
-				format = fsutils.XZ
-			case "7z":
-				format = fsutils.SEVENZIP
-			default:
-				return fmt.Errorf("不支持的压缩格式: %s", compressionType)
+			var err error
+			if format, err = parseFormat(compressionType); err != nil {
+				return err
 			}
 		} else {
 			// 否则根据目标文件扩展名自动检测
@@ -89,46 +246,219 @@ var compressCmd = &cobra.Command{
 				format = fsutils.TARBZ2
 			case strings.HasSuffix(dst, ".tar.xz"), strings.HasSuffix(dst, ".txz"):
 				format = fsutils.TARXZ
+			case strings.HasSuffix(dst, ".tar.zst"), strings.HasSuffix(dst, ".tzst"):
+				format = fsutils.TARZST
+			case strings.HasSuffix(dst, ".tar.lz4"):
+				format = fsutils.TARLZ4
+			case strings.HasSuffix(dst, ".tar.br"):
+				format = fsutils.TARBR
 			case strings.HasSuffix(dst, ".gz"):
 				format = fsutils.GZ
 			case strings.HasSuffix(dst, ".bz2"):
 				format = fsutils.BZ2
 			case strings.HasSuffix(dst, ".xz"):
 				format = fsutils.XZ
+			case strings.HasSuffix(dst, ".zst"):
+				format = fsutils.ZSTD
+			case strings.HasSuffix(dst, ".lz4"):
+				format = fsutils.LZ4
+			case strings.HasSuffix(dst, ".br"):
+				format = fsutils.BR
 			case strings.HasSuffix(dst, ".7z"):
 				format = fsutils.SEVENZIP
 			default:
-				return fmt.Errorf("无法从文件扩展名识别压缩格式，请使用 --type 选项指定压缩格式")
+				return errs.InvalidInput("无法从文件扩展名识别压缩格式，请使用 --type 选项指定压缩格式")
 			}
 		}
 
+		// 压缩数据写入标准输出，不能是终端
+		if dst == "-" && isatty.IsTerminal(os.Stdout.Fd()) {
+			return errs.InvalidInput("不能将压缩数据写入终端，请重定向标准输出")
+		}
+
 		// 检查源路径是否为目录
 		srcInfo, err := os.Stat(src)
 		if err != nil {
-			return fmt.Errorf("无法访问源文件/目录: %v", err)
+			return errs.Wrap(err, "无法访问源文件/目录: %v", err)
 		}
 
 		// 检查单文件压缩格式是否用于目录
-		if srcInfo.IsDir() && (format == fsutils.GZ || format == fsutils.BZ2 || format == fsutils.XZ) {
-			return fmt.Errorf("%s 格式不支持压缩目录，请使用 zip、tar.gz、tar.bz2、tar.xz", format)
+		if srcInfo.IsDir() && (format == fsutils.GZ || format == fsutils.BZ2 || format == fsutils.XZ || format == fsutils.ZSTD || format == fsutils.LZ4 || format == fsutils.BR) {
+			return errs.InvalidInput("%s 格式不支持压缩目录，请使用 zip、tar.gz、tar.bz2、tar.xz、tar.zst、tar.lz4、tar.br", format)
 		}
 
 		level, _ := cmd.Flags().GetInt("level")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
 
+		include, _ := cmd.Flags().GetStringSlice("include")
+		exclude, _ := cmd.Flags().GetStringSlice("exclude")
+		if (len(include) > 0 || len(exclude) > 0) && !srcInfo.IsDir() {
+			return errs.InvalidInput("--include 和 --exclude 只能用于压缩目录")
+		}
+
+		volumeSize := flagtype.GetSize(cmd.Flags(), "volume-size")
+		if volumeSize > 0 && dst == "-" {
+			return errs.InvalidInput("分卷压缩不能写入标准输出")
+		}
+
+		onProgress, finish := progressPrinter(i18n.T("压缩"))
+		defer finish()
 		options := fsutils.CompressOptions{
-			Format: format,
-			Level:  level,
+			Format:          format,
+			Level:           level,
+			Progress:        onProgress,
+			Parallel:        parallel,
+			Password:        password,
+			IncludePatterns: include,
+			ExcludePatterns: exclude,
+			MinSize:         flagtype.GetSize(cmd.Flags(), "min-size"),
+			MaxSize:         flagtype.GetSize(cmd.Flags(), "max-size"),
+			VolumeSize:      volumeSize,
+		}
+		options.Manifest, _ = cmd.Flags().GetString("manifest")
+		options.Dedupe, _ = cmd.Flags().GetBool("dedupe")
+		if since := flagtype.GetDuration(cmd.Flags(), "since"); since > 0 {
+			options.ModifiedAfter = time.Now().Add(-since)
+		}
+		if before := flagtype.GetDuration(cmd.Flags(), "before"); before > 0 {
+			options.ModifiedBefore = time.Now().Add(-before)
 		}
 
-		return fsutils.Compress(src, dst, options)
+		if dst == "-" {
+			err = fsutils.CompressToContext(ctx, src, os.Stdout, options)
+		} else {
+			err = fsutils.CompressContext(ctx, src, dst, options)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf("压缩已取消")
+			}
+			return err
+		}
+		return nil
 	},
 }
 
+// parseFormat 解析 --type 指定的压缩格式
+func parseFormat(name string) (fsutils.CompressFormat, error) {
+	switch strings.ToLower(name) {
+	case "zip":
+		return fsutils.ZIP, nil
+	case "tar.gz", "tgz":
+		return fsutils.TARGZ, nil
+	case "tar.bz2", "tbz2":
+		return fsutils.TARBZ2, nil
+	case "tar.xz", "txz":
+		return fsutils.TARXZ, nil
+	case "tar.zst", "tzst":
+		return fsutils.TARZST, nil
+	case "tar.lz4":
+		return fsutils.TARLZ4, nil
+	case "tar.br":
+		return fsutils.TARBR, nil
+	case "gz":
+		return fsutils.GZ, nil
+	case "bz2":
+		return fsutils.BZ2, nil
+	case "xz":
+		return fsutils.XZ, nil
+	case "zst", "zstd":
+		return fsutils.ZSTD, nil
+	case "lz4":
+		return fsutils.LZ4, nil
+	case "br", "brotli":
+		return fsutils.BR, nil
+	case "rar":
+		return fsutils.RAR, nil
+	case "7z":
+		return fsutils.SEVENZIP, nil
+	}
+	return "", errs.InvalidInput("不支持的压缩格式: %s", name)
+}
+
+// readPassword 读取 --password 指定的密码，支持 @文件路径 和 env:变量名
+func readPassword(cmd *cobra.Command) (string, error) {
+	if !cmd.Flags().Changed("password") {
+		return "", nil
+	}
+	spec, _ := cmd.Flags().GetString("password")
+	password, err := digest.ParseKey(spec)
+	if err != nil {
+		return "", err
+	}
+	if len(password) == 0 {
+		return "", errs.InvalidInput("密码不能为空")
+	}
+	return string(password), nil
+}
+
+// progressPrinter 返回在标准错误上显示进度的回调和清除进度行的函数，
+// 标准错误不是终端时回调为nil；总大小不超过 progressThreshold 时不显示
+func progressPrinter(action string) (fsutils.ProgressFunc, func()) {
+	if !isatty.IsTerminal(os.Stderr.Fd()) {
+		return nil, func() {}
+	}
+	var last time.Time
+	shown := false
+	onProgress := func(current, total int64, path string) {
+		if total < progressThreshold || (time.Since(last) < 200*time.Millisecond && current < total) {
+			return
+		}
+		last = time.Now()
+		shown = true
+		if runes := []rune(path); len(runes) > 40 {
+			path = "..." + string(runes[len(runes)-37:])
+		}
+		fmt.Fprintf(os.Stderr, "\r\033[K%s: %5.1f%% (%s / %s) %s", action, float64(current)*100/float64(total),
+			fsutils.FormatSize(current), fsutils.FormatSize(total), path)
+	}
+	finish := func() {
+		if shown {
+			fmt.Fprint(os.Stderr, "\r\033[K")
+		}
+	}
+	return onProgress, finish
+}
+
 func init() {
-	compressCmd.Flags().StringP("mode", "m", "compress", "操作模式（compress 或 decompress）(解压缩额外支持rar、7z)")
-	compressCmd.Flags().StringP("type", "t", "", `压缩格式（可选值：zip, tar.gz, tar.bz2, tar.xz, gz, bz2, xz）
-如果不指定，将根据目标文件扩展名自动检测`)
+	compressCmd.Flags().StringP("mode", "m", "compress", "操作模式（compress、decompress 或 verify）(解压缩额外支持rar、7z)")
+	compressCmd.Flags().StringP("type", "t", "", `压缩格式（可选值：zip, tar.gz, tar.bz2, tar.xz, tar.zst, tar.lz4, tar.br, gz, bz2, xz, zst, lz4, br）
+压缩时如果不指定，将根据目标文件扩展名自动检测，写入标准输出时必须指定；解压缩时根据文件内容自动识别`)
 	compressCmd.Flags().IntP("level", "l", 6, "压缩级别（1-9）")
+	compressCmd.Flags().String("password", "", "压缩时加密zip（AES-256），解压缩时解密zip、rar、7z的密码，支持 @文件路径、env:变量名 或原文")
+	compressCmd.Flags().StringSlice("include", nil, "只压缩或解压匹配的文件（支持通配符和 **），可以多次指定")
+	compressCmd.Flags().StringSlice("exclude", nil, "压缩时跳过匹配的文件和目录（支持通配符和 **），可以多次指定")
+	flagtype.Size(compressCmd.Flags(), "min-size", 0, units.Byte, "压缩时只包含不小于该大小的文件，如 1K、10M")
+	flagtype.Size(compressCmd.Flags(), "max-size", 0, units.Byte, "压缩时只包含不大于该大小的文件，如 100M、1G")
+	flagtype.Duration(compressCmd.Flags(), "since", 0, units.Day, "压缩时只包含最近修改的文件，如 24h、7d，纯数字表示天")
+	flagtype.Duration(compressCmd.Flags(), "before", 0, units.Day, "压缩时只包含修改时间早于该时长之前的文件，如 30d，纯数字表示天")
+	flagtype.Size(compressCmd.Flags(), "volume-size", 0, units.Byte, "将zip分卷，每个分卷的大小，如 100M、4G（不小于64K）")
+	compressCmd.Flags().String("manifest", "", "压缩时生成SHA-256校验和清单，解压缩和校验时按清单检查文件内容")
+	compressCmd.Flags().Bool("dedupe", false, "压缩tar时内容相同的文件只保存一次，其余写为硬链接")
+	compressCmd.Flags().BoolP("preserve", "p", false, "解压缩时恢复权限、修改时间和（以root运行时）所有者")
+	compressCmd.Flags().IntP("parallel", "j", 0, "tar.gz和gz并行压缩的线程数或zip并行解压的文件数，-1 表示使用全部CPU核心，默认不并行")
+
+	// 参数补全
+	compressCmd.RegisterFlagCompletionFunc("mode", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"compress\t" + i18n.T("压缩"), "decompress\t" + i18n.T("解压缩"), "verify\t" + i18n.T("校验")},
+			cobra.ShellCompDirectiveNoFileComp
+	})
+	compressCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		formats := []fsutils.CompressFormat{fsutils.ZIP, fsutils.TARGZ, fsutils.TARBZ2, fsutils.TARXZ, fsutils.TARZST, fsutils.TARLZ4, fsutils.TARBR, fsutils.GZ, fsutils.BZ2, fsutils.XZ, fsutils.ZSTD, fsutils.LZ4, fsutils.BR}
+		// 解压缩模式额外支持rar和7z
+		if mode, _ := cmd.Flags().GetString("mode"); mode == "decompress" || mode == "verify" {
+			formats = append(formats, fsutils.RAR, fsutils.SEVENZIP)
+		}
+		completions := make([]string, 0, len(formats))
+		for _, format := range formats {
+			completions = append(completions, string(format))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	FsCmd.AddCommand(compressCmd)
 }
@@ -0,0 +1,112 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"toolbox/pkg/i18n"
+
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/fsutils"
+
+	"github.com/spf13/cobra"
+)
+
+// duCmd 表示 du 命令
+var duCmd = &cobra.Command{
+	Use:   "du [目录路径]",
+	Short: "分析目录的磁盘占用",
+	Long: `统计目录中每个子目录累计的大小和文件数量，列出占用空间最大的目录和文件。
+
+多个目录并行读取，大目录也能较快完成。默认与 du 相同按实际占用的磁盘空间统计（包括目录本身），
+--apparent-size 按文件大小统计；不跟随符号链接，同一文件的多个硬链接只计算一次。
+--depth 只列出不超过该深度的目录（如 1 表示只比较根目录的直接子目录），大小仍包括更深的文件。
+
+使用全局选项 --output json 输出完整的统计结果，便于导入监控面板。
+
+示例:
+  %[1]s fs du                                  # 分析当前目录
+  %[1]s fs du /var --top 20                    # 列出最大的20个目录和文件
+  %[1]s fs du ~ --depth 1                      # 只比较主目录下的各个子目录
+  %[1]s fs du . --exclude .git -e node_modules # 排除目录
+  %[1]s fs du /data --output json              # 以JSON格式输出`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) > 0 {
+			root = args[0]
+		}
+		top, _ := cmd.Flags().GetInt("top")
+		depth, _ := cmd.Flags().GetInt("depth")
+		exclude, _ := cmd.Flags().GetStringSlice("exclude")
+		apparent, _ := cmd.Flags().GetBool("apparent-size")
+		threads, _ := cmd.Flags().GetInt("threads")
+		if top < 0 || depth < 0 {
+			return i18n.Errorf("--top 和 --depth 不能为负数")
+		}
+
+		options := fsutils.UsageOptions{
+			Top:      top,
+			MaxDepth: depth,
+			Exclude:  exclude,
+			Apparent: apparent,
+			Threads:  threads,
+			OnError: func(path string, err error) {
+				fmt.Fprint(os.Stderr, i18n.Tf("警告: %s: %v\n", path, err))
+			},
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		report, err := fsutils.AnalyzeUsageContext(ctx, root, options)
+		if err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf("分析已取消")
+			}
+			return err
+		}
+
+		return output.Render(cmd, report, func() {
+			fmt.Print(i18n.Tf("%s: %s，%d 个文件，%d 个目录\n", report.Root, fsutils.FormatSize(report.Size), report.Files, report.Dirs))
+			if len(report.TopDirs) > 0 {
+				fmt.Println(i18n.T("\n最大的目录:"))
+				table := output.NewTable(os.Stdout, []string{i18n.T("大小"), i18n.T("占比"), i18n.T("文件数"), i18n.T("目录")})
+				for _, entry := range report.TopDirs {
+					table.Append([]string{fsutils.FormatSize(entry.Size), usageShare(entry.Size, report.Size), strconv.Itoa(entry.Files), entry.Path})
+				}
+				table.Render()
+			}
+			if len(report.TopFiles) > 0 {
+				fmt.Println(i18n.T("\n最大的文件:"))
+				table := output.NewTable(os.Stdout, []string{i18n.T("大小"), i18n.T("占比"), i18n.T("文件")})
+				for _, entry := range report.TopFiles {
+					table.Append([]string{fsutils.FormatSize(entry.Size), usageShare(entry.Size, report.Size), entry.Path})
+				}
+				table.Render()
+			}
+			if report.Errors > 0 {
+				fmt.Print(i18n.Tf("\n%d 个目录或文件无法读取，未计入统计\n", report.Errors))
+			}
+		})
+	},
+}
+
+// usageShare 返回占总大小的百分比
+func usageShare(size, total int64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(size)*100/float64(total))
+}
+
+func init() {
+	FsCmd.AddCommand(duCmd)
+
+	duCmd.Flags().IntP("top", "n", 10, "列出最大的目录和文件各多少个")
+	duCmd.Flags().IntP("depth", "d", 0, "只列出不超过该深度的目录，0表示不限制")
+	duCmd.Flags().StringSliceP("exclude", "e", nil, "排除匹配的文件和目录（支持通配符，可多次使用）")
+	duCmd.Flags().BoolP("apparent-size", "b", false, "按文件大小统计，而不是实际占用的磁盘空间")
+	duCmd.Flags().IntP("threads", "t", 0, "同时读取的目录数量（默认为CPU核心数的2倍）")
+}
@@ -0,0 +1,154 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"toolbox/pkg/i18n"
+
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/units"
+
+	"github.com/spf13/cobra"
+)
+
+// dupesCmd 表示 dupes 命令
+var dupesCmd = &cobra.Command{
+	Use:   "dupes [目录路径]",
+	Short: "查找内容相同的重复文件",
+	Long: `查找目录中内容相同的文件，报告每组重复文件和浪费的空间。
+
+先按文件大小分组，大小相同的文件比较开头4KB的校验和，仍然相同的才读取整个文件计算SHA-256，
+大目录中也只需要读取少量文件。空文件和已经互为硬链接的文件不算重复。
+
+--link 将每组中除第一个（按路径排序）以外的文件替换为指向第一个文件的硬链接，释放空间但保留所有路径，
+文件需要在同一文件系统中；--delete 删除除第一个以外的文件。这两个选项都可以用 --dry-run 预览。
+硬链接后修改其中一个文件会影响组中的所有文件。
+
+示例:
+  %[1]s fs dupes ~/Downloads                     # 查找重复文件
+  %[1]s fs dupes . --name "*.jpg" --min-size 1M   # 只比较不小于1MB的jpg文件
+  %[1]s fs dupes build --exclude node_modules     # 排除目录
+  %[1]s fs dupes photos --delete --dry-run        # 预览将要删除的重复文件
+  %[1]s fs dupes build --link                     # 将重复文件替换为硬链接
+  %[1]s fs dupes . --output json                  # 以JSON格式输出`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) > 0 {
+			root = args[0]
+		}
+		link, _ := cmd.Flags().GetBool("link")
+		remove, _ := cmd.Flags().GetBool("delete")
+		if link && remove {
+			return errs.InvalidInput("--link 和 --delete 不能同时使用")
+		}
+
+		name, _ := cmd.Flags().GetString("name")
+		exclude, _ := cmd.Flags().GetStringSlice("exclude")
+		options := fsutils.FindOptions{
+			Name:        name,
+			ExcludeDirs: exclude,
+			MinSize:     flagtype.GetSize(cmd.Flags(), "min-size"),
+			OnError: func(path string, err error) {
+				fmt.Fprint(os.Stderr, i18n.Tf("警告: %s: %v\n", path, err))
+			},
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		sets, err := fsutils.FindDuplicatesContext(ctx, root, options)
+		if err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf("查找已取消")
+			}
+			return err
+		}
+
+		if link || remove {
+			return resolveDuplicates(cmd, sets, link)
+		}
+
+		entries := make([]dupeEntry, 0, len(sets))
+		var wasted int64
+		for _, set := range sets {
+			entries = append(entries, dupeEntry{Size: set.Size, Wasted: set.Wasted(), Paths: set.Paths})
+			wasted += set.Wasted()
+		}
+		return output.Render(cmd, entries, func() {
+			for _, set := range sets {
+				fmt.Print(i18n.Tf("%d 个相同的文件，每个 %s，浪费 %s:\n", len(set.Paths), fsutils.FormatSize(set.Size), fsutils.FormatSize(set.Wasted())))
+				for _, path := range set.Paths {
+					fmt.Printf("  %s\n", path)
+				}
+				fmt.Println()
+			}
+			fmt.Print(i18n.Tf("共 %d 组重复文件，浪费 %s\n", len(sets), fsutils.FormatSize(wasted)))
+		})
+	},
+}
+
+// dupeEntry dupes 命令的结构化输出
+type dupeEntry struct {
+	Size   int64    `json:"size"`
+	Wasted int64    `json:"wasted"`
+	Paths  []string `json:"paths"`
+}
+
+// resolveDuplicates 保留每组的第一个文件，将其余文件替换为硬链接或删除；预演模式下只列出操作
+func resolveDuplicates(cmd *cobra.Command, sets []fsutils.DuplicateSet, link bool) error {
+	plan := &dryrun.Plan{}
+	var freed int64
+	failed := 0
+	for _, set := range sets {
+		keep := set.Paths[0]
+		for _, dup := range set.Paths[1:] {
+			if dryrun.Enabled(cmd) {
+				if link {
+					plan.Addf(dryrun.ActionModify, dup, "替换为指向 %s 的硬链接", keep)
+				} else {
+					plan.Addf(dryrun.ActionDelete, dup, "与 %s 相同", keep)
+				}
+				continue
+			}
+
+			var err error
+			if link {
+				err = fsutils.LinkDuplicate(keep, dup)
+			} else {
+				err = os.Remove(dup)
+			}
+			if err != nil {
+				failed++
+				fmt.Fprint(os.Stderr, i18n.Tf("处理 %s 失败: %v\n", dup, err))
+				continue
+			}
+			freed += set.Size
+		}
+	}
+
+	if dryrun.Enabled(cmd) {
+		return dryrun.Render(cmd, plan)
+	}
+	fmt.Print(i18n.Tf("已释放 %s\n", fsutils.FormatSize(freed)))
+	if failed > 0 {
+		return i18n.Errorf("%d 个文件处理失败", failed)
+	}
+	return nil
+}
+
+func init() {
+	FsCmd.AddCommand(dupesCmd)
+
+	dupesCmd.Flags().StringP("name", "n", "", "只比较文件名匹配的文件（支持通配符）")
+	dupesCmd.Flags().StringSliceP("exclude", "e", nil, "排除的目录（可多次使用）")
+	flagtype.Size(dupesCmd.Flags(), "min-size", 0, units.Byte, "只比较不小于该大小的文件，如 1M")
+	dupesCmd.Flags().Bool("link", false, "将重复的文件替换为指向第一个文件的硬链接")
+	dupesCmd.Flags().Bool("delete", false, "删除每组中除第一个以外的重复文件")
+	dryrun.AddFlag(dupesCmd)
+}
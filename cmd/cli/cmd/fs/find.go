@@ -1,13 +1,28 @@
 package fs
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
+	"toolbox/pkg/i18n"
 
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/host"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
 	"toolbox/pkg/fsutils"
+	"toolbox/pkg/remote"
+	"toolbox/pkg/units"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -17,18 +32,68 @@ var findCmd = &cobra.Command{
 	Short: "搜索文件和目录",
 	Long: `在指定目录中搜索文件和目录，支持多种搜索条件。
 
+--content 和 --content-regex 按文件内容筛选，只保留包含指定字符串或匹配正则表达式的普通文件，
+其他条件都满足的文件才会读取，一次遍历就完成 find 和 grep。正则表达式中的 ^ 和 $ 匹配每行的开头和结尾。
+
+--gitignore 按各级目录中的 .gitignore 和 .ignore 文件跳过被忽略的文件和目录（如 node_modules、构建产物），
+同时跳过 .git 目录；在git仓库的子目录中搜索时，上层目录的忽略规则同样生效。--ignore-file 指定额外读取的
+忽略文件名（如 .fdignore），语法与 .gitignore 相同。
+
+--json 边搜索边输出每个结果的路径、类型、大小、权限、修改时间和深度，每行一个JSON对象，可以直接交给 jq 处理；
+--csv 输出带表头的CSV，便于导入表格。全局的 --output json 收集全部结果后输出一个JSON数组。
+
+--template 按 Go 模板输出每个结果，可用的字段有 .Path、.Name、.Type、.Size、.Mode、.ModTime、.Depth，
+{{size .Size}} 输出易读的大小，模板中的 \t、\n 和 \0 分别表示制表符、换行和NUL。--print0 以NUL而不是换行
+分隔每个结果，文件名中含有空格或换行时也能交给 xargs -0 安全处理。
+
+--sort 按 name（文件名）、path、size 或 mtime 排序结果，--desc 改为降序；--limit 只保留前N个结果。
+排序时需要找到全部文件后才开始输出，不排序时找到N个结果后立即停止搜索。
+
+--watch 持续监视目录，输出之后新建或修改的匹配文件（启动前已存在的文件不输出），按 Ctrl+C 结束。
+新建的子目录自动加入监视，同一文件的连续写入只输出一次；可以与 --json、--template、--exec 等一起使用，
+例如实时查看构建输出目录中生成的文件。
+
+--exec 对每个匹配的文件执行一次命令，命令中的 {} 替换为文件路径（已按shell规则加引号），通过 sh -c
+（Windows 上为 cmd /C）执行，可以使用管道和重定向。命令失败时报告并继续处理其余文件；
+命令删除了匹配的目录时不再进入该目录。指定 --exec 时不输出匹配的路径。
+
+--delete 删除匹配的文件和目录：目录在其中的文件处理完之后删除，只能删除空目录，起始目录本身不会删除。
+与 find -delete 不同，是否匹配在删除之前判断，因此 --empty --delete 只删除原本就为空的文件和目录，
+删除其中的内容后才变为空的上层目录不会删除，需要时可以再执行一次。建议先用 --delete --dry-run 预览
+将要删除的文件。没有 --yes 时在终端中先显示将要删除的数量并要求确认，不在终端中运行（如脚本中）时拒绝删除。
+
 示例:
   %[1]s fs find .                         # 列出当前目录下的所有文件
   %[1]s fs find /path -name "*.go"        # 搜索Go源文件
   %[1]s fs find . -type f                 # 只搜索普通文件
   %[1]s fs find . -type d                 # 只搜索目录
-  %[1]s fs find . -size +1M              # 搜索大于1MB的文件
-  %[1]s fs find . -mtime -7              # 搜索7天内修改的文件
+  %[1]s fs find . --minsize 1.5M         # 搜索不小于1.5MB的文件
+  %[1]s fs find . -m -7                  # 搜索7天内修改的文件
+  %[1]s fs find . -m -2h                 # 搜索2小时内修改的文件
   %[1]s fs find . -regex ".*\\.txt$"     # 使用正则表达式搜索txt文件
   %[1]s fs find . -maxdepth 2            # 最大搜索深度为2层
   %[1]s fs find . -exclude "node_modules" # 排除node_modules目录
-  %[1]s fs find . -include "src,lib"     # 只在src和lib目录中搜索`,
-	Run: func(cmd *cobra.Command, args []string) {
+  %[1]s fs find . -include "src,lib"     # 只在src和lib目录中搜索
+  %[1]s fs find . --gitignore -name "*.js"  # 跳过 .gitignore 忽略的文件
+  %[1]s fs find . --ignore-file .fdignore  # 按自定义的忽略文件跳过文件
+  %[1]s fs find . -type f --json | jq -r 'select(.size > 1048576) | .path'  # 输出JSON记录交给jq筛选
+  %[1]s fs find . -type f --csv > files.csv  # 导出为CSV
+  %[1]s fs find . -name "*.log" --print0 | xargs -0 rm  # 文件名含有空格时也能正确处理
+  %[1]s fs find . -type f --template "{{.Path}}\t{{.Size}}"  # 按模板输出路径和大小
+  %[1]s fs find /var -type f --sort size --desc --limit 20  # 最大的20个文件
+  %[1]s fs find . -type f --sort mtime --desc --limit 10   # 最近修改的10个文件
+  %[1]s fs find build -name "*.o" --watch  # 监视构建输出目录中新生成的文件
+  %[1]s fs find logs -name "*.log" --watch --exec "gzip -9 {}"  # 压缩新写入完成的日志
+  %[1]s fs find . --empty                # 搜索空文件和空目录
+  %[1]s fs find . --empty -type d --delete --yes  # 删除空目录，不要求确认
+  %[1]s fs find . -name "*.go" --content "TODO"               # 搜索包含TODO的Go源文件
+  %[1]s fs find /etc --content-regex "^\s*PermitRootLogin\s+yes"  # 按正则表达式搜索文件内容
+  %[1]s fs find /var/log -name "*.gz" --host web1  # 在远程主机上搜索（需要GNU find）
+  %[1]s fs find . -name "*.sh" --exec "chmod +x {}"  # 对每个匹配的文件执行命令
+  %[1]s fs find . -name "*.log" --exec "gzip -9"     # 没有 {} 时文件路径追加在命令末尾
+  %[1]s fs find . -name "*.tmp" --delete --dry-run   # 预览将要删除的文件
+  %[1]s fs find . -name "*.tmp" --delete             # 确认后删除匹配的文件`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 获取搜索根目录
 		root := "."
 		if len(args) > 0 {
@@ -38,106 +103,371 @@ var findCmd = &cobra.Command{
 		// 获取命令行选项
 		name, _ := cmd.Flags().GetString("name")
 		fileType, _ := cmd.Flags().GetString("type")
-		minSize, _ := cmd.Flags().GetString("minsize")
-		maxSize, _ := cmd.Flags().GetString("maxsize")
 		minDepth, _ := cmd.Flags().GetInt("mindepth")
 		maxDepth, _ := cmd.Flags().GetInt("maxdepth")
-		mtime, _ := cmd.Flags().GetInt("mtime")
+		mtime := flagtype.GetDuration(cmd.Flags(), "mtime")
 		regex, _ := cmd.Flags().GetString("regex")
 		excludeDirs, _ := cmd.Flags().GetStringSlice("exclude")
 		includeDirs, _ := cmd.Flags().GetStringSlice("include")
 		followSymlinks, _ := cmd.Flags().GetBool("follow")
+		empty, _ := cmd.Flags().GetBool("empty")
+		gitignore, _ := cmd.Flags().GetBool("gitignore")
+		jsonLines, _ := cmd.Flags().GetBool("json")
+		csvOutput, _ := cmd.Flags().GetBool("csv")
+		tmpl, _ := cmd.Flags().GetString("template")
+		print0, _ := cmd.Flags().GetBool("print0")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		desc, _ := cmd.Flags().GetBool("desc")
+		limit, _ := cmd.Flags().GetInt("limit")
+		ignoreFiles, _ := cmd.Flags().GetStringSlice("ignore-file")
+		content, _ := cmd.Flags().GetString("content")
+		contentRegex, _ := cmd.Flags().GetString("content-regex")
+		command, _ := cmd.Flags().GetString("exec")
+		deleteMatched, _ := cmd.Flags().GetBool("delete")
+		yes, _ := cmd.Flags().GetBool("yes")
+		watch, _ := cmd.Flags().GetBool("watch")
 
 		// 创建搜索选项
 		options := fsutils.FindOptions{
-			Name:           name,
-			Type:           fileType,
-			MinDepth:       minDepth,
-			MaxDepth:       maxDepth,
-			Regex:          regex,
-			ExcludeDirs:    excludeDirs,
-			IncludeDirs:    includeDirs,
-			FollowSymlinks: followSymlinks,
-		}
-
-		// 处理文件大小选项
-		if minSize != "" {
-			size, err := parseSize(minSize)
-			if err != nil {
-				fmt.Printf("错误: 无效的最小文件大小: %v\n", err)
-				os.Exit(1)
-			}
-			options.MinSize = size
-		}
-		if maxSize != "" {
-			size, err := parseSize(maxSize)
-			if err != nil {
-				fmt.Printf("错误: 无效的最大文件大小: %v\n", err)
-				os.Exit(1)
-			}
-			options.MaxSize = size
+			Name:             name,
+			Type:             fileType,
+			MinDepth:         minDepth,
+			MaxDepth:         maxDepth,
+			Regex:            regex,
+			ExcludeDirs:      excludeDirs,
+			IncludeDirs:      includeDirs,
+			FollowSymlinks:   followSymlinks,
+			Empty:            empty,
+			RespectGitignore: gitignore,
+			Template:         tmpl,
+			Print0:           print0,
+			SortBy:           sortBy,
+			SortDesc:         desc,
+			Limit:            limit,
+			IgnoreFiles:      ignoreFiles,
+			Content:          content,
+			ContentRegex:     contentRegex,
+			MinSize:          flagtype.GetSize(cmd.Flags(), "minsize"),
+			MaxSize:          flagtype.GetSize(cmd.Flags(), "maxsize"),
 		}
 
 		// 处理修改时间
 		if mtime != 0 {
 			if mtime > 0 {
-				options.ModifiedBefore = time.Now().AddDate(0, 0, -mtime)
+				options.ModifiedBefore = time.Now().Add(-mtime)
 			} else {
-				options.ModifiedAfter = time.Now().AddDate(0, 0, mtime)
+				options.ModifiedAfter = time.Now().Add(mtime)
 			}
 		}
 
-		// 执行搜索
-		err := fsutils.ExecuteFind(root, os.Stdout, options)
-		if err != nil {
-			fmt.Printf("错误: %v\n", err)
-			os.Exit(1)
+		if dryrun.Enabled(cmd) {
+			if !deleteMatched || command != "" {
+				return errs.InvalidInput("--dry-run 只能用于预览 --delete，不能与 --exec 一起使用")
+			}
+			if host.Enabled(cmd) {
+				return errs.InvalidInput("--delete 不能用于远程主机")
+			}
+			return findDeletePlan(cmd, root, options)
+		}
+
+		if watch {
+			if host.Enabled(cmd) || output.IsStructured(cmd) || deleteMatched {
+				return errs.InvalidInput("--watch 不能用于远程主机、--output 和 --delete，需要JSON记录时可以使用 --json")
+			}
+			return findWatch(root, options, command, jsonLines, csvOutput)
+		}
+
+		if command != "" || deleteMatched {
+			if host.Enabled(cmd) || output.IsStructured(cmd) {
+				return errs.InvalidInput("--exec 和 --delete 不能用于远程主机或结构化输出")
+			}
+			if deleteMatched && !yes {
+				if err := confirmFindDelete(root, options); err != nil {
+					return err
+				}
+			}
+			failed := 0
+			if command != "" {
+				options.Action = findExec(command, &failed)
+			}
+			options.Delete = deleteMatched
+			if err := fsutils.ExecuteFind(root, os.Stderr, options); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return i18n.Errorf("%d 个文件执行命令失败", failed)
+			}
+			return nil
 		}
+
+		if jsonLines || csvOutput {
+			if jsonLines && csvOutput {
+				return errs.InvalidInput("--json 和 --csv 不能同时使用")
+			}
+			if host.Enabled(cmd) || output.IsStructured(cmd) {
+				return errs.InvalidInput("--json 和 --csv 不能用于远程主机，也不能与 --output 一起使用")
+			}
+			options.OutputFormat = fsutils.FindOutputJSON
+			if csvOutput {
+				options.OutputFormat = fsutils.FindOutputCSV
+			}
+			// 警告写入标准错误，避免混入输出的记录
+			options.OnError = func(path string, err error) {
+				fmt.Fprint(os.Stderr, i18n.Tf("警告: %s: %v\n", path, err))
+			}
+			return fsutils.ExecuteFind(root, os.Stdout, options)
+		}
+
+		if tmpl != "" || print0 {
+			if host.Enabled(cmd) || output.IsStructured(cmd) {
+				return errs.InvalidInput("--template 和 --print0 不能用于远程主机，也不能与 --output 一起使用")
+			}
+			options.OnError = func(path string, err error) {
+				fmt.Fprint(os.Stderr, i18n.Tf("警告: %s: %v\n", path, err))
+			}
+		}
+
+		if host.Enabled(cmd) {
+			return findRemote(cmd, root, options)
+		}
+
+		// 结构化输出时收集结果后统一输出，警告信息写入标准错误
+		if output.IsStructured(cmd) {
+			results, err := fsutils.FindFiles(root, os.Stderr, options)
+			if err != nil {
+				return err
+			}
+			entries := make([]fsutils.FindRecord, 0, len(results))
+			for _, result := range results {
+				entries = append(entries, fsutils.NewFindRecord(result))
+			}
+			return output.Render(cmd, entries, nil)
+		}
+
+		// 执行搜索
+		return fsutils.ExecuteFind(root, os.Stdout, options)
 	},
 }
 
+// findWatch 监视新建和修改的匹配文件，按 --json、--csv、--template 等输出或执行 --exec 的命令，按 Ctrl+C 结束
+func findWatch(root string, options fsutils.FindOptions, command string, jsonLines, csvOutput bool) error {
+	if jsonLines && csvOutput {
+		return errs.InvalidInput("--json 和 --csv 不能同时使用")
+	}
+	switch {
+	case jsonLines:
+		options.OutputFormat = fsutils.FindOutputJSON
+	case csvOutput:
+		options.OutputFormat = fsutils.FindOutputCSV
+	}
+	writer, err := fsutils.NewFindWriter(os.Stdout, options)
+	if err != nil {
+		return err
+	}
+	options.OnError = func(path string, err error) {
+		fmt.Fprint(os.Stderr, i18n.Tf("警告: %s: %v\n", path, err))
+	}
+	failed := 0
+	if command != "" {
+		options.Action = findExec(command, &failed)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err = fsutils.WatchFindContext(ctx, root, options, func(result fsutils.FindResult) error {
+		if command != "" {
+			return nil
+		}
+		return writer.Write(result)
+	})
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return i18n.Errorf("%d 个文件执行命令失败", failed)
+	}
+	return nil
+}
+
+// findExec 返回对每个匹配的文件执行命令的 Action：命令中的 {} 替换为加了引号的文件路径，没有 {} 时追加在末尾。
+// 命令失败时在标准错误报告并累计到failed，继续处理其余文件
+func findExec(command string, failed *int) func(fsutils.FindResult) error {
+	return func(result fsutils.FindResult) error {
+		quoted := quoteShellArg(result.Path)
+		line := command + " " + quoted
+		if strings.Contains(command, "{}") {
+			line = strings.ReplaceAll(command, "{}", quoted)
+		}
+
+		var c *exec.Cmd
+		if runtime.GOOS == "windows" {
+			c = exec.Command("cmd", "/C", line)
+		} else {
+			c = exec.Command("sh", "-c", line)
+		}
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := c.Run(); err != nil {
+			*failed++
+			fmt.Fprint(os.Stderr, i18n.Tf("执行失败: %s: %v\n", result.Path, err))
+		}
+
+		// 命令删除或移动了目录时不再进入该目录
+		if result.FileInfo.IsDir() {
+			if _, err := os.Lstat(result.Path); os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	}
+}
+
+// confirmFindDelete 在没有 --yes 时确认 --delete：终端中列出将要删除的数量并询问，
+// 不在终端中运行时拒绝删除，避免脚本中误删
+func confirmFindDelete(root string, options fsutils.FindOptions) error {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return errs.InvalidInput("--delete 会删除匹配的文件，请先用 --dry-run 预览，确认后加上 --yes 执行")
+	}
+	results, err := fsutils.FindFiles(root, os.Stderr, options)
+	if err != nil {
+		return err
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	// 与实际删除时一样不计起始目录
+	count := 0
+	for _, result := range results {
+		if result.Path != absRoot {
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	fmt.Fprint(os.Stderr, i18n.Tf("将删除 %d 个匹配的文件和目录（可以用 --dry-run 查看列表），是否继续？[y/N]: ", count))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		return i18n.Errorf("已取消，没有删除任何文件")
+	}
+	return nil
+}
+
+// findDeletePlan 预演 --delete，列出将要删除的文件和目录
+func findDeletePlan(cmd *cobra.Command, root string, options fsutils.FindOptions) error {
+	results, err := fsutils.FindFiles(root, os.Stderr, options)
+	if err != nil {
+		return err
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	plan := &dryrun.Plan{}
+	for _, result := range results {
+		// 与实际删除时一样跳过起始目录
+		if result.Path == absRoot {
+			continue
+		}
+		if result.FileInfo.IsDir() {
+			plan.Add(dryrun.ActionDelete, result.Path, "目录（删除其中的文件后为空时才会删除）")
+		} else {
+			plan.Add(dryrun.ActionDelete, result.Path, fsutils.FormatSize(result.FileInfo.Size()))
+		}
+	}
+	return dryrun.Render(cmd, plan)
+}
+
+// quoteShellArg 按 sh 或 cmd 的规则给参数加引号
+func quoteShellArg(arg string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + arg + `"`
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// hostFindEntry 远程执行时带有主机名的搜索结果
+type hostFindEntry struct {
+	Host string `json:"host"`
+	fsutils.FindRecord
+}
+
+// findRemote 在远程主机上搜索文件，多台主机时路径前加上主机名
+func findRemote(cmd *cobra.Command, root string, options fsutils.FindOptions) error {
+	targets, err := host.Targets(cmd)
+	if err != nil {
+		return err
+	}
+
+	found := make([][]fsutils.FindResult, len(targets))
+	results := remote.ForEach(targets, func(i int, client *remote.Client) error {
+		list, err := remote.Find(client, root, options)
+		found[i] = list
+		return err
+	})
+
+	var entries []hostFindEntry
+	for i, list := range found {
+		for _, result := range list {
+			entries = append(entries, hostFindEntry{Host: targets[i].Label(), FindRecord: fsutils.NewFindRecord(result)})
+		}
+	}
+	err = output.Render(cmd, entries, func() {
+		for _, entry := range entries {
+			if len(targets) > 1 {
+				fmt.Printf("%s:%s\n", entry.Host, entry.Path)
+			} else {
+				fmt.Println(entry.Path)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return host.ReportErrors(targets, results)
+}
+
 func init() {
+	host.MarkSupported(findCmd)
 	FsCmd.AddCommand(findCmd)
 
 	// 添加命令行标志
 	findCmd.Flags().StringP("name", "n", "", "按文件名搜索（支持通配符）")
 	findCmd.Flags().StringP("type", "t", "", "按类型搜索 (f:文件, d:目录, l:符号链接)")
-	findCmd.Flags().StringP("minsize", "", "", "最小文件大小 (例如: 1M, 500K)")
-	findCmd.Flags().StringP("maxsize", "", "", "最大文件大小 (例如: 10M, 1G)")
+	flagtype.Size(findCmd.Flags(), "minsize", 0, units.Byte, "最小文件大小 (例如: 1M, 500K)")
+	flagtype.Size(findCmd.Flags(), "maxsize", 0, units.Byte, "最大文件大小 (例如: 10M, 1G)")
 	findCmd.Flags().IntP("mindepth", "", 0, "最小搜索深度")
 	findCmd.Flags().IntP("maxdepth", "", 0, "最大搜索深度")
-	findCmd.Flags().IntP("mtime", "m", 0, "按修改时间搜索（天数，负数表示之内，正数表示之前）")
+	flagtype.SignedDurationP(findCmd.Flags(), "mtime", "m", 0, units.Day, "按修改时间搜索（如 7、2h，纯数字表示天数，负数表示之内，正数表示之前）")
 	findCmd.Flags().StringP("regex", "r", "", "使用正则表达式匹配文件名")
 	findCmd.Flags().StringSliceP("exclude", "e", nil, "排除的目录（可多次使用）")
 	findCmd.Flags().StringSliceP("include", "i", nil, "只在指定目录中搜索（可多次使用）")
 	findCmd.Flags().BoolP("follow", "L", false, "跟随符号链接")
-}
+	findCmd.Flags().Bool("empty", false, "只搜索空文件和空目录")
+	findCmd.Flags().Bool("json", false, "每行输出一个JSON对象（路径、大小、修改时间、权限等）")
+	findCmd.Flags().Bool("csv", false, "以带表头的CSV格式输出路径、大小、修改时间、权限等")
+	findCmd.Flags().String("template", "", "按 Go 模板输出每个结果，如 \"{{.Path}}\\t{{.Size}}\"")
+	findCmd.Flags().Bool("print0", false, "以NUL而不是换行分隔每个结果（配合 xargs -0）")
+	findCmd.Flags().String("sort", "", "排序方式 (name, path, size, mtime)")
+	findCmd.Flags().Bool("desc", false, "按降序排序")
+	findCmd.Flags().Int("limit", 0, "最多输出的结果数量，0表示全部")
+	findCmd.Flags().BoolP("watch", "w", false, "持续监视并输出新建或修改的匹配文件")
+	findCmd.Flags().Bool("gitignore", false, "跳过 .gitignore 和 .ignore 中忽略的文件")
+	findCmd.Flags().StringSlice("ignore-file", nil, "额外读取的忽略文件名，语法与 .gitignore 相同（可多次使用）")
+	findCmd.Flags().String("content", "", "只保留内容包含该字符串的文件")
+	findCmd.Flags().String("content-regex", "", "只保留内容匹配该正则表达式的文件")
+	findCmd.Flags().Bool("delete", false, "删除匹配的文件和空目录（可以先用 --dry-run 预览）")
+	findCmd.Flags().BoolP("yes", "y", false, "配合 --delete 使用，不要求确认直接删除")
+	dryrun.AddFlag(findCmd)
 
-// parseSize 解析文件大小字符串（如 1K, 2M, 3G）
-func parseSize(sizeStr string) (int64, error) {
-	var size float64
-	var unit string
-	_, err := fmt.Sscanf(sizeStr, "%f%s", &size, &unit)
-	if err != nil {
-		return 0, fmt.Errorf("无效的大小格式")
-	}
-
-	unit = strings.ToUpper(unit)
-	var multiplier float64
-	switch unit {
-	case "K", "KB":
-		multiplier = 1024
-	case "M", "MB":
-		multiplier = 1024 * 1024
-	case "G", "GB":
-		multiplier = 1024 * 1024 * 1024
-	case "T", "TB":
-		multiplier = 1024 * 1024 * 1024 * 1024
-	case "B", "":
-		multiplier = 1
-	default:
-		return 0, fmt.Errorf("未知的单位: %s", unit)
-	}
-
-	return int64(size * multiplier), nil
+	findCmd.RegisterFlagCompletionFunc("sort", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"name\t" + i18n.T("按文件名排序"), "path\t" + i18n.T("按路径排序"), "size\t" + i18n.T("按大小排序"), "mtime\t" + i18n.T("按修改时间排序")},
+			cobra.ShellCompDirectiveNoFileComp
+	})
+	findCmd.Flags().String("exec", "", "对每个匹配的文件执行命令，{} 替换为文件路径（如 \"chmod 644 {}\"）")
 }
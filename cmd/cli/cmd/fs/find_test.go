@@ -0,0 +1,47 @@
+package fs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"toolbox/pkg/errs"
+)
+
+// 不在终端中运行、没有 --yes 时 --delete 拒绝删除，加上 --yes 后才删除
+func TestFindDeleteRequiresConfirmation(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.tmp")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 标准输入换成管道，模拟在脚本中运行
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	FsCmd.SetArgs([]string{"find", dir, "--name", "*.tmp", "--delete"})
+	FsCmd.SilenceUsage = true
+	FsCmd.SilenceErrors = true
+	if err := FsCmd.Execute(); !errors.Is(err, errs.ErrInvalidInput) {
+		t.Fatalf("没有 --yes 时应拒绝删除，返回 %v", err)
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Fatalf("拒绝删除后文件不应被删除: %v", err)
+	}
+
+	FsCmd.SetArgs([]string{"find", dir, "--name", "*.tmp", "--delete", "--yes"})
+	if err := FsCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("加上 --yes 后文件应被删除: %v", err)
+	}
+}
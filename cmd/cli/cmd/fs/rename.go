@@ -0,0 +1,88 @@
+package fs
+
+import (
+	"fmt"
+	"path/filepath"
+	"toolbox/pkg/i18n"
+
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/fsutils"
+
+	"github.com/spf13/cobra"
+)
+
+// renameCmd 表示 rename 命令
+var renameCmd = &cobra.Command{
+	Use:   "rename <模式> <替换> [目录路径]",
+	Short: "按正则表达式批量重命名文件",
+	Long: `将目录中文件名匹配正则表达式的文件重命名，文件名中的所有匹配替换为替换文本。
+
+替换文本中可以使用:
+  $1、${name}   引用捕获组（后面紧跟字母、数字或下划线时用 ${1} 的写法）
+  {n}           序号，按文件名排序依次递增，从 --start 开始
+  {n:3}         补零到3位的序号，如 001
+
+--case 对替换后的整个文件名做大小写转换：upper 全部大写，lower 全部小写，title 每个单词首字母大写。
+默认只重命名文件，--dirs 同时重命名目录；不进入子目录。
+
+重命名前先检查所有新名称：两个文件的新名称相同，或新名称与不参与重命名的文件同名时报告冲突，不修改任何文件。
+互相交换名称的文件也可以正确重命名；中途失败时撤销已完成的重命名。建议先用 --dry-run 预览。
+
+示例:
+  %[1]s fs rename '^IMG_(\d+)\.JPG$' 'photo_$1.jpg' ~/Pictures    # 使用捕获组
+  %[1]s fs rename '.*\.jpg$' 'trip-{n:3}.jpg' . --start 1          # 按顺序编号为 trip-001.jpg …
+  %[1]s fs rename '\.jpeg$' '.jpg' -i                              # 忽略大小写，修改扩展名
+  %[1]s fs rename ' ' '_' docs --case lower --dry-run              # 空格改为下划线并转为小写，只预览
+  %[1]s fs rename '^(\w+)-(\w+)$' '${2}-${1}' . --dirs             # 交换名称的两部分，包括目录`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 2 {
+			dir = args[2]
+		}
+		ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+		dirs, _ := cmd.Flags().GetBool("dirs")
+		caseMode, _ := cmd.Flags().GetString("case")
+		start, _ := cmd.Flags().GetInt("start")
+
+		preview := dryrun.Enabled(cmd)
+		renames, err := fsutils.BatchRename(dir, args[0], args[1], fsutils.RenameOptions{
+			IgnoreCase: ignoreCase,
+			Dirs:       dirs,
+			Case:       caseMode,
+			Start:      start,
+			DryRun:     preview,
+		})
+		if err != nil {
+			return err
+		}
+
+		if preview {
+			plan := &dryrun.Plan{}
+			for _, r := range renames {
+				plan.Addf(dryrun.ActionModify, filepath.Join(dir, r.From), "重命名为 %s", r.To)
+			}
+			return dryrun.Render(cmd, plan)
+		}
+		if renames == nil {
+			renames = []fsutils.Rename{}
+		}
+		return output.Render(cmd, renames, func() {
+			for _, r := range renames {
+				fmt.Printf("%s -> %s\n", r.From, r.To)
+			}
+			fmt.Print(i18n.Tf("已重命名 %d 个文件\n", len(renames)))
+		})
+	},
+}
+
+func init() {
+	FsCmd.AddCommand(renameCmd)
+
+	renameCmd.Flags().BoolP("ignore-case", "i", false, "匹配文件名时忽略大小写")
+	renameCmd.Flags().Bool("dirs", false, "同时重命名目录")
+	renameCmd.Flags().String("case", "", "转换新文件名的大小写：upper、lower、title")
+	renameCmd.Flags().Int("start", 1, "序号 {n} 的起始值")
+	dryrun.AddFlag(renameCmd)
+}
@@ -2,21 +2,40 @@ package fs
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
 	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
 var splitCmd = &cobra.Command{
-	Use:   "split [目录]",
+	Use:   "split [目录或文件]",
 	Short: "将目录打包并分片",
 	Long: `将目录打包并分片，支持以下功能：
 1. 将目录内容打包（支持多种压缩格式）
 2. 将打包后的文件分割成指定大小的分片
-3. 支持多线程并发处理
-4. 支持合并分片还原文件
+3. tar.gz格式支持多线程并行压缩
+4. 支持合并分片还原文件，合并时按分片清单检查每个分片和合并后的文件
+5. 使用 --raw 直接切分已有的文件（如ISO镜像、数据库备份），不打包也不压缩
+
+分片时在输出目录中写入清单（文件名.manifest.json），记录每个分片的大小和SHA-256校验和以及合并后的文件的校验和。
+合并时如果目录中有清单，先检查分片是否齐全、大小是否一致，合并时检查校验和，发现缺少或损坏的分片时
+列出所有问题并删除不完整的输出文件；未指定 --output 时使用清单中的文件名。
+
+打包时压缩的数据直接写入分片，不需要先生成完整的压缩文件，输出目录只需要能容纳分片的空间。
+
+分片和合并被中断后，再次执行同样的命令会从中断处继续：打包分片时重新压缩，但已经写完的分片中
+内容相同的部分不再重写；--raw 切分时跳过已经写完且校验和一致的分片；合并时从最后一个合并完的分片之后继续。
 
 示例:
   # 使用默认设置分片（100M，zip格式）
@@ -28,10 +47,19 @@ var splitCmd = &cobra.Command{
   # 指定输出目录和线程数
   %[1]s fs split ./mydir --output ./chunks --threads 4
 
+  # 直接切分单个文件，输出到 backup.sql_chunks
+  %[1]s fs split backup.sql --raw --size 1G
+
   # 合并分片
-  %[1]s fs split ./mydir_chunks --merge mydir.zip`,
+  %[1]s fs split ./mydir_chunks --merge mydir.zip
+
+  # 预览分片后删除源目录会执行的操作
+  %[1]s fs split ./mydir --remove --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.CheckFileFlag(cmd); err != nil {
+			return err
+		}
 		path := args[0]
 		merge, _ := cmd.Flags().GetBool("merge")
 
@@ -39,48 +67,52 @@ var splitCmd = &cobra.Command{
 			// 合并模式
 			output, _ := cmd.Flags().GetString("output")
 			if output == "" {
-				// 如果未指定输出文件，使用目录名推测
+				// 如果未指定输出文件，使用清单中的文件名，没有清单时使用目录名推测
 				dir := filepath.Clean(path)
-				base := filepath.Base(dir)
-				base = strings.TrimSuffix(base, "_chunks")
-				// 尝试确定文件扩展名
-				files, err := filepath.Glob(filepath.Join(dir, "chunk_001*"))
-				if err == nil && len(files) > 0 {
-					ext := filepath.Ext(files[0])
-					if ext != "" {
-						base += ext
+				if name, ok := fsutils.ChunkedFileName(dir); ok {
+					output = name
+				} else {
+					base := filepath.Base(dir)
+					base = strings.TrimSuffix(base, "_chunks")
+					// 尝试确定文件扩展名
+					files, err := filepath.Glob(filepath.Join(dir, "chunk_001*"))
+					if err == nil && len(files) > 0 {
+						ext := filepath.Ext(files[0])
+						if ext != "" {
+							base += ext
+						}
 					}
+					output = base
 				}
-				output = base
+			}
+
+			if dryrun.Enabled(cmd) {
+				plan := &dryrun.Plan{}
+				plan.Addf(dryrun.ActionCreate, output, "合并 %s 中的分片", path)
+				return dryrun.Render(cmd, plan)
 			}
 
 			if err := fsutils.MergeChunks(path, output, false); err != nil {
-				return fmt.Errorf("合并分片失败: %v", err)
+				return errs.Wrap(err, "合并分片失败: %v", err)
 			}
-			fmt.Printf("分片已合并到：%s\n", output)
+			fmt.Print(i18n.Tf("分片已合并到：%s\n", output))
 			return nil
 		}
 
 		// 分片模式
-		size, _ := cmd.Flags().GetString("size")
 		format, _ := cmd.Flags().GetString("format")
 		threads, _ := cmd.Flags().GetInt("threads")
 		output, _ := cmd.Flags().GetString("output")
 		remove, _ := cmd.Flags().GetBool("remove")
+		raw, _ := cmd.Flags().GetBool("raw")
 
-		// 解析分片大小
-		var chunkSize int64 = 100 * 1024 * 1024 // 默认100M
-		if size != "" {
-			var multiplier int64 = 1024 * 1024 // 默认单位为M
-			size = strings.ToUpper(size)
-			if strings.HasSuffix(size, "G") {
-				multiplier = 1024 * 1024 * 1024
-				size = strings.TrimSuffix(size, "G")
-			} else if strings.HasSuffix(size, "M") {
-				size = strings.TrimSuffix(size, "M")
-			}
-			fmt.Sscanf(size, "%d", &chunkSize)
-			chunkSize *= multiplier
+		chunkSize := flagtype.GetSize(cmd.Flags(), "size")
+		if chunkSize <= 0 {
+			return errs.InvalidInput("分片大小必须大于0")
+		}
+
+		if raw {
+			return splitRaw(cmd, path, output, chunkSize, remove)
 		}
 
 		// 解析压缩格式
@@ -95,7 +127,7 @@ var splitCmd = &cobra.Command{
 		case "tar.xz", "txz":
 			compressType = fsutils.TARXZ
 		default:
-			return fmt.Errorf("不支持的压缩格式：%s（支持的格式：zip, tar.gz/tgz, tar.bz2/tbz2, tar.xz/txz）", format)
+			return errs.InvalidInput("不支持的压缩格式：%s（支持的格式：zip, tar.gz/tgz, tar.bz2/tbz2, tar.xz/txz）", format)
 		}
 
 		// 准备选项
@@ -108,23 +140,136 @@ var splitCmd = &cobra.Command{
 			DeleteSource: remove,
 		}
 
-		// 执行分片
-		if err := fsutils.SplitArchive(&opts); err != nil {
-			return fmt.Errorf("分片失败: %v", err)
+		if dryrun.Enabled(cmd) {
+			return planSplit(cmd, &opts, format)
+		}
+
+		// 执行分片，在终端上显示进度
+		var finish func()
+		opts.Progress, finish = splitProgressPrinter()
+		err := fsutils.SplitArchive(&opts)
+		finish()
+		if err != nil {
+			return errs.Wrap(err, "分片失败: %v", err)
 		}
 
-		fmt.Printf("分片完成，输出目录：%s\n", opts.OutputDir)
+		fmt.Print(i18n.Tf("分片完成，输出目录：%s\n", opts.OutputDir))
 		return nil
 	},
 }
 
+// planSplit 预演分片操作，只统计源目录，不创建或删除任何文件
+func planSplit(cmd *cobra.Command, opts *fsutils.SplitOptions, format string) error {
+	splitPlan, err := fsutils.PlanSplit(opts)
+	if err != nil {
+		return errs.Wrap(err, "分片失败: %v", err)
+	}
+
+	plan := &dryrun.Plan{}
+	plan.Addf(dryrun.ActionCreate, splitPlan.OutputDir, "将 %d 个文件（%s）打包为 %s，按 %s 分片，最多 %d 片",
+		splitPlan.FileCount, fsutils.FormatSize(splitPlan.TotalSize), format,
+		fsutils.FormatSize(opts.ChunkSize), splitPlan.MaxChunks)
+	if opts.DeleteSource {
+		plan.Addf(dryrun.ActionDelete, splitPlan.SourceDir, "分片完成后删除源目录及其中的 %d 个文件", splitPlan.FileCount)
+	}
+	return dryrun.Render(cmd, plan)
+}
+
+// splitProgressPrinter 返回在标准错误上显示分片进度条、已写入的分片和预计剩余时间的回调，以及清除进度行的函数。
+// 标准错误不是终端时回调为nil；源目录的大小不超过 progressThreshold 时不显示
+func splitProgressPrinter() (fsutils.SplitProgressFunc, func()) {
+	if !isatty.IsTerminal(os.Stderr.Fd()) {
+		return nil, func() {}
+	}
+	var (
+		last    time.Time
+		started = time.Now() // 用于估算剩余时间
+		shown   bool
+	)
+	onProgress := func(p fsutils.SplitProgress) {
+		if p.Total < progressThreshold {
+			return
+		}
+		if p.Phase == fsutils.SplitPhaseDelete {
+			shown = true
+			fmt.Fprint(os.Stderr, i18n.T("\r\033[K删除源目录..."))
+			return
+		}
+		if time.Since(last) < 200*time.Millisecond && p.Current < p.Total {
+			return
+		}
+		last = time.Now()
+		shown = true
+
+		const width = 24
+		ratio := float64(p.Current) / float64(p.Total)
+		filled := int(ratio * width)
+		bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+		line := i18n.Tf("分片 [%s] %5.1f%% %s / %s，已写入 %s（%d 个分片）", bar, ratio*100,
+			fsutils.FormatSize(p.Current), fsutils.FormatSize(p.Total), fsutils.FormatSize(p.Compressed), p.Chunks)
+		if elapsed := time.Since(started); p.Current > 0 && p.Current < p.Total && elapsed > time.Second {
+			remaining := time.Duration(float64(elapsed) * float64(p.Total-p.Current) / float64(p.Current))
+			line += i18n.T("，剩余 ") + remaining.Round(time.Second).String()
+		}
+		fmt.Fprint(os.Stderr, "\r\033[K"+line)
+	}
+	finish := func() {
+		if shown {
+			fmt.Fprint(os.Stderr, "\r\033[K")
+		}
+	}
+	return onProgress, finish
+}
+
+// splitRaw 直接切分单个文件，完成后按需删除源文件
+func splitRaw(cmd *cobra.Command, path, output string, chunkSize int64, remove bool) error {
+	if output == "" {
+		output = path + "_chunks"
+	}
+	if dryrun.Enabled(cmd) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return errs.Wrap(err, "无法访问文件: %v", err)
+		}
+		if !info.Mode().IsRegular() {
+			return errs.InvalidInput("%s 不是普通文件，--raw 只能切分单个文件", path)
+		}
+		chunks := max((info.Size()+chunkSize-1)/chunkSize, 1)
+		plan := &dryrun.Plan{}
+		plan.Addf(dryrun.ActionCreate, output, "将 %s（%s）按 %s 切分为 %d 片",
+			path, fsutils.FormatSize(info.Size()), fsutils.FormatSize(chunkSize), chunks)
+		if remove {
+			plan.Add(dryrun.ActionDelete, path, "切分完成后删除源文件")
+		}
+		return dryrun.Render(cmd, plan)
+	}
+
+	if err := fsutils.SplitFile(path, chunkSize, output); err != nil {
+		return errs.Wrap(err, "分片失败: %v", err)
+	}
+	if remove {
+		if err := os.Remove(path); err != nil {
+			return i18n.Errorf("删除源文件失败: %v", err)
+		}
+	}
+	fmt.Print(i18n.Tf("分片完成，输出目录：%s\n", output))
+	return nil
+}
+
 func init() {
-	splitCmd.Flags().StringP("size", "s", "100M", "分片大小（例如：100M, 1G）")
+	flagtype.SizeP(splitCmd.Flags(), "size", "s", 100*units.MegaByte, units.MegaByte, "分片大小（例如：100M, 1.5G，纯数字表示MB）")
 	splitCmd.Flags().StringP("format", "f", "zip", "压缩格式（zip, tar.gz/tgz, tar.bz2/tbz2, tar.xz/txz）")
 	splitCmd.Flags().StringP("output", "o", "", "输出目录（默认为源目录名_chunks）")
-	splitCmd.Flags().IntP("threads", "t", 0, "线程数（默认为CPU核心数）")
+	splitCmd.Flags().IntP("threads", "t", 0, "tar.gz并行压缩的线程数（默认为CPU核心数）")
 	splitCmd.Flags().BoolP("remove", "r", false, "完成后删除源目录")
+	splitCmd.Flags().Bool("raw", false, "直接切分单个文件，不打包也不压缩")
 	splitCmd.Flags().Bool("merge", false, "合并模式（将指定目录中的分片合并）")
+	splitCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{"zip", "tar.gz", "tar.bz2", "tar.xz"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+
+	dryrun.AddFlag(splitCmd)
 
 	FsCmd.AddCommand(splitCmd)
 }
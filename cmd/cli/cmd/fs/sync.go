@@ -0,0 +1,146 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"toolbox/pkg/i18n"
+
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/fsutils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// syncCmd 表示 sync 命令
+var syncCmd = &cobra.Command{
+	Use:   "sync <源目录> <目标目录>",
+	Short: "将目录单向同步到另一个目录",
+	Long: `将源目录单向同步到目标目录，类似 rsync -a，适合备份：
+只复制目标目录中没有的和有变化的文件，保留权限和修改时间，符号链接按链接本身复制。
+
+默认大小和修改时间（精确到秒）都相同的文件认为没有变化；--checksum 时大小相同的文件再比较SHA-256，
+更准确但需要读取两边的文件。--delete 删除目标目录中源目录没有的文件和目录，使两边完全一致。
+--exclude 排除的文件既不复制，在目标目录中也不会被删除。
+
+文件先写入临时文件再重命名，同步中断时目标目录中不会留下不完整的文件，再次同步时从没有同步的文件继续。
+
+示例:
+  %[1]s fs sync ~/photos /mnt/backup/photos                   # 复制新增和修改的文件
+  %[1]s fs sync ./site /srv/www --delete                      # 删除目标中多余的文件
+  %[1]s fs sync . ../backup --exclude node_modules -e "*.log" # 排除目录和文件
+  %[1]s fs sync ./docs /mnt/docs --delete --dry-run           # 预览将要进行的修改
+  %[1]s fs sync ./data /mnt/data --checksum --output json     # 按内容比较，以JSON格式输出`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, dst := args[0], args[1]
+		checksum, _ := cmd.Flags().GetBool("checksum")
+		remove, _ := cmd.Flags().GetBool("delete")
+		exclude, _ := cmd.Flags().GetStringSlice("exclude")
+
+		preview := dryrun.Enabled(cmd)
+		structured := output.IsStructured(cmd)
+		var changes []fsutils.SyncChange
+		options := fsutils.SyncOptions{
+			Checksum: checksum,
+			Delete:   remove,
+			DryRun:   preview,
+			Exclude:  exclude,
+			OnChange: func(change fsutils.SyncChange) {
+				if preview || structured {
+					changes = append(changes, change)
+					return
+				}
+				printSyncChange(change)
+			},
+			OnError: func(path string, err error) {
+				fmt.Fprint(os.Stderr, i18n.Tf("警告: %s: %v\n", path, err))
+			},
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		result, err := fsutils.SyncDirsContext(ctx, src, dst, options)
+		if err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf("同步已取消，已同步的文件会保留")
+			}
+			return err
+		}
+
+		if preview {
+			return dryrun.Render(cmd, syncPlan(dst, changes))
+		}
+		if structured {
+			if changes == nil {
+				changes = []fsutils.SyncChange{}
+			}
+			if err := output.Render(cmd, syncOutput{Changes: changes, Result: result}, nil); err != nil {
+				return err
+			}
+		} else {
+			fmt.Print(i18n.Tf("新建 %d，更新 %d，删除 %d，未变化 %d，共复制 %s\n",
+				result.Created, result.Updated, result.Deleted, result.Unchanged, fsutils.FormatSize(result.Bytes)))
+		}
+		if result.Failed > 0 {
+			return i18n.Errorf("%d 个文件同步失败", result.Failed)
+		}
+		return nil
+	},
+}
+
+// syncOutput sync 命令的结构化输出
+type syncOutput struct {
+	Changes []fsutils.SyncChange `json:"changes"`
+	Result  fsutils.SyncResult   `json:"result"`
+}
+
+// printSyncChange 输出一项修改：+ 新建，~ 更新，- 删除，目录以 / 结尾
+func printSyncChange(change fsutils.SyncChange) {
+	name := change.Path
+	if change.IsDir {
+		name += "/"
+	}
+	switch change.Action {
+	case fsutils.SyncCreate:
+		fmt.Printf("%s %s\n", color.GreenString("+"), name)
+	case fsutils.SyncUpdate:
+		fmt.Printf("%s %s\n", color.YellowString("~"), name)
+	case fsutils.SyncDelete:
+		fmt.Printf("%s %s\n", color.RedString("-"), name)
+	}
+}
+
+// syncPlan 将预演时比较出的修改转换为预演计划
+func syncPlan(dst string, changes []fsutils.SyncChange) *dryrun.Plan {
+	plan := &dryrun.Plan{}
+	for _, change := range changes {
+		target := filepath.Join(dst, filepath.FromSlash(change.Path))
+		switch {
+		case change.Action == fsutils.SyncDelete:
+			plan.Add(dryrun.ActionDelete, target, "源目录中没有")
+		case change.IsDir && change.Action == fsutils.SyncUpdate:
+			plan.Add(dryrun.ActionModify, target, "替换为目录")
+		case change.IsDir:
+			plan.Add(dryrun.ActionCreate, target, "创建目录")
+		case change.Action == fsutils.SyncCreate:
+			plan.Addf(dryrun.ActionCreate, target, "复制 %s", fsutils.FormatSize(change.Size))
+		default:
+			plan.Addf(dryrun.ActionModify, target, "覆盖 %s", fsutils.FormatSize(change.Size))
+		}
+	}
+	return plan
+}
+
+func init() {
+	FsCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().BoolP("checksum", "c", false, "大小相同的文件按SHA-256比较内容，而不是修改时间")
+	syncCmd.Flags().Bool("delete", false, "删除目标目录中源目录没有的文件和目录")
+	syncCmd.Flags().StringSliceP("exclude", "e", nil, "排除匹配的文件和目录（支持通配符，可多次使用）")
+	dryrun.AddFlag(syncCmd)
+}
@@ -1,10 +1,12 @@
 package fs
 
 import (
-	"fmt"
 	"os"
 
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
 	"toolbox/pkg/fsutils"
+	"toolbox/pkg/termcolor"
 
 	"github.com/spf13/cobra"
 )
@@ -16,6 +18,16 @@ var treeCmd = &cobra.Command{
 	Long: `显示指定目录的文件和子目录结构，类似于Linux/Windows的tree命令。
 该命令以树状图形方式展示目录结构，可以指定显示深度、过滤条件等。
 
+--du 计算并显示每个目录中所有文件的大小之和（包括隐藏文件和超出显示深度的文件，硬链接只计算一次），
+不需要再单独运行 du；--sort-size 将同一目录中的条目按大小从大到小排序，可以快速找到占用空间最多的目录。
+
+输出到终端时按文件类型给名称加颜色：目录、符号链接、可执行文件和压缩文件，颜色可以通过 LS_COLORS
+环境变量修改（支持 di、ln、or、ex 和 *.扩展名），使用全局的 --no-color 关闭。
+
+--json 输出嵌套的JSON结构，每个节点包含名称、路径、类型、大小、权限、修改时间和子节点（children），
+便于其他工具处理；也可以使用全局的 --output json 或 --output yaml。--markdown 输出Markdown的嵌套列表，
+--html 输出可以点击折叠目录的HTML页面，便于在文档和wiki中展示目录结构。
+
 示例:
   %[1]s fs tree                   # 显示当前目录的结构
   %[1]s fs tree /path/to/dir      # 显示指定目录的结构
@@ -23,8 +35,13 @@ var treeCmd = &cobra.Command{
   %[1]s fs tree -a                # 显示隐藏文件
   %[1]s fs tree -L                # 跟随符号链接
   %[1]s fs tree -D                # 只显示目录
-  %[1]s fs tree -s                # 显示文件大小`,
-	Run: func(cmd *cobra.Command, args []string) {
+  %[1]s fs tree -s                # 显示文件大小
+  %[1]s fs tree src --json        # 以JSON格式输出目录树
+  %[1]s fs tree src -d 2 --markdown > layout.md  # 导出为Markdown列表
+  %[1]s fs tree . --du --html > tree.html  # 导出为可折叠的HTML页面
+  %[1]s fs tree --du -D -d 2      # 显示两层目录及其累计大小
+  %[1]s fs tree ~ --sort-size -D -d 1  # 按大小排序家目录中的子目录`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 获取目录路径参数
 		path := "."
 		if len(args) > 0 {
@@ -37,6 +54,11 @@ var treeCmd = &cobra.Command{
 		onlyDirs, _ := cmd.Flags().GetBool("dirs-only")
 		followSymlink, _ := cmd.Flags().GetBool("follow")
 		showSize, _ := cmd.Flags().GetBool("size")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		markdown, _ := cmd.Flags().GetBool("markdown")
+		htmlOutput, _ := cmd.Flags().GetBool("html")
+		du, _ := cmd.Flags().GetBool("du")
+		sortSize, _ := cmd.Flags().GetBool("sort-size")
 
 		// 创建选项
 		options := fsutils.TreeOptions{
@@ -45,14 +67,40 @@ var treeCmd = &cobra.Command{
 			OnlyDirs:      onlyDirs,
 			FollowSymlink: followSymlink,
 			ShowSize:      showSize,
+			DirSizes:      du || sortSize,
+			SortBySize:    sortSize,
+			NoColor:       !termcolor.Enabled(),
+		}
+
+		if markdown || htmlOutput {
+			if markdown && htmlOutput {
+				return errs.InvalidInput("--markdown 和 --html 不能同时使用")
+			}
+			node, _, err := fsutils.BuildTree(path, options)
+			if err != nil {
+				return err
+			}
+			if markdown {
+				return fsutils.WriteTreeMarkdown(os.Stdout, node, options)
+			}
+			return fsutils.WriteTreeHTML(os.Stdout, node, options)
+		}
+
+		// 结构化输出时输出节点树，--json 不受全局输出格式影响
+		if jsonOutput || output.IsStructured(cmd) {
+			node, _, err := fsutils.BuildTree(path, options)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return output.Write(os.Stdout, output.FormatJSON, node, nil)
+			}
+			return output.Render(cmd, node, nil)
 		}
 
 		// 执行目录树展示
 		_, err := fsutils.DisplayTree(path, os.Stdout, options)
-		if err != nil {
-			fmt.Printf("错误: %v\n", err)
-			os.Exit(1)
-		}
+		return err
 	},
 }
 
@@ -65,4 +113,9 @@ func init() {
 	treeCmd.Flags().BoolP("dirs-only", "D", false, "只显示目录")
 	treeCmd.Flags().BoolP("follow", "L", false, "跟随符号链接")
 	treeCmd.Flags().BoolP("size", "s", false, "显示文件大小")
+	treeCmd.Flags().Bool("du", false, "显示每个目录中所有文件的累计大小")
+	treeCmd.Flags().Bool("sort-size", false, "按大小从大到小排序（包含 --du）")
+	treeCmd.Flags().Bool("markdown", false, "以Markdown嵌套列表输出目录树")
+	treeCmd.Flags().Bool("html", false, "输出可折叠目录的HTML页面")
+	treeCmd.Flags().Bool("json", false, "以嵌套的JSON结构输出目录树")
 }
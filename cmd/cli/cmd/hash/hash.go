@@ -0,0 +1,23 @@
+package hash
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// HashCmd 表示摘要计算命令
+var HashCmd = &cobra.Command{
+	Use:   "hash",
+	Short: "计算和校验摘要",
+	Long: `摘要计算工具集，用于校验文件和调试Webhook签名。
+
+包含以下子命令:
+  hmac - 计算或校验HMAC
+
+示例:
+  %[1]s hash hmac -k secret -s hello`,
+}
+
+func init() {
+	// 添加子命令
+	HashCmd.AddCommand(hmacCmd)
+}
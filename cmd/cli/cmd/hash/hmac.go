@@ -0,0 +1,168 @@
+package hash
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/digest"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// HMACResult 单个输入的HMAC计算结果
+type HMACResult struct {
+	Source    string `json:"source"`          // 文件路径，标准输入为 "-"
+	Algorithm string `json:"algorithm"`       // 摘要算法
+	HMAC      string `json:"hmac"`            // 十六进制或base64格式的HMAC
+	Match     *bool  `json:"match,omitempty"` // 校验模式下是否与期望的签名一致
+}
+
+// hmacCmd 表示 hash hmac 命令
+var hmacCmd = &cobra.Command{
+	Use:   "hmac [文件...]",
+	Short: "计算或校验HMAC",
+	Long: `计算文件、标准输入或 --string 指定文本的HMAC，输出格式与 sha256sum 相同；
+使用 --verify 时与给定的签名比较，用于调试Webhook签名。
+
+密钥的写法:
+  @文件路径     从文件读取，去掉末尾的换行符
+  env:变量名    从环境变量读取，避免密钥出现在命令历史中
+  hex:十六进制  base64:文本   解码后作为密钥
+  其他          按原文作为密钥
+
+--verify 的签名可以是十六进制或base64，允许带有 sha256= 这样的前缀（GitHub的
+X-Hub-Signature-256 格式）。签名不一致时以退出码1退出。
+
+示例:
+  %[1]s hash hmac -k @secret.txt payload.json
+  %[1]s hash hmac -a sha1 -k env:WEBHOOK_SECRET --base64 -s '{"id":1}'
+  cat body.json | %[1]s hash hmac -k @secret.txt --verify "sha256=5d1f..."
+  %[1]s hash hmac -k hex:0b0b0b0b -a sha512 *.bin --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		algorithm, _ := cmd.Flags().GetString("alg")
+		keySpec, _ := cmd.Flags().GetString("key")
+		expected, _ := cmd.Flags().GetString("verify")
+		useBase64, _ := cmd.Flags().GetBool("base64")
+		text, _ := cmd.Flags().GetString("string")
+
+		if keySpec == "" {
+			return errs.InvalidInput("必须使用 --key 指定密钥")
+		}
+		algorithm, err := digest.Normalize(algorithm)
+		if err != nil {
+			return err
+		}
+		key, err := digest.ParseKey(keySpec)
+		if err != nil {
+			return err
+		}
+		if len(key) == 0 {
+			return errs.InvalidInput("密钥不能为空")
+		}
+
+		// 确定输入源
+		sources := args
+		if cmd.Flags().Changed("string") {
+			if len(args) > 0 {
+				return errs.InvalidInput("--string 不能与文件参数同时使用")
+			}
+			sources = nil
+		} else if len(sources) == 0 {
+			if isatty.IsTerminal(os.Stdin.Fd()) {
+				return errs.InvalidInput("请指定文件、通过管道输入内容，或使用 --string 指定文本")
+			}
+			sources = []string{"-"}
+		}
+
+		compute := func(source string, reader io.Reader) (HMACResult, error) {
+			sum, err := digest.HMAC(algorithm, key, reader)
+			if err != nil {
+				return HMACResult{}, i18n.Errorf("读取 %s 失败: %v", source, err)
+			}
+			result := HMACResult{Source: source, Algorithm: algorithm, HMAC: hex.EncodeToString(sum)}
+			if useBase64 {
+				result.HMAC = base64.StdEncoding.EncodeToString(sum)
+			}
+			if expected != "" {
+				match := digest.Verify(sum, expected)
+				result.Match = &match
+			}
+			return result, nil
+		}
+
+		var results []HMACResult
+		if sources == nil {
+			result, err := compute("-", strings.NewReader(text))
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		for _, source := range sources {
+			var reader io.Reader = os.Stdin
+			if source != "-" {
+				file, err := os.Open(source)
+				if err != nil {
+					return errs.Wrap(err, "无法打开文件 %s: %v", source, err)
+				}
+				defer file.Close()
+				reader = file
+			}
+			result, err := compute(source, reader)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+
+		if err := output.Render(cmd, results, func() {
+			printResults(results)
+		}); err != nil {
+			return err
+		}
+
+		// 结果已经输出，签名不一致时只设置退出码
+		for _, result := range results {
+			if result.Match != nil && !*result.Match {
+				return errs.Exit(1)
+			}
+		}
+		return nil
+	},
+}
+
+// printResults 按 sha256sum 的格式输出结果，校验模式下显示是否一致
+func printResults(results []HMACResult) {
+	for _, result := range results {
+		if result.Match == nil {
+			fmt.Printf("%s  %s\n", result.HMAC, result.Source)
+			continue
+		}
+		status := color.GreenString(i18n.T("签名一致"))
+		if !*result.Match {
+			status = color.RedString(i18n.T("签名不一致"))
+		}
+		fmt.Printf("%s: %s (%s)\n", result.Source, status, result.HMAC)
+	}
+}
+
+func init() {
+	hmacCmd.Flags().StringP("alg", "a", "sha256", "摘要算法: md5、sha1、sha224、sha256、sha384、sha512、sha3-256、sha3-512")
+	hmacCmd.Flags().StringP("key", "k", "", "密钥，支持 @文件、env:变量名、hex:、base64: 前缀")
+	hmacCmd.Flags().String("verify", "", "与给定的签名（十六进制或base64）比较")
+	hmacCmd.Flags().Bool("base64", false, "以base64而不是十六进制输出")
+	hmacCmd.Flags().StringP("string", "s", "", "直接指定要处理的文本，而不是读取文件或标准输入")
+
+	hmacCmd.RegisterFlagCompletionFunc("alg", cobra.FixedCompletions(
+		digest.Algorithms(),
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
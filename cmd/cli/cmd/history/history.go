@@ -0,0 +1,113 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/history"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// HistoryCmd 表示 history 命令
+var HistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "查看执行过的命令",
+	Long: `查看最近执行过的工具箱命令，包括执行时间、耗时和退出码。
+
+每次执行命令后都会记录完整的命令行参数和工作目录，可以使用 rerun 按序号重新执行，
+适合重复耗时较长的诊断命令。history、rerun、completion 和任务文件中的步骤不记录。
+
+历史记录默认保存在用户配置目录下的 toolbox/history.jsonl 中，最多保留 1000 条；
+可以通过 TOOLBOX_HISTORY 环境变量指定文件路径，设置 TOOLBOX_NO_HISTORY=1 则不记录。
+注意命令行中的密码等敏感参数也会被记录，必要时使用 --clear 清空。
+
+示例:
+  %[1]s history                  # 显示最近20条命令
+  %[1]s history -n 0             # 显示全部命令
+  %[1]s history --filter ping    # 只显示包含ping的命令
+  %[1]s history --failed         # 只显示执行失败的命令
+  %[1]s history --clear          # 清空历史记录
+  %[1]s rerun 42                 # 重新执行第42条命令`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		filter, _ := cmd.Flags().GetString("filter")
+		failed, _ := cmd.Flags().GetBool("failed")
+		clear, _ := cmd.Flags().GetBool("clear")
+
+		if clear {
+			if err := history.Clear(); err != nil {
+				return err
+			}
+			output.Infof(cmd, "历史记录已清空\n")
+			return nil
+		}
+
+		entries, err := history.Load()
+		if err != nil {
+			return err
+		}
+
+		// 按条件筛选
+		var matched []history.Entry
+		for _, entry := range entries {
+			if failed && entry.ExitCode == 0 {
+				continue
+			}
+			if filter != "" && !strings.Contains(entry.CommandLine(), filter) {
+				continue
+			}
+			matched = append(matched, entry)
+		}
+		if limit > 0 && len(matched) > limit {
+			matched = matched[len(matched)-limit:]
+		}
+		if matched == nil {
+			matched = []history.Entry{}
+		}
+
+		return output.Render(cmd, matched, func() {
+			printEntries(matched)
+		})
+	},
+}
+
+// printEntries 以表格形式输出历史记录
+func printEntries(entries []history.Entry) {
+	if len(entries) == 0 {
+		fmt.Println(i18n.T("没有历史记录"))
+		return
+	}
+
+	table := output.NewTable(os.Stdout, []string{i18n.T("序号"), i18n.T("时间"), i18n.T("耗时"), i18n.T("退出码"), i18n.T("命令")})
+	for _, entry := range entries {
+		exitCode := strconv.Itoa(entry.ExitCode)
+		if entry.ExitCode == 0 {
+			exitCode = color.GreenString(exitCode)
+		} else {
+			exitCode = color.RedString(exitCode)
+		}
+		table.Append([]string{
+			strconv.Itoa(entry.ID),
+			entry.Time.Format("2006-01-02 15:04:05"),
+			units.FormatDuration(time.Duration(entry.DurationMs) * time.Millisecond),
+			exitCode,
+			entry.CommandLine(),
+		})
+	}
+	table.Render()
+}
+
+func init() {
+	HistoryCmd.Flags().IntP("limit", "n", 20, "显示最近的条数，0表示全部")
+	HistoryCmd.Flags().String("filter", "", "只显示包含指定文本的命令")
+	HistoryCmd.Flags().Bool("failed", false, "只显示执行失败的命令")
+	HistoryCmd.Flags().Bool("clear", false, "清空历史记录")
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/history"
+	"toolbox/pkg/i18n"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// RerunCmd 表示 rerun 命令
+var RerunCmd = &cobra.Command{
+	Use:   "rerun <序号|last>",
+	Short: "重新执行历史记录中的命令",
+	Long: `按 history 中的序号重新执行一条命令，last 表示最近一条。
+
+命令在原来的工作目录中以相同的参数执行，目录已不存在或指定 --here 时在当前目录执行。
+密码、密钥等参数的值没有记录在历史中（显示为 ******），含有这些参数的命令不能重新执行。
+重新执行的命令会作为新的记录追加到历史中，rerun 以该命令的退出码退出。
+
+示例:
+  %[1]s rerun 42          # 重新执行第42条命令
+  %[1]s rerun last        # 重新执行最近一条命令
+  %[1]s rerun 42 --here   # 在当前目录重新执行`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		entries, _ := history.Load()
+		if len(entries) > 20 {
+			entries = entries[len(entries)-20:]
+		}
+		completions := []string{"last"}
+		for i := len(entries) - 1; i >= 0; i-- {
+			completions = append(completions, fmt.Sprintf("%d\t%s", entries[i].ID, entries[i].CommandLine()))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		here, _ := cmd.Flags().GetBool("here")
+
+		id := -1
+		if args[0] != "last" {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return errs.InvalidInput("无效的序号: %s", args[0])
+			}
+			id = n
+		}
+
+		entry, err := history.Find(id)
+		if err != nil {
+			return err
+		}
+		// 密码、密钥等参数记录时已经替换为 ******，重新执行会把 ****** 当作实际的值
+		if entry.Redacted() {
+			return i18n.Errorf("第 %d 条命令中的密码、密钥等参数没有记录（显示为 %s），无法重新执行，请直接运行该命令", entry.ID, history.RedactedValue)
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return i18n.Errorf("获取程序路径失败: %v", err)
+		}
+
+		child := exec.Command(executable, entry.Args...)
+		child.Stdin = os.Stdin
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		if !here && entry.Dir != "" {
+			if info, err := os.Stat(entry.Dir); err == nil && info.IsDir() {
+				child.Dir = entry.Dir
+			} else {
+				color.New(color.FgYellow).Fprintf(os.Stderr, i18n.T("原工作目录 %s 不存在，在当前目录执行\n"), entry.Dir)
+			}
+		}
+
+		color.New(color.FgCyan, color.Bold).Fprintf(os.Stderr, "==> [%d] %s\n", entry.ID, entry.CommandLine())
+		if err := child.Run(); err != nil {
+			// 命令已经输出了自己的错误信息，这里只传递退出码
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+				return errs.Exit(exitErr.ExitCode())
+			}
+			return i18n.Errorf("执行命令失败: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RerunCmd.Flags().Bool("here", false, "在当前目录而不是原工作目录中执行")
+}
@@ -0,0 +1,112 @@
+// Package host 实现全局 --host 标志，使部分命令可以通过SSH在远程主机上执行
+//
+// 支持远程执行的命令调用 MarkSupported 标记自己，并在 Enabled 时改为通过 pkg/remote
+// 获取数据，结果仍由本地按相同格式输出。
+package host
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/remote"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// FlagName 全局主机标志的名称
+const FlagName = "host"
+
+// annotation 标记命令支持远程执行的注解
+const annotation = "toolbox/remote"
+
+// AddFlag 在根命令上注册全局 --host 标志
+func AddFlag(root *cobra.Command) {
+	root.PersistentFlags().StringSlice(FlagName, nil, "通过SSH在远程主机上执行（user@host[:port] 或配置文件中的主机名，多个用逗号分隔）")
+	root.RegisterFlagCompletionFunc(FlagName, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		profiles, err := remote.LoadProfiles()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var names []string
+		for name, profile := range profiles {
+			names = append(names, fmt.Sprintf("%s\t%s@%s", name, profile.User, profile.Address))
+		}
+		sort.Strings(names)
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// MarkSupported 标记命令支持 --host
+func MarkSupported(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[annotation] = "true"
+}
+
+// Enabled 是否指定了远程主机
+func Enabled(cmd *cobra.Command) bool {
+	hosts, _ := cmd.Root().PersistentFlags().GetStringSlice(FlagName)
+	return len(hosts) > 0
+}
+
+// Check 检查当前命令是否支持 --host，在根命令的 PersistentPreRunE 中调用
+func Check(cmd *cobra.Command) error {
+	if !Enabled(cmd) || cmd.Annotations[annotation] == "true" {
+		return nil
+	}
+
+	var supported []string
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if c.Annotations[annotation] == "true" {
+			supported = append(supported, strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" "))
+		}
+		for _, child := range c.Commands() {
+			walk(child)
+		}
+	}
+	walk(cmd.Root())
+	return errs.InvalidInput("%s 不支持 --host（支持的命令: %s）", cmd.CommandPath(), strings.Join(supported, ", "))
+}
+
+// Targets 解析 --host 指定的远程主机
+func Targets(cmd *cobra.Command) ([]remote.Profile, error) {
+	hosts, _ := cmd.Root().PersistentFlags().GetStringSlice(FlagName)
+	profiles, err := remote.LoadProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]remote.Profile, 0, len(hosts))
+	for _, spec := range hosts {
+		profile, err := remote.Resolve(spec, profiles)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, profile)
+	}
+	return targets, nil
+}
+
+// ReportErrors 将各主机的错误写入标准错误，有主机失败时返回以第一个错误的退出码退出的错误
+func ReportErrors(targets []remote.Profile, results []error) error {
+	var first error
+	for i, err := range results {
+		if err == nil {
+			continue
+		}
+		color.New(color.FgRed).Fprintf(os.Stderr, i18n.T("错误: %s: %v\n"), targets[i].Label(), err)
+		if first == nil {
+			first = err
+		}
+	}
+	if first != nil {
+		return errs.Exit(errs.ExitCode(first))
+	}
+	return nil
+}
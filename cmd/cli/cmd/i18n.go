@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"toolbox/pkg/i18n"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// addLangFlag 注册全局 --lang 标志
+func addLangFlag(root *cobra.Command) {
+	root.PersistentFlags().String("lang", "", "界面语言 (zh, en)，默认根据LANG环境变量选择")
+	root.RegisterFlagCompletionFunc("lang", cobra.FixedCompletions(
+		[]string{i18n.LangZH, i18n.LangEN},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
+
+// setupLanguage 在解析命令行之前确定界面语言
+//
+// 帮助文本在cobra解析标志之前就需要翻译，因此直接从参数中查找 --lang，
+// 未指定时根据环境变量推断。
+func setupLanguage(args []string) error {
+	lang := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if arg == "--lang" && i+1 < len(args) {
+			lang = args[i+1]
+			i++
+		} else if strings.HasPrefix(arg, "--lang=") {
+			lang = strings.TrimPrefix(arg, "--lang=")
+		}
+	}
+
+	if lang == "" {
+		return i18n.SetLanguage(i18n.FromEnv())
+	}
+	return i18n.SetLanguage(lang)
+}
+
+// localizeCommands 将命令树中的说明文字和标志说明翻译为当前语言
+func localizeCommands(root *cobra.Command) {
+	var walk func(cmd *cobra.Command, path string)
+	walk = func(cmd *cobra.Command, path string) {
+		// Use 中命令名之后的参数说明（如 <文件>）同样需要翻译
+		if i := strings.IndexByte(cmd.Use, ' '); i >= 0 {
+			cmd.Use = cmd.Use[:i+1] + i18n.T(cmd.Use[i+1:])
+		}
+		cmd.Short = i18n.T(cmd.Short)
+		if long, ok := i18n.Lookup("long:" + path); ok {
+			cmd.Long = long
+		}
+
+		translate := func(flag *pflag.Flag) {
+			flag.Usage = i18n.T(flag.Usage)
+		}
+		cmd.Flags().VisitAll(translate)
+		cmd.PersistentFlags().VisitAll(translate)
+
+		for _, child := range cmd.Commands() {
+			childPath := child.Name()
+			if path != "" {
+				childPath = path + " " + childPath
+			}
+			walk(child, childPath)
+		}
+	}
+	walk(root, "")
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"toolbox/pkg/i18n"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// TestHelpTranslated 检查命令树中每个命令的简短说明、详细说明和标志说明都有英文翻译，
+// 新增命令或修改说明文字后缺少翻译时失败；同时检查详细说明中程序名占位符以外的 % 都已转义
+func TestHelpTranslated(t *testing.T) {
+	if err := i18n.SetLanguage(i18n.LangEN); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLanguage(i18n.LangZH)
+
+	var missing []string
+	check := func(kind, key string) {
+		if key == "" {
+			return
+		}
+		if _, ok := i18n.Lookup(key); !ok {
+			missing = append(missing, kind+": "+key)
+		}
+	}
+
+	// 详细说明在 Execute 中用 fmt.Sprintf 替换程序名，未转义的 % 会输出 %!x(MISSING) 之类的内容
+	format := func(key, text string) {
+		if strings.Contains(withProgramName(text), "%!") {
+			missing = append(missing, "format: "+key)
+		}
+	}
+
+	var walk func(cmd *cobra.Command, path string)
+	walk = func(cmd *cobra.Command, path string) {
+		check("short "+path, cmd.Short)
+		if cmd.Long != "" {
+			check("long", "long:"+path)
+			format("long:"+path+" (zh)", cmd.Long)
+			if long, ok := i18n.Lookup("long:" + path); ok {
+				format("long:"+path+" (en)", long)
+			}
+		}
+		visit := func(flag *pflag.Flag) {
+			check("flag "+path+" --"+flag.Name, flag.Usage)
+		}
+		cmd.LocalNonPersistentFlags().VisitAll(visit)
+		cmd.PersistentFlags().VisitAll(visit)
+		for _, child := range cmd.Commands() {
+			childPath := strings.TrimSpace(path + " " + child.Name())
+			walk(child, childPath)
+		}
+	}
+	walk(rootCmd, "")
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		t.Errorf("%d 项帮助文字没有英文翻译（在 pkg/i18n/catalog_en.go 中添加）或格式有误:\n%s", len(missing), strings.Join(missing, "\n"))
+	}
+}
+
+// matchPackages 参数用于匹配或处理文本（如解析其他程序的中文输出）而不是输出给用户的包
+var matchPackages = map[string]bool{"strings": true, "bytes": true, "regexp": true, "strconv": true, "filepath": true, "unicode": true}
+
+// dataVars 值为生成的数据而不是提示信息的变量，如 mock 生成中文姓名用的姓和名
+var dataVars = map[string]bool{"surnamesZh": true, "givenZh": true}
+
+// printMethods 直接输出格式化文本的函数和方法，如 fmt.Printf、color.Red、(*color.Color).Println
+var printMethods = map[string]bool{"Printf": true, "Println": true, "Print": true, "Fprintf": true, "Fprintln": true,
+	"Fprint": true, "Sprintf": true, "Sprint": true, "Sprintln": true, "Errorf": true}
+
+// TestMessagesTranslated 检查源代码中面向用户的中文字符串（运行时输出、表头、错误信息等）都有英文翻译，
+// 并且直接交给 fmt 和 color 输出的中文字符串都经过 i18n 翻译。
+// 用于匹配的字符串（strings、regexp 等函数的参数，比较和 case 中的字符串，map 的键）、结构体标签和
+// 详细说明（由 TestHelpTranslated 检查）不在此检查；命令的 Use 只检查命令名之后的参数说明
+func TestMessagesTranslated(t *testing.T) {
+	if err := i18n.SetLanguage(i18n.LangEN); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLanguage(i18n.LangZH)
+
+	// 详细说明按命令路径翻译，即使不是直接写在 Long 字段中（如 service start 的说明作为参数传入）
+	longs := map[string]bool{}
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		longs[cmd.Long] = true
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+
+	root := filepath.Join("..", "..", "..")
+	fset := token.NewFileSet()
+	missing := map[string]string{} // 缺少翻译的字符串 → 第一次出现的位置
+	var direct []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || path == filepath.Join(root, "pkg", "i18n") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		var stack []ast.Node
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil {
+				stack = stack[:len(stack)-1]
+				return true
+			}
+			defer func() { stack = append(stack, n) }()
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			text, err := strconv.Unquote(lit.Value)
+			if err != nil || longs[text] || !strings.ContainsFunc(text, func(r rune) bool { return unicode.Is(unicode.Han, r) }) {
+				return true
+			}
+			position := fset.Position(lit.Pos()).String()
+			keys := messageKeys(stack, lit, text)
+			if call, isCall := stack[len(stack)-1].(*ast.CallExpr); isCall && len(keys) > 0 && isPrintCall(call) {
+				direct = append(direct, position+": "+text)
+			}
+			for _, key := range keys {
+				if _, ok := i18n.Lookup(key); !ok && missing[key] == "" {
+					missing[key] = position
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(direct) > 0 {
+		t.Errorf("%d 处中文字符串直接交给 fmt 或 color 输出，没有经过 i18n.T、i18n.Tf 或 i18n.Errorf 翻译:\n%s",
+			len(direct), strings.Join(direct, "\n"))
+	}
+	if len(missing) > 0 {
+		var lines []string
+		for key, position := range missing {
+			lines = append(lines, fmt.Sprintf("%s: %q", position, key))
+		}
+		sort.Strings(lines)
+		t.Errorf("%d 条消息没有英文翻译（在 pkg/i18n/messages_en.go 中添加）:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+}
+
+// templateText 模板中通过 t 函数翻译的文字，如 {{t "上传"}}
+var templateText = regexp.MustCompile(`{{t "([^"]*)"}}`)
+
+// messageKeys 返回字符串字面量在消息目录中的键，不需要翻译时返回空，stack 为字面量的各级父节点
+func messageKeys(stack []ast.Node, lit *ast.BasicLit, text string) []string {
+	for _, node := range stack {
+		if spec, ok := node.(*ast.ValueSpec); ok && len(spec.Names) > 0 && dataVars[spec.Names[0].Name] {
+			return nil
+		}
+	}
+	switch p := stack[len(stack)-1].(type) {
+	case *ast.Field:
+		if p.Tag == lit {
+			return nil
+		}
+	case *ast.CallExpr:
+		if sel, ok := p.Fun.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && matchPackages[pkg.Name] {
+				return nil
+			}
+			// HTML模板只翻译其中的 {{t "..."}}
+			if sel.Sel.Name == "Parse" {
+				var keys []string
+				for _, m := range templateText.FindAllStringSubmatch(text, -1) {
+					keys = append(keys, m[1])
+				}
+				return keys
+			}
+		}
+	case *ast.BinaryExpr:
+		if p.Op == token.EQL || p.Op == token.NEQ {
+			return nil
+		}
+		// 拼接出的 Use，如 action + " <服务名>"
+		if kv, ok := stack[len(stack)-2].(*ast.KeyValueExpr); ok && p.Op == token.ADD && isKey(kv, "Use") {
+			return []string{strings.TrimSpace(text)}
+		}
+	case *ast.CaseClause, *ast.IndexExpr:
+		return nil
+	case *ast.KeyValueExpr:
+		if p.Key == lit || isKey(p, "Long") || isKey(p, "Example") {
+			return nil
+		}
+		if isKey(p, "Use") {
+			if _, args, ok := strings.Cut(text, " "); ok {
+				return []string{args}
+			}
+			return nil
+		}
+	}
+	return []string{text}
+}
+
+// isKey 判断键值对的键是否为指定的字段名
+func isKey(kv *ast.KeyValueExpr, name string) bool {
+	key, ok := kv.Key.(*ast.Ident)
+	return ok && key.Name == name
+}
+
+// isPrintCall 判断是否为 fmt.Printf、color.Red、(*color.Color).Println 等直接输出文本的调用
+func isPrintCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	if pkg, ok := sel.X.(*ast.Ident); ok {
+		switch pkg.Name {
+		case "i18n", "logger":
+			return false
+		case "color":
+			return sel.Sel.Name != "New"
+		}
+	}
+	return printMethods[sel.Sel.Name]
+}
@@ -0,0 +1,31 @@
+package id
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// IdCmd 表示 ID 生成与解析命令
+var IdCmd = &cobra.Command{
+	Use:   "id",
+	Short: "生成和解析UUID、ULID、Snowflake ID",
+	Long: `生成 UUID、ULID 和 Snowflake ID，或解析已有ID中的版本、时间戳等信息，
+适合生成测试数据和排查ID冲突。
+
+包含以下子命令:
+  uuid - 生成UUID（v4或v7）
+  ulid - 生成ULID
+  snowflake - 生成Snowflake ID
+  inspect - 解析ID的类型、版本和时间戳
+
+示例:
+  %[1]s id uuid -v 7
+  %[1]s id inspect 01ARZ3NDEKTSV4RRFFQ69G5FAV`,
+}
+
+func init() {
+	// 添加子命令
+	IdCmd.AddCommand(uuidCmd)
+	IdCmd.AddCommand(ulidCmd)
+	IdCmd.AddCommand(snowflakeCmd)
+	IdCmd.AddCommand(inspectCmd)
+}
@@ -0,0 +1,24 @@
+package log
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// LogCmd 表示日志查看命令组
+var LogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "查看和跟踪日志文件",
+	Long: `查看和跟踪日志文件，在 tail、grep 的基础上识别每行日志的时间。
+
+包含以下子命令:
+  tail - 同时跟踪多个日志文件，按正则表达式过滤和高亮
+
+示例:
+  %[1]s log tail app.log err.log -f
+  %[1]s log tail app.log --grep ERROR --highlight 'timeout|5xx' --since 10m`,
+}
+
+func init() {
+	// 添加子命令
+	LogCmd.AddCommand(tailCmd)
+}
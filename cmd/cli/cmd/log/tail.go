@@ -0,0 +1,193 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/logtail"
+	"toolbox/pkg/timeconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// fileColors 区分不同文件的前缀颜色，文件多于颜色数时循环使用
+var fileColors = []color.Attribute{color.FgCyan, color.FgGreen, color.FgMagenta, color.FgBlue, color.FgYellow}
+
+// tailCmd 表示 log tail 命令
+var tailCmd = &cobra.Command{
+	Use:   "tail <文件...>",
+	Short: "同时跟踪多个日志文件，按正则表达式过滤和高亮",
+	Long: `输出一个或多个日志文件的最后几行，--follow 时继续输出新写入的行，按 Ctrl+C 结束。
+多个文件时每行前显示文件名，不同文件使用不同颜色。
+
+--grep 只保留匹配任意一个模式的行，--exclude 丢弃匹配的行，两者都可以重复指定；
+--grep 匹配的部分以红色显示，--highlight 匹配的部分以黄色背景显示，但不影响过滤。
+--since 根据行中的时间（ISO 8601、访问日志、syslog等格式）跳过较早的日志，
+没有时间的行（如异常堆栈）沿用上一行的时间。指定 --since 而未指定 --lines 时显示该时间之后的全部行。
+跟踪时能处理文件被截断和轮转。
+
+示例:
+  %[1]s log tail app.log -n 50
+  %[1]s log tail app.log err.log -f
+  %[1]s log tail app.log err.log --grep ERROR --highlight 'timeout|5xx' --since 10m
+  %[1]s log tail access.log -f -v 'GET /health' --highlight ' 5[0-9]{2} '`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lines, _ := cmd.Flags().GetInt("lines")
+		follow, _ := cmd.Flags().GetBool("follow")
+		ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+		grepPatterns, _ := cmd.Flags().GetStringArray("grep")
+		excludePatterns, _ := cmd.Flags().GetStringArray("exclude")
+		highlightPatterns, _ := cmd.Flags().GetStringArray("highlight")
+		since := flagtype.GetDuration(cmd.Flags(), "since")
+		tz, _ := cmd.Flags().GetString("tz")
+
+		include, err := compilePatterns(grepPatterns, ignoreCase)
+		if err != nil {
+			return err
+		}
+		exclude, err := compilePatterns(excludePatterns, ignoreCase)
+		if err != nil {
+			return err
+		}
+		highlight, err := compilePatterns(highlightPatterns, ignoreCase)
+		if err != nil {
+			return err
+		}
+		loc, err := timeconv.LoadLocation(tz)
+		if err != nil {
+			return err
+		}
+
+		options := logtail.Options{
+			Lines:    lines,
+			Follow:   follow,
+			Include:  include,
+			Exclude:  exclude,
+			Location: loc,
+		}
+		if since > 0 {
+			options.Since = time.Now().Add(-since)
+			if !cmd.Flags().Changed("lines") {
+				options.Lines = -1
+			}
+		}
+
+		printer := newLinePrinter(args, include, highlight)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return logtail.Tail(ctx, args, options, printer.print)
+	},
+}
+
+// compilePatterns 编译正则表达式列表
+func compilePatterns(patterns []string, ignoreCase bool) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		if ignoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errs.InvalidInput("无效的正则表达式 %q: %v", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// linePrinter 输出日志行，多个文件时加上对齐的彩色文件名前缀
+type linePrinter struct {
+	prefixes  []string
+	grep      []*regexp.Regexp
+	highlight []*regexp.Regexp
+}
+
+// newLinePrinter 创建输出器，只有一个文件时不显示前缀
+func newLinePrinter(paths []string, grep, highlight []*regexp.Regexp) *linePrinter {
+	p := &linePrinter{grep: grep, highlight: highlight}
+	if len(paths) < 2 {
+		return p
+	}
+	width := 0
+	for _, path := range paths {
+		width = max(width, len(path))
+	}
+	for i, path := range paths {
+		label := fmt.Sprintf("%-*s |", width, path)
+		p.prefixes = append(p.prefixes, color.New(fileColors[i%len(fileColors)]).Sprint(label)+" ")
+	}
+	return p
+}
+
+// print 输出一行
+func (p *linePrinter) print(line logtail.Line) {
+	prefix := ""
+	if p.prefixes != nil {
+		prefix = p.prefixes[line.Index]
+	}
+	fmt.Println(prefix + p.colorize(line.Text))
+}
+
+// 匹配部分的样式，数值大的优先
+const (
+	styleNone = iota
+	styleGrep
+	styleHighlight
+)
+
+// colorize 为匹配的部分着色；先在原文上标记每个字节的样式再统一输出，避免模式匹配到颜色控制字符
+func (p *linePrinter) colorize(text string) string {
+	if color.NoColor || (len(p.grep) == 0 && len(p.highlight) == 0) {
+		return text
+	}
+	styles := make([]int, len(text))
+	mark := func(patterns []*regexp.Regexp, style int) {
+		for _, re := range patterns {
+			for _, m := range re.FindAllStringIndex(text, -1) {
+				for i := m[0]; i < m[1]; i++ {
+					styles[i] = max(styles[i], style)
+				}
+			}
+		}
+	}
+	mark(p.grep, styleGrep)
+	mark(p.highlight, styleHighlight)
+
+	painters := map[int]*color.Color{
+		styleGrep:      color.New(color.FgRed, color.Bold),
+		styleHighlight: color.New(color.FgBlack, color.BgYellow),
+	}
+	var b strings.Builder
+	for start := 0; start < len(text); {
+		end := start
+		for end < len(text) && styles[end] == styles[start] {
+			end++
+		}
+		if painter, ok := painters[styles[start]]; ok {
+			b.WriteString(painter.Sprint(text[start:end]))
+		} else {
+			b.WriteString(text[start:end])
+		}
+		start = end
+	}
+	return b.String()
+}
+
+func init() {
+	tailCmd.Flags().IntP("lines", "n", 10, "开始时每个文件显示的最后几行（过滤后），-1 表示全部")
+	tailCmd.Flags().BoolP("follow", "f", false, "持续输出新写入的行")
+	tailCmd.Flags().StringArrayP("grep", "g", nil, "只显示匹配该正则表达式的行，可重复指定，满足任意一个即可")
+	tailCmd.Flags().StringArrayP("exclude", "v", nil, "不显示匹配该正则表达式的行，可重复指定")
+	tailCmd.Flags().StringArray("highlight", nil, "高亮匹配该正则表达式的部分，可重复指定")
+	tailCmd.Flags().BoolP("ignore-case", "i", false, "正则表达式忽略大小写")
+	flagtype.Duration(tailCmd.Flags(), "since", 0, time.Minute, "跳过时间早于该时长之前的行，如 10m、2h，纯数字表示分钟")
+	tailCmd.Flags().String("tz", "local", "解释日志中不含时区的时间使用的时区")
+}
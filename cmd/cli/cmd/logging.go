@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
+
+	"github.com/spf13/cobra"
+)
+
+// addLoggingFlags 注册全局日志标志
+//
+// 部分子命令已有同名的 -v/-q 短选项，这里只提供长选项，并始终从根命令读取取值。
+func addLoggingFlags(root *cobra.Command) {
+	root.PersistentFlags().Bool("verbose", false, "输出调试日志")
+	root.PersistentFlags().Bool("quiet", false, "只输出错误日志")
+	root.PersistentFlags().String("log-file", "", "将日志写入指定文件而不是标准错误")
+}
+
+// setupLogging 根据全局标志配置日志级别和输出位置
+func setupLogging(cmd *cobra.Command) error {
+	flags := cmd.Root().PersistentFlags()
+	verbose, _ := flags.GetBool("verbose")
+	quiet, _ := flags.GetBool("quiet")
+	logFile, _ := flags.GetString("log-file")
+
+	if verbose && quiet {
+		return i18n.Errorf("--verbose 和 --quiet 不能同时使用")
+	}
+
+	switch {
+	case verbose:
+		logger.SetLevel(logger.LevelDebug)
+	case quiet:
+		logger.SetLevel(logger.LevelError)
+	}
+
+	if logFile != "" {
+		// 进程退出时由操作系统关闭文件，写入不经过缓冲，不会丢失日志
+		file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return i18n.Errorf("打开日志文件失败: %v", err)
+		}
+		logger.SetOutput(file)
+	}
+	return nil
+}
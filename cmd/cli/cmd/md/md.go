@@ -0,0 +1,24 @@
+package md
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// MdCmd 表示Markdown命令组
+var MdCmd = &cobra.Command{
+	Use:   "md",
+	Short: "Markdown文档工具",
+	Long: `处理Markdown文档，方便在终端中阅读说明文档和运维手册。
+
+包含以下子命令:
+  render - 将Markdown渲染为终端文本或HTML
+
+示例:
+  %[1]s md render README.md
+  %[1]s md render runbook.md --format html -o runbook.html`,
+}
+
+func init() {
+	// 添加子命令
+	MdCmd.AddCommand(renderCmd)
+}
@@ -0,0 +1,129 @@
+package md
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/markdown"
+	"toolbox/pkg/termcolor"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// renderCmd 表示 md render 命令
+var renderCmd = &cobra.Command{
+	Use:   "render [文件]",
+	Short: "将Markdown渲染为终端文本或HTML",
+	Long: `将Markdown文档渲染为带颜色和样式的终端文本，或包含样式的独立HTML页面。
+支持GitHub风格的表格、删除线、任务列表和自动链接。未指定文件或文件为 - 时从标准输入读取。
+
+终端文本默认按终端宽度自动换行，可以用 --width 指定。
+--out 的扩展名为 .html 或 .htm 时默认输出HTML。文档中的原始HTML默认不输出到页面中，
+确认来源可信时可以用 --allow-html 保留。
+
+示例:
+  %[1]s md render README.md
+  %[1]s md render README.md --width 100 | less -R
+  %[1]s md render runbook.md -o runbook.html
+  %[1]s md render notes.md --format html --fragment
+  curl -s https://example.com/README.md | %[1]s md render`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		outPath, _ := cmd.Flags().GetString("out")
+		width, _ := cmd.Flags().GetInt("width")
+		title, _ := cmd.Flags().GetString("title")
+		fragment, _ := cmd.Flags().GetBool("fragment")
+		allowHTML, _ := cmd.Flags().GetBool("allow-html")
+
+		if !cmd.Flags().Changed("format") {
+			if ext := strings.ToLower(filepath.Ext(outPath)); ext == ".html" || ext == ".htm" {
+				format = "html"
+			}
+		}
+		if format != "terminal" && format != "html" {
+			return errs.InvalidInput("不支持的格式: %s（支持 terminal、html）", format)
+		}
+
+		path := "-"
+		if len(args) > 0 {
+			path = args[0]
+		}
+		source, err := readSource(path)
+		if err != nil {
+			return err
+		}
+
+		var rendered []byte
+		if format == "html" {
+			rendered, err = markdown.RenderHTML(source, markdown.HTMLOptions{Title: title, Fragment: fragment, AllowHTML: allowHTML})
+			if err != nil {
+				return errs.Wrap(err, "渲染HTML失败: %v", err)
+			}
+		} else {
+			if outPath != "" {
+				// 写入文件时不输出颜色
+				termcolor.SetEnabled(false)
+			}
+			if width <= 0 {
+				width = terminalWidth()
+			}
+			rendered = []byte(markdown.RenderTerminal(source, markdown.TerminalOptions{Width: width}))
+		}
+
+		if outPath == "" {
+			_, err = os.Stdout.Write(rendered)
+			return err
+		}
+		if err := os.WriteFile(outPath, rendered, 0644); err != nil {
+			return errs.Wrap(err, "写入文件失败: %v", err)
+		}
+		output.Infof(cmd, "已写入 %s\n", outPath)
+		return nil
+	},
+}
+
+// readSource 读取Markdown文件，- 表示标准输入
+func readSource(path string) ([]byte, error) {
+	if path == "-" {
+		if stat, _ := os.Stdin.Stat(); stat.Mode()&os.ModeCharDevice != 0 {
+			return nil, errs.InvalidInput("未指定输入文件，且无标准输入")
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, errs.Wrap(err, "读取标准输入失败: %v", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取文件失败: %v", err)
+	}
+	return data, nil
+}
+
+// terminalWidth 返回终端宽度，不是终端时返回80；过宽的终端限制为120列以便阅读
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+	return min(width, 120)
+}
+
+func init() {
+	renderCmd.Flags().StringP("format", "f", "terminal", "输出格式: terminal、html")
+	renderCmd.Flags().StringP("out", "o", "", "输出到文件而非标准输出")
+	renderCmd.Flags().IntP("width", "w", 0, "终端文本自动换行的宽度，0 表示使用终端宽度")
+	renderCmd.Flags().String("title", "", "HTML页面标题，默认为第一个标题的文字")
+	renderCmd.Flags().Bool("fragment", false, "只输出HTML正文片段，不包含页面框架和样式")
+	renderCmd.Flags().Bool("allow-html", false, "保留文档中的原始HTML")
+	renderCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{"terminal", "html"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"os"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/mockdata"
+
+	"github.com/spf13/cobra"
+)
+
+// typesCmd 表示 mock types 命令
+var typesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "列出支持的字段类型",
+	Long: `列出schema中可用的字段类型及其选项。
+
+所有类型都支持 null_rate 选项（0-1），按比例输出 null。`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		types := mockdata.Types()
+		return output.Render(cmd, types, func() {
+			table := output.NewTable(os.Stdout, []string{i18n.T("类型"), i18n.T("说明"), i18n.T("选项")})
+			for _, t := range types {
+				table.Append([]string{t.Name, t.Description, t.Options})
+			}
+			table.Render()
+		})
+	},
+}
@@ -6,6 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netutils"
 
 	"github.com/spf13/cobra"
@@ -17,16 +21,24 @@ var certCmd = &cobra.Command{
 	Long: `证书工具，用于检查和生成证书。
 
 支持的功能：
-1. 检查证书信息（有效期、颁发机构、证书链等）
-2. 生成自签名证书（用于开发测试）`,
+1. 检查证书信息（有效期、颁发机构、证书链等），支持本地文件和远程主机
+2. 生成自签名证书（用于开发测试）
+3. 生成证书签名请求（CSR）并使用CA签发证书
+4. 管理本地CA（签发、吊销证书，生成CRL）
+5. PEM与PKCS#12（.pfx）格式互相转换
+6. 批量监控证书有效期
+7. PEM、DER和PKCS#7格式转换`,
 }
 
 var certCheckCmd = &cobra.Command{
-	Use:   "check [证书文件]",
-	Short: "检查证书文件",
+	Use:   "check [证书文件|主机:端口]",
+	Short: "检查证书文件或远程主机证书",
 	Long: `检查证书文件的详细信息，包括有效期、颁发机构、证书链等。
 支持检查单个证书文件或包含完整证书链的文件。
 
+也可以指定远程主机地址（host:port），通过TLS握手获取服务器提供的证书链，
+并执行相同的有效期和信任检查，同时校验证书与主机名是否匹配。
+
 示例:
   # 检查单个证书文件
   %[1]s network cert check server.crt
@@ -35,35 +47,86 @@ var certCheckCmd = &cobra.Command{
   %[1]s network cert check fullchain.pem
 
   # 仅显示证书问题
-  %[1]s network cert check server.crt --issues-only`,
+  %[1]s network cert check server.crt --issues-only
+
+  # 检查远程主机的证书
+  %[1]s network cert check example.com:443
+
+  # 指定SNI和ALPN
+  %[1]s network cert check 10.0.0.5:8443 --sni api.example.com --alpn h2,http/1.1
+
+  # 使用私有CA验证证书（只信任指定的根证书）
+  %[1]s network cert check server.crt --ca-bundle ca/root.crt --intermediates ca/intermediate.crt --strict-ca`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		certFile := args[0]
+		target := args[0]
 		issuesOnly, _ := cmd.Flags().GetBool("issues-only")
+		remote, _ := cmd.Flags().GetBool("remote")
+		sni, _ := cmd.Flags().GetString("sni")
+		alpn, _ := cmd.Flags().GetStringSlice("alpn")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
+		caBundles, _ := cmd.Flags().GetStringSlice("ca-bundle")
+		intermediates, _ := cmd.Flags().GetStringSlice("intermediates")
+		strictCA, _ := cmd.Flags().GetBool("strict-ca")
+
+		// 文件不存在且形如 host:port 时视为远程主机
+		if !remote {
+			if _, err := os.Stat(target); err != nil && strings.Contains(target, ":") {
+				remote = true
+			}
+		}
 
-		checker := netutils.NewCertChecker(certFile)
+		var checker *netutils.CertChecker
+		if remote {
+			checker = netutils.NewRemoteCertChecker(target)
+			checker.ServerName = sni
+			checker.ALPN = alpn
+			checker.Timeout = timeout
+		} else {
+			checker = netutils.NewCertChecker(target)
+		}
+		checker.RootFiles = caBundles
+		checker.IntermediateFiles = intermediates
+		checker.StrictRoots = strictCA
 
 		// 获取证书信息
 		certs, err := checker.CheckCertificate()
 		if err != nil {
-			return fmt.Errorf("检查证书失败: %v", err)
+			return i18n.Errorf("检查证书失败: %v", err)
 		}
 
 		// 验证证书
 		issues, err := checker.ValidateCertificate()
 		if err != nil {
-			return fmt.Errorf("验证证书失败: %v", err)
+			return i18n.Errorf("验证证书失败: %v", err)
+		}
+
+		// 结构化输出
+		if output.IsStructured(cmd) {
+			result := certCheckOutput{
+				Target:     target,
+				Connection: checker.Connection,
+				Issues:     issues,
+				Valid:      len(issues) == 0,
+			}
+			if !issuesOnly {
+				result.Certificates = certs
+			}
+			if result.Issues == nil {
+				result.Issues = []string{}
+			}
+			return output.Render(cmd, result, nil)
 		}
 
 		// 如果只显示问题，且没有问题，则直接返回
 		if issuesOnly && len(issues) == 0 {
-			fmt.Println("证书有效，未发现问题")
+			fmt.Println(i18n.T("证书有效，未发现问题"))
 			return nil
 		}
 
 		// 如果有问题，显示问题列表
 		if len(issues) > 0 {
-			fmt.Println("发现以下问题：")
+			fmt.Println(i18n.T("发现以下问题："))
 			for _, issue := range issues {
 				fmt.Printf("- %s\n", issue)
 			}
@@ -72,27 +135,40 @@ var certCheckCmd = &cobra.Command{
 
 		// 如果不是只显示问题，则显示完整信息
 		if !issuesOnly {
+			if conn := checker.Connection; conn != nil {
+				fmt.Println(i18n.T("连接信息："))
+				fmt.Print(i18n.Tf("地址: %s\n", conn.Address))
+				fmt.Printf("SNI: %s\n", conn.ServerName)
+				fmt.Print(i18n.Tf("TLS版本: %s\n", conn.TLSVersion))
+				fmt.Print(i18n.Tf("密码套件: %s\n", conn.CipherSuite))
+				if conn.ALPN != "" {
+					fmt.Printf("ALPN: %s\n", conn.ALPN)
+				}
+				fmt.Print(i18n.Tf("主机名匹配: %v\n", conn.HostnameError == ""))
+				fmt.Println()
+			}
+
 			for i, cert := range certs {
 				if len(certs) > 1 {
-					fmt.Printf("\n证书 #%d:\n", i+1)
+					fmt.Print(i18n.Tf("\n证书 #%d:\n", i+1))
 				} else {
-					fmt.Println("证书信息：")
+					fmt.Println(i18n.T("证书信息："))
 				}
 
-				fmt.Printf("主体: %s\n", cert.Subject)
-				fmt.Printf("颁发者: %s\n", cert.Issuer)
-				fmt.Printf("生效时间: %s\n", cert.NotBefore.Format("2006-01-02 15:04:05"))
-				fmt.Printf("过期时间: %s\n", cert.NotAfter.Format("2006-01-02 15:04:05"))
-				fmt.Printf("剩余天数: %d\n", cert.RemainingDays)
-				fmt.Printf("序列号: %s\n", cert.SerialNumber)
-				fmt.Printf("签名算法: %s\n", cert.SignatureAlg)
-				fmt.Printf("公钥算法: %s\n", cert.PublicKeyAlg)
-				fmt.Printf("证书版本: %d\n", cert.Version)
-				fmt.Printf("是否为CA: %v\n", cert.IsCA)
-				fmt.Printf("是否由受信任的CA颁发: %v\n", cert.HasTrustedIssuer)
+				fmt.Print(i18n.Tf("主体: %s\n", cert.Subject))
+				fmt.Print(i18n.Tf("颁发者: %s\n", cert.Issuer))
+				fmt.Print(i18n.Tf("生效时间: %s\n", cert.NotBefore.Format("2006-01-02 15:04:05")))
+				fmt.Print(i18n.Tf("过期时间: %s\n", cert.NotAfter.Format("2006-01-02 15:04:05")))
+				fmt.Print(i18n.Tf("剩余天数: %d\n", cert.RemainingDays))
+				fmt.Print(i18n.Tf("序列号: %s\n", cert.SerialNumber))
+				fmt.Print(i18n.Tf("签名算法: %s\n", cert.SignatureAlg))
+				fmt.Print(i18n.Tf("公钥算法: %s\n", cert.PublicKeyAlg))
+				fmt.Print(i18n.Tf("证书版本: %d\n", cert.Version))
+				fmt.Print(i18n.Tf("是否为CA: %v\n", cert.IsCA))
+				fmt.Print(i18n.Tf("是否由受信任的CA颁发: %v\n", cert.HasTrustedIssuer))
 
 				if len(cert.DNSNames) > 0 {
-					fmt.Printf("DNS名称: %s\n", strings.Join(cert.DNSNames, ", "))
+					fmt.Print(i18n.Tf("DNS名称: %s\n", strings.Join(cert.DNSNames, ", ")))
 				}
 			}
 		}
@@ -101,6 +177,24 @@ var certCheckCmd = &cobra.Command{
 	},
 }
 
+// certCheckOutput cert check 的结构化输出
+type certCheckOutput struct {
+	Target       string                      `json:"target"`
+	Connection   *netutils.TLSConnectionInfo `json:"connection,omitempty"`
+	Certificates []*netutils.CertInfo        `json:"certificates,omitempty"`
+	Issues       []string                    `json:"issues"`
+	Valid        bool                        `json:"valid"`
+}
+
+// isSupportedKeyType 检查密钥类型是否受支持
+func isSupportedKeyType(keyType string) bool {
+	switch strings.ToLower(keyType) {
+	case "rsa2048", "rsa4096", "ecdsa-p256", "ecdsa-p384", "ed25519":
+		return true
+	}
+	return false
+}
+
 // askQuestion 从用户获取输入
 func askQuestion(reader *bufio.Reader, question string, defaultValue string) string {
 	if defaultValue != "" {
@@ -146,10 +240,17 @@ var certGenerateCmd = &cobra.Command{
   %[1]s network cert generate example.com
 
   # 使用所有默认值（不推荐）
-  %[1]s network cert generate example.com --no-interactive`,
+  %[1]s network cert generate example.com --no-interactive
+
+  # 生成ECDSA P-256证书
+  %[1]s network cert generate example.com --key-type ecdsa-p256 --no-interactive`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		noInteractive, _ := cmd.Flags().GetBool("no-interactive")
+		keyType, _ := cmd.Flags().GetString("key-type")
+		if !isSupportedKeyType(keyType) {
+			return i18n.Errorf("不支持的密钥类型: %s", keyType)
+		}
 		reader := bufio.NewReader(os.Stdin)
 		var name string
 
@@ -158,17 +259,17 @@ var certGenerateCmd = &cobra.Command{
 		}
 
 		if !noInteractive {
-			fmt.Println("欢迎使用证书生成向导！")
-			fmt.Println("请回答以下问题，或直接按回车使用默认值。")
+			fmt.Println(i18n.T("欢迎使用证书生成向导！"))
+			fmt.Println(i18n.T("请回答以下问题，或直接按回车使用默认值。"))
 			fmt.Println()
 
 			// 1. 获取通用名称（域名）
 			if name == "" {
-				name = askQuestion(reader, "请输入域名（例如：example.com）", "localhost")
+				name = askQuestion(reader, i18n.T("请输入域名（例如：example.com）"), "localhost")
 			}
 
 			// 2. 确认是否需要通配符证书
-			if askYesNo(reader, "是否需要通配符证书（可用于所有子域名）", false) {
+			if askYesNo(reader, i18n.T("是否需要通配符证书（可用于所有子域名）"), false) {
 				if !strings.HasPrefix(name, "*.") {
 					name = "*." + strings.TrimPrefix(name, "www.")
 				}
@@ -177,8 +278,8 @@ var certGenerateCmd = &cobra.Command{
 			// 3. 获取其他DNS名称
 			var dnsNames []string
 			baseDomain := strings.TrimPrefix(name, "*.")
-			if askYesNo(reader, "是否添加其他DNS名称", true) {
-				fmt.Println("请输入其他DNS名称，每行一个，留空结束：")
+			if askYesNo(reader, i18n.T("是否添加其他DNS名称"), true) {
+				fmt.Println(i18n.T("请输入其他DNS名称，每行一个，留空结束："))
 				for {
 					dns := askQuestion(reader, "> ", "")
 					if dns == "" {
@@ -196,8 +297,8 @@ var certGenerateCmd = &cobra.Command{
 
 			// 4. 获取IP地址
 			var ips []string
-			if askYesNo(reader, "是否添加IP地址", false) {
-				fmt.Println("请输入IP地址，每行一个，留空结束：")
+			if askYesNo(reader, i18n.T("是否添加IP地址"), false) {
+				fmt.Println(i18n.T("请输入IP地址，每行一个，留空结束："))
 				for {
 					ip := askQuestion(reader, "> ", "")
 					if ip == "" {
@@ -209,45 +310,43 @@ var certGenerateCmd = &cobra.Command{
 
 			// 5. 获取有效期
 			days := 3650
-			if !askYesNo(reader, "是否使用默认有效期（10年）", true) {
+			if !askYesNo(reader, i18n.T("是否使用默认有效期（10年）"), true) {
 				for {
-					daysStr := askQuestion(reader, "请输入有效期（天数）", "3650")
+					daysStr := askQuestion(reader, i18n.T("请输入有效期（天数）"), "3650")
 					fmt.Sscanf(daysStr, "%d", &days)
 					if days > 0 {
 						break
 					}
-					fmt.Println("请输入大于0的数字！")
+					fmt.Println(i18n.T("请输入大于0的数字！"))
 				}
 			}
 
-			// 6. 获取密钥长度
-			bits := 2048
-			if !askYesNo(reader, "是否使用默认密钥长度（2048位）", true) {
+			// 6. 获取密钥类型
+			if !cmd.Flags().Changed("key-type") && !askYesNo(reader, i18n.T("是否使用默认密钥类型（RSA 2048位）"), true) {
 				for {
-					bitsStr := askQuestion(reader, "请输入密钥长度（2048或4096）", "2048")
-					fmt.Sscanf(bitsStr, "%d", &bits)
-					if bits == 2048 || bits == 4096 {
+					keyType = askQuestion(reader, i18n.T("请输入密钥类型（rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519）"), "rsa2048")
+					if isSupportedKeyType(keyType) {
 						break
 					}
-					fmt.Println("请输入2048或4096！")
+					fmt.Println(i18n.T("不支持的密钥类型！"))
 				}
 			}
 
 			// 7. 获取输出文件名
-			certFile := askQuestion(reader, "请输入证书文件名", name+".crt")
-			keyFile := askQuestion(reader, "请输入私钥文件名", name+".key")
+			certFile := askQuestion(reader, i18n.T("请输入证书文件名"), name+".crt")
+			keyFile := askQuestion(reader, i18n.T("请输入私钥文件名"), name+".key")
 
 			// 创建输出目录
 			certDir := filepath.Dir(certFile)
 			keyDir := filepath.Dir(keyFile)
 			if certDir != "." {
 				if err := os.MkdirAll(certDir, 0755); err != nil {
-					return fmt.Errorf("创建证书目录失败: %v", err)
+					return i18n.Errorf("创建证书目录失败: %v", err)
 				}
 			}
 			if keyDir != "." {
 				if err := os.MkdirAll(keyDir, 0755); err != nil {
-					return fmt.Errorf("创建私钥目录失败: %v", err)
+					return i18n.Errorf("创建私钥目录失败: %v", err)
 				}
 			}
 
@@ -258,20 +357,20 @@ var certGenerateCmd = &cobra.Command{
 				IPAddresses: ips,
 				ValidDays:   days,
 				IsCA:        false,
-				KeySize:     bits,
+				KeyType:     keyType,
 			}
 
 			if err := netutils.GenerateCertificate(config, certFile, keyFile); err != nil {
-				return fmt.Errorf("生成证书失败: %v", err)
+				return i18n.Errorf("生成证书失败: %v", err)
 			}
 
-			fmt.Printf("\n证书已生成：\n证书文件：%s\n私钥文件：%s\n", certFile, keyFile)
+			fmt.Print(i18n.Tf("\n证书已生成：\n证书文件：%s\n私钥文件：%s\n", certFile, keyFile))
 			return nil
 		}
 
 		// 非交互式模式
 		if name == "" {
-			return fmt.Errorf("非交互式模式下必须指定域名")
+			return i18n.Errorf("非交互式模式下必须指定域名")
 		}
 
 		certFile := name
@@ -288,14 +387,14 @@ var certGenerateCmd = &cobra.Command{
 			DNSNames:   []string{name},
 			ValidDays:  3650,
 			IsCA:       false,
-			KeySize:    2048,
+			KeyType:    keyType,
 		}
 
 		if err := netutils.GenerateCertificate(config, certFile, keyFile); err != nil {
-			return fmt.Errorf("生成证书失败: %v", err)
+			return i18n.Errorf("生成证书失败: %v", err)
 		}
 
-		fmt.Printf("证书已生成：\n证书文件：%s\n私钥文件：%s\n", certFile, keyFile)
+		fmt.Print(i18n.Tf("证书已生成：\n证书文件：%s\n私钥文件：%s\n", certFile, keyFile))
 		return nil
 	},
 }
@@ -303,9 +402,17 @@ var certGenerateCmd = &cobra.Command{
 func init() {
 	// 检查命令的选项
 	certCheckCmd.Flags().Bool("issues-only", false, "仅显示证书问题")
+	certCheckCmd.Flags().Bool("remote", false, "将参数视为远程主机地址")
+	certCheckCmd.Flags().String("sni", "", "TLS握手使用的服务器名称（默认为主机名）")
+	certCheckCmd.Flags().StringSlice("alpn", nil, "TLS握手时声明的ALPN协议（例如: h2,http/1.1）")
+	flagtype.Duration(certCheckCmd.Flags(), "timeout", 10*time.Second, time.Second, "连接远程主机的超时时间")
+	certCheckCmd.Flags().StringSlice("ca-bundle", nil, "额外信任的根证书文件（PEM）")
+	certCheckCmd.Flags().StringSlice("intermediates", nil, "额外的中间证书文件（PEM）")
+	certCheckCmd.Flags().Bool("strict-ca", false, "只信任 --ca-bundle 指定的根证书，不使用系统根证书")
 
 	// 生成命令的选项
 	certGenerateCmd.Flags().Bool("no-interactive", false, "使用默认值（不进行交互）")
+	certGenerateCmd.Flags().String("key-type", "rsa2048", "密钥类型 (rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519)")
 
 	certCmd.AddCommand(certCheckCmd)
 	certCmd.AddCommand(certGenerateCmd)
@@ -0,0 +1,375 @@
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netutils"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// revokeReasons 吊销原因名称与RFC 5280 CRLReason代码的对应关系
+var revokeReasons = map[string]int{
+	"unspecified":          0,
+	"keyCompromise":        1,
+	"caCompromise":         2,
+	"affiliationChanged":   3,
+	"superseded":           4,
+	"cessationOfOperation": 5,
+}
+
+var certCaCmd = &cobra.Command{
+	Use:   "ca",
+	Short: "本地CA管理",
+	Long: `管理用于实验室和开发环境的本地证书颁发机构（CA）。
+
+CA目录结构：
+  root.crt / root.key                  根CA证书和私钥
+  intermediate.crt / intermediate.key  中间CA证书和私钥（可选）
+  index.json                           签发记录
+  certs/                               已签发证书的副本
+  crl.pem                              证书吊销列表
+
+示例:
+  %[1]s network cert ca init --intermediate
+  %[1]s network cert ca issue example.com --dns www.example.com
+  %[1]s network cert ca issue alice --profile client
+  %[1]s network cert ca list
+  %[1]s network cert ca revoke 3F2A --reason keyCompromise
+  %[1]s network cert ca crl`,
+}
+
+var certCaInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "初始化CA",
+	Long: `在CA目录中创建根CA，可选创建由根CA签发的中间CA。
+创建中间CA后，之后的证书都由中间CA签发。
+
+示例:
+  %[1]s network cert ca init
+  %[1]s network cert ca init --name "Example Root CA" --org "Example Inc" --intermediate
+  %[1]s network cert ca init --dir /srv/pki --key-type ecdsa-p384 --days 7300`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		name, _ := cmd.Flags().GetString("name")
+		org, _ := cmd.Flags().GetStringSlice("org")
+		country, _ := cmd.Flags().GetStringSlice("country")
+		keyType, _ := cmd.Flags().GetString("key-type")
+		days, _ := cmd.Flags().GetInt("days")
+		intermediate, _ := cmd.Flags().GetBool("intermediate")
+		intermediateDays, _ := cmd.Flags().GetInt("intermediate-days")
+
+		if !isSupportedKeyType(keyType) {
+			return i18n.Errorf("不支持的密钥类型: %s", keyType)
+		}
+
+		ca, err := netutils.InitCA(dir, netutils.CAInitOptions{
+			CommonName:       name,
+			Organization:     org,
+			Country:          country,
+			KeyType:          keyType,
+			ValidDays:        days,
+			Intermediate:     intermediate,
+			IntermediateDays: intermediateDays,
+		})
+		if err != nil {
+			return i18n.Errorf("初始化CA失败: %v", err)
+		}
+
+		signer := ca.SignerCertificate()
+		fmt.Print(i18n.Tf("CA已初始化：%s\n", dir))
+		fmt.Print(i18n.Tf("签发CA: %s\n", signer.Subject.CommonName))
+		fmt.Print(i18n.Tf("过期时间: %s\n", signer.NotAfter.Format("2006-01-02 15:04:05")))
+		return nil
+	},
+}
+
+var certCaIssueCmd = &cobra.Command{
+	Use:   "issue [名称]",
+	Short: "由CA签发证书",
+	Long: `生成私钥并由CA签发服务器或客户端证书。
+
+示例:
+  %[1]s network cert ca issue example.com
+  %[1]s network cert ca issue example.com --dns www.example.com --ip 10.0.0.5 --days 90
+  %[1]s network cert ca issue alice --profile client --key-type ed25519`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		dir, _ := cmd.Flags().GetString("dir")
+		profile, _ := cmd.Flags().GetString("profile")
+		keyType, _ := cmd.Flags().GetString("key-type")
+		dnsNames, _ := cmd.Flags().GetStringSlice("dns")
+		ips, _ := cmd.Flags().GetStringSlice("ip")
+		days, _ := cmd.Flags().GetInt("days")
+		certFile, _ := cmd.Flags().GetString("out")
+		keyFile, _ := cmd.Flags().GetString("key")
+
+		if !isSupportedKeyType(keyType) {
+			return i18n.Errorf("不支持的密钥类型: %s", keyType)
+		}
+		if profile == netutils.ProfileCA {
+			return i18n.Errorf("请使用 init --intermediate 创建中间CA")
+		}
+
+		baseName := strings.TrimPrefix(name, "*.")
+		if certFile == "" {
+			certFile = baseName + ".crt"
+		}
+		if keyFile == "" {
+			keyFile = baseName + ".key"
+		}
+		// 服务器证书的通用名称也应出现在DNS名称中
+		if profile != netutils.ProfileClient {
+			dnsNames = append([]string{name}, dnsNames...)
+		}
+
+		ca, err := netutils.OpenCA(dir)
+		if err != nil {
+			return err
+		}
+
+		entry, err := ca.Issue(netutils.CertConfig{
+			CommonName:  name,
+			DNSNames:    dnsNames,
+			IPAddresses: ips,
+			KeyType:     keyType,
+		}, netutils.SignProfile{
+			Usage:     profile,
+			ValidDays: days,
+		}, certFile, keyFile)
+		if err != nil {
+			return i18n.Errorf("签发证书失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("证书已签发：\n证书文件：%s\n私钥文件：%s\n", certFile, keyFile))
+		fmt.Print(i18n.Tf("序列号: %s\n", entry.Serial))
+		fmt.Print(i18n.Tf("CA证书: %s\n", ca.ChainFile()))
+		return nil
+	},
+}
+
+var certCaSignCmd = &cobra.Command{
+	Use:   "sign [CSR文件]",
+	Short: "由CA签发证书签名请求",
+	Long: `由CA签发外部提交的证书签名请求（CSR），并记录到CA索引中。
+
+示例:
+  %[1]s network cert ca sign example.com.csr
+  %[1]s network cert ca sign client.csr --profile client -o client.crt`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		csrFile := args[0]
+		dir, _ := cmd.Flags().GetString("dir")
+		profile, _ := cmd.Flags().GetString("profile")
+		days, _ := cmd.Flags().GetInt("days")
+		certFile, _ := cmd.Flags().GetString("out")
+
+		if profile == netutils.ProfileCA {
+			return i18n.Errorf("请使用 init --intermediate 创建中间CA")
+		}
+		if certFile == "" {
+			certFile = strings.TrimSuffix(csrFile, ".csr") + ".crt"
+		}
+
+		csrData, err := ioutil.ReadFile(csrFile)
+		if err != nil {
+			return i18n.Errorf("读取证书签名请求失败: %v", err)
+		}
+
+		ca, err := netutils.OpenCA(dir)
+		if err != nil {
+			return err
+		}
+
+		certPEM, entry, err := ca.SignCSR(csrData, netutils.SignProfile{
+			Usage:     profile,
+			ValidDays: days,
+		})
+		if err != nil {
+			return i18n.Errorf("签发证书失败: %v", err)
+		}
+		if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+			return i18n.Errorf("写入证书文件失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("证书已签发：%s\n", certFile))
+		fmt.Print(i18n.Tf("序列号: %s\n", entry.Serial))
+		return nil
+	},
+}
+
+var certCaListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出CA签发的证书",
+	Long: `列出CA签发的所有证书及其状态。
+
+示例:
+  %[1]s network cert ca list
+  %[1]s network cert ca list --dir /srv/pki`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+
+		ca, err := netutils.OpenCA(dir)
+		if err != nil {
+			return err
+		}
+
+		entries := ca.List()
+		return output.Render(cmd, entries, func() {
+			if len(entries) == 0 {
+				fmt.Println(i18n.T("CA尚未签发任何证书"))
+				return
+			}
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{i18n.T("序列号"), i18n.T("名称"), i18n.T("用途"), i18n.T("过期时间"), i18n.T("状态")})
+			table.SetBorder(false)
+			for _, entry := range entries {
+				status := i18n.T("有效")
+				if entry.Status == netutils.CertStatusRevoked {
+					status = color.RedString(i18n.T("已吊销"))
+				}
+				serial := entry.Serial
+				if len(serial) > 16 {
+					serial = serial[:16] + "..."
+				}
+				table.Append([]string{
+					serial,
+					entry.CommonName,
+					entry.Profile,
+					entry.NotAfter.Format("2006-01-02"),
+					status,
+				})
+			}
+			table.Render()
+		})
+	},
+}
+
+var certCaRevokeCmd = &cobra.Command{
+	Use:   "revoke [序列号]",
+	Short: "吊销证书",
+	Long: `吊销CA签发的证书，序列号可以使用唯一的前缀。吊销后需要重新生成CRL。
+
+吊销原因（--reason）:
+  unspecified, keyCompromise, caCompromise, affiliationChanged,
+  superseded, cessationOfOperation
+
+示例:
+  %[1]s network cert ca revoke 3F2A9C
+  %[1]s network cert ca revoke 3F2A9C --reason keyCompromise`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		reasonName, _ := cmd.Flags().GetString("reason")
+
+		reason, ok := revokeReasons[reasonName]
+		if !ok {
+			return i18n.Errorf("不支持的吊销原因: %s", reasonName)
+		}
+
+		ca, err := netutils.OpenCA(dir)
+		if err != nil {
+			return err
+		}
+
+		entry, err := ca.Revoke(args[0], reason)
+		if err != nil {
+			return i18n.Errorf("吊销证书失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("证书已吊销：%s (%s)\n", entry.CommonName, entry.Serial))
+		fmt.Println(i18n.T("请执行 cert ca crl 重新生成证书吊销列表"))
+		return nil
+	},
+}
+
+var certCaCrlCmd = &cobra.Command{
+	Use:   "crl",
+	Short: "生成证书吊销列表",
+	Long: `根据吊销记录生成证书吊销列表（CRL），保存为CA目录中的 crl.pem。
+
+示例:
+  %[1]s network cert ca crl
+  %[1]s network cert ca crl --days 7 -o /var/www/pki/crl.pem`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		days, _ := cmd.Flags().GetInt("days")
+		outFile, _ := cmd.Flags().GetString("out")
+
+		ca, err := netutils.OpenCA(dir)
+		if err != nil {
+			return err
+		}
+
+		crlPEM, err := ca.GenerateCRL(days)
+		if err != nil {
+			return err
+		}
+		if outFile != "" {
+			if err := ioutil.WriteFile(outFile, crlPEM, 0644); err != nil {
+				return i18n.Errorf("写入CRL文件失败: %v", err)
+			}
+		}
+
+		revoked := 0
+		for _, entry := range ca.List() {
+			if entry.Status == netutils.CertStatusRevoked {
+				revoked++
+			}
+		}
+		fmt.Print(i18n.Tf("CRL已生成，包含 %d 个吊销的证书\n", revoked))
+		return nil
+	},
+}
+
+func init() {
+	certCaCmd.PersistentFlags().String("dir", "ca", "CA目录")
+
+	// 初始化命令的选项
+	certCaInitCmd.Flags().String("name", "Toolbox Root CA", "根CA的通用名称")
+	certCaInitCmd.Flags().StringSlice("org", nil, "组织名称")
+	certCaInitCmd.Flags().StringSlice("country", nil, "国家代码")
+	certCaInitCmd.Flags().String("key-type", "ecdsa-p256", "密钥类型 (rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519)")
+	certCaInitCmd.Flags().Int("days", 3650, "根CA有效期（天）")
+	certCaInitCmd.Flags().Bool("intermediate", false, "同时创建中间CA")
+	certCaInitCmd.Flags().Int("intermediate-days", 1825, "中间CA有效期（天）")
+
+	// 签发命令的选项
+	certCaIssueCmd.Flags().String("profile", netutils.ProfileServer, "证书用途 (server, client, both)")
+	certCaIssueCmd.Flags().String("key-type", "ecdsa-p256", "密钥类型 (rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519)")
+	certCaIssueCmd.Flags().StringSlice("dns", nil, "额外的DNS名称")
+	certCaIssueCmd.Flags().StringSlice("ip", nil, "IP地址")
+	certCaIssueCmd.Flags().Int("days", 365, "证书有效期（天）")
+	certCaIssueCmd.Flags().StringP("out", "o", "", "证书输出文件（默认为 <名称>.crt）")
+	certCaIssueCmd.Flags().String("key", "", "私钥输出文件（默认为 <名称>.key）")
+
+	certCaSignCmd.Flags().String("profile", netutils.ProfileServer, "证书用途 (server, client, both)")
+	certCaSignCmd.Flags().Int("days", 365, "证书有效期（天）")
+	certCaSignCmd.Flags().StringP("out", "o", "", "证书输出文件（默认为CSR文件名加 .crt）")
+
+	// 吊销命令的选项
+	certCaRevokeCmd.Flags().String("reason", "unspecified", "吊销原因")
+
+	// CRL命令的选项
+	certCaCrlCmd.Flags().Int("days", 30, "CRL有效期（天）")
+	certCaCrlCmd.Flags().StringP("out", "o", "", "额外输出CRL的文件")
+
+	certCaCmd.AddCommand(certCaInitCmd)
+	certCaCmd.AddCommand(certCaIssueCmd)
+	certCaCmd.AddCommand(certCaSignCmd)
+	certCaCmd.AddCommand(certCaListCmd)
+	certCaCmd.AddCommand(certCaRevokeCmd)
+	certCaCmd.AddCommand(certCaCrlCmd)
+	certCmd.AddCommand(certCaCmd)
+}
@@ -0,0 +1,86 @@
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netutils"
+
+	"github.com/spf13/cobra"
+)
+
+var certConvertCmd = &cobra.Command{
+	Use:   "convert [输入文件]",
+	Short: "转换证书和私钥格式",
+	Long: `在PEM和DER格式之间转换证书、私钥、CSR和CRL，并支持展开PKCS#7（.p7b）证书链。
+输入格式自动识别。DER格式每个文件只能保存一个对象，
+输入包含多个对象（如证书链）时会按序号输出多个文件。
+
+示例:
+  # DER证书转换为PEM
+  %[1]s network cert convert server.der --to pem
+
+  # PEM私钥转换为DER
+  %[1]s network cert convert server.key --to der -o server.key.der
+
+  # 展开PKCS#7证书链为PEM
+  %[1]s network cert convert chain.p7b --to pem -o chain.pem
+
+  # 查看文件内容而不转换
+  %[1]s network cert convert chain.p7b --info`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inFile := args[0]
+		to, _ := cmd.Flags().GetString("to")
+		outFile, _ := cmd.Flags().GetString("out")
+		info, _ := cmd.Flags().GetBool("info")
+
+		if info {
+			data, err := ioutil.ReadFile(inFile)
+			if err != nil {
+				return i18n.Errorf("读取文件失败: %v", err)
+			}
+			objects, format, err := netutils.ParseCertObjects(data)
+			if err != nil {
+				return err
+			}
+			fmt.Print(i18n.Tf("格式: %s，包含 %d 个对象\n", strings.ToUpper(format), len(objects)))
+			for i, obj := range objects {
+				fmt.Printf("  %d. %s\n", i+1, obj.Summary)
+			}
+			return nil
+		}
+
+		to = strings.ToLower(to)
+		if to != netutils.FormatPEM && to != netutils.FormatDER {
+			return i18n.Errorf("必须通过 --to 指定目标格式 (pem, der)")
+		}
+		if outFile == "" {
+			outFile = strings.TrimSuffix(inFile, filepath.Ext(inFile)) + "." + to
+		}
+		if outFile == inFile {
+			return i18n.Errorf("输出文件不能与输入文件相同，请使用 -o 指定")
+		}
+
+		written, err := netutils.ConvertCertFile(inFile, outFile, to)
+		if err != nil {
+			return i18n.Errorf("转换失败: %v", err)
+		}
+
+		fmt.Println(i18n.T("转换完成："))
+		for _, name := range written {
+			fmt.Printf("  %s\n", name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	certConvertCmd.Flags().String("to", "", "目标格式 (pem, der)")
+	certConvertCmd.Flags().StringP("out", "o", "", "输出文件（默认替换输入文件的扩展名）")
+	certConvertCmd.Flags().Bool("info", false, "只显示文件内容，不进行转换")
+
+	certCmd.AddCommand(certConvertCmd)
+}
@@ -0,0 +1,154 @@
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netutils"
+
+	"github.com/spf13/cobra"
+)
+
+var certCsrCmd = &cobra.Command{
+	Use:   "csr [域名]",
+	Short: "生成证书签名请求（CSR）",
+	Long: `生成私钥和证书签名请求（CSR），可以提交给外部CA或使用 cert sign 命令签发。
+
+示例:
+  # 为域名生成CSR和私钥
+  %[1]s network cert csr example.com
+
+  # 添加额外的DNS名称和IP地址
+  %[1]s network cert csr example.com --dns www.example.com --ip 10.0.0.5
+
+  # 使用ECDSA密钥并指定组织信息
+  %[1]s network cert csr example.com --key-type ecdsa-p256 --org "Example Inc" --country CN`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		keyType, _ := cmd.Flags().GetString("key-type")
+		dnsNames, _ := cmd.Flags().GetStringSlice("dns")
+		ips, _ := cmd.Flags().GetStringSlice("ip")
+		org, _ := cmd.Flags().GetStringSlice("org")
+		country, _ := cmd.Flags().GetStringSlice("country")
+		province, _ := cmd.Flags().GetStringSlice("province")
+		locality, _ := cmd.Flags().GetStringSlice("locality")
+		csrFile, _ := cmd.Flags().GetString("out")
+		keyFile, _ := cmd.Flags().GetString("key")
+
+		if !isSupportedKeyType(keyType) {
+			return i18n.Errorf("不支持的密钥类型: %s", keyType)
+		}
+
+		baseName := strings.TrimPrefix(name, "*.")
+		if csrFile == "" {
+			csrFile = baseName + ".csr"
+		}
+		if keyFile == "" {
+			keyFile = baseName + ".key"
+		}
+
+		// 通配符证书之外，通用名称也应出现在DNS名称中
+		if !strings.HasPrefix(name, "*.") || len(dnsNames) == 0 {
+			dnsNames = append([]string{name}, dnsNames...)
+		}
+
+		config := netutils.CertConfig{
+			CommonName:   name,
+			Organization: org,
+			Country:      country,
+			Province:     province,
+			Locality:     locality,
+			DNSNames:     dnsNames,
+			IPAddresses:  ips,
+			KeyType:      keyType,
+		}
+
+		csrPEM, err := netutils.GenerateCSR(config, keyFile)
+		if err != nil {
+			return i18n.Errorf("生成CSR失败: %v", err)
+		}
+		if err := ioutil.WriteFile(csrFile, csrPEM, 0644); err != nil {
+			return i18n.Errorf("写入CSR文件失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("CSR已生成：\nCSR文件：%s\n私钥文件：%s\n", csrFile, keyFile))
+		return nil
+	},
+}
+
+var certSignCmd = &cobra.Command{
+	Use:   "sign [CSR文件]",
+	Short: "使用CA签发证书签名请求",
+	Long: `使用指定的CA证书和私钥签发证书签名请求（CSR）。
+
+证书用途（--profile）:
+  server  服务器证书（默认）
+  client  客户端证书
+  both    同时用于服务器和客户端认证
+  ca      中间CA证书
+
+示例:
+  # 签发服务器证书
+  %[1]s network cert sign example.com.csr --ca-cert ca.crt --ca-key ca.key
+
+  # 签发有效期90天的客户端证书
+  %[1]s network cert sign client.csr --ca-cert ca.crt --ca-key ca.key --profile client --days 90
+
+  # 签发中间CA证书
+  %[1]s network cert sign intermediate.csr --ca-cert root.crt --ca-key root.key --profile ca -o intermediate.crt`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		csrFile := args[0]
+		caCert, _ := cmd.Flags().GetString("ca-cert")
+		caKey, _ := cmd.Flags().GetString("ca-key")
+		profile, _ := cmd.Flags().GetString("profile")
+		days, _ := cmd.Flags().GetInt("days")
+		pathLen, _ := cmd.Flags().GetInt("path-len")
+		certFile, _ := cmd.Flags().GetString("out")
+
+		if caCert == "" || caKey == "" {
+			return i18n.Errorf("必须同时指定 --ca-cert 和 --ca-key")
+		}
+		if certFile == "" {
+			certFile = strings.TrimSuffix(csrFile, ".csr") + ".crt"
+		}
+
+		err := netutils.SignCSRFile(csrFile, caCert, caKey, netutils.SignProfile{
+			Usage:     profile,
+			ValidDays: days,
+			PathLen:   pathLen,
+		}, certFile)
+		if err != nil {
+			return i18n.Errorf("签发证书失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("证书已签发：%s\n", certFile))
+		return nil
+	},
+}
+
+func init() {
+	// CSR命令的选项
+	certCsrCmd.Flags().String("key-type", "rsa2048", "密钥类型 (rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519)")
+	certCsrCmd.Flags().StringSlice("dns", nil, "额外的DNS名称")
+	certCsrCmd.Flags().StringSlice("ip", nil, "IP地址")
+	certCsrCmd.Flags().StringSlice("org", nil, "组织名称")
+	certCsrCmd.Flags().StringSlice("country", nil, "国家代码")
+	certCsrCmd.Flags().StringSlice("province", nil, "省份")
+	certCsrCmd.Flags().StringSlice("locality", nil, "城市")
+	certCsrCmd.Flags().StringP("out", "o", "", "CSR输出文件（默认为 <域名>.csr）")
+	certCsrCmd.Flags().String("key", "", "私钥输出文件（默认为 <域名>.key）")
+
+	// 签发命令的选项
+	certSignCmd.Flags().String("ca-cert", "", "CA证书文件")
+	certSignCmd.Flags().String("ca-key", "", "CA私钥文件")
+	certSignCmd.Flags().String("profile", netutils.ProfileServer, "证书用途 (server, client, both, ca)")
+	certSignCmd.Flags().Int("days", 365, "证书有效期（天）")
+	certSignCmd.Flags().Int("path-len", -1, "中间CA允许的下级CA层数，-1表示不限制")
+	certSignCmd.Flags().StringP("out", "o", "", "证书输出文件（默认为CSR文件名加 .crt）")
+
+	certCmd.AddCommand(certCsrCmd)
+	certCmd.AddCommand(certSignCmd)
+}
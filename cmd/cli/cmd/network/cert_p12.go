@@ -0,0 +1,133 @@
+package network
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netutils"
+
+	"github.com/spf13/cobra"
+)
+
+var certExportP12Cmd = &cobra.Command{
+	Use:   "export-p12",
+	Short: "将PEM证书和私钥导出为PKCS#12（.pfx）",
+	Long: `将PEM格式的证书和私钥打包为PKCS#12（.pfx/.p12）文件，
+用于Windows/IIS、Java密钥库等需要PKCS#12格式的场景。
+
+如果证书文件包含完整证书链，其余证书会一并写入。
+未指定 --password 时将提示输入密码。
+
+示例:
+  %[1]s network cert export-p12 --cert server.crt --key server.key -o server.pfx
+  %[1]s network cert export-p12 --cert server.crt --key server.key --ca ca.crt -o server.pfx --password secret
+  %[1]s network cert export-p12 --cert server.crt --key server.key -o server.pfx --legacy`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		certFile, _ := cmd.Flags().GetString("cert")
+		keyFile, _ := cmd.Flags().GetString("key")
+		caFiles, _ := cmd.Flags().GetStringSlice("ca")
+		outFile, _ := cmd.Flags().GetString("out")
+		legacy, _ := cmd.Flags().GetBool("legacy")
+
+		if certFile == "" || keyFile == "" {
+			return i18n.Errorf("必须同时指定 --cert 和 --key")
+		}
+		if outFile == "" {
+			outFile = strings.TrimSuffix(certFile, ".crt") + ".pfx"
+		}
+
+		password := readP12Password(cmd)
+
+		info, err := netutils.ExportPKCS12(certFile, keyFile, outFile, netutils.PKCS12ExportOptions{
+			Password: password,
+			Legacy:   legacy,
+			CAFiles:  caFiles,
+		})
+		if err != nil {
+			return i18n.Errorf("导出PKCS#12失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("PKCS#12文件已生成：%s\n", outFile))
+		printP12Info(info)
+		return nil
+	},
+}
+
+var certImportP12Cmd = &cobra.Command{
+	Use:   "import-p12 [PKCS#12文件]",
+	Short: "将PKCS#12（.pfx）拆分为PEM证书和私钥",
+	Long: `将PKCS#12（.pfx/.p12）文件拆分为PEM格式的证书和私钥。
+默认将CA证书追加到证书文件中，形成完整证书链。
+未指定 --password 时将提示输入密码。
+
+示例:
+  %[1]s network cert import-p12 server.pfx
+  %[1]s network cert import-p12 server.pfx --cert server.crt --key server.key --password secret
+  %[1]s network cert import-p12 server.pfx --no-chain`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pfxFile := args[0]
+		certFile, _ := cmd.Flags().GetString("cert")
+		keyFile, _ := cmd.Flags().GetString("key")
+		noChain, _ := cmd.Flags().GetBool("no-chain")
+
+		baseName := strings.TrimSuffix(strings.TrimSuffix(pfxFile, ".pfx"), ".p12")
+		if certFile == "" {
+			certFile = baseName + ".crt"
+		}
+		if keyFile == "" {
+			keyFile = baseName + ".key"
+		}
+
+		password := readP12Password(cmd)
+
+		info, err := netutils.ImportPKCS12(pfxFile, password, certFile, keyFile, !noChain)
+		if err != nil {
+			return i18n.Errorf("导入PKCS#12失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("已导出：\n证书文件：%s\n私钥文件：%s\n", certFile, keyFile))
+		printP12Info(info)
+		return nil
+	},
+}
+
+// readP12Password 获取PKCS#12密码，未通过参数指定时从标准输入读取
+func readP12Password(cmd *cobra.Command) string {
+	if cmd.Flags().Changed("password") {
+		password, _ := cmd.Flags().GetString("password")
+		return password
+	}
+	return askQuestion(bufio.NewReader(os.Stdin), i18n.T("请输入PKCS#12密码"), "")
+}
+
+// printP12Info 显示PKCS#12内容摘要
+func printP12Info(info *netutils.PKCS12Info) {
+	fmt.Print(i18n.Tf("主体: %s\n", info.Subject))
+	fmt.Print(i18n.Tf("颁发者: %s\n", info.Issuer))
+	fmt.Print(i18n.Tf("私钥类型: %s\n", info.KeyType))
+	fmt.Print(i18n.Tf("CA证书数量: %d\n", info.CACount))
+	fmt.Print(i18n.Tf("过期时间: %s\n", info.NotAfter))
+}
+
+func init() {
+	// 导出命令的选项
+	certExportP12Cmd.Flags().String("cert", "", "PEM证书文件（可包含证书链）")
+	certExportP12Cmd.Flags().String("key", "", "PEM私钥文件")
+	certExportP12Cmd.Flags().StringSlice("ca", nil, "额外加入的CA证书文件")
+	certExportP12Cmd.Flags().StringP("out", "o", "", "输出文件（默认为证书文件名加 .pfx）")
+	certExportP12Cmd.Flags().String("password", "", "PKCS#12保护密码")
+	certExportP12Cmd.Flags().Bool("legacy", false, "使用旧版加密算法，兼容旧版Windows和Java")
+
+	// 导入命令的选项
+	certImportP12Cmd.Flags().String("cert", "", "证书输出文件（默认为 <文件名>.crt）")
+	certImportP12Cmd.Flags().String("key", "", "私钥输出文件（默认为 <文件名>.key）")
+	certImportP12Cmd.Flags().String("password", "", "PKCS#12保护密码")
+	certImportP12Cmd.Flags().Bool("no-chain", false, "不将CA证书写入证书文件")
+
+	certCmd.AddCommand(certExportP12Cmd)
+	certCmd.AddCommand(certImportP12Cmd)
+}
@@ -0,0 +1,149 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netutils"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var certWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "批量监控证书有效期",
+	Long: `批量检查多个证书文件和远程主机的证书有效期，按剩余天数排序输出报告。
+
+只要有证书剩余天数低于告警阈值、已过期或检查失败，命令就以非零状态码退出，
+适合在CI或cron中使用。
+
+目标文件（YAML）格式:
+  threshold: 30
+  targets:
+    - example.com:443
+    - /etc/ssl/certs/server.crt
+    - name: 内部API
+      address: 10.0.0.5:8443
+      sni: api.internal
+      ca_bundle: [ca/root.crt]
+      strict_ca: true
+
+也可以直接写成目标列表:
+  - example.com:443
+  - /etc/ssl/certs/server.crt
+
+示例:
+  %[1]s network cert watch --targets targets.yaml
+  %[1]s network cert watch --targets targets.yaml --threshold 14
+  %[1]s network cert watch --targets targets.yaml --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetsFile, _ := cmd.Flags().GetString("targets")
+		threshold, _ := cmd.Flags().GetInt("threshold")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		if targetsFile == "" {
+			return errs.InvalidInput("必须指定 --targets")
+		}
+
+		config, err := netutils.LoadWatchConfig(targetsFile)
+		if err != nil {
+			return err
+		}
+		if len(config.Targets) == 0 {
+			return errs.InvalidInput("目标文件中没有监控目标")
+		}
+
+		// 命令行参数优先于配置文件中的阈值
+		if !cmd.Flags().Changed("threshold") && config.Threshold > 0 {
+			threshold = config.Threshold
+		}
+
+		results := netutils.WatchCertificates(config.Targets, netutils.WatchOptions{
+			Threshold:   threshold,
+			Concurrency: concurrency,
+			Timeout:     timeout,
+		})
+
+		// --json 等同于 --output json
+		if jsonOutput {
+			err = output.Write(os.Stdout, output.FormatJSON, results, nil)
+		} else {
+			err = output.Render(cmd, results, func() {
+				printWatchReport(results, threshold)
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		// 报告已经输出，存在即将过期或检查失败的证书时只设置退出码
+		for _, result := range results {
+			if result.Status != netutils.WatchStatusOK {
+				return errs.Exit(errs.ExitFailure)
+			}
+		}
+		return nil
+	},
+}
+
+// printWatchReport 以表格形式输出证书监控报告
+func printWatchReport(results []netutils.WatchResult, threshold int) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{i18n.T("目标"), i18n.T("主体"), i18n.T("过期时间"), i18n.T("剩余天数"), i18n.T("状态")})
+	table.SetBorder(false)
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Status]++
+
+		var status, expires, days string
+		switch result.Status {
+		case netutils.WatchStatusOK:
+			status = color.GreenString(i18n.T("正常"))
+		case netutils.WatchStatusWarning:
+			status = color.YellowString(i18n.T("即将过期"))
+		case netutils.WatchStatusExpired:
+			status = color.RedString(i18n.T("已过期"))
+		default:
+			status = color.RedString(i18n.T("检查失败"))
+		}
+		if result.Status != netutils.WatchStatusError {
+			expires = result.NotAfter.Format("2006-01-02")
+			days = fmt.Sprintf("%d", result.RemainingDays)
+		}
+
+		table.Append([]string{result.Target, result.Subject, expires, days, status})
+	}
+	table.Render()
+
+	// 显示检查失败的原因
+	for _, result := range results {
+		if result.Error != "" {
+			color.Red("%s: %s\n", result.Target, result.Error)
+		}
+	}
+
+	fmt.Print(i18n.Tf("\n共 %d 个目标，告警阈值 %d 天：正常 %d，即将过期 %d，已过期 %d，检查失败 %d\n",
+		len(results), threshold,
+		counts[netutils.WatchStatusOK], counts[netutils.WatchStatusWarning],
+		counts[netutils.WatchStatusExpired], counts[netutils.WatchStatusError]))
+}
+
+func init() {
+	certWatchCmd.Flags().StringP("targets", "t", "", "监控目标文件（YAML）")
+	certWatchCmd.Flags().Int("threshold", 30, "告警阈值（天）")
+	certWatchCmd.Flags().IntP("concurrency", "c", 8, "并发检查数量")
+	flagtype.Duration(certWatchCmd.Flags(), "timeout", 10*time.Second, time.Second, "连接远程主机的超时时间")
+	certWatchCmd.Flags().Bool("json", false, "以JSON格式输出报告（等同于 --output json）")
+
+	certCmd.AddCommand(certWatchCmd)
+}
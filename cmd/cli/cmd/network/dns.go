@@ -1,8 +1,12 @@
 package network
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netdiag"
 
 	"github.com/fatih/color"
@@ -28,22 +32,92 @@ var dnsCmd = &cobra.Command{
   %[1]s network dns example.com --dns-server 8.8.8.8
   %[1]s network dns example.com --dns-server 8.8.8.8:53 --type all`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		domain := args[0]
 		recordType, _ := cmd.Flags().GetString("type")
 		dnsServer, _ := cmd.Flags().GetString("dns-server")
 
+		servers := []string{dnsServer}
 		if dnsServer == "" {
-			Server := netdiag.GetSystemDNSServers()
-			for _, server := range Server {
-				executeDNSQuery(domain, recordType, server)
+			servers = netdiag.GetSystemDNSServers()
+		}
+
+		// 结构化输出时汇总所有服务器的查询结果
+		if output.IsStructured(cmd) {
+			var results []dnsQueryOutput
+			for _, server := range servers {
+				serverResults, err := lookupDNSRecords(domain, recordType, server)
+				if err != nil {
+					return err
+				}
+				results = append(results, serverResults...)
+			}
+			return output.Render(cmd, results, nil)
+		}
+
+		// 某个服务器查询失败时继续查询其他服务器，最后以失败的退出码退出
+		var failed error
+		for _, server := range servers {
+			if err := executeDNSQuery(domain, recordType, server); err != nil {
+				if errors.Is(err, errs.ErrInvalidInput) {
+					return err
+				}
+				color.Red("%v\n", err)
+				failed = err
 			}
-		} else {
-			executeDNSQuery(domain, recordType, dnsServer)
 		}
+		if failed != nil {
+			return errs.Exit(errs.ExitCode(failed))
+		}
+		return nil
 	},
 }
 
+// dnsQueryOutput 结构化输出中的单条查询结果
+type dnsQueryOutput struct {
+	Type string `json:"type"` // 查询的记录类型
+	netdiag.DNSQueryResult
+}
+
+// dnsRecordTypes 查询 all 时依次输出的记录类型
+var dnsRecordTypes = []string{"IP", "MX", "NS", "TXT"}
+
+// lookupDNSRecords 查询指定类型的DNS记录，查询失败的原因记录在结果的Error字段中
+func lookupDNSRecords(domain string, recordType string, dnsServer string) ([]dnsQueryOutput, error) {
+	recordType = strings.ToLower(recordType)
+
+	if recordType == "all" {
+		results := netdiag.QueryDNS(domain, dnsServer)
+		outputs := make([]dnsQueryOutput, 0, len(dnsRecordTypes))
+		for _, t := range dnsRecordTypes {
+			outputs = append(outputs, dnsQueryOutput{Type: t, DNSQueryResult: results[t]})
+		}
+		return outputs, nil
+	}
+
+	var result netdiag.DNSQueryResult
+	var err error
+	switch recordType {
+	case "ip":
+		result, err = netdiag.LookupIP(domain, dnsServer)
+	case "mx":
+		result, err = netdiag.LookupMX(domain, dnsServer)
+	case "ns":
+		result, err = netdiag.LookupNS(domain, dnsServer)
+	case "txt":
+		result, err = netdiag.LookupTXT(domain, dnsServer)
+	default:
+		return nil, errs.InvalidInput("不支持的DNS记录类型: %s", recordType)
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if result.Domain == "" {
+		result.Domain = domain
+	}
+	return []dnsQueryOutput{{Type: strings.ToUpper(recordType), DNSQueryResult: result}}, nil
+}
+
 func init() {
 	NetworkCmd.AddCommand(dnsCmd)
 
@@ -53,10 +127,10 @@ func init() {
 }
 
 // executeDNSQuery 执行DNS查询
-func executeDNSQuery(domain string, recordType string, dnsServer string) {
-	fmt.Printf("正在查询 %s 的DNS记录...\n", domain)
+func executeDNSQuery(domain string, recordType string, dnsServer string) error {
+	fmt.Print(i18n.Tf("正在查询 %s 的DNS记录...\n", domain))
 	if dnsServer != "" {
-		fmt.Printf("使用DNS服务器: %s\n", dnsServer)
+		fmt.Print(i18n.Tf("使用DNS服务器: %s\n", dnsServer))
 	}
 
 	recordType = strings.ToLower(recordType)
@@ -67,66 +141,66 @@ func executeDNSQuery(domain string, recordType string, dnsServer string) {
 
 		for recordType, result := range results {
 			if result.Error != "" {
-				color.Red("%s记录查询失败: %s\n", recordType, result.Error)
+				color.Red(i18n.T("%s记录查询失败: %s\n"), recordType, result.Error)
 				continue
 			}
 
 			if len(result.Records) == 0 {
-				color.Yellow("未找到%s记录。\n", recordType)
+				color.Yellow(i18n.T("未找到%s记录。\n"), recordType)
 				continue
 			}
 
-			color.Green("%s记录 (查询方式: %s):\n", recordType, getQueryMethodText(result))
+			color.Green(i18n.T("%s记录 (查询方式: %s):\n"), recordType, getQueryMethodText(result))
 			for _, record := range result.Records {
-				fmt.Printf("类型: %s, 值: %s\n", record.Type, record.Value)
+				fmt.Print(i18n.Tf("类型: %s, 值: %s\n", record.Type, record.Value))
 			}
 			fmt.Println()
 		}
-	} else {
-		// 查询指定类型的记录
-		var result netdiag.DNSQueryResult
-		var err error
-
-		switch recordType {
-		case "ip":
-			result, err = netdiag.LookupIP(domain, dnsServer)
-		case "mx":
-			result, err = netdiag.LookupMX(domain, dnsServer)
-		case "ns":
-			result, err = netdiag.LookupNS(domain, dnsServer)
-		case "txt":
-			result, err = netdiag.LookupTXT(domain, dnsServer)
-		default:
-			fmt.Printf("不支持的DNS记录类型: %s\n", recordType)
-			return
-		}
+		return nil
+	}
 
-		if err != nil {
-			color.Red("DNS查询失败: %s\n", err)
-			return
-		}
+	// 查询指定类型的记录
+	var result netdiag.DNSQueryResult
+	var err error
+
+	switch recordType {
+	case "ip":
+		result, err = netdiag.LookupIP(domain, dnsServer)
+	case "mx":
+		result, err = netdiag.LookupMX(domain, dnsServer)
+	case "ns":
+		result, err = netdiag.LookupNS(domain, dnsServer)
+	case "txt":
+		result, err = netdiag.LookupTXT(domain, dnsServer)
+	default:
+		return errs.InvalidInput("不支持的DNS记录类型: %s", recordType)
+	}
 
-		if len(result.Records) == 0 {
-			color.Yellow("未找到%s记录。\n", recordType)
-			return
-		}
+	if err != nil {
+		return errs.Wrap(err, "DNS查询失败: %v", err)
+	}
 
-		color.Green("%s记录 (查询方式: %s):\n", strings.ToUpper(recordType), getQueryMethodText(result))
-		for _, record := range result.Records {
-			fmt.Printf("类型: %s, 值: %s\n", record.Type, record.Value)
-		}
+	if len(result.Records) == 0 {
+		color.Yellow(i18n.T("未找到%s记录。\n"), recordType)
+		return nil
+	}
+
+	color.Green(i18n.T("%s记录 (查询方式: %s):\n"), strings.ToUpper(recordType), getQueryMethodText(result))
+	for _, record := range result.Records {
+		fmt.Print(i18n.Tf("类型: %s, 值: %s\n", record.Type, record.Value))
 	}
+	return nil
 }
 
 // getQueryMethodText 获取查询方式的文本描述
 func getQueryMethodText(result netdiag.DNSQueryResult) string {
 	if result.Method == "host" {
 		if strings.Contains(result.ServerUsed, "系统DNS") {
-			return "系统DNS"
+			return i18n.T("系统DNS")
 		}
-		return fmt.Sprintf("系统DNS服务器 (%s)", result.ServerUsed)
+		return i18n.Tf("系统DNS服务器 (%s)", result.ServerUsed)
 	} else if result.Method == "dns" {
-		return fmt.Sprintf("自定义DNS服务器 (%s)", result.ServerUsed)
+		return i18n.Tf("自定义DNS服务器 (%s)", result.ServerUsed)
 	}
-	return "未知"
+	return i18n.T("未知")
 }
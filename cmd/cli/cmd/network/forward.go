@@ -0,0 +1,96 @@
+package network
+
+import (
+	"fmt"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netdiag"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// forwardCmd 表示端口转发命令
+var forwardCmd = &cobra.Command{
+	Use:   "forward",
+	Short: "TCP/UDP端口转发",
+	Long: `在本地监听端口，并将连接转发到目标地址，相当于一个简易的socat。
+
+支持TCP和UDP协议，支持限制最大连接数和设置空闲超时，
+每个连接的建立和关闭都会输出日志，使用全局选项 --quiet 时不输出。
+空闲超时按两个方向合计计算，单向的下载或上传不会被中断；一方关闭写入后另一方向仍可继续传输。
+UDP没有连接状态，未指定 --idle-timeout 时UDP会话空闲60秒后回收，之后同一客户端的数据作为新会话转发。
+按 Ctrl+C 停止转发。
+
+示例:
+  %[1]s network forward --listen :8080 --target 10.0.0.5:80
+  %[1]s network forward --listen :5353 --target 8.8.8.8:53 --protocol udp
+  %[1]s network forward -l 127.0.0.1:3306 -T db.internal:3306 --max-conn 20 --idle-timeout 5m
+  %[1]s network forward -l :9000 -T 10.0.0.5:9000 --protocol both`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen, _ := cmd.Flags().GetString("listen")
+		target, _ := cmd.Flags().GetString("target")
+		protocol, _ := cmd.Flags().GetString("protocol")
+		maxConn, _ := cmd.Flags().GetInt("max-conn")
+		idleTimeout := flagtype.GetDuration(cmd.Flags(), "idle-timeout")
+		dialTimeout := flagtype.GetDuration(cmd.Flags(), "dial-timeout")
+		// 使用全局的 --quiet 不输出连接日志
+		quiet, _ := cmd.Root().PersistentFlags().GetBool("quiet")
+
+		if listen == "" || target == "" {
+			return errs.InvalidInput("必须同时指定 --listen 和 --target")
+		}
+
+		return executeForward(netdiag.ForwardOptions{
+			Listen:         listen,
+			Target:         target,
+			Protocol:       protocol,
+			MaxConnections: maxConn,
+			IdleTimeout:    idleTimeout,
+			DialTimeout:    dialTimeout,
+		}, quiet)
+	},
+}
+
+func init() {
+	NetworkCmd.AddCommand(forwardCmd)
+
+	// 添加命令行标志
+	forwardCmd.Flags().StringP("listen", "l", "", "本地监听地址 (例如: :8080 或 127.0.0.1:8080)")
+	forwardCmd.Flags().StringP("target", "T", "", "转发目标地址 (例如: 10.0.0.5:80)")
+	forwardCmd.Flags().StringP("protocol", "p", "tcp", "转发协议 (tcp, udp, both)")
+	forwardCmd.Flags().IntP("max-conn", "m", 0, "最大并发连接数，0表示不限制")
+	flagtype.Duration(forwardCmd.Flags(), "idle-timeout", 0, time.Second, "连接空闲超时时间 (例如: 30s, 5m)，0表示不超时（UDP会话为60秒）")
+	flagtype.Duration(forwardCmd.Flags(), "dial-timeout", 10*time.Second, time.Second, "连接目标的超时时间")
+}
+
+// executeForward 执行端口转发
+func executeForward(options netdiag.ForwardOptions, quiet bool) error {
+	titleColor := color.New(color.FgYellow, color.Bold)
+	timeColor := color.New(color.Faint)
+
+	titleColor.Printf(i18n.T("开始转发 %s -> %s (%s)\n"), options.Listen, options.Target, options.Protocol)
+	fmt.Println(i18n.T("按 Ctrl+C 停止转发"))
+	fmt.Println()
+
+	if !quiet {
+		options.LogCallback = func(line string) {
+			fmt.Printf("%s %s\n", timeColor.Sprint(time.Now().Format("15:04:05")), line)
+		}
+	}
+
+	stats, err := netdiag.StartForward(options)
+	if err != nil {
+		return errs.Wrap(err, "端口转发失败: %v", err)
+	}
+
+	fmt.Println(i18n.T("\n---- 转发统计信息 ----"))
+	fmt.Print(i18n.Tf("累计连接数: %d\n", stats.TotalConnections))
+	fmt.Print(i18n.Tf("拒绝连接数: %d\n", stats.RejectedCount))
+	fmt.Print(i18n.Tf("发送字节数: %d\n", stats.BytesIn))
+	fmt.Print(i18n.Tf("接收字节数: %d\n", stats.BytesOut))
+	return nil
+}
@@ -0,0 +1,122 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netdiag"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// http3Cmd 表示 http3 检查命令
+var http3Cmd = &cobra.Command{
+	Use:   "http3 [URL]",
+	Short: "检查HTTP/3与QUIC支持",
+	Long: `检查指定端点是否支持HTTP/3（QUIC）。
+
+该命令会：
+1. 通过TCP发起HTTP/2请求，读取Alt-Svc响应头中的h3广告
+2. 尝试QUIC握手，报告协商的QUIC版本和ALPN
+3. 通过HTTP/3发起请求，并与TCP上的HTTP/2对比延迟
+
+示例:
+  %[1]s network http3 https://cloudflare.com
+  %[1]s network http3 www.google.com
+  %[1]s network http3 https://localhost:8443 --insecure`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
+		insecure, _ := cmd.Flags().GetBool("insecure")
+
+		return executeHTTP3Check(args[0], netdiag.HTTP3CheckOptions{
+			Timeout:  timeout,
+			Insecure: insecure,
+		})
+	},
+}
+
+func init() {
+	NetworkCmd.AddCommand(http3Cmd)
+
+	// 添加命令行标志
+	flagtype.DurationP(http3Cmd.Flags(), "timeout", "t", 10*time.Second, time.Second, "每个检查阶段的超时时间")
+	http3Cmd.Flags().BoolP("insecure", "k", false, "跳过证书校验")
+}
+
+// executeHTTP3Check 执行HTTP/3检查
+func executeHTTP3Check(target string, options netdiag.HTTP3CheckOptions) error {
+	fmt.Print(i18n.Tf("正在检查 %s 的HTTP/3支持...\n\n", target))
+
+	result, err := netdiag.CheckHTTP3(target, options)
+	if err != nil {
+		return errs.Wrap(err, "检查失败: %v", err)
+	}
+
+	bold := color.New(color.Bold)
+
+	// Alt-Svc 广告
+	bold.Println(i18n.T("Alt-Svc 广告:"))
+	switch {
+	case result.H2Error != "":
+		color.Red(i18n.T("  无法获取（TCP请求失败）\n"))
+	case result.AltSvc == "":
+		color.Yellow(i18n.T("  未返回Alt-Svc响应头\n"))
+	default:
+		fmt.Printf("  %s\n", result.AltSvc)
+		if result.AltSvcH3 {
+			color.Green(i18n.T("  已通过 %s 声明支持HTTP/3\n"), result.AltSvcProtocol)
+		} else {
+			color.Yellow(i18n.T("  未声明h3\n"))
+		}
+	}
+	fmt.Println()
+
+	// QUIC 握手
+	bold.Println(i18n.T("QUIC 握手:"))
+	if result.QUICSupported {
+		color.Green(i18n.T("  握手成功\n"))
+		fmt.Print(i18n.Tf("  QUIC版本: %s\n", result.QUICVersion))
+		fmt.Printf("  ALPN: %s\n", result.QUICALPN)
+		fmt.Print(i18n.Tf("  TLS版本: %s\n", result.TLSVersion))
+		fmt.Print(i18n.Tf("  握手耗时: %s\n", formatLatency(result.HandshakeTime)))
+	} else {
+		color.Red(i18n.T("  握手失败: %s\n"), result.QUICError)
+	}
+	fmt.Println()
+
+	// 延迟对比
+	bold.Println(i18n.T("延迟对比（首次请求，含握手）:"))
+	if result.H3Error != "" {
+		color.Red(i18n.T("  HTTP/3:  请求失败: %s\n"), result.H3Error)
+	} else if result.QUICSupported {
+		fmt.Print(i18n.Tf("  HTTP/3:  %-10s 状态码 %d\n", formatLatency(result.H3Latency), result.H3Status))
+	} else {
+		fmt.Println(i18n.T("  HTTP/3:  不可用"))
+	}
+	if result.H2Error != "" {
+		color.Red(i18n.T("  TCP:     请求失败: %s\n"), result.H2Error)
+	} else {
+		fmt.Print(i18n.Tf("  %-8s %-10s 状态码 %d\n", strings.Replace(result.H2Protocol, ".0", "", 1)+":",
+			formatLatency(result.H2Latency), result.H2Status))
+	}
+
+	if result.H3Error == "" && result.QUICSupported && result.H2Error == "" && result.H2Latency > 0 {
+		diff := result.H2Latency - result.H3Latency
+		if diff > 0 {
+			color.Green(i18n.T("  HTTP/3 快 %s (%.1f%%)\n"), formatLatency(diff), float64(diff)*100/float64(result.H2Latency))
+		} else {
+			color.Yellow(i18n.T("  HTTP/3 慢 %s (%.1f%%)\n"), formatLatency(-diff), float64(-diff)*100/float64(result.H2Latency))
+		}
+	}
+	return nil
+}
+
+// formatLatency 格式化延迟时间
+func formatLatency(d time.Duration) string {
+	return fmt.Sprintf("%.2f ms", float64(d.Microseconds())/1000.0)
+}
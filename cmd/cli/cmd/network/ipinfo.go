@@ -2,6 +2,9 @@ package network
 
 import (
 	"fmt"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netdiag"
 
 	"github.com/fatih/color"
@@ -21,12 +24,12 @@ var ipinfoCmd = &cobra.Command{
   %[1]s network ipinfo
   %[1]s network ipinfo 8.8.8.8`,
 	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		var ip string
 		if len(args) > 0 {
 			ip = args[0]
 		}
-		executeIPInfo(ip)
+		return executeIPInfo(cmd, ip)
 	},
 }
 
@@ -34,54 +37,63 @@ func init() {
 	NetworkCmd.AddCommand(ipinfoCmd)
 }
 
+// ipInfoResult 结构化输出的IP信息，查询本机时包括本地网络接口
+type ipInfoResult struct {
+	netdiag.IPInfo
+	Interfaces []netdiag.LocalIPInfo `json:"interfaces,omitempty"`
+}
+
 // executeIPInfo 获取IP信息
-func executeIPInfo(ip string) {
+func executeIPInfo(cmd *cobra.Command, ip string) error {
 	if ip == "" {
-		fmt.Println("正在获取本机公网IP信息...")
+		output.Infof(cmd, "正在获取本机公网IP信息...\n")
 	} else {
-		fmt.Printf("正在获取IP %s 的信息...\n", ip)
+		output.Infof(cmd, "正在获取IP %s 的信息...\n", ip)
 	}
 
 	info, err := netdiag.GetIPInfo(ip)
 	if err != nil {
-		color.Red("获取IP信息失败: %s\n", err)
-		return
+		return errs.Wrap(err, "获取IP信息失败: %v", err)
 	}
 
-	color.Green("IP信息:\n")
-	fmt.Printf("IP地址: %s\n", info.IP)
-	fmt.Printf("位置: %s, %s, %s\n", info.City, info.Region, info.Country)
-	fmt.Printf("邮政编码: %s\n", info.PostalCode)
-	fmt.Printf("ISP: %s\n", info.ISP)
-	fmt.Printf("时区: %s\n", info.Timezone)
-
 	// 如果是本机IP，还显示本地网络接口信息
+	result := ipInfoResult{IPInfo: info}
 	if ip == "" {
-		fmt.Println("\n本地网络接口信息:")
-		localIPs, err := netdiag.GetLocalIPs()
+		result.Interfaces, err = netdiag.GetLocalIPs()
 		if err != nil {
-			color.Red("获取本地网络接口信息失败: %s\n", err)
-			return
+			return i18n.Errorf("获取本地网络接口信息失败: %v", err)
 		}
+	}
 
-		for i, localIP := range localIPs {
-			ipVersion := "IPv4"
-			if !localIP.IsIPv4 {
-				ipVersion = "IPv6"
-			}
+	return output.Render(cmd, result, func() {
+		color.Green(i18n.T("IP信息:\n"))
+		fmt.Print(i18n.Tf("IP地址: %s\n", info.IP))
+		fmt.Print(i18n.Tf("位置: %s, %s, %s\n", info.City, info.Region, info.Country))
+		fmt.Print(i18n.Tf("邮政编码: %s\n", info.PostalCode))
+		fmt.Printf("ISP: %s\n", info.ISP)
+		fmt.Print(i18n.Tf("时区: %s\n", info.Timezone))
+
+		if ip == "" {
+			fmt.Println(i18n.T("\n本地网络接口信息:"))
+			for i, localIP := range result.Interfaces {
+				ipVersion := "IPv4"
+				if !localIP.IsIPv4 {
+					ipVersion = "IPv6"
+				}
 
-			fmt.Printf("[%d] 接口: %s\n", i+1, localIP.InterfaceName)
-			fmt.Printf("    IP地址: %s (%s)\n", localIP.IPAddress, ipVersion)
-			fmt.Printf("    MAC地址: %s\n", localIP.MACAddress)
-			fmt.Printf("    状态: %s\n\n", statusText(localIP.IsUp))
+				fmt.Print(i18n.Tf("[%d] 接口: %s\n", i+1, localIP.InterfaceName))
+				fmt.Print(i18n.Tf("    IP地址: %s (%s)\n", localIP.IPAddress, ipVersion))
+				fmt.Print(i18n.Tf("    MAC地址: %s\n", localIP.MACAddress))
+				fmt.Print(i18n.Tf("    状态: %s\n\n", statusText(localIP.IsUp)))
+			}
 		}
-	}
+	})
 }
 
 // statusText 状态文本
 func statusText(isUp bool) string {
 	if isUp {
-		return "已连接"
+		return i18n.T("已连接")
 	}
-	return "已断开"
+	return i18n.T("已断开")
 }
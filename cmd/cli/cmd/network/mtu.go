@@ -0,0 +1,129 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netdiag"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// tunnelOverhead 常见隧道协议的封装开销
+type tunnelOverhead struct {
+	Name     string
+	Overhead int
+}
+
+// commonTunnels 常见VPN/隧道的IPv4封装开销（字节）
+var commonTunnels = []tunnelOverhead{
+	{"PPPoE", 8},
+	{"GRE", 24},
+	{"IPIP", 20},
+	{"VXLAN", 50},
+	{"WireGuard", 60},
+	{"IPsec (ESP/AES)", 73},
+	{"OpenVPN (UDP)", 69},
+}
+
+// mtuCmd 表示路径MTU探测命令
+var mtuCmd = &cobra.Command{
+	Use:   "mtu [主机名或IP]",
+	Short: "探测路径MTU",
+	Long: `通过发送设置了DF（禁止分片）位的ICMP探测包，二分查找到达目标主机的路径MTU。
+
+输出包括路径MTU、开始要求分片的路由器地址，以及常见VPN/隧道场景下
+建议设置的隧道MTU和TCP MSS。该命令需要root权限或CAP_NET_RAW能力。
+
+示例:
+  %[1]s network mtu example.com
+  %[1]s network mtu 8.8.8.8 --max 9000
+  %[1]s network mtu 10.0.0.1 --min 1200 --max 1500 --timeout 1s --retries 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		minSize, _ := cmd.Flags().GetInt("min")
+		maxSize, _ := cmd.Flags().GetInt("max")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
+		retries, _ := cmd.Flags().GetInt("retries")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+
+		return executeMTU(args[0], netdiag.MTUOptions{
+			MinSize: minSize,
+			MaxSize: maxSize,
+			Timeout: timeout,
+			Retries: retries,
+		}, quiet)
+	},
+}
+
+func init() {
+	NetworkCmd.AddCommand(mtuCmd)
+
+	// 添加命令行标志
+	mtuCmd.Flags().Int("min", 576, "探测的最小MTU")
+	mtuCmd.Flags().Int("max", 1500, "探测的最大MTU")
+	flagtype.DurationP(mtuCmd.Flags(), "timeout", "t", 2*time.Second, time.Second, "每个探测包的超时时间")
+	mtuCmd.Flags().IntP("retries", "r", 1, "探测包超时后的重试次数")
+	mtuCmd.Flags().BoolP("quiet", "q", false, "不显示每个探测包的结果")
+}
+
+// executeMTU 执行路径MTU探测
+func executeMTU(host string, options netdiag.MTUOptions, quiet bool) error {
+	fmt.Print(i18n.Tf("正在探测到 %s 的路径MTU (范围 %d-%d)...\n\n", host, options.MinSize, options.MaxSize))
+
+	if !quiet {
+		options.ProgressCallback = func(line string) {
+			fmt.Println(line)
+		}
+	}
+
+	result, err := netdiag.DiscoverMTU(host, options)
+	if err != nil {
+		fmt.Println()
+		return errs.Wrap(err, "路径MTU探测失败: %v", err)
+	}
+
+	fmt.Println(i18n.T("\n---- 路径MTU探测结果 ----"))
+	fmt.Print(i18n.Tf("目标: %s (%s)\n", result.Host, result.TargetIP))
+	color.Green(i18n.T("路径MTU: %d 字节\n"), result.PathMTU)
+	fmt.Print(i18n.Tf("最大ICMP载荷: %d 字节\n", result.MaxICMP))
+	fmt.Print(i18n.Tf("TCP MSS: %d 字节\n", result.MSS))
+	fmt.Print(i18n.Tf("探测包数量: %d\n", result.Probes))
+
+	switch {
+	case result.FragHop != "":
+		if result.FragMTU > 0 {
+			color.Yellow(i18n.T("开始分片位置: %s (报告下一跳MTU %d)\n"), result.FragHop, result.FragMTU)
+		} else {
+			color.Yellow(i18n.T("开始分片位置: %s\n"), result.FragHop)
+		}
+	case result.LocalOnly:
+		fmt.Println(i18n.T("开始分片位置: 本地接口"))
+	case result.PathMTU < options.MaxSize:
+		color.Yellow(i18n.T("开始分片位置: 未知 (路径上的设备丢弃了超长包但没有返回ICMP通知)\n"))
+	default:
+		fmt.Println(i18n.T("开始分片位置: 在探测范围内未发生分片"))
+	}
+
+	// 隧道MTU和MSS建议
+	fmt.Println(i18n.T("\n---- VPN/隧道建议 ----"))
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{i18n.T("隧道类型"), i18n.T("封装开销"), i18n.T("隧道MTU"), i18n.T("TCP MSS钳制")})
+	table.SetBorder(false)
+	for _, tunnel := range commonTunnels {
+		tunnelMTU := result.PathMTU - tunnel.Overhead
+		table.Append([]string{
+			tunnel.Name,
+			fmt.Sprintf("%d", tunnel.Overhead),
+			fmt.Sprintf("%d", tunnelMTU),
+			fmt.Sprintf("%d", tunnelMTU-40),
+		})
+	}
+	table.Render()
+	return nil
+}
@@ -18,7 +18,11 @@ var NetworkCmd = &cobra.Command{
   %[1]s network speedtest
   %[1]s network ipinfo 8.8.8.8
   %[1]s network sniff eth0 --filter "tcp and port 80"
-  %[1]s network sniff --list-interfaces`,
+  %[1]s network sniff --list-interfaces
+  %[1]s network forward --listen :8080 --target 10.0.0.5:80
+  %[1]s network http3 https://cloudflare.com
+  %[1]s network mtu example.com
+  %[1]s network ssh fingerprint --scan github.com`,
 }
 
 func init() {
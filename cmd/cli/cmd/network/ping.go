@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"os"
 	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netdiag"
 
 	"github.com/fatih/color"
@@ -22,12 +26,12 @@ var pingCmd = &cobra.Command{
   %[1]s network ping 8.8.8.8 --count 10
   %[1]s network ping example.com --interval 2`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		host := args[0]
 		count, _ := cmd.Flags().GetInt("count")
-		interval, _ := cmd.Flags().GetFloat64("interval")
+		interval := flagtype.GetDuration(cmd.Flags(), "interval")
 
-		executePing(host, count, time.Duration(interval*float64(time.Second)))
+		return executePing(cmd, host, count, interval)
 	},
 }
 
@@ -36,12 +40,12 @@ func init() {
 
 	// 添加命令行标志
 	pingCmd.Flags().IntP("count", "c", 4, "要发送的Ping包数量")
-	pingCmd.Flags().Float64P("interval", "i", 1.0, "Ping的间隔时间(秒)")
+	flagtype.DurationP(pingCmd.Flags(), "interval", "i", time.Second, time.Second, "Ping的间隔时间，如 500ms、2s（纯数字表示秒）")
 }
 
-// executePing 执行Ping命令
-func executePing(host string, count int, interval time.Duration) {
-	fmt.Printf("正在Ping %s (%d次，间隔%.1f秒)...\n\n", host, count, interval.Seconds())
+// executePing 执行Ping命令，结构化输出时ping的实时输出写入标准错误，结束后输出结果
+func executePing(cmd *cobra.Command, host string, count int, interval time.Duration) error {
+	output.Infof(cmd, "正在Ping %s (%d次，间隔%.1f秒)...\n\n", host, count, interval.Seconds())
 
 	// 创建颜色对象
 	successColor := color.New(color.FgGreen)
@@ -56,23 +60,38 @@ func executePing(host string, count int, interval time.Duration) {
 	// 回调函数，用于实时显示ping结果
 	pingCallback := func(line string) {
 		if line != "" {
-			fmt.Println(line)
+			output.Infof(cmd, "%s\n", line)
 		}
 	}
 
 	// 执行ping操作
 	result, err := netdiag.Ping(host, options, pingCallback)
+	if output.IsStructured(cmd) {
+		// 没有响应等失败也输出结果，便于脚本判断
+		if renderErr := output.Render(cmd, result, nil); renderErr != nil {
+			return renderErr
+		}
+		if err != nil {
+			return err
+		}
+		if !result.Success {
+			fmt.Fprint(os.Stderr, i18n.Tf("Ping %s 失败: %s\n", host, result.Error))
+			return errs.Exit(errs.ExitFailure)
+		}
+		return nil
+	}
 	if err != nil {
-		fmt.Println("\n错误:", err)
-		os.Exit(1)
+		fmt.Println()
+		return err
 	}
 
 	if !result.Success {
-		errorColor.Printf("\nPing %s 失败: %s\n", host, result.Error)
-		os.Exit(1)
+		errorColor.Printf(i18n.T("\nPing %s 失败: %s\n"), host, result.Error)
+		return errs.Exit(errs.ExitFailure)
 	}
 
 	// 显示统计信息
-	fmt.Println("\n---- Ping 统计信息 ----")
-	successColor.Printf("Ping %s 成功:\n", host)
+	fmt.Println(i18n.T("\n---- Ping 统计信息 ----"))
+	successColor.Printf(i18n.T("Ping %s 成功:\n"), host)
+	return nil
 }
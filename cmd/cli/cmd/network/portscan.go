@@ -5,6 +5,10 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netdiag"
 
 	"github.com/fatih/color"
@@ -26,17 +30,16 @@ var portScanCmd = &cobra.Command{
   %[1]s network portscan example.com --common-ports
   %[1]s network portscan example.com --ports 22,80,443,3306,8080`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		host := args[0]
 		startPort, _ := cmd.Flags().GetInt("start-port")
 		endPort, _ := cmd.Flags().GetInt("end-port")
 		commonPorts, _ := cmd.Flags().GetBool("common-ports")
 		portList, _ := cmd.Flags().GetString("ports")
-		timeout, _ := cmd.Flags().GetInt("timeout")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
 		concurrency, _ := cmd.Flags().GetInt("concurrency")
 
-		timeoutDuration := time.Duration(timeout) * time.Millisecond
-		executePortScan(host, startPort, endPort, commonPorts, portList, timeoutDuration, concurrency)
+		return executePortScan(cmd, host, startPort, endPort, commonPorts, portList, timeout, concurrency)
 	},
 }
 
@@ -48,52 +51,66 @@ func init() {
 	portScanCmd.Flags().IntP("end-port", "e", 1024, "结束端口号")
 	portScanCmd.Flags().BoolP("common-ports", "c", false, "仅扫描常见端口")
 	portScanCmd.Flags().StringP("ports", "p", "", "一组非连续的端口，用逗号分隔")
-	portScanCmd.Flags().IntP("timeout", "t", 1000, "连接超时(毫秒)")
+	flagtype.DurationP(portScanCmd.Flags(), "timeout", "t", time.Second, time.Millisecond, "连接超时，如 500ms、2s（纯数字表示毫秒）")
 	portScanCmd.Flags().IntP("concurrency", "C", 100, "并发连接数")
 }
 
 // executePortScan 执行端口扫描
-func executePortScan(host string, startPort, endPort int, commonPorts bool, portList string, timeout time.Duration, concurrency int) {
-	fmt.Printf("正在扫描 %s 的端口...\n", host)
+func executePortScan(cmd *cobra.Command, host string, startPort, endPort int, commonPorts bool, portList string, timeout time.Duration, concurrency int) error {
+	output.Infof(cmd, "正在扫描 %s 的端口...\n", host)
 
 	var result netdiag.PortScanResult
 
 	if portList != "" {
 		// 扫描指定的端口列表
-		fmt.Println("扫描指定的端口列表...")
+		output.Infof(cmd, "扫描指定的端口列表...\n")
 		ports, err := parsePortList(portList)
 		if err != nil {
-			color.Red("解析端口列表失败: %s\n", err)
-			return
+			return errs.InvalidInput("解析端口列表失败: %v", err)
 		}
 		result = netdiag.ScanSpecificPorts(host, ports, timeout, concurrency)
 	} else if commonPorts {
 		// 扫描常见端口
-		fmt.Println("仅扫描常见端口...")
+		output.Infof(cmd, "仅扫描常见端口...\n")
 		result = netdiag.ScanCommonPorts(host, timeout, concurrency)
 	} else {
 		// 扫描端口范围
-		fmt.Printf("扫描端口范围: %d-%d...\n", startPort, endPort)
+		output.Infof(cmd, "扫描端口范围: %d-%d...\n", startPort, endPort)
 		result = netdiag.ScanPorts(host, startPort, endPort, timeout, concurrency)
 	}
 
+	// 结构化输出时直接输出完整结果，包括错误信息
+	if output.IsStructured(cmd) {
+		if result.Ports == nil {
+			result.Ports = []netdiag.PortStatus{}
+		}
+		if err := output.Render(cmd, result, nil); err != nil {
+			return err
+		}
+		// 错误信息已包含在输出结果中，只设置退出码
+		if result.Error != "" {
+			return errs.Exit(errs.ExitFailure)
+		}
+		return nil
+	}
+
 	if result.Error != "" {
-		color.Red("端口扫描失败: %s\n", result.Error)
-		return
+		return i18n.Errorf("端口扫描失败: %s", result.Error)
 	}
 
 	if len(result.Ports) == 0 {
-		color.Yellow("未发现开放的端口。\n")
-		return
+		color.Yellow(i18n.T("未发现开放的端口。\n"))
+		return nil
 	}
 
-	color.Green("发现 %d 个开放的端口:\n", len(result.Ports))
-	fmt.Println("端口\t状态\t服务")
+	color.Green(i18n.T("发现 %d 个开放的端口:\n"), len(result.Ports))
+	fmt.Println(i18n.T("端口\t状态\t服务"))
 	fmt.Println("----\t----\t----")
 
 	for _, port := range result.Ports {
-		fmt.Printf("%d\t%s\t%s\n", port.Port, "开放", port.Service)
+		fmt.Printf("%d\t%s\t%s\n", port.Port, i18n.T("开放"), port.Service)
 	}
+	return nil
 }
 
 // parsePortList 解析端口列表字符串
@@ -113,19 +130,19 @@ func parsePortList(portList string) ([]int, error) {
 		// 尝试将字符串转换为整数
 		port, err := strconv.Atoi(portStr)
 		if err != nil {
-			return nil, fmt.Errorf("端口 '%s' 格式无效: %v", portStr, err)
+			return nil, i18n.Errorf("端口 '%s' 格式无效: %v", portStr, err)
 		}
 
 		// 检查端口范围
 		if port < 1 || port > 65535 {
-			return nil, fmt.Errorf("端口 %d 超出有效范围 (1-65535)", port)
+			return nil, i18n.Errorf("端口 %d 超出有效范围 (1-65535)", port)
 		}
 
 		ports = append(ports, port)
 	}
 
 	if len(ports) == 0 {
-		return nil, fmt.Errorf("未提供有效的端口")
+		return nil, i18n.Errorf("未提供有效的端口")
 	}
 
 	return ports, nil
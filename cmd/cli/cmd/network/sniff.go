@@ -2,9 +2,12 @@ package network
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netdiag"
 
 	"github.com/fatih/color"
@@ -25,20 +28,20 @@ var sniffCmd = &cobra.Command{
   %[1]s network sniff eth0 --output capture.txt
   %[1]s network sniff eth0 --pcap capture.pcap
   %[1]s network sniff --list-interfaces`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.CheckFileFlag(cmd); err != nil {
+			return err
+		}
+
 		// 检查是否要列出接口
 		listInterfaces, _ := cmd.Flags().GetBool("list-interfaces")
 		if listInterfaces {
-			showInterfaces()
-			return
+			return showInterfaces()
 		}
 
 		// 需要指定接口名
 		if len(args) < 1 {
-			fmt.Println("错误: 必须指定网络接口名称")
-			fmt.Println("可以使用 --list-interfaces 查看可用的网络接口")
-			cmd.Help()
-			os.Exit(1)
+			return errs.InvalidInput("必须指定网络接口名称，可以使用 --list-interfaces 查看可用的网络接口")
 		}
 
 		// 获取参数
@@ -52,11 +55,11 @@ var sniffCmd = &cobra.Command{
 		stats, _ := cmd.Flags().GetBool("stats")
 		snaplen, _ := cmd.Flags().GetInt("snaplen")
 		payloadLen, _ := cmd.Flags().GetInt("payload")
-		timeout, _ := cmd.Flags().GetFloat64("timeout")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
 
 		// 执行抓包
-		executeSniff(interfaceName, filter, output, pcapFile, count, verbose,
-			promiscuous, stats, snaplen, payloadLen, time.Duration(timeout*float64(time.Second)))
+		return executeSniff(interfaceName, filter, output, pcapFile, count, verbose,
+			promiscuous, stats, snaplen, payloadLen, timeout)
 	},
 }
 
@@ -74,34 +77,46 @@ func init() {
 	sniffCmd.Flags().BoolP("list-interfaces", "l", false, "列出可用的网络接口")
 	sniffCmd.Flags().IntP("snaplen", "", 1600, "捕获的数据包大小限制")
 	sniffCmd.Flags().IntP("payload", "", 64, "显示的载荷长度，0表示不显示")
-	sniffCmd.Flags().Float64P("timeout", "t", 0, "捕获超时时间(秒)，0表示一直捕获直到中断")
+	flagtype.DurationP(sniffCmd.Flags(), "timeout", "t", 0, time.Second, "捕获超时时间，如 30s、5m（纯数字表示秒），0表示一直捕获直到中断")
+
+	// 补全网络接口名称
+	sniffCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		interfaces, err := netdiag.InterfaceCompletions()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return interfaces, cobra.ShellCompDirectiveNoFileComp
+	}
 }
 
 // showInterfaces 显示所有可用的网络接口
-func showInterfaces() {
+func showInterfaces() error {
 	interfaces, err := netdiag.ListInterfaces()
 	if err != nil {
-		fmt.Printf("获取网络接口列表失败: %v\n", err)
-		os.Exit(1)
+		return err
 	}
 
-	fmt.Println("可用的网络接口:")
+	fmt.Println(i18n.T("可用的网络接口:"))
 	for i, iface := range interfaces {
 		fmt.Printf("%d. %s\n", i+1, iface)
 	}
+	return nil
 }
 
 // executeSniff 执行抓包操作
 func executeSniff(interfaceName, filter, output, pcapFile string, count int, verbose,
-	promiscuous, stats bool, snaplen, payloadLen int, timeout time.Duration) {
+	promiscuous, stats bool, snaplen, payloadLen int, timeout time.Duration) error {
 
 	// 使用粗体黄色打印
 	boldYellow := color.New(color.FgYellow, color.Bold)
-	boldYellow.Printf("开始在接口 %s 上抓包...\n", interfaceName)
+	boldYellow.Printf(i18n.T("开始在接口 %s 上抓包...\n"), interfaceName)
 	if filter != "" {
-		boldYellow.Printf("过滤规则: %s\n", filter)
+		boldYellow.Printf(i18n.T("过滤规则: %s\n"), filter)
 	}
-	fmt.Println("按 Ctrl+C 停止抓包")
+	fmt.Println(i18n.T("按 Ctrl+C 停止抓包"))
 	fmt.Println()
 
 	// 准备配置
@@ -129,16 +144,17 @@ func executeSniff(interfaceName, filter, output, pcapFile string, count int, ver
 	// 执行抓包 - 现在信号处理已在内部实现
 	if err := netdiag.StartSniffer(config); err != nil {
 		if !strings.Contains(err.Error(), "由于系统调用而中断") {
-			fmt.Printf("\n抓包失败: %v\n", err)
-			os.Exit(1)
+			fmt.Println()
+			return errs.Wrap(err, "抓包失败: %v", err)
 		}
 	}
 
 	// 打印输出信息
 	if output != "" {
-		fmt.Printf("\n抓包结果已保存到: %s\n", output)
+		fmt.Print(i18n.Tf("\n抓包结果已保存到: %s\n", output))
 	}
 	if pcapFile != "" {
-		fmt.Printf("PCAP文件已保存到: %s\n", pcapFile)
+		fmt.Print(i18n.Tf("PCAP文件已保存到: %s\n", pcapFile))
 	}
+	return nil
 }
@@ -1,11 +1,17 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netdiag"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -27,17 +33,19 @@ var speedtestCmd = &cobra.Command{
 
   # 执行速度测试
   %[1]s network speedtest`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 检查是否以服务器模式运行
 		isServer, _ := cmd.Flags().GetBool("server")
 		if isServer {
+			if output.IsStructured(cmd) {
+				return errs.InvalidInput("服务器模式不支持 --output %s", output.Get(cmd))
+			}
 			port, _ := cmd.Flags().GetInt("port")
 			host, _ := cmd.Flags().GetString("host")
 			dataSize, _ := cmd.Flags().GetInt("size")
-			startServer(port, host, dataSize)
-		} else {
-			executeSpeedTest()
+			return startServer(port, host, dataSize)
 		}
+		return executeSpeedTest(cmd)
 	},
 }
 
@@ -51,26 +59,53 @@ func init() {
 	speedtestCmd.Flags().IntP("size", "S", 10, "用于测试的数据大小(MB)")
 }
 
-// executeSpeedTest 执行网络速度测试
-func executeSpeedTest() {
-	fmt.Println("正在进行网络速度测试...")
+// executeSpeedTest 执行网络速度测试，标准错误为终端时实时显示当前速度，Ctrl+C 取消
+func executeSpeedTest(cmd *cobra.Command) error {
+	output.Infof(cmd, "正在进行网络速度测试...\n")
 
-	result := netdiag.RunSpeedTest()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	options := netdiag.SpeedTestOptions{}
+	live := isatty.IsTerminal(os.Stderr.Fd())
+	if live {
+		options.OnSample = printSpeedSample
+	}
+	result := netdiag.RunSpeedTestContext(ctx, options)
+	if live {
+		// 清除进度行
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
 
 	if result.Error != "" {
-		color.Red("速度测试失败: %s\n", result.Error)
-		return
+		return i18n.Errorf("速度测试失败: %s", result.Error)
 	}
 
-	color.Green("速度测试完成(服务器: %s):\n", result.ServerName)
-	fmt.Printf("下载速度: %.2f Mbps\n", result.DownloadSpeed)
-	fmt.Printf("上传速度: %.2f Mbps\n", result.UploadSpeed)
-	fmt.Printf("延迟: %.0f ms\n", result.Latency)
+	return output.Render(cmd, result, func() {
+		color.Green(i18n.T("速度测试完成(服务器: %s):\n"), result.ServerName)
+		fmt.Print(i18n.Tf("下载速度: %.2f Mbps\n", result.DownloadSpeed))
+		fmt.Print(i18n.Tf("上传速度: %.2f Mbps\n", result.UploadSpeed))
+		fmt.Print(i18n.Tf("延迟: %.0f ms\n", result.Latency))
+	})
+}
+
+// printSpeedSample 在同一行刷新显示测试进度
+func printSpeedSample(sample netdiag.SpeedSample) {
+	var line string
+	switch sample.Phase {
+	case netdiag.PhaseLatency:
+		line = i18n.Tf("测试延迟: %.0f ms", sample.Speed)
+	case netdiag.PhaseDownload:
+		line = i18n.Tf("测试下载: %.2f Mbps (%.1f MB)", sample.Speed, float64(sample.Bytes)/1000000)
+	case netdiag.PhaseUpload:
+		line = i18n.Tf("测试上传: %.2f Mbps (%.1f MB)", sample.Speed, float64(sample.Bytes)/1000000)
+	}
+	fmt.Fprintf(os.Stderr, "\r\033[K%s", line)
 }
 
 // startServer 启动速度测试服务器
-func startServer(port int, host string, dataSize int) {
-	fmt.Printf("正在启动速度测试服务器 %s:%d...\n", host, port)
+func startServer(port int, host string, dataSize int) error {
+	fmt.Print(i18n.Tf("正在启动速度测试服务器 %s:%d...\n", host, port))
 
 	config := &netdiag.SpeedTestServer{
 		Port:     port,
@@ -80,7 +115,7 @@ func startServer(port int, host string, dataSize int) {
 
 	err := netdiag.StartSpeedTestServer(config)
 	if err != nil {
-		fmt.Printf("启动服务器失败: %v\n", err)
-		os.Exit(1)
+		return errs.Wrap(err, "启动服务器失败: %v", err)
 	}
+	return nil
 }
@@ -0,0 +1,199 @@
+package network
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netutils"
+
+	"github.com/spf13/cobra"
+)
+
+var sshCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "SSH密钥工具",
+	Long: `SSH密钥工具，用于生成SSH密钥和计算公钥指纹。
+
+支持的功能：
+1. 生成ed25519、RSA或ECDSA密钥对，支持密码保护
+2. 计算公钥、authorized_keys和known_hosts中公钥的MD5/SHA256指纹
+3. 扫描远程主机的公钥并生成known_hosts条目`,
+}
+
+var sshKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "生成SSH密钥对",
+	Long: `生成OpenSSH格式的SSH密钥对，私钥写入指定文件，公钥写入同名的 .pub 文件。
+未指定 --passphrase 时将提示输入密码，直接回车表示不加密。
+
+示例:
+  %[1]s network ssh keygen
+  %[1]s network ssh keygen -t rsa -b 4096 -f ~/.ssh/id_rsa_work -C work@example.com
+  %[1]s network ssh keygen -t ecdsa -b 384 --passphrase ""`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyType, _ := cmd.Flags().GetString("type")
+		bits, _ := cmd.Flags().GetInt("bits")
+		comment, _ := cmd.Flags().GetString("comment")
+		file, _ := cmd.Flags().GetString("file")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if file == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return i18n.Errorf("无法获取用户目录: %v", err)
+			}
+			file = filepath.Join(home, ".ssh", "id_"+strings.ToLower(keyType))
+		}
+		if strings.HasPrefix(file, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				file = filepath.Join(home, file[2:])
+			}
+		}
+		if _, err := os.Stat(file); err == nil && !force {
+			return i18n.Errorf("文件 %s 已存在，使用 --force 覆盖", file)
+		}
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return i18n.Errorf("创建目录失败: %v", err)
+		}
+
+		if comment == "" {
+			hostname, _ := os.Hostname()
+			comment = hostname
+			if current, err := user.Current(); err == nil {
+				comment = current.Username + "@" + hostname
+			}
+		}
+
+		passphrase, _ := cmd.Flags().GetString("passphrase")
+		if !cmd.Flags().Changed("passphrase") {
+			passphrase = askQuestion(bufio.NewReader(os.Stdin), i18n.T("请输入私钥保护密码（直接回车表示不加密）"), "")
+		}
+
+		fingerprint, err := netutils.GenerateSSHKey(netutils.SSHKeyOptions{
+			Type:       keyType,
+			Bits:       bits,
+			Comment:    comment,
+			Passphrase: passphrase,
+		}, file)
+		if err != nil {
+			return i18n.Errorf("生成SSH密钥失败: %v", err)
+		}
+
+		fmt.Print(i18n.Tf("私钥已保存到: %s\n", file))
+		fmt.Print(i18n.Tf("公钥已保存到: %s.pub\n", file))
+		fmt.Println(i18n.T("密钥指纹:"))
+		printSSHFingerprint(*fingerprint, "sha256")
+		return nil
+	},
+}
+
+var sshFingerprintCmd = &cobra.Command{
+	Use:   "fingerprint [公钥文件...]",
+	Short: "计算SSH公钥指纹",
+	Long: `计算SSH公钥的MD5或SHA256指纹。
+支持单个公钥文件、authorized_keys、known_hosts以及未加密的私钥文件。
+
+使用 --scan 可以连接远程主机获取其主机公钥（类似ssh-keyscan），
+并输出可写入known_hosts的条目。
+
+示例:
+  %[1]s network ssh fingerprint ~/.ssh/id_ed25519.pub
+  %[1]s network ssh fingerprint ~/.ssh/authorized_keys --hash md5
+  %[1]s network ssh fingerprint --scan github.com
+  %[1]s network ssh fingerprint --scan 10.0.0.5:2222 --known-hosts >> ~/.ssh/known_hosts`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hash, _ := cmd.Flags().GetString("hash")
+		scan, _ := cmd.Flags().GetString("scan")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
+		knownHosts, _ := cmd.Flags().GetBool("known-hosts")
+
+		hash = strings.ToLower(hash)
+		if hash != "sha256" && hash != "md5" && hash != "all" {
+			return i18n.Errorf("不支持的指纹算法: %s", hash)
+		}
+
+		if scan != "" {
+			keys, err := netutils.ScanSSHHostKeys(scan, timeout)
+			if err != nil {
+				return i18n.Errorf("扫描主机公钥失败: %v", err)
+			}
+			for _, key := range keys {
+				if knownHosts {
+					fmt.Println(key.KnownHostsLine)
+				} else {
+					printSSHFingerprint(key.Fingerprint, hash)
+				}
+			}
+			return nil
+		}
+
+		if len(args) == 0 {
+			return i18n.Errorf("请指定公钥文件或使用 --scan 扫描远程主机")
+		}
+
+		for _, file := range args {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return i18n.Errorf("读取文件失败: %v", err)
+			}
+			fingerprints, err := netutils.FingerprintSSHKeys(data)
+			if err != nil {
+				return fmt.Errorf("%s: %v", file, err)
+			}
+			if len(args) > 1 {
+				fmt.Printf("%s:\n", file)
+			}
+			for _, fingerprint := range fingerprints {
+				printSSHFingerprint(fingerprint, hash)
+			}
+		}
+		return nil
+	},
+}
+
+// printSSHFingerprint 以ssh-keygen -l的格式输出指纹
+func printSSHFingerprint(fingerprint netutils.SSHFingerprint, hash string) {
+	keyType := strings.ToUpper(strings.TrimPrefix(fingerprint.Type, "ssh-"))
+	if strings.HasPrefix(fingerprint.Type, "ecdsa-") {
+		keyType = "ECDSA"
+	}
+	comment := fingerprint.Comment
+	if comment == "" {
+		comment = "no comment"
+	}
+
+	if hash == "sha256" || hash == "all" {
+		fmt.Printf("%d %s %s (%s)\n", fingerprint.Bits, fingerprint.SHA256, comment, keyType)
+	}
+	if hash == "md5" || hash == "all" {
+		fmt.Printf("%d MD5:%s %s (%s)\n", fingerprint.Bits, fingerprint.MD5, comment, keyType)
+	}
+}
+
+func init() {
+	// 生成命令的选项
+	sshKeygenCmd.Flags().StringP("type", "t", "ed25519", "密钥类型 (ed25519, rsa, ecdsa)")
+	sshKeygenCmd.Flags().IntP("bits", "b", 0, "密钥长度（RSA默认3072，ECDSA可选256、384、521）")
+	sshKeygenCmd.Flags().StringP("comment", "C", "", "公钥注释（默认为 用户名@主机名）")
+	sshKeygenCmd.Flags().StringP("file", "f", "", "私钥文件（默认为 ~/.ssh/id_<类型>）")
+	sshKeygenCmd.Flags().String("passphrase", "", "私钥保护密码")
+	sshKeygenCmd.Flags().Bool("force", false, "覆盖已存在的文件")
+
+	// 指纹命令的选项
+	sshFingerprintCmd.Flags().String("hash", "sha256", "指纹算法 (sha256, md5, all)")
+	sshFingerprintCmd.Flags().String("scan", "", "扫描远程主机的公钥 (host[:port])")
+	flagtype.Duration(sshFingerprintCmd.Flags(), "timeout", 5*time.Second, time.Second, "连接远程主机的超时时间")
+	sshFingerprintCmd.Flags().Bool("known-hosts", false, "扫描时输出known_hosts格式的条目")
+
+	sshCmd.AddCommand(sshKeygenCmd)
+	sshCmd.AddCommand(sshFingerprintCmd)
+	NetworkCmd.AddCommand(sshCmd)
+}
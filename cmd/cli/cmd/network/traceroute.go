@@ -2,8 +2,10 @@ package network
 
 import (
 	"fmt"
-	"os"
 	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/netdiag"
 
 	"github.com/fatih/color"
@@ -18,18 +20,26 @@ var tracerouteCmd = &cobra.Command{
 
 该命令会显示数据包经过的每个路由节点，包括IP地址、主机名和延迟。
 
+使用 --paris 启用Paris-traceroute模式：所有探测包保持相同的流标识，
+避免按流负载均衡导致不同跳的探测包走不同路径、结果被打乱。
+使用 --flows N 以N个不同的流标识分别探测，用于枚举负载均衡下的备选路径。
+
 示例:
   %[1]s network traceroute example.com
-  %[1]s network traceroute 8.8.8.8 --max-hops 20`,
+  %[1]s network traceroute 8.8.8.8 --max-hops 20
+  %[1]s network traceroute 8.8.8.8 --paris
+  %[1]s network traceroute 8.8.8.8 --paris --flows 8`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		host := args[0]
 		maxHops, _ := cmd.Flags().GetInt("max-hops")
-		timeout, _ := cmd.Flags().GetDuration("timeout")
+		timeout := flagtype.GetDuration(cmd.Flags(), "timeout")
 		packetSize, _ := cmd.Flags().GetInt("packet-size")
-		noColor, _ := cmd.Flags().GetBool("no-color")
+		paris, _ := cmd.Flags().GetBool("paris")
+		flowID, _ := cmd.Flags().GetUint16("flow-id")
+		flows, _ := cmd.Flags().GetInt("flows")
 
-		executeTraceroute(host, maxHops, timeout, packetSize, !noColor)
+		return executeTraceroute(cmd, host, maxHops, timeout, packetSize, paris, flowID, flows)
 	},
 }
 
@@ -38,16 +48,15 @@ func init() {
 
 	// 添加命令行标志
 	tracerouteCmd.Flags().IntP("max-hops", "m", 30, "最大跳数")
-	tracerouteCmd.Flags().DurationP("timeout", "t", 3*time.Second, "超时时间")
+	flagtype.DurationP(tracerouteCmd.Flags(), "timeout", "t", 3*time.Second, time.Second, "超时时间")
 	tracerouteCmd.Flags().IntP("packet-size", "s", 60, "数据包大小(字节)")
-	tracerouteCmd.Flags().Bool("no-color", false, "禁用彩色输出")
+	tracerouteCmd.Flags().Bool("paris", false, "使用Paris-traceroute模式（保持流标识一致）")
+	tracerouteCmd.Flags().Uint16("flow-id", 0, "Paris模式使用的流标识，0表示自动生成")
+	tracerouteCmd.Flags().Int("flows", 1, "Paris模式下探测的流数量，大于1时枚举备选路径")
 }
 
-// executeTraceroute 执行路由跟踪
-func executeTraceroute(host string, maxHops int, timeout time.Duration, packetSize int, useColor bool) {
-	// 如果不使用彩色输出，禁用color库的颜色功能
-	color.NoColor = !useColor
-
+// executeTraceroute 执行路由跟踪，结构化输出时不实时显示每一跳，结束后输出完整结果
+func executeTraceroute(cmd *cobra.Command, host string, maxHops int, timeout time.Duration, packetSize int, paris bool, flowID uint16, flows int) error {
 	// 创建彩色输出对象
 	titleColor := color.New(color.FgHiWhite, color.Bold)
 	headerColor := color.New(color.FgCyan, color.Bold)
@@ -57,15 +66,38 @@ func executeTraceroute(host string, maxHops int, timeout time.Duration, packetSi
 	timeoutColor := color.New(color.FgRed)
 	rttColor := color.New(color.FgMagenta)
 
-	titleColor.Printf("正在执行到 %s 的路由跟踪 (最大跳数: %d)...\n\n", host, maxHops)
+	if output.IsStructured(cmd) {
+		output.Infof(cmd, "正在执行到 %s 的路由跟踪 (最大跳数: %d)...\n", host, maxHops)
+		result, err := netdiag.Traceroute(host, netdiag.TracerouteOptions{
+			MaxHops:    maxHops,
+			Timeout:    timeout,
+			PacketSize: packetSize,
+			Paris:      paris,
+			FlowID:     flowID,
+			FlowCount:  flows,
+		})
+		if err != nil {
+			return err
+		}
+		if result.Error != "" {
+			return fmt.Errorf("%s", result.Error)
+		}
+		return output.Render(cmd, result, nil)
+	}
+
+	titleColor.Printf(i18n.T("正在执行到 %s 的路由跟踪 (最大跳数: %d)...\n"), host, maxHops)
+	if paris || flows > 1 {
+		titleColor.Printf(i18n.T("Paris模式: 每个流标识的探测包走同一路径，共探测 %d 个流\n"), flows)
+	}
+	fmt.Println()
 
 	// 打印表头
-	headerColor.Println("Traceroute 路由跟踪")
+	headerColor.Println(i18n.T("Traceroute 路由跟踪"))
 	fmt.Printf("%s %s %s %s\n",
-		headerColor.Sprint(fmt.Sprintf("%-5s", "跳数")),
-		headerColor.Sprint(fmt.Sprintf("%-40s", "主机名")),
-		headerColor.Sprint(fmt.Sprintf("%-15s", "IP地址")),
-		headerColor.Sprint("延迟"))
+		headerColor.Sprint(fmt.Sprintf("%-5s", i18n.T("跳数"))),
+		headerColor.Sprint(fmt.Sprintf("%-40s", i18n.T("主机名"))),
+		headerColor.Sprint(fmt.Sprintf("%-15s", i18n.T("IP地址"))),
+		headerColor.Sprint(i18n.T("延迟")))
 	fmt.Println(fmt.Sprintf("%s", color.New(color.Faint).Sprint(
 		"--------------------------------------------------------------------------------")))
 
@@ -73,6 +105,9 @@ func executeTraceroute(host string, maxHops int, timeout time.Duration, packetSi
 		MaxHops:    maxHops,
 		Timeout:    timeout,
 		PacketSize: packetSize,
+		Paris:      paris,
+		FlowID:     flowID,
+		FlowCount:  flows,
 		RealTimeCallback: func(hop netdiag.HopInfo) {
 			// 实时回调函数，当每一跳有结果时会调用此函数
 
@@ -113,24 +148,61 @@ func executeTraceroute(host string, maxHops int, timeout time.Duration, packetSi
 	// 开始执行traceroute，这次不会收集所有结果后统一输出，而是通过回调函数实时输出
 	result, err := netdiag.Traceroute(host, options)
 	if err != nil {
-		color.Red("错误: %v\n", err)
-		os.Exit(1)
+		return err
 	}
 
 	if result.Error != "" {
-		color.Red("错误: %s\n", result.Error)
-		os.Exit(1)
+		return fmt.Errorf("%s", result.Error)
 	}
 
 	// 输出完成信息
 	if len(result.Hops) > 0 {
 		lastHop := result.Hops[len(result.Hops)-1]
 		if lastHop.IP != "*" && lastHop.IP == result.TargetIP {
-			titleColor.Printf("\n路由跟踪完成: 共经过 %d 跳到达目标 %s\n", len(result.Hops), host)
+			titleColor.Printf(i18n.T("\n路由跟踪完成: 共经过 %d 跳到达目标 %s\n"), len(result.Hops), host)
 		} else {
-			color.Yellow("\n路由跟踪未能到达目标，已达到最大跳数限制: %d\n", maxHops)
+			color.Yellow(i18n.T("\n路由跟踪未能到达目标，已达到最大跳数限制: %d\n"), maxHops)
 		}
 	} else {
-		color.Red("\n路由跟踪失败，未获取到任何路由信息\n")
+		color.Red(i18n.T("\n路由跟踪失败，未获取到任何路由信息\n"))
+	}
+
+	// 多流探测时输出每一跳的备选路径
+	if len(result.Flows) > 1 {
+		printHopAlternatives(result, headerColor, numberColor, ipColor)
+	}
+	return nil
+}
+
+// printHopAlternatives 输出多流探测发现的每跳备选地址
+func printHopAlternatives(result netdiag.TracerouteResult, headerColor, numberColor, ipColor *color.Color) {
+	alternatives := result.HopAlternatives()
+
+	maxHop := 0
+	for _, flow := range result.Flows {
+		if len(flow.Hops) > maxHop {
+			maxHop = len(flow.Hops)
+		}
+	}
+
+	fmt.Println()
+	headerColor.Printf(i18n.T("多路径探测结果 (共 %d 个流):\n"), len(result.Flows))
+	for number := 1; number <= maxHop; number++ {
+		ips := alternatives[number]
+		if len(ips) == 0 {
+			fmt.Printf("%s *\n", numberColor.Sprintf("%-5d", number))
+			continue
+		}
+		line := ""
+		for i, ip := range ips {
+			if i > 0 {
+				line += ", "
+			}
+			line += ipColor.Sprint(ip)
+		}
+		if len(ips) > 1 {
+			line += color.YellowString(i18n.T("  (%d条备选路径)"), len(ips))
+		}
+		fmt.Printf("%s %s\n", numberColor.Sprintf("%-5d", number), line)
 	}
 }
@@ -0,0 +1,174 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Format 输出格式
+type Format string
+
+// 支持的输出格式
+const (
+	FormatTable Format = "table" // 人类可读的表格或文本（默认）
+	FormatJSON  Format = "json"  // JSON
+	FormatYAML  Format = "yaml"  // YAML
+)
+
+// FlagName 根命令上全局输出格式标志的名称
+const FlagName = "output"
+
+// ParseFormat 解析输出格式名称
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "table", "text":
+		return FormatTable, nil
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	default:
+		return "", i18n.Errorf("不支持的输出格式: %s（可选: table, json, yaml）", name)
+	}
+}
+
+// AddFlag 在根命令上注册全局 --output 标志
+//
+// 部分子命令已使用 -o/--output 表示输出文件，因此这里不设置短选项，
+// 并且始终从根命令读取取值，避免与子命令的同名标志冲突。
+func AddFlag(root *cobra.Command) {
+	root.PersistentFlags().String(FlagName, string(FormatTable), "输出格式 (table, json, yaml)")
+	root.RegisterFlagCompletionFunc(FlagName, cobra.FixedCompletions(
+		[]string{string(FormatTable), string(FormatJSON), string(FormatYAML)},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
+
+// Get 返回当前命令使用的输出格式，取值无效时返回表格格式
+func Get(cmd *cobra.Command) Format {
+	name, _ := cmd.Root().PersistentFlags().GetString(FlagName)
+	format, err := ParseFormat(name)
+	if err != nil {
+		return FormatTable
+	}
+	return format
+}
+
+// IsStructured 当前命令是否输出JSON或YAML等机器可读格式
+func IsStructured(cmd *cobra.Command) bool {
+	return Get(cmd) != FormatTable
+}
+
+// Render 按当前输出格式渲染数据
+//
+// JSON和YAML格式直接序列化data，表格格式调用table回调自行输出。
+func Render(cmd *cobra.Command, data interface{}, table func()) error {
+	return Write(os.Stdout, Get(cmd), data, table)
+}
+
+// Write 以指定格式将数据写入w
+func Write(w io.Writer, format Format, data interface{}, table func()) error {
+	// 空切片输出为 [] 而不是 null
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
+		data = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return i18n.Errorf("编码JSON失败: %v", err)
+		}
+	case FormatYAML:
+		if err := writeYAML(w, data); err != nil {
+			return i18n.Errorf("编码YAML失败: %v", err)
+		}
+	default:
+		if table != nil {
+			table()
+		}
+	}
+	return nil
+}
+
+// writeYAML 将数据编码为YAML
+//
+// 先编码为JSON再转换，使YAML与JSON使用相同的字段名（json标签）并保持字段顺序。
+func writeYAML(w io.Writer, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetStyle 清除从JSON继承的流式风格和引号，输出块风格的YAML
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// Infof 输出提示信息，结构化输出时写入标准错误，避免混入结果数据；格式字符串按当前界面语言翻译
+func Infof(cmd *cobra.Command, format string, args ...interface{}) {
+	if IsStructured(cmd) {
+		fmt.Fprint(os.Stderr, i18n.Tf(format, args...))
+		return
+	}
+	fmt.Print(i18n.Tf(format, args...))
+}
+
+// RequireTable 只能输出文本的命令（如按行处理文本的过滤器）在开头调用，
+// 指定了 --output json 或 yaml 时返回输入无效的错误，而不是忽略该选项输出文本
+func RequireTable(cmd *cobra.Command) error {
+	if IsStructured(cmd) {
+		return errs.InvalidInput("%s 只能输出文本，不支持 --output %s", cmd.CommandPath(), Get(cmd))
+	}
+	return nil
+}
+
+// CheckFileFlag 检查子命令自己的 -o/--output 标志（表示输出文件或目录，会覆盖全局的 --output）的取值，
+// 取值为 json、yaml 等输出格式名称时多半是想指定输出格式，返回输入无效的错误而不是写入同名的文件
+func CheckFileFlag(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString(FlagName)
+	if _, err := ParseFormat(path); path != "" && err == nil {
+		return errs.InvalidInput("%s 的 --output 表示输出路径，不能指定输出格式；确实要输出到 %s 时请写成 ./%s",
+			cmd.CommandPath(), path, path)
+	}
+	return nil
+}
+
+// NewTable 创建与其他命令风格一致的无边框表格
+func NewTable(w io.Writer, header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	return table
+}
@@ -2,9 +2,11 @@ package process
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/process"
 
 	"github.com/spf13/cobra"
@@ -19,47 +21,49 @@ var childrenCmd = &cobra.Command{
 示例:
   %[1]s process children 1234     # 列出PID为1234的进程的所有子进程`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 开始计时
 		startTime := time.Now()
 
 		// 解析PID
 		pid, err := strconv.ParseInt(args[0], 10, 32)
 		if err != nil {
-			fmt.Printf("无效的PID: %v\n", err)
-			os.Exit(1)
+			return errs.InvalidInput("无效的PID: %v", err)
 		}
 
 		// 获取父进程信息
 		parentInfo, err := process.GetProcessByPID(int32(pid))
 		if err != nil {
-			fmt.Printf("获取进程信息失败: %v\n", err)
-			os.Exit(1)
+			return errs.Wrap(err, "获取进程信息失败: %v", err)
 		}
 
-		fmt.Printf("正在查找进程 %d (%s) 的子进程...\n\n", parentInfo.PID, parentInfo.Name)
+		output.Infof(cmd, "正在查找进程 %d (%s) 的子进程...\n\n", parentInfo.PID, parentInfo.Name)
 
 		// 获取子进程
 		children, err := process.GetChildProcesses(int32(pid))
 		if err != nil {
-			fmt.Printf("获取子进程失败: %v\n", err)
-			os.Exit(1)
-		}
-
-		if len(children) == 0 {
-			fmt.Printf("进程 %d 没有子进程\n", pid)
-			return
+			return i18n.Errorf("获取子进程失败: %v", err)
 		}
 
 		// 获取命令行显示选项
 		fullCmd, _ := cmd.Flags().GetBool("full-cmd")
 
 		// 输出子进程列表
-		fmt.Printf("找到 %d 个子进程:\n\n", len(children))
-		printProcessList(children, fullCmd)
+		err = output.Render(cmd, children, func() {
+			if len(children) == 0 {
+				fmt.Print(i18n.Tf("进程 %d 没有子进程\n", pid))
+				return
+			}
+			fmt.Print(i18n.Tf("找到 %d 个子进程:\n\n", len(children)))
+			printProcessList(children, fullCmd)
+		})
+		if err != nil {
+			return err
+		}
 
 		// 显示执行时间
-		fmt.Printf("执行时间: %.2f秒\n", time.Since(startTime).Seconds())
+		output.Infof(cmd, "执行时间: %.2f秒\n", time.Since(startTime).Seconds())
+		return nil
 	},
 }
 
@@ -0,0 +1,40 @@
+package process
+
+import (
+	"fmt"
+	"strings"
+	"toolbox/pkg/process"
+
+	"github.com/spf13/cobra"
+)
+
+// completePID 为接收单个PID参数的命令提供补全，候选项附带进程名称
+func completePID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	processes, err := process.ListProcessNames()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, p := range processes {
+		pid := fmt.Sprintf("%d", p.PID)
+		if !strings.HasPrefix(pid, toComplete) {
+			continue
+		}
+		if p.Name != "" {
+			pid += "\t" + p.Name
+		}
+		completions = append(completions, pid)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{infoCmd, killCmd, childrenCmd, treeCmd} {
+		cmd.ValidArgsFunction = completePID
+	}
+}
@@ -1,10 +1,14 @@
 package process
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/docker"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/process"
 
 	"github.com/fatih/color"
@@ -20,74 +24,105 @@ var infoCmd = &cobra.Command{
 示例:
   %[1]s process info 1234     # 显示PID为1234的进程详细信息`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 解析PID
 		pid, err := strconv.ParseInt(args[0], 10, 32)
 		if err != nil {
-			fmt.Printf("无效的PID: %v\n", err)
-			os.Exit(1)
+			return errs.InvalidInput("无效的PID: %v", err)
 		}
 
 		// 获取进程信息
 		procInfo, err := process.GetProcessByPID(int32(pid))
 		if err != nil {
-			fmt.Printf("获取进程信息失败: %v\n", err)
-			os.Exit(1)
+			return errs.Wrap(err, "获取进程信息失败: %v", err)
 		}
 
 		// 打印进程详情
-		printProcessInfo(procInfo)
+		detail := processDetail{ProcessInfo: procInfo, Container: containerOf(procInfo.PID)}
+		return output.Render(cmd, detail, func() {
+			printProcessInfo(detail.ProcessInfo, detail.Container)
+		})
 	},
 }
 
+// processDetail 结构化输出的进程详情，包括进程所在的容器
+type processDetail struct {
+	process.ProcessInfo
+	Container string `json:"container,omitempty"`
+}
+
+// containerOf 返回进程所在容器的说明，不在容器中时返回空字符串；能连接Docker时显示容器名称
+func containerOf(pid int32) string {
+	id := docker.ContainerIDOfPID(pid)
+	if id == "" {
+		return ""
+	}
+	label := id[:12]
+	client, err := docker.NewClient("")
+	if err != nil {
+		return label
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if name, err := client.ContainerName(ctx, id); err == nil {
+		label = fmt.Sprintf("%s (%s)", name, label)
+	}
+	return label
+}
+
 func init() {
 	ProcessCmd.AddCommand(infoCmd)
 }
 
-// 打印进程详细信息
-func printProcessInfo(p process.ProcessInfo) {
+// 打印进程详细信息，container 为进程所在的容器
+func printProcessInfo(p process.ProcessInfo, container string) {
 	bold := color.New(color.Bold)
 	cyan := color.New(color.FgCyan)
 	yellow := color.New(color.FgYellow)
 
-	fmt.Println("==============进程详情==============")
-	bold.Printf("进程ID: ")
+	fmt.Println(i18n.T("==============进程详情=============="))
+	bold.Printf(i18n.T("进程ID: "))
 	fmt.Printf("%d\n", p.PID)
 
-	bold.Printf("父进程ID: ")
+	bold.Printf(i18n.T("父进程ID: "))
 	fmt.Printf("%d\n", p.PPID)
 
-	bold.Printf("进程名称: ")
+	bold.Printf(i18n.T("进程名称: "))
 	fmt.Printf("%s\n", p.Name)
 
-	bold.Printf("可执行文件: ")
+	bold.Printf(i18n.T("可执行文件: "))
 	fmt.Printf("%s\n", p.Executable)
 
-	bold.Printf("用户: ")
+	bold.Printf(i18n.T("用户: "))
 	fmt.Printf("%s\n", p.Username)
 
-	bold.Printf("状态: ")
+	bold.Printf(i18n.T("状态: "))
 	fmt.Printf("%s\n", p.Status)
 
-	bold.Printf("创建时间: ")
-	fmt.Printf("%s (%s前)\n",
+	bold.Printf(i18n.T("创建时间: "))
+	fmt.Print(i18n.Tf("%s (%s前)\n",
 		p.CreateTime.Format("2006-01-02 15:04:05"),
-		formatDuration(time.Since(p.CreateTime)))
+		formatDuration(time.Since(p.CreateTime))))
 
-	bold.Printf("CPU使用率: ")
+	bold.Printf(i18n.T("CPU使用率: "))
 	fmt.Printf("%.2f%%\n", p.CPU)
 
-	bold.Printf("内存使用: ")
-	fmt.Printf("%.2f%% (RSS: %s, 虚拟: %s)\n",
+	bold.Printf(i18n.T("内存使用: "))
+	fmt.Print(i18n.Tf("%.2f%% (RSS: %s, 虚拟: %s)\n",
 		p.Memory,
 		formatBytes(p.MemoryInfo.RSS),
-		formatBytes(p.MemoryInfo.VMS))
+		formatBytes(p.MemoryInfo.VMS)))
 
-	bold.Printf("线程数: ")
+	bold.Printf(i18n.T("线程数: "))
 	fmt.Printf("%d\n", p.Threads)
 
+	if container != "" {
+		bold.Printf(i18n.T("容器: "))
+		fmt.Printf("%s\n", container)
+	}
+
 	// 打印命令行
-	bold.Println("命令行:")
+	bold.Println(i18n.T("命令行:"))
 	if len(p.CmdLine) > 0 {
 		cmdLine := ""
 		for i, arg := range p.CmdLine {
@@ -98,12 +133,12 @@ func printProcessInfo(p process.ProcessInfo) {
 		}
 		cyan.Printf("  %s\n", cmdLine)
 	} else {
-		yellow.Println("  [无法获取命令行]")
+		yellow.Println(i18n.T("  [无法获取命令行]"))
 	}
 
 	// 打印打开的文件
 	if len(p.OpenFiles) > 0 {
-		bold.Println("打开的文件:")
+		bold.Println(i18n.T("打开的文件:"))
 		for _, file := range p.OpenFiles {
 			fmt.Printf("  %s\n", file)
 		}
@@ -122,13 +157,13 @@ func formatDuration(d time.Duration) string {
 	seconds := int(d.Seconds()) % 60
 
 	if days > 0 {
-		return fmt.Sprintf("%d天%d小时", days, hours)
+		return i18n.Tf("%d天%d小时", days, hours)
 	} else if hours > 0 {
-		return fmt.Sprintf("%d小时%d分钟", hours, minutes)
+		return i18n.Tf("%d小时%d分钟", hours, minutes)
 	} else if minutes > 0 {
-		return fmt.Sprintf("%d分钟%d秒", minutes, seconds)
+		return i18n.Tf("%d分钟%d秒", minutes, seconds)
 	}
-	return fmt.Sprintf("%d秒", seconds)
+	return i18n.Tf("%d秒", seconds)
 }
 
 // 格式化字节数为人类可读格式
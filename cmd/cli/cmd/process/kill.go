@@ -2,8 +2,10 @@ package process
 
 import (
 	"fmt"
-	"os"
 	"strconv"
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/process"
 
 	"github.com/spf13/cobra"
@@ -16,36 +18,43 @@ var killCmd = &cobra.Command{
 	Long: `终止指定PID的进程，尝试先优雅地终止，如果失败则强制终止。
 
 示例:
-  %[1]s process kill 1234     # 终止PID为1234的进程`,
+  %[1]s process kill 1234              # 终止PID为1234的进程
+  %[1]s process kill 1234 --dry-run    # 只显示将要终止的进程`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 解析PID
 		pid, err := strconv.ParseInt(args[0], 10, 32)
 		if err != nil {
-			fmt.Printf("无效的PID: %v\n", err)
-			os.Exit(1)
+			return errs.InvalidInput("无效的PID: %v", err)
 		}
 
 		// 获取进程信息
 		procInfo, err := process.GetProcessByPID(int32(pid))
 		if err != nil {
-			fmt.Printf("获取进程信息失败: %v\n", err)
-			os.Exit(1)
+			return errs.Wrap(err, "获取进程信息失败: %v", err)
 		}
 
-		fmt.Printf("正在终止进程 %d (%s)...\n", procInfo.PID, procInfo.Name)
+		if dryrun.Enabled(cmd) {
+			plan := &dryrun.Plan{}
+			plan.Addf(dryrun.ActionKill, fmt.Sprintf("%d (%s)", procInfo.PID, procInfo.Name),
+				"先尝试优雅终止，失败时强制终止")
+			return dryrun.Render(cmd, plan)
+		}
+
+		fmt.Print(i18n.Tf("正在终止进程 %d (%s)...\n", procInfo.PID, procInfo.Name))
 
 		// 终止进程
 		err = process.KillProcess(int32(pid))
 		if err != nil {
-			fmt.Printf("终止进程失败: %v\n", err)
-			os.Exit(1)
+			return errs.Wrap(err, "终止进程失败: %v", err)
 		}
 
-		fmt.Printf("进程 %d 已成功终止\n", pid)
+		fmt.Print(i18n.Tf("进程 %d 已成功终止\n", pid))
+		return nil
 	},
 }
 
 func init() {
+	dryrun.AddFlag(killCmd)
 	ProcessCmd.AddCommand(killCmd)
 }
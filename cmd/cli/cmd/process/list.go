@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"time"
+	"toolbox/cmd/cli/cmd/host"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/process"
+	"toolbox/pkg/remote"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
@@ -25,8 +30,9 @@ var listCmd = &cobra.Command{
   %[1]s process list --sort memory  # 按内存使用率排序
   %[1]s process list --show-system  # 显示系统进程
   %[1]s process list --no-empty     # 不显示没有名称的进程
-  %[1]s process list --full-cmd     # 显示完整命令行`,
-	Run: func(cmd *cobra.Command, args []string) {
+  %[1]s process list --full-cmd     # 显示完整命令行
+  %[1]s process list --sort cpu --top 10 --host web1,web2  # 查看远程主机CPU占用最高的进程`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 开始计时
 		startTime := time.Now()
 
@@ -38,6 +44,13 @@ var listCmd = &cobra.Command{
 		noEmpty, _ := cmd.Flags().GetBool("no-empty")
 		fullCmd, _ := cmd.Flags().GetBool("full-cmd")
 
+		if sortBy == "" {
+			sortBy = "pid"
+		}
+		if host.Enabled(cmd) {
+			return listRemote(cmd, filter, sortBy, top, noEmpty, fullCmd)
+		}
+
 		var processList []process.ProcessInfo
 		var err error
 
@@ -46,16 +59,14 @@ var listCmd = &cobra.Command{
 			// 使用名称筛选
 			processList, err = process.FilterProcessesByName(filter)
 			if err != nil {
-				fmt.Printf("获取进程列表失败: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Printf("找到 %d 个匹配 '%s' 的进程\n", len(processList), filter)
+			output.Infof(cmd, "找到 %d 个匹配 '%s' 的进程\n", len(processList), filter)
 		} else {
 			// 获取所有进程
 			processList, err = process.GetProcessList()
 			if err != nil {
-				fmt.Printf("获取进程列表失败: %v\n", err)
-				os.Exit(1)
+				return err
 			}
 		}
 
@@ -73,13 +84,8 @@ var listCmd = &cobra.Command{
 			processList = filtered
 		}
 
-		// 按特定字段排序
-		if sortBy != "" {
-			sortProcessList(processList, sortBy)
-		} else {
-			// 默认按PID排序
-			sortProcessList(processList, "pid")
-		}
+		// 按特定字段排序，默认按PID排序
+		sortProcessList(processList, sortBy)
 
 		// 限制显示的数量
 		if top > 0 && top < len(processList) {
@@ -87,14 +93,82 @@ var listCmd = &cobra.Command{
 		}
 
 		// 输出结果
-		printProcessList(processList, fullCmd)
+		err = output.Render(cmd, processList, func() {
+			printProcessList(processList, fullCmd)
+		})
+		if err != nil {
+			return err
+		}
 
 		// 显示执行时间
-		fmt.Printf("执行时间: %.2f秒\n", time.Since(startTime).Seconds())
+		output.Infof(cmd, "执行时间: %.2f秒\n", time.Since(startTime).Seconds())
+		return nil
 	},
 }
 
+// hostProcess 远程执行时带有主机名的进程信息
+type hostProcess struct {
+	Host string `json:"host"`
+	process.ProcessInfo
+}
+
+// listRemote 获取远程主机的进程列表，每台主机分别过滤、排序后输出
+func listRemote(cmd *cobra.Command, filter, sortBy string, top int, noEmpty, fullCmd bool) error {
+	targets, err := host.Targets(cmd)
+	if err != nil {
+		return err
+	}
+
+	lists := make([][]process.ProcessInfo, len(targets))
+	results := remote.ForEach(targets, func(i int, client *remote.Client) error {
+		processList, err := remote.ProcessList(client)
+		if err != nil {
+			return err
+		}
+
+		var filtered []process.ProcessInfo
+		for _, p := range processList {
+			if noEmpty && p.Name == "" {
+				continue
+			}
+			if filter != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(filter)) {
+				continue
+			}
+			filtered = append(filtered, p)
+		}
+
+		sortProcessList(filtered, sortBy)
+		if top > 0 && top < len(filtered) {
+			filtered = filtered[:top]
+		}
+		lists[i] = filtered
+		return nil
+	})
+
+	var entries []hostProcess
+	for i, processList := range lists {
+		for _, p := range processList {
+			entries = append(entries, hostProcess{Host: targets[i].Label(), ProcessInfo: p})
+		}
+	}
+	err = output.Render(cmd, entries, func() {
+		for i, processList := range lists {
+			if results[i] != nil {
+				continue
+			}
+			color.New(color.FgCyan, color.Bold).Printf("==> %s <==\n", targets[i].Label())
+			printProcessList(processList, fullCmd)
+			fmt.Println()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return host.ReportErrors(targets, results)
+}
+
 func init() {
+	host.MarkSupported(listCmd)
 	ProcessCmd.AddCommand(listCmd)
 
 	// 添加命令行标志
@@ -104,6 +178,12 @@ func init() {
 	listCmd.Flags().BoolP("show-system", "S", false, "显示系统进程")
 	listCmd.Flags().BoolP("no-empty", "e", false, "不显示没有名称的进程")
 	listCmd.Flags().BoolP("full-cmd", "c", false, "显示完整命令行")
+
+	// 参数补全
+	listCmd.RegisterFlagCompletionFunc("sort", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"pid\t" + i18n.T("按PID排序"), "cpu\t" + i18n.T("按CPU使用率排序"), "memory\t" + i18n.T("按内存使用率排序")},
+			cobra.ShellCompDirectiveNoFileComp
+	})
 }
 
 // 根据指定字段对进程列表进行排序
@@ -138,7 +218,7 @@ func printProcessList(processes []process.ProcessInfo, fullCmd bool) {
 	table := tablewriter.NewWriter(os.Stdout)
 
 	// 设置表头
-	table.SetHeader([]string{"PID", "PPID", "CPU%", "MEM%", "用户", "名称", "命令"})
+	table.SetHeader([]string{"PID", "PPID", "CPU%", "MEM%", i18n.T("用户"), i18n.T("名称"), i18n.T("命令")})
 
 	// 设置表格样式
 	table.SetAutoWrapText(false)
@@ -158,7 +238,7 @@ func printProcessList(processes []process.ProcessInfo, fullCmd bool) {
 		// 格式化进程名称
 		name := p.Name
 		if name == "" {
-			name = color.YellowString("[无名称]")
+			name = color.YellowString(i18n.T("[无名称]"))
 		}
 
 		// 格式化用户名
@@ -189,7 +269,7 @@ func printProcessList(processes []process.ProcessInfo, fullCmd bool) {
 	table.Render()
 
 	// 添加总结信息
-	fmt.Printf("\n共 %d 个进程\n", len(processes))
+	fmt.Print(i18n.Tf("\n共 %d 个进程\n", len(processes)))
 }
 
 // 格式化命令行
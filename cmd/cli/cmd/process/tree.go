@@ -1,9 +1,13 @@
 package process
 
 import (
-	"fmt"
+	"os"
 	"strconv"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 	"toolbox/pkg/process"
+	"toolbox/pkg/termcolor"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -25,20 +29,17 @@ var treeCmd = &cobra.Command{
 示例:
   %[1]s process tree       # 显示所有进程的树形结构
   %[1]s process tree 1234  # 显示PID为1234的进程及其子进程的树形结构`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 获取所有进程
 		processList, err := process.GetProcessList()
 		if err != nil {
-			errorColor.Printf("获取进程列表失败: %v\n", err)
-			return
+			return err
 		}
 
 		// 使用筛选名称参数
 		filter, _ := cmd.Flags().GetString("filter")
 		// 获取是否显示详细信息
 		showDetail, _ := cmd.Flags().GetBool("detail")
-		// 获取是否显示彩色输出
-		noColor, _ := cmd.Flags().GetBool("no-color")
 
 		// 构建进程树选项
 		options := process.ProcessTreeOptions{
@@ -52,8 +53,7 @@ var treeCmd = &cobra.Command{
 			if pid, err := strconv.ParseInt(args[0], 10, 32); err == nil {
 				options.RootPID = int32(pid)
 			} else {
-				errorColor.Printf("无效的PID: %v\n", err)
-				return
+				return errs.InvalidInput("无效的PID: %v", err)
 			}
 		}
 
@@ -61,11 +61,10 @@ var treeCmd = &cobra.Command{
 		tree, err := process.BuildProcessTree(processList, options)
 		if err != nil {
 			if options.RootPID != 0 {
-				errorColor.Printf("构建进程树失败: %v\n", err)
-				return
+				return errs.Wrap(err, "构建进程树失败: %v", err)
 			}
 			// 如果是根进程树构建失败，尝试使用一个备用方案
-			errorColor.Printf("警告: %v, 尝试使用备用方法...\n", err)
+			errorColor.Fprintf(os.Stderr, i18n.T("警告: %v, 尝试使用备用方法...\n"), err)
 
 			// 创建一个模拟的系统节点
 			tree = &process.ProcessTreeNode{
@@ -78,21 +77,28 @@ var treeCmd = &cobra.Command{
 		}
 
 		// 创建渲染器
-		renderer := process.NewTableRenderer(showDetail, noColor)
+		renderer := process.NewTableRenderer(showDetail, !termcolor.Enabled())
 
 		// 设置标题
 		if options.RootPID == 0 {
-			renderer.Title = "系统进程树:"
+			renderer.Title = i18n.T("系统进程树:")
 		} else {
-			renderer.Title = fmt.Sprintf("进程 %d (%s) 的进程树:",
+			renderer.Title = i18n.Tf("进程 %d (%s) 的进程树:",
 				tree.Process.PID, tree.Process.Name)
 		}
 
 		// 渲染进程树
-		if err := renderer.Render(tree); err != nil {
-			errorColor.Printf("渲染进程树失败: %v\n", err)
-			return
+		var renderErr error
+		err = output.Render(cmd, tree, func() {
+			renderErr = renderer.Render(tree)
+		})
+		if err != nil {
+			return err
+		}
+		if renderErr != nil {
+			return i18n.Errorf("渲染进程树失败: %v", renderErr)
 		}
+		return nil
 	},
 }
 
@@ -102,5 +108,4 @@ func init() {
 	// 添加命令行标志
 	treeCmd.Flags().StringP("filter", "f", "", "按进程名称过滤")
 	treeCmd.Flags().BoolP("detail", "d", false, "显示详细信息，包括内存和CPU使用情况")
-	treeCmd.Flags().Bool("no-color", false, "禁用彩色输出")
 }
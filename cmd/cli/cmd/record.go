@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"time"
+	"toolbox/pkg/history"
+	"toolbox/pkg/logger"
+	"toolbox/pkg/metrics"
+	"toolbox/pkg/tasks"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// skipHistory 不记录到历史中的顶层命令，clip 的参数可能是要复制的敏感内容；
+// 其他命令中敏感选项的值由 redactArgs 隐藏
+var skipHistory = map[string]bool{
+	"history":                       true,
+	"rerun":                         true,
+	"clip":                          true,
+	"help":                          true,
+	"completion":                    true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// skipMetrics 不记录指标的顶层命令
+var skipMetrics = map[string]bool{
+	"stats":                         true,
+	"help":                          true,
+	"completion":                    true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// recordHistory 将本次执行的命令、耗时和退出码追加到历史记录
+//
+// 只显示帮助的命令、历史相关命令以及任务文件中的步骤不记录，
+// 写入失败不影响命令本身的结果。
+func recordHistory(cmd *cobra.Command, start time.Time, exitCode int) {
+	if cmd == nil || cmd == rootCmd || !history.Enabled() || os.Getenv(tasks.DepthEnv) != "" {
+		return
+	}
+	if skipHistory[topLevelName(cmd)] || isHelp(cmd) {
+		return
+	}
+
+	dir, _ := os.Getwd()
+	err := history.Append(history.Entry{
+		Time:       start,
+		Command:    commandPath(cmd),
+		Args:       redactArgs(cmd, os.Args[1:]),
+		Dir:        dir,
+		DurationMs: time.Since(start).Milliseconds(),
+		ExitCode:   exitCode,
+	})
+	if err != nil {
+		logger.Debugf("记录历史失败: %v", err)
+	}
+}
+
+// recordMetrics 在开启指标记录时追加本次执行的耗时和资源占用
+//
+// 资源占用包括已结束的子进程，run、watch、map 等命令的统计中含有其执行的子命令。
+func recordMetrics(cmd *cobra.Command, start time.Time, exitCode int) {
+	if cmd == nil || cmd == rootCmd || skipMetrics[topLevelName(cmd)] || isHelp(cmd) || !metrics.Enabled() {
+		return
+	}
+	err := metrics.Record(commandPath(cmd), redactArgs(cmd, os.Args[1:]), start, metrics.ReadUsage(), exitCode)
+	if err != nil {
+		logger.Debugf("记录指标失败: %v", err)
+	}
+}
+
+// sensitiveFlagWords 选项名含有这些词时，历史和指标中不记录选项的值
+var sensitiveFlagWords = []string{"secret", "key", "password", "passphrase", "token", "auth"}
+
+// redactedValue 替换敏感选项的值
+const redactedValue = history.RedactedValue
+
+// redactArgs 返回将敏感选项（如 --secret、hash hmac --key、--password）的值替换为 ****** 的参数副本，
+// 支持 --flag=值、--flag 值、-k 值 和 -k值 的写法；-- 之后的参数原样保留。
+// 含有 secret= 的参数（如 totp --from 的 otpauth:// URI）无论是哪个选项的值都整个隐藏
+func redactArgs(cmd *cobra.Command, args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+	flags := cmd.Flags()
+	for i := 0; i < len(redacted); i++ {
+		arg := redacted[i]
+		if name, value, ok := strings.Cut(arg, "="); ok && strings.HasPrefix(arg, "--") {
+			if containsSecret(value) {
+				redacted[i] = name + "=" + redactedValue
+				continue
+			}
+		} else if containsSecret(arg) {
+			redacted[i] = redactedValue
+			continue
+		}
+		if arg == "--" {
+			break
+		}
+		var flag *pflag.Flag
+		var name string
+		inline := false // 值与选项写在同一个参数中
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			var value string
+			name, value, inline = strings.Cut(arg[2:], "=")
+			flag = flags.Lookup(name)
+			if inline && sensitiveFlag(name) && value != "" {
+				redacted[i] = "--" + name + "=" + redactedValue
+				continue
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			if flag = flags.ShorthandLookup(arg[1:2]); flag == nil {
+				continue
+			}
+			name = flag.Name
+			if len(arg) > 2 {
+				if sensitiveFlag(name) && flag.NoOptDefVal == "" {
+					redacted[i] = arg[:2] + redactedValue
+				}
+				continue
+			}
+		default:
+			continue
+		}
+		if inline || !sensitiveFlag(name) || i+1 >= len(redacted) {
+			continue
+		}
+		// 布尔等不需要值的选项不处理下一个参数；未知的选项按带值处理
+		if flag != nil && flag.NoOptDefVal != "" {
+			continue
+		}
+		i++
+		redacted[i] = redactedValue
+	}
+	return redacted
+}
+
+// containsSecret 判断参数中是否含有 secret= 形式的密钥，如 otpauth:// URI 的查询参数
+func containsSecret(arg string) bool {
+	return strings.Contains(strings.ToLower(arg), "secret=")
+}
+
+// sensitiveFlag 判断选项名是否表示密钥、密码等敏感内容
+func sensitiveFlag(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveFlagWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// topLevelName 返回命令所属的顶层命令名称
+func topLevelName(cmd *cobra.Command) string {
+	top := cmd
+	for top.HasParent() && top.Parent() != rootCmd {
+		top = top.Parent()
+	}
+	return top.Name()
+}
+
+// commandPath 返回不含程序名的命令路径，如 "fs find"
+func commandPath(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+}
+
+// isHelp 判断本次执行是否只显示帮助
+func isHelp(cmd *cobra.Command) bool {
+	help, _ := cmd.Flags().GetBool("help")
+	return help
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"toolbox/cmd/cli/cmd/totp"
+)
+
+// 敏感选项的值和含有 secret= 的参数（如 otpauth:// URI）都不写入历史
+func TestRedactArgs(t *testing.T) {
+	uri := "otpauth://totp/Example:alice?secret=JBSWY3DPEHPK3PXP&issuer=Example"
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"totp", "--secret", "JBSWY3DPEHPK3PXP"}, []string{"totp", "--secret", redactedValue}},
+		{[]string{"totp", "--secret=JBSWY3DPEHPK3PXP"}, []string{"totp", "--secret=" + redactedValue}},
+		{[]string{"totp", "-sJBSWY3DPEHPK3PXP"}, []string{"totp", "-s" + redactedValue}},
+		{[]string{"totp", "--from", uri}, []string{"totp", "--from", redactedValue}},
+		{[]string{"totp", "--from=" + uri}, []string{"totp", "--from=" + redactedValue}},
+		{[]string{"totp", "--from", "@key.txt", "-d", "8"}, []string{"totp", "--from", "@key.txt", "-d", "8"}},
+	}
+	for _, tt := range tests {
+		if got := redactArgs(totp.TotpCmd, tt.args); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("redactArgs(%q) = %q，应为 %q", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package regex
+
+import (
+	"os"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/textproc"
+
+	"github.com/spf13/cobra"
+)
+
+// explainCmd 表示 regex explain 命令
+var explainCmd = &cobra.Command{
+	Use:   "explain <模式>",
+	Short: "将正则表达式拆分为带说明的组成部分",
+	Long: `解析正则表达式并按结构逐层列出每个组成部分及其含义，如字符类、重复次数、捕获组和分支。
+
+显示的模式是解析后的规范形式，例如 \d 显示为 [0-9]，相同前缀的分支可能被合并。
+
+示例:
+  %[1]s regex explain '\d{4}-\d{2}'
+  %[1]s regex explain '^(?P<user>[\w.]+)@([a-z0-9-]+\.)+[a-z]{2,}$'
+  %[1]s regex explain -i 'error|warn(ing)?'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		parts, err := textproc.ExplainRegex(regexOptions(cmd, args[0]))
+		if err != nil {
+			return err
+		}
+
+		return output.Render(cmd, parts, func() {
+			table := output.NewTable(os.Stdout, []string{i18n.T("部分"), i18n.T("说明")})
+			for _, part := range parts {
+				table.Append([]string{strings.Repeat("  ", part.Depth) + part.Pattern, part.Description})
+			}
+			table.Render()
+		})
+	},
+}
+
+func init() {
+	addRegexFlags(explainCmd)
+}
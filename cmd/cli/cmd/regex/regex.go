@@ -0,0 +1,49 @@
+package regex
+
+import (
+	"toolbox/pkg/textproc"
+
+	"github.com/spf13/cobra"
+)
+
+// RegexCmd 表示正则表达式调试命令组
+var RegexCmd = &cobra.Command{
+	Use:   "regex",
+	Short: "测试和解释正则表达式",
+	Long: `测试和解释正则表达式，方便在使用 text grep、text replace 和 text filter 前调试模式。
+使用Go的RE2语法，与 text 系列命令一致。
+
+包含以下子命令:
+  test - 显示匹配的位置和捕获组
+  explain - 将正则表达式拆分为带说明的组成部分
+
+示例:
+  %[1]s regex test '\d{4}-\d{2}' --input app.log
+  %[1]s regex explain '^(?P<user>\w+)@(\w+\.)+com$'`,
+}
+
+// regexOptions 从命令行标志读取正则选项
+func regexOptions(cmd *cobra.Command, pattern string) textproc.RegexOptions {
+	ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+	multiline, _ := cmd.Flags().GetBool("multiline")
+	dotAll, _ := cmd.Flags().GetBool("dotall")
+	return textproc.RegexOptions{
+		Pattern:    pattern,
+		IgnoreCase: ignoreCase,
+		Multiline:  multiline,
+		DotAll:     dotAll,
+	}
+}
+
+// addRegexFlags 添加正则标志相关的选项
+func addRegexFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("ignore-case", "i", false, "忽略大小写")
+	cmd.Flags().BoolP("multiline", "m", false, "^ 和 $ 匹配每行的开头和结尾")
+	cmd.Flags().BoolP("dotall", "s", false, ". 匹配换行符")
+}
+
+func init() {
+	// 添加子命令
+	RegexCmd.AddCommand(testCmd)
+	RegexCmd.AddCommand(explainCmd)
+}
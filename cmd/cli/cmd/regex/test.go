@@ -0,0 +1,135 @@
+package regex
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/textproc"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// testCmd 表示 regex test 命令
+var testCmd = &cobra.Command{
+	Use:   "test <模式> [文本...]",
+	Short: "显示匹配的位置和捕获组",
+	Long: `在文本中查找正则表达式的所有匹配，显示每个匹配的行号、列号、匹配文本和捕获组。
+
+文本可以来自命令行参数（多个参数按行拼接）、--input 指定的文件或标准输入。
+匹配在整段文本上进行，因此模式可以跨行；需要逐行匹配 ^ 和 $ 时使用 -m。
+没有任何匹配时以退出码1退出。
+
+示例:
+  %[1]s regex test '\d{4}-\d{2}' --input app.log
+  %[1]s regex test '(?P<key>\w+)=(?P<value>[^&]*)' 'a=1&b=2&c='
+  %[1]s regex test -i '^error' -m --input app.log -n 20
+  cat access.log | %[1]s regex test '" (\d{3}) ' --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath, _ := cmd.Flags().GetString("input")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		var text string
+		switch {
+		case len(args) > 1:
+			if inputPath != "" {
+				return errs.InvalidInput("--input 不能与文本参数同时使用")
+			}
+			text = strings.Join(args[1:], "\n")
+		case inputPath != "" && inputPath != "-":
+			data, err := os.ReadFile(inputPath)
+			if err != nil {
+				return errs.Wrap(err, "读取文件失败: %v", err)
+			}
+			text = string(data)
+		default:
+			if inputPath == "" && isatty.IsTerminal(os.Stdin.Fd()) {
+				return errs.InvalidInput("请提供文本参数、使用 --input 指定文件，或通过管道输入内容")
+			}
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return errs.Wrap(err, "读取标准输入失败: %v", err)
+			}
+			text = string(data)
+		}
+
+		options := regexOptions(cmd, args[0])
+		options.MaxMatches = limit
+		result, err := textproc.TestRegex(text, options)
+		if err != nil {
+			return err
+		}
+
+		if err := output.Render(cmd, result, func() { printMatches(result) }); err != nil {
+			return err
+		}
+		if len(result.Matches) == 0 {
+			return errs.Exit(1)
+		}
+		return nil
+	},
+}
+
+// printMatches 以表格形式输出匹配结果
+func printMatches(result textproc.RegexTestResult) {
+	if len(result.Matches) == 0 {
+		fmt.Println(i18n.T("没有匹配"))
+		return
+	}
+
+	hasGroups := len(result.Matches[0].Groups) > 0
+	header := []string{"#", i18n.T("位置"), i18n.T("匹配")}
+	if hasGroups {
+		header = append(header, i18n.T("捕获组"))
+	}
+	table := output.NewTable(os.Stdout, header)
+	for i, m := range result.Matches {
+		row := []string{
+			strconv.Itoa(i + 1),
+			fmt.Sprintf("%d:%d", m.Line, m.Column),
+			quoteText(m.Text),
+		}
+		if hasGroups {
+			groups := make([]string, 0, len(m.Groups))
+			for _, g := range m.Groups {
+				name := strconv.Itoa(g.Index)
+				if g.Name != "" {
+					name = g.Name
+				}
+				value := i18n.T("(未匹配)")
+				if g.Matched {
+					value = quoteText(g.Text)
+				}
+				groups = append(groups, name+"="+value)
+			}
+			row = append(row, strings.Join(groups, " "))
+		}
+		table.Append(row)
+	}
+	table.Render()
+
+	summary := i18n.Tf("共 %d 个匹配", len(result.Matches))
+	if result.Truncated {
+		summary = i18n.Tf("显示前 %d 个匹配，使用 --limit 0 显示全部", len(result.Matches))
+	}
+	color.New(color.Faint).Println(summary)
+}
+
+// quoteText 在表格中显示匹配文本，转义换行等不可见字符
+func quoteText(text string) string {
+	quoted := strconv.Quote(text)
+	return quoted[1 : len(quoted)-1]
+}
+
+func init() {
+	testCmd.Flags().String("input", "", "从文件读取文本，- 表示标准输入")
+	testCmd.Flags().IntP("limit", "n", 100, "最多显示的匹配数，0 表示不限制")
+	addRegexFlags(testCmd)
+}
@@ -1,17 +1,50 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/clip"
+	"toolbox/cmd/cli/cmd/conv"
+	"toolbox/cmd/cli/cmd/cron"
+	"toolbox/cmd/cli/cmd/crypt"
+	"toolbox/cmd/cli/cmd/disk"
+	"toolbox/cmd/cli/cmd/docker"
+	"toolbox/cmd/cli/cmd/enc"
+	"toolbox/cmd/cli/cmd/env"
+	"toolbox/cmd/cli/cmd/fanout"
 	fmt_local "toolbox/cmd/cli/cmd/fmt"
 	"toolbox/cmd/cli/cmd/fs"
+	"toolbox/cmd/cli/cmd/hash"
+	"toolbox/cmd/cli/cmd/history"
+	"toolbox/cmd/cli/cmd/host"
+	"toolbox/cmd/cli/cmd/id"
+	log_local "toolbox/cmd/cli/cmd/log"
+	"toolbox/cmd/cli/cmd/md"
+	"toolbox/cmd/cli/cmd/mock"
 	"toolbox/cmd/cli/cmd/network"
+	"toolbox/cmd/cli/cmd/output"
 	"toolbox/cmd/cli/cmd/process"
+	"toolbox/cmd/cli/cmd/regex"
+	"toolbox/cmd/cli/cmd/run"
+	"toolbox/cmd/cli/cmd/servedir"
+	"toolbox/cmd/cli/cmd/service"
+	"toolbox/cmd/cli/cmd/stats"
+	"toolbox/cmd/cli/cmd/sysinfo"
 	"toolbox/cmd/cli/cmd/text"
+	time_local "toolbox/cmd/cli/cmd/time"
+	"toolbox/cmd/cli/cmd/totp"
+	"toolbox/cmd/cli/cmd/tui"
 	"toolbox/cmd/cli/cmd/version"
+	"toolbox/cmd/cli/cmd/watch"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/termcolor"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -20,9 +53,28 @@ var programName string
 
 // rootCmd 表示基础命令
 var rootCmd = &cobra.Command{
-	Use:   "toolbox",
+	Use:   "%[1]s", // 在Execute中替换为实际的程序名，使帮助和补全脚本与可执行文件名一致
 	Short: "一个功能丰富的命令行工具箱",
 	Long:  `Toolbox 是一个集成了多种实用功能的命令行工具箱，具体功能使用 -h 查看`,
+	// 错误统一由Execute输出并转换为退出码
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		setupColor(cmd)
+		if err := host.Check(cmd); err != nil {
+			return err
+		}
+
+		// 校验全局输出格式
+		name, _ := cmd.Root().PersistentFlags().GetString(output.FlagName)
+		if _, err := output.ParseFormat(name); err != nil {
+			return err
+		}
+		if err := setupLogging(cmd); err != nil {
+			return err
+		}
+		return setupClipboard(cmd)
+	},
 }
 
 // Execute 将所有子命令添加到根命令并设置标志。
@@ -31,24 +83,85 @@ func Execute() {
 	// 获取程序名
 	programName = getProgramName()
 
+	// 先根据环境变量确定是否输出颜色，解析标志后再应用 --no-color
+	termcolor.SetEnabled(termcolor.FromEnv())
+
+	// 确定界面语言并翻译命令说明
+	if err := setupLanguage(os.Args[1:]); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	localizeCommands(rootCmd)
+	wrapArgsErrors(rootCmd)
+
 	// 设置根命令的说明
 	rootCmd.Use = fmt.Sprintf(rootCmd.Use, programName)
-	rootCmd.Long = fmt.Sprintf(rootCmd.Long, programName)
+	rootCmd.Long = withProgramName(rootCmd.Long)
 
 	// 遍历所有子命令，替换模板变量
 	for _, cmd := range rootCmd.Commands() {
-		if cmd.Long != "" {
-			cmd.Long = fmt.Sprintf(strings.ReplaceAll(cmd.Long, "{{.CommandPath}}", "%[1]s"), programName)
-		}
-		if cmd.Example != "" {
-			cmd.Example = fmt.Sprintf(strings.ReplaceAll(cmd.Example, "{{.CommandPath}}", "%[1]s"), programName)
-		}
+		expandProgramName(cmd)
 	}
 
-	err := rootCmd.Execute()
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	if clipErr := finishClipboard(); clipErr != nil && err == nil {
+		err = clipErr
+	}
+	exitCode := 0
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitCode = handleError(cmd, err)
+	}
+	recordHistory(cmd, start, exitCode)
+	recordMetrics(cmd, start, exitCode)
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// expandProgramName 将cmd及其所有子命令说明中的程序名占位符替换为实际的程序名
+func expandProgramName(cmd *cobra.Command) {
+	cmd.Long = withProgramName(cmd.Long)
+	cmd.Example = withProgramName(cmd.Example)
+	for _, child := range cmd.Commands() {
+		expandProgramName(child)
+	}
+}
+
+// withProgramName 替换说明文字中的 %[1]s 和 {{.CommandPath}}；
+// 有占位符的文字按格式字符串处理，其中的 % 需要写成 %%，没有占位符的原样返回
+func withProgramName(text string) string {
+	text = strings.ReplaceAll(text, "{{.CommandPath}}", "%[1]s")
+	if !strings.Contains(text, "%[1]s") {
+		return text
+	}
+	return fmt.Sprintf(text, programName)
+}
+
+// handleError 输出命令返回的错误，并返回对应的退出码
+func handleError(cmd *cobra.Command, err error) int {
+	var exitErr *errs.ExitError
+	if !errors.As(err, &exitErr) {
+		color.New(color.FgRed).Fprintln(os.Stderr, i18n.Tf("错误: %v", err))
+		if errors.Is(err, errs.ErrInvalidInput) && cmd != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("运行 '%s -h' 查看帮助", cmd.CommandPath()))
+		}
+	}
+	return errs.ExitCode(err)
+}
+
+// wrapArgsErrors 将各命令参数校验失败的错误标记为输入无效
+func wrapArgsErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return errs.InvalidInput("%v", err)
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		wrapArgsErrors(child)
 	}
 }
 
@@ -67,11 +180,48 @@ func init() {
 	// 初始化程序名
 	programName = getProgramName()
 
+	// 全局标志
+	output.AddFlag(rootCmd)
+	addLoggingFlags(rootCmd)
+	addLangFlag(rootCmd)
+	addColorFlag(rootCmd)
+	host.AddFlag(rootCmd)
+	addClipboardFlag(rootCmd)
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return errs.InvalidInput("%v", err)
+	})
+
 	// 添加模块
 	rootCmd.AddCommand(network.NetworkCmd)
 	rootCmd.AddCommand(fmt_local.FmtCmd)
 	rootCmd.AddCommand(fs.FsCmd)
 	rootCmd.AddCommand(text.TextCmd)
 	rootCmd.AddCommand(process.ProcessCmd)
+	rootCmd.AddCommand(run.RunCmd)
+	rootCmd.AddCommand(history.HistoryCmd)
+	rootCmd.AddCommand(history.RerunCmd)
+	rootCmd.AddCommand(stats.StatsCmd)
+	rootCmd.AddCommand(watch.WatchCmd)
+	rootCmd.AddCommand(fanout.MapCmd)
+	rootCmd.AddCommand(clip.ClipCmd)
+	rootCmd.AddCommand(enc.EncCmd)
+	rootCmd.AddCommand(hash.HashCmd)
+	rootCmd.AddCommand(id.IdCmd)
+	rootCmd.AddCommand(time_local.TimeCmd)
+	rootCmd.AddCommand(mock.MockCmd)
+	rootCmd.AddCommand(crypt.CryptCmd)
+	rootCmd.AddCommand(regex.RegexCmd)
+	rootCmd.AddCommand(conv.ConvCmd)
+	rootCmd.AddCommand(sysinfo.SysinfoCmd)
+	rootCmd.AddCommand(disk.DiskCmd)
+	rootCmd.AddCommand(service.ServiceCmd)
+	rootCmd.AddCommand(cron.CronCmd)
+	rootCmd.AddCommand(log_local.LogCmd)
+	rootCmd.AddCommand(servedir.ServeDirCmd)
+	rootCmd.AddCommand(md.MdCmd)
+	rootCmd.AddCommand(env.EnvCmd)
+	rootCmd.AddCommand(docker.DockerCmd)
+	rootCmd.AddCommand(totp.TotpCmd)
+	rootCmd.AddCommand(tui.TuiCmd)
 	rootCmd.AddCommand(version.VersionCmd)
 }
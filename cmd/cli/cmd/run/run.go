@@ -0,0 +1,195 @@
+package run
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/tasks"
+	"toolbox/pkg/units"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// inheritedFlags 传递给每个工具箱步骤的全局标志
+var inheritedFlags = []string{"lang", "no-color", "verbose", "quiet", "log-file"}
+
+// RunCmd 表示 run 命令
+var RunCmd = &cobra.Command{
+	Use:   "run <任务文件>",
+	Short: "按任务文件批量执行命令",
+	Long: `按YAML任务文件依次执行一组工具箱命令和外部命令，并在最后输出执行汇总。
+
+适合把压缩、分割、校验、上传等经常重复的操作固化下来，不需要编写shell脚本。
+默认任一步骤失败后停止执行，后续步骤标记为跳过；可以在任务文件或单个步骤中
+设置 continue_on_error，或使用 --continue-on-error 继续执行。
+只要有步骤失败，命令就以第一个失败步骤的退出码退出。
+
+任务文件格式:
+  name: 每日备份
+  vars:
+    src: ./data
+    archive: backup.tar.gz
+  continue_on_error: false
+  steps:
+    - name: 压缩
+      run: fs compress ${src} ${archive}
+    - name: 分卷
+      run: fs split ${src} --size 100M -o ${src}_chunks
+      timeout: 10m
+    - name: 校验
+      shell: sha256sum ${archive} > ${archive}.sha256
+      continue_on_error: true
+    - name: 上传
+      shell: scp ${archive} ${archive}.sha256 backup@server:/backups/
+      env:
+        SSH_AUTH_SOCK: /tmp/agent.sock
+
+步骤字段:
+  run                 工具箱子命令及参数（不包含程序名）
+  args                追加到run之后的参数列表，每项作为一个参数，不需要转义
+  shell               通过系统shell执行的外部命令，与run二选一
+  dir                 工作目录
+  env                 额外的环境变量
+  timeout             超时时间，如 30s、10m
+  continue_on_error   失败后是否继续执行后续步骤
+
+命令中的 ${name} 依次从 --var、vars 和环境变量中取值，未定义时报错；
+$name 形式不做替换，留给shell处理。步骤也可以直接写成字符串，表示 run 的内容。
+
+示例:
+  %[1]s run tasks.yaml
+  %[1]s run tasks.yaml --var src=/var/www --var archive=www.tar.gz
+  %[1]s run tasks.yaml --continue-on-error
+  %[1]s run tasks.yaml --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+		varList, _ := cmd.Flags().GetStringArray("var")
+
+		file, err := tasks.LoadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		vars, err := parseVars(varList)
+		if err != nil {
+			return err
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return i18n.Errorf("获取程序路径失败: %v", err)
+		}
+
+		// 结构化输出时，步骤的输出写入标准错误，标准输出只保留汇总
+		stdout := os.Stdout
+		if output.IsStructured(cmd) {
+			stdout = os.Stderr
+		}
+
+		headerColor := color.New(color.FgCyan, color.Bold)
+		summary, err := tasks.Run(file, tasks.RunOptions{
+			Executable:      executable,
+			GlobalArgs:      globalArgs(cmd),
+			Vars:            vars,
+			ContinueOnError: continueOnError,
+			Stdout:          stdout,
+			Stderr:          os.Stderr,
+			OnStepStart: func(index int, step tasks.Step, command string) {
+				headerColor.Fprintf(stdout, "==> [%d/%d] %s\n", index, len(file.Steps), step.Label())
+				if step.Name != "" {
+					fmt.Fprintf(stdout, "    %s\n", command)
+				}
+			},
+			OnStepDone: func(result tasks.StepResult) {
+				if result.Status == tasks.StepStatusOK {
+					color.New(color.FgGreen).Fprintf(stdout, i18n.T("<== 完成 (%s)\n\n"), units.FormatDuration(time.Duration(result.DurationMs)*time.Millisecond))
+				} else {
+					color.New(color.FgRed).Fprintf(stdout, i18n.T("<== 失败: %s (%s)\n\n"), result.Error, units.FormatDuration(time.Duration(result.DurationMs)*time.Millisecond))
+				}
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := output.Render(cmd, summary, func() {
+			printSummary(summary)
+		}); err != nil {
+			return err
+		}
+
+		// 汇总已经输出，有步骤失败时只设置退出码
+		if failure := summary.FirstFailure(); failure != nil {
+			return errs.Exit(failure.ExitCode)
+		}
+		return nil
+	},
+}
+
+// parseVars 解析 --var name=value 形式的变量
+func parseVars(list []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, item := range list {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, errs.InvalidInput("无效的变量: %s（格式为 name=value）", item)
+		}
+		vars[strings.TrimSpace(name)] = value
+	}
+	return vars, nil
+}
+
+// globalArgs 返回需要传递给步骤的全局标志，使子命令与当前命令的语言、颜色和日志设置一致
+func globalArgs(cmd *cobra.Command) []string {
+	var args []string
+	flags := cmd.Root().PersistentFlags()
+	for _, name := range inheritedFlags {
+		if flag := flags.Lookup(name); flag != nil && flag.Changed {
+			args = append(args, fmt.Sprintf("--%s=%s", name, flag.Value.String()))
+		}
+	}
+	return args
+}
+
+// printSummary 以表格形式输出执行汇总
+func printSummary(summary *tasks.RunSummary) {
+	title := i18n.T("执行汇总")
+	if summary.Name != "" {
+		title = i18n.Tf("执行汇总: %s", summary.Name)
+	}
+	color.New(color.Bold).Println(title)
+
+	table := output.NewTable(os.Stdout, []string{i18n.T("序号"), i18n.T("步骤"), i18n.T("状态"), i18n.T("退出码"), i18n.T("耗时")})
+	for _, result := range summary.Steps {
+		var status, exitCode, duration string
+		switch result.Status {
+		case tasks.StepStatusOK:
+			status = color.GreenString(i18n.T("成功"))
+			exitCode = "0"
+			duration = units.FormatDuration(time.Duration(result.DurationMs) * time.Millisecond)
+		case tasks.StepStatusFailed:
+			status = color.RedString(i18n.T("失败"))
+			exitCode = fmt.Sprintf("%d", result.ExitCode)
+			duration = units.FormatDuration(time.Duration(result.DurationMs) * time.Millisecond)
+		default:
+			status = color.YellowString(i18n.T("跳过"))
+		}
+		table.Append([]string{fmt.Sprintf("%d", result.Index), result.Name, status, exitCode, duration})
+	}
+	table.Render()
+
+	fmt.Print(i18n.Tf("\n共 %d 个步骤：成功 %d，失败 %d，跳过 %d，总耗时 %s\n",
+		len(summary.Steps), summary.Succeeded, summary.Failed, summary.Skipped,
+		units.FormatDuration(time.Duration(summary.DurationMs)*time.Millisecond)))
+}
+
+func init() {
+	RunCmd.Flags().Bool("continue-on-error", false, "步骤失败后继续执行后续步骤")
+	RunCmd.Flags().StringArray("var", nil, "设置变量，格式为 name=value，可多次指定")
+}
@@ -0,0 +1,203 @@
+package servedir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fileserver"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// ServeDirCmd 表示 serve-dir 命令
+var ServeDirCmd = &cobra.Command{
+	Use:   "serve-dir [目录]",
+	Short: "通过HTTP提供目录中的静态文件",
+	Long: `启动一个HTTP静态文件服务器，默认提供当前目录，用于临时分享文件或预览前端构建产物，
+可以代替 python -m http.server。
+
+  - 目录中有 index.html 时返回该文件，否则显示目录列表
+  - 支持Range请求，可以断点续传和拖动播放音视频
+  - --auth user:pass 启用Basic认证
+  - --tls 使用临时生成的自签名证书启用HTTPS，也可以用 --cert/--key 指定证书
+  - --upload 允许在目录列表页面上传文件，或用 curl -T 上传，不会覆盖已存在的文件
+  - 默认不提供以 . 开头的文件和目录（如 .git、.env），--hidden 可以关闭该限制
+
+每个请求输出一行访问日志，按 Ctrl+C 停止。
+
+示例:
+  %[1]s serve-dir
+  %[1]s serve-dir ./dist --listen :8000
+  %[1]s serve-dir /data --listen 127.0.0.1:9000 --auth admin:secret --tls
+  %[1]s serve-dir ./inbox --upload --max-upload 2GB
+  curl -T report.pdf http://host:8000/report.pdf          # --upload 时上传文件`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) > 0 {
+			root = args[0]
+		}
+		listen, _ := cmd.Flags().GetString("listen")
+		auth, _ := cmd.Flags().GetString("auth")
+		upload, _ := cmd.Flags().GetBool("upload")
+		hidden, _ := cmd.Flags().GetBool("hidden")
+		useTLS, _ := cmd.Flags().GetBool("tls")
+		certFile, _ := cmd.Flags().GetString("cert")
+		keyFile, _ := cmd.Flags().GetString("key")
+
+		options := fileserver.Options{
+			Root:      root,
+			Upload:    upload,
+			MaxUpload: flagtype.GetSize(cmd.Flags(), "max-upload"),
+			Hidden:    hidden,
+			OnRequest: printRequest,
+		}
+		if auth != "" {
+			user, pass, ok := strings.Cut(auth, ":")
+			if !ok || user == "" {
+				return errs.InvalidInput("--auth 的格式应为 用户名:密码")
+			}
+			options.Username, options.Password = user, pass
+		}
+		if (certFile == "") != (keyFile == "") {
+			return errs.InvalidInput("--cert 和 --key 必须同时指定")
+		}
+
+		handler, err := fileserver.NewHandler(options)
+		if err != nil {
+			return errs.Wrap(err, "无法提供目录 %s: %v", root, err)
+		}
+		listener, err := net.Listen("tcp", listen)
+		if err != nil {
+			return errs.Wrap(err, "监听 %s 失败: %v", listen, err)
+		}
+		defer listener.Close()
+
+		scheme, fingerprint := "http", ""
+		if useTLS || certFile != "" {
+			scheme = "https"
+			if certFile == "" {
+				dir, err := os.MkdirTemp("", "toolbox-serve-*")
+				if err != nil {
+					return errs.Wrap(err, "创建临时目录失败: %v", err)
+				}
+				defer os.RemoveAll(dir)
+				host, _, _ := net.SplitHostPort(listen)
+				if certFile, keyFile, err = fileserver.SelfSigned(dir, []string{host}); err != nil {
+					return err
+				}
+			}
+			if fingerprint, err = fileserver.Fingerprint(certFile, keyFile); err != nil {
+				return err
+			}
+		}
+		printBanner(root, scheme, listener.Addr(), options)
+		if fingerprint != "" {
+			fmt.Print(i18n.Tf("证书SHA-256指纹: %s\n", fingerprint))
+		}
+		fmt.Println()
+
+		server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+		}()
+
+		if scheme == "https" {
+			err = server.ServeTLS(listener, certFile, keyFile)
+		} else {
+			err = server.Serve(listener)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return errs.Wrap(err, "服务器运行失败: %v", err)
+		}
+		fmt.Println(i18n.T("\n服务器已停止"))
+		return nil
+	},
+}
+
+// printBanner 输出提供的目录、可访问的地址和安全提示
+func printBanner(root, scheme string, addr net.Addr, options fileserver.Options) {
+	abs, _ := filepath.Abs(root)
+	tcpAddr := addr.(*net.TCPAddr)
+	fmt.Print(i18n.Tf("提供目录 %s\n", abs))
+	for _, url := range listenURLs(scheme, tcpAddr) {
+		fmt.Printf("  %s\n", color.CyanString(url))
+	}
+
+	public := !tcpAddr.IP.IsLoopback()
+	if options.Username != "" && scheme == "http" {
+		color.Yellow(i18n.T("注意: 未启用TLS，Basic认证的密码以明文传输"))
+	}
+	if options.Upload && options.Username == "" && public {
+		color.Yellow(i18n.T("注意: 已启用上传且未设置认证，网络中的任何人都可以上传文件"))
+	}
+}
+
+// listenURLs 列出可以访问服务器的地址，监听所有地址时列出本机各接口的IPv4地址
+func listenURLs(scheme string, addr *net.TCPAddr) []string {
+	format := func(host string) string {
+		return fmt.Sprintf("%s://%s/", scheme, net.JoinHostPort(host, fmt.Sprint(addr.Port)))
+	}
+	if !addr.IP.IsUnspecified() {
+		return []string{format(addr.IP.String())}
+	}
+
+	urls := []string{format("localhost")}
+	ifaceAddrs, _ := net.InterfaceAddrs()
+	for _, a := range ifaceAddrs {
+		if ipNet, ok := a.(*net.IPNet); ok && ipNet.IP.To4() != nil && !ipNet.IP.IsLoopback() {
+			urls = append(urls, format(ipNet.IP.String()))
+		}
+	}
+	return urls
+}
+
+// printRequest 输出一行访问日志
+func printRequest(r fileserver.Request) {
+	status := fmt.Sprint(r.Status)
+	switch {
+	case r.Status >= 500:
+		status = color.RedString(status)
+	case r.Status >= 400:
+		status = color.YellowString(status)
+	case r.Status >= 300:
+		status = color.CyanString(status)
+	default:
+		status = color.GreenString(status)
+	}
+	host, _, err := net.SplitHostPort(r.Remote)
+	if err != nil {
+		host = r.Remote
+	}
+	fmt.Printf("%s %s %s %s %s %s %s\n",
+		r.Time.Format("15:04:05"), host, status, r.Method, r.Path,
+		units.FormatSize(r.Bytes), r.Duration.Round(time.Millisecond))
+}
+
+func init() {
+	ServeDirCmd.Flags().StringP("listen", "l", ":8000", "监听地址，如 :8000、127.0.0.1:9000，端口为0时随机选择")
+	ServeDirCmd.Flags().String("auth", "", "启用Basic认证，格式为 用户名:密码")
+	ServeDirCmd.Flags().Bool("upload", false, "允许上传文件")
+	flagtype.Size(ServeDirCmd.Flags(), "max-upload", 0, units.MegaByte, "单次上传的最大大小，如 500MB，纯数字表示MB，0 表示不限制")
+	ServeDirCmd.Flags().Bool("hidden", false, "提供以 . 开头的文件和目录")
+	ServeDirCmd.Flags().Bool("tls", false, "使用临时生成的自签名证书启用HTTPS")
+	ServeDirCmd.Flags().String("cert", "", "HTTPS使用的证书文件（PEM）")
+	ServeDirCmd.Flags().String("key", "", "HTTPS使用的私钥文件（PEM）")
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/service"
+
+	"github.com/spf13/cobra"
+)
+
+// startCmd 表示 service start 命令
+var startCmd = newControlCmd(dryrun.ActionStart, "启动", `启动服务，完成后显示服务状态。通常需要root或管理员权限。
+
+示例:
+  sudo %[1]s service start nginx
+  %[1]s service start nginx --dry-run`)
+
+// stopCmd 表示 service stop 命令
+var stopCmd = newControlCmd(dryrun.ActionStop, "停止", `停止服务，完成后显示服务状态。通常需要root或管理员权限。
+Windows上会等待服务进入停止状态，最长30秒。
+
+示例:
+  sudo %[1]s service stop nginx
+  %[1]s service stop nginx --dry-run`)
+
+// restartCmd 表示 service restart 命令
+var restartCmd = newControlCmd(dryrun.ActionRestart, "重启", `重启服务，服务未运行时直接启动，完成后显示服务状态。通常需要root或管理员权限。
+
+示例:
+  sudo %[1]s service restart nginx
+  %[1]s service restart nginx --dry-run`)
+
+// newControlCmd 创建启动、停止或重启服务的命令
+func newControlCmd(action, verb, long string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   action + " <服务名>",
+		Short: verb + "服务",
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := service.NewManager()
+			if err != nil {
+				return err
+			}
+			name := args[0]
+
+			// 先查询一次，确认服务存在
+			before, err := manager.Status(name)
+			if err != nil {
+				return err
+			}
+
+			if dryrun.Enabled(cmd) {
+				plan := &dryrun.Plan{}
+				plan.Addf(action, before.Name, "当前状态: %s，通过%s执行", before.State, manager.Name())
+				return dryrun.Render(cmd, plan)
+			}
+
+			switch action {
+			case dryrun.ActionStart:
+				err = manager.Start(name)
+			case dryrun.ActionStop:
+				err = manager.Stop(name)
+			default:
+				err = manager.Restart(name)
+			}
+			if err != nil {
+				return err
+			}
+
+			after, err := manager.Status(name)
+			if err != nil {
+				return err
+			}
+			output.Infof(cmd, "已%s服务 %s\n", i18n.T(verb), after.Name)
+			return output.Render(cmd, after, func() { printStatuses([]service.Status{after}) })
+		},
+	}
+	dryrun.AddFlag(cmd)
+	return cmd
+}
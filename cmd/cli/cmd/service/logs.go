@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/pkg/service"
+
+	"github.com/spf13/cobra"
+)
+
+// logsCmd 表示 service logs 命令
+var logsCmd = &cobra.Command{
+	Use:   "logs <服务名>",
+	Short: "查看服务日志",
+	Long: `查看服务最近的日志，Linux上读取systemd日志（journalctl），按 Ctrl+C 结束 --follow。
+读取其他用户的服务日志通常需要root权限或属于 systemd-journal 组。
+Windows服务没有统一的日志，请在事件查看器中查看。
+
+示例:
+  %[1]s service logs nginx
+  %[1]s service logs nginx -n 200 --since 1h
+  %[1]s service logs nginx -f`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lines, _ := cmd.Flags().GetInt("lines")
+		follow, _ := cmd.Flags().GetBool("follow")
+		since := flagtype.GetDuration(cmd.Flags(), "since")
+
+		manager, err := service.NewManager()
+		if err != nil {
+			return err
+		}
+		if _, err := manager.Status(args[0]); err != nil {
+			return err
+		}
+
+		options := service.LogOptions{Lines: lines, Follow: follow}
+		if since > 0 {
+			options.Since = time.Now().Add(-since)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return manager.Logs(ctx, args[0], options, os.Stdout)
+	},
+}
+
+func init() {
+	logsCmd.Flags().IntP("lines", "n", 50, "显示最后几行，0 表示全部")
+	logsCmd.Flags().BoolP("follow", "f", false, "持续输出新的日志")
+	flagtype.Duration(logsCmd.Flags(), "since", 0, time.Minute, "只显示最近一段时间的日志，如 30m、2h，纯数字表示分钟")
+}
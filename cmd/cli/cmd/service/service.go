@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ServiceCmd 表示服务管理命令组
+var ServiceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "查看和控制系统服务",
+	Long: `查看和控制系统服务，Linux上通过systemd（systemctl、journalctl），
+Windows上通过服务控制管理器，不同平台使用相同的命令。
+
+包含以下子命令:
+  status - 显示服务状态
+  start - 启动服务
+  stop - 停止服务
+  restart - 重启服务
+  logs - 查看服务日志
+
+示例:
+  %[1]s service status nginx sshd
+  sudo %[1]s service restart nginx
+  %[1]s service logs nginx -n 100 -f`,
+}
+
+func init() {
+	// 添加子命令
+	ServiceCmd.AddCommand(statusCmd)
+	ServiceCmd.AddCommand(startCmd)
+	ServiceCmd.AddCommand(stopCmd)
+	ServiceCmd.AddCommand(restartCmd)
+	ServiceCmd.AddCommand(logsCmd)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"os"
+	"strconv"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/service"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// statusCmd 表示 service status 命令
+var statusCmd = &cobra.Command{
+	Use:   "status <服务名...>",
+	Short: "显示服务状态",
+	Long: `显示服务的运行状态、启动方式、主进程PID、进入当前状态的时间和内存占用。
+任一服务未在运行时以退出码1退出，服务不存在时以退出码3退出。
+
+示例:
+  %[1]s service status nginx
+  %[1]s service status nginx sshd docker --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := service.NewManager()
+		if err != nil {
+			return err
+		}
+
+		statuses := make([]service.Status, 0, len(args))
+		for _, name := range args {
+			status, err := manager.Status(name)
+			if err != nil {
+				return err
+			}
+			statuses = append(statuses, status)
+		}
+
+		if err := output.Render(cmd, statuses, func() { printStatuses(statuses) }); err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if !status.Running() {
+				return errs.Exit(1)
+			}
+		}
+		return nil
+	},
+}
+
+// printStatuses 以表格形式输出服务状态
+func printStatuses(statuses []service.Status) {
+	table := output.NewTable(os.Stdout, []string{i18n.T("服务"), i18n.T("状态"), i18n.T("启动方式"), "PID", i18n.T("持续时间"), i18n.T("内存"), i18n.T("说明")})
+	for _, s := range statuses {
+		state := s.State
+		if s.SubState != "" && s.SubState != s.State {
+			state += " (" + s.SubState + ")"
+		}
+		switch {
+		case s.Running():
+			state = color.GreenString(state)
+		case s.State == "failed":
+			state = color.RedString(state)
+		}
+
+		pid, since, memory := "-", "-", "-"
+		if s.PID > 0 {
+			pid = strconv.Itoa(s.PID)
+		}
+		if s.Since != nil {
+			since = formatDuration(time.Since(*s.Since))
+		}
+		if s.Memory > 0 {
+			memory = fsutils.FormatSize(int64(s.Memory))
+		}
+		table.Append([]string{s.Name, state, s.Enabled, pid, since, memory, s.Description})
+	}
+	table.Render()
+
+	for _, s := range statuses {
+		if s.Restarts > 0 {
+			color.New(color.FgYellow).Printf(i18n.T("%s 已自动重启 %d 次\n"), s.Name, s.Restarts)
+		}
+	}
+}
+
+// formatDuration 格式化时间间隔
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	days := int(d.Hours() / 24)
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	if days > 0 {
+		return i18n.Tf("%d天%d小时", days, hours)
+	} else if hours > 0 {
+		return i18n.Tf("%d小时%d分钟", hours, minutes)
+	} else if minutes > 0 {
+		return i18n.Tf("%d分钟%d秒", minutes, seconds)
+	}
+	return i18n.Tf("%d秒", seconds)
+}
@@ -0,0 +1,155 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/metrics"
+	"toolbox/pkg/units"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// StatsResult stats 命令的输出
+type StatsResult struct {
+	Enabled  bool                   `json:"enabled"`  // 当前是否开启指标记录
+	Runs     int                    `json:"runs"`     // 参与统计的执行次数
+	Commands []metrics.CommandStats `json:"commands"` // 按命令汇总，平均耗时长的在前
+	Slowest  []metrics.Entry        `json:"slowest"`  // 耗时最长的几次执行
+}
+
+// StatsCmd 表示 stats 命令
+var StatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "查看命令的耗时和资源占用统计",
+	Long: `按命令汇总执行耗时、CPU时间和内存峰值，并列出耗时最长的几次执行，
+用于找出哪些命令或参数组合需要优化。
+
+指标记录默认关闭，设置环境变量 TOOLBOX_METRICS=1 或在配置文件中加入 metrics: true
+后，每次执行命令都会把耗时和资源占用追加到用户配置目录下的 toolbox/metrics.jsonl，
+最多保留 5000 条，数据只保存在本地。可以用 TOOLBOX_METRICS_FILE 指定文件路径。
+资源占用包括命令启动的子进程，因此 run、watch、map 的统计中含有其执行的子命令。
+
+示例:
+  %[1]s stats                        # 所有记录的汇总和最慢的10次执行
+  %[1]s stats --since 7d             # 只统计最近7天
+  %[1]s stats --command "fs find"    # 只统计 fs find 及其子命令
+  %[1]s stats -n 20 --output json    # 最慢的20次执行，JSON格式
+  %[1]s stats --clear                # 清空指标记录`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		since := flagtype.GetDuration(cmd.Flags(), "since")
+		command, _ := cmd.Flags().GetString("command")
+		clear, _ := cmd.Flags().GetBool("clear")
+
+		if clear {
+			if err := metrics.Clear(); err != nil {
+				return err
+			}
+			output.Infof(cmd, "指标记录已清空\n")
+			return nil
+		}
+
+		entries, err := metrics.Load()
+		if err != nil {
+			return err
+		}
+
+		// 按时间和命令筛选
+		var cutoff time.Time
+		if since > 0 {
+			cutoff = time.Now().Add(-since)
+		}
+		var matched []metrics.Entry
+		for _, entry := range entries {
+			if entry.Time.Before(cutoff) {
+				continue
+			}
+			if command != "" && entry.Command != command && !strings.HasPrefix(entry.Command, command+" ") {
+				continue
+			}
+			matched = append(matched, entry)
+		}
+
+		result := StatsResult{
+			Enabled:  metrics.Enabled(),
+			Runs:     len(matched),
+			Commands: metrics.Summarize(matched),
+			Slowest:  metrics.Slowest(matched, limit),
+		}
+		return output.Render(cmd, result, func() {
+			printResult(result)
+		})
+	},
+}
+
+// printResult 以表格形式输出统计结果
+func printResult(result StatsResult) {
+	if result.Runs == 0 {
+		fmt.Println(i18n.T("没有指标记录"))
+		if !result.Enabled {
+			fmt.Println(i18n.T("指标记录未开启，设置 TOOLBOX_METRICS=1 或在配置文件中加入 metrics: true 后开始记录"))
+		}
+		return
+	}
+
+	color.New(color.Bold).Println(i18n.T("按命令汇总"))
+	table := output.NewTable(os.Stdout, []string{i18n.T("命令"), i18n.T("次数"), i18n.T("失败"), i18n.T("平均耗时"), "P95", i18n.T("最长"), i18n.T("平均CPU"), i18n.T("内存峰值")})
+	for _, s := range result.Commands {
+		failures := strconv.Itoa(s.Failures)
+		if s.Failures > 0 {
+			failures = color.RedString(failures)
+		}
+		table.Append([]string{
+			s.Command,
+			strconv.Itoa(s.Runs),
+			failures,
+			units.FormatDuration(time.Duration(s.AvgMs) * time.Millisecond),
+			units.FormatDuration(time.Duration(s.P95Ms) * time.Millisecond),
+			units.FormatDuration(time.Duration(s.MaxMs) * time.Millisecond),
+			units.FormatDuration(time.Duration(s.AvgCPUMs) * time.Millisecond),
+			fsutils.FormatSize(int64(s.MaxRSS)),
+		})
+	}
+	table.Render()
+
+	fmt.Println()
+	color.New(color.Bold).Println(i18n.T("最慢的执行"))
+	table = output.NewTable(os.Stdout, []string{i18n.T("时间"), i18n.T("耗时"), "CPU", i18n.T("内存峰值"), i18n.T("退出码"), i18n.T("命令")})
+	for _, entry := range result.Slowest {
+		exitCode := strconv.Itoa(entry.ExitCode)
+		if entry.ExitCode == 0 {
+			exitCode = color.GreenString(exitCode)
+		} else {
+			exitCode = color.RedString(exitCode)
+		}
+		table.Append([]string{
+			entry.Time.Format("2006-01-02 15:04:05"),
+			units.FormatDuration(time.Duration(entry.DurationMs) * time.Millisecond),
+			units.FormatDuration(time.Duration(entry.CPUMs()) * time.Millisecond),
+			fsutils.FormatSize(int64(entry.MaxRSS)),
+			exitCode,
+			strings.Join(entry.Args, " "),
+		})
+	}
+	table.Render()
+
+	if !result.Enabled {
+		fmt.Println(i18n.T("\n指标记录当前未开启，以上为之前记录的数据"))
+	}
+}
+
+func init() {
+	StatsCmd.Flags().IntP("limit", "n", 10, "列出耗时最长的执行次数，0表示全部")
+	flagtype.Duration(StatsCmd.Flags(), "since", 0, units.Day, "只统计最近一段时间，如 24h、7d，纯数字表示天")
+	StatsCmd.Flags().String("command", "", "只统计指定命令及其子命令，如 \"fs find\"")
+	StatsCmd.Flags().Bool("clear", false, "清空指标记录")
+}
@@ -0,0 +1,156 @@
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/sysinfo"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// SysinfoCmd 表示 sysinfo 命令
+var SysinfoCmd = &cobra.Command{
+	Use:   "sysinfo",
+	Short: "显示操作系统、CPU、内存、磁盘和网络接口信息",
+	Long: `汇总显示本机的系统信息:
+  主机 - 主机名、发行版、内核版本、虚拟化类型、启动时间和运行时长
+  CPU - 型号、物理和逻辑核数、主频、使用率和系统负载
+  内存 - 内存和交换空间的使用情况
+  磁盘 - 已挂载文件系统的容量和使用率
+  网络接口 - 接口状态、MAC地址、MTU和IP地址
+
+CPU使用率需要采样一段时间，默认500ms，--cpu-sample 0 可跳过采样。
+某一部分在当前平台上无法获取时会给出提示，不影响其他部分。配合 --host 可以查看远程主机的信息。
+
+示例:
+  %[1]s sysinfo
+  %[1]s sysinfo --output json
+  %[1]s sysinfo --all-disks --cpu-sample 2s
+  %[1]s sysinfo --host web1,web2 --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		allDisks, _ := cmd.Flags().GetBool("all-disks")
+		info := sysinfo.Collect(sysinfo.Options{
+			CPUSample: flagtype.GetDuration(cmd.Flags(), "cpu-sample"),
+			AllDisks:  allDisks,
+		})
+
+		return output.Render(cmd, info, func() { printInfo(info) })
+	},
+}
+
+// printInfo 分部分输出系统信息
+func printInfo(info sysinfo.Info) {
+	heading := color.New(color.Bold, color.FgCyan)
+	field := func(label, format string, args ...interface{}) {
+		fmt.Printf("  %s: %s\n", label, fmt.Sprintf(format, args...))
+	}
+
+	h := info.Host
+	heading.Println(i18n.T("主机"))
+	field(i18n.T("主机名"), "%s", h.Hostname)
+	system := strings.TrimSpace(h.Platform + " " + h.PlatformVersion)
+	if system == "" {
+		system = h.OS
+	} else {
+		system += " (" + h.OS + ")"
+	}
+	field(i18n.T("系统"), "%s", system)
+	field(i18n.T("内核"), "%s %s", h.KernelVersion, h.Arch)
+	if h.Virtualization != "" {
+		field(i18n.T("虚拟化"), "%s", h.Virtualization)
+	}
+	if !h.BootTime.IsZero() {
+		field(i18n.T("启动时间"), i18n.T("%s（已运行 %s）"), h.BootTime.Format("2006-01-02 15:04:05"),
+			formatDuration(time.Duration(h.Uptime)*time.Second))
+	}
+	if h.Procs > 0 {
+		field(i18n.T("进程数"), "%d", h.Procs)
+	}
+
+	c := info.CPU
+	heading.Println("CPU")
+	if c.Model != "" {
+		field(i18n.T("型号"), "%s", c.Model)
+	}
+	field(i18n.T("核数"), i18n.T("%d 物理 / %d 逻辑"), c.PhysicalCores, c.LogicalCores)
+	if c.Mhz > 0 {
+		field(i18n.T("主频"), "%.0f MHz", c.Mhz)
+	}
+	if c.Usage >= 0 {
+		field(i18n.T("使用率"), "%.1f%%", c.Usage)
+	}
+	if l := info.Load; l != nil {
+		field(i18n.T("负载"), i18n.T("%.2f %.2f %.2f（1、5、15分钟）"), l.Load1, l.Load5, l.Load15)
+	}
+
+	heading.Println(i18n.T("内存"))
+	m := info.Memory
+	field(i18n.T("内存"), i18n.T("%s / %s (%.1f%%)，可用 %s"), fsutils.FormatSize(int64(m.Used)), fsutils.FormatSize(int64(m.Total)), m.UsedPercent, fsutils.FormatSize(int64(m.Available)))
+	if s := info.Swap; s.Total > 0 {
+		field(i18n.T("交换空间"), "%s / %s (%.1f%%)", fsutils.FormatSize(int64(s.Used)), fsutils.FormatSize(int64(s.Total)), s.UsedPercent)
+	} else {
+		field(i18n.T("交换空间"), i18n.T("未启用"))
+	}
+
+	if len(info.Disks) > 0 {
+		heading.Println(i18n.T("磁盘"))
+		table := output.NewTable(os.Stdout, []string{i18n.T("挂载点"), i18n.T("设备"), i18n.T("类型"), i18n.T("容量"), i18n.T("已用"), i18n.T("可用"), i18n.T("使用率")})
+		for _, d := range info.Disks {
+			table.Append([]string{
+				d.Mountpoint,
+				d.Device,
+				d.Fstype,
+				fsutils.FormatSize(int64(d.Total)),
+				fsutils.FormatSize(int64(d.Used)),
+				fsutils.FormatSize(int64(d.Free)),
+				fmt.Sprintf("%.1f%%", d.UsedPercent),
+			})
+		}
+		table.Render()
+	}
+
+	if len(info.Interfaces) > 0 {
+		heading.Println(i18n.T("网络接口"))
+		table := output.NewTable(os.Stdout, []string{i18n.T("接口"), i18n.T("状态"), "MAC", "MTU", i18n.T("地址")})
+		for _, iface := range info.Interfaces {
+			state := "down"
+			if iface.Up {
+				state = "up"
+			}
+			table.Append([]string{iface.Name, state, iface.MAC, strconv.Itoa(iface.MTU), strings.Join(iface.Addrs, " ")})
+		}
+		table.Render()
+	}
+
+	for _, warning := range info.Warnings {
+		color.New(color.FgYellow).Fprintln(os.Stderr, warning)
+	}
+}
+
+// formatDuration 格式化运行时长
+func formatDuration(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	if days > 0 {
+		return i18n.Tf("%d天%d小时", days, hours)
+	} else if hours > 0 {
+		return i18n.Tf("%d小时%d分钟", hours, minutes)
+	}
+	return i18n.Tf("%d分钟", minutes)
+}
+
+func init() {
+	SysinfoCmd.Flags().Bool("all-disks", false, "包括 tmpfs、overlay 等虚拟文件系统")
+	flagtype.Duration(SysinfoCmd.Flags(), "cpu-sample", 500*time.Millisecond, time.Second, "采样CPU使用率的时长，如 1s、500ms，0 表示不采样")
+}
@@ -3,7 +3,10 @@ package text
 import (
 	"fmt"
 	"os"
+	"toolbox/pkg/i18n"
 
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
 	"toolbox/pkg/textproc"
 
 	"github.com/spf13/cobra"
@@ -23,11 +26,12 @@ var textFilterCmd = &cobra.Command{
   %[1]s text filter 'length($0) > 80' file.txt        # 过滤长度大于80的行
   cat file.txt | %[1]s text filter '$3 ~ /pattern/'   # 过滤第三列匹配正则表达式的行
   %[1]s text filter -p '${1} ${3}' data.txt           # 只打印第1和第3列`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
-			fmt.Println("错误: 必须指定过滤表达式")
-			cmd.Help()
-			os.Exit(1)
+			return errs.InvalidInput("必须指定过滤表达式")
+		}
+		if err := output.RequireTable(cmd); err != nil {
+			return err
 		}
 
 		// 获取选项
@@ -52,25 +56,25 @@ var textFilterCmd = &cobra.Command{
 			if (stat.Mode() & os.ModeCharDevice) == 0 {
 				sources = []string{"-"} // 表示从标准输入读取
 			} else {
-				fmt.Println("错误: 未指定输入文件，且无标准输入")
-				cmd.Help()
-				os.Exit(1)
+				return errs.InvalidInput("未指定输入文件，且无标准输入")
 			}
 		}
 
-		// 处理每个输入源
+		// 处理每个输入源，单个文件失败时继续处理其他文件，最后以失败的退出码退出
+		var failed error
 		for _, source := range sources {
 			var file *os.File
 			var sourceName string
 
 			if source == "-" {
 				file = os.Stdin
-				sourceName = "标准输入"
+				sourceName = i18n.T("标准输入")
 			} else {
 				var err error
 				file, err = os.Open(source)
 				if err != nil {
-					fmt.Printf("错误: 无法打开文件 %s: %v\n", source, err)
+					failed = errs.Wrap(err, "无法打开文件 %s: %v", source, err)
+					fmt.Print(i18n.Tf("错误: %v\n", failed))
 					continue
 				}
 				defer file.Close()
@@ -84,7 +88,8 @@ var textFilterCmd = &cobra.Command{
 
 			_, err := textproc.ExecuteFilter(file, os.Stdout, options)
 			if err != nil {
-				fmt.Printf("错误: %v\n", err)
+				failed = err
+				fmt.Print(i18n.Tf("错误: %v\n", err))
 				continue
 			}
 
@@ -92,6 +97,11 @@ var textFilterCmd = &cobra.Command{
 				fmt.Println() // 文件之间添加空行
 			}
 		}
+
+		if failed != nil {
+			return errs.Exit(errs.ExitCode(failed))
+		}
+		return nil
 	},
 }
 
@@ -3,7 +3,10 @@ package text
 import (
 	"fmt"
 	"os"
+	"toolbox/pkg/i18n"
 
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
 	"toolbox/pkg/textproc"
 
 	"github.com/spf13/cobra"
@@ -25,11 +28,12 @@ var textGrepCmd = &cobra.Command{
   %[1]s text grep -i "pattern" file.txt     # 忽略大小写搜索
   %[1]s text grep -r "pattern" ./src        # 递归搜索目录
   %[1]s text grep -r -f "*.go" "func" ./src # 递归搜索目录中的go文件`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
-			fmt.Println("错误: 必须指定搜索模式")
-			cmd.Help()
-			os.Exit(1)
+			return errs.InvalidInput("必须指定搜索模式")
+		}
+		if err := output.RequireTable(cmd); err != nil {
+			return err
 		}
 
 		// 获取选项
@@ -68,13 +72,12 @@ var textGrepCmd = &cobra.Command{
 			if (stat.Mode() & os.ModeCharDevice) == 0 {
 				sources = []string{"-"} // 表示从标准输入读取
 			} else {
-				fmt.Println("错误: 未指定输入文件，且无标准输入")
-				cmd.Help()
-				os.Exit(1)
+				return errs.InvalidInput("未指定输入文件，且无标准输入")
 			}
 		}
 
-		// 处理每个输入源
+		// 处理每个输入源，单个文件失败时继续处理其他文件，最后以失败的退出码退出
+		var failed error
 		totalMatches := 0
 		for _, source := range sources {
 			// 递归处理目录
@@ -82,7 +85,8 @@ var textGrepCmd = &cobra.Command{
 				// 检查是否是目录
 				fileInfo, err := os.Stat(source)
 				if err != nil {
-					fmt.Printf("错误: 无法访问 %s: %v\n", source, err)
+					failed = errs.Wrap(err, "无法访问 %s: %v", source, err)
+					fmt.Print(i18n.Tf("错误: %v\n", failed))
 					continue
 				}
 
@@ -90,7 +94,8 @@ var textGrepCmd = &cobra.Command{
 					// 是目录，递归搜索
 					result, err := textproc.GrepDirectory(source, os.Stdout, options)
 					if err != nil {
-						fmt.Printf("错误: %v\n", err)
+						failed = err
+						fmt.Print(i18n.Tf("错误: %v\n", err))
 						continue
 					}
 
@@ -110,12 +115,13 @@ var textGrepCmd = &cobra.Command{
 
 			if source == "-" {
 				file = os.Stdin
-				sourceName = "标准输入"
+				sourceName = i18n.T("标准输入")
 			} else {
 				var err error
 				file, err = os.Open(source)
 				if err != nil {
-					fmt.Printf("错误: 无法打开文件 %s: %v\n", source, err)
+					failed = errs.Wrap(err, "无法打开文件 %s: %v", source, err)
+					fmt.Print(i18n.Tf("错误: %v\n", failed))
 					continue
 				}
 				defer file.Close()
@@ -125,7 +131,8 @@ var textGrepCmd = &cobra.Command{
 			// 执行搜索
 			result, err := textproc.ExecuteGrep(file, os.Stdout, options, sourceName)
 			if err != nil {
-				fmt.Printf("错误: %v\n", err)
+				failed = err
+				fmt.Print(i18n.Tf("错误: %v\n", err))
 				continue
 			}
 
@@ -140,6 +147,11 @@ var textGrepCmd = &cobra.Command{
 		if onlyCount && !recursive {
 			fmt.Println(totalMatches)
 		}
+
+		if failed != nil {
+			return errs.Exit(errs.ExitCode(failed))
+		}
+		return nil
 	},
 }
 
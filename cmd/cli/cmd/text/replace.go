@@ -2,8 +2,13 @@ package text
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"toolbox/pkg/i18n"
 
+	"toolbox/cmd/cli/cmd/dryrun"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
 	"toolbox/pkg/textproc"
 
 	"github.com/spf13/cobra"
@@ -22,12 +27,11 @@ var textReplaceCmd = &cobra.Command{
   %[1]s text replace "User-(\\d+)" "ID-$1" users.txt     # 使用正则表达式和引用
   cat file.txt | %[1]s text replace "pattern" "new" -    # 从标准输入替换并输出到标准输出
   %[1]s text replace -i "error" "warning" log.txt        # 忽略大小写替换
-  %[1]s text replace -g "pattern" "new" file.txt         # 全局替换（每行多次）`,
-	Run: func(cmd *cobra.Command, args []string) {
+  %[1]s text replace -g "pattern" "new" file.txt         # 全局替换（每行多次）
+  %[1]s text replace -I "old" "new" *.txt --dry-run      # 预览原地替换会修改哪些文件`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 2 {
-			fmt.Println("错误: 必须指定搜索模式和替换文本")
-			cmd.Help()
-			os.Exit(1)
+			return errs.InvalidInput("必须指定搜索模式和替换文本")
 		}
 
 		// 获取选项
@@ -55,24 +59,31 @@ var textReplaceCmd = &cobra.Command{
 			stat, _ := os.Stdin.Stat()
 			if (stat.Mode() & os.ModeCharDevice) == 0 {
 				if inPlace {
-					fmt.Println("错误: 无法对标准输入执行原地替换")
-					os.Exit(1)
+					return errs.InvalidInput("无法对标准输入执行原地替换")
 				}
 				sources = []string{"-"} // 表示从标准输入读取
 			} else {
-				fmt.Println("错误: 未指定输入文件，且无标准输入")
-				cmd.Help()
-				os.Exit(1)
+				return errs.InvalidInput("未指定输入文件，且无标准输入")
 			}
 		}
 
-		// 处理每个输入源
+		// 预演原地替换，只统计每个文件的替换次数
+		if inPlace && dryrun.Enabled(cmd) {
+			return planReplace(cmd, sources, options, backup)
+		}
+		if err := output.RequireTable(cmd); err != nil {
+			return err
+		}
+
+		// 处理每个输入源，单个文件失败时继续处理其他文件，最后以失败的退出码退出
+		var failed error
 		for _, source := range sources {
 			if source == "-" {
 				// 标准输入输出模式
 				_, err := textproc.ExecuteReplace(os.Stdin, os.Stdout, options)
 				if err != nil {
-					fmt.Printf("错误: %v\n", err)
+					failed = err
+					fmt.Print(i18n.Tf("错误: %v\n", err))
 				}
 			} else {
 				// 文件模式
@@ -81,14 +92,16 @@ var textReplaceCmd = &cobra.Command{
 					tempFile := source + ".tmp"
 					origFile, err := os.Open(source)
 					if err != nil {
-						fmt.Printf("错误: 无法打开文件 %s: %v\n", source, err)
+						failed = errs.Wrap(err, "无法打开文件 %s: %v", source, err)
+						fmt.Print(i18n.Tf("错误: %v\n", failed))
 						continue
 					}
 
 					// 创建备份（如果需要）
 					if backup != "" {
 						if err := textproc.CreateBackup(source, backup); err != nil {
-							fmt.Printf("错误: 无法创建备份 %s: %v\n", source+backup, err)
+							failed = errs.Wrap(err, "无法创建备份 %s: %v", source+backup, err)
+							fmt.Print(i18n.Tf("错误: %v\n", failed))
 							origFile.Close()
 							continue
 						}
@@ -97,7 +110,8 @@ var textReplaceCmd = &cobra.Command{
 					// 创建临时文件用于写入
 					tmpFile, err := os.Create(tempFile)
 					if err != nil {
-						fmt.Printf("错误: 无法创建临时文件: %v\n", err)
+						failed = errs.Wrap(err, "无法创建临时文件: %v", err)
+						fmt.Print(i18n.Tf("错误: %v\n", failed))
 						origFile.Close()
 						continue
 					}
@@ -105,7 +119,8 @@ var textReplaceCmd = &cobra.Command{
 					// 执行替换
 					result, err := textproc.ExecuteReplace(origFile, tmpFile, options)
 					if err != nil {
-						fmt.Printf("错误: %v\n", err)
+						failed = err
+						fmt.Print(i18n.Tf("错误: %v\n", err))
 						origFile.Close()
 						tmpFile.Close()
 						os.Remove(tempFile) // 清理临时文件
@@ -118,17 +133,19 @@ var textReplaceCmd = &cobra.Command{
 
 					// 用临时文件替换原文件
 					if err := os.Rename(tempFile, source); err != nil {
-						fmt.Printf("错误: 无法替换原文件: %v\n", err)
+						failed = errs.Wrap(err, "无法替换原文件: %v", err)
+						fmt.Print(i18n.Tf("错误: %v\n", failed))
 						os.Remove(tempFile) // 清理临时文件
 						continue
 					}
 
-					fmt.Printf("已处理 %d 行，替换了 %d 处\n", result.LinesProcessed, result.Replacements)
+					fmt.Print(i18n.Tf("已处理 %d 行，替换了 %d 处\n", result.LinesProcessed, result.Replacements))
 				} else {
 					// 输出到标准输出模式
 					file, err := os.Open(source)
 					if err != nil {
-						fmt.Printf("错误: 无法打开文件 %s: %v\n", source, err)
+						failed = errs.Wrap(err, "无法打开文件 %s: %v", source, err)
+						fmt.Print(i18n.Tf("错误: %v\n", failed))
 						continue
 					}
 					defer file.Close()
@@ -142,9 +159,49 @@ var textReplaceCmd = &cobra.Command{
 				}
 			}
 		}
+
+		if failed != nil {
+			return errs.Exit(errs.ExitCode(failed))
+		}
+		return nil
 	},
 }
 
+// planReplace 统计原地替换将修改的文件，不写入任何内容
+func planReplace(cmd *cobra.Command, sources []string, options textproc.ReplaceOptions, backup string) error {
+	plan := &dryrun.Plan{}
+	var failed error
+	for _, source := range sources {
+		file, err := os.Open(source)
+		if err != nil {
+			failed = errs.Wrap(err, "无法打开文件 %s: %v", source, err)
+			fmt.Fprint(os.Stderr, i18n.Tf("错误: %v\n", failed))
+			continue
+		}
+		result, err := textproc.ExecuteReplace(file, io.Discard, options)
+		file.Close()
+		if err != nil {
+			return err
+		}
+
+		if result.Replacements == 0 {
+			continue
+		}
+		if backup != "" {
+			plan.Addf(dryrun.ActionCreate, source+backup, "备份 %s", source)
+		}
+		plan.Addf(dryrun.ActionModify, source, "共 %d 行，替换 %d 处", result.LinesProcessed, result.Replacements)
+	}
+
+	if err := dryrun.Render(cmd, plan); err != nil {
+		return err
+	}
+	if failed != nil {
+		return errs.Exit(errs.ExitCode(failed))
+	}
+	return nil
+}
+
 func init() {
 	TextCmd.AddCommand(textReplaceCmd)
 
@@ -153,4 +210,5 @@ func init() {
 	textReplaceCmd.Flags().BoolP("global", "g", false, "全局替换（每行多次）")
 	textReplaceCmd.Flags().BoolP("in-place", "I", false, "原地修改文件")
 	textReplaceCmd.Flags().StringP("backup", "b", "", "创建备份，指定备份后缀")
+	dryrun.AddFlag(textReplaceCmd)
 }
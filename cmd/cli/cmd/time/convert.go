@@ -0,0 +1,166 @@
+package time
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/timeconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// TimeResult 单个时间的转换结果
+type TimeResult struct {
+	Input    string               `json:"input"`           // 输入的时间文本
+	Detected string               `json:"detected"`        // 识别出的格式
+	Zone     string               `json:"zone"`            // 输出使用的时区
+	Formats  []timeconv.Formatted `json:"formats"`         // 各种格式的时间
+	Zones    []timeconv.Formatted `json:"zones,omitempty"` // --to 指定的其他时区中的时间
+}
+
+// nowCmd 表示 time now 命令
+var nowCmd = &cobra.Command{
+	Use:   "now",
+	Short: "以多种格式显示当前时间",
+	Long: `以RFC3339、Unix秒/毫秒/微秒/纳秒、RFC1123等格式显示当前时间，
+可以用 --to 同时显示其他时区的时间。
+
+示例:
+  %[1]s time now
+  %[1]s time now --to UTC,America/New_York,+05:30
+  %[1]s time now --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return convert(cmd, []string{"now"})
+	},
+}
+
+// convertCmd 表示 time convert 命令
+var convertCmd = &cobra.Command{
+	Use:     "convert <时间...>",
+	Aliases: []string{"parse"},
+	Short:   "转换时间戳或时间文本",
+	Long: `识别Unix时间戳或日志中常见的时间写法，并以多种格式和时区输出。
+
+时间戳默认按位数判断单位：11位以内为秒，12-14位为毫秒，15-17位为微秒，更长为纳秒，
+带小数时按秒；可以用 --unit 指定。支持的时间写法包括 RFC3339、2006-01-02 15:04:05
+（可带毫秒和时区）、2006-01-02、RFC1123、RFC822、ANSIC、Apache日志的
+02/Jan/2006:15:04:05 -0700 和 syslog 的 Jan 2 15:04:05（使用当前年份）等。
+
+不含时区的时间按 --tz 指定的时区解释（默认本地时区），结果按第一个 --to 时区输出，
+其余时区额外列出。时区可以是 local、UTC、IANA名称（Asia/Shanghai）或固定偏移（+08:00）。
+
+示例:
+  %[1]s time convert 1700000000
+  %[1]s time convert 1700000000123 --to UTC
+  %[1]s time convert "2024-03-01 12:00:00" --tz Asia/Shanghai --to UTC,America/New_York
+  %[1]s time convert "10/Oct/2023:13:55:36 -0700" --to local
+  %[1]s time convert 1700000000 --unit ms`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return convert(cmd, args)
+	},
+}
+
+// convert 解析每个时间并输出转换结果
+func convert(cmd *cobra.Command, inputs []string) error {
+	unit, _ := cmd.Flags().GetString("unit")
+	tzName, _ := cmd.Flags().GetString("tz")
+	toNames, _ := cmd.Flags().GetStringSlice("to")
+
+	loc, err := timeconv.LoadLocation(tzName)
+	if err != nil {
+		return err
+	}
+	targets := make([]*time.Location, 0, len(toNames))
+	for _, name := range toNames {
+		target, err := timeconv.LoadLocation(name)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+
+	results := make([]TimeResult, 0, len(inputs))
+	for _, input := range inputs {
+		t, detected, err := timeconv.Parse(input, unit, loc)
+		if err != nil {
+			return err
+		}
+
+		if len(targets) > 0 {
+			t = t.In(targets[0])
+		}
+		zone := t.Location().String()
+		if zone == "" {
+			// 文本中自带的偏移没有时区名
+			zone = t.Format("-07:00")
+		}
+		result := TimeResult{
+			Input:    input,
+			Detected: detected,
+			Zone:     zone,
+			Formats:  timeconv.Format(t),
+		}
+		if len(targets) > 1 {
+			for i, target := range targets[1:] {
+				result.Zones = append(result.Zones, timeconv.Formatted{
+					Name:  toNames[i+1],
+					Value: t.In(target).Format("2006-01-02 15:04:05.000 -07:00"),
+				})
+			}
+		}
+		results = append(results, result)
+	}
+
+	return output.Render(cmd, results, func() {
+		for i, result := range results {
+			if i > 0 {
+				fmt.Println()
+			}
+			printResult(result)
+		}
+	})
+}
+
+// printResult 以表格形式输出单个时间的转换结果
+func printResult(result TimeResult) {
+	color.New(color.Bold).Printf("%s", result.Input)
+	fmt.Print(i18n.Tf("（识别为 %s，时区 %s）\n", result.Detected, result.Zone))
+
+	table := output.NewTable(os.Stdout, []string{i18n.T("格式"), i18n.T("值")})
+	for _, f := range result.Formats {
+		table.Append([]string{f.Name, f.Value})
+	}
+	for _, z := range result.Zones {
+		table.Append([]string{z.Name, z.Value})
+	}
+	table.Render()
+}
+
+// validateUnit 检查 --unit 的取值
+func validateUnit(cmd *cobra.Command, args []string) error {
+	unit, _ := cmd.Flags().GetString("unit")
+	switch unit {
+	case timeconv.UnitAuto, timeconv.UnitSecond, timeconv.UnitMillisecond, timeconv.UnitMicrosecond, timeconv.UnitNanosecond:
+		return nil
+	}
+	return errs.InvalidInput("不支持的时间戳单位: %s（支持 auto、s、ms、us、ns）", unit)
+}
+
+func init() {
+	for _, c := range []*cobra.Command{nowCmd, convertCmd} {
+		c.Flags().StringSlice("to", nil, "输出时区，多个用逗号分隔，如 UTC,Asia/Shanghai,+05:30")
+	}
+	convertCmd.Flags().String("tz", "local", "解释不含时区的时间时使用的时区")
+	convertCmd.Flags().String("unit", timeconv.UnitAuto, "时间戳单位: auto、s、ms、us、ns")
+	convertCmd.PreRunE = validateUnit
+	convertCmd.RegisterFlagCompletionFunc("unit", cobra.FixedCompletions(
+		[]string{"auto", "s", "ms", "us", "ns"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
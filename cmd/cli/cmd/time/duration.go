@@ -0,0 +1,77 @@
+package time
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
+
+	"github.com/spf13/cobra"
+)
+
+// DurationResult 时长的解析结果
+type DurationResult struct {
+	Input        string  `json:"input"`        // 输入的时长文本
+	Normalized   string  `json:"normalized"`   // 规范化后的紧凑写法，如 1d2h
+	Go           string  `json:"go"`           // Go time.Duration 写法，如 26h0m0s
+	Seconds      float64 `json:"seconds"`      // 秒数
+	Milliseconds int64   `json:"milliseconds"` // 毫秒数
+}
+
+// durationCmd 表示 time duration 命令
+var durationCmd = &cobra.Command{
+	Use:   "duration <时长...>",
+	Short: "解析时长",
+	Long: `解析 1h30m、1.5d、2w3d、300ms 这类易读的时长，输出规范化写法、秒数和毫秒数。
+可用的单位为 ms、s、m、h、d、w，纯数字按 --unit 指定的单位解释（默认为秒）。
+
+示例:
+  %[1]s time duration 1h30m
+  %[1]s time duration 90 5400000 --unit ms
+  %[1]s time duration "2d 4h" --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		unitText, _ := cmd.Flags().GetString("unit")
+		defaultUnit, err := units.ParseDuration("1"+unitText, time.Second)
+		if err != nil {
+			return err
+		}
+
+		results := make([]DurationResult, 0, len(args))
+		for _, arg := range args {
+			// 允许 "2d 4h" 这样带空格的写法
+			d, err := units.ParseDuration(strings.Join(strings.Fields(arg), ""), defaultUnit)
+			if err != nil {
+				return err
+			}
+			results = append(results, DurationResult{
+				Input:        arg,
+				Normalized:   units.FormatDuration(d),
+				Go:           d.String(),
+				Seconds:      d.Seconds(),
+				Milliseconds: d.Milliseconds(),
+			})
+		}
+
+		return output.Render(cmd, results, func() {
+			table := output.NewTable(os.Stdout, []string{i18n.T("输入"), i18n.T("规范写法"), i18n.T("Go写法"), i18n.T("秒"), i18n.T("毫秒")})
+			for _, r := range results {
+				table.Append([]string{
+					r.Input,
+					r.Normalized,
+					r.Go,
+					strconv.FormatFloat(r.Seconds, 'f', -1, 64),
+					strconv.FormatInt(r.Milliseconds, 10),
+				})
+			}
+			table.Render()
+		})
+	},
+}
+
+func init() {
+	durationCmd.Flags().String("unit", "s", "纯数字时长的单位: ms、s、m、h、d、w")
+}
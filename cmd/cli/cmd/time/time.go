@@ -0,0 +1,30 @@
+package time
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// TimeCmd 表示时间转换命令组
+var TimeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "时间戳、时区和时长转换",
+	Long: `在Unix时间戳、RFC3339等常见时间格式和时区之间转换，并解析易读的时长，
+便于阅读日志中的时间。
+
+包含以下子命令:
+  now - 以多种格式显示当前时间
+  convert - 转换时间戳或时间文本
+  duration - 解析时长
+
+示例:
+  %[1]s time now --to UTC
+  %[1]s time convert 1700000000123 --to Asia/Shanghai
+  %[1]s time duration 1h30m`,
+}
+
+func init() {
+	// 添加子命令
+	TimeCmd.AddCommand(nowCmd)
+	TimeCmd.AddCommand(convertCmd)
+	TimeCmd.AddCommand(durationCmd)
+}
@@ -0,0 +1,223 @@
+package totp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/totp"
+
+	"github.com/fatih/color"
+	qrcode "github.com/skip2/go-qrcode"
+	"github.com/spf13/cobra"
+)
+
+// CodeResult 当前和下一个验证码
+type CodeResult struct {
+	Issuer    string    `json:"issuer,omitempty"`
+	Account   string    `json:"account,omitempty"`
+	Code      string    `json:"code"`
+	Next      string    `json:"next"`
+	Remaining int       `json:"remaining"` // 当前验证码剩余的有效秒数
+	ExpiresAt time.Time `json:"expires_at"`
+	Algorithm string    `json:"algorithm"`
+	Digits    int       `json:"digits"`
+	Period    int       `json:"period"`
+}
+
+// TotpCmd 表示 totp 命令
+var TotpCmd = &cobra.Command{
+	Use:   "totp",
+	Short: "生成TOTP两步验证码",
+	Long: `根据密钥生成TOTP（RFC 6238）两步验证码，与 Google Authenticator、1Password 等验证器应用一致，
+适合在没有手机的无界面服务器上登录，或在CI中测试启用了两步验证的账号。
+
+同时输出当前验证码、剩余有效秒数和下一个验证码，剩余时间很短时可以直接使用下一个。
+密钥可以用 --secret 指定base32编码的密钥，或用 --from 指定验证器应用使用的
+otpauth://totp/ URI（通常是二维码的内容，包含发行方、账号、算法等参数）。
+
+--secret 和 --from 的取值可以写作:
+  @文件路径     从文件读取，去掉末尾的换行符
+  env:变量名    从环境变量读取，避免密钥出现在命令历史中
+
+--qr 在终端中显示二维码，--qr-out 保存为PNG图片，可以用手机上的验证器应用扫描导入。
+--algorithm、--digits、--period 会覆盖URI中的参数。
+
+示例:
+  %[1]s totp --secret JBSWY3DPEHPK3PXP
+  %[1]s totp --secret env:GITHUB_TOTP_SECRET
+  %[1]s totp --from 'otpauth://totp/GitHub:alice?secret=JBSWY3DPEHPK3PXP&issuer=GitHub'
+  %[1]s totp --from @github.otpauth --output json
+  %[1]s totp --secret @secret.txt --issuer GitHub --account alice --qr
+  %[1]s totp --secret JBSWY3DPEHPK3PXP --account ci-bot --qr-out ci-bot.png
+  %[1]s watch -n 1 -- totp --secret env:TOTP_SECRET          # 持续刷新`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secret, _ := cmd.Flags().GetString("secret")
+		from, _ := cmd.Flags().GetString("from")
+		showQR, _ := cmd.Flags().GetBool("qr")
+		qrOut, _ := cmd.Flags().GetString("qr-out")
+
+		key, err := loadKey(cmd, secret, from)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		code, err := key.Code(now)
+		if err != nil {
+			return err
+		}
+		next, err := key.CodeAt(key.Counter(now) + 1)
+		if err != nil {
+			return err
+		}
+		remaining := key.Remaining(now)
+		result := CodeResult{
+			Issuer:    key.Issuer,
+			Account:   key.Account,
+			Code:      code,
+			Next:      next,
+			Remaining: int((remaining + time.Second - 1) / time.Second),
+			ExpiresAt: now.Add(remaining).Truncate(time.Second),
+			Algorithm: strings.ToUpper(key.Algorithm),
+			Digits:    key.Digits,
+			Period:    key.Period,
+		}
+
+		var qr *qrcode.QRCode
+		if showQR || qrOut != "" {
+			if key.Account == "" {
+				return errs.InvalidInput("生成二维码需要用 --account 指定账号名称")
+			}
+			if qr, err = qrcode.New(key.URL(), qrcode.Medium); err != nil {
+				return i18n.Errorf("生成二维码失败: %v", err)
+			}
+			if qrOut != "" {
+				if err := qr.WriteFile(256, qrOut); err != nil {
+					return errs.Wrap(err, "保存二维码失败: %v", err)
+				}
+				output.Infof(cmd, "二维码已保存到 %s\n", qrOut)
+			}
+		}
+
+		return output.Render(cmd, result, func() {
+			printResult(result, key.Period)
+			if showQR {
+				fmt.Println()
+				fmt.Print(qr.ToSmallString(false))
+				fmt.Println(key.URL())
+			}
+		})
+	},
+}
+
+// loadKey 从 --secret 或 --from 读取密钥，并应用命令行指定的参数
+func loadKey(cmd *cobra.Command, secret, from string) (totp.Key, error) {
+	if (secret == "") == (from == "") {
+		return totp.Key{}, errs.InvalidInput("必须指定 --secret 或 --from 中的一个")
+	}
+
+	var key totp.Key
+	if secret != "" {
+		value, err := resolveValue(secret)
+		if err != nil {
+			return totp.Key{}, err
+		}
+		if key, err = totp.NewKey(value); err != nil {
+			return totp.Key{}, err
+		}
+	} else {
+		value, err := resolveValue(from)
+		if err != nil {
+			return totp.Key{}, err
+		}
+		if key, err = totp.ParseURL(value); err != nil {
+			return totp.Key{}, err
+		}
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("issuer") {
+		key.Issuer, _ = flags.GetString("issuer")
+	}
+	if flags.Changed("account") {
+		key.Account, _ = flags.GetString("account")
+	}
+	if flags.Changed("algorithm") {
+		key.Algorithm, _ = flags.GetString("algorithm")
+		key.Algorithm = strings.ToUpper(key.Algorithm)
+	}
+	if flags.Changed("digits") {
+		key.Digits, _ = flags.GetInt("digits")
+	}
+	if flags.Changed("period") {
+		key.Period, _ = flags.GetInt("period")
+	}
+	return key, key.Validate()
+}
+
+// resolveValue 处理 @文件路径 和 env:变量名 形式的取值
+func resolveValue(spec string) (string, error) {
+	switch {
+	case strings.HasPrefix(spec, "@"):
+		data, err := os.ReadFile(spec[1:])
+		if err != nil {
+			return "", errs.Wrap(err, "读取文件失败: %v", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case strings.HasPrefix(spec, "env:"):
+		value, ok := os.LookupEnv(spec[4:])
+		if !ok {
+			return "", errs.NotFound("环境变量 %s 不存在", spec[4:])
+		}
+		return value, nil
+	}
+	return spec, nil
+}
+
+// printResult 输出验证码，剩余时间不足5秒时以红色显示
+func printResult(result CodeResult, period int) {
+	if label := keyLabel(result); label != "" {
+		color.New(color.FgCyan, color.Bold).Println(label)
+	}
+	remaining := color.GreenString(i18n.T("%d秒"), result.Remaining)
+	if result.Remaining <= 5 {
+		remaining = color.RedString(i18n.T("%d秒"), result.Remaining)
+	} else if result.Remaining <= period/3 {
+		remaining = color.YellowString(i18n.T("%d秒"), result.Remaining)
+	}
+	fmt.Print(i18n.Tf("当前验证码: %s  剩余 %s\n", color.New(color.Bold).Sprint(result.Code), remaining))
+	fmt.Print(i18n.Tf("下一个验证码: %s\n", result.Next))
+}
+
+// keyLabel 返回 发行方 (账号) 形式的名称
+func keyLabel(result CodeResult) string {
+	switch {
+	case result.Issuer != "" && result.Account != "":
+		return fmt.Sprintf("%s (%s)", result.Issuer, result.Account)
+	case result.Issuer != "":
+		return result.Issuer
+	}
+	return result.Account
+}
+
+func init() {
+	TotpCmd.Flags().StringP("secret", "s", "", "base32编码的密钥，支持 @文件路径 和 env:变量名")
+	TotpCmd.Flags().String("from", "", "otpauth://totp/ URI，支持 @文件路径 和 env:变量名")
+	TotpCmd.Flags().String("issuer", "", "发行方，显示在验证器应用中")
+	TotpCmd.Flags().String("account", "", "账号名称，生成二维码时必需")
+	TotpCmd.Flags().StringP("algorithm", "a", totp.DefaultAlgorithm, "哈希算法（SHA1、SHA256、SHA512）")
+	TotpCmd.Flags().IntP("digits", "d", totp.DefaultDigits, "验证码位数（6-8）")
+	TotpCmd.Flags().IntP("period", "p", totp.DefaultPeriod, "时间步长（秒）")
+	TotpCmd.Flags().Bool("qr", false, "在终端中显示可供验证器应用扫描的二维码")
+	TotpCmd.Flags().String("qr-out", "", "将二维码保存为PNG图片")
+
+	TotpCmd.RegisterFlagCompletionFunc("algorithm", cobra.FixedCompletions(
+		[]string{"SHA1", "SHA256", "SHA512"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"toolbox/pkg/fsutils"
+	"toolbox/pkg/i18n"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// findResultMsg 文件搜索结果
+type findResultMsg struct {
+	id      int
+	results []fsutils.FindResult
+	err     error
+}
+
+// findPanel 文件搜索面板
+type findPanel struct {
+	id        int
+	form      *form
+	searching bool
+	results   []fsutils.FindResult
+	searched  bool
+	offset    int
+	err       error
+}
+
+// newFindPanel 创建文件搜索面板
+func newFindPanel() *findPanel {
+	return &findPanel{
+		id: nextPanelID(),
+		form: newForm(
+			[3]string{i18n.T("目录"), i18n.T("搜索的根目录"), "."},
+			[3]string{i18n.T("文件名"), i18n.T("支持通配符，例如 *.go"), ""},
+			[3]string{i18n.T("最大深度"), i18n.T("留空表示不限制"), ""},
+		),
+	}
+}
+
+// Init 实现 panel
+func (p *findPanel) Init() tea.Cmd {
+	return nil
+}
+
+// Editing 输入框始终处于编辑状态
+func (p *findPanel) Editing() bool {
+	return true
+}
+
+// Update 处理消息
+func (p *findPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case findResultMsg:
+		if msg.id != p.id {
+			return nil
+		}
+		p.searching = false
+		p.searched = true
+		p.results, p.err = msg.results, msg.err
+		p.offset = 0
+		return nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			return p.search()
+		case "pgdown", "ctrl+d":
+			p.offset += 10
+			return nil
+		case "pgup", "ctrl+u":
+			p.offset = max(p.offset-10, 0)
+			return nil
+		}
+	}
+	return p.form.Update(msg)
+}
+
+// search 在后台执行文件搜索
+func (p *findPanel) search() tea.Cmd {
+	if p.searching {
+		return nil
+	}
+	root := p.form.Value(0)
+	if root == "" {
+		root = "."
+	}
+	maxDepth := 0
+	if value := p.form.Value(2); value != "" {
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 0 {
+			p.err = i18n.Errorf("最大深度必须是非负整数")
+			return nil
+		}
+		maxDepth = depth
+	}
+
+	options := fsutils.FindOptions{
+		Name:     p.form.Value(1),
+		Type:     "f",
+		MaxDepth: maxDepth,
+	}
+	p.searching = true
+	p.err = nil
+
+	id := p.id
+	return func() tea.Msg {
+		results, err := fsutils.FindAll(context.Background(), root, options)
+		return findResultMsg{id: id, results: results, err: err}
+	}
+}
+
+// View 渲染面板
+func (p *findPanel) View(width, height int) string {
+	var b strings.Builder
+	b.WriteString(p.form.View())
+	b.WriteString(helpStyle.Render(i18n.T("Tab 切换输入框  Enter 搜索  PgUp/PgDn 翻页")))
+	b.WriteString("\n\n")
+
+	switch {
+	case p.searching:
+		b.WriteString(i18n.T("正在搜索...\n"))
+	case p.err != nil:
+		b.WriteString(errorStyle.Render(p.err.Error()))
+		b.WriteString("\n")
+	case p.searched:
+		// 表单、帮助和摘要约占8行
+		rows := max(height-8, 1)
+		if p.offset > len(p.results)-rows {
+			p.offset = max(len(p.results)-rows, 0)
+		}
+		end := min(p.offset+rows, len(p.results))
+
+		b.WriteString(i18n.Tf("找到 %d 个文件", len(p.results)))
+		if len(p.results) > rows {
+			b.WriteString(i18n.Tf("（显示第 %d-%d 个）", p.offset+1, end))
+		}
+		b.WriteString("\n\n")
+		for _, result := range p.results[p.offset:end] {
+			line := fmt.Sprintf("%10s  %s", fsutils.FormatSize(result.FileInfo.Size()), result.Path)
+			b.WriteString(truncate(line, width))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// form 由若干输入框组成的简单表单，Tab在输入框之间切换
+type form struct {
+	inputs []textinput.Model
+	labels []string
+	focus  int
+}
+
+// newForm 创建表单，fields依次为标签、占位提示和默认值
+func newForm(fields ...[3]string) *form {
+	f := &form{}
+	for _, field := range fields {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = field[1]
+		input.SetValue(field[2])
+		input.CharLimit = 256
+		input.Width = 40
+		f.inputs = append(f.inputs, input)
+		f.labels = append(f.labels, field[0])
+	}
+	f.inputs[0].Focus()
+	return f
+}
+
+// Value 返回第i个输入框的内容
+func (f *form) Value(i int) string {
+	return strings.TrimSpace(f.inputs[i].Value())
+}
+
+// Update 处理按键，Tab和Shift+Tab切换焦点
+func (f *form) Update(msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "tab", "down":
+			f.setFocus((f.focus + 1) % len(f.inputs))
+			return nil
+		case "shift+tab", "up":
+			f.setFocus((f.focus + len(f.inputs) - 1) % len(f.inputs))
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return cmd
+}
+
+// setFocus 将焦点移动到第i个输入框
+func (f *form) setFocus(i int) {
+	f.inputs[f.focus].Blur()
+	f.focus = i
+	f.inputs[f.focus].Focus()
+}
+
+// View 渲染表单
+func (f *form) View() string {
+	var b strings.Builder
+	for i, input := range f.inputs {
+		label := "  " + f.labels[i] + ": "
+		if i == f.focus {
+			label = selectedStyle.Render("> "+f.labels[i]) + ": "
+		}
+		b.WriteString(label)
+		b.WriteString(input.View())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"strings"
+	"toolbox/pkg/i18n"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 界面样式
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	headerStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
+)
+
+// panel 主菜单中的一个工具面板
+type panel interface {
+	// Init 面板打开时执行的初始命令
+	Init() tea.Cmd
+	// Update 处理消息，返回需要执行的命令
+	Update(msg tea.Msg) tea.Cmd
+	// View 渲染面板内容，width和height为可用区域大小
+	View(width, height int) string
+	// Editing 面板是否正在编辑输入框，此时q等字符键作为输入而不是快捷键
+	Editing() bool
+}
+
+// menuItem 主菜单项
+type menuItem struct {
+	title       string
+	description string
+	create      func() panel
+}
+
+// menuItems 主菜单中的所有面板
+var menuItems = []menuItem{
+	{"进程监控", "实时查看进程的CPU和内存占用", func() panel { return newProcessPanel() }},
+	{"端口扫描", "扫描主机开放的端口", func() panel { return newPortScanPanel() }},
+	{"Ping/路由跟踪", "检查连通性并跟踪网络路径", func() panel { return newPingPanel() }},
+	{"文件搜索", "按文件名在目录中搜索文件", func() panel { return newFindPanel() }},
+}
+
+// model 交互式界面的根模型
+type model struct {
+	cursor int   // 主菜单中选中的项
+	active panel // 当前打开的面板，nil表示显示主菜单
+	title  string
+	width  int
+	height int
+}
+
+// newModel 创建根模型
+func newModel() *model {
+	return &model{width: 80, height: 24}
+}
+
+// Init 实现 tea.Model
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+// Update 实现 tea.Model
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if m.active != nil {
+				m.active = nil
+				return m, nil
+			}
+		}
+
+		if m.active == nil {
+			return m, m.updateMenu(msg)
+		}
+		if msg.String() == "q" && !m.active.Editing() {
+			return m, tea.Quit
+		}
+	}
+
+	if m.active != nil {
+		return m, m.active.Update(msg)
+	}
+	return m, nil
+}
+
+// updateMenu 处理主菜单的按键
+func (m *model) updateMenu(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(menuItems)-1 {
+			m.cursor++
+		}
+	case "enter", " ":
+		item := menuItems[m.cursor]
+		m.title = i18n.T(item.title)
+		m.active = item.create()
+		return m.active.Init()
+	}
+	return nil
+}
+
+// View 实现 tea.Model
+func (m *model) View() string {
+	var b strings.Builder
+
+	if m.active == nil {
+		b.WriteString(titleStyle.Render(i18n.T("Toolbox 交互式工具箱")))
+		b.WriteString("\n\n")
+		for i, item := range menuItems {
+			line := "  " + i18n.T(item.title)
+			if i == m.cursor {
+				line = selectedStyle.Render("> " + i18n.T(item.title))
+			}
+			b.WriteString(line)
+			b.WriteString(helpStyle.Render("  " + i18n.T(item.description)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render(i18n.T("↑/↓ 选择  Enter 打开  q 退出")))
+		return b.String()
+	}
+
+	b.WriteString(titleStyle.Render(m.title))
+	b.WriteString("\n\n")
+	// 标题和底部帮助各占两行
+	b.WriteString(m.active.View(m.width, m.height-4))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render(i18n.T("Esc 返回主菜单  Ctrl+C 退出")))
+	return b.String()
+}
+
+// truncate 按显示宽度截断字符串
+func truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+3 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// tailLines 返回最后n行
+func tailLines(lines []string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// panelCounter 面板实例计数，用于丢弃已关闭面板的后台消息
+var panelCounter int
+
+// nextPanelID 返回新的面板实例ID
+func nextPanelID() int {
+	panelCounter++
+	return panelCounter
+}
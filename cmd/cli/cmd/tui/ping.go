@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netdiag"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// streamLineMsg 后台任务输出的一行
+type streamLineMsg struct {
+	id   int
+	line string
+}
+
+// streamDoneMsg 后台任务结束
+type streamDoneMsg struct {
+	id  int
+	err error
+}
+
+// stream 将后台任务的逐行输出转换为消息
+type stream struct {
+	lines chan string
+	done  chan error
+}
+
+// startStream 在后台运行run，run通过emit逐行输出结果
+func startStream(run func(emit func(string)) error) *stream {
+	s := &stream{lines: make(chan string, 64), done: make(chan error, 1)}
+	go func() {
+		err := run(func(line string) { s.lines <- line })
+		close(s.lines)
+		s.done <- err
+	}()
+	return s
+}
+
+// wait 等待下一行输出或任务结束
+func (s *stream) wait(id int) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-s.lines
+		if !ok {
+			return streamDoneMsg{id: id, err: <-s.done}
+		}
+		return streamLineMsg{id: id, line: line}
+	}
+}
+
+// pingPanel Ping和路由跟踪面板
+type pingPanel struct {
+	id      int
+	form    *form
+	stream  *stream
+	running string // 正在执行的操作，为空表示空闲
+	lines   []string
+	err     error
+}
+
+// newPingPanel 创建Ping面板
+func newPingPanel() *pingPanel {
+	return &pingPanel{
+		id:   nextPanelID(),
+		form: newForm([3]string{i18n.T("主机"), i18n.T("例如 example.com 或 8.8.8.8"), ""}),
+	}
+}
+
+// Init 实现 panel
+func (p *pingPanel) Init() tea.Cmd {
+	return nil
+}
+
+// Editing 输入框始终处于编辑状态
+func (p *pingPanel) Editing() bool {
+	return true
+}
+
+// Update 处理消息
+func (p *pingPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case streamLineMsg:
+		if msg.id != p.id {
+			return nil
+		}
+		p.lines = append(p.lines, msg.line)
+		return p.stream.wait(p.id)
+
+	case streamDoneMsg:
+		if msg.id != p.id {
+			return nil
+		}
+		p.running = ""
+		p.err = msg.err
+		return nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			return p.start("ping")
+		case "ctrl+t":
+			return p.start("traceroute")
+		}
+	}
+	return p.form.Update(msg)
+}
+
+// start 开始执行ping或traceroute
+func (p *pingPanel) start(action string) tea.Cmd {
+	if p.running != "" {
+		return nil
+	}
+	host := p.form.Value(0)
+	if host == "" {
+		p.err = i18n.Errorf("请输入主机")
+		return nil
+	}
+
+	p.running = action
+	p.lines = nil
+	p.err = nil
+
+	if action == "ping" {
+		p.stream = startStream(func(emit func(string)) error {
+			_, err := netdiag.Ping(host, netdiag.PingOptions{Count: 4, Interval: time.Second}, emit)
+			return err
+		})
+	} else {
+		p.stream = startStream(func(emit func(string)) error {
+			emit(fmt.Sprintf("%-4s %-40s %s", i18n.T("跳数"), i18n.T("主机"), i18n.T("延迟")))
+			_, err := netdiag.Traceroute(host, netdiag.TracerouteOptions{
+				MaxHops: 30,
+				Timeout: 2 * time.Second,
+				RealTimeCallback: func(hop netdiag.HopInfo) {
+					emit(formatHop(hop))
+				},
+			})
+			return err
+		})
+	}
+	return p.stream.wait(p.id)
+}
+
+// formatHop 格式化路由跟踪的一跳
+func formatHop(hop netdiag.HopInfo) string {
+	host := hop.IP
+	if hop.Name != "" && hop.Name != "*" && hop.Name != hop.IP {
+		host = fmt.Sprintf("%s (%s)", hop.Name, hop.IP)
+	}
+	rtt := "*"
+	if len(hop.RTT) > 0 {
+		rtt = strings.Join(hop.RTT, " ")
+	}
+	return fmt.Sprintf("%-4d %-40s %s", hop.Number, host, rtt)
+}
+
+// View 渲染面板
+func (p *pingPanel) View(width, height int) string {
+	var b strings.Builder
+	b.WriteString(p.form.View())
+	b.WriteString(helpStyle.Render(i18n.T("Enter 执行Ping  Ctrl+T 路由跟踪")))
+	b.WriteString("\n\n")
+
+	if p.running != "" {
+		b.WriteString(i18n.Tf("正在执行 %s...\n", p.running))
+	}
+	if p.err != nil {
+		b.WriteString(errorStyle.Render(p.err.Error()))
+		b.WriteString("\n")
+	}
+	// 表单、帮助和状态约占6行
+	for _, line := range tailLines(p.lines, height-6) {
+		b.WriteString(truncate(line, width))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
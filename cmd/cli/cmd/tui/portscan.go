@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netdiag"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// portScanMsg 端口扫描结果
+type portScanMsg struct {
+	id     int
+	result netdiag.PortScanResult
+	err    error
+}
+
+// portScanPanel 端口扫描面板
+type portScanPanel struct {
+	id       int
+	form     *form
+	scanning bool
+	started  time.Time
+	elapsed  time.Duration
+	result   *netdiag.PortScanResult
+	err      error
+}
+
+// newPortScanPanel 创建端口扫描面板
+func newPortScanPanel() *portScanPanel {
+	return &portScanPanel{
+		id: nextPanelID(),
+		form: newForm(
+			[3]string{i18n.T("主机"), i18n.T("例如 example.com 或 192.168.1.1"), ""},
+			[3]string{i18n.T("端口"), i18n.T("例如 22,80,8000-8100，留空扫描常见端口"), ""},
+		),
+	}
+}
+
+// Init 实现 panel
+func (p *portScanPanel) Init() tea.Cmd {
+	return nil
+}
+
+// Editing 输入框始终处于编辑状态
+func (p *portScanPanel) Editing() bool {
+	return true
+}
+
+// Update 处理消息
+func (p *portScanPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case portScanMsg:
+		if msg.id != p.id {
+			return nil
+		}
+		p.scanning = false
+		p.elapsed = time.Since(p.started)
+		p.result, p.err = &msg.result, msg.err
+		return nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEnter {
+			if p.scanning {
+				return nil
+			}
+			return p.scan()
+		}
+	}
+	return p.form.Update(msg)
+}
+
+// scan 在后台执行端口扫描
+func (p *portScanPanel) scan() tea.Cmd {
+	host := p.form.Value(0)
+	if host == "" {
+		p.err = i18n.Errorf("请输入主机")
+		return nil
+	}
+	ports, err := parsePortRanges(p.form.Value(1))
+	if err != nil {
+		p.err = err
+		return nil
+	}
+
+	p.scanning = true
+	p.started = time.Now()
+	p.result, p.err = nil, nil
+
+	id := p.id
+	return func() tea.Msg {
+		var result netdiag.PortScanResult
+		if len(ports) == 0 {
+			result = netdiag.ScanCommonPorts(host, time.Second, 100)
+		} else {
+			result = netdiag.ScanSpecificPorts(host, ports, time.Second, 100)
+		}
+		if result.Error != "" {
+			return portScanMsg{id: id, result: result, err: fmt.Errorf("%s", result.Error)}
+		}
+		return portScanMsg{id: id, result: result}
+	}
+}
+
+// View 渲染面板
+func (p *portScanPanel) View(width, height int) string {
+	var b strings.Builder
+	b.WriteString(p.form.View())
+	b.WriteString(helpStyle.Render(i18n.T("Tab 切换输入框  Enter 开始扫描")))
+	b.WriteString("\n\n")
+
+	switch {
+	case p.scanning:
+		b.WriteString(i18n.T("正在扫描...\n"))
+	case p.err != nil:
+		b.WriteString(errorStyle.Render(p.err.Error()))
+		b.WriteString("\n")
+	case p.result != nil:
+		b.WriteString(i18n.Tf("发现 %d 个开放端口（耗时 %.1f 秒）\n\n", len(p.result.Ports), p.elapsed.Seconds()))
+		if len(p.result.Ports) > 0 {
+			b.WriteString(headerStyle.Render(fmt.Sprintf("%-8s %s", i18n.T("端口"), i18n.T("服务"))))
+			b.WriteString("\n")
+			// 表单、帮助和结果摘要约占8行
+			for _, port := range p.result.Ports[:min(len(p.result.Ports), max(height-8, 0))] {
+				b.WriteString(fmt.Sprintf("%-8d %s\n", port.Port, port.Service))
+			}
+		}
+	}
+	return b.String()
+}
+
+// parsePortRanges 解析逗号分隔的端口列表，支持 8000-8100 形式的范围
+func parsePortRanges(value string) ([]int, error) {
+	var ports []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		start, end := part, part
+		if i := strings.Index(part, "-"); i > 0 {
+			start, end = part[:i], part[i+1:]
+		}
+		from, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil {
+			return nil, i18n.Errorf("端口 '%s' 格式无效", part)
+		}
+		to, err := strconv.Atoi(strings.TrimSpace(end))
+		if err != nil {
+			return nil, i18n.Errorf("端口 '%s' 格式无效", part)
+		}
+		if from < 1 || to > 65535 || from > to {
+			return nil, i18n.Errorf("端口 '%s' 超出有效范围 (1-65535)", part)
+		}
+		for port := from; port <= to; port++ {
+			ports = append(ports, port)
+		}
+	}
+	return ports, nil
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/process"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// processRefreshInterval 进程列表刷新间隔
+const processRefreshInterval = 2 * time.Second
+
+// processSortKeys 可切换的排序方式
+var processSortKeys = []string{"cpu", "memory", "pid"}
+
+// processListMsg 进程列表采集结果
+type processListMsg struct {
+	id        int
+	processes []process.ProcessInfo
+	err       error
+}
+
+// processTickMsg 定时刷新消息
+type processTickMsg struct {
+	id int
+}
+
+// processPanel 进程监控面板，类似top
+type processPanel struct {
+	id        int
+	processes []process.ProcessInfo
+	err       error
+	sortIndex int
+	filter    string
+	filtering bool
+	updated   time.Time
+}
+
+// newProcessPanel 创建进程监控面板
+func newProcessPanel() *processPanel {
+	return &processPanel{id: nextPanelID()}
+}
+
+// Init 打开面板时立即采集一次进程列表
+func (p *processPanel) Init() tea.Cmd {
+	return p.fetch()
+}
+
+// fetch 在后台采集进程列表
+func (p *processPanel) fetch() tea.Cmd {
+	id := p.id
+	return func() tea.Msg {
+		processes, err := process.GetProcessList()
+		return processListMsg{id: id, processes: processes, err: err}
+	}
+}
+
+// Editing 正在输入过滤条件时返回true
+func (p *processPanel) Editing() bool {
+	return p.filtering
+}
+
+// Update 处理消息
+func (p *processPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case processListMsg:
+		if msg.id != p.id {
+			return nil
+		}
+		p.processes, p.err = msg.processes, msg.err
+		p.updated = time.Now()
+		id := p.id
+		return tea.Tick(processRefreshInterval, func(time.Time) tea.Msg {
+			return processTickMsg{id: id}
+		})
+
+	case processTickMsg:
+		if msg.id != p.id {
+			return nil
+		}
+		return p.fetch()
+
+	case tea.KeyMsg:
+		if p.filtering {
+			switch msg.Type {
+			case tea.KeyEnter:
+				p.filtering = false
+			case tea.KeyBackspace:
+				if runes := []rune(p.filter); len(runes) > 0 {
+					p.filter = string(runes[:len(runes)-1])
+				}
+			case tea.KeyRunes, tea.KeySpace:
+				p.filter += string(msg.Runes)
+			}
+			return nil
+		}
+
+		switch msg.String() {
+		case "s":
+			p.sortIndex = (p.sortIndex + 1) % len(processSortKeys)
+		case "/":
+			p.filtering = true
+			p.filter = ""
+		case "r":
+			return p.fetch()
+		}
+	}
+	return nil
+}
+
+// View 渲染进程列表
+func (p *processPanel) View(width, height int) string {
+	var b strings.Builder
+
+	if p.err != nil {
+		b.WriteString(errorStyle.Render(i18n.Tf("获取进程列表失败: %v", p.err)))
+		b.WriteString("\n")
+	}
+	if p.updated.IsZero() {
+		b.WriteString(i18n.T("正在采集进程信息...\n"))
+		return b.String()
+	}
+
+	processes := p.visibleProcesses()
+
+	status := i18n.Tf("共 %d 个进程  排序: %s  更新于 %s",
+		len(processes), processSortKeys[p.sortIndex], p.updated.Format("15:04:05"))
+	if p.filtering || p.filter != "" {
+		status += i18n.T("  过滤: ") + p.filter
+		if p.filtering {
+			status += "_"
+		}
+	}
+	b.WriteString(status + "\n")
+	b.WriteString(helpStyle.Render(i18n.T("s 切换排序  / 按名称过滤  r 立即刷新")))
+	b.WriteString("\n\n")
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%-8s %-8s %6s %6s  %-12s %s", "PID", "PPID", "CPU%", "MEM%", i18n.T("用户"), i18n.T("名称"))))
+	b.WriteString("\n")
+
+	// 状态、帮助、空行和表头占4行
+	rows := height - 4
+	if rows > len(processes) {
+		rows = len(processes)
+	}
+	for _, proc := range processes[:max(rows, 0)] {
+		line := fmt.Sprintf("%-8d %-8d %6.1f %6.1f  %-12s %s",
+			proc.PID, proc.PPID, proc.CPU, proc.Memory, truncate(proc.Username, 12), proc.Name)
+		b.WriteString(truncate(line, width))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// visibleProcesses 返回过滤和排序后的进程列表
+func (p *processPanel) visibleProcesses() []process.ProcessInfo {
+	processes := make([]process.ProcessInfo, 0, len(p.processes))
+	filter := strings.ToLower(p.filter)
+	for _, proc := range p.processes {
+		if filter != "" && !strings.Contains(strings.ToLower(proc.Name), filter) {
+			continue
+		}
+		processes = append(processes, proc)
+	}
+
+	switch processSortKeys[p.sortIndex] {
+	case "cpu":
+		sort.SliceStable(processes, func(i, j int) bool {
+			return processes[i].CPU > processes[j].CPU
+		})
+	case "memory":
+		sort.SliceStable(processes, func(i, j int) bool {
+			return processes[i].Memory > processes[j].Memory
+		})
+	default:
+		sort.SliceStable(processes, func(i, j int) bool {
+			return processes[i].PID < processes[j].PID
+		})
+	}
+	return processes
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"io"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
+	"toolbox/pkg/termcolor"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+// TuiCmd 表示交互式界面命令
+var TuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "交互式终端界面",
+	Long: `以交互式终端界面使用常用工具，无需记忆命令行参数。
+
+包含以下面板：
+1. 进程监控：实时刷新的进程列表，可按CPU、内存或PID排序
+2. 端口扫描：输入主机和端口列表后扫描开放端口
+3. Ping/路由跟踪：实时显示ping或traceroute的输出
+4. 文件搜索：按文件名通配符在目录中搜索文件
+
+快捷键：
+  ↑/↓ 或 k/j   选择面板
+  Enter        打开面板或执行操作
+  Esc          返回主菜单
+  q / Ctrl+C   退出
+
+示例:
+  %[1]s tui`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 日志输出到终端会破坏界面，未指定日志文件时将其丢弃
+		if !cmd.Flags().Changed("log-file") {
+			logger.SetOutput(io.Discard)
+		}
+
+		// 禁用颜色时只保留粗体、下划线等样式
+		if !termcolor.Enabled() {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+
+		program := tea.NewProgram(newModel(), tea.WithAltScreen())
+		if _, err := program.Run(); err != nil {
+			return i18n.Errorf("运行交互式界面失败: %v", err)
+		}
+		return nil
+	},
+}
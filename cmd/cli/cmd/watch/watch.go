@@ -0,0 +1,165 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"time"
+	"toolbox/cmd/cli/cmd/flagtype"
+	"toolbox/cmd/cli/cmd/output"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/history"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/termcolor"
+	"toolbox/pkg/units"
+	"toolbox/pkg/watch"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// inheritedFlags 传递给被监视命令的全局标志
+var inheritedFlags = []string{"lang", "output", "verbose", "quiet", "log-file"}
+
+// clearScreen 清屏并将光标移到左上角
+const clearScreen = "\033[H\033[2J"
+
+// WatchCmd 表示 watch 命令
+var WatchCmd = &cobra.Command{
+	Use:   "watch [选项] -- <子命令> [参数...]",
+	Short: "周期性执行子命令并高亮变化",
+	Long: `按固定间隔重复执行一个工具箱子命令，每次刷新屏幕显示最新输出，并高亮与上一次相比发生变化的字符。
+
+可以指定停止条件：子命令以指定的退出码退出（--until-exit），或输出匹配正则表达式（--until-match），
+也可以用 --count 限制执行次数，按 Ctrl+C 随时停止。
+输出不是终端或指定 --no-clear 时不清屏，每次的输出依次追加。
+满足停止条件或按 Ctrl+C 中断时退出码为0，达到 --count 次数时以最后一次执行的退出码退出。
+
+示例:
+  %[1]s watch -- process list --sort cpu --top 10
+  %[1]s watch -n 5 -- network portscan example.com -p 443
+  %[1]s watch -n 10 --until-exit 0 -- network ping 10.0.0.1 -c 1    # 等待主机恢复
+  %[1]s watch --until-match 'open' -- network portscan db -p 5432       # 等待端口开放
+  %[1]s watch -n 60 --count 5 --no-clear -- fs find /var/log -m -1`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval := flagtype.GetDuration(cmd.Flags(), "interval")
+		count, _ := cmd.Flags().GetInt("count")
+		untilMatch, _ := cmd.Flags().GetString("until-match")
+		noDiff, _ := cmd.Flags().GetBool("no-diff")
+		noClear, _ := cmd.Flags().GetBool("no-clear")
+
+		if interval < 100*time.Millisecond {
+			return errs.InvalidInput("间隔不能小于100ms")
+		}
+		if args[0] == cmd.Name() {
+			return errs.InvalidInput("不能监视 watch 命令本身")
+		}
+
+		options := watch.Options{
+			Args:     append(globalArgs(cmd), args...),
+			Env:      []string{history.DisableEnv + "=1"},
+			Interval: interval,
+			Count:    count,
+		}
+		if cmd.Flags().Changed("until-exit") {
+			code, _ := cmd.Flags().GetInt("until-exit")
+			options.UntilExit = &code
+		}
+		if untilMatch != "" {
+			re, err := regexp.Compile(untilMatch)
+			if err != nil {
+				return errs.InvalidInput("无效的正则表达式: %v", err)
+			}
+			options.UntilMatch = re
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return i18n.Errorf("获取程序路径失败: %v", err)
+		}
+		options.Executable = executable
+
+		redraw := !noClear && isatty.IsTerminal(os.Stdout.Fd())
+		highlight := func(s string) string { return s }
+		if !noDiff && termcolor.Enabled() {
+			highlight = func(s string) string { return color.New(color.ReverseVideo).Sprint(s) }
+		}
+		title := strings.Join(append([]string{cmd.Root().Name()}, args...), " ")
+
+		options.OnRun = func(run watch.Run) {
+			if redraw {
+				fmt.Print(clearScreen)
+			} else if run.Index > 1 {
+				fmt.Println()
+			}
+
+			status := color.GreenString(i18n.T("退出码 %d"), run.ExitCode)
+			if run.ExitCode != 0 {
+				status = color.RedString(i18n.T("退出码 %d"), run.ExitCode)
+			}
+			color.New(color.FgCyan, color.Bold).Printf(i18n.T("每 %s: %s"), units.FormatDuration(options.Interval), title)
+			fmt.Print(i18n.Tf("    第 %d 次  %s  %s\n\n", run.Index, run.Time.Format("15:04:05"), status))
+
+			text := run.Output
+			if run.Changed {
+				text = watch.Diff(run.Previous, run.Output, highlight)
+			}
+			fmt.Print(text)
+			if text != "" && !strings.HasSuffix(text, "\n") {
+				fmt.Println()
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		summary, err := watch.Watch(ctx, options)
+		if err != nil {
+			return err
+		}
+
+		// 满足停止条件或被中断时正常退出，达到执行次数时以最后一次的退出码退出
+		switch summary.Reason {
+		case watch.ReasonExitCode:
+			output.Infof(cmd, "\n满足停止条件: 退出码为 %d\n", summary.LastExitCode)
+			return nil
+		case watch.ReasonMatch:
+			output.Infof(cmd, "\n满足停止条件: 输出匹配 %s\n", untilMatch)
+			return nil
+		case watch.ReasonCanceled:
+			output.Infof(cmd, "\n已停止，共执行 %d 次\n", summary.Runs)
+			return nil
+		}
+		if summary.LastExitCode != 0 {
+			return errs.Exit(summary.LastExitCode)
+		}
+		return nil
+	},
+}
+
+// globalArgs 返回需要传递给子命令的全局标志
+func globalArgs(cmd *cobra.Command) []string {
+	var args []string
+	flags := cmd.Root().PersistentFlags()
+	for _, name := range inheritedFlags {
+		if flag := flags.Lookup(name); flag != nil && flag.Changed {
+			args = append(args, fmt.Sprintf("--%s=%s", name, flag.Value.String()))
+		}
+	}
+	return args
+}
+
+func init() {
+	WatchCmd.Flags().SetInterspersed(false)
+	flagtype.DurationP(WatchCmd.Flags(), "interval", "n", 2*time.Second, time.Second, "两次执行之间的间隔，如 500ms、1m（纯数字表示秒）")
+	WatchCmd.Flags().Int("count", 0, "最多执行的次数，0表示不限制")
+	WatchCmd.Flags().Int("until-exit", 0, "子命令以指定的退出码退出时停止")
+	WatchCmd.Flags().String("until-match", "", "输出匹配正则表达式时停止")
+	WatchCmd.Flags().Bool("no-diff", false, "不高亮变化的内容")
+	WatchCmd.Flags().Bool("no-clear", false, "不清屏，依次追加每次的输出")
+}
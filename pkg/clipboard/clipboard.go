@@ -0,0 +1,64 @@
+// Package clipboard 读写系统剪贴板
+//
+// Windows和macOS使用系统自带的命令，Linux等系统需要安装 xclip、xsel 或 wl-clipboard。
+// 没有可用的剪贴板工具但标准错误是终端时（例如通过SSH登录），复制改为输出OSC 52
+// 控制序列，由支持该序列的终端写入本地剪贴板。
+package clipboard
+
+import (
+	"encoding/base64"
+	"os"
+	"regexp"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/atotto/clipboard"
+	"github.com/mattn/go-isatty"
+)
+
+// ansiPattern 匹配终端颜色等ANSI控制序列
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)`)
+
+// Copy 将文本写入剪贴板
+func Copy(text string) error {
+	if clipboard.Unsupported {
+		if isatty.IsTerminal(os.Stderr.Fd()) {
+			return copyOSC52(text)
+		}
+		return errs.NotFound("没有可用的剪贴板工具，请安装 xclip、xsel 或 wl-clipboard")
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		return i18n.Errorf("写入剪贴板失败: %v", err)
+	}
+	return nil
+}
+
+// Paste 读取剪贴板中的文本
+func Paste() (string, error) {
+	if clipboard.Unsupported {
+		return "", errs.NotFound("没有可用的剪贴板工具，请安装 xclip、xsel 或 wl-clipboard")
+	}
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		return "", i18n.Errorf("读取剪贴板失败: %v", err)
+	}
+	return text, nil
+}
+
+// StripANSI 去掉文本中的ANSI控制序列，使彩色输出复制后是纯文本
+func StripANSI(text string) string {
+	return ansiPattern.ReplaceAllString(text, "")
+}
+
+// copyOSC52 通过OSC 52控制序列让终端设置剪贴板
+func copyOSC52(text string) error {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	// tmux需要把控制序列转发给外层终端
+	if os.Getenv("TMUX") != "" {
+		sequence = "\x1bPtmux;\x1b" + sequence + "\x1b\\"
+	}
+	if _, err := os.Stderr.WriteString(sequence); err != nil {
+		return i18n.Errorf("写入剪贴板失败: %v", err)
+	}
+	return nil
+}
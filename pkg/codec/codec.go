@@ -0,0 +1,187 @@
+// Package codec 提供常见文本编码的编码、解码和自动识别
+//
+// 支持 base64、base64url、十六进制、URL编码、HTML实体和quoted-printable，
+// 用于查看接口报文、日志和邮件中的编码数据。
+package codec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"html"
+	"io"
+	"mime/quotedprintable"
+	"net/url"
+	"regexp"
+	"strings"
+	"toolbox/pkg/errs"
+)
+
+// Format 编码格式
+type Format string
+
+const (
+	Base64          Format = "base64"    // 标准base64
+	Base64URL       Format = "base64url" // URL安全的base64，不带填充
+	Hex             Format = "hex"       // 十六进制
+	URL             Format = "url"       // URL编码（百分号编码）
+	HTML            Format = "html"      // HTML实体
+	QuotedPrintable Format = "qp"        // quoted-printable
+)
+
+// Formats 返回支持的编码格式，按自动识别时的优先级排列
+func Formats() []Format {
+	return []Format{URL, HTML, QuotedPrintable, Hex, Base64URL, Base64}
+}
+
+// ParseFormat 解析编码格式名称，允许常见的别名
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "base64", "b64":
+		return Base64, nil
+	case "base64url", "b64url":
+		return Base64URL, nil
+	case "hex", "base16":
+		return Hex, nil
+	case "url", "percent":
+		return URL, nil
+	case "html", "entity":
+		return HTML, nil
+	case "qp", "quoted-printable":
+		return QuotedPrintable, nil
+	}
+	return "", errs.InvalidInput("不支持的编码格式: %s（支持 base64、base64url、hex、url、html、qp）", name)
+}
+
+// Encode 将数据编码为指定格式
+func Encode(format Format, data []byte) ([]byte, error) {
+	switch format {
+	case Base64:
+		return []byte(base64.StdEncoding.EncodeToString(data)), nil
+	case Base64URL:
+		return []byte(base64.RawURLEncoding.EncodeToString(data)), nil
+	case Hex:
+		return []byte(hex.EncodeToString(data)), nil
+	case URL:
+		return []byte(url.QueryEscape(string(data))), nil
+	case HTML:
+		return []byte(html.EscapeString(string(data))), nil
+	case QuotedPrintable:
+		var buf bytes.Buffer
+		writer := quotedprintable.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, errs.InvalidInput("不支持的编码格式: %s", format)
+}
+
+// Decode 按指定格式解码数据，忽略首尾空白
+func Decode(format Format, data []byte) ([]byte, error) {
+	text := strings.TrimSpace(string(data))
+	switch format {
+	case Base64, Base64URL:
+		return decodeBase64(text)
+	case Hex:
+		decoded, err := hex.DecodeString(normalizeHex(text))
+		if err != nil {
+			return nil, errs.InvalidInput("十六进制解码失败: %v", err)
+		}
+		return decoded, nil
+	case URL:
+		decoded, err := url.QueryUnescape(text)
+		if err != nil {
+			return nil, errs.InvalidInput("URL解码失败: %v", err)
+		}
+		return []byte(decoded), nil
+	case HTML:
+		return []byte(html.UnescapeString(text)), nil
+	case QuotedPrintable:
+		decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(text)))
+		if err != nil {
+			return nil, errs.InvalidInput("quoted-printable解码失败: %v", err)
+		}
+		return decoded, nil
+	}
+	return nil, errs.InvalidInput("不支持的编码格式: %s", format)
+}
+
+var (
+	percentPattern = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+	entityPattern  = regexp.MustCompile(`&(#[0-9]+|#[xX][0-9A-Fa-f]+|[A-Za-z][A-Za-z0-9]*);`)
+	qpPattern      = regexp.MustCompile(`=[0-9A-F]{2}|=\r?\n`)
+	hexPattern     = regexp.MustCompile(`^[0-9A-Fa-f]+$`)
+	base64Pattern  = regexp.MustCompile(`^[A-Za-z0-9+/]+={0,2}$`)
+	base64URLChars = regexp.MustCompile(`^[A-Za-z0-9_-]+={0,2}$`)
+)
+
+// Detect 根据内容猜测数据的编码格式，无法识别时返回错误
+//
+// 依次检查URL编码、HTML实体、quoted-printable、十六进制和base64；
+// 同时符合十六进制和base64的内容（如 "cafe"）按十六进制处理。
+func Detect(data []byte) (Format, error) {
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return "", errs.InvalidInput("输入为空，无法识别编码格式")
+	}
+
+	switch {
+	case percentPattern.MatchString(text) && !strings.ContainsAny(text, " \t\r\n"):
+		return URL, nil
+	case entityPattern.MatchString(text):
+		return HTML, nil
+	case qpPattern.MatchString(text) && !base64Pattern.MatchString(removeSpace(text)):
+		return QuotedPrintable, nil
+	}
+
+	compact := removeSpace(text)
+	if hexText := normalizeHex(text); hexPattern.MatchString(hexText) && len(hexText)%2 == 0 {
+		return Hex, nil
+	}
+	if strings.ContainsAny(compact, "-_") && base64URLChars.MatchString(compact) {
+		if _, err := decodeBase64(compact); err == nil {
+			return Base64URL, nil
+		}
+	}
+	if base64Pattern.MatchString(compact) {
+		if _, err := decodeBase64(compact); err == nil {
+			return Base64, nil
+		}
+	}
+	return "", errs.InvalidInput("无法识别编码格式，请使用 --type 指定")
+}
+
+// decodeBase64 解码标准或URL安全的base64，允许省略填充和包含换行
+func decodeBase64(text string) ([]byte, error) {
+	text = strings.TrimRight(removeSpace(text), "=")
+	encoding := base64.RawStdEncoding
+	if strings.ContainsAny(text, "-_") {
+		encoding = base64.RawURLEncoding
+	}
+	decoded, err := encoding.DecodeString(text)
+	if err != nil {
+		return nil, errs.InvalidInput("base64解码失败: %v", err)
+	}
+	return decoded, nil
+}
+
+// normalizeHex 去掉十六进制文本中的 0x 前缀、空白和冒号分隔符（如 aa:bb:cc）
+func normalizeHex(text string) string {
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "0x"), "0X")
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\r', '\n', ':':
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// removeSpace 去掉所有空白字符，base64常按固定宽度换行
+func removeSpace(text string) string {
+	return strings.Join(strings.Fields(text), "")
+}
@@ -0,0 +1,58 @@
+// Package config 定位工具箱的配置文件和数据目录
+//
+// 配置文件默认为用户配置目录下的 toolbox/config.yaml（Linux上为 ~/.config/toolbox/config.yaml），
+// 可以通过 TOOLBOX_CONFIG 环境变量指定其他路径。各功能只读取配置文件中属于自己的部分。
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"toolbox/pkg/errs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PathEnv 指定配置文件路径的环境变量
+const PathEnv = "TOOLBOX_CONFIG"
+
+// Dir 返回工具箱的配置目录
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errs.Wrap(err, "获取用户配置目录失败: %v", err)
+	}
+	return filepath.Join(dir, "toolbox"), nil
+}
+
+// Path 返回配置文件路径
+func Path() (string, error) {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path, nil
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Load 读取配置文件并解码到out，配置文件不存在时不修改out并返回nil
+func Load(out interface{}) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errs.Wrap(err, "读取配置文件失败: %v", err)
+	}
+	if err := yaml.Unmarshal(data, out); err != nil {
+		return errs.InvalidInput("解析配置文件 %s 失败: %v", path, err)
+	}
+	return nil
+}
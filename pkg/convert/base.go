@@ -0,0 +1,70 @@
+package convert
+
+import (
+	"math/big"
+	"strings"
+	"toolbox/pkg/errs"
+	"unicode"
+)
+
+// BaseResult 一个整数在各进制下的表示
+type BaseResult struct {
+	Input   string `json:"input"`
+	Decimal string `json:"decimal"`
+	Hex     string `json:"hex"`
+	Octal   string `json:"octal"`
+	Binary  string `json:"binary"`
+	Bits    int    `json:"bits"`           // 表示该数所需的位数
+	Char    string `json:"char,omitempty"` // 数值为可打印的Unicode码点时对应的字符
+}
+
+// ParseInteger 按指定进制解析整数，base 为0时按前缀识别：0x 十六进制、0o 或 0 开头八进制、
+// 0b 二进制，其余为十进制；数字中可以用 _ 分隔
+func ParseInteger(text string, base int) (*big.Int, error) {
+	s := strings.TrimSpace(text)
+	if base != 0 {
+		// 显式指定进制时允许带上对应的前缀
+		s = strings.ReplaceAll(s, "_", "")
+		sign := ""
+		if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+			sign, s = s[:1], s[1:]
+		}
+		if prefix, ok := basePrefixes[base]; ok && len(s) > 2 && strings.EqualFold(s[:2], prefix) {
+			s = s[2:]
+		}
+		s = sign + s
+	}
+	n, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		if base == 0 {
+			return nil, errs.InvalidInput("无效的整数: %s", text)
+		}
+		return nil, errs.InvalidInput("无效的%d进制整数: %s", base, text)
+	}
+	return n, nil
+}
+
+// basePrefixes 各进制的前缀
+var basePrefixes = map[int]string{16: "0x", 8: "0o", 2: "0b"}
+
+// FormatBases 返回整数在十进制、十六进制、八进制和二进制下的表示
+func FormatBases(input string, n *big.Int) BaseResult {
+	sign, abs := "", new(big.Int).Abs(n)
+	if n.Sign() < 0 {
+		sign = "-"
+	}
+	result := BaseResult{
+		Input:   input,
+		Decimal: n.String(),
+		Hex:     sign + "0x" + abs.Text(16),
+		Octal:   sign + "0o" + abs.Text(8),
+		Binary:  sign + "0b" + abs.Text(2),
+		Bits:    abs.BitLen(),
+	}
+	if n.IsInt64() && n.Sign() > 0 {
+		if r := rune(n.Int64()); n.Int64() <= unicode.MaxRune && unicode.IsPrint(r) {
+			result.Char = string(r)
+		}
+	}
+	return result
+}
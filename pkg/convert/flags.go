@@ -0,0 +1,278 @@
+package convert
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// FlagBit 一个标志位
+type FlagBit struct {
+	Name        string `json:"name"`
+	Value       uint64 `json:"value"`
+	Description string `json:"description"`
+}
+
+// FlagKind 一组标志位的定义
+type FlagKind struct {
+	Name        string    // 名称，如 mode、tcp
+	Description string    // 说明
+	Base        int       // 数值不带前缀时的默认进制
+	Flags       []FlagBit // 全部标志位，按数值从大到小排列
+}
+
+// FlagsResult 分解标志位的结果
+type FlagsResult struct {
+	Kind    string    `json:"kind"`
+	Value   uint64    `json:"value"`
+	Hex     string    `json:"hex"`
+	Octal   string    `json:"octal"`
+	Binary  string    `json:"binary"`
+	Text    string    `json:"text"`              // 组合写法，如 rwxr-xr-x、SYN|ACK
+	Set     []FlagBit `json:"set"`               // 已设置的标志位
+	Unknown uint64    `json:"unknown,omitempty"` // 不属于任何已知标志的位
+}
+
+// flagKinds 支持的标志类型，bits 不在此列，按位号逐位分解
+var flagKinds = []FlagKind{
+	{
+		Name:        "mode",
+		Description: "Unix文件权限，如 0755、rwxr-xr-x",
+		Base:        8,
+		Flags: []FlagBit{
+			{"setuid", 04000, "以文件所有者身份执行"},
+			{"setgid", 02000, "以文件所属组身份执行，目录中新文件继承所属组"},
+			{"sticky", 01000, "目录中的文件只能由所有者删除"},
+			{"owner-read", 0400, "所有者可读"},
+			{"owner-write", 0200, "所有者可写"},
+			{"owner-exec", 0100, "所有者可执行"},
+			{"group-read", 040, "所属组可读"},
+			{"group-write", 020, "所属组可写"},
+			{"group-exec", 010, "所属组可执行"},
+			{"other-read", 04, "其他用户可读"},
+			{"other-write", 02, "其他用户可写"},
+			{"other-exec", 01, "其他用户可执行"},
+		},
+	},
+	{
+		Name:        "tcp",
+		Description: "TCP报文头中的标志位，如 0x12、SYN|ACK",
+		Base:        0,
+		Flags: []FlagBit{
+			{"NS", 0x100, "ECN随机数隐藏保护"},
+			{"CWR", 0x80, "拥塞窗口已减小"},
+			{"ECE", 0x40, "ECN回显"},
+			{"URG", 0x20, "紧急指针有效"},
+			{"ACK", 0x10, "确认号有效"},
+			{"PSH", 0x08, "尽快交付给应用"},
+			{"RST", 0x04, "重置连接"},
+			{"SYN", 0x02, "同步序号，建立连接"},
+			{"FIN", 0x01, "发送方已无数据，关闭连接"},
+		},
+	},
+}
+
+// FlagKinds 返回支持的标志类型，最后一项 bits 表示按位号分解，说明文字按当前语言翻译
+func FlagKinds() []FlagKind {
+	kinds := make([]FlagKind, 0, len(flagKinds)+1)
+	for _, kind := range flagKinds {
+		kind.Description = i18n.T(kind.Description)
+		flags := make([]FlagBit, len(kind.Flags))
+		for i, flag := range kind.Flags {
+			flag.Description = i18n.T(flag.Description)
+			flags[i] = flag
+		}
+		kind.Flags = flags
+		kinds = append(kinds, kind)
+	}
+	return append(kinds, FlagKind{
+		Name:        "bits",
+		Description: i18n.T("任意整数，列出已设置的位号"),
+		Base:        0,
+	})
+}
+
+// lookupFlagKind 按名称查找标志类型
+func lookupFlagKind(name string) (FlagKind, error) {
+	var names []string
+	for _, kind := range FlagKinds() {
+		if strings.EqualFold(kind.Name, name) {
+			return kind, nil
+		}
+		names = append(names, kind.Name)
+	}
+	return FlagKind{}, errs.InvalidInput("未知的标志类型: %s，可选值: %s", name, strings.Join(names, ", "))
+}
+
+// DecodeFlags 将数值分解为已设置的标志位
+func DecodeFlags(kindName string, value uint64) (FlagsResult, error) {
+	kind, err := lookupFlagKind(kindName)
+	if err != nil {
+		return FlagsResult{}, err
+	}
+	result := FlagsResult{
+		Kind:   kind.Name,
+		Value:  value,
+		Hex:    "0x" + strconv.FormatUint(value, 16),
+		Octal:  "0o" + strconv.FormatUint(value, 8),
+		Binary: "0b" + strconv.FormatUint(value, 2),
+		Set:    []FlagBit{},
+	}
+
+	if kind.Name == "bits" {
+		var names []string
+		for i := bits.Len64(value) - 1; i >= 0; i-- {
+			if value&(1<<i) != 0 {
+				name := fmt.Sprintf("bit %d", i)
+				result.Set = append(result.Set, FlagBit{Name: name, Value: 1 << i})
+				names = append(names, strconv.Itoa(i))
+			}
+		}
+		result.Text = strings.Join(names, ",")
+		return result, nil
+	}
+
+	rest := value
+	var names []string
+	for _, flag := range kind.Flags {
+		if value&flag.Value != 0 {
+			result.Set = append(result.Set, flag)
+			names = append(names, flag.Name)
+			rest &^= flag.Value
+		}
+	}
+	result.Unknown = rest
+	if kind.Name == "mode" {
+		result.Text = modeString(value)
+	} else {
+		// TCP标志按习惯从低位到高位书写，如 SYN|ACK
+		for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+			names[i], names[j] = names[j], names[i]
+		}
+		result.Text = strings.Join(names, "|")
+	}
+	return result, nil
+}
+
+// ParseFlags 解析标志值，可以是数值（按类型的默认进制，或带 0x、0o、0b 前缀），
+// 也可以是组合写法：mode 支持 rwxr-xr-x，其他类型支持以 | 、, 或 + 分隔的标志名，
+// bits 支持以逗号分隔的位号
+func ParseFlags(kindName, text string) (uint64, error) {
+	kind, err := lookupFlagKind(kindName)
+	if err != nil {
+		return 0, err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, errs.InvalidInput("标志值不能为空")
+	}
+
+	if text[0] >= '0' && text[0] <= '9' && !(kind.Name == "bits" && strings.Contains(text, ",")) {
+		base := kind.Base
+		if len(text) > 2 && text[0] == '0' && strings.ContainsAny(text[1:2], "xXoObB") {
+			base = 0
+		}
+		n, err := ParseInteger(text, base)
+		if err != nil {
+			return 0, err
+		}
+		if n.Sign() < 0 || !n.IsUint64() {
+			return 0, errs.InvalidInput("标志值超出范围: %s", text)
+		}
+		return n.Uint64(), nil
+	}
+
+	switch kind.Name {
+	case "mode":
+		return parseModeString(text)
+	case "bits":
+		var value uint64
+		for _, part := range strings.Split(text, ",") {
+			bit, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || bit < 0 || bit > 63 {
+				return 0, errs.InvalidInput("无效的位号: %s，应为0到63之间的整数", part)
+			}
+			value |= 1 << bit
+		}
+		return value, nil
+	}
+
+	var value uint64
+	names := strings.FieldsFunc(text, func(r rune) bool { return r == '|' || r == ',' || r == '+' || r == ' ' })
+	for _, name := range names {
+		found := false
+		for _, flag := range kind.Flags {
+			if strings.EqualFold(flag.Name, name) {
+				value |= flag.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, errs.InvalidInput("未知的%s标志: %s", kind.Name, name)
+		}
+	}
+	return value, nil
+}
+
+// modeString 返回与 ls -l 一致的权限写法，如 rwsr-xr-t
+func modeString(mode uint64) string {
+	const rwx = "rwxrwxrwx"
+	buf := []byte("---------")
+	for i := 0; i < 9; i++ {
+		if mode&(1<<uint(8-i)) != 0 {
+			buf[i] = rwx[i]
+		}
+	}
+	special := []struct {
+		bit   uint64
+		index int
+		char  byte
+	}{{04000, 2, 's'}, {02000, 5, 's'}, {01000, 8, 't'}}
+	for _, s := range special {
+		if mode&s.bit == 0 {
+			continue
+		}
+		if buf[s.index] == 'x' {
+			buf[s.index] = s.char
+		} else {
+			buf[s.index] = s.char - 'a' + 'A'
+		}
+	}
+	return string(buf)
+}
+
+// parseModeString 解析 rwxr-xr-x 形式的权限，允许带 ls -l 输出中的文件类型字符
+func parseModeString(text string) (uint64, error) {
+	if len(text) == 10 {
+		text = text[1:]
+	}
+	if len(text) != 9 {
+		return 0, errs.InvalidInput("无效的权限写法: %s，应为9个字符，如 rwxr-xr-x", text)
+	}
+	var mode uint64
+	for i := 0; i < 9; i++ {
+		c := text[i]
+		bit := uint64(1) << uint(8-i)
+		switch {
+		case c == '-':
+		case c == "rwxrwxrwx"[i]:
+			mode |= bit
+		case i%3 == 2 && (c == 's' || c == 'S' || c == 't' || c == 'T'):
+			// s、t 表示同时设置执行位，大写表示未设置执行位
+			if (i == 8) != (c == 't' || c == 'T') {
+				return 0, errs.InvalidInput("无效的权限写法: %s", text)
+			}
+			mode |= 04000 >> uint(i/3)
+			if c == 's' || c == 't' {
+				mode |= bit
+			}
+		default:
+			return 0, errs.InvalidInput("无效的权限写法: %s", text)
+		}
+	}
+	return mode, nil
+}
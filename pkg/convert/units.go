@@ -0,0 +1,159 @@
+// Package convert 在不同单位、进制之间换算数值，并分解位标志
+//
+// 单位换算区分十进制和二进制前缀：KB、MB、GB 按1000进制，KiB、MiB、GiB 按1024进制；
+// 为与其他命令的大小参数一致，单独的 K、M、G 按1024进制。
+package convert
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"toolbox/pkg/errs"
+)
+
+// Category 单位的类别，只有同类单位之间可以换算
+type Category string
+
+// 单位类别
+const (
+	CategorySize     Category = "size"     // 数据大小，基准单位为字节
+	CategoryDuration Category = "duration" // 时长，基准单位为秒
+	CategoryRate     Category = "rate"     // 传输速率，基准单位为字节/秒
+)
+
+// Unit 一个单位
+type Unit struct {
+	Name     string   `json:"name"`              // 规范名称
+	Category Category `json:"category"`          // 类别
+	Factor   float64  `json:"factor"`            // 1个该单位等于多少基准单位
+	Aliases  []string `json:"aliases,omitempty"` // 其他写法
+}
+
+// unitList 支持的单位，同类单位按从小到大排列；不区分大小写查找时先注册的优先
+var unitList = []Unit{
+	{"B", CategorySize, 1, []string{"byte", "bytes"}},
+	{"KB", CategorySize, 1e3, nil},
+	{"MB", CategorySize, 1e6, nil},
+	{"GB", CategorySize, 1e9, nil},
+	{"TB", CategorySize, 1e12, nil},
+	{"PB", CategorySize, 1e15, nil},
+	{"KiB", CategorySize, 1 << 10, []string{"K"}},
+	{"MiB", CategorySize, 1 << 20, []string{"M"}},
+	{"GiB", CategorySize, 1 << 30, []string{"G"}},
+	{"TiB", CategorySize, 1 << 40, []string{"T"}},
+	{"PiB", CategorySize, 1 << 50, []string{"P"}},
+	{"bit", CategorySize, 1.0 / 8, []string{"b", "bits"}},
+	{"Kbit", CategorySize, 1e3 / 8, []string{"Kb"}},
+	{"Mbit", CategorySize, 1e6 / 8, []string{"Mb"}},
+	{"Gbit", CategorySize, 1e9 / 8, []string{"Gb"}},
+
+	{"ns", CategoryDuration, 1e-9, nil},
+	{"us", CategoryDuration, 1e-6, []string{"µs"}},
+	{"ms", CategoryDuration, 1e-3, nil},
+	{"s", CategoryDuration, 1, []string{"sec", "second", "seconds"}},
+	{"min", CategoryDuration, 60, []string{"m", "minute", "minutes"}},
+	{"h", CategoryDuration, 3600, []string{"hour", "hours"}},
+	{"d", CategoryDuration, 86400, []string{"day", "days"}},
+	{"w", CategoryDuration, 7 * 86400, []string{"week", "weeks"}},
+
+	{"bps", CategoryRate, 1.0 / 8, []string{"bit/s"}},
+	{"Kbps", CategoryRate, 1e3 / 8, []string{"Kbit/s"}},
+	{"Mbps", CategoryRate, 1e6 / 8, []string{"Mbit/s"}},
+	{"Gbps", CategoryRate, 1e9 / 8, []string{"Gbit/s"}},
+	{"B/s", CategoryRate, 1, nil},
+	{"KB/s", CategoryRate, 1e3, nil},
+	{"MB/s", CategoryRate, 1e6, nil},
+	{"GB/s", CategoryRate, 1e9, nil},
+	{"KiB/s", CategoryRate, 1 << 10, nil},
+	{"MiB/s", CategoryRate, 1 << 20, nil},
+	{"GiB/s", CategoryRate, 1 << 30, nil},
+}
+
+// 按名称查找单位的索引
+var (
+	unitsExact = make(map[string]Unit)
+	unitsFold  = make(map[string]Unit)
+)
+
+func init() {
+	for _, u := range unitList {
+		for _, name := range append([]string{u.Name}, u.Aliases...) {
+			unitsExact[name] = u
+			if _, ok := unitsFold[strings.ToLower(name)]; !ok {
+				unitsFold[strings.ToLower(name)] = u
+			}
+		}
+	}
+}
+
+// LookupUnit 按名称查找单位，优先区分大小写匹配（Mb 为兆比特，MB 为兆字节），
+// 找不到时再不区分大小写匹配
+func LookupUnit(name string) (Unit, error) {
+	if u, ok := unitsExact[name]; ok {
+		return u, nil
+	}
+	if u, ok := unitsFold[strings.ToLower(name)]; ok {
+		return u, nil
+	}
+	return Unit{}, errs.InvalidInput("未知的单位: %s", name)
+}
+
+// Units 返回某一类别的全部单位
+func Units(category Category) []Unit {
+	var units []Unit
+	for _, u := range unitList {
+		if u.Category == category {
+			units = append(units, u)
+		}
+	}
+	return units
+}
+
+// Quantity 带单位的数值
+type Quantity struct {
+	Value float64 `json:"value"`
+	Unit  Unit    `json:"unit"`
+}
+
+// ParseQuantity 解析带单位的数值，如 1.5GiB、100 Mbps、90min，数字中可以有 , 或 _ 分隔
+func ParseQuantity(text string) (Quantity, error) {
+	s := strings.NewReplacer(",", "", "_", "").Replace(strings.TrimSpace(text))
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == '-' || s[i] == '+' ||
+		(s[i] == 'e' || s[i] == 'E') && i > 0 && i+1 < len(s) && (s[i+1] >= '0' && s[i+1] <= '9' || s[i+1] == '-')) {
+		i++
+	}
+	number, unitName := s[:i], strings.TrimSpace(s[i:])
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || number == "" {
+		return Quantity{}, errs.InvalidInput("无效的数值: %s，应为数字加单位，如 1.5GiB、100Mbps、90min", text)
+	}
+	if unitName == "" {
+		return Quantity{}, errs.InvalidInput("缺少单位: %s", text)
+	}
+	unit, err := LookupUnit(unitName)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: value, Unit: unit}, nil
+}
+
+// Convert 将数值换算为目标单位
+func Convert(q Quantity, to Unit) (float64, error) {
+	if q.Unit.Category != to.Category {
+		return 0, errs.InvalidInput("不能将 %s 换算为 %s：单位类别不同", q.Unit.Name, to.Name)
+	}
+	return q.Value * q.Unit.Factor / to.Factor, nil
+}
+
+// FormatNumber 格式化换算结果，保留12位有效数字以去掉浮点误差
+func FormatNumber(v float64) string {
+	if v == 0 {
+		return "0"
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	if abs := math.Abs(rounded); abs >= 1e15 || abs < 1e-6 {
+		return strconv.FormatFloat(rounded, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,282 @@
+// Package cronexpr 解析标准的5字段cron表达式，计算下次执行时间并生成中文说明
+//
+// 字段依次为分钟、小时、日、月、星期，支持 *、列表（1,3）、范围（1-5）、步长（*/15、1-30/5）、
+// 月份和星期的英文缩写（JAN、MON）以及 @daily 等宏。与Vixie cron一致，日和星期都不是 * 时，
+// 满足其中之一即执行。
+package cronexpr
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// fieldSpec 一个字段的取值范围
+type fieldSpec struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+// 字段的序号
+const (
+	fieldMinute = iota
+	fieldHour
+	fieldDom
+	fieldMonth
+	fieldDow
+)
+
+var specs = [5]fieldSpec{
+	{name: "分钟", min: 0, max: 59},
+	{name: "小时", min: 0, max: 23},
+	{name: "日", min: 1, max: 31},
+	{name: "月", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	{name: "星期", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+// macros 预定义的宏
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// item 字段中以逗号分隔的一项，如 1-30/5
+type item struct {
+	start, end, step int
+	star             bool // 是否由 * 开头
+}
+
+// Schedule 解析后的cron表达式
+type Schedule struct {
+	Expr   string // 原始表达式
+	fields [5]uint64
+	items  [5][]item
+}
+
+// Parse 解析cron表达式，出错时返回带字段位置和修改提示的错误
+func Parse(expr string) (*Schedule, error) {
+	text := strings.TrimSpace(expr)
+	if strings.HasPrefix(text, "@") {
+		if text == "@reboot" {
+			return nil, errs.InvalidInput("@reboot 在系统启动时执行，没有固定的执行时间")
+		}
+		expanded, ok := macros[strings.ToLower(text)]
+		if !ok {
+			return nil, errs.InvalidInput("未知的宏: %s，可用的宏: @yearly、@monthly、@weekly、@daily、@hourly", text)
+		}
+		text = expanded
+	}
+
+	parts := strings.Fields(text)
+	switch {
+	case len(parts) == 6:
+		return nil, errs.InvalidInput(i18n.T("表达式有6个字段，只支持标准的5个字段: 分钟 小时 日 月 星期；") +
+			i18n.T("如果第一个字段是秒（Quartz、Spring等格式），请去掉它"))
+	case len(parts) == 7:
+		return nil, errs.InvalidInput("表达式有7个字段，看起来是包含秒和年的Quartz格式，只支持标准的5个字段: 分钟 小时 日 月 星期")
+	case len(parts) != 5:
+		return nil, errs.InvalidInput("表达式应有5个字段: 分钟 小时 日 月 星期，实际有%d个，例如 */15 2 * * 1-5", len(parts))
+	}
+
+	s := &Schedule{Expr: strings.TrimSpace(expr)}
+	for i, part := range parts {
+		items, bits, err := parseField(part, specs[i])
+		if err != nil {
+			return nil, errs.InvalidInput("第%d个字段（%s）%q 无效: %v", i+1, i18n.T(specs[i].name), part, err)
+		}
+		s.items[i], s.fields[i] = items, bits
+	}
+	// 7 与 0 都表示周日
+	if s.fields[fieldDow]&(1<<7) != 0 {
+		s.fields[fieldDow] = s.fields[fieldDow]&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// parseField 解析一个字段，返回各项和取值的位集合
+func parseField(text string, spec fieldSpec) ([]item, uint64, error) {
+	var items []item
+	var bits uint64
+	for _, part := range strings.Split(text, ",") {
+		it, err := parseItem(part, spec)
+		if err != nil {
+			return nil, 0, err
+		}
+		for v := it.start; v <= it.end; v += it.step {
+			bits |= 1 << uint(v)
+		}
+		items = append(items, it)
+	}
+	return items, bits, nil
+}
+
+// parseItem 解析字段中的一项: *、a、a-b，可带 /step
+func parseItem(text string, spec fieldSpec) (item, error) {
+	if text == "" {
+		return item{}, i18n.Errorf("列表中有空项，检查是否多写了逗号")
+	}
+	rangeText, stepText, hasStep := strings.Cut(text, "/")
+	it := item{step: 1}
+	if hasStep {
+		step, err := strconv.Atoi(stepText)
+		if err != nil {
+			return item{}, i18n.Errorf("步长 %q 不是整数", stepText)
+		}
+		if step <= 0 {
+			return item{}, i18n.Errorf("步长必须大于0")
+		}
+		it.step = step
+	}
+
+	if rangeText == "*" {
+		it.star, it.start, it.end = true, spec.min, spec.max
+		if spec.max == 7 {
+			// 星期字段的 * 只覆盖 0-6，避免周日被计算两次
+			it.end = 6
+		}
+		return it, nil
+	}
+
+	startText, endText, isRange := strings.Cut(rangeText, "-")
+	start, err := parseValue(startText, spec)
+	if err != nil {
+		return item{}, err
+	}
+	it.start, it.end = start, start
+	switch {
+	case isRange:
+		end, err := parseValue(endText, spec)
+		if err != nil {
+			return item{}, err
+		}
+		if end < start {
+			return item{}, i18n.Errorf("范围 %s 的起点大于终点，cron不支持跨越边界的范围，可以拆成两段，如 22-23,0-2", rangeText)
+		}
+		it.end = end
+	case hasStep:
+		// a/step 表示从a开始到最大值，每隔step
+		it.end = spec.max
+	}
+	return it, nil
+}
+
+// parseValue 解析单个取值，可以是数字或英文缩写
+func parseValue(text string, spec fieldSpec) (int, error) {
+	if v, ok := spec.names[strings.ToUpper(text)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		if strings.ContainsAny(strings.ToUpper(text), "?LW#") {
+			return 0, i18n.Errorf("不支持Quartz扩展语法 ?、L、W、#，请使用 * 或具体的取值")
+		}
+		if spec.names != nil {
+			return 0, i18n.Errorf("无法识别 %q，应为 %d-%d 的数字或英文缩写（%s）", text, spec.min, spec.max, nameHint(spec))
+		}
+		return 0, i18n.Errorf("无法识别 %q，应为 %d-%d 的数字", text, spec.min, spec.max)
+	}
+	if v < spec.min || v > spec.max {
+		hint := ""
+		switch {
+		case spec.name == "月" && v == 0:
+			hint = i18n.T("，月份从1开始")
+		case spec.name == "日" && v == 0:
+			hint = i18n.T("，日期从1开始")
+		case spec.name == "星期":
+			hint = i18n.T("，0和7都表示周日")
+		case spec.name == "小时" && v == 24:
+			hint = i18n.T("，午夜请写0")
+		}
+		return 0, i18n.Errorf("%d 超出范围 %d-%d%s", v, spec.min, spec.max, hint)
+	}
+	return v, nil
+}
+
+// nameHint 列出字段可用的英文缩写
+func nameHint(spec fieldSpec) string {
+	if spec.name == "月" {
+		return "JAN-DEC"
+	}
+	return "SUN-SAT"
+}
+
+// Next 返回晚于t的下一次执行时间，使用t的时区；一直不会执行时（如2月30日）返回零值
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	// 闰年2月29日最多相隔8年，超出范围说明永远不会执行
+	limit := t.Year() + 9
+
+	for t.Year() <= limit {
+		if !s.has(fieldMonth, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.has(fieldHour, t.Hour()) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// 夏令时结束时同一小时会出现两次，直接跳过
+				next = t.Add(time.Hour).Truncate(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if !s.has(fieldMinute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// NextN 返回晚于t的n次执行时间
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for len(times) < n {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// has 字段是否包含取值v
+func (s *Schedule) has(field, v int) bool {
+	return s.fields[field]&(1<<uint(v)) != 0
+}
+
+// restricted 字段是否有限制，与Vixie cron一致，以 * 开头（包括 */2）的字段视为没有限制
+func (s *Schedule) restricted(field int) bool {
+	return !s.items[field][0].star
+}
+
+// dayMatches 日期是否匹配日和星期字段，两者都有限制时满足其一即可
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.has(fieldDom, t.Day())
+	dow := s.has(fieldDow, int(t.Weekday()))
+	if s.restricted(fieldDom) && s.restricted(fieldDow) {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,139 @@
+package cronexpr
+
+import (
+	"fmt"
+	"strings"
+	"toolbox/pkg/i18n"
+)
+
+// weekdayNames 星期的中文名称，7 与 0 都表示周日
+var weekdayNames = []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六", "周日"}
+
+// Describe 返回表达式的说明，如 "每周一到周五 2点的每15分钟"，按当前界面语言翻译
+func (s *Schedule) Describe() string {
+	timeDesc := s.describeTime()
+
+	var date string
+	if s.restricted(fieldMonth) {
+		// 只有单个月份和日期时写成 "1月1号"，其余情况加 "的" 以免产生歧义
+		if months, days := singles(s.items[fieldMonth]), singles(s.items[fieldDom]); len(months) == 1 && len(days) == 1 && !s.restricted(fieldDow) {
+			return i18n.Tf("%d月%d号", months[0], days[0]) + " " + timeDesc
+		}
+		date = s.describeItems(fieldMonth) + i18n.T("的")
+	}
+	switch dom, dow := s.restricted(fieldDom), s.restricted(fieldDow); {
+	case dom && dow:
+		date += s.describeItems(fieldDom) + i18n.T("或") + s.describeItems(fieldDow)
+	case dom:
+		if date == "" {
+			date = i18n.T("每月")
+		}
+		date += s.describeItems(fieldDom)
+	case dow:
+		date += i18n.T("每") + s.describeItems(fieldDow)
+	case date == "":
+		// 日期没有限制时，重复执行的时间说明本身就足够
+		if strings.HasPrefix(timeDesc, i18n.T("每")) {
+			return timeDesc
+		}
+		date = i18n.T("每天")
+	default:
+		date += i18n.T("每天")
+	}
+	return date + " " + timeDesc
+}
+
+// describeTime 说明小时和分钟字段
+func (s *Schedule) describeTime() string {
+	minutes, hours := s.items[fieldMinute], s.items[fieldHour]
+	minuteSingles, hourSingles := singles(minutes), singles(hours)
+
+	// 取值较少时直接列出具体时刻
+	if minuteSingles != nil && hourSingles != nil && len(minuteSingles)*len(hourSingles) <= 6 {
+		var times []string
+		for _, h := range hourSingles {
+			for _, m := range minuteSingles {
+				times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+			}
+		}
+		return strings.Join(times, i18n.T("、"))
+	}
+
+	var minuteDesc string
+	switch {
+	case !s.restricted(fieldMinute) && minutes[0].step == 1:
+		minuteDesc = i18n.T("每分钟")
+	case len(minuteSingles) == 1 && minuteSingles[0] == 0:
+		minuteDesc = i18n.T("整点")
+	case minuteSingles != nil:
+		minuteDesc = i18n.Tf("第%s分钟", joinInts(minuteSingles))
+	default:
+		minuteDesc = s.describeItems(fieldMinute)
+	}
+
+	if !s.restricted(fieldHour) && hours[0].step == 1 {
+		if minuteSingles != nil {
+			return i18n.T("每小时的") + minuteDesc
+		}
+		return minuteDesc
+	}
+	return s.describeItems(fieldHour) + i18n.T("的") + minuteDesc
+}
+
+// describeItems 说明一个字段的各项，如 "1号到15号"、"每2小时"、"周一、周三"
+func (s *Schedule) describeItems(field int) string {
+	var parts []string
+	for _, it := range s.items[field] {
+		parts = append(parts, describeItem(field, it))
+	}
+	return strings.Join(parts, i18n.T("、"))
+}
+
+// describeItem 说明字段中的一项
+func describeItem(field int, it item) string {
+	value := func(v int) string {
+		switch field {
+		case fieldMinute:
+			return i18n.Tf("%d分", v)
+		case fieldHour:
+			return i18n.Tf("%d点", v)
+		case fieldDom:
+			return i18n.Tf("%d号", v)
+		case fieldMonth:
+			return i18n.Tf("%d月", v)
+		}
+		return i18n.T(weekdayNames[v])
+	}
+	units := [5]string{i18n.T("分钟"), i18n.T("小时"), i18n.T("天"), i18n.T("个月"), i18n.T("天")}
+
+	switch {
+	case it.star && it.step > 1:
+		return i18n.Tf("每%d%s", it.step, units[field])
+	case it.start == it.end:
+		return value(it.start)
+	case it.step == 1:
+		return value(it.start) + i18n.T("到") + value(it.end)
+	}
+	return i18n.Tf("%s到%s每%d%s", value(it.start), value(it.end), it.step, units[field])
+}
+
+// singles 字段中的各项都是单个取值时返回这些取值，否则返回 nil
+func singles(items []item) []int {
+	var values []int
+	for _, it := range items {
+		if it.star || it.start != it.end {
+			return nil
+		}
+		values = append(values, it.start)
+	}
+	return values
+}
+
+// joinInts 以顿号连接整数
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, i18n.T("、"))
+}
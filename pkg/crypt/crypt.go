@@ -0,0 +1,369 @@
+// Package crypt 使用口令对文件进行流式加密和解密
+//
+// 密钥由口令经 scrypt 或 argon2id 派生，数据按块使用 AES-256-GCM 加密。
+// 每块的nonce由随机前缀、块序号和结束标志组成（STREAM构造），文件头作为每块的附加数据，
+// 因此块被调换、删除、截断或文件头被修改都能在解密时发现。
+//
+// 文件格式（整数均为大端序）:
+//
+//	magic    8字节  "TBCRYPT" + 版本号 0x01
+//	kdf      1字节  1=scrypt，2=argon2id
+//	params   9字节  scrypt: r(4) p(4) log2(N)(1)；argon2id: time(4) memoryKiB(4) threads(1)
+//	salt     16字节
+//	chunk    4字节  明文块大小
+//	nonce    7字节  nonce前缀
+//	数据块   每块为 min(chunk, 剩余明文) 字节的密文加16字节认证标签
+package crypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDF 密钥派生算法
+type KDF string
+
+// 支持的密钥派生算法
+const (
+	KDFScrypt   KDF = "scrypt"
+	KDFArgon2id KDF = "argon2id"
+)
+
+// 默认参数
+const (
+	DefaultChunkSize = 64 * 1024 // 默认明文块大小
+
+	scryptLogN = 17 // N=2^17，约占用128MB内存
+	scryptR    = 8
+	scryptP    = 1
+
+	// 读取文件头时允许的 scrypt 参数上限，为默认值的小倍数：N 和 r 共同决定内存（128*N*r 字节），
+	// 上限约为默认的4倍（512MB），p 决定计算次数
+	maxScryptLogN   = scryptLogN + 2
+	maxScryptR      = scryptR * 4
+	maxScryptP      = scryptP * 4
+	maxScryptMemory = 4 * 128 * scryptR << scryptLogN // 默认内存的4倍，单位字节
+
+	argonTime    = 3
+	argonMemory  = 64 * 1024 // 64MB，单位KiB
+	argonThreads = 4
+
+	// 读取文件头时允许的 argon2id 参数上限，与 scrypt 相同为默认值的4倍
+	maxArgonTime    = argonTime * 4
+	maxArgonMemory  = argonMemory * 4 // 256MB
+	maxArgonThreads = argonThreads * 4
+)
+
+const (
+	magic      = "TBCRYPT\x01"
+	headerSize = 8 + 1 + 9 + 16 + 4 + 7
+	saltSize   = 16
+	prefixSize = 7
+	keySize    = 32
+
+	minChunkSize = 1024
+	maxChunkSize = 16 * 1024 * 1024
+)
+
+var kdfIDs = map[KDF]byte{KDFScrypt: 1, KDFArgon2id: 2}
+
+// ErrNotEncrypted 输入不是本工具加密的数据
+var ErrNotEncrypted = i18n.NewError("不是toolbox加密的文件")
+
+// Options 加密选项
+type Options struct {
+	KDF        KDF              // 密钥派生算法，默认为 scrypt
+	ChunkSize  int              // 明文块大小，默认 64KB
+	OnProgress func(done int64) // 每处理完一块后调用，参数为已处理的明文字节数
+}
+
+// Header 加密数据的文件头
+type Header struct {
+	KDF       KDF    `json:"kdf"`        // 密钥派生算法
+	Params    string `json:"params"`     // 密钥派生参数
+	ChunkSize int    `json:"chunk_size"` // 明文块大小
+
+	raw    []byte
+	params [3]uint32
+	salt   []byte
+	prefix []byte
+}
+
+// ParseKDF 解析密钥派生算法名称
+func ParseKDF(name string) (KDF, error) {
+	switch KDF(name) {
+	case "", KDFScrypt:
+		return KDFScrypt, nil
+	case KDFArgon2id, "argon2":
+		return KDFArgon2id, nil
+	}
+	return "", errs.InvalidInput("不支持的密钥派生算法: %s（可选: scrypt, argon2id）", name)
+}
+
+// Encrypt 使用口令加密src并写入dst，返回明文字节数
+func Encrypt(dst io.Writer, src io.Reader, passphrase []byte, opts Options) (int64, error) {
+	if len(passphrase) == 0 {
+		return 0, errs.InvalidInput("口令不能为空")
+	}
+	kdf, err := ParseKDF(string(opts.KDF))
+	if err != nil {
+		return 0, err
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize < minChunkSize || chunkSize > maxChunkSize {
+		return 0, errs.InvalidInput("块大小必须在 %d 到 %d 字节之间", minChunkSize, maxChunkSize)
+	}
+
+	header, err := newHeader(kdf, chunkSize)
+	if err != nil {
+		return 0, err
+	}
+	aead, err := header.aead(passphrase)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := dst.Write(header.raw); err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, chunkSize)
+	next := make([]byte, chunkSize)
+	out := make([]byte, 0, chunkSize+aead.Overhead())
+
+	n, err := readChunk(src, buf)
+	if err != nil {
+		return 0, err
+	}
+	var done int64
+	for counter := uint32(0); ; counter++ {
+		// 读满一块时预读下一块，以确定当前块是否为最后一块
+		last := n < chunkSize
+		m := 0
+		if !last {
+			if m, err = readChunk(src, next); err != nil {
+				return done, err
+			}
+			last = m == 0
+		}
+		if counter == ^uint32(0) && !last {
+			return done, errs.InvalidInput("文件过大，超出加密格式支持的块数")
+		}
+
+		out = aead.Seal(out[:0], header.nonce(counter, last), buf[:n], header.raw)
+		if _, err := dst.Write(out); err != nil {
+			return done, err
+		}
+		done += int64(n)
+		if opts.OnProgress != nil {
+			opts.OnProgress(done)
+		}
+		if last {
+			return done, nil
+		}
+		buf, next, n = next, buf, m
+	}
+}
+
+// Decrypt 使用口令解密src并写入dst，返回明文字节数
+//
+// 数据按块校验后立即写出，出错时dst中可能已有部分明文，调用方应丢弃。
+func Decrypt(dst io.Writer, src io.Reader, passphrase []byte, onProgress func(done int64)) (int64, error) {
+	header, err := ReadHeader(src)
+	if err != nil {
+		return 0, err
+	}
+	aead, err := header.aead(passphrase)
+	if err != nil {
+		return 0, err
+	}
+
+	size := header.ChunkSize + aead.Overhead()
+	buf := make([]byte, size)
+	next := make([]byte, size)
+	out := make([]byte, 0, header.ChunkSize)
+
+	n, err := readChunk(src, buf)
+	if err != nil {
+		return 0, err
+	}
+	var done int64
+	for counter := uint32(0); ; counter++ {
+		if n < aead.Overhead() {
+			return done, errs.InvalidInput("数据已被截断")
+		}
+		last := n < size
+		m := 0
+		if !last {
+			if m, err = readChunk(src, next); err != nil {
+				return done, err
+			}
+			last = m == 0
+		}
+
+		out, err = aead.Open(out[:0], header.nonce(counter, last), buf[:n], header.raw)
+		if err != nil {
+			if counter == 0 {
+				return done, errs.InvalidInput("口令错误或文件已损坏")
+			}
+			return done, errs.InvalidInput("文件已损坏或被截断（第 %d 块校验失败）", counter+1)
+		}
+		if _, err := dst.Write(out); err != nil {
+			return done, err
+		}
+		done += int64(len(out))
+		if onProgress != nil {
+			onProgress(done)
+		}
+		if last {
+			return done, nil
+		}
+		buf, next, n = next, buf, m
+	}
+}
+
+// ReadHeader 读取并校验文件头
+func ReadHeader(src io.Reader) (*Header, error) {
+	raw := make([]byte, headerSize)
+	if _, err := io.ReadFull(src, raw); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrNotEncrypted
+		}
+		return nil, err
+	}
+	if !bytes.Equal(raw[:len(magic)], []byte(magic)) {
+		return nil, ErrNotEncrypted
+	}
+
+	h := &Header{raw: raw}
+	p := raw[len(magic):]
+	switch p[0] {
+	case kdfIDs[KDFScrypt]:
+		h.KDF = KDFScrypt
+	case kdfIDs[KDFArgon2id]:
+		h.KDF = KDFArgon2id
+	default:
+		return nil, errs.InvalidInput("未知的密钥派生算法: %d", p[0])
+	}
+	h.params = [3]uint32{binary.BigEndian.Uint32(p[1:5]), binary.BigEndian.Uint32(p[5:9]), uint32(p[9])}
+	h.salt = p[10 : 10+saltSize]
+	h.ChunkSize = int(binary.BigEndian.Uint32(p[10+saltSize : 14+saltSize]))
+	h.prefix = p[14+saltSize:]
+
+	if h.ChunkSize < minChunkSize || h.ChunkSize > maxChunkSize {
+		return nil, errs.InvalidInput("文件头中的块大小无效: %d", h.ChunkSize)
+	}
+	if err := h.checkParams(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// newHeader 生成随机盐和nonce前缀，构造文件头
+func newHeader(kdf KDF, chunkSize int) (*Header, error) {
+	h := &Header{KDF: kdf, ChunkSize: chunkSize}
+	if kdf == KDFArgon2id {
+		h.params = [3]uint32{argonTime, argonMemory, argonThreads}
+	} else {
+		h.params = [3]uint32{scryptR, scryptP, scryptLogN}
+	}
+
+	raw := make([]byte, headerSize)
+	copy(raw, magic)
+	p := raw[len(magic):]
+	p[0] = kdfIDs[kdf]
+	binary.BigEndian.PutUint32(p[1:5], h.params[0])
+	binary.BigEndian.PutUint32(p[5:9], h.params[1])
+	p[9] = byte(h.params[2])
+	binary.BigEndian.PutUint32(p[10+saltSize:14+saltSize], uint32(chunkSize))
+	if _, err := rand.Read(p[10 : 10+saltSize]); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(p[14+saltSize:]); err != nil {
+		return nil, err
+	}
+
+	h.raw = raw
+	h.salt = p[10 : 10+saltSize]
+	h.prefix = p[14+saltSize:]
+	h.Params = h.describeParams()
+	return h, nil
+}
+
+// checkParams 限制密钥派生参数，避免恶意文件头耗尽内存或CPU
+func (h *Header) checkParams() error {
+	a, b, c := h.params[0], h.params[1], h.params[2]
+	switch h.KDF {
+	case KDFScrypt:
+		if a == 0 || a > maxScryptR || b == 0 || b > maxScryptP || c < 10 || c > maxScryptLogN ||
+			128*uint64(a)<<c > maxScryptMemory {
+			return errs.InvalidInput("文件头中的scrypt参数无效")
+		}
+	case KDFArgon2id:
+		if a == 0 || a > maxArgonTime || b < 8 || b > maxArgonMemory || c == 0 || c > maxArgonThreads {
+			return errs.InvalidInput("文件头中的argon2id参数无效")
+		}
+	}
+	h.Params = h.describeParams()
+	return nil
+}
+
+// describeParams 返回便于阅读的密钥派生参数
+func (h *Header) describeParams() string {
+	if h.KDF == KDFArgon2id {
+		return fmt.Sprintf("time=%d memory=%dMB threads=%d", h.params[0], h.params[1]/1024, h.params[2])
+	}
+	return fmt.Sprintf("N=2^%d r=%d p=%d", h.params[2], h.params[0], h.params[1])
+}
+
+// aead 由口令派生密钥并创建 AES-256-GCM
+func (h *Header) aead(passphrase []byte) (cipher.AEAD, error) {
+	var key []byte
+	if h.KDF == KDFArgon2id {
+		key = argon2.IDKey(passphrase, h.salt, h.params[0], h.params[1], uint8(h.params[2]), keySize)
+	} else {
+		var err error
+		key, err = scrypt.Key(passphrase, h.salt, 1<<h.params[2], int(h.params[0]), int(h.params[1]), keySize)
+		if err != nil {
+			return nil, i18n.Errorf("派生密钥失败: %v", err)
+		}
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// nonce 返回第counter块的nonce：前缀 + 块序号 + 结束标志
+func (h *Header) nonce(counter uint32, last bool) []byte {
+	nonce := make([]byte, prefixSize+5)
+	copy(nonce, h.prefix)
+	binary.BigEndian.PutUint32(nonce[prefixSize:], counter)
+	if last {
+		nonce[prefixSize+4] = 1
+	}
+	return nonce
+}
+
+// readChunk 尽量读满buf，到达末尾时返回实际读取的字节数
+func readChunk(r io.Reader, buf []byte) (int, error) {
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return n, nil
+	}
+	return n, err
+}
@@ -0,0 +1,53 @@
+package crypt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"toolbox/pkg/errs"
+)
+
+// 文件头中超过上限的密钥派生参数在派生密钥之前被拒绝，默认参数可以正常读取
+func TestReadHeaderRejectsLargeParams(t *testing.T) {
+	var encrypted bytes.Buffer
+	if _, err := Encrypt(&encrypted, bytes.NewReader([]byte("hello")), []byte("pw"), Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadHeader(bytes.NewReader(encrypted.Bytes())); err != nil {
+		t.Fatalf("默认参数的文件头应可以读取: %v", err)
+	}
+
+	// header 按 kdf 和三个参数构造文件头，参数的位置与 newHeader 相同
+	header := func(kdf KDF, a, b, c uint32) []byte {
+		raw := append([]byte(nil), encrypted.Bytes()[:headerSize]...)
+		p := raw[len(magic):]
+		p[0] = kdfIDs[kdf]
+		binary.BigEndian.PutUint32(p[1:5], a)
+		binary.BigEndian.PutUint32(p[5:9], b)
+		p[9] = byte(c)
+		return raw
+	}
+	tests := []struct {
+		name    string
+		kdf     KDF
+		a, b, c uint32
+	}{
+		{"scrypt r", KDFScrypt, maxScryptR + 1, scryptP, scryptLogN},
+		{"scrypt p", KDFScrypt, scryptR, maxScryptP + 1, scryptLogN},
+		{"scrypt N", KDFScrypt, scryptR, scryptP, maxScryptLogN + 1},
+		{"scrypt 内存", KDFScrypt, maxScryptR, scryptP, maxScryptLogN},
+		{"argon2id time", KDFArgon2id, maxArgonTime + 1, argonMemory, argonThreads},
+		{"argon2id memory", KDFArgon2id, argonTime, maxArgonMemory + 1, argonThreads},
+		{"argon2id threads", KDFArgon2id, argonTime, argonMemory, maxArgonThreads + 1},
+	}
+	for _, tt := range tests {
+		_, err := ReadHeader(bytes.NewReader(header(tt.kdf, tt.a, tt.b, tt.c)))
+		if !errors.Is(err, errs.ErrInvalidInput) {
+			t.Errorf("%s: 超过上限的参数应被拒绝，返回 %v", tt.name, err)
+		}
+	}
+	if _, err := ReadHeader(bytes.NewReader(header(KDFArgon2id, maxArgonTime, maxArgonMemory, maxArgonThreads))); err != nil {
+		t.Errorf("上限内的 argon2id 参数应可以读取: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Package digest 提供HMAC等带密钥摘要的计算和校验
+//
+// 主要用于调试Webhook签名：计算文件或请求体的HMAC，并与请求头中的签名比较。
+package digest
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"toolbox/pkg/errs"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// algorithms 支持的摘要算法
+var algorithms = map[string]func() hash.Hash{
+	"md5":      md5.New,
+	"sha1":     sha1.New,
+	"sha224":   sha256.New224,
+	"sha256":   sha256.New,
+	"sha384":   sha512.New384,
+	"sha512":   sha512.New,
+	"sha3-256": sha3.New256,
+	"sha3-512": sha3.New512,
+}
+
+// Algorithms 返回支持的算法名称，按名称排序
+func Algorithms() []string {
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Normalize 返回算法的规范名称，名称不区分大小写，允许 sha-256 这样的写法
+func Normalize(algorithm string) (string, error) {
+	name := strings.ToLower(algorithm)
+	if !strings.HasPrefix(name, "sha3") {
+		name = strings.Replace(name, "sha-", "sha", 1)
+	}
+	if _, ok := algorithms[name]; !ok {
+		return "", errs.InvalidInput("不支持的算法: %s（支持 %s）", algorithm, strings.Join(Algorithms(), "、"))
+	}
+	return name, nil
+}
+
+// NewHash 根据算法名称返回摘要构造函数
+func NewHash(algorithm string) (func() hash.Hash, error) {
+	name, err := Normalize(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return algorithms[name], nil
+}
+
+// ParseKey 解析密钥参数
+//
+// 支持以下写法：
+//   - @文件路径：从文件读取，去掉末尾的换行符
+//   - env:变量名：从环境变量读取
+//   - hex:十六进制、base64:base64文本：解码后作为密钥
+//   - 其他：按原文作为密钥
+func ParseKey(spec string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(spec, "@"):
+		data, err := os.ReadFile(spec[1:])
+		if err != nil {
+			return nil, errs.Wrap(err, "读取密钥文件失败: %v", err)
+		}
+		return []byte(strings.TrimRight(string(data), "\r\n")), nil
+	case strings.HasPrefix(spec, "env:"):
+		value, ok := os.LookupEnv(spec[4:])
+		if !ok {
+			return nil, errs.NotFound("环境变量 %s 不存在", spec[4:])
+		}
+		return []byte(value), nil
+	case strings.HasPrefix(spec, "hex:"):
+		key, err := hex.DecodeString(spec[4:])
+		if err != nil {
+			return nil, errs.InvalidInput("十六进制密钥无效: %v", err)
+		}
+		return key, nil
+	case strings.HasPrefix(spec, "base64:"):
+		key, err := base64.StdEncoding.DecodeString(spec[7:])
+		if err != nil {
+			return nil, errs.InvalidInput("base64密钥无效: %v", err)
+		}
+		return key, nil
+	}
+	return []byte(spec), nil
+}
+
+// HMAC 计算reader中全部数据的HMAC
+func HMAC(algorithm string, key []byte, reader io.Reader) ([]byte, error) {
+	newHash, err := NewHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(newHash, key)
+	if _, err := io.Copy(mac, reader); err != nil {
+		return nil, err
+	}
+	return mac.Sum(nil), nil
+}
+
+// Verify 以恒定时间比较计算得到的摘要和期望的签名
+//
+// 期望的签名可以是十六进制或base64，允许带有 "sha256=" 这样的算法前缀
+// （GitHub等服务的Webhook签名头格式）。
+func Verify(sum []byte, expected string) bool {
+	expected = strings.TrimSpace(expected)
+	if i := strings.Index(expected, "="); i > 0 && i < len(expected)-2 {
+		if _, err := Normalize(expected[:i]); err == nil {
+			expected = expected[i+1:]
+		}
+	}
+
+	if decoded, err := hex.DecodeString(expected); err == nil && hmac.Equal(sum, decoded) {
+		return true
+	}
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if decoded, err := encoding.DecodeString(expected); err == nil && hmac.Equal(sum, decoded) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+//go:build linux
+
+package docker
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// cgroupContainerID 匹配cgroup路径中的64位容器ID，兼容 docker-<id>.scope、/docker/<id>、
+// cri-containerd-<id>.scope 和 libpod-<id>.scope 等形式
+var cgroupContainerID = regexp.MustCompile(`(?:docker|containerd|libpod|crio|/kubepods\S*)[-/]([0-9a-f]{64})`)
+
+// ContainerIDOfPID 根据 /proc/<pid>/cgroup 返回进程所在容器的ID，不在容器中时返回空字符串。
+// 不需要访问Docker守护进程，也能识别containerd、Podman和Kubernetes的容器
+func ContainerIDOfPID(pid int32) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return ""
+	}
+	if m := cgroupContainerID.FindSubmatch(data); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
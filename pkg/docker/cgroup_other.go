@@ -0,0 +1,8 @@
+//go:build !linux
+
+package docker
+
+// ContainerIDOfPID 只在Linux上能根据cgroup判断进程所在的容器，其他系统上始终返回空字符串
+func ContainerIDOfPID(pid int32) string {
+	return ""
+}
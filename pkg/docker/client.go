@@ -0,0 +1,185 @@
+// Package docker 通过Docker Engine API查看容器
+//
+// 直接访问本地的Docker守护进程socket（也兼容Podman的Docker兼容接口），不依赖docker命令行。
+// 容器主进程和容器内进程的PID为宿主机上的PID，可以与 pkg/process 的进程信息对应。
+package docker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// Client Docker Engine API客户端
+type Client struct {
+	Host    string // 守护进程地址，如 unix:///var/run/docker.sock、tcp://10.0.0.5:2376
+	baseURL string
+	http    *http.Client
+}
+
+// DefaultHost 返回默认的守护进程地址：优先使用 DOCKER_HOST，否则使用第一个存在的本地socket
+func DefaultHost() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	if runtime.GOOS == "windows" {
+		return "npipe:////./pipe/docker_engine"
+	}
+
+	candidates := []string{"/var/run/docker.sock"}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		candidates = append(candidates, filepath.Join(dir, "docker.sock"), filepath.Join(dir, "podman", "podman.sock"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".docker", "run", "docker.sock"))
+	}
+	candidates = append(candidates, "/run/podman/podman.sock")
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return "unix://" + path
+		}
+	}
+	return "unix://" + candidates[0]
+}
+
+// NewClient 创建客户端，host 为空时使用 DefaultHost；tcp 地址在设置了 DOCKER_CERT_PATH 时使用TLS
+func NewClient(host string) (*Client, error) {
+	if host == "" {
+		host = DefaultHost()
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, errs.InvalidInput("无效的Docker地址 %q: %v", host, err)
+	}
+
+	transport := &http.Transport{}
+	baseURL := "http://docker"
+	switch u.Scheme {
+	case "unix":
+		path := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", path)
+		}
+	case "npipe":
+		path := strings.ReplaceAll(u.Path, "/", `\`)
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialPipe(ctx, path)
+		}
+	case "tcp", "http", "https":
+		baseURL = "http://" + u.Host
+		if certPath := os.Getenv("DOCKER_CERT_PATH"); certPath != "" || u.Scheme == "https" {
+			config, err := tlsConfig(certPath)
+			if err != nil {
+				return nil, err
+			}
+			transport.TLSClientConfig = config
+			baseURL = "https://" + u.Host
+		}
+	default:
+		return nil, errs.InvalidInput("不支持的Docker地址 %q，应为 unix://、npipe:// 或 tcp://", host)
+	}
+
+	return &Client{Host: host, baseURL: baseURL, http: &http.Client{Transport: transport}}, nil
+}
+
+// tlsConfig 从 DOCKER_CERT_PATH 目录加载 ca.pem、cert.pem 和 key.pem
+func tlsConfig(certPath string) (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: os.Getenv("DOCKER_TLS_VERIFY") == ""}
+	if certPath == "" {
+		return config, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(filepath.Join(certPath, "cert.pem"), filepath.Join(certPath, "key.pem"))
+	if err != nil {
+		return nil, errs.Wrap(err, "加载Docker客户端证书失败: %v", err)
+	}
+	config.Certificates = []tls.Certificate{cert}
+
+	caData, err := os.ReadFile(filepath.Join(certPath, "ca.pem"))
+	if err != nil {
+		return nil, errs.Wrap(err, "加载Docker CA证书失败: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(caData)
+	config.RootCAs = pool
+	return config, nil
+}
+
+// do 发送GET请求并检查状态码，调用方负责关闭响应
+func (c *Client) do(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, c.connectError(err)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, apiError(resp)
+	}
+	return resp, nil
+}
+
+// get 发送GET请求并将JSON响应解码到out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	resp, err := c.do(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return i18n.Errorf("解析Docker API响应失败: %v", err)
+	}
+	return nil
+}
+
+// connectError 说明无法连接守护进程的常见原因
+func (c *Client) connectError(err error) error {
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		return errs.PermissionDenied("没有权限访问 %s，将当前用户加入docker组或使用sudo: %v", c.Host, err)
+	case errors.Is(err, fs.ErrNotExist):
+		return errs.NotFound("无法连接Docker守护进程 %s，Docker未运行或未安装；可以用 DOCKER_HOST 或 --docker-host 指定地址", c.Host)
+	}
+	return errs.Wrap(err, "无法连接Docker守护进程 %s: %v", c.Host, err)
+}
+
+// apiError 将错误响应转换为错误，404 表示容器不存在
+func apiError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var payload struct {
+		Message string `json:"message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &payload) == nil && payload.Message != "" {
+		message = payload.Message
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errs.NotFound("%s", message)
+	}
+	return i18n.Errorf("Docker API错误（%d）: %s", resp.StatusCode, message)
+}
@@ -0,0 +1,300 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"toolbox/pkg/i18n"
+)
+
+// Container 容器信息
+type Container struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Image   string    `json:"image"`
+	Command string    `json:"command"`
+	Created time.Time `json:"created"`
+	State   string    `json:"state"`  // running、exited、paused 等
+	Status  string    `json:"status"` // 如 Up 2 hours、Exited (0) 3 days ago
+	Ports   []string  `json:"ports,omitempty"`
+	PID     int32     `json:"pid,omitempty"` // 主进程在宿主机上的PID，未运行时为0
+}
+
+// ShortID 返回12位的短ID
+func (c Container) ShortID() string {
+	if len(c.ID) > 12 {
+		return c.ID[:12]
+	}
+	return c.ID
+}
+
+// apiContainer /containers/json 返回的容器
+type apiContainer struct {
+	ID      string   `json:"Id"`
+	Names   []string `json:"Names"`
+	Image   string   `json:"Image"`
+	Command string   `json:"Command"`
+	Created int64    `json:"Created"`
+	State   string   `json:"State"`
+	Status  string   `json:"Status"`
+	Ports   []struct {
+		IP          string `json:"IP"`
+		PrivatePort int    `json:"PrivatePort"`
+		PublicPort  int    `json:"PublicPort"`
+		Type        string `json:"Type"`
+	} `json:"Ports"`
+}
+
+// inspectResult /containers/{id}/json 返回的部分字段
+type inspectResult struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	State struct {
+		Pid int32 `json:"Pid"`
+	} `json:"State"`
+	Config struct {
+		Tty bool `json:"Tty"`
+	} `json:"Config"`
+}
+
+// ListContainers 列出容器，all 为 false 时只列出运行中的容器；运行中的容器会查询主进程PID
+func (c *Client) ListContainers(ctx context.Context, all bool) ([]Container, error) {
+	query := url.Values{}
+	if all {
+		query.Set("all", "1")
+	}
+	var list []apiContainer
+	if err := c.get(ctx, "/containers/json", query, &list); err != nil {
+		return nil, err
+	}
+
+	containers := make([]Container, 0, len(list))
+	for _, item := range list {
+		container := Container{
+			ID:      item.ID,
+			Image:   item.Image,
+			Command: item.Command,
+			Created: time.Unix(item.Created, 0),
+			State:   item.State,
+			Status:  item.Status,
+		}
+		if len(item.Names) > 0 {
+			container.Name = strings.TrimPrefix(item.Names[0], "/")
+		}
+		for _, port := range item.Ports {
+			if port.PublicPort == 0 {
+				container.Ports = append(container.Ports, fmt.Sprintf("%d/%s", port.PrivatePort, port.Type))
+				continue
+			}
+			container.Ports = append(container.Ports, fmt.Sprintf("%s:%d->%d/%s", port.IP, port.PublicPort, port.PrivatePort, port.Type))
+		}
+		if container.State == "running" {
+			// 容器可能在列出后退出，查询失败时保留PID为0
+			if inspect, err := c.inspect(ctx, container.ID); err == nil {
+				container.PID = inspect.State.Pid
+			}
+		}
+		containers = append(containers, container)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+	return containers, nil
+}
+
+// inspect 查询容器详情，id 可以是名称、完整ID或ID前缀
+func (c *Client) inspect(ctx context.Context, id string) (inspectResult, error) {
+	var result inspectResult
+	err := c.get(ctx, "/containers/"+url.PathEscape(id)+"/json", nil, &result)
+	return result, err
+}
+
+// ContainerName 返回容器的名称
+func (c *Client) ContainerName(ctx context.Context, id string) (string, error) {
+	result, err := c.inspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(result.Name, "/"), nil
+}
+
+// Processes 返回容器内所有进程在宿主机上的PID
+func (c *Client) Processes(ctx context.Context, id string) ([]int32, error) {
+	var top struct {
+		Titles    []string   `json:"Titles"`
+		Processes [][]string `json:"Processes"`
+	}
+	if err := c.get(ctx, "/containers/"+url.PathEscape(id)+"/top", nil, &top); err != nil {
+		return nil, err
+	}
+
+	column := -1
+	for i, title := range top.Titles {
+		if title == "PID" {
+			column = i
+			break
+		}
+	}
+	if column < 0 {
+		return nil, i18n.Errorf("Docker API返回的进程列表中没有PID列")
+	}
+	var pids []int32
+	for _, row := range top.Processes {
+		if column >= len(row) {
+			continue
+		}
+		if pid, err := strconv.ParseInt(row[column], 10, 32); err == nil {
+			pids = append(pids, int32(pid))
+		}
+	}
+	return pids, nil
+}
+
+// FindByPID 查找宿主机上的进程所在的运行中的容器，找不到时返回 false
+func (c *Client) FindByPID(ctx context.Context, pid int32) (Container, bool, error) {
+	containers, err := c.ListContainers(ctx, false)
+	if err != nil {
+		return Container{}, false, err
+	}
+	// 先按cgroup中的容器ID匹配，不需要逐个查询容器内的进程
+	if id := ContainerIDOfPID(pid); id != "" {
+		for _, container := range containers {
+			if container.ID == id {
+				return container, true, nil
+			}
+		}
+	}
+	for _, container := range containers {
+		pids, err := c.Processes(ctx, container.ID)
+		if err != nil {
+			continue
+		}
+		for _, p := range pids {
+			if p == pid {
+				return container, true, nil
+			}
+		}
+	}
+	return Container{}, false, nil
+}
+
+// Stats 容器的资源使用情况
+type Stats struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	CPUPercent    float64 `json:"cpu_percent"` // 相对于单个CPU，多核时可能超过100
+	MemoryUsage   uint64  `json:"memory_usage"`
+	MemoryLimit   uint64  `json:"memory_limit"`
+	MemoryPercent float64 `json:"memory_percent"`
+	NetRx         uint64  `json:"net_rx"`
+	NetTx         uint64  `json:"net_tx"`
+	BlockRead     uint64  `json:"block_read"`
+	BlockWrite    uint64  `json:"block_write"`
+	PIDs          uint64  `json:"pids"`
+}
+
+// cpuStats Docker API中的CPU统计
+type cpuStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+// apiStats /containers/{id}/stats 返回的部分字段
+type apiStats struct {
+	Name        string   `json:"name"`
+	ID          string   `json:"id"`
+	CPUStats    cpuStats `json:"cpu_stats"`
+	PreCPUStats cpuStats `json:"precpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	BlkioStats struct {
+		IoServiceBytesRecursive []struct {
+			Op    string `json:"op"`
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+	} `json:"pids_stats"`
+}
+
+// Stats 获取容器当前的资源使用情况；守护进程需要约1秒采样CPU使用率
+func (c *Client) Stats(ctx context.Context, id string) (Stats, error) {
+	var raw apiStats
+	if err := c.get(ctx, "/containers/"+url.PathEscape(id)+"/stats", url.Values{"stream": {"false"}}, &raw); err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{
+		ID:          raw.ID,
+		Name:        strings.TrimPrefix(raw.Name, "/"),
+		MemoryLimit: raw.MemoryStats.Limit,
+		PIDs:        raw.PidsStats.Current,
+	}
+
+	cpuDelta := float64(raw.CPUStats.CPUUsage.TotalUsage) - float64(raw.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(raw.CPUStats.SystemUsage) - float64(raw.PreCPUStats.SystemUsage)
+	cpus := float64(raw.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(raw.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		stats.CPUPercent = cpuDelta / systemDelta * cpus * 100
+	}
+
+	// 与docker stats一致，内存使用量不计入可回收的页缓存（cgroup v1 为 total_inactive_file，v2 为 inactive_file）
+	stats.MemoryUsage = raw.MemoryStats.Usage
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if v, ok := raw.MemoryStats.Stats[key]; ok && v < stats.MemoryUsage {
+			stats.MemoryUsage -= v
+			break
+		}
+	}
+	if stats.MemoryLimit > 0 {
+		stats.MemoryPercent = float64(stats.MemoryUsage) / float64(stats.MemoryLimit) * 100
+	}
+
+	for _, network := range raw.Networks {
+		stats.NetRx += network.RxBytes
+		stats.NetTx += network.TxBytes
+	}
+	for _, entry := range raw.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockRead += entry.Value
+		case "write":
+			stats.BlockWrite += entry.Value
+		}
+	}
+	return stats, nil
+}
+
+// StatsAll 并发获取多个容器的资源使用情况，结果与 ids 的顺序一致
+func (c *Client) StatsAll(ctx context.Context, ids []string) ([]Stats, []error) {
+	results := make([]Stats, len(ids))
+	failures := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			results[i], failures[i] = c.Stats(ctx, id)
+		}(i, id)
+	}
+	wg.Wait()
+	return results, failures
+}
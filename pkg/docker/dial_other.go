@@ -0,0 +1,14 @@
+//go:build !windows
+
+package docker
+
+import (
+	"context"
+	"net"
+	"toolbox/pkg/errs"
+)
+
+// dialPipe 命名管道只在Windows上可用
+func dialPipe(ctx context.Context, path string) (net.Conn, error) {
+	return nil, errs.InvalidInput("命名管道 %s 只在Windows上可用", path)
+}
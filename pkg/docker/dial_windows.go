@@ -0,0 +1,15 @@
+//go:build windows
+
+package docker
+
+import (
+	"context"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// dialPipe 连接Windows命名管道，如 \\.\pipe\docker_engine
+func dialPipe(ctx context.Context, path string) (net.Conn, error) {
+	return winio.DialPipeContext(ctx, path)
+}
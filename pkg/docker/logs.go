@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+	"toolbox/pkg/i18n"
+)
+
+// LogOptions 定义了读取容器日志的选项
+type LogOptions struct {
+	Follow     bool      // 持续输出新的日志
+	Tail       int       // 只输出最后几行，负数表示全部
+	Since      time.Time // 只输出该时间之后的日志，零值表示不限制
+	Timestamps bool      // 每行前加上时间戳
+}
+
+// Logs 将容器的标准输出和标准错误分别写入 stdout 和 stderr，Follow 时直到容器退出或 ctx 取消才返回
+func (c *Client) Logs(ctx context.Context, id string, options LogOptions, stdout, stderr io.Writer) error {
+	inspect, err := c.inspect(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{"stdout": {"1"}, "stderr": {"1"}, "tail": {"all"}}
+	if options.Follow {
+		query.Set("follow", "1")
+	}
+	if options.Tail >= 0 {
+		query.Set("tail", strconv.Itoa(options.Tail))
+	}
+	if !options.Since.IsZero() {
+		query.Set("since", strconv.FormatInt(options.Since.Unix(), 10))
+	}
+	if options.Timestamps {
+		query.Set("timestamps", "1")
+	}
+
+	resp, err := c.do(ctx, "/containers/"+url.PathEscape(inspect.ID)+"/logs", query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 使用TTY的容器输出原始流，否则输出带8字节头部的多路复用流
+	if inspect.Config.Tty {
+		_, err = io.Copy(stdout, resp.Body)
+	} else {
+		err = demux(resp.Body, stdout, stderr)
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// demux 拆分多路复用流：每帧以8字节头部开始，第1字节为流类型（1标准输出，2标准错误），后4字节为大端序的长度
+func demux(r io.Reader, stdout, stderr io.Writer) error {
+	reader := bufio.NewReader(r)
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		out := stdout
+		switch header[0] {
+		case 0, 1:
+		case 2:
+			out = stderr
+		default:
+			return i18n.Errorf("无法识别的日志流类型 %d", header[0])
+		}
+		if _, err := io.CopyN(out, reader, int64(binary.BigEndian.Uint32(header[4:]))); err != nil {
+			return err
+		}
+	}
+}
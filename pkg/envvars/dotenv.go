@@ -0,0 +1,186 @@
+package envvars
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"toolbox/pkg/errs"
+)
+
+// validName 合法的变量名
+var validName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// ParseDotenv 解析 .env 文件
+//
+// 支持 # 注释、export 前缀、单引号（原样保留）和双引号（支持 \n \t \" \\ \$ 转义）的取值，
+// 引号中的取值可以跨多行；未加引号的取值在空白后的 # 处截断。不展开 ${VAR} 引用。
+// 重复的变量以后出现的为准。
+func ParseDotenv(data string) ([]Var, error) {
+	values := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, rest, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, errs.InvalidInput("第 %d 行: 缺少 =", lineNo)
+		}
+		if !validName.MatchString(name) {
+			return nil, errs.InvalidInput("第 %d 行: 无效的变量名 %q", lineNo, name)
+		}
+		rest = strings.TrimLeft(rest, " \t")
+
+		if rest == "" || (rest[0] != '"' && rest[0] != '\'') {
+			values[name] = unquotedValue(rest)
+			continue
+		}
+
+		// 引号中的取值可能跨多行，拼接后续行直到找到结束引号
+		quote := rest[0]
+		text := rest[1:]
+		for {
+			value, after, closed := cutQuoted(text, quote)
+			if closed {
+				after = strings.TrimSpace(after)
+				if after != "" && !strings.HasPrefix(after, "#") {
+					return nil, errs.InvalidInput("第 %d 行: 结束引号后有多余的内容 %q", i+1, after)
+				}
+				values[name] = value
+				break
+			}
+			if i+1 >= len(lines) {
+				return nil, errs.InvalidInput("第 %d 行: 变量 %s 的引号没有结束", lineNo, name)
+			}
+			i++
+			text += "\n" + lines[i]
+		}
+	}
+	return fromMap(values), nil
+}
+
+// unquotedValue 处理未加引号的取值，去掉行尾注释和空白
+func unquotedValue(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') {
+			s = s[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// cutQuoted 在s中查找结束引号，返回引号内处理转义后的取值和结束引号之后的内容
+func cutQuoted(s string, quote byte) (value, after string, closed bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return b.String(), s[i+1:], true
+		case c == '\\' && quote == '"' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\', '$':
+				b.WriteByte(s[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+// plainValue 不需要加引号的取值
+var plainValue = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+// FormatDotenv 将变量格式化为 .env 文件内容，含有空白或特殊字符的取值加双引号并转义
+func FormatDotenv(vars []Var) string {
+	var b strings.Builder
+	for _, v := range vars {
+		b.WriteString(v.Name)
+		b.WriteByte('=')
+		if plainValue.MatchString(v.Value) {
+			b.WriteString(v.Value)
+		} else {
+			b.WriteString(quoteDotenv(v.Value))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// quoteDotenv 为取值加双引号，转义引号、反斜杠、$ 和换行
+func quoteDotenv(value string) string {
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	).Replace(value) + `"`
+}
+
+// 比较的结果
+const (
+	DiffSame    = "same"    // 取值相同
+	DiffChanged = "changed" // 取值不同
+	DiffMissing = "missing" // 文件中有但当前环境中没有
+	DiffExtra   = "extra"   // 当前环境中有但文件中没有
+)
+
+// DiffEntry 一个变量的比较结果
+type DiffEntry struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Current string `json:"current,omitempty"` // 当前环境中的取值
+	File    string `json:"file,omitempty"`    // 文件中的取值
+	Secret  bool   `json:"secret,omitempty"`
+}
+
+// Diff 比较当前环境和文件中的变量，按名称排序；extra 为 true 时也列出只在当前环境中的变量
+func Diff(current, file []Var, extra bool) []DiffEntry {
+	var entries []DiffEntry
+	inFile := make(map[string]bool)
+	for _, f := range file {
+		inFile[nameKey(f.Name)] = true
+		entry := DiffEntry{Name: f.Name, File: f.Value, Secret: f.Secret}
+		c, ok := Lookup(current, f.Name)
+		switch {
+		case !ok:
+			entry.Status = DiffMissing
+		case c.Value == f.Value:
+			entry.Status = DiffSame
+			entry.Current = c.Value
+		default:
+			entry.Status = DiffChanged
+			entry.Current = c.Value
+			entry.Secret = entry.Secret || c.Secret
+		}
+		entries = append(entries, entry)
+	}
+	if extra {
+		for _, c := range current {
+			if !inFile[nameKey(c.Name)] {
+				entries = append(entries, DiffEntry{Name: c.Name, Status: DiffExtra, Current: c.Value, Secret: c.Secret})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
@@ -0,0 +1,164 @@
+package envvars
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// 路径列表中一项的问题
+const (
+	ProblemEmpty     = "empty"     // 空项，Unix 上表示当前目录
+	ProblemRelative  = "relative"  // 相对路径，随当前目录变化
+	ProblemMissing   = "missing"   // 目录不存在
+	ProblemNotDir    = "not-dir"   // 不是目录
+	ProblemDuplicate = "duplicate" // 与较早的一项重复（包括通过符号链接指向同一目录）
+)
+
+// PathEntry 路径列表中的一项
+type PathEntry struct {
+	Index     int    `json:"index"` // 从1开始的序号
+	Dir       string `json:"dir"`
+	Problem   string `json:"problem,omitempty"`
+	Duplicate int    `json:"duplicate,omitempty"` // 重复时为较早一项的序号
+}
+
+// Shadow PATH中较早目录里的可执行文件覆盖了较晚目录里的同名文件
+type Shadow struct {
+	Name     string   `json:"name"`
+	Used     string   `json:"used"`     // 实际执行的文件
+	Shadowed []string `json:"shadowed"` // 被覆盖的文件
+}
+
+// IsPathList 变量是否为以路径列表分隔符分隔的目录列表，如 PATH、PYTHONPATH、LD_LIBRARY_PATH、XDG_DATA_DIRS。
+// 名称以 PATH 或 _DIRS 结尾，且取值包含多项或是一个目录（排除 *_FILE_PATH 这类单个文件路径）
+func IsPathList(name, value string) bool {
+	upper := strings.ToUpper(name)
+	if !strings.HasSuffix(upper, "PATH") && !strings.HasSuffix(upper, "_DIRS") {
+		return false
+	}
+	if strings.ContainsRune(value, os.PathListSeparator) {
+		return true
+	}
+	info, err := os.Stat(value)
+	return err == nil && info.IsDir()
+}
+
+// AnalyzePathList 拆分路径列表并检查每一项
+func AnalyzePathList(value string) []PathEntry {
+	var entries []PathEntry
+	seen := make(map[string]int)
+	for i, dir := range filepath.SplitList(value) {
+		entry := PathEntry{Index: i + 1, Dir: dir}
+		entries = append(entries, entry)
+		current := &entries[len(entries)-1]
+
+		if dir == "" {
+			current.Problem = ProblemEmpty
+			continue
+		}
+		info, err := os.Stat(dir)
+		key := dirKey(dir)
+		switch {
+		case seen[key] > 0:
+			current.Problem = ProblemDuplicate
+			current.Duplicate = seen[key]
+			continue
+		case err != nil:
+			current.Problem = ProblemMissing
+		case !info.IsDir():
+			current.Problem = ProblemNotDir
+		case !filepath.IsAbs(dir):
+			current.Problem = ProblemRelative
+		}
+		seen[key] = current.Index
+	}
+	return entries
+}
+
+// dirKey 比较目录是否相同使用的键，解析符号链接，Windows 上不区分大小写
+func dirKey(dir string) string {
+	key := filepath.Clean(dir)
+	if resolved, err := filepath.EvalSymlinks(key); err == nil {
+		key = resolved
+	}
+	if abs, err := filepath.Abs(key); err == nil {
+		key = abs
+	}
+	if runtime.GOOS == "windows" {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// FindShadowed 找出PATH中被较早目录覆盖的同名可执行文件，跳过重复和有问题的项
+func FindShadowed(entries []PathEntry) []Shadow {
+	var shadows []Shadow
+	found := make(map[string]int) // 命令名到 shadows 中的下标，-1 表示尚未被覆盖
+	used := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Problem != "" && entry.Problem != ProblemRelative {
+			continue
+		}
+		files, err := os.ReadDir(entry.Dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			path := filepath.Join(entry.Dir, file.Name())
+			name, ok := commandName(path, file.Name())
+			if !ok {
+				continue
+			}
+			first, exists := used[name]
+			if !exists {
+				used[name] = path
+				found[name] = -1
+				continue
+			}
+			if sameFile(first, path) {
+				continue
+			}
+			if found[name] < 0 {
+				found[name] = len(shadows)
+				shadows = append(shadows, Shadow{Name: name, Used: first})
+			}
+			shadows[found[name]].Shadowed = append(shadows[found[name]].Shadowed, path)
+		}
+	}
+	return shadows
+}
+
+// sameFile 两个路径是否为同一个文件（如符号链接或硬链接），这种情况不算覆盖
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	return err == nil && os.SameFile(infoA, infoB)
+}
+
+// commandName 文件可以作为命令执行时返回命令名；Windows 上按 PATHEXT 判断并去掉扩展名
+func commandName(path, name string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	if runtime.GOOS != "windows" {
+		return name, info.Mode().Perm()&0111 != 0
+	}
+
+	pathext := os.Getenv("PATHEXT")
+	if pathext == "" {
+		pathext = ".COM;.EXE;.BAT;.CMD"
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range strings.Split(strings.ToLower(pathext), ";") {
+		if e != "" && e == ext {
+			return strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name))), true
+		}
+	}
+	return "", false
+}
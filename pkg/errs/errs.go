@@ -0,0 +1,161 @@
+// Package errs 定义各模块共用的错误类别
+//
+// 底层包返回的错误可以通过 errors.Is 判断类别，命令行根据类别返回不同的退出码，
+// 便于脚本区分“未找到”“权限不足”“超时”和“输入无效”等情况。
+package errs
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+	"toolbox/pkg/i18n"
+)
+
+// 错误类别
+var (
+	ErrNotFound         = i18n.NewError("未找到")
+	ErrPermissionDenied = i18n.NewError("权限不足")
+	ErrTimeout          = i18n.NewError("超时")
+	ErrInvalidInput     = i18n.NewError("无效的输入")
+)
+
+// 退出码
+const (
+	ExitOK               = 0 // 成功
+	ExitFailure          = 1 // 其他错误
+	ExitInvalidInput     = 2 // 参数或输入无效
+	ExitNotFound         = 3 // 文件、进程、主机等不存在
+	ExitPermissionDenied = 4 // 权限不足
+	ExitTimeout          = 5 // 操作超时
+)
+
+// Error 带有类别的错误
+type Error struct {
+	Kind error // 错误类别，为上面的 ErrXxx 之一
+	Err  error // 具体错误
+}
+
+// Error 返回具体错误的信息，不附加类别
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap 使 errors.Is 同时匹配错误类别和具体错误
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// New 创建指定类别的错误，格式字符串按当前界面语言翻译
+func New(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: i18n.Errorf(format, args...)}
+}
+
+// NotFound 创建“未找到”类别的错误
+func NotFound(format string, args ...interface{}) error {
+	return New(ErrNotFound, format, args...)
+}
+
+// PermissionDenied 创建“权限不足”类别的错误
+func PermissionDenied(format string, args ...interface{}) error {
+	return New(ErrPermissionDenied, format, args...)
+}
+
+// Timeout 创建“超时”类别的错误
+func Timeout(format string, args ...interface{}) error {
+	return New(ErrTimeout, format, args...)
+}
+
+// InvalidInput 创建“输入无效”类别的错误
+func InvalidInput(format string, args ...interface{}) error {
+	return New(ErrInvalidInput, format, args...)
+}
+
+// Wrap 根据原始错误推断类别，并使用格式化后的信息创建错误
+//
+// 与 fmt.Errorf("...: %v", err) 的用法相同，但保留了错误类别，
+// 无法推断类别时返回普通错误。
+func Wrap(err error, format string, args ...interface{}) error {
+	wrapped := i18n.Errorf(format, args...)
+	if kind := KindOf(err); kind != nil {
+		return &Error{Kind: kind, Err: wrapped}
+	}
+	return wrapped
+}
+
+// KindOf 返回错误的类别，无法判断时返回nil
+//
+// 除本包定义的类别外，还能识别文件不存在、权限不足、上下文超时和网络超时等标准库错误。
+func KindOf(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, kind := range []error{ErrNotFound, ErrPermissionDenied, ErrTimeout, ErrInvalidInput} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return ErrNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return ErrPermissionDenied
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return ErrTimeout
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return ErrNotFound
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrTimeout
+	}
+	return nil
+}
+
+// ExitCode 返回错误对应的退出码
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	switch KindOf(err) {
+	case ErrInvalidInput:
+		return ExitInvalidInput
+	case ErrNotFound:
+		return ExitNotFound
+	case ErrPermissionDenied:
+		return ExitPermissionDenied
+	case ErrTimeout:
+		return ExitTimeout
+	default:
+		return ExitFailure
+	}
+}
+
+// ExitError 只设置退出码、不需要再输出信息的错误
+//
+// 用于命令已经输出了结果（例如检查报告中存在失败项），只需要以非零状态退出的情况。
+type ExitError struct {
+	Code int
+}
+
+// Error 实现 error 接口
+func (e *ExitError) Error() string {
+	return i18n.Tf("退出码 %d", e.Code)
+}
+
+// Exit 创建指定退出码的 ExitError
+func Exit(code int) error {
+	return &ExitError{Code: code}
+}
@@ -0,0 +1,217 @@
+// Package fanout 对一组目标并发执行同一条命令，并汇总每个目标的执行结果
+package fanout
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// Placeholder 命令参数中代表目标的占位符
+const Placeholder = "{}"
+
+// 目标的执行状态
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusTimeout = "timeout"
+)
+
+// Options 执行选项
+type Options struct {
+	Executable string        // 要执行的程序
+	Args       []string      // 命令参数，其中的 {} 替换为目标
+	Targets    []string      // 目标列表
+	Parallel   int           // 最大并发数，小于1时为1
+	Timeout    time.Duration // 每个目标的超时时间，0表示不限制
+	Env        []string      // 额外的环境变量
+
+	// OnDone 每个目标执行结束后调用，调用是串行的
+	OnDone func(result Result)
+}
+
+// Result 单个目标的执行结果
+type Result struct {
+	Index      int           `json:"index"`           // 目标在列表中的序号，从1开始
+	Target     string        `json:"target"`          // 目标
+	Args       []string      `json:"args"`            // 替换占位符后的命令参数
+	Status     string        `json:"status"`          // ok、failed 或 timeout
+	ExitCode   int           `json:"exit_code"`       // 退出码
+	Duration   time.Duration `json:"-"`               // 耗时
+	DurationMs int64         `json:"duration_ms"`     // 耗时（毫秒）
+	Output     string        `json:"output"`          // 标准输出和标准错误的内容
+	Error      string        `json:"error,omitempty"` // 失败原因
+}
+
+// Summary 执行汇总
+type Summary struct {
+	Total      int      `json:"total"`       // 目标数
+	Succeeded  int      `json:"succeeded"`   // 成功数
+	Failed     int      `json:"failed"`      // 失败数（包括超时）
+	DurationMs int64    `json:"duration_ms"` // 总耗时（毫秒）
+	Results    []Result `json:"results"`     // 与目标顺序一致的结果
+}
+
+// FirstFailure 返回按目标顺序第一个失败的结果，全部成功时返回nil
+func (s *Summary) FirstFailure() *Result {
+	for i := range s.Results {
+		if s.Results[i].Status != StatusOK {
+			return &s.Results[i]
+		}
+	}
+	return nil
+}
+
+// LoadTargets 从文件读取目标列表，每行一个，忽略空行和 # 开头的注释，path为 "-" 时从标准输入读取
+func LoadTargets(path string) ([]string, error) {
+	var reader io.Reader
+	if path == "-" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, errs.Wrap(err, "打开目标文件失败: %v", err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	var targets []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("读取目标文件失败: %v", err)
+	}
+	return targets, nil
+}
+
+// Expand 将参数中的占位符替换为目标，没有占位符时把目标追加为最后一个参数
+func Expand(args []string, target string) []string {
+	expanded := make([]string, len(args))
+	found := false
+	for i, arg := range args {
+		if strings.Contains(arg, Placeholder) {
+			found = true
+		}
+		expanded[i] = strings.ReplaceAll(arg, Placeholder, target)
+	}
+	if !found {
+		expanded = append(expanded, target)
+	}
+	return expanded
+}
+
+// Run 并发地对每个目标执行命令，ctx被取消时未开始的目标不再执行
+func Run(ctx context.Context, options Options) (*Summary, error) {
+	if len(options.Targets) == 0 {
+		return nil, errs.InvalidInput("没有指定目标")
+	}
+	parallel := options.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	start := time.Now()
+	summary := &Summary{
+		Total:   len(options.Targets),
+		Results: make([]Result, len(options.Targets)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallel)
+	for i, target := range options.Targets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := runTarget(ctx, options, target)
+			result.Index = i + 1
+
+			mu.Lock()
+			defer mu.Unlock()
+			summary.Results[i] = result
+			if result.Status == StatusOK {
+				summary.Succeeded++
+			} else {
+				summary.Failed++
+			}
+			if options.OnDone != nil {
+				options.OnDone(result)
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	summary.DurationMs = time.Since(start).Milliseconds()
+	if ctx.Err() != nil {
+		return summary, errs.Wrap(ctx.Err(), "执行被中断: %v", ctx.Err())
+	}
+	return summary, nil
+}
+
+// runTarget 对单个目标执行命令
+func runTarget(ctx context.Context, options Options, target string) Result {
+	result := Result{Target: target, Args: Expand(options.Args, target)}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	var buf bytes.Buffer
+	cmd := exec.CommandContext(ctx, options.Executable, result.Args...)
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	cmd.Env = append(os.Environ(), options.Env...)
+
+	start := time.Now()
+	err := cmd.Run()
+	result.Duration = time.Since(start)
+	result.DurationMs = result.Duration.Milliseconds()
+	result.Output = buf.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		result.Status = StatusOK
+	case ctx.Err() == context.DeadlineExceeded:
+		result.Status = StatusTimeout
+		result.ExitCode = errs.ExitTimeout
+		result.Error = i18n.Tf("执行超过 %s", options.Timeout)
+	case errors.As(err, &exitErr) && exitErr.ExitCode() > 0:
+		result.Status = StatusFailed
+		result.ExitCode = exitErr.ExitCode()
+		result.Error = i18n.Tf("退出码 %d", result.ExitCode)
+	default:
+		result.Status = StatusFailed
+		result.ExitCode = errs.ExitFailure
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,445 @@
+// Package fileserver 通过HTTP提供目录中的静态文件
+//
+// 支持目录列表、Range请求（断点续传）、Basic认证和可选的文件上传：
+// 向目录地址以 multipart 表单 POST（目录列表页面中的上传表单）或向文件地址 PUT（curl -T）。
+// 上传不会覆盖已存在的文件。
+package fileserver
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/units"
+)
+
+// Options 定义了文件服务的选项
+type Options struct {
+	Root      string // 提供服务的目录
+	Username  string // Basic认证的用户名，为空表示不认证
+	Password  string // Basic认证的密码
+	Upload    bool   // 是否允许上传
+	MaxUpload int64  // 单次上传的最大字节数，0 表示不限制
+	Hidden    bool   // 是否列出和提供以 . 开头的文件
+
+	// OnRequest 每个请求处理完后调用，用于输出访问日志
+	OnRequest func(Request)
+}
+
+// Request 一次请求的记录
+type Request struct {
+	Time     time.Time
+	Remote   string
+	Method   string
+	Path     string
+	Status   int
+	Bytes    int64 // 响应体的字节数，上传时为接收的字节数
+	Duration time.Duration
+}
+
+// Handler 文件服务的HTTP处理器
+type Handler struct {
+	options Options
+	root    string
+}
+
+// NewHandler 创建文件服务的处理器，Root 必须是已存在的目录
+func NewHandler(options Options) (*Handler, error) {
+	root, err := filepath.Abs(options.Root)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, i18n.Errorf("%s 不是目录", options.Root)
+	}
+	return &Handler{options: options, root: root}, nil
+}
+
+// ServeHTTP 实现 http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+	start := time.Now()
+	defer func() {
+		if h.options.OnRequest != nil {
+			bytes := rec.bytes
+			if rec.received > 0 {
+				bytes = rec.received
+			}
+			h.options.OnRequest(Request{
+				Time:     start,
+				Remote:   r.RemoteAddr,
+				Method:   r.Method,
+				Path:     r.URL.Path,
+				Status:   rec.status,
+				Bytes:    bytes,
+				Duration: time.Since(start),
+			})
+		}
+	}()
+
+	if !h.authorized(r) {
+		rec.Header().Set("WWW-Authenticate", `Basic realm="toolbox", charset="UTF-8"`)
+		http.Error(rec, i18n.T("需要认证"), http.StatusUnauthorized)
+		return
+	}
+
+	urlPath := path.Clean("/" + r.URL.Path)
+	if !h.options.Hidden && hasHiddenPart(urlPath) {
+		http.NotFound(rec, r)
+		return
+	}
+	name, ok := h.resolve(urlPath)
+	if !ok {
+		http.Error(rec, i18n.T("无效的路径"), http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.serveGet(rec, r, urlPath, name)
+	case http.MethodPost, http.MethodPut:
+		if !h.options.Upload {
+			rec.Header().Set("Allow", "GET, HEAD")
+			http.Error(rec, i18n.T("未启用上传"), http.StatusMethodNotAllowed)
+			return
+		}
+		if h.options.MaxUpload > 0 {
+			r.Body = http.MaxBytesReader(rec, r.Body, h.options.MaxUpload)
+		}
+		if r.Method == http.MethodPost {
+			h.servePost(rec, r, urlPath, name)
+		} else {
+			h.servePut(rec, r, name)
+		}
+	default:
+		http.Error(rec, i18n.T("不支持的请求方法"), http.StatusMethodNotAllowed)
+	}
+}
+
+// resolve 将清理过的URL路径转换为root中的文件路径。与 http.Dir 相同，拒绝含有 / 以外的路径分隔符的路径：
+// Windows上 /..\..\secret 经过 path.Clean 不变，按本地路径解析时会跳出root；最后再检查结果仍在root内
+func (h *Handler) resolve(urlPath string) (string, bool) {
+	if filepath.Separator != '/' && strings.ContainsRune(urlPath, filepath.Separator) {
+		return "", false
+	}
+	name := filepath.Join(h.root, filepath.FromSlash(urlPath))
+	if name != h.root && !strings.HasPrefix(name, strings.TrimSuffix(h.root, string(filepath.Separator))+string(filepath.Separator)) {
+		return "", false
+	}
+	return name, true
+}
+
+// authorized 检查Basic认证，未设置用户名时总是通过
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.options.Username == "" {
+		return true
+	}
+	user, pass, ok := r.BasicAuth()
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(h.options.Username)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(h.options.Password)) == 1
+	return ok && userOK && passOK
+}
+
+// serveGet 提供文件或目录列表，目录中有 index.html 时提供该文件
+func (h *Handler) serveGet(w http.ResponseWriter, r *http.Request, urlPath, name string) {
+	info, err := os.Stat(name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			// 使用相对地址，以 // 开头的请求路径不会被当成其他主机的地址
+			target := &url.URL{Path: path.Base(urlPath) + "/", RawQuery: r.URL.RawQuery}
+			http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+			return
+		}
+		index := filepath.Join(name, "index.html")
+		if indexInfo, err := os.Stat(index); err == nil && !indexInfo.IsDir() {
+			name, info = index, indexInfo
+		} else {
+			h.serveListing(w, r, urlPath, name)
+			return
+		}
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	defer file.Close()
+	// ServeContent 负责Range、If-Modified-Since等条件请求
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// entry 目录列表中的一项
+type entry struct {
+	Name    string
+	URL     string
+	IsDir   bool
+	Size    string
+	ModTime string
+}
+
+// serveListing 输出目录列表页面，目录在前，按名称排序
+func (h *Handler) serveListing(w http.ResponseWriter, r *http.Request, urlPath, name string) {
+	dirEntries, err := os.ReadDir(name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var entries []entry
+	for _, d := range dirEntries {
+		if !h.options.Hidden && strings.HasPrefix(d.Name(), ".") {
+			continue
+		}
+		info, err := d.Info()
+		if err != nil {
+			continue
+		}
+		// 符号链接按其指向的目标显示
+		if d.Type()&os.ModeSymlink != 0 {
+			if target, err := os.Stat(filepath.Join(name, d.Name())); err == nil {
+				info = target
+			}
+		}
+		e := entry{
+			Name:    d.Name(),
+			URL:     (&url.URL{Path: d.Name()}).String(),
+			IsDir:   info.IsDir(),
+			ModTime: info.ModTime().Format("2006-01-02 15:04:05"),
+		}
+		if e.IsDir {
+			e.Name += "/"
+			e.URL += "/"
+		} else {
+			e.Size = units.FormatSize(info.Size())
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].IsDir != entries[j].IsDir {
+			return entries[i].IsDir
+		}
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if r.Method == http.MethodHead {
+		return
+	}
+	listingTemplate.Execute(w, struct {
+		Path    string
+		Parent  bool
+		Upload  bool
+		Entries []entry
+	}{urlPath, urlPath != "/", h.options.Upload, entries})
+}
+
+// servePost 保存以 multipart 表单上传到目录的文件，完成后返回目录列表
+func (h *Handler) servePost(w *recorder, r *http.Request, urlPath, dir string) {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		http.Error(w, i18n.T("只能上传到目录"), http.StatusBadRequest)
+		return
+	}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, i18n.T("请求不是multipart表单: ")+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var saved []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			http.Error(w, i18n.T("读取上传内容失败: ")+err.Error(), uploadStatus(err))
+			return
+		}
+		if part.FileName() == "" {
+			continue
+		}
+		base := filepath.Base(filepath.FromSlash(strings.ReplaceAll(part.FileName(), `\`, "/")))
+		if base == "." || base == ".." || base == string(filepath.Separator) {
+			http.Error(w, i18n.T("无效的文件名: ")+part.FileName(), http.StatusBadRequest)
+			return
+		}
+		n, err := save(filepath.Join(dir, base), part)
+		w.received += n
+		if err != nil {
+			http.Error(w, err.Error(), uploadStatus(err))
+			return
+		}
+		saved = append(saved, base)
+	}
+	if len(saved) == 0 {
+		http.Error(w, i18n.T("没有上传任何文件"), http.StatusBadRequest)
+		return
+	}
+
+	// 浏览器表单提交后回到目录列表，其他客户端得到保存的文件名
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		http.Redirect(w, r, strings.TrimSuffix(urlPath, "/")+"/", http.StatusSeeOther)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintln(w, strings.Join(saved, "\n"))
+}
+
+// servePut 将请求体保存为文件，父目录必须已存在
+func (h *Handler) servePut(w *recorder, r *http.Request, name string) {
+	if name == h.root {
+		http.Error(w, i18n.T("需要指定文件名"), http.StatusBadRequest)
+		return
+	}
+	if info, err := os.Stat(filepath.Dir(name)); err != nil || !info.IsDir() {
+		http.Error(w, i18n.T("目录不存在"), http.StatusNotFound)
+		return
+	}
+	n, err := save(name, r.Body)
+	w.received += n
+	if err != nil {
+		http.Error(w, err.Error(), uploadStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprint(w, i18n.Tf("已保存 %s（%d 字节）\n", filepath.Base(name), n))
+}
+
+// errExists 上传的文件已存在
+var errExists = i18n.NewError("文件已存在")
+
+// save 将内容写入新文件，文件已存在时返回 errExists；写入失败时删除不完整的文件
+func save(name string, body io.Reader) (int64, error) {
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return 0, fmt.Errorf("%w: %s", errExists, filepath.Base(name))
+		}
+		return 0, err
+	}
+	n, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(name)
+	}
+	return n, err
+}
+
+// uploadStatus 根据上传错误选择状态码
+func uploadStatus(err error) int {
+	var maxErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxErr):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, errExists):
+		return http.StatusConflict
+	case os.IsPermission(err):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError 根据文件系统错误返回对应的状态码
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case os.IsNotExist(err):
+		http.NotFound(w, r)
+	case os.IsPermission(err):
+		http.Error(w, i18n.T("没有访问权限"), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// hasHiddenPart 路径中是否有以 . 开头的部分
+func hasHiddenPart(urlPath string) bool {
+	for _, part := range strings.Split(urlPath, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// recorder 记录响应的状态码和字节数
+type recorder struct {
+	http.ResponseWriter
+	status   int
+	bytes    int64 // 写出的响应体字节数
+	received int64 // 上传时保存的字节数
+	wrote    bool
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if !r.wrote {
+		r.status, r.wrote = status, true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	r.wrote = true
+	n, err := r.ResponseWriter.Write(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func init() {
+	// 部分系统的MIME数据库中没有这些常见的前端文件类型
+	mime.AddExtensionType(".js", "text/javascript; charset=utf-8")
+	mime.AddExtensionType(".mjs", "text/javascript; charset=utf-8")
+	mime.AddExtensionType(".wasm", "application/wasm")
+	mime.AddExtensionType(".md", "text/markdown; charset=utf-8")
+}
+
+// listingTemplate 目录列表页面，页面中的文字通过 t 函数按当前界面语言翻译
+var listingTemplate = template.Must(template.New("listing").Funcs(template.FuncMap{"t": i18n.T}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Path}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", sans-serif; margin: 2em; color: #24292f; }
+table { border-collapse: collapse; min-width: 40em; }
+th, td { text-align: left; padding: 0.3em 1.2em 0.3em 0; }
+th { border-bottom: 1px solid #d0d7de; }
+td.size { text-align: right; font-variant-numeric: tabular-nums; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+form { margin: 1.5em 0; }
+</style>
+</head>
+<body>
+<h2>{{.Path}}</h2>
+{{if .Upload}}<form method="post" enctype="multipart/form-data"><input type="file" name="file" multiple> <button type="submit">{{t "上传"}}</button></form>
+{{end}}<table>
+<tr><th>{{t "名称"}}</th><th>{{t "大小"}}</th><th>{{t "修改时间"}}</th></tr>
+{{if .Parent}}<tr><td><a href="../">../</a></td><td></td><td></td></tr>
+{{end}}{{range .Entries}}<tr><td><a href="{{.URL}}">{{.Name}}</a></td><td class="size">{{.Size}}</td><td>{{.ModTime}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,58 @@
+package fileserver
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/netutils"
+)
+
+// SelfSigned 为本机生成临时的自签名证书，写入 dir 目录，返回证书和私钥文件路径
+//
+// 证书包含 localhost、本机主机名、回环地址和 hosts 中的名称或IP，有效期7天。
+func SelfSigned(dir string, hosts []string) (certFile, keyFile string, err error) {
+	config := netutils.CertConfig{
+		CommonName:   "localhost",
+		Organization: []string{"toolbox serve-dir"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []string{"127.0.0.1", "::1"},
+		ValidDays:    7,
+		KeyType:      "ecdsa-p256",
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		config.DNSNames = append(config.DNSNames, hostname)
+	}
+	for _, host := range hosts {
+		if net.ParseIP(host) != nil {
+			config.IPAddresses = append(config.IPAddresses, host)
+		} else if host != "" {
+			config.DNSNames = append(config.DNSNames, host)
+		}
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := netutils.GenerateCertificate(config, certFile, keyFile); err != nil {
+		return "", "", err
+	}
+	return certFile, keyFile, nil
+}
+
+// Fingerprint 返回证书文件中第一个证书的SHA-256指纹，以冒号分隔的十六进制表示
+func Fingerprint(certFile, keyFile string) (string, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return "", i18n.Errorf("加载证书失败: %v", err)
+	}
+	sum := sha256.Sum256(pair.Certificate[0])
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":"), nil
+}
@@ -3,13 +3,15 @@ package formatter
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/termcolor"
 
 	"github.com/beevik/etree"
 	"github.com/fatih/color"
@@ -34,7 +36,7 @@ type Options struct {
 	Pretty  bool       // 是否美化输出
 	Indent  int        // 缩进数量
 	Compact bool       // 是否压缩输出
-	Color   bool       // 是否彩色输出
+	Color   bool       // 是否彩色输出，全局禁用颜色时无效
 }
 
 // 默认缩进值
@@ -77,10 +79,15 @@ type Result struct {
 func Format(input io.Reader, opts Options) (*Result, error) {
 	startTime := time.Now()
 
+	// 全局禁用颜色时忽略彩色选项
+	if !termcolor.Enabled() {
+		opts.Color = false
+	}
+
 	// 读取输入数据
 	data, err := ioutil.ReadAll(input)
 	if err != nil {
-		return nil, fmt.Errorf("读取输入失败: %v", err)
+		return nil, errs.Wrap(err, "读取输入失败: %v", err)
 	}
 
 	inputSize := int64(len(data))
@@ -107,15 +114,16 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 		// 确保输入是有效的JSON
 		var jsonObj interface{}
 		if err := json.Unmarshal(data, &jsonObj); err != nil {
-			return nil, fmt.Errorf("解析JSON失败: %v", err)
+			return nil, i18n.Errorf("解析JSON失败: %v", err)
 		}
 
 		if opts.Pretty {
-			// 美化JSON
-			jsonData, err := json.MarshalIndent(jsonObj, "", strings.Repeat(" ", opts.GetIndent()))
-			if err != nil {
-				return nil, fmt.Errorf("生成美化JSON失败: %v", err)
+			// 美化JSON，直接缩进原始数据以保留键的顺序
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, bytes.TrimSpace(data), "", strings.Repeat(" ", opts.GetIndent())); err != nil {
+				return nil, i18n.Errorf("生成美化JSON失败: %v", err)
 			}
+			jsonData := buf.Bytes()
 
 			if opts.Color {
 				output = pretty.Color(jsonData, nil)
@@ -126,7 +134,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 			// 压缩JSON
 			var buf bytes.Buffer
 			if err := json.Compact(&buf, data); err != nil {
-				return nil, fmt.Errorf("压缩JSON失败: %v", err)
+				return nil, i18n.Errorf("压缩JSON失败: %v", err)
 			}
 			output = buf.Bytes()
 		} else {
@@ -142,7 +150,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 		doc.ReadSettings.CharsetReader = nil
 		err := doc.ReadFromBytes(data)
 		if err != nil {
-			return nil, fmt.Errorf("解析XML失败: %v", err)
+			return nil, i18n.Errorf("解析XML失败: %v", err)
 		}
 
 		if opts.Pretty {
@@ -152,7 +160,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 			doc.IndentWithSettings(settings)
 			xmlBytes, err := doc.WriteToBytes()
 			if err != nil {
-				return nil, fmt.Errorf("美化XML失败: %v", err)
+				return nil, i18n.Errorf("美化XML失败: %v", err)
 			}
 
 			if opts.Color {
@@ -167,7 +175,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 			doc.Indent(0) // 不缩进
 			xmlBytes, err := doc.WriteToBytes()
 			if err != nil {
-				return nil, fmt.Errorf("压缩XML失败: %v", err)
+				return nil, i18n.Errorf("压缩XML失败: %v", err)
 			}
 			// 去除额外的空白
 			xmlStr := string(xmlBytes)
@@ -180,7 +188,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 			doc.Indent(indentValue) // 使用格式对应的默认缩进
 			xmlBytes, err := doc.WriteToBytes()
 			if err != nil {
-				return nil, fmt.Errorf("格式化XML失败: %v", err)
+				return nil, i18n.Errorf("格式化XML失败: %v", err)
 			}
 
 			if opts.Color {
@@ -198,7 +206,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 		// 检查YAML是否有效
 		var yamlObj interface{}
 		if err := yaml.Unmarshal(data, &yamlObj); err != nil {
-			return nil, fmt.Errorf("解析YAML失败: %v", err)
+			return nil, i18n.Errorf("解析YAML失败: %v", err)
 		}
 
 		// 创建编码器，设置缩进
@@ -208,7 +216,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 
 		// 将数据编码为YAML
 		if err := encoder.Encode(yamlObj); err != nil {
-			return nil, fmt.Errorf("生成YAML失败: %v", err)
+			return nil, i18n.Errorf("生成YAML失败: %v", err)
 		}
 		encoder.Close()
 
@@ -224,7 +232,7 @@ func Format(input io.Reader, opts Options) (*Result, error) {
 		}
 
 	default:
-		return nil, fmt.Errorf("不支持的格式: %s", opts.Format)
+		return nil, errs.InvalidInput("不支持的格式: %s", opts.Format)
 	}
 
 	duration := time.Since(startTime)
@@ -337,7 +345,7 @@ func (r *Result) ToFile(path string) error {
 func FormatFile(path string, opts Options) (*Result, error) {
 	file, err := ioutil.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("读取文件失败: %v", err)
+		return nil, errs.Wrap(err, "读取文件失败: %v", err)
 	}
 
 	return Format(bytes.NewReader(file), opts)
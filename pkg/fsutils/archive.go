@@ -0,0 +1,353 @@
+package fsutils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/andybalholm/brotli"
+	"github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/nwaples/rardecode"
+	"github.com/saracen/go7z"
+	"github.com/saracen/go7z/headers"
+	"github.com/ulikunitz/xz"
+)
+
+// ArchiveEntry 压缩文件中的一个文件或目录
+type ArchiveEntry struct {
+	Name           string      // 在压缩文件中的路径，以 / 分隔
+	Size           int64       // 解压后的大小
+	CompressedSize int64       // 压缩后的大小；tar、7z等整体压缩的格式无法得知单个文件的压缩后大小，为-1
+	Mode           os.FileMode // 权限和文件类型
+	ModTime        time.Time   // 修改时间，压缩文件中没有记录时为零值
+	IsDir          bool
+	IsHardLink     bool   // tar中的硬链接，没有内容，Linkname 为链接到的文件在压缩文件中的路径
+	Linkname       string // tar中符号链接的目标；zip、7z等格式的符号链接以文件内容保存目标，为空
+}
+
+// entryOpener 打开当前文件的内容；流式格式只能在回调返回之前读取
+type entryOpener func() (io.Reader, error)
+
+// formatFromName 根据文件扩展名判断压缩格式
+func formatFromName(name string) (CompressFormat, bool) {
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return ZIP, true
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return TARGZ, true
+	case strings.HasSuffix(name, ".tar.bz2"), strings.HasSuffix(name, ".tbz2"):
+		return TARBZ2, true
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".txz"):
+		return TARXZ, true
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return TARZST, true
+	case strings.HasSuffix(name, ".tar.lz4"):
+		return TARLZ4, true
+	case strings.HasSuffix(name, ".tar.br"):
+		return TARBR, true
+	case strings.HasSuffix(name, ".gz"):
+		return GZ, true
+	case strings.HasSuffix(name, ".bz2"):
+		return BZ2, true
+	case strings.HasSuffix(name, ".xz"):
+		return XZ, true
+	case strings.HasSuffix(name, ".zst"):
+		return ZSTD, true
+	case strings.HasSuffix(name, ".lz4"):
+		return LZ4, true
+	case strings.HasSuffix(name, ".br"):
+		return BR, true
+	case strings.HasSuffix(name, ".rar"):
+		return RAR, true
+	case strings.HasSuffix(name, ".7z"):
+		return SEVENZIP, true
+	}
+	return "", false
+}
+
+// ListArchive 列出压缩文件中的文件和目录，不解压到磁盘
+//
+// zip和rar直接读取文件头；tar格式需要解压缩整个数据流，但跳过文件内容的写入；
+// 7z和单文件格式（gz、bz2等）没有记录解压后的大小，需要完整解压缩（不写入磁盘）才能得到。
+func ListArchive(path string) ([]ArchiveEntry, error) {
+	var entries []ArchiveEntry
+	err := walkArchive(context.Background(), path, DecompressOptions{}, func(entry ArchiveEntry, open entryOpener) error {
+		if entry.Size < 0 {
+			r, err := open()
+			if err != nil {
+				return err
+			}
+			if entry.Size, err = io.Copy(io.Discard, r); err != nil {
+				return fmt.Errorf("%s: %v", entry.Name, err)
+			}
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// walkArchive 按顺序对压缩文件中的每个文件调用fn，解压后的大小未知时 entry.Size 为-1。
+// 使用 options 中的 Format、Password 和 Progress，ctx取消时在读取下一块数据前停止
+func walkArchive(ctx context.Context, path string, options DecompressOptions, fn func(entry ArchiveEntry, open entryOpener) error) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return errs.Wrap(err, "无法访问压缩文件: %v", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	format := options.Format
+	if format == "" {
+		if format, err = detectFormat(path, io.NewSectionReader(file, 0, info.Size())); err != nil {
+			return err
+		}
+	}
+
+	// 分卷zip按所有分卷的总大小报告进度
+	var zipReader io.ReaderAt = file
+	size := info.Size()
+	if format == ZIP {
+		var closeVolumes func()
+		if zipReader, size, closeVolumes, err = openZipVolumes(file, path, size); err != nil {
+			return err
+		}
+		defer closeVolumes()
+	}
+
+	p := newProgress(ctx, options.Progress, size)
+	defer func() {
+		if err == nil {
+			p.done()
+		}
+	}()
+	walk := func(entry ArchiveEntry, open entryOpener) error {
+		p.start(entry.Name)
+		return fn(entry, open)
+	}
+
+	switch format {
+	case ZIP:
+		return walkZip(p.readerAt(zipReader), size, options.Password, walk)
+	case RAR:
+		return walkRar(p.reader(file), options.Password, walk)
+	case SEVENZIP:
+		return walk7z(p.readerAt(file), info.Size(), options.Password, walk)
+	case TARGZ, TARBZ2, TARXZ, TARZST, TARLZ4, TARBR:
+		r, err := newDecompressReader(format, p.reader(file))
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		if err := walkTar(r, walk); err != nil {
+			return err
+		}
+		// tar的结束标记之后还有填充，读完整个数据流，gzip等格式在末尾校验CRC
+		_, err = io.Copy(io.Discard, r)
+		return err
+	}
+
+	// 单文件格式只有一个文件，名称为去掉扩展名后的文件名
+	r, err := newDecompressReader(format, p.reader(file))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	entry := ArchiveEntry{
+		Name:           strings.TrimSuffix(filepath.Base(path), "."+string(format)),
+		Size:           -1,
+		CompressedSize: info.Size(),
+		Mode:           info.Mode().Perm(),
+		ModTime:        info.ModTime(),
+	}
+	return walk(entry, func() (io.Reader, error) { return r, nil })
+}
+
+// newDecompressReader 返回解压缩gz、bz2、xz、zst、lz4、br数据流（以及对应的tar格式）的reader
+func newDecompressReader(format CompressFormat, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case GZ, TARGZ:
+		return gzip.NewReader(r)
+	case BZ2, TARBZ2:
+		return bzip2.NewReader(r, nil)
+	case XZ, TARXZ:
+		xzr, err := xz.NewReader(bufio.NewReader(r))
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xzr), nil
+	case ZSTD, TARZST:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	case LZ4, TARLZ4:
+		return io.NopCloser(newLz4Reader(r)), nil
+	case BR, TARBR:
+		return io.NopCloser(brotli.NewReader(r)), nil
+	}
+	return nil, errs.InvalidInput("不支持的压缩格式: %s", format)
+}
+
+func walkZip(file io.ReaderAt, size int64, password string, fn func(ArchiveEntry, entryOpener) error) error {
+	reader, err := zip.NewReader(file, size)
+	if err != nil {
+		return err
+	}
+	for _, f := range reader.File {
+		entry := ArchiveEntry{
+			Name:           f.Name,
+			Size:           int64(f.UncompressedSize64),
+			CompressedSize: int64(f.CompressedSize64),
+			Mode:           f.Mode(),
+			ModTime:        f.Modified,
+			IsDir:          f.FileInfo().IsDir(),
+		}
+		var rc io.ReadCloser
+		err := fn(entry, func() (io.Reader, error) {
+			var err error
+			rc, err = openZipEntry(f, password)
+			return rc, err
+		})
+		if rc != nil {
+			rc.Close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTar(r io.Reader, fn func(ArchiveEntry, entryOpener) error) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		info := header.FileInfo()
+		entry := ArchiveEntry{
+			Name:           header.Name,
+			Size:           header.Size,
+			CompressedSize: -1,
+			Mode:           info.Mode(),
+			ModTime:        header.ModTime,
+			IsDir:          info.IsDir(),
+			IsHardLink:     header.Typeflag == tar.TypeLink,
+			Linkname:       header.Linkname,
+		}
+		if err := fn(entry, func() (io.Reader, error) { return tr, nil }); err != nil {
+			return err
+		}
+	}
+}
+
+func walkRar(file io.Reader, password string, fn func(ArchiveEntry, entryOpener) error) error {
+	rr, err := rardecode.NewReader(file, password)
+	if err != nil {
+		err = rarPasswordError(err)
+		return errs.Wrap(err, "无法读取RAR文件: %v", err)
+	}
+	for {
+		header, err := rr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return rarPasswordError(err)
+		}
+		entry := ArchiveEntry{
+			Name:           header.Name,
+			Size:           header.UnPackedSize,
+			CompressedSize: header.PackedSize,
+			Mode:           header.Mode(),
+			ModTime:        header.ModificationTime,
+			IsDir:          header.IsDir,
+		}
+		if header.UnKnownSize {
+			entry.Size = -1
+		}
+		if err := fn(entry, func() (io.Reader, error) { return rr, nil }); err != nil {
+			return err
+		}
+	}
+}
+
+func walk7z(file io.ReaderAt, size int64, password string, fn func(ArchiveEntry, entryOpener) error) error {
+	sz, err := go7z.NewReader(file, size)
+	if err != nil {
+		return i18n.Errorf("无法读取7z文件: %v", err)
+	}
+	sz.Options.SetPassword(password)
+	for {
+		hdr, err := sz.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		entry := ArchiveEntry{
+			Name:           hdr.Name,
+			Size:           -1,
+			CompressedSize: -1,
+			Mode:           sevenZipMode(hdr.Attrib),
+			ModTime:        hdr.ModifiedAt,
+			IsDir:          sevenZipIsDir(hdr),
+		}
+		if entry.IsDir {
+			entry.Size = 0
+			entry.Mode |= os.ModeDir
+		}
+		if err := fn(entry, func() (io.Reader, error) { return sz, nil }); err != nil {
+			return err
+		}
+	}
+}
+
+// 7z的文件属性与Windows相同，p7zip等在高16位保存Unix权限
+const (
+	sevenZipAttrDir       = 0x10
+	sevenZipAttrUnixExtra = 0x8000
+)
+
+// sevenZipIsDir 判断7z中的条目是否为目录
+func sevenZipIsDir(hdr *headers.FileInfo) bool {
+	return hdr.Attrib&sevenZipAttrDir != 0 || (hdr.IsEmptyStream && !hdr.IsEmptyFile) || strings.HasSuffix(hdr.Name, "/")
+}
+
+// sevenZipMode 从7z的文件属性中读取权限，没有保存Unix权限时目录为0755，文件为0644
+func sevenZipMode(attrib uint32) os.FileMode {
+	if attrib&sevenZipAttrUnixExtra != 0 {
+		mode := os.FileMode(attrib>>16) & os.ModePerm
+		if attrib>>16&0xF000 == 0xA000 { // S_IFLNK
+			mode |= os.ModeSymlink
+		}
+		return mode
+	}
+	if attrib&sevenZipAttrDir != 0 {
+		return 0755
+	}
+	return 0644
+}
@@ -0,0 +1,77 @@
+package fsutils
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fileAttrs 解压后要恢复的文件属性
+type fileAttrs struct {
+	mode    os.FileMode
+	modTime time.Time // 为零值时不恢复
+	uid     int       // 小于0时不恢复所有者
+	gid     int
+}
+
+// attrRestorer 在 PreserveAttrs 时恢复解压出的文件的属性；
+// 向目录中写入文件会改变目录的修改时间，所以目录的属性在全部解压完成后再恢复
+type attrRestorer struct {
+	root bool // 只有root能修改文件的所有者
+	dirs map[string]fileAttrs
+}
+
+func newAttrRestorer() *attrRestorer {
+	return &attrRestorer{root: os.Geteuid() == 0, dirs: make(map[string]fileAttrs)}
+}
+
+// file 恢复文件或符号链接的属性；符号链接只恢复所有者
+func (r *attrRestorer) file(path string, attrs fileAttrs) error {
+	if r == nil {
+		return nil
+	}
+	// 修改所有者会清除setuid位，所以先于chmod
+	if r.root && attrs.uid >= 0 {
+		if err := os.Lchown(path, attrs.uid, attrs.gid); err != nil {
+			return err
+		}
+	}
+	if attrs.mode&os.ModeSymlink != 0 {
+		return nil
+	}
+	if err := os.Chmod(path, attrs.mode&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	if !attrs.modTime.IsZero() {
+		return os.Chtimes(path, attrs.modTime, attrs.modTime)
+	}
+	return nil
+}
+
+// dir 记录目录的属性，在 finish 时恢复
+func (r *attrRestorer) dir(path string, attrs fileAttrs) {
+	if r != nil {
+		r.dirs[path] = attrs
+	}
+}
+
+// finish 从最深的目录开始恢复目录的属性，避免恢复父目录后又修改了其中的子目录
+func (r *attrRestorer) finish() error {
+	if r == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(r.dirs))
+	for path := range r.dirs {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.Count(paths[i], string(os.PathSeparator)) > strings.Count(paths[j], string(os.PathSeparator))
+	})
+	for _, path := range paths {
+		if err := r.file(path, r.dirs[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
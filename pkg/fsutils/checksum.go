@@ -0,0 +1,336 @@
+package fsutils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"toolbox/pkg/errs"
+)
+
+// ChecksumManifestName 目录校验和清单的默认文件名，放在目录中
+const ChecksumManifestName = "SHA256SUMS"
+
+// ChecksumOptions 生成和校验目录校验和清单的选项
+type ChecksumOptions struct {
+	Exclude  []string     // 排除匹配的文件和目录，语法与 CompressOptions.ExcludePatterns 相同；校验时只用于查找新增的文件
+	Threads  int          // 同时计算校验和的文件数量，0表示CPU核心数
+	Progress ProgressFunc // 按读取的字节数报告进度，为nil时不报告
+
+	OnError func(path string, err error) // 无法读取的目录或文件，为nil时忽略；并行计算时也按顺序调用
+}
+
+// ChecksumResult 生成校验和清单的结果
+type ChecksumResult struct {
+	Manifest string `json:"manifest"`
+	Files    int    `json:"files"`  // 写入清单的文件数量
+	Size     int64  `json:"size"`   // 写入清单的文件的总大小
+	Errors   int    `json:"errors"` // 无法读取、没有写入清单的文件和目录的数量
+}
+
+// ChecksumReport 按清单校验目录的结果，路径都是清单中记录的相对路径
+type ChecksumReport struct {
+	Manifest   string   `json:"manifest"`
+	Files      int      `json:"files"`      // 清单中的文件数量
+	OK         int      `json:"ok"`         // 校验和一致的文件数量
+	Size       int64    `json:"size"`       // 读取的文件的总大小
+	Mismatched []string `json:"mismatched"` // 内容与清单不一致的文件
+	Missing    []string `json:"missing"`    // 清单中有但已经不存在的文件
+	Unreadable []string `json:"unreadable"` // 存在但无法读取的文件
+	Extra      []string `json:"extra"`      // 目录中有但清单中没有的文件
+}
+
+// Passed 清单中的所有文件是否都存在且内容一致；清单中没有的文件不影响结果
+func (r *ChecksumReport) Passed() bool {
+	return len(r.Mismatched) == 0 && len(r.Missing) == 0 && len(r.Unreadable) == 0
+}
+
+// CreateChecksums 并行计算root下所有普通文件的SHA-256校验和，按 sha256sum 的格式写入manifest，
+// 文件名为相对于root的路径，按路径排序，可以在root中用 sha256sum -c 检查。
+// manifest 为空时写入 root/SHA256SUMS；清单文件本身不计算在内。不跟随符号链接
+func CreateChecksums(root, manifest string, options ChecksumOptions) (ChecksumResult, error) {
+	return CreateChecksumsContext(context.Background(), root, manifest, options)
+}
+
+// CreateChecksumsContext 与 CreateChecksums 相同，ctx取消时停止计算并返回ctx的错误，不写入清单
+func CreateChecksumsContext(ctx context.Context, root, manifest string, options ChecksumOptions) (ChecksumResult, error) {
+	result := ChecksumResult{}
+	if manifest == "" {
+		manifest = filepath.Join(root, ChecksumManifestName)
+	}
+	result.Manifest = manifest
+	h, err := newTreeHasher(ctx, root, manifest, options)
+	if err != nil {
+		return result, err
+	}
+
+	files, err := h.list()
+	if err != nil {
+		return result, err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	h.hashAll(files, total)
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	sums := newChecksums()
+	for _, f := range files {
+		if f.err != nil {
+			h.fail(filepath.Join(root, filepath.FromSlash(f.name)), f.err)
+			continue
+		}
+		sums.add(f.name, f.sum)
+		result.Files++
+		result.Size += f.size
+	}
+	result.Errors = h.errors
+	if err := sums.write(manifest); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// VerifyChecksums 按manifest（sha256sum 的格式，如 CreateChecksums 生成的清单）并行检查root下的文件，
+// 清单中的相对路径相对于root。报告内容不一致、已经不存在和无法读取的文件，以及目录中新增的文件。
+// manifest 为空时读取 root/SHA256SUMS
+func VerifyChecksums(root, manifest string, options ChecksumOptions) (*ChecksumReport, error) {
+	return VerifyChecksumsContext(context.Background(), root, manifest, options)
+}
+
+// VerifyChecksumsContext 与 VerifyChecksums 相同，ctx取消时停止计算并返回ctx的错误
+func VerifyChecksumsContext(ctx context.Context, root, manifest string, options ChecksumOptions) (*ChecksumReport, error) {
+	if manifest == "" {
+		manifest = filepath.Join(root, ChecksumManifestName)
+	}
+	sums, err := readChecksums(manifest)
+	if err != nil {
+		return nil, err
+	}
+	h, err := newTreeHasher(ctx, root, manifest, options)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ChecksumReport{
+		Manifest:   manifest,
+		Files:      len(sums.names),
+		Mismatched: []string{},
+		Missing:    []string{},
+		Unreadable: []string{},
+		Extra:      []string{},
+	}
+	var files []*treeFile
+	var total int64
+	for _, name := range sums.names {
+		info, err := os.Stat(h.path(name))
+		switch {
+		case os.IsNotExist(err):
+			report.Missing = append(report.Missing, name)
+		case err != nil:
+			h.fail(h.path(name), err)
+			report.Unreadable = append(report.Unreadable, name)
+		default:
+			files = append(files, &treeFile{name: name, size: info.Size()})
+			total += info.Size()
+		}
+	}
+	h.hashAll(files, total)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		switch {
+		case f.err != nil:
+			h.fail(h.path(f.name), f.err)
+			report.Unreadable = append(report.Unreadable, f.name)
+		case f.sum != sums.sums[f.name]:
+			report.Mismatched = append(report.Mismatched, f.name)
+		default:
+			report.OK++
+		}
+		report.Size += f.size
+	}
+
+	present, err := h.list()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range present {
+		if _, ok := sums.sums[f.name]; !ok {
+			report.Extra = append(report.Extra, f.name)
+		}
+	}
+	return report, nil
+}
+
+// treeFile 要计算校验和的一个文件，name 为相对于根目录、以 / 分隔的路径
+type treeFile struct {
+	name string
+	size int64
+	sum  string
+	err  error
+}
+
+// treeHasher 列出和并行计算目录中文件的校验和，mu 保护 errors
+type treeHasher struct {
+	ctx      context.Context
+	root     string
+	manifest string // 清单文件的绝对路径，列出文件时跳过
+	options  ChecksumOptions
+	exclude  []string
+
+	mu     sync.Mutex
+	errors int
+}
+
+func newTreeHasher(ctx context.Context, root, manifest string, options ChecksumOptions) (*treeHasher, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, errs.Wrap(err, "无法访问目录 %s: %v", root, err)
+	}
+	if !info.IsDir() {
+		return nil, errs.InvalidInput("%s 不是一个目录", root)
+	}
+	if options.Threads <= 0 {
+		options.Threads = runtime.NumCPU()
+	}
+	h := &treeHasher{ctx: ctx, root: root, options: options}
+	if h.manifest, err = filepath.Abs(manifest); err != nil {
+		return nil, err
+	}
+	for _, pattern := range options.Exclude {
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		h.exclude = append(h.exclude, glob)
+	}
+	return h, nil
+}
+
+// path 返回清单中的路径对应的文件路径
+func (h *treeHasher) path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(h.root, filepath.FromSlash(name))
+}
+
+// list 按路径顺序列出根目录下没有排除的普通文件，跳过清单文件本身
+func (h *treeHasher) list() ([]*treeFile, error) {
+	var files []*treeFile
+	err := filepath.WalkDir(h.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == h.root {
+				return err
+			}
+			h.fail(path, err)
+			return nil
+		}
+		if err := h.ctx.Err(); err != nil {
+			return err
+		}
+		if path == h.root {
+			return nil
+		}
+		rel, err := filepath.Rel(h.root, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if h.excluded(name) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if abs, err := filepath.Abs(path); err == nil && abs == h.manifest {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			h.fail(path, err)
+			return nil
+		}
+		files = append(files, &treeFile{name: name, size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		if h.ctx.Err() != nil {
+			return nil, h.ctx.Err()
+		}
+		return nil, errs.Wrap(err, "无法读取目录 %s: %v", h.root, err)
+	}
+	return files, nil
+}
+
+// hashAll 用 Threads 个goroutine计算files的校验和，结果和错误记录在每个文件中
+func (h *treeHasher) hashAll(files []*treeFile, total int64) {
+	p := newProgress(h.ctx, h.options.Progress, total)
+	queue := make(chan *treeFile)
+	var wg sync.WaitGroup
+	for i := 0; i < h.options.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range queue {
+				f.sum, f.err = h.hash(h.path(f.name), f.name, p)
+			}
+		}()
+	}
+	for _, f := range files {
+		if h.ctx.Err() != nil {
+			break
+		}
+		queue <- f
+	}
+	close(queue)
+	wg.Wait()
+	p.done()
+}
+
+// hash 计算一个文件的校验和，读取时累计进度并检查取消
+func (h *treeHasher) hash(path, name string, p *progress) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	p.start(name)
+	sum := sha256.New()
+	if _, err := io.Copy(sum, p.reader(file)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// excluded 是否排除相对路径为name的文件或目录
+func (h *treeHasher) excluded(name string) bool {
+	for _, glob := range h.exclude {
+		if matchGlob(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *treeHasher) fail(path string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.errors++
+	if h.options.OnError != nil {
+		h.options.OnError(path, err)
+	}
+}
@@ -0,0 +1,43 @@
+package fsutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"toolbox/pkg/errs"
+)
+
+// 内容不一致记录在报告中而不是作为错误返回；清单损坏是一般错误，不是参数错误
+func TestVerifyChecksumsFailureKinds(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a")
+	if err := os.WriteFile(file, []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateChecksums(dir, "", ChecksumOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := VerifyChecksums(dir, "", ChecksumOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Passed() || len(report.Mismatched) != 1 {
+		t.Fatalf("应报告 a 不一致: %+v", report)
+	}
+
+	manifest := filepath.Join(dir, ChecksumManifestName)
+	if err := os.WriteFile(manifest, []byte("zzz  a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = VerifyChecksums(dir, "", ChecksumOptions{})
+	if err == nil {
+		t.Fatal("损坏的清单应返回错误")
+	}
+	if code := errs.ExitCode(err); code != errs.ExitFailure {
+		t.Fatalf("损坏的清单的退出码应为 %d，实际为 %d: %v", errs.ExitFailure, code, err)
+	}
+}
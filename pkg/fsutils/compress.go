@@ -4,13 +4,22 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
-	"fmt"
+	"context"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 
+	aeszip "github.com/alexmullins/zip"
+	"github.com/andybalholm/brotli"
 	"github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/klauspost/pgzip"
 	"github.com/nwaples/rardecode"
 	"github.com/saracen/go7z"
 	"github.com/ulikunitz/xz"
@@ -24,9 +33,15 @@ const (
 	TARGZ    CompressFormat = "tar.gz"
 	TARBZ2   CompressFormat = "tar.bz2"
 	TARXZ    CompressFormat = "tar.xz"
+	TARZST   CompressFormat = "tar.zst"
+	TARLZ4   CompressFormat = "tar.lz4"
+	TARBR    CompressFormat = "tar.br"
 	GZ       CompressFormat = "gz"
 	BZ2      CompressFormat = "bz2"
 	XZ       CompressFormat = "xz"
+	ZSTD     CompressFormat = "zst"
+	LZ4      CompressFormat = "lz4"
+	BR       CompressFormat = "br"
 	RAR      CompressFormat = "rar" // 仅支持解压缩
 	SEVENZIP CompressFormat = "7z"
 )
@@ -36,6 +51,39 @@ type CompressOptions struct {
 	Format       CompressFormat // 压缩格式
 	Level        int            // 压缩级别（1-9，0表示默认）
 	ExcludePaths []string       // 要排除的路径列表
+	Progress     ProgressFunc   // 进度回调，为nil时不报告进度
+	Parallel     int            // tar.gz和gz并行压缩的线程数，0或1表示不并行，负数表示使用全部CPU核心
+	Password     string         // zip压缩文件的密码，为空时不加密；其他格式不支持密码
+
+	// VolumeSize 大于0时创建标准的分卷zip：除最后一个分卷外每个分卷为该大小，依次写入与目标文件同名的
+	// .z01、.z02……，最后一个分卷为目标文件本身（.zip），7-Zip、WinRAR 等工具可以直接解压。
+	// 只能用于zip格式，不小于 MinVolumeSize；只有 Compress 支持分卷，写入数据流时不能分卷
+	VolumeSize int64
+
+	// Manifest 不为空时压缩完成后在该路径写入SHA-256校验和清单（sha256sum 的格式）：
+	// 每个源文件以在压缩文件中的路径记录，最后是压缩文件本身（分卷zip为每个分卷）的文件名，
+	// 解压缩时可以用 DecompressOptions.Manifest 检查，也可以在压缩文件所在的目录用 sha256sum -c 检查压缩文件。
+	// 写入数据流（CompressTo）时不记录压缩文件本身
+	Manifest string
+
+	// Dedupe 只用于tar格式：内容和权限都相同的普通文件只写入第一次出现的文件，之后的文件写为指向它的硬链接条目，
+	// 适合有大量重复文件的构建输出目录。解压后这些文件是同一个文件的硬链接，修改时间以第一个文件为准；
+	// 只解压部分文件时，硬链接指向的文件也需要解压
+	Dedupe bool
+
+	// IncludePatterns 只压缩匹配的文件，ExcludePatterns 跳过匹配的文件和目录（包括目录下的所有文件）。
+	// 模式匹配文件在压缩文件中的路径（相对于源目录，以 / 分隔），语法与 path.Match 相同，** 匹配任意层目录；
+	// 不含 / 的模式匹配任意层级的文件名，如 *.log、node_modules。include 匹配某个目录时包括其下的所有文件
+	IncludePatterns []string
+	ExcludePatterns []string
+
+	MinSize        int64     // 只压缩不小于该大小的普通文件，0表示不限制
+	MaxSize        int64     // 只压缩不大于该大小的普通文件，0表示不限制
+	ModifiedAfter  time.Time // 只压缩在此时间及之后修改的文件，零值表示不限制
+	ModifiedBefore time.Time // 只压缩在此时间之前修改的文件，零值表示不限制
+
+	filter *sourceFilter // 由 Compress 根据以上规则和 ExcludePaths 创建；过滤规则只用于zip和tar格式
+	sums   *checksums    // 设置了 Manifest 时由 Compress 创建
 }
 
 // shouldExclude 检查路径是否应该被排除
@@ -67,541 +115,542 @@ func shouldExclude(path string, excludePaths []string) bool {
 
 // Compress 压缩文件或目录
 func Compress(src string, dst string, options CompressOptions) error {
-	// 检查源路径是否存在
-	srcInfo, err := os.Stat(src)
-	if err != nil {
-		return fmt.Errorf("无法访问源文件/目录: %v", err)
-	}
-
-	// 根据不同格式调用相应的压缩函数
-	switch options.Format {
-	case ZIP:
-		return compressZip(src, dst, srcInfo.IsDir(), options)
-	case TARGZ:
-		return compressTarGz(src, dst, srcInfo.IsDir(), options)
-	case TARBZ2:
-		return compressTarBz2(src, dst, srcInfo.IsDir(), options)
-	case TARXZ:
-		return compressTarXz(src, dst, srcInfo.IsDir(), options)
-	case GZ:
-		if srcInfo.IsDir() {
-			return fmt.Errorf("gz格式不支持压缩目录")
-		}
-		return compressGz(src, dst)
-	case BZ2:
-		if srcInfo.IsDir() {
-			return fmt.Errorf("bz2格式不支持压缩目录")
-		}
-		return compressBz2(src, dst)
-	case XZ:
-		if srcInfo.IsDir() {
-			return fmt.Errorf("xz格式不支持压缩目录")
-		}
-		return compressXz(src, dst)
-	case RAR:
-		return fmt.Errorf("RAR格式仅支持解压缩，不支持压缩（因为是专有格式）")
-	case SEVENZIP:
-		return compress7z()
-	default:
-		return fmt.Errorf("不支持的压缩格式: %s", options.Format)
-	}
+	return CompressContext(context.Background(), src, dst, options)
 }
 
-// Decompress 解压缩文件
-func Decompress(src string, dst string) error {
-	// 检查源文件是否存在
-	if _, err := os.Stat(src); err != nil {
-		return fmt.Errorf("无法访问压缩文件: %v", err)
+// CompressContext 压缩文件或目录，ctx取消时在读取下一块数据前停止，删除未完成的压缩文件并返回ctx的错误
+func CompressContext(ctx context.Context, src string, dst string, options CompressOptions) error {
+	// 已经取消时直接返回，避免下面删除已有的dst
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	// 创建目标目录（如果不存在）
-	if err := os.MkdirAll(dst, 0755); err != nil {
-		return fmt.Errorf("无法创建目标目录: %v", err)
+	// 检查源路径是否存在
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return errs.Wrap(err, "无法访问源文件/目录: %v", err)
 	}
-
-	// 根据文件扩展名判断压缩格式
-	switch {
-	case strings.HasSuffix(src, ".zip"):
-		return decompressZip(src, dst)
-	case strings.HasSuffix(src, ".tar.gz"), strings.HasSuffix(src, ".tgz"):
-		return decompressTarGz(src, dst)
-	case strings.HasSuffix(src, ".tar.bz2"), strings.HasSuffix(src, ".tbz2"):
-		return decompressTarBz2(src, dst)
-	case strings.HasSuffix(src, ".tar.xz"), strings.HasSuffix(src, ".txz"):
-		return decompressTarXz(src, dst)
-	case strings.HasSuffix(src, ".gz"):
-		return decompressGz(src, dst)
-	case strings.HasSuffix(src, ".bz2"):
-		return decompressBz2(src, dst)
-	case strings.HasSuffix(src, ".xz"):
-		return decompressXz(src, dst)
-	case strings.HasSuffix(src, ".rar"):
-		return decompressRar(src, dst)
-	case strings.HasSuffix(src, ".7z"):
-		return decompress7z(src, dst)
-	default:
-		return fmt.Errorf("无法识别的压缩格式")
+	// 先检查格式和过滤规则，避免不支持时留下空的压缩文件
+	if err := checkCompressFormat(options, srcInfo.IsDir()); err != nil {
+		return err
 	}
-}
-
-// compressZip 创建zip压缩文件
-func compressZip(src, dst string, isDir bool, options CompressOptions) error {
-	zipfile, err := os.Create(dst)
-	if err != nil {
+	if options.filter, err = newSourceFilter(options); err != nil {
 		return err
 	}
-	defer zipfile.Close()
-
-	archive := zip.NewWriter(zipfile)
-	defer archive.Close()
-
-	if isDir {
-		// 遍历目录
-		return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			// 检查是否应该排除此路径
-			if shouldExclude(path, options.ExcludePaths) {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
-
-			// 获取相对路径
-			header, err := zip.FileInfoHeader(info)
-			if err != nil {
-				return err
-			}
-
-			// 设置相对路径
-			relPath, err := filepath.Rel(src, path)
-			if err != nil {
-				return err
-			}
-			header.Name = filepath.ToSlash(relPath)
-
-			if info.IsDir() {
-				header.Name += "/"
-			} else {
-				header.Method = zip.Deflate
-			}
-
-			writer, err := archive.CreateHeader(header)
-			if err != nil {
-				return err
-			}
+	if options.Manifest != "" {
+		options.sums = newChecksums()
+	}
 
-			if !info.IsDir() {
-				file, err := os.Open(path)
-				if err != nil {
-					return err
-				}
-				defer file.Close()
-				_, err = io.Copy(writer, file)
-				if err != nil {
-					return err
-				}
+	archives := []string{dst}
+	if options.VolumeSize > 0 {
+		vw := newVolumeWriter(dst, options.VolumeSize)
+		err = compressTo(ctx, src, srcInfo, vw, options)
+		if err == nil {
+			err = vw.finish()
+		}
+		// 不完整的分卷无法解压，出错时全部删除
+		if err != nil {
+			vw.abort()
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
 			return err
-		})
-	} else {
-		// 压缩单个文件
-		if shouldExclude(src, options.ExcludePaths) {
-			return nil
 		}
-
-		file, err := os.Open(src)
+		archives = vw.paths
+	} else {
+		file, err := os.Create(dst)
 		if err != nil {
 			return err
 		}
-		defer file.Close()
-
-		writer, err := archive.Create(filepath.Base(src))
+		err = compressTo(ctx, src, srcInfo, file, options)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
 		if err != nil {
+			if ctx.Err() != nil {
+				os.Remove(dst)
+				return ctx.Err()
+			}
 			return err
 		}
-
-		_, err = io.Copy(writer, file)
-		return err
 	}
-}
 
-// compressTarGz 创建tar.gz压缩文件
-func compressTarGz(src, dst string, isDir bool, options CompressOptions) error {
-	file, err := os.Create(dst)
-	if err != nil {
-		return err
+	if options.sums == nil {
+		return nil
 	}
-	defer file.Close()
-
-	gzw := gzip.NewWriter(file)
-	defer gzw.Close()
-
-	tw := tar.NewWriter(gzw)
-	defer tw.Close()
-
-	if isDir {
-		// 遍历目录
-		return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			// 检查是否应该排除此路径
-			if shouldExclude(path, options.ExcludePaths) {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
-
-			// 获取相对路径
-			relPath, err := filepath.Rel(src, path)
-			if err != nil {
-				return err
-			}
-
-			// 创建tar头部
-			header, err := tar.FileInfoHeader(info, info.Name())
-			if err != nil {
-				return err
-			}
-			header.Name = filepath.ToSlash(relPath)
-
-			if err := tw.WriteHeader(header); err != nil {
-				return err
-			}
-
-			if !info.IsDir() {
-				file, err := os.Open(path)
-				if err != nil {
-					return err
-				}
-				defer file.Close()
-				_, err = io.Copy(tw, file)
-				if err != nil {
-					return err
-				}
-			}
-			return nil
-		})
-	} else {
-		// 压缩单个文件
-		if shouldExclude(src, options.ExcludePaths) {
-			return nil
+	for _, path := range archives {
+		if err := options.sums.addFile(filepath.Base(path), path); err != nil {
+			return errs.Wrap(err, "无法计算压缩文件的校验和: %v", err)
 		}
+	}
+	return options.sums.write(options.Manifest)
+}
 
-		file, err := os.Open(src)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
+// checkCompressFormat 检查压缩格式和密码是否可以用于源文件或目录
+func checkCompressFormat(options CompressOptions, isDir bool) error {
+	if options.Password != "" && options.Format != ZIP {
+		return errs.InvalidInput("%s 格式不支持密码，只有zip格式可以加密", options.Format)
+	}
+	if options.VolumeSize > 0 && options.Format != ZIP {
+		return errs.InvalidInput("%s 格式不支持分卷，只有zip格式可以分卷", options.Format)
+	}
+	if options.Dedupe && tarFormats[singleFormat(options.Format)] != options.Format {
+		return errs.InvalidInput("%s 格式不支持去重，只有tar格式可以将重复的文件写为硬链接", options.Format)
+	}
+	if options.VolumeSize > 0 && options.VolumeSize < MinVolumeSize {
+		return errs.InvalidInput("分卷大小不能小于 %dKB", MinVolumeSize>>10)
+	}
 
-		info, err := file.Stat()
-		if err != nil {
-			return err
+	switch options.Format {
+	case ZIP, TARGZ, TARBZ2, TARXZ, TARZST, TARLZ4, TARBR:
+		return nil
+	case GZ, BZ2, XZ, ZSTD, LZ4, BR:
+		if isDir {
+			return i18n.Errorf("%s格式不支持压缩目录", options.Format)
 		}
+		return nil
+	case RAR:
+		return errs.InvalidInput("RAR格式仅支持解压缩，不支持压缩（因为是专有格式）")
+	case SEVENZIP:
+		return compress7z()
+	case "":
+		return errs.InvalidInput("没有指定压缩格式")
+	default:
+		return errs.InvalidInput("不支持的压缩格式: %s", options.Format)
+	}
+}
 
-		header, err := tar.FileInfoHeader(info, info.Name())
-		if err != nil {
-			return err
+// compressTo 按 options.Format 压缩src并写入w，格式已经由 checkCompressFormat 检查过
+func compressTo(ctx context.Context, src string, srcInfo os.FileInfo, w io.Writer, options CompressOptions) error {
+	// 只有需要报告进度时才预先统计总大小，避免多遍历一次目录
+	var total int64
+	if options.Progress != nil {
+		var err error
+		if total, err = sourceSize(src, srcInfo.IsDir(), options.filter); err != nil {
+			return errs.Wrap(err, "无法统计源文件大小: %v", err)
 		}
-		header.Name = filepath.Base(src)
+	}
+	p := newProgress(ctx, options.Progress, total)
 
-		if err := tw.WriteHeader(header); err != nil {
-			return err
+	var err error
+	switch options.Format {
+	case ZIP:
+		if vw, ok := w.(*volumeWriter); ok {
+			err = compressSplitZip(src, vw, srcInfo.IsDir(), options, p)
+		} else {
+			err = compressZip(src, w, srcInfo.IsDir(), options, p)
 		}
-
-		_, err = io.Copy(tw, file)
-		return err
+	case TARGZ, TARBZ2, TARXZ, TARZST, TARLZ4, TARBR:
+		err = compressTar(src, w, srcInfo.IsDir(), options, p)
+	default:
+		err = compressFile(src, w, options, p)
+	}
+	if err == nil {
+		p.done()
 	}
+	return err
+}
+
+// Decompress 解压缩文件，返回解压出的文件、跳过的可疑路径和失败的文件；返回错误时结果中也包含已经处理的文件
+func Decompress(src string, dst string, options DecompressOptions) (DecompressResult, error) {
+	return DecompressContext(context.Background(), src, dst, options)
 }
 
-// compressTarBz2 创建tar.bz2压缩文件
-func compressTarBz2(src, dst string, isDir bool, options CompressOptions) error {
-	file, err := os.Create(dst)
+// DecompressContext 解压缩文件，ctx取消时在读取下一块数据前停止，删除正在写入的文件并返回ctx的错误；
+// 已经解压完成的文件会保留
+func DecompressContext(ctx context.Context, src string, dst string, options DecompressOptions) (DecompressResult, error) {
+	// 检查源文件是否存在
+	file, err := os.Open(src)
 	if err != nil {
-		return err
+		return DecompressResult{}, errs.Wrap(err, "无法访问压缩文件: %v", err)
 	}
 	defer file.Close()
-
-	bz2w, err := bzip2.NewWriter(file, nil)
+	srcInfo, err := file.Stat()
 	if err != nil {
-		return err
+		return DecompressResult{}, errs.Wrap(err, "无法访问压缩文件: %v", err)
 	}
-	defer bz2w.Close()
-
-	tw := tar.NewWriter(bz2w)
-	defer tw.Close()
 
-	if isDir {
-		// 遍历目录
-		return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			// 检查是否应该排除此路径
-			if shouldExclude(path, options.ExcludePaths) {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
-
-			// 获取相对路径
-			relPath, err := filepath.Rel(src, path)
-			if err != nil {
-				return err
-			}
-
-			// 创建tar头部
-			header, err := tar.FileInfoHeader(info, info.Name())
-			if err != nil {
-				return err
-			}
-			header.Name = filepath.ToSlash(relPath)
-
-			if err := tw.WriteHeader(header); err != nil {
-				return err
-			}
-
-			if !info.IsDir() {
-				file, err := os.Open(path)
-				if err != nil {
-					return err
-				}
-				defer file.Close()
-				_, err = io.Copy(tw, file)
-				if err != nil {
-					return err
-				}
-			}
-			return nil
-		})
-	} else {
-		// 压缩单个文件
-		if shouldExclude(src, options.ExcludePaths) {
-			return nil
+	// 没有指定格式时根据文件内容和扩展名判断，读取内容时不改变file的读取位置
+	if options.Format == "" {
+		if options.Format, err = detectFormat(src, io.NewSectionReader(file, 0, srcInfo.Size())); err != nil {
+			return DecompressResult{}, err
 		}
-
-		file, err := os.Open(src)
+	}
+	if options.Format == ZIP {
+		ra, size, closeVolumes, err := openZipVolumes(file, src, srcInfo.Size())
 		if err != nil {
-			return err
+			return DecompressResult{}, err
 		}
-		defer file.Close()
+		defer closeVolumes()
+		return decompressResult(ctx, file, ra, size, dst, options)
+	}
+	return decompressResult(ctx, file, file, srcInfo.Size(), dst, options)
+}
 
-		info, err := file.Stat()
-		if err != nil {
-			return err
+// decompressResult 调用 decompress 并返回记录的 DecompressResult
+func decompressResult(ctx context.Context, r io.Reader, ra io.ReaderAt, size int64, dst string, options DecompressOptions) (DecompressResult, error) {
+	options.result = &resultRecorder{}
+	err := decompress(ctx, r, ra, size, dst, options)
+	return options.result.get(), err
+}
+
+// decompress 按 options.Format 解压缩r中的数据，zip和7z需要随机访问，从ra读取；
+// size 为压缩数据的大小，未知时为0
+func decompress(ctx context.Context, r io.Reader, ra io.ReaderAt, size int64, dst string, options DecompressOptions) (err error) {
+	p := newProgress(ctx, options.Progress, size)
+	defer func() {
+		if err == nil {
+			p.done()
+		} else if ctx.Err() != nil {
+			// 写了一半的文件已由 writeEntry 删除
+			err = ctx.Err()
 		}
+	}()
 
-		header, err := tar.FileInfoHeader(info, info.Name())
-		if err != nil {
-			return err
+	singleFile := isSingleFile(options.Format)
+	if options.include, err = newIncludeFilter(options.Include); err != nil {
+		return err
+	}
+	if options.include != nil && singleFile {
+		return errs.InvalidInput("单文件格式只包含一个文件，不支持按模式选择要解压的文件")
+	}
+	if err := options.loadManifest(); err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = options.include.unmatched()
 		}
-		header.Name = filepath.Base(src)
+		if err == nil {
+			err = options.manifestMissing()
+		}
+	}()
+	if options.PreserveAttrs {
+		options.attrs = newAttrRestorer()
+		defer func() {
+			if err == nil {
+				err = options.attrs.finish()
+			}
+		}()
+	}
 
-		if err := tw.WriteHeader(header); err != nil {
+	// 单文件格式解压为文件，其余格式解压到目录，创建目标目录（如果不存在）
+	dir := dst
+	if singleFile {
+		dir = filepath.Dir(dst)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errs.Wrap(err, "无法创建目标目录: %v", err)
+	}
+
+	switch options.Format {
+	case ZIP:
+		return decompressZip(ra, size, dst, options, p)
+	case TARGZ, TARBZ2, TARXZ, TARZST, TARLZ4, TARBR:
+		tr, err := newDecompressReader(options.Format, p.reader(r))
+		if err != nil {
 			return err
 		}
-
-		_, err = io.Copy(tw, file)
-		return err
+		defer tr.Close()
+		return decompressTar(tr, dst, options, p)
+	case GZ, BZ2, XZ, ZSTD, LZ4, BR:
+		return decompressFile(r, dst, options, p)
+	case RAR:
+		return decompressRar(r, dst, options, p)
+	case SEVENZIP:
+		return decompress7z(ra, size, dst, options, p)
+	default:
+		return errs.InvalidInput("不支持的压缩格式: %s", options.Format)
 	}
 }
 
-// compressTarXz 创建tar.xz压缩文件
-func compressTarXz(src, dst string, isDir bool, options CompressOptions) error {
-	file, err := os.Create(dst)
-	if err != nil {
-		return err
+// isSingleFile 是否为单文件格式（gz、bz2、xz、zst、lz4、br），这些格式解压后得到一个文件
+func isSingleFile(format CompressFormat) bool {
+	switch format {
+	case GZ, BZ2, XZ, ZSTD, LZ4, BR:
+		return true
 	}
-	defer file.Close()
+	return false
+}
 
-	xzw, err := xz.NewWriter(file)
-	if err != nil {
+// compressZip 创建zip压缩文件
+func compressZip(src string, w io.Writer, isDir bool, options CompressOptions, p *progress) error {
+	create, archive := newZipWriter(w, options.Password)
+	if err := writeZip(create, src, isDir, options, p); err != nil {
+		archive.Close()
 		return err
 	}
-	defer xzw.Close()
-
-	tw := tar.NewWriter(xzw)
-	defer tw.Close()
-
-	if isDir {
-		// 遍历目录
-		return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			// 检查是否应该排除此路径
-			if shouldExclude(path, options.ExcludePaths) {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
+	return archive.Close()
+}
 
-			// 获取相对路径
-			relPath, err := filepath.Rel(src, path)
-			if err != nil {
-				return err
-			}
+// zipArchiveCloser 标准库和aeszip的 zip.Writer 共有的方法
+type zipArchiveCloser interface {
+	SetOffset(n int64)
+	Flush() error
+	Close() error
+}
 
-			// 创建tar头部
-			header, err := tar.FileInfoHeader(info, info.Name())
+// newZipWriter 创建向w写入zip的writer，返回创建条目的函数和结束写入的 zip.Writer；
+// 设置了密码时使用AES-256加密文件内容（WinZip AE-2格式，文件名不加密）
+func newZipWriter(w io.Writer, password string) (zipEntryFunc, zipArchiveCloser) {
+	if password == "" {
+		zw := zip.NewWriter(w)
+		create := func(name string, info os.FileInfo) (io.Writer, error) {
+			header, err := zip.FileInfoHeader(info)
 			if err != nil {
-				return err
-			}
-			header.Name = filepath.ToSlash(relPath)
-
-			if err := tw.WriteHeader(header); err != nil {
-				return err
+				return nil, err
 			}
-
+			header.Name = name
 			if !info.IsDir() {
-				file, err := os.Open(path)
-				if err != nil {
-					return err
-				}
-				defer file.Close()
-				_, err = io.Copy(tw, file)
-				if err != nil {
-					return err
-				}
+				header.Method = zip.Deflate
 			}
-			return nil
-		})
-	} else {
-		// 压缩单个文件
-		if shouldExclude(src, options.ExcludePaths) {
-			return nil
+			return zw.CreateHeader(header)
 		}
+		return create, zw
+	}
 
-		file, err := os.Open(src)
+	zw := aeszip.NewWriter(w)
+	create := func(name string, info os.FileInfo) (io.Writer, error) {
+		header, err := aeszip.FileInfoHeader(info)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		defer file.Close()
-
-		info, err := file.Stat()
-		if err != nil {
-			return err
+		header.Name = name
+		if !info.IsDir() {
+			header.Method = aeszip.Deflate
+			header.SetPassword(password)
 		}
+		return zw.CreateHeader(header)
+	}
+	return create, zw
+}
 
-		header, err := tar.FileInfoHeader(info, info.Name())
-		if err != nil {
-			return err
-		}
-		header.Name = filepath.Base(src)
+// zipEntryFunc 在zip中创建一个条目，返回写入文件内容的Writer
+type zipEntryFunc func(name string, info os.FileInfo) (io.Writer, error)
 
-		if err := tw.WriteHeader(header); err != nil {
-			return err
+// writeZip 将文件或目录写入zip，目录按相对路径写入并跳过排除的文件
+func writeZip(create zipEntryFunc, src string, isDir bool, options CompressOptions, p *progress) error {
+	return walkSource(src, isDir, options.filter, func(path, name string, info os.FileInfo) error {
+		if info.IsDir() {
+			name += "/"
 		}
+		return writeZipEntry(create, path, name, info, options.sums, p)
+	})
+}
 
-		_, err = io.Copy(tw, file)
+// writeZipEntry 写入一个zip条目，普通文件同时写入内容；sums 不为nil时记录文件的校验和
+func writeZipEntry(create zipEntryFunc, path, name string, info os.FileInfo, sums *checksums, p *progress) error {
+	writer, err := create(name, info)
+	if err != nil {
 		return err
 	}
-}
-
-// compressGz 创建gz压缩文件
-func compressGz(src, dst string) error {
-	if shouldExclude(src, nil) {
+	if info.IsDir() {
 		return nil
 	}
 
-	srcFile, err := os.Open(src)
+	// zip的符号链接以文件内容保存链接目标（与 zip -y 相同），不能写入链接指向的文件的内容
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(writer, target)
+		return err
+	}
+
+	p.start(name)
+	file, err := os.Open(path)
 	if err != nil {
 		return err
 	}
-	defer srcFile.Close()
+	defer file.Close()
+	_, err = io.Copy(writer, sums.record(name, p.reader(file)))
+	return err
+}
 
-	dstFile, err := os.Create(dst)
+// compressTar 创建tar.gz、tar.bz2、tar.xz、tar.zst、tar.lz4、tar.br压缩文件
+func compressTar(src string, w io.Writer, isDir bool, options CompressOptions, p *progress) error {
+	cw, err := newCompressWriter(options.Format, w, options)
 	if err != nil {
 		return err
 	}
-	defer dstFile.Close()
+	tw := tar.NewWriter(cw)
+	if err := writeTar(tw, src, isDir, options, p); err != nil {
+		cw.Close()
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		cw.Close()
+		return err
+	}
+	return cw.Close()
+}
 
-	gzw := gzip.NewWriter(dstFile)
-	defer gzw.Close()
+// newCompressWriter 返回gz、bz2、xz、zst、lz4、br（以及对应的tar格式）的压缩writer，与 newDecompressReader 对应。
+// gz和bz2使用默认压缩级别，lz4只有一种压缩级别，zst和br使用 options.Level
+func newCompressWriter(format CompressFormat, w io.Writer, options CompressOptions) (io.WriteCloser, error) {
+	switch format {
+	case GZ, TARGZ:
+		return newGzipWriter(w, options.Parallel)
+	case BZ2, TARBZ2:
+		return bzip2.NewWriter(w, nil)
+	case XZ, TARXZ:
+		return xz.NewWriter(w)
+	case ZSTD, TARZST:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstdLevel(options.Level)))
+	case LZ4, TARLZ4:
+		return newLz4Writer(w), nil
+	case BR, TARBR:
+		return brotli.NewWriterLevel(w, brotliLevel(options.Level)), nil
+	}
+	return nil, errs.InvalidInput("不支持的压缩格式: %s", format)
+}
 
-	_, err = io.Copy(gzw, srcFile)
-	return err
+// zstdLevel 将1-9的压缩级别映射为zstd的编码级别：1为最快，2-5为默认（相当于zstd -3），
+// 6-8为较高压缩率（相当于zstd -7），9为最高压缩率；0表示默认级别
+func zstdLevel(level int) zstd.EncoderLevel {
+	switch {
+	case level <= 0:
+		return zstd.SpeedDefault
+	case level == 1:
+		return zstd.SpeedFastest
+	case level <= 5:
+		return zstd.SpeedDefault
+	case level <= 8:
+		return zstd.SpeedBetterCompression
+	}
+	return zstd.SpeedBestCompression
 }
 
-// compressBz2 创建bz2压缩文件
-func compressBz2(src, dst string) error {
-	if shouldExclude(src, nil) {
-		return nil
+// brotliLevel 将1-9的压缩级别映射为brotli的0-11级：1-8不变，9为最高压缩率（brotli -q 11）；
+// 0表示默认级别（与 brotli 命令行工具的默认值不同，11级太慢，使用6级）
+func brotliLevel(level int) int {
+	switch {
+	case level <= 0:
+		return brotli.DefaultCompression
+	case level >= 9:
+		return brotli.BestCompression
 	}
+	return level
+}
 
-	srcFile, err := os.Open(src)
+// writeTar 将文件或目录写入tar，目录按相对路径写入并跳过排除的文件
+func writeTar(tw *tar.Writer, src string, isDir bool, options CompressOptions, p *progress) error {
+	// 记录已写入的有多个硬链接的文件，同一文件再次出现时写为硬链接条目
+	links := make(map[fileKey]string)
+	var dedupe *tarDedupe
+	if options.Dedupe {
+		dedupe = newTarDedupe()
+	}
+	return walkSource(src, isDir, options.filter, func(path, name string, info os.FileInfo) error {
+		return writeTarEntry(tw, path, name, info, links, dedupe, options.sums, p)
+	})
+}
+
+// writeTarEntry 写入一个tar条目，普通文件同时写入内容，符号链接记录链接目标；
+// links 不为nil时，已经写入过的文件的其他硬链接写为指向第一次出现的路径的硬链接条目；
+// dedupe 不为nil时，与已写入的文件内容相同的文件也写为硬链接条目；sums 不为nil时记录文件的校验和
+func writeTarEntry(tw *tar.Writer, path, name string, info os.FileInfo, links map[fileKey]string, dedupe *tarDedupe, sums *checksums, p *progress) error {
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		link = target
+	}
+	header, err := tar.FileInfoHeader(info, link)
 	if err != nil {
 		return err
 	}
-	defer srcFile.Close()
-
-	dstFile, err := os.Create(dst)
+	header.Name = name
+	if key, ok := hardLinkKey(info); ok && links != nil {
+		if first, seen := links[key]; seen {
+			header.Typeflag = tar.TypeLink
+			header.Linkname = first
+			header.Size = 0
+			return tw.WriteHeader(header)
+		}
+		links[key] = name
+	}
+	same, err := dedupe.find(path, info, p)
 	if err != nil {
 		return err
 	}
-	defer dstFile.Close()
+	if same != "" {
+		header.Typeflag = tar.TypeLink
+		header.Linkname = same
+		header.Size = 0
+		p.start(name)
+		p.add(info.Size())
+		return tw.WriteHeader(header)
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
 
-	bz2w, err := bzip2.NewWriter(dstFile, nil)
+	p.start(name)
+	file, err := os.Open(path)
 	if err != nil {
 		return err
 	}
-	defer bz2w.Close()
-
-	_, err = io.Copy(bz2w, srcFile)
+	defer file.Close()
+	_, err = io.Copy(tw, dedupe.record(name, info, sums.record(name, p.reader(file))))
 	return err
 }
 
-// compressXz 创建xz压缩文件
-func compressXz(src, dst string) error {
-	if shouldExclude(src, nil) {
-		return nil
-	}
-
+// compressFile 创建gz、bz2、xz、zst、lz4、br单文件压缩文件
+func compressFile(src string, w io.Writer, options CompressOptions, p *progress) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return err
 	}
 	defer srcFile.Close()
 
-	dstFile, err := os.Create(dst)
+	cw, err := newCompressWriter(options.Format, w, options)
 	if err != nil {
 		return err
 	}
-	defer dstFile.Close()
-
-	xzw, err := xz.NewWriter(dstFile)
-	if err != nil {
+	p.start(filepath.Base(src))
+	if _, err := io.Copy(cw, options.sums.record(filepath.Base(src), p.reader(srcFile))); err != nil {
+		cw.Close()
 		return err
 	}
-	defer xzw.Close()
+	return cw.Close()
+}
 
-	_, err = io.Copy(xzw, srcFile)
-	return err
+// newGzipWriter 创建gzip写入器，parallel 大于1或为负数时使用pgzip将数据分为1MB的块并行压缩，
+// 输出仍是标准的gzip格式
+func newGzipWriter(w io.Writer, parallel int) (io.WriteCloser, error) {
+	if parallel == 0 || parallel == 1 {
+		return gzip.NewWriter(w), nil
+	}
+	if parallel < 0 {
+		parallel = runtime.NumCPU()
+	}
+	pw := pgzip.NewWriter(w)
+	if err := pw.SetConcurrency(1<<20, parallel); err != nil {
+		return nil, err
+	}
+	return pw, nil
 }
 
 // compress7z 创建7z压缩文件
 func compress7z() error {
 	// 目前 go7z 库不支持写入操作
-	return fmt.Errorf("当前版本暂不支持创建7z文件（因为使用的库仅支持解压缩），请使用其他格式如 zip 或 tar.gz")
+	return i18n.Errorf("当前版本暂不支持创建7z文件（因为使用的库仅支持解压缩），请使用其他格式如 zip 或 tar.gz")
 }
 
-// decompressZip 解压zip文件
-func decompressZip(src, dst string) error {
-	reader, err := zip.OpenReader(src)
+// decompressZip 解压zip文件；options.Concurrency 大于1时多个goroutine同时解压文件内容，
+// 路径检查和创建目录仍按顺序进行
+func decompressZip(file io.ReaderAt, size int64, dst string, options DecompressOptions, p *progress) error {
+	reader, err := zip.NewReader(p.readerAt(file), size)
 	if err != nil {
 		return err
 	}
-	defer reader.Close()
 
 	// 确保目标目录存在
 	if err := os.MkdirAll(dst, 0755); err != nil {
@@ -613,13 +662,65 @@ func decompressZip(src, dst string) error {
 	if err != nil {
 		return err
 	}
+	// 目标目录的实际路径，用于检查链接目标
+	dstReal, err := filepath.EvalSymlinks(dstAbs)
+	if err != nil {
+		return err
+	}
+
+	workers := options.Concurrency
+	if workers < 0 {
+		workers = runtime.NumCPU()
+	}
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, max(workers, 1))
+		mu       sync.Mutex // 保护 firstErr，并保证不会同时调用 OnError
+		firstErr error
+		pending  = make(map[string]bool) // 已交给goroutine解压的路径
+	)
+	// 返回前等待正在解压的文件，之后才能恢复目录属性
+	defer wg.Wait()
+	extract := func(file *zip.File, path string, attrs fileAttrs) error {
+		srcFile, err := openZipEntry(file, options.Password)
+		if err == nil {
+			err = writeEntry(path, file.Mode(), options.sums.verify(file.Name, srcFile))
+			srcFile.Close()
+		}
+		if err == nil {
+			err = options.attrs.file(path, attrs)
+		}
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			return options.entryFailed(file.Name, err)
+		}
+		options.result.extracted(file.Name)
+		return nil
+	}
+	failed := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr
+	}
 
 	for _, file := range reader.File {
+		if err := failed(); err != nil {
+			break
+		}
+
 		// 清理文件路径，移除开头的 / 或 ../
 		cleanedPath := filepath.Clean(file.Name)
-		if cleanedPath == "." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+		if cleanedPath == "." {
+			continue // 压缩文件的根目录
+		}
+		if cleanedPath == ".." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+			options.result.skipped(file.Name)
 			continue // 跳过可疑路径
 		}
+		if !options.included(file.Name) {
+			continue // 不在 Include 范围内
+		}
 
 		path := filepath.Join(dst, cleanedPath)
 
@@ -631,11 +732,27 @@ func decompressZip(src, dst string) error {
 
 		// 确保解压的文件路径在目标目录内
 		if !strings.HasPrefix(pathAbs, dstAbs) {
-			return fmt.Errorf("非法的文件路径: %s", file.Name)
+			return i18n.Errorf("非法的文件路径: %s", file.Name)
 		}
 
+		p.start(file.Name)
+		attrs := fileAttrs{mode: file.Mode(), modTime: file.Modified, uid: -1}
+
+		// 与tar相同，路径可能经过之前解压出的符号链接，按实际路径检查父目录（目录则检查本身）
+		checked := filepath.Dir(pathAbs)
+		if file.FileInfo().IsDir() {
+			checked = pathAbs
+		}
+		if err := checkExtractPath(dstReal, checked); err != nil {
+			if err := options.entryFailed(file.Name, i18n.Errorf("非法的文件路径: %v", err)); err != nil {
+				return err
+			}
+			continue
+		}
 		if file.FileInfo().IsDir() {
 			os.MkdirAll(path, file.Mode())
+			options.attrs.dir(path, attrs)
+			options.result.extracted(file.Name)
 			continue
 		}
 
@@ -643,79 +760,74 @@ func decompressZip(src, dst string) error {
 			return err
 		}
 
-		dstFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-		if err != nil {
-			return err
+		if file.Mode()&os.ModeSymlink != 0 {
+			// 之后的文件可能经过这个链接，先等之前的写入完成再创建
+			wg.Wait()
+			clear(pending)
+			if err := failed(); err != nil {
+				break
+			}
+			if err := writeZipLink(file, dstReal, pathAbs, options.Password); err != nil {
+				if err := options.entryFailed(file.Name, err); err != nil {
+					return err
+				}
+				continue
+			}
+			options.result.extracted(file.Name)
+			continue
 		}
-
-		srcFile, err := file.Open()
-		if err != nil {
-			dstFile.Close()
-			return err
+		// 与tar命令一样替换已有的符号链接，而不是写入链接指向的文件
+		if existing, lerr := os.Lstat(path); lerr == nil && existing.Mode()&os.ModeSymlink != 0 {
+			os.Remove(path)
 		}
 
-		_, err = io.Copy(dstFile, srcFile)
-		srcFile.Close()
-		dstFile.Close()
-		if err != nil {
-			return err
+		if workers <= 1 {
+			if err := extract(file, path, attrs); err != nil {
+				return err
+			}
+			continue
 		}
-	}
-	return nil
-}
-
-// decompressTarGz 解压tar.gz文件
-func decompressTarGz(src, dst string) error {
-	file, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
 
-	gzr, err := gzip.NewReader(file)
-	if err != nil {
-		return err
-	}
-	defer gzr.Close()
-
-	return decompressTar(gzr, dst)
-}
-
-// decompressTarBz2 解压tar.bz2文件
-func decompressTarBz2(src, dst string) error {
-	file, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	bz2r, err := bzip2.NewReader(file, nil)
-	if err != nil {
-		return err
+		// 同一路径在zip中出现多次时，等之前的写入完成，后面的覆盖前面的
+		if pending[path] {
+			wg.Wait()
+			clear(pending)
+		}
+		pending[path] = true
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(file *zip.File, path string, attrs fileAttrs) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := extract(file, path, attrs); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(file, path, attrs)
 	}
-	defer bz2r.Close()
-
-	return decompressTar(bz2r, dst)
+	wg.Wait()
+	return failed()
 }
 
-// decompressTarXz 解压tar.xz文件
-func decompressTarXz(src, dst string) error {
-	file, err := os.Open(src)
+// writeZipLink 在name处创建zip中的符号链接，zip以文件内容保存链接目标，目标必须在dstReal内
+func writeZipLink(file *zip.File, dstReal, name, password string) error {
+	r, err := openZipEntry(file, password)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	xzr, err := xz.NewReader(file)
+	defer r.Close()
+	target, err := symlinkTarget(ArchiveEntry{Mode: file.Mode()}, func() (io.Reader, error) { return r, nil })
 	if err != nil {
 		return err
 	}
-
-	return decompressTar(xzr, dst)
+	return writeLink(dstReal, name, &tar.Header{Typeflag: tar.TypeSymlink, Linkname: target})
 }
 
 // decompressTar 解压tar文件
-func decompressTar(reader io.Reader, dst string) error {
+func decompressTar(reader io.Reader, dst string, options DecompressOptions, p *progress) error {
 	tr := tar.NewReader(reader)
 
 	// 确保目标目录存在
@@ -728,6 +840,11 @@ func decompressTar(reader io.Reader, dst string) error {
 	if err != nil {
 		return err
 	}
+	// 目标目录的实际路径，用于检查链接目标
+	dstReal, err := filepath.EvalSymlinks(dstAbs)
+	if err != nil {
+		return err
+	}
 
 	for {
 		header, err := tr.Next()
@@ -740,9 +857,16 @@ func decompressTar(reader io.Reader, dst string) error {
 
 		// 清理文件路径，移除开头的 / 或 ../
 		cleanedPath := filepath.Clean(header.Name)
-		if cleanedPath == "." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+		if cleanedPath == "." {
+			continue // 压缩文件的根目录
+		}
+		if cleanedPath == ".." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+			options.result.skipped(header.Name)
 			continue // 跳过可疑路径
 		}
+		if !options.included(header.Name) {
+			continue // 不在 Include 范围内
+		}
 
 		path := filepath.Join(dst, cleanedPath)
 
@@ -754,14 +878,30 @@ func decompressTar(reader io.Reader, dst string) error {
 
 		// 确保解压的文件路径在目标目录内
 		if !strings.HasPrefix(pathAbs, dstAbs) {
-			return fmt.Errorf("非法的文件路径: %s", header.Name)
+			return i18n.Errorf("非法的文件路径: %s", header.Name)
 		}
 
+		p.start(header.Name)
 		info := header.FileInfo()
+		attrs := fileAttrs{mode: info.Mode(), modTime: header.ModTime, uid: header.Uid, gid: header.Gid}
+
+		// 路径可能经过之前解压出的符号链接，创建目录和写入时会跟随链接，按实际路径检查父目录（目录则检查本身）
+		checked := filepath.Dir(pathAbs)
+		if info.IsDir() {
+			checked = pathAbs
+		}
+		if err := checkExtractPath(dstReal, checked); err != nil {
+			if err := options.entryFailed(header.Name, i18n.Errorf("非法的文件路径: %v", err)); err != nil {
+				return err
+			}
+			continue
+		}
 		if info.IsDir() {
 			if err = os.MkdirAll(path, info.Mode()); err != nil {
 				return err
 			}
+			options.attrs.dir(path, attrs)
+			options.result.extracted(header.Name)
 			continue
 		}
 
@@ -769,104 +909,59 @@ func decompressTar(reader io.Reader, dst string) error {
 			return err
 		}
 
-		file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
-		if err != nil {
-			return err
+		switch header.Typeflag {
+		case tar.TypeSymlink, tar.TypeLink:
+			err = writeLink(dstReal, pathAbs, header)
+		default:
+			// 与tar命令一样替换已有的符号链接，而不是写入链接指向的文件
+			if existing, lerr := os.Lstat(path); lerr == nil && existing.Mode()&os.ModeSymlink != 0 {
+				os.Remove(path)
+			}
+			err = writeEntry(path, info.Mode(), options.sums.verify(header.Name, tr))
+		}
+		// 硬链接与目标是同一个文件，不需要再次恢复属性
+		if err == nil && header.Typeflag != tar.TypeLink {
+			err = options.attrs.file(path, attrs)
 		}
-		defer file.Close()
-
-		_, err = io.Copy(file, tr)
 		if err != nil {
-			return err
+			if err := options.entryFailed(header.Name, err); err != nil {
+				return err
+			}
+			continue
 		}
+		options.result.extracted(header.Name)
 	}
 	return nil
 }
 
-// decompressGz 解压gz文件
-func decompressGz(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer srcFile.Close()
-
-	gzr, err := gzip.NewReader(srcFile)
-	if err != nil {
-		return err
-	}
-	defer gzr.Close()
-
-	dstFile, err := os.Create(dst)
-	if err != nil {
-		return err
-	}
-	defer dstFile.Close()
-
-	_, err = io.Copy(dstFile, gzr)
-	return err
-}
-
-// decompressBz2 解压bz2文件
-func decompressBz2(src, dst string) error {
-	srcFile, err := os.Open(src)
+// decompressFile 解压gz、bz2、xz、zst、lz4、br单文件格式，dst为解压后的文件；
+// 解压后的文件名可能与压缩前不同，按清单中唯一的文件检查校验和
+func decompressFile(r io.Reader, dst string, options DecompressOptions, p *progress) error {
+	zr, err := newDecompressReader(options.Format, p.reader(r))
 	if err != nil {
 		return err
 	}
-	defer srcFile.Close()
+	defer zr.Close()
 
-	bz2r, err := bzip2.NewReader(srcFile, nil)
-	if err != nil {
-		return err
+	p.start(filepath.Base(dst))
+	var content io.Reader = zr
+	if options.sums != nil {
+		content = options.sums.verify(options.sums.single(), zr)
 	}
-	defer bz2r.Close()
-
-	dstFile, err := os.Create(dst)
-	if err != nil {
+	if err := writeEntry(dst, 0666, content); err != nil {
 		return err
 	}
-	defer dstFile.Close()
-
-	_, err = io.Copy(dstFile, bz2r)
-	return err
-}
-
-// decompressXz 解压xz文件
-func decompressXz(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer srcFile.Close()
-
-	xzr, err := xz.NewReader(srcFile)
-	if err != nil {
-		return err
-	}
-
-	dstFile, err := os.Create(dst)
-	if err != nil {
-		return err
-	}
-	defer dstFile.Close()
-
-	_, err = io.Copy(dstFile, xzr)
-	return err
+	options.result.extracted(filepath.Base(dst))
+	return nil
 }
 
 // decompressRar 解压rar文件
-func decompressRar(src, dst string) error {
-	// 打开RAR文件
-	rfile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer rfile.Close()
-
+func decompressRar(r io.Reader, dst string, options DecompressOptions, p *progress) error {
 	// 创建RAR解压器
-	rr, err := rardecode.NewReader(rfile, "")
+	rr, err := rardecode.NewReader(p.reader(r), options.Password)
 	if err != nil {
-		return fmt.Errorf("无法读取RAR文件: %v", err)
+		err = rarPasswordError(err)
+		return errs.Wrap(err, "无法读取RAR文件: %v", err)
 	}
 
 	// 获取目标目录的绝对路径
@@ -882,14 +977,21 @@ func decompressRar(src, dst string) error {
 			break
 		}
 		if err != nil {
-			return err
+			return rarPasswordError(err)
 		}
 
 		// 清理文件路径，移除开头的 / 或 ../
 		cleanedPath := filepath.Clean(header.Name)
-		if cleanedPath == "." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+		if cleanedPath == "." {
+			continue // 压缩文件的根目录
+		}
+		if cleanedPath == ".." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+			options.result.skipped(header.Name)
 			continue // 跳过可疑路径
 		}
+		if !options.included(header.Name) {
+			continue // 不在 Include 范围内
+		}
 
 		path := filepath.Join(dst, cleanedPath)
 
@@ -901,13 +1003,17 @@ func decompressRar(src, dst string) error {
 
 		// 确保解压的文件路径在目标目录内
 		if !strings.HasPrefix(pathAbs, dstAbs) {
-			return fmt.Errorf("非法的文件路径: %s", header.Name)
+			return i18n.Errorf("非法的文件路径: %s", header.Name)
 		}
 
+		p.start(header.Name)
+		attrs := fileAttrs{mode: header.Mode(), modTime: header.ModificationTime, uid: -1}
 		if header.IsDir {
 			if err = os.MkdirAll(path, 0755); err != nil {
 				return err
 			}
+			options.attrs.dir(path, attrs)
+			options.result.extracted(header.Name)
 			continue
 		}
 
@@ -916,31 +1022,38 @@ func decompressRar(src, dst string) error {
 			return err
 		}
 
-		// 创建文件
-		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-		if err != nil {
-			return err
+		// 写入文件内容
+		if err = writeEntry(path, 0644, options.sums.verify(header.Name, rr)); err == nil {
+			err = options.attrs.file(path, attrs)
 		}
-
-		// 复制文件内容
-		_, err = io.Copy(file, rr)
-		file.Close()
 		if err != nil {
-			return err
+			if err := options.entryFailed(header.Name, rarPasswordError(err)); err != nil {
+				return err
+			}
+			continue
 		}
+		options.result.extracted(header.Name)
 	}
 
 	return nil
 }
 
+// rarPasswordError 将 rardecode 的密码错误转换为 ErrWrongPassword，该库没有导出这个错误
+func rarPasswordError(err error) error {
+	if err != nil && strings.HasSuffix(err.Error(), "incorrect password") {
+		return ErrWrongPassword
+	}
+	return err
+}
+
 // decompress7z 解压7z文件
-func decompress7z(src, dst string) error {
-	// 打开源文件
-	sz, err := go7z.OpenReader(src)
+func decompress7z(file io.ReaderAt, size int64, dst string, options DecompressOptions, p *progress) error {
+	// go7z 只能在读取文件列表之后设置密码，因此不支持文件列表也加密（7z -mhe=on）的文件
+	sz, err := go7z.NewReader(p.readerAt(file), size)
 	if err != nil {
-		return fmt.Errorf("无法读取7z文件: %v", err)
+		return i18n.Errorf("无法读取7z文件: %v", err)
 	}
-	defer sz.Close()
+	sz.Options.SetPassword(options.Password)
 
 	// 获取目标目录的绝对路径
 	dstAbs, err := filepath.Abs(dst)
@@ -960,9 +1073,16 @@ func decompress7z(src, dst string) error {
 
 		// 清理文件路径，移除开头的 / 或 ../
 		cleanedPath := filepath.Clean(hdr.Name)
-		if cleanedPath == "." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+		if cleanedPath == "." {
+			continue // 压缩文件的根目录
+		}
+		if cleanedPath == ".." || strings.HasPrefix(cleanedPath, ".."+string(os.PathSeparator)) {
+			options.result.skipped(hdr.Name)
 			continue // 跳过可疑路径
 		}
+		if !options.included(hdr.Name) {
+			continue // 不在 Include 范围内
+		}
 
 		path := filepath.Join(dst, cleanedPath)
 
@@ -974,14 +1094,19 @@ func decompress7z(src, dst string) error {
 
 		// 确保解压的文件路径在目标目录内
 		if !strings.HasPrefix(pathAbs, dstAbs) {
-			return fmt.Errorf("非法的文件路径: %s", hdr.Name)
+			return i18n.Errorf("非法的文件路径: %s", hdr.Name)
 		}
 
+		p.start(hdr.Name)
+		attrs := fileAttrs{mode: sevenZipMode(hdr.Attrib), modTime: hdr.ModifiedAt, uid: -1}
+
 		// 如果是目录
-		if strings.HasSuffix(hdr.Name, "/") {
+		if sevenZipIsDir(hdr) {
 			if err := os.MkdirAll(path, 0755); err != nil {
 				return err
 			}
+			options.attrs.dir(path, attrs)
+			options.result.extracted(hdr.Name)
 			continue
 		}
 
@@ -990,18 +1115,18 @@ func decompress7z(src, dst string) error {
 			return err
 		}
 
-		// 创建文件
-		outFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-		if err != nil {
-			return err
+		// 写入文件内容；7z的AES加密没有密码校验值，密码错误时表现为数据损坏
+		err = writeEntry(path, 0644, options.sums.verify(hdr.Name, sz))
+		if err == nil {
+			err = options.attrs.file(path, attrs)
 		}
-
-		// 复制内容
-		_, err = io.Copy(outFile, sz)
-		outFile.Close()
 		if err != nil {
-			return err
+			if err := options.entryFailed(hdr.Name, err); err != nil {
+				return err
+			}
+			continue
 		}
+		options.result.extracted(hdr.Name)
 	}
 
 	return nil
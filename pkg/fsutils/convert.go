@@ -0,0 +1,292 @@
+package fsutils
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// ConvertArchive 将压缩文件转换为另一种格式（如 zip 转为 tar.zst），逐个读取源压缩文件中的文件直接写入目标压缩文件，
+// 不解压到磁盘，保留路径、权限、修改时间和符号链接。
+//
+// from 是读取源压缩文件的选项，使用 Format、Password、Include 和 Progress（按读取源压缩文件的进度）；
+// to 是写入目标压缩文件的选项，Format 为空时根据dst的扩展名判断，使用 Level、Parallel 和 Password（仅zip）。
+// 目标为单文件格式（gz、bz2等）时源压缩文件中只能有一个文件；zip不支持硬链接。
+// 写入tar时需要预先知道文件大小，7z等没有记录解压后大小的格式中的文件先写入临时文件。
+// 转换失败时删除未完成的目标压缩文件
+func ConvertArchive(src, dst string, from DecompressOptions, to CompressOptions) error {
+	return ConvertArchiveContext(context.Background(), src, dst, from, to)
+}
+
+// ConvertArchiveContext 与 ConvertArchive 相同，ctx取消时在读取下一块数据前停止并返回ctx的错误
+func ConvertArchiveContext(ctx context.Context, src, dst string, from DecompressOptions, to CompressOptions) (err error) {
+	if to.Format == "" {
+		format, ok := formatFromName(dst)
+		if !ok {
+			return errs.InvalidInput("无法从文件扩展名识别目标压缩格式: %s", path.Base(dst))
+		}
+		to.Format = format
+	}
+	if err := checkCompressFormat(to, false); err != nil {
+		return err
+	}
+	if to.VolumeSize > 0 {
+		return errs.InvalidInput("转换压缩文件时不支持分卷")
+	}
+	if to.Dedupe {
+		return errs.InvalidInput("转换压缩文件时不支持去重")
+	}
+	// 创建目标文件会清空它，不能覆盖正在读取的源压缩文件
+	if srcInfo, err := os.Stat(src); err == nil {
+		if dstInfo, err := os.Stat(dst); err == nil && os.SameFile(srcInfo, dstInfo) {
+			return errs.InvalidInput("目标压缩文件不能与源压缩文件相同")
+		}
+	}
+	if from.include, err = newIncludeFilter(from.Include); err != nil {
+		return err
+	}
+
+	file, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(dst)
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+		}
+	}()
+
+	aw, err := newArchiveWriter(file, to)
+	if err != nil {
+		return err
+	}
+	err = walkArchive(ctx, src, from, func(entry ArchiveEntry, open entryOpener) error {
+		if !from.included(entry.Name) {
+			return nil
+		}
+		if err := aw.add(entry, open); err != nil {
+			return &EntryError{Name: entry.Name, Err: err}
+		}
+		return nil
+	})
+	if closeErr := aw.close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = from.include.unmatched()
+	}
+	return err
+}
+
+// archiveWriter 向压缩文件中逐个写入条目
+type archiveWriter interface {
+	add(entry ArchiveEntry, open entryOpener) error
+	close() error
+}
+
+// newArchiveWriter 按 options.Format 创建向w写入的archiveWriter
+func newArchiveWriter(w io.Writer, options CompressOptions) (archiveWriter, error) {
+	switch options.Format {
+	case ZIP:
+		create, closer := newZipWriter(w, options.Password)
+		return &zipArchiveWriter{create: create, closer: closer}, nil
+	case TARGZ, TARBZ2, TARXZ, TARZST, TARLZ4, TARBR:
+		cw, err := newCompressWriter(options.Format, w, options)
+		if err != nil {
+			return nil, err
+		}
+		return &tarArchiveWriter{cw: cw, tw: tar.NewWriter(cw)}, nil
+	}
+	cw, err := newCompressWriter(options.Format, w, options)
+	if err != nil {
+		return nil, err
+	}
+	return &singleArchiveWriter{format: options.Format, cw: cw}, nil
+}
+
+type zipArchiveWriter struct {
+	create zipEntryFunc
+	closer io.Closer
+}
+
+func (zw *zipArchiveWriter) add(entry ArchiveEntry, open entryOpener) error {
+	if entry.IsHardLink {
+		return errs.InvalidInput("zip格式不支持硬链接（链接到 %s），请转换为tar格式", entry.Linkname)
+	}
+	name := entry.Name
+	if entry.IsDir && !strings.HasSuffix(name, "/") {
+		name += "/"
+	}
+	w, err := zw.create(name, entryInfo{entry})
+	if err != nil || entry.IsDir {
+		return err
+	}
+
+	// zip的符号链接以文件内容保存目标
+	if entry.Mode&os.ModeSymlink != 0 {
+		target, err := symlinkTarget(entry, open)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, target)
+		return err
+	}
+	r, err := open()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func (zw *zipArchiveWriter) close() error {
+	return zw.closer.Close()
+}
+
+type tarArchiveWriter struct {
+	cw io.WriteCloser
+	tw *tar.Writer
+}
+
+func (tw *tarArchiveWriter) add(entry ArchiveEntry, open entryOpener) error {
+	var link string
+	if entry.Mode&os.ModeSymlink != 0 {
+		var err error
+		if link, err = symlinkTarget(entry, open); err != nil {
+			return err
+		}
+	}
+	header, err := tar.FileInfoHeader(entryInfo{entry}, link)
+	if err != nil {
+		return err
+	}
+	header.Name = entry.Name
+	if entry.IsDir && !strings.HasSuffix(header.Name, "/") {
+		header.Name += "/"
+	}
+	if entry.IsHardLink {
+		header.Typeflag = tar.TypeLink
+		header.Linkname = entry.Linkname
+		header.Size = 0
+	}
+	if header.Typeflag != tar.TypeReg {
+		return tw.tw.WriteHeader(header)
+	}
+
+	r, err := open()
+	if err != nil {
+		return err
+	}
+	// tar的文件头中要写入大小，大小未知时先写入临时文件
+	if entry.Size < 0 {
+		file, size, err := spoolTemp(r)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(file.Name())
+		defer file.Close()
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		header.Size, r = size, file
+	}
+	if err := tw.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw.tw, r)
+	return err
+}
+
+func (tw *tarArchiveWriter) close() error {
+	if err := tw.tw.Close(); err != nil {
+		tw.cw.Close()
+		return err
+	}
+	return tw.cw.Close()
+}
+
+// singleArchiveWriter 写入gz、bz2等单文件格式，只能写入一个文件
+type singleArchiveWriter struct {
+	format  CompressFormat
+	cw      io.WriteCloser
+	written bool
+}
+
+func (sw *singleArchiveWriter) add(entry ArchiveEntry, open entryOpener) error {
+	if entry.IsDir {
+		return nil
+	}
+	if sw.written {
+		return errs.InvalidInput("%s 格式只能包含一个文件，源压缩文件中有多个文件", sw.format)
+	}
+	sw.written = true
+	r, err := open()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(sw.cw, r)
+	return err
+}
+
+func (sw *singleArchiveWriter) close() error {
+	return sw.cw.Close()
+}
+
+// maxLinkTarget 符号链接目标的最大长度，与Linux的 PATH_MAX 相同
+const maxLinkTarget = 4096
+
+// symlinkTarget 返回符号链接的目标，zip、7z等格式以文件内容保存目标。
+// 内容过长或含有NUL字符时不是有效的链接目标（例如错误地保存了链接指向的文件的内容），返回错误
+func symlinkTarget(entry ArchiveEntry, open entryOpener) (string, error) {
+	target := entry.Linkname
+	if target == "" {
+		r, err := open()
+		if err != nil {
+			return "", err
+		}
+		content, err := io.ReadAll(io.LimitReader(r, maxLinkTarget+1))
+		if err != nil {
+			return "", err
+		}
+		target = string(content)
+	}
+	switch {
+	case target == "":
+		return "", i18n.Errorf("符号链接没有目标")
+	case len(target) > maxLinkTarget:
+		return "", i18n.Errorf("符号链接的目标超过 %d 字节，不是有效的链接", maxLinkTarget)
+	case strings.ContainsRune(target, 0):
+		return "", i18n.Errorf("符号链接的目标含有NUL字符，不是有效的链接")
+	}
+	return target, nil
+}
+
+// entryInfo 将 ArchiveEntry 包装为 os.FileInfo，用于创建zip和tar的文件头
+type entryInfo struct {
+	entry ArchiveEntry
+}
+
+func (i entryInfo) Name() string       { return path.Base(i.entry.Name) }
+func (i entryInfo) Size() int64        { return i.entry.Size }
+func (i entryInfo) ModTime() time.Time { return i.entry.ModTime }
+func (i entryInfo) IsDir() bool        { return i.entry.IsDir }
+func (i entryInfo) Sys() interface{}   { return nil }
+
+func (i entryInfo) Mode() os.FileMode {
+	if i.entry.IsDir {
+		return i.entry.Mode | os.ModeDir
+	}
+	return i.entry.Mode
+}
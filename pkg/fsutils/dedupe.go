@@ -0,0 +1,58 @@
+package fsutils
+
+import (
+	"crypto/sha256"
+	"io"
+	"os"
+)
+
+// tarDedupe 压缩tar时识别内容相同的文件。已写入的文件按大小分组，
+// 只有与已写入的文件大小相同时才预先计算校验和比较，大多数文件只在写入时计算一次
+type tarDedupe struct {
+	written map[int64][]dedupeEntry // 文件大小 → 已写入的文件
+}
+
+// dedupeEntry 已写入tar的普通文件
+type dedupeEntry struct {
+	name string // 在tar中的路径
+	mode os.FileMode
+	sum  string
+}
+
+func newTarDedupe() *tarDedupe {
+	return &tarDedupe{written: make(map[int64][]dedupeEntry)}
+}
+
+// find 返回与path内容和权限都相同的已写入文件在tar中的路径，没有时返回空字符串。
+// 硬链接解压后共享权限，权限不同的文件不合并；空文件写为硬链接不会更小，不查找；d为nil时不查找
+func (d *tarDedupe) find(path string, info os.FileInfo, p *progress) (string, error) {
+	if d == nil || !info.Mode().IsRegular() || info.Size() == 0 {
+		return "", nil
+	}
+	candidates := d.written[info.Size()]
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	sum, err := fileSum(path, p)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range candidates {
+		if c.sum == sum && c.mode == info.Mode() {
+			return c.name, nil
+		}
+	}
+	return "", nil
+}
+
+// record 返回读取r的同时计算校验和的reader，读完后将文件记录为已写入；d为nil时直接返回r。
+// 校验和按实际写入的内容计算，压缩过程中被修改的文件不会与之后的文件错误地合并
+func (d *tarDedupe) record(name string, info os.FileInfo, r io.Reader) io.Reader {
+	if d == nil || info.Size() == 0 {
+		return r
+	}
+	return &hashReader{r: r, h: sha256.New(), done: func(sum string) error {
+		d.written[info.Size()] = append(d.written[info.Size()], dedupeEntry{name: name, mode: info.Mode(), sum: sum})
+		return nil
+	}}
+}
@@ -0,0 +1,138 @@
+package fsutils
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"toolbox/pkg/errs"
+)
+
+// sniffSize 从数据流判断格式时预读的大小；bzip2至少要读完第一个数据块（最大900KB）才能解压出tar头
+const sniffSize = 1 << 20
+
+// 各格式文件开头的特征字节（magic number）
+var (
+	zipMagic      = []byte("PK\x03\x04")
+	zipEmptyMagic = []byte("PK\x05\x06") // 没有文件的zip只有目录结束记录
+	sevenZipMagic = []byte{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C}
+	rarMagic      = []byte("Rar!\x1a\x07") // RAR 4 和 RAR 5 相同的前缀
+	gzipMagic     = []byte{0x1F, 0x8B}
+	bzip2Magic    = []byte("BZh")
+	xzMagic       = []byte{0xFD, '7', 'z', 'X', 'Z', 0x00}
+	zstdMagic     = []byte{0x28, 0xB5, 0x2F, 0xFD}
+	lz4Magic      = []byte{0x04, 0x22, 0x4D, 0x18}
+)
+
+// tarFormats 单文件格式对应的tar格式
+var tarFormats = map[CompressFormat]CompressFormat{
+	GZ:   TARGZ,
+	BZ2:  TARBZ2,
+	XZ:   TARXZ,
+	ZSTD: TARZST,
+	LZ4:  TARLZ4,
+	BR:   TARBR,
+}
+
+// DetectFormat 判断压缩文件的格式：先根据文件开头的特征字节识别zip、gzip、bzip2、xz、zst、lz4、7z、rar，
+// gzip等格式再解压开头的一块数据判断是否为tar；内容无法识别时根据扩展名判断，
+// brotli没有特征字节，只能根据扩展名（.br、.tar.br）识别。
+// 扩展名与内容是同一种压缩算法时（如 .tgz 和gzip）以扩展名为准
+func DetectFormat(path string) (CompressFormat, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errs.Wrap(err, "无法访问压缩文件: %v", err)
+	}
+	defer file.Close()
+	return detectFormat(path, file)
+}
+
+// detectFormat 根据r开头的数据和文件名判断格式
+func detectFormat(name string, r io.Reader) (CompressFormat, error) {
+	byName, nameOK := formatFromName(name)
+	format, ok := sniffFormat(r)
+	switch {
+	case ok && nameOK && singleFormat(format) == singleFormat(byName):
+		return byName, nil
+	case ok:
+		return format, nil
+	case nameOK:
+		return byName, nil
+	}
+	return "", errs.InvalidInput("无法识别的压缩格式: %s", filepath.Base(name))
+}
+
+// sniffFormat 根据r开头的数据判断格式，r应从压缩数据的开头读取
+func sniffFormat(r io.Reader) (CompressFormat, bool) {
+	header := make([]byte, 8)
+	n, _ := io.ReadFull(r, header)
+	header = header[:n]
+
+	var format CompressFormat
+	switch {
+	case bytes.HasPrefix(header, zipMagic), bytes.HasPrefix(header, zipEmptyMagic),
+		bytes.HasPrefix(header, splitZipMagic), bytes.HasPrefix(header, unsplitZipMagic):
+		return ZIP, true
+	case bytes.HasPrefix(header, sevenZipMagic):
+		return SEVENZIP, true
+	case bytes.HasPrefix(header, rarMagic):
+		return RAR, true
+	case bytes.HasPrefix(header, gzipMagic):
+		format = GZ
+	case bytes.HasPrefix(header, bzip2Magic):
+		format = BZ2
+	case bytes.HasPrefix(header, xzMagic):
+		format = XZ
+	case bytes.HasPrefix(header, zstdMagic):
+		format = ZSTD
+	case bytes.HasPrefix(header, lz4Magic):
+		format = LZ4
+	default:
+		return "", false
+	}
+
+	// 解压出第一个512字节的块，是tar头时为对应的tar格式
+	zr, err := newDecompressReader(format, io.MultiReader(bytes.NewReader(header), r))
+	if err != nil {
+		return format, true
+	}
+	defer zr.Close()
+	block := make([]byte, 512)
+	if _, err := io.ReadFull(zr, block); err == nil && isTarHeader(block) {
+		return tarFormats[format], true
+	}
+	return format, true
+}
+
+// singleFormat 返回tar格式对应的单文件格式，即所用的压缩算法；其他格式原样返回
+func singleFormat(format CompressFormat) CompressFormat {
+	for single, tar := range tarFormats {
+		if format == tar {
+			return single
+		}
+	}
+	return format
+}
+
+// isTarHeader 判断是否为tar文件头：POSIX和GNU格式在257处有 ustar 标记，
+// 更早的v7格式没有标记，检查头部的校验和
+func isTarHeader(block []byte) bool {
+	if len(block) < 512 {
+		return false
+	}
+	if bytes.HasPrefix(block[257:], []byte("ustar")) {
+		return true
+	}
+	// 校验和是头部所有字节之和，计算时校验和字段本身按8个空格计算
+	sum := 0
+	for i, c := range block[:512] {
+		if i >= 148 && i < 156 {
+			c = ' '
+		}
+		sum += int(c)
+	}
+	stored, err := strconv.ParseInt(strings.Trim(string(block[148:156]), " \x00"), 8, 64)
+	return err == nil && stored == int64(sum)
+}
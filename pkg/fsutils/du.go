@@ -0,0 +1,249 @@
+package fsutils
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"toolbox/pkg/errs"
+)
+
+// UsageOptions 磁盘占用分析的选项
+type UsageOptions struct {
+	Top      int      // 报告最大的目录和文件各多少个，0表示10个
+	MaxDepth int      // 只报告深度不超过该值的目录（根目录的子目录深度为1），0表示不限制；大小仍包括更深的文件
+	Exclude  []string // 排除匹配的文件和目录，语法与 CompressOptions.ExcludePatterns 相同
+	Apparent bool     // 按文件大小统计，默认按实际占用的磁盘空间（与 du 相同）
+	Threads  int      // 同时读取的目录数量，0表示CPU核心数的2倍
+
+	OnError func(path string, err error) // 无法读取的目录或文件，为nil时忽略；并行读取时也按顺序调用
+}
+
+// UsageEntry 一个目录或文件的占用
+type UsageEntry struct {
+	Path  string `json:"path"`
+	Size  int64  `json:"size"`
+	Files int    `json:"files,omitempty"` // 目录中（包括子目录中）的文件数量
+	Dirs  int    `json:"dirs,omitempty"`  // 目录中（包括子目录中）的目录数量
+}
+
+// UsageReport 磁盘占用分析的结果
+type UsageReport struct {
+	Root     string       `json:"root"`
+	Size     int64        `json:"size"`
+	Files    int          `json:"files"`
+	Dirs     int          `json:"dirs"`
+	Errors   int          `json:"errors"`    // 无法读取的目录和文件的数量
+	TopDirs  []UsageEntry `json:"top_dirs"`  // 按大小从大到小排序，不包括根目录
+	TopFiles []UsageEntry `json:"top_files"` // 按大小从大到小排序
+}
+
+// AnalyzeUsage 统计root下每个目录累计的大小和文件数量，返回总计以及最大的目录和文件。
+// 多个目录并行读取；不跟随符号链接（只计算链接本身），同一文件的多个硬链接只计算一次
+func AnalyzeUsage(root string, options UsageOptions) (*UsageReport, error) {
+	return AnalyzeUsageContext(context.Background(), root, options)
+}
+
+// AnalyzeUsageContext 与 AnalyzeUsage 相同，ctx取消时停止读取并返回ctx的错误
+func AnalyzeUsageContext(ctx context.Context, root string, options UsageOptions) (*UsageReport, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, errs.Wrap(err, "无法访问目录 %s: %v", root, err)
+	}
+	if !info.IsDir() {
+		return nil, errs.InvalidInput("%s 不是一个目录", root)
+	}
+	if options.Top <= 0 {
+		options.Top = 10
+	}
+	if options.Threads <= 0 {
+		options.Threads = runtime.NumCPU() * 2
+	}
+
+	a := &usageAnalyzer{
+		ctx:     ctx,
+		options: options,
+		sem:     make(chan struct{}, options.Threads),
+		linked:  make(map[fileKey]bool),
+		dirs:    topEntries{n: options.Top},
+		files:   topEntries{n: options.Top},
+	}
+	for _, pattern := range options.Exclude {
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		a.exclude = append(a.exclude, glob)
+	}
+
+	total := a.walk(root, "", 0, info)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &UsageReport{
+		Root:     root,
+		Size:     total.Size,
+		Files:    total.Files,
+		Dirs:     total.Dirs,
+		Errors:   a.errors,
+		TopDirs:  a.dirs.sorted(),
+		TopFiles: a.files.sorted(),
+	}, nil
+}
+
+// usageAnalyzer 分析时的状态，mu 保护 linked、dirs、files 和 errors
+type usageAnalyzer struct {
+	ctx     context.Context
+	options UsageOptions
+	exclude []string
+	sem     chan struct{} // 限制同时读取的目录数量
+
+	mu     sync.Mutex
+	linked map[fileKey]bool
+	dirs   topEntries
+	files  topEntries
+	errors int
+}
+
+// walk 统计目录中所有文件的大小，rel 为相对于根目录的路径；有空闲的线程时子目录在新的goroutine中读取。
+// 按占用的磁盘空间统计时与 du 相同，也计算目录本身占用的空间
+func (a *usageAnalyzer) walk(dir, rel string, depth int, info os.FileInfo) UsageEntry {
+	total := UsageEntry{Path: dir}
+	if !a.options.Apparent {
+		total.Size = allocatedSize(info)
+	}
+	if a.ctx.Err() != nil {
+		return total
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		a.fail(dir, err)
+		return total
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex // 保护 total
+	)
+	addDir := func(sub UsageEntry) {
+		mu.Lock()
+		total.Size += sub.Size
+		total.Files += sub.Files
+		total.Dirs += sub.Dirs + 1
+		mu.Unlock()
+	}
+	for _, entry := range entries {
+		name := path.Join(rel, entry.Name())
+		if a.excluded(name) {
+			continue
+		}
+		full := filepath.Join(dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			a.fail(full, err)
+			continue
+		}
+
+		if entry.IsDir() {
+			select {
+			case a.sem <- struct{}{}:
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-a.sem }()
+					addDir(a.walk(full, name, depth+1, info))
+				}()
+			default:
+				// 没有空闲的线程时在当前goroutine中读取，避免所有线程都在等待子目录
+				addDir(a.walk(full, name, depth+1, info))
+			}
+			continue
+		}
+
+		size := a.fileSize(info)
+		mu.Lock()
+		total.Size += size
+		total.Files++
+		mu.Unlock()
+		a.mu.Lock()
+		a.files.add(UsageEntry{Path: full, Size: size})
+		a.mu.Unlock()
+	}
+	wg.Wait()
+
+	if depth > 0 && (a.options.MaxDepth == 0 || depth <= a.options.MaxDepth) {
+		a.mu.Lock()
+		a.dirs.add(total)
+		a.mu.Unlock()
+	}
+	return total
+}
+
+// fileSize 返回文件计入的大小，同一文件的其他硬链接已经计算过时为0
+func (a *usageAnalyzer) fileSize(info os.FileInfo) int64 {
+	if key, ok := hardLinkKey(info); ok {
+		a.mu.Lock()
+		seen := a.linked[key]
+		a.linked[key] = true
+		a.mu.Unlock()
+		if seen {
+			return 0
+		}
+	}
+	if a.options.Apparent {
+		return info.Size()
+	}
+	return allocatedSize(info)
+}
+
+// excluded 是否排除相对路径为name的文件或目录
+func (a *usageAnalyzer) excluded(name string) bool {
+	for _, glob := range a.exclude {
+		if matchGlob(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *usageAnalyzer) fail(path string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.errors++
+	if a.options.OnError != nil {
+		a.options.OnError(path, err)
+	}
+}
+
+// topEntries 保留最大的n项：先追加，超过2n项时排序并截断
+type topEntries struct {
+	n     int
+	items []UsageEntry
+}
+
+func (t *topEntries) add(entry UsageEntry) {
+	t.items = append(t.items, entry)
+	if len(t.items) >= 2*t.n {
+		t.items = t.sorted()
+	}
+}
+
+// sorted 返回按大小从大到小（相同时按路径）排序的最大的n项
+func (t *topEntries) sorted() []UsageEntry {
+	sort.Slice(t.items, func(i, j int) bool {
+		if t.items[i].Size != t.items[j].Size {
+			return t.items[i].Size > t.items[j].Size
+		}
+		return t.items[i].Path < t.items[j].Path
+	})
+	if len(t.items) > t.n {
+		t.items = t.items[:t.n]
+	}
+	if t.items == nil {
+		return []UsageEntry{}
+	}
+	return t.items
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import (
+	"os"
+	"syscall"
+)
+
+// allocatedSize 返回文件实际占用的磁盘空间（按512字节的块计算），稀疏文件可能小于文件大小
+func allocatedSize(info os.FileInfo) int64 {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return int64(st.Blocks) * 512
+	}
+	return info.Size()
+}
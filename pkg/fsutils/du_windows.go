@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package fsutils
+
+import "os"
+
+// allocatedSize Windows上的 os.FileInfo 不包含占用的块数，使用文件大小
+func allocatedSize(info os.FileInfo) int64 {
+	return info.Size()
+}
@@ -0,0 +1,168 @@
+package fsutils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"toolbox/pkg/i18n"
+)
+
+// partialHashSize 预筛选时读取文件开头的字节数
+const partialHashSize = 4 << 10
+
+// DuplicateSet 一组内容相同的文件
+type DuplicateSet struct {
+	Size  int64    // 每个文件的大小
+	Paths []string // 文件路径，按路径排序
+}
+
+// Wasted 除保留一个文件外其余文件占用的字节数
+func (s DuplicateSet) Wasted() int64 {
+	return s.Size * int64(len(s.Paths)-1)
+}
+
+// FindDuplicates 在root下查找内容相同的普通文件，options 按 FindFiles 的规则选择要比较的文件
+// （Type、Action、Delete 和按内容筛选不使用）。
+//
+// 先按大小分组，大小相同的文件再比较开头4KB的校验和，仍然相同的才读取整个文件计算SHA-256，
+// 大多数文件只需要读取元数据。空文件和已经互为硬链接的文件不算重复。
+// 返回的重复文件组按浪费的空间从大到小排序；读取出错的文件交给 options.OnError 并跳过
+func FindDuplicates(root string, options FindOptions) ([]DuplicateSet, error) {
+	return FindDuplicatesContext(context.Background(), root, options)
+}
+
+// FindDuplicatesContext 与 FindDuplicates 相同，ctx取消时停止并返回ctx的错误
+func FindDuplicatesContext(ctx context.Context, root string, options FindOptions) ([]DuplicateSet, error) {
+	options.Type = "f"
+	options.Action, options.Delete = nil, false
+	options.Content, options.ContentRegex = "", ""
+	files, err := FindAll(ctx, root, options)
+	if err != nil {
+		return nil, err
+	}
+	warn := func(path string, err error) {
+		if options.OnError != nil {
+			options.OnError(path, err)
+		}
+	}
+
+	// 按大小分组，同一个文件的多个硬链接只保留一个
+	bySize := make(map[int64][]string)
+	seen := make(map[fileKey]bool)
+	for _, file := range files {
+		info := file.FileInfo
+		if !info.Mode().IsRegular() || info.Size() == 0 {
+			continue
+		}
+		if key, ok := hardLinkKey(info); ok {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		bySize[info.Size()] = append(bySize[info.Size()], file.Path)
+	}
+
+	var sets []DuplicateSet
+	for size, paths := range bySize {
+		if len(paths) < 2 {
+			continue
+		}
+		// 先比较开头的数据，不超过4KB的文件这就是全部内容
+		groups, err := groupByHash(ctx, paths, partialHashSize, warn)
+		if err != nil {
+			return nil, err
+		}
+		if size > partialHashSize {
+			var full [][]string
+			for _, group := range groups {
+				same, err := groupByHash(ctx, group, -1, warn)
+				if err != nil {
+					return nil, err
+				}
+				full = append(full, same...)
+			}
+			groups = full
+		}
+		for _, group := range groups {
+			sort.Strings(group)
+			sets = append(sets, DuplicateSet{Size: size, Paths: group})
+		}
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Wasted() != sets[j].Wasted() {
+			return sets[i].Wasted() > sets[j].Wasted()
+		}
+		return sets[i].Paths[0] < sets[j].Paths[0]
+	})
+	return sets, nil
+}
+
+// groupByHash 按文件前limit字节（limit小于0时为整个文件）的校验和分组，只返回有两个及以上文件的组
+func groupByHash(ctx context.Context, paths []string, limit int64, warn func(string, error)) ([][]string, error) {
+	byHash := make(map[string][]string)
+	var order []string
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sum, err := headSum(ctx, path, limit)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			warn(path, i18n.Errorf("读取时出错: %v", err))
+			continue
+		}
+		if _, ok := byHash[sum]; !ok {
+			order = append(order, sum)
+		}
+		byHash[sum] = append(byHash[sum], path)
+	}
+
+	var groups [][]string
+	for _, sum := range order {
+		if len(byHash[sum]) > 1 {
+			groups = append(groups, byHash[sum])
+		}
+	}
+	return groups, nil
+}
+
+// headSum 计算文件前limit字节的SHA-256校验和，limit小于0时计算整个文件
+func headSum(ctx context.Context, path string, limit int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	var r io.Reader = newProgress(ctx, nil, 0).reader(file)
+	if limit >= 0 {
+		r = io.LimitReader(r, limit)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// LinkDuplicate 用指向keep的硬链接替换dup，两者应在同一文件系统中且内容相同。
+// 先在dup所在目录创建临时链接再重命名覆盖dup，失败时dup保持不变
+func LinkDuplicate(keep, dup string) error {
+	tmp := filepath.Join(filepath.Dir(dup), fmt.Sprintf(".%s.link-%d", filepath.Base(dup), os.Getpid()))
+	if err := os.Link(keep, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dup); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package fsutils
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// 解压加密的压缩文件时的错误，可以用 errors.Is 判断，也属于 errs.ErrInvalidInput 类别
+var (
+	ErrPasswordRequired error = &errs.Error{Kind: errs.ErrInvalidInput, Err: i18n.NewError("文件已加密，需要提供密码")}
+	ErrWrongPassword    error = &errs.Error{Kind: errs.ErrInvalidInput, Err: i18n.NewError("密码错误")}
+)
+
+// DecompressOptions 定义解压缩选项
+type DecompressOptions struct {
+	Format   CompressFormat // 压缩格式，为空时根据文件开头的特征字节判断，无法识别时再根据扩展名判断（见 DetectFormat）
+	Progress ProgressFunc   // 进度回调，为nil时不报告进度
+	Password string         // 加密的zip（AES或传统加密）、rar、7z文件的密码
+
+	// Include 只解压匹配的文件，为空时解压所有文件；单文件格式（gz、bz2等）不支持。
+	// 模式以 / 分隔，语法与 path.Match 相同，** 匹配任意层目录；不含 / 的模式匹配任意层级的文件名，
+	// 匹配某个目录时包括其下的所有文件。某个模式没有匹配任何文件时返回 errs.ErrNotFound 类别的错误
+	Include []string
+
+	// Concurrency 并行解压zip中文件的数量，0或1表示按顺序解压，负数表示使用全部CPU核心。
+	// 只用于zip，其他格式只能按顺序读取；并行时 OnError 和 Progress 不会被同时调用
+	Concurrency int
+
+	// PreserveAttrs 恢复文件和目录的权限、修改时间，以root运行时还恢复tar中记录的所有者；
+	// 为false时按压缩文件中的权限创建文件（受umask影响），修改时间为解压的时间
+	PreserveAttrs bool
+
+	// OnError 在解压某个文件失败时调用，返回nil时跳过该文件继续解压，返回错误时停止解压并返回该错误；
+	// 为nil时由 ContinueOnError 决定是否继续。读取文件列表或下一个文件头失败时不调用，直接返回错误
+	OnError func(err *EntryError) error
+
+	// ContinueOnError 没有设置 OnError 时，某个文件解压失败后跳过该文件继续解压其余文件，
+	// 失败的文件记录在 DecompressResult.Errors 中，返回的错误为nil；为false时遇到第一个错误即停止。
+	// 密码错误（ErrWrongPassword、ErrPasswordRequired）时所有加密的文件都会失败，仍然直接停止
+	ContinueOnError bool
+
+	// Manifest 不为空时按该路径的SHA-256校验和清单（sha256sum 的格式，如 CompressOptions.Manifest 生成的清单）
+	// 检查解压出的每个文件，不一致的文件按解压失败处理。清单中没有的文件不检查；
+	// 清单中有但压缩文件中没有的文件最后返回 errs.ErrNotFound 类别的错误，指定了 Include 时不检查
+	Manifest string
+
+	include *includeFilter  // 由 Decompress 根据 Include 创建
+	attrs   *attrRestorer   // PreserveAttrs 时由 Decompress 创建
+	sums    *checksums      // 由 Decompress 读取 Manifest 创建
+	result  *resultRecorder // 由 Decompress 创建，记录 DecompressResult
+}
+
+// DecompressResult 解压缩的结果，解压中途停止时也包含已经处理的文件
+type DecompressResult struct {
+	Extracted []string      // 解压出的文件、目录和链接在压缩文件中的路径，按解压完成的顺序
+	Skipped   []string      // 路径指向目标目录之外（包含 ..）而跳过的条目
+	Errors    []*EntryError // 解压失败的文件，包括导致停止解压的错误
+}
+
+// resultRecorder 在解压过程中记录 DecompressResult，并行解压时可以同时调用；为nil时不记录
+type resultRecorder struct {
+	mu     sync.Mutex
+	result DecompressResult
+}
+
+func (r *resultRecorder) extracted(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Extracted = append(r.result.Extracted, name)
+}
+
+func (r *resultRecorder) skipped(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Skipped = append(r.result.Skipped, name)
+}
+
+func (r *resultRecorder) failed(err *EntryError) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Errors = append(r.result.Errors, err)
+}
+
+// get 返回记录的结果，调用时不能再有正在解压的文件
+func (r *resultRecorder) get() DecompressResult {
+	if r == nil {
+		return DecompressResult{}
+	}
+	return r.result
+}
+
+// EntryError 解压压缩文件中的某个文件失败
+type EntryError struct {
+	Name string // 文件在压缩文件中的路径
+	Err  error
+}
+
+func (e *EntryError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Name, e.Err)
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+// entryFailed 处理解压某个文件时的错误，返回nil表示跳过该文件继续解压；被取消时总是停止
+func (o DecompressOptions) entryFailed(name string, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	entryErr := &EntryError{Name: name, Err: err}
+	o.result.failed(entryErr)
+	if o.OnError != nil {
+		return o.OnError(entryErr)
+	}
+	if o.ContinueOnError && !errors.Is(err, ErrWrongPassword) && !errors.Is(err, ErrPasswordRequired) {
+		return nil
+	}
+	return entryErr
+}
+
+// writeEntry 将r的内容写入path，失败时删除写了一半的文件，避免留下内容错误的文件
+func writeEntry(path string, mode os.FileMode, r io.Reader) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// writeLink 在name处创建tar中的符号链接或硬链接，链接目标必须在dstReal（解压目标目录的实际路径）内
+func writeLink(dstReal, name string, header *tar.Header) error {
+	var target string
+	switch header.Typeflag {
+	case tar.TypeSymlink:
+		// 相对路径的目标相对于链接所在的目录。目标中的路径可能经过之前解压出的符号链接（如 sub/u/..），
+		// 不能按字面清理，按实际位置解析
+		target = header.Linkname
+		if !filepath.IsAbs(target) {
+			parent, err := filepath.EvalSymlinks(filepath.Dir(name))
+			if err != nil {
+				return err
+			}
+			target = parent + string(filepath.Separator) + filepath.FromSlash(target)
+		}
+		var err error
+		if target, err = realPath(target); err != nil {
+			return i18n.Errorf("链接目标 %s 无效: %v", header.Linkname, err)
+		}
+	case tar.TypeLink:
+		// 硬链接的目标是压缩文件中的另一个路径，必须已经解压
+		var err error
+		target, err = filepath.EvalSymlinks(filepath.Join(dstReal, filepath.FromSlash(path.Clean("/"+header.Linkname))))
+		if err != nil {
+			return err
+		}
+	}
+	if !withinDir(target, dstReal) {
+		return i18n.Errorf("链接目标 %s 不在目标目录内", header.Linkname)
+	}
+
+	// 与tar命令一样先删除已有的文件
+	os.Remove(name)
+	if header.Typeflag == tar.TypeSymlink {
+		return os.Symlink(header.Linkname, name)
+	}
+	return os.Link(target, name)
+}
+
+// realPath 返回path的实际路径：已经存在的部分按 EvalSymlinks 解析符号链接，不存在的部分直接拼接。
+// 不存在的部分中有 .. 时无法确定实际位置，返回错误
+func realPath(path string) (string, error) {
+	sep := string(filepath.Separator)
+	current := strings.TrimRight(path, sep)
+	var rest []string // 不存在的部分，从后向前
+	for {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			for i := len(rest) - 1; i >= 0; i-- {
+				if rest[i] == ".." {
+					return "", i18n.Errorf("路径 %s 中不存在的部分含有 ..", path)
+				}
+				resolved = filepath.Join(resolved, rest[i])
+			}
+			return resolved, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		i := strings.LastIndex(current, sep)
+		if i <= 0 {
+			return "", err
+		}
+		rest = append(rest, current[i+1:])
+		current = strings.TrimRight(current[:i], sep)
+	}
+}
+
+// checkExtractPath 检查按实际路径解析后path仍在dstReal内。压缩文件中之前的符号链接可能指向目标目录外，
+// 之后的文件经过这些链接时，创建目录或写入文件都会跟随链接，只按字面检查路径不够
+func checkExtractPath(dstReal, path string) error {
+	real, err := realPath(path)
+	if err != nil {
+		return err
+	}
+	if !withinDir(real, dstReal) {
+		return i18n.Errorf("路径经过符号链接指向目标目录外")
+	}
+	return nil
+}
+
+// withinDir 判断path是否为dir或其中的文件，两者都应是清理过的绝对路径
+func withinDir(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// loadManifest 读取 Manifest 指定的校验和清单
+func (o *DecompressOptions) loadManifest() (err error) {
+	if o.Manifest != "" {
+		o.sums, err = readChecksums(o.Manifest)
+	}
+	return err
+}
+
+// manifestMissing 返回清单中的文件不在压缩文件中的错误，只选择了部分文件时不检查
+func (o DecompressOptions) manifestMissing() error {
+	if o.include != nil {
+		return nil
+	}
+	return o.sums.missing()
+}
+
+// included 判断文件是否需要解压
+func (o DecompressOptions) included(name string) bool {
+	return o.include == nil || o.include.match(name)
+}
+
+// includeFilter 按 Include 过滤要解压的文件，并记录每个模式是否匹配过
+type includeFilter struct {
+	patterns []string // 原始模式，用于报告
+	globs    []string // 转换后的模式
+	matched  []bool
+}
+
+// newIncludeFilter 检查并转换模式，patterns为空时返回nil
+func newIncludeFilter(patterns []string) (*includeFilter, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	f := &includeFilter{patterns: patterns, matched: make([]bool, len(patterns))}
+	for _, pattern := range patterns {
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		f.globs = append(f.globs, glob+"/**")
+	}
+	return f, nil
+}
+
+func (f *includeFilter) match(name string) bool {
+	name = strings.Trim(path.Clean("/"+name), "/")
+	found := false
+	for i, glob := range f.globs {
+		if matchGlob(glob, name) {
+			f.matched[i] = true
+			found = true
+		}
+	}
+	return found
+}
+
+// unmatched 返回没有匹配任何文件的错误，所有模式都匹配过时返回nil
+func (f *includeFilter) unmatched() error {
+	if f == nil {
+		return nil
+	}
+	var missing []string
+	for i, pattern := range f.patterns {
+		if !f.matched[i] {
+			missing = append(missing, pattern)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return errs.NotFound("压缩文件中没有匹配 %s 的文件", strings.Join(missing, ", "))
+}
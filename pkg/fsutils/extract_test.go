@@ -0,0 +1,84 @@
+package fsutils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 通过连续的符号链接（sub/u → ..，e → sub/u/..）把之后的文件写到目标目录外
+func TestDecompressTarSymlinkChainEscape(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	entries := []*tar.Header{
+		{Name: "sub/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "sub/u", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		{Name: "e", Typeflag: tar.TypeSymlink, Linkname: "sub/u/.."},
+	}
+	for _, header := range entries {
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+	}
+	content := []byte("pwned\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "e/PWNED", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(content)
+	tw.Close()
+	zw.Close()
+
+	base := t.TempDir()
+	out := filepath.Join(base, "out")
+	_, _ = DecompressFrom(&buf, out, DecompressOptions{Format: TARGZ, ContinueOnError: true})
+
+	if _, err := os.Lstat(filepath.Join(base, "PWNED")); err == nil {
+		t.Fatal("文件被写到了目标目录外")
+	}
+	if target, err := os.Readlink(filepath.Join(out, "e")); err == nil {
+		t.Fatalf("指向目标目录外的符号链接 e → %s 不应被创建", target)
+	}
+}
+
+// zip中的符号链接解压后仍是符号链接，加密的zip也一样
+func TestZipSymlinkRoundTrip(t *testing.T) {
+	for _, password := range []string{"", "secret"} {
+		base := t.TempDir()
+		src := filepath.Join(base, "src")
+		if err := os.MkdirAll(filepath.Join(src, "a", "b"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(src, "a", "b", "n.txt"), []byte("hello\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink("b/n.txt", filepath.Join(src, "a", "link")); err != nil {
+			t.Fatal(err)
+		}
+
+		archive := filepath.Join(base, "x.zip")
+		if err := Compress(src, archive, CompressOptions{Format: ZIP, Password: password}); err != nil {
+			t.Fatal(err)
+		}
+		out := filepath.Join(base, "out")
+		if _, err := Decompress(archive, out, DecompressOptions{Format: ZIP, Password: password}); err != nil {
+			t.Fatal(err)
+		}
+
+		link := filepath.Join(out, "a", "link")
+		target, err := os.Readlink(link)
+		if err != nil {
+			t.Fatalf("密码 %q: a/link 应解压为符号链接: %v", password, err)
+		}
+		if target != "b/n.txt" {
+			t.Fatalf("密码 %q: 链接目标为 %s，应为 b/n.txt", password, target)
+		}
+		content, err := os.ReadFile(link)
+		if err != nil || string(content) != "hello\n" {
+			t.Fatalf("密码 %q: 通过链接读取的内容错误: %q %v", password, content, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package fsutils
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+)
+
+// compileGlob 检查并转换用户指定的模式：去掉开头的 / 和 ./，不含 / 的模式转换为匹配任意层级的文件名
+func compileGlob(pattern string) (string, error) {
+	if err := validGlob(pattern); err != nil {
+		return "", errs.InvalidInput("无效的匹配模式 %q: %v", pattern, err)
+	}
+	glob := strings.Trim(path.Clean("/"+pattern), "/")
+	if !strings.Contains(glob, "/") {
+		glob = "**/" + glob
+	}
+	return glob, nil
+}
+
+// sourceFilter 压缩目录时按 CompressOptions 中的规则选择要写入的文件
+type sourceFilter struct {
+	excludePaths   []string
+	include        []string // 转换后的模式，匹配文件本身及其下的所有文件
+	exclude        []string // 转换后的模式
+	minSize        int64
+	maxSize        int64
+	modifiedAfter  time.Time
+	modifiedBefore time.Time
+}
+
+// newSourceFilter 根据压缩选项创建过滤规则，检查模式的语法
+func newSourceFilter(options CompressOptions) (*sourceFilter, error) {
+	if options.MinSize < 0 || options.MaxSize < 0 || (options.MaxSize > 0 && options.MinSize > options.MaxSize) {
+		return nil, errs.InvalidInput("无效的文件大小范围: %d - %d", options.MinSize, options.MaxSize)
+	}
+	f := &sourceFilter{
+		excludePaths:   options.ExcludePaths,
+		minSize:        options.MinSize,
+		maxSize:        options.MaxSize,
+		modifiedAfter:  options.ModifiedAfter,
+		modifiedBefore: options.ModifiedBefore,
+	}
+	for _, pattern := range options.IncludePatterns {
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		f.include = append(f.include, glob+"/**")
+	}
+	for _, pattern := range options.ExcludePatterns {
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		f.exclude = append(f.exclude, glob)
+	}
+	return f, nil
+}
+
+// excluded 判断路径是否被排除，被排除的目录不再遍历其中的文件；name 为在压缩文件中的路径
+func (f *sourceFilter) excluded(path, name string) bool {
+	if f == nil {
+		return false
+	}
+	if shouldExclude(path, f.excludePaths) {
+		return true
+	}
+	for _, glob := range f.exclude {
+		if matchGlob(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// included 判断是否写入该文件或目录。指定了 IncludePatterns 时只写入匹配的目录，但仍遍历其中的文件；
+// 大小只检查普通文件，修改时间不检查目录
+func (f *sourceFilter) included(name string, info os.FileInfo) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchAny(f.include, name) {
+		return false
+	}
+	if info.IsDir() {
+		return true
+	}
+	if info.Mode().IsRegular() {
+		if info.Size() < f.minSize || (f.maxSize > 0 && info.Size() > f.maxSize) {
+			return false
+		}
+	}
+	if !f.modifiedAfter.IsZero() && info.ModTime().Before(f.modifiedAfter) {
+		return false
+	}
+	if !f.modifiedBefore.IsZero() && !info.ModTime().Before(f.modifiedBefore) {
+		return false
+	}
+	return true
+}
+
+func matchAny(globs []string, name string) bool {
+	for _, glob := range globs {
+		if matchGlob(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// walkSource 遍历要压缩的文件或目录，按过滤规则跳过文件，对每个要写入的文件调用fn；
+// name 为在压缩文件中的路径：压缩目录时相对于源目录，压缩单个文件时为文件名
+func walkSource(src string, isDir bool, filter *sourceFilter, fn func(path, name string, info os.FileInfo) error) error {
+	if !isDir {
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		name := filepath.Base(src)
+		if filter.excluded(src, name) || !filter.included(name, info) {
+			return nil
+		}
+		return fn(src, name, info)
+	}
+
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(relPath)
+		if filter.excluded(path, name) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !filter.included(name, info) {
+			return nil
+		}
+		return fn(path, name, info)
+	})
+}
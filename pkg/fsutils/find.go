@@ -1,13 +1,19 @@
 package fsutils
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // FindOptions 定义文件搜索的选项
@@ -24,6 +30,47 @@ type FindOptions struct {
 	ExcludeDirs    []string  // 要排除的目录
 	IncludeDirs    []string  // 要包含的目录（为空则搜索所有目录）
 	FollowSymlinks bool      // 是否跟随符号链接
+	Empty          bool      // 只匹配空的普通文件和空目录（与 find -empty 相同）
+
+	// RespectGitignore 按各级目录中的 .gitignore 和 .ignore 文件跳过被忽略的文件和目录，同时跳过 .git 目录。
+	// 起始目录在git仓库中时，仓库根目录到起始目录之间的忽略文件和 .git/info/exclude 同样生效
+	RespectGitignore bool
+	// IgnoreFiles 每个目录中额外读取的忽略文件名（如 .fdignore），语法与 .gitignore 相同，不需要 RespectGitignore
+	IgnoreFiles []string
+
+	// Content 和 ContentRegex 按内容筛选：只保留内容包含 Content 字符串、匹配 ContentRegex 正则表达式的普通文件，
+	// 同时指定时两者都要满足。其他条件都满足的文件才会打开读取，相当于一次遍历完成 find 和 grep。
+	// ContentRegex 使用多行模式，^ 和 $ 匹配每行的开头和结尾
+	Content      string
+	ContentRegex string
+
+	// Action 不为nil时对每个匹配的文件调用，用于在搜索的同时处理文件（修改权限、删除、执行命令等）；
+	// 返回错误时停止搜索并返回该错误。处理的是目录时可以返回 filepath.SkipDir 不进入该目录（如已删除该目录）
+	Action func(result FindResult) error
+
+	// Delete 删除匹配的文件、符号链接和目录（在 Action 之后）：目录在其中的文件处理完之后删除，
+	// 只能删除空目录，起始目录本身不会被删除。与 find -delete 不同，是否匹配（包括 Empty）在删除之前判断。
+	// 删除失败时写入警告并继续，最后返回失败数量的错误
+	Delete bool
+
+	// OutputFormat ExecuteFind 写入结果的格式，为空时每行一个路径
+	OutputFormat FindOutputFormat
+	// Template 文本格式中每条结果的 text/template 模板，数据为 FindRecord（另有 {{.Name}} 和 {{size .Size}}），
+	// 支持 \t、\n、\0 转义；为空时输出路径
+	Template string
+	// Print0 文本格式中每条结果之后写入NUL而不是换行，文件名含有空格或换行时也能被 xargs -0 正确分隔
+	Print0 bool
+
+	// SortBy 按 name（文件名）、path、size 或 mtime 排序结果，SortDesc 为降序；为空时按遍历的顺序。
+	// 排序需要先找到全部文件，Action 和 Delete 在遍历完成后按排序后的顺序执行
+	SortBy   string
+	SortDesc bool
+	// Limit 大于0时最多返回这么多个结果，排序时为排序后的前几个；不排序时达到数量后立即停止遍历
+	Limit int
+
+	// OnError 访问某个路径或删除失败时调用，不影响继续搜索；为nil时 ExecuteFind 和 FindFiles 将警告写入指定的writer，
+	// FindAll 和 FindIter 忽略这些错误
+	OnError func(path string, err error)
 }
 
 // FindResult 存储搜索结果
@@ -33,28 +80,91 @@ type FindResult struct {
 	Depth    int         // 相对于起始目录的深度
 }
 
-// ExecuteFind 执行文件搜索
+// ExecuteFind 执行文件搜索，将匹配的结果按 OutputFormat 逐条写入output；设置了 Action 或 Delete 时只处理文件，不写入结果
 func ExecuteFind(root string, output io.Writer, options FindOptions) error {
-	// 编译正则表达式（如果提供）
-	var re *regexp.Regexp
-	var err error
-	if options.Regex != "" {
-		re, err = regexp.Compile(options.Regex)
-		if err != nil {
-			return fmt.Errorf("无效的正则表达式: %v", err)
+	writer, err := NewFindWriter(output, options)
+	if err != nil {
+		return err
+	}
+	quiet := options.Action != nil || options.Delete
+	err = walkFind(context.Background(), root, warnTo(output, options), func(result FindResult) error {
+		if quiet {
+			return nil
 		}
+		return writer.Write(result)
+	})
+	if err != nil || quiet {
+		return err
 	}
+	return writer.Close()
+}
 
-	// 创建通配符模式（如果提供）
-	var pattern string
-	if options.Name != "" {
-		pattern = options.Name
+// FindFiles 执行文件搜索并返回所有匹配结果，访问出错的路径警告写入warnings
+func FindFiles(root string, warnings io.Writer, options FindOptions) ([]FindResult, error) {
+	return FindAll(context.Background(), root, warnTo(warnings, options))
+}
+
+// FindAll 执行文件搜索并返回所有匹配结果，按遍历的顺序（同一目录中按文件名排序）；
+// ctx取消时停止并返回ctx的错误。访问出错的路径交给 options.OnError，不会中止搜索
+func FindAll(ctx context.Context, root string, options FindOptions) ([]FindResult, error) {
+	var results []FindResult
+	err := walkFind(ctx, root, options, func(result FindResult) error {
+		results = append(results, result)
+		return nil
+	})
+	return results, err
+}
+
+// FindIter 在后台执行文件搜索，通过返回的通道逐个发送匹配结果，适合结果很多或需要边搜索边显示的场景。
+// 搜索结束后关闭结果通道，然后向错误通道发送搜索的错误（成功时为nil）并关闭它。
+// 调用方不再读取结果时应取消ctx，否则后台的搜索会一直等待发送
+func FindIter(ctx context.Context, root string, options FindOptions) (<-chan FindResult, <-chan error) {
+	results := make(chan FindResult)
+	errc := make(chan error, 1)
+	go func() {
+		err := walkFind(ctx, root, options, func(result FindResult) error {
+			select {
+			case results <- result:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		close(results)
+		errc <- err
+		close(errc)
+	}()
+	return results, errc
+}
+
+// warnTo 没有设置 OnError 时将警告写入w
+func warnTo(w io.Writer, options FindOptions) FindOptions {
+	if options.OnError == nil {
+		options.OnError = func(path string, err error) {
+			fmt.Fprint(w, i18n.Tf("警告: %s: %v\n", path, err))
+		}
+	}
+	return options
+}
+
+// walkFind 遍历目录，对每个匹配的文件调用fn，fn返回错误时停止
+func walkFind(ctx context.Context, root string, options FindOptions, fn func(FindResult) error) error {
+	// 编译文件筛选条件
+	matcher, err := newFindMatcher(options)
+	if err != nil {
+		return err
+	}
+	if err := checkFindSort(options); err != nil {
+		return err
 	}
 
 	// 规范化根目录路径
 	root, err = filepath.Abs(root)
 	if err != nil {
-		return fmt.Errorf("无法获取绝对路径: %v", err)
+		return i18n.Errorf("无法获取绝对路径: %v", err)
+	}
+	if _, err := os.Stat(root); err != nil {
+		return errs.Wrap(err, "无法访问目录 %s: %v", root, err)
 	}
 
 	// 规范化包含目录路径
@@ -68,10 +178,57 @@ func ExecuteFind(root string, output io.Writer, options FindOptions) error {
 		normalizedIncludeDirs = append(normalizedIncludeDirs, dir)
 	}
 
+	// Delete 时匹配的目录在遍历完成后从深到浅删除，此时其中的文件已经删除
+	var matchedDirs []string
+	deleteFailed := 0
+	warn := func(path string, err error) {
+		if options.OnError != nil {
+			options.OnError(path, err)
+		}
+	}
+	remove := func(path string) {
+		if err := os.Remove(path); err != nil {
+			warn(path, i18n.Errorf("删除失败: %v", err))
+			deleteFailed++
+		}
+	}
+	ignores := newIgnoreMatcher(root, options, warn)
+
+	// handle 对匹配的文件执行 Action、调用fn，并按 Delete 删除文件或记录要删除的目录
+	handle := func(result FindResult) error {
+		if options.Action != nil {
+			if err := options.Action(result); err != nil {
+				if err == filepath.SkipDir && result.FileInfo.IsDir() {
+					if fnErr := fn(result); fnErr != nil {
+						return fnErr
+					}
+				}
+				return err
+			}
+		}
+		if err := fn(result); err != nil {
+			return err
+		}
+
+		if options.Delete && result.Path != root {
+			if result.FileInfo.IsDir() {
+				matchedDirs = append(matchedDirs, result.Path)
+			} else {
+				remove(result.Path)
+			}
+		}
+		return nil
+	}
+	var matches []FindResult // 需要排序时收集的结果
+	count := 0
+
 	// 遍历目录
 	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
-			fmt.Fprintf(output, "警告: 访问 %s 时出错: %v\n", path, err)
+			warn(path, i18n.Errorf("访问时出错: %v", err))
 			return nil // 继续处理其他文件
 		}
 
@@ -90,6 +247,19 @@ func ExecuteFind(root string, output io.Writer, options FindOptions) error {
 			depth = len(strings.Split(relPath, "/"))
 		}
 
+		// 被忽略文件排除的目录不再进入；目录中的忽略文件在检查其中的文件之前读取
+		if ignores != nil {
+			if path != root && ignores.ignored(path, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.IsDir() {
+				ignores.load(path, warn)
+			}
+		}
+
 		// 检查深度限制
 		if options.MinDepth > 0 && depth < options.MinDepth {
 			return nil
@@ -159,62 +329,330 @@ func ExecuteFind(root string, output io.Writer, options FindOptions) error {
 			}
 		}
 
-		// 检查文件类型
-		if options.Type != "" {
-			isMatch := false
-			switch options.Type {
-			case "f":
-				isMatch = !info.IsDir()
-			case "d":
-				isMatch = info.IsDir()
-			case "l":
-				isMatch = info.Mode()&os.ModeSymlink != 0
+		// 检查类型、大小、修改时间和文件名，最后读取内容
+		matched, err := matcher.matchFile(ctx, path, info)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
-			if !isMatch {
-				return nil
+			warn(path, err)
+			return nil
+		}
+		if !matched {
+			return nil
+		}
+
+		// 需要排序时先收集全部结果，否则立即处理，达到 Limit 后停止遍历
+		result := FindResult{Path: path, FileInfo: info, Depth: depth}
+		if options.SortBy != "" {
+			matches = append(matches, result)
+			return nil
+		}
+		if err := handle(result); err != nil {
+			return err
+		}
+		if count++; options.Limit > 0 && count >= options.Limit {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if options.SortBy != "" {
+		sortFindResults(matches, options.SortBy, options.SortDesc)
+		if options.Limit > 0 && len(matches) > options.Limit {
+			matches = matches[:options.Limit]
+		}
+		for _, result := range matches {
+			// 已经遍历完成，Action 返回的 SkipDir 不再有意义
+			if err := handle(result); err != nil && err != filepath.SkipDir {
+				return err
 			}
 		}
+	}
+
+	// 子目录的路径总是比上层目录长，按路径长度从长到短删除
+	sort.SliceStable(matchedDirs, func(i, j int) bool {
+		return len(matchedDirs[i]) > len(matchedDirs[j])
+	})
+	for _, dir := range matchedDirs {
+		remove(dir)
+	}
+	if deleteFailed > 0 {
+		return i18n.Errorf("%d 个文件或目录删除失败", deleteFailed)
+	}
+	return nil
+}
+
+// findMatcher 按类型、大小、修改时间和文件名筛选文件，需要时再按内容筛选
+type findMatcher struct {
+	options   FindOptions
+	re        *regexp.Regexp
+	content   []byte
+	contentRe *regexp.Regexp
+}
+
+// newFindMatcher 根据搜索选项创建筛选器
+func newFindMatcher(options FindOptions) (*findMatcher, error) {
+	matcher := &findMatcher{options: options}
+	if options.Regex != "" {
+		re, err := regexp.Compile(options.Regex)
+		if err != nil {
+			return nil, errs.InvalidInput("无效的正则表达式: %v", err)
+		}
+		matcher.re = re
+	}
+	if options.Content != "" {
+		matcher.content = []byte(options.Content)
+	}
+	if options.ContentRegex != "" {
+		re, err := regexp.Compile("(?m)" + options.ContentRegex)
+		if err != nil {
+			return nil, errs.InvalidInput("无效的内容正则表达式: %v", err)
+		}
+		matcher.contentRe = re
+	}
+	return matcher, nil
+}
+
+// matchFile 依次检查类型、大小、修改时间、文件名、是否为空和内容，只在需要时读取目录或文件
+func (m *findMatcher) matchFile(ctx context.Context, path string, info os.FileInfo) (bool, error) {
+	if !m.match(info) {
+		return false, nil
+	}
+	if m.options.Empty {
+		empty, err := isEmpty(path, info)
+		if err != nil {
+			return false, i18n.Errorf("访问时出错: %v", err)
+		}
+		if !empty {
+			return false, nil
+		}
+	}
+	if m.checksContent() {
+		matched, err := m.matchContent(ctx, path, info)
+		if err != nil {
+			return false, i18n.Errorf("读取内容时出错: %v", err)
+		}
+		return matched, nil
+	}
+	return true, nil
+}
+
+// isEmpty 判断是否为空的普通文件或没有任何条目的目录，其他类型的文件都不算空
+func isEmpty(path string, info os.FileInfo) (bool, error) {
+	switch {
+	case info.Mode().IsRegular():
+		return info.Size() == 0, nil
+	case info.IsDir():
+		dir, err := os.Open(path)
+		if err != nil {
+			return false, err
+		}
+		defer dir.Close()
+		if _, err := dir.Readdirnames(1); err != io.EOF {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
 
-		// 检查文件大小
+// checksContent 是否需要按内容筛选
+func (m *findMatcher) checksContent() bool {
+	return m.content != nil || m.contentRe != nil
+}
+
+// matchContent 读取文件检查内容，只有普通文件可能匹配。ctx取消时停止读取
+func (m *findMatcher) matchContent(ctx context.Context, path string, info os.FileInfo) (bool, error) {
+	if !info.Mode().IsRegular() {
+		return false, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	r := newProgress(ctx, nil, 0).reader(file)
+
+	if m.content != nil {
+		found, err := containsBytes(r, m.content)
+		if err != nil || !found || m.contentRe == nil {
+			return found, err
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+	}
+	// MatchReader 不返回读取错误，读取出错时数据不完整，不能确定不匹配
+	er := &errorReader{r: r}
+	matched := m.contentRe.MatchReader(bufio.NewReader(er))
+	if !matched && er.err != nil {
+		return false, er.err
+	}
+	return matched, nil
+}
+
+// errorReader 记录读取时遇到的第一个错误（io.EOF 除外）
+type errorReader struct {
+	r   io.Reader
+	err error
+}
+
+func (er *errorReader) Read(p []byte) (int, error) {
+	n, err := er.r.Read(p)
+	if err != nil && err != io.EOF && er.err == nil {
+		er.err = err
+	}
+	return n, err
+}
+
+// containsBytes 分块读取r，检查是否包含sub；块之间保留 len(sub)-1 字节，跨块的内容也能找到
+func containsBytes(r io.Reader, sub []byte) (bool, error) {
+	buf := make([]byte, 0, max(64<<10, 2*len(sub)))
+	for {
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if bytes.Contains(buf, sub) {
+			return true, nil
+		}
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if keep := len(sub) - 1; len(buf) > keep {
+			buf = buf[:copy(buf, buf[len(buf)-keep:])]
+		}
+	}
+}
+
+// match 检查文件是否满足筛选条件
+func (m *findMatcher) match(info os.FileInfo) bool {
+	options := m.options
+
+	// 检查文件类型
+	switch options.Type {
+	case "f":
+		if info.IsDir() {
+			return false
+		}
+	case "d":
 		if !info.IsDir() {
-			size := info.Size()
-			if options.MinSize > 0 && size < options.MinSize {
-				return nil
-			}
-			if options.MaxSize > 0 && size > options.MaxSize {
-				return nil
-			}
+			return false
 		}
+	case "l":
+		if info.Mode()&os.ModeSymlink == 0 {
+			return false
+		}
+	}
 
-		// 检查修改时间
-		modTime := info.ModTime()
-		if !options.ModifiedAfter.IsZero() && modTime.Before(options.ModifiedAfter) {
-			return nil
+	// 检查文件大小
+	if !info.IsDir() {
+		size := info.Size()
+		if options.MinSize > 0 && size < options.MinSize {
+			return false
 		}
-		if !options.ModifiedBefore.IsZero() && modTime.After(options.ModifiedBefore) {
-			return nil
+		if options.MaxSize > 0 && size > options.MaxSize {
+			return false
 		}
+	}
 
-		// 检查文件名模式
-		if pattern != "" {
-			matched, err := filepath.Match(pattern, info.Name())
-			if err != nil || !matched {
-				return nil
-			}
+	// 检查修改时间
+	modTime := info.ModTime()
+	if !options.ModifiedAfter.IsZero() && modTime.Before(options.ModifiedAfter) {
+		return false
+	}
+	if !options.ModifiedBefore.IsZero() && modTime.After(options.ModifiedBefore) {
+		return false
+	}
+
+	// 检查文件名模式
+	if options.Name != "" {
+		matched, err := filepath.Match(options.Name, info.Name())
+		if err != nil || !matched {
+			return false
 		}
+	}
 
-		// 检查正则表达式
-		if re != nil && !re.MatchString(info.Name()) {
-			return nil
+	// 检查正则表达式
+	return m.re == nil || m.re.MatchString(info.Name())
+}
+
+// FilterFindResults 按搜索选项筛选已有的文件列表，用于筛选远程主机等其他来源的结果
+//
+// 列表应已按 ExcludeDirs 排除目录，IncludeDirs、FollowSymlinks、忽略文件和按内容筛选不在此处理，
+// Empty 只检查普通文件的大小。
+func FilterFindResults(results []FindResult, options FindOptions) ([]FindResult, error) {
+	matcher, err := newFindMatcher(options)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFindSort(options); err != nil {
+		return nil, err
+	}
+
+	var filtered []FindResult
+	for _, result := range results {
+		if options.MinDepth > 0 && result.Depth < options.MinDepth {
+			continue
 		}
+		if options.MaxDepth > 0 && result.Depth > options.MaxDepth {
+			continue
+		}
+		if !matcher.match(result.FileInfo) {
+			continue
+		}
+		// 目录是否为空需要列出其中的文件，只能由调用方筛选
+		if options.Empty && result.FileInfo.Mode().IsRegular() && result.FileInfo.Size() > 0 {
+			continue
+		}
+		filtered = append(filtered, result)
+	}
+	if options.SortBy != "" {
+		sortFindResults(filtered, options.SortBy, options.SortDesc)
+	}
+	if options.Limit > 0 && len(filtered) > options.Limit {
+		filtered = filtered[:options.Limit]
+	}
+	return filtered, nil
+}
 
-		// 输出结果
-		fmt.Fprintln(output, path)
+// checkFindSort 检查排序方式和数量限制
+func checkFindSort(options FindOptions) error {
+	switch options.SortBy {
+	case "", "name", "path", "size", "mtime":
+	default:
+		return errs.InvalidInput("不支持的排序方式: %s（可选: name, path, size, mtime）", options.SortBy)
+	}
+	if options.Limit < 0 {
+		return errs.InvalidInput("无效的结果数量: %d", options.Limit)
+	}
+	return nil
+}
 
-		return nil
+// sortFindResults 按指定方式稳定排序，值相同的结果保持原来的顺序
+func sortFindResults(results []FindResult, sortBy string, desc bool) {
+	less := func(a, b FindResult) bool {
+		switch sortBy {
+		case "name":
+			return a.FileInfo.Name() < b.FileInfo.Name()
+		case "size":
+			return a.FileInfo.Size() < b.FileInfo.Size()
+		case "mtime":
+			return a.FileInfo.ModTime().Before(b.FileInfo.ModTime())
+		}
+		return a.Path < b.Path
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if desc {
+			return less(results[j], results[i])
+		}
+		return less(results[i], results[j])
 	})
-
-	return err
 }
 
 // isExcludedDir 检查目录是否应该被排除
@@ -0,0 +1,173 @@
+package fsutils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"toolbox/pkg/errs"
+)
+
+// FindOutputFormat ExecuteFind 写入结果的格式
+type FindOutputFormat string
+
+// 支持的搜索结果格式
+const (
+	FindOutputText FindOutputFormat = "text" // 每行一个路径（默认）
+	FindOutputJSON FindOutputFormat = "json" // 每行一个JSON对象，可以直接交给 jq 处理
+	FindOutputCSV  FindOutputFormat = "csv"  // 带表头的CSV
+)
+
+// ParseFindOutputFormat 解析搜索结果格式的名称
+func ParseFindOutputFormat(name string) (FindOutputFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "text":
+		return FindOutputText, nil
+	case "json", "jsonl", "ndjson":
+		return FindOutputJSON, nil
+	case "csv":
+		return FindOutputCSV, nil
+	}
+	return "", errs.InvalidInput("不支持的搜索结果格式: %s（可选: text, json, csv）", name)
+}
+
+// FindRecord 以JSON或CSV格式输出的一条搜索结果
+type FindRecord struct {
+	Path    string    `json:"path"`
+	Type    string    `json:"type"` // file, dir, symlink
+	Size    int64     `json:"size"`
+	Mode    string    `json:"mode"`
+	ModTime time.Time `json:"mod_time"`
+	Depth   int       `json:"depth"`
+}
+
+// Name 文件名，不含目录，用于输出模板中的 {{.Name}}
+func (r FindRecord) Name() string {
+	return filepath.Base(r.Path)
+}
+
+// findRecordFields CSV的表头，与 FindRecord 的JSON字段名相同
+var findRecordFields = []string{"path", "type", "size", "mode", "mod_time", "depth"}
+
+// NewFindRecord 根据搜索结果创建输出记录
+func NewFindRecord(result FindResult) FindRecord {
+	info := result.FileInfo
+	entryType := "file"
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		entryType = "symlink"
+	case info.IsDir():
+		entryType = "dir"
+	}
+	return FindRecord{
+		Path:    result.Path,
+		Type:    entryType,
+		Size:    info.Size(),
+		Mode:    info.Mode().String(),
+		ModTime: info.ModTime(),
+		Depth:   result.Depth,
+	}
+}
+
+// FindWriter 按 OutputFormat、Template 和 Print0 逐条写入搜索结果，ExecuteFind 使用它输出，
+// 也可以用于输出 FindIter、WatchFind 等得到的结果
+type FindWriter struct {
+	w      io.Writer
+	format FindOutputFormat
+	tmpl   *template.Template
+	end    string // 文本格式中每条结果之后的分隔符
+	csv    *csv.Writer
+	count  int
+}
+
+// NewFindWriter 创建写入w的 FindWriter，检查输出格式和模板
+func NewFindWriter(w io.Writer, options FindOptions) (*FindWriter, error) {
+	format, err := ParseFindOutputFormat(string(options.OutputFormat))
+	if err != nil {
+		return nil, err
+	}
+	if format != FindOutputText && (options.Template != "" || options.Print0) {
+		return nil, errs.InvalidInput("输出模板和NUL分隔只能用于文本格式")
+	}
+	fw := &FindWriter{w: w, format: format, end: "\n"}
+	if options.Print0 {
+		fw.end = "\x00"
+	}
+	if options.Template != "" {
+		tmpl, err := template.New("find").Funcs(template.FuncMap{"size": FormatSize}).Parse(unescapeTemplate(options.Template))
+		if err != nil {
+			return nil, errs.InvalidInput("无效的输出模板: %v", err)
+		}
+		fw.tmpl = tmpl
+	}
+	if format == FindOutputCSV {
+		fw.csv = csv.NewWriter(w)
+	}
+	return fw, nil
+}
+
+// unescapeTemplate 处理模板中的 \t、\n、\0 和 \\，在shell中不需要 $'...' 就能写出制表符等字符
+func unescapeTemplate(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n", `\0`, "\x00").Replace(s)
+}
+
+// Write 写入一条结果，CSV在第一条结果前写入表头
+func (fw *FindWriter) Write(result FindResult) error {
+	defer func() { fw.count++ }()
+
+	switch fw.format {
+	case FindOutputJSON:
+		line, err := json.Marshal(NewFindRecord(result))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(fw.w, "%s\n", line)
+		return err
+
+	case FindOutputCSV:
+		if fw.count == 0 {
+			if err := fw.csv.Write(findRecordFields); err != nil {
+				return err
+			}
+		}
+		record := NewFindRecord(result)
+		fw.csv.Write([]string{
+			record.Path,
+			record.Type,
+			strconv.FormatInt(record.Size, 10),
+			record.Mode,
+			record.ModTime.Format(time.RFC3339),
+			strconv.Itoa(record.Depth),
+		})
+		// 逐行刷新，边搜索边输出
+		fw.csv.Flush()
+		return fw.csv.Error()
+	}
+
+	if fw.tmpl != nil {
+		var b strings.Builder
+		if err := fw.tmpl.Execute(&b, NewFindRecord(result)); err != nil {
+			return errs.InvalidInput("执行输出模板失败: %v", err)
+		}
+		_, err := io.WriteString(fw.w, b.String()+fw.end)
+		return err
+	}
+	_, err := io.WriteString(fw.w, result.Path+fw.end)
+	return err
+}
+
+// Close 结束输出，没有任何结果时补写CSV的表头；不会关闭底层的writer
+func (fw *FindWriter) Close() error {
+	if fw.format != FindOutputCSV || fw.count > 0 {
+		return nil
+	}
+	fw.csv.Write(findRecordFields)
+	fw.csv.Flush()
+	return fw.csv.Error()
+}
@@ -0,0 +1,42 @@
+package fsutils
+
+import (
+	"path"
+	"strings"
+)
+
+// matchGlob 判断以 / 分隔的路径是否匹配模式，** 匹配任意层目录（包括零层），其余语法与 path.Match 相同
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// validGlob 检查模式的语法
+func validGlob(pattern string) error {
+	_, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), "")
+	return err
+}
@@ -0,0 +1,168 @@
+package fsutils
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitignoreFiles RespectGitignore 时每个目录中读取的忽略文件
+var gitignoreFiles = []string{".gitignore", ".ignore"}
+
+// ignoreRule 忽略文件中的一条规则
+type ignoreRule struct {
+	glob    string // 相对于忽略文件所在目录的模式，已转换为 matchGlob 的语法
+	negate  bool   // 以 ! 开头，重新包含之前被忽略的路径
+	dirOnly bool   // 以 / 结尾，只匹配目录
+}
+
+// ignoreMatcher 按 .gitignore 的规则判断路径是否被忽略。
+// 每个目录的忽略文件在遍历到该目录时读取，其中的规则作用于该目录下的所有路径；
+// 较深的目录中的规则优先，同一文件中靠后的规则优先
+type ignoreMatcher struct {
+	names []string                // 每个目录中读取的忽略文件名
+	rules map[string][]ignoreRule // 目录的绝对路径 → 该目录的规则
+	top   string                  // 最上层读取了忽略文件的目录，git仓库中为仓库根目录
+	git   bool                    // 跳过 .git 目录
+}
+
+// newIgnoreMatcher 根据搜索选项创建忽略规则，不需要时返回nil。
+// RespectGitignore 时root在git仓库中的话还会读取从仓库根目录到root之间各级目录的忽略文件和 .git/info/exclude
+func newIgnoreMatcher(root string, options FindOptions, warn func(string, error)) *ignoreMatcher {
+	if !options.RespectGitignore && len(options.IgnoreFiles) == 0 {
+		return nil
+	}
+	m := &ignoreMatcher{rules: make(map[string][]ignoreRule), top: root, git: options.RespectGitignore}
+	if options.RespectGitignore {
+		m.names = append(m.names, gitignoreFiles...)
+	}
+	m.names = append(m.names, options.IgnoreFiles...)
+
+	if options.RespectGitignore {
+		if repo, ok := gitRoot(root); ok {
+			m.top = repo
+			m.rules[repo] = readIgnoreFile(filepath.Join(repo, ".git", "info", "exclude"), warn)
+			for dir := root; dir != repo; dir = filepath.Dir(dir) {
+				if dir != root {
+					m.load(dir, warn)
+				}
+			}
+			if repo != root {
+				m.load(repo, warn)
+			}
+		}
+	}
+	return m
+}
+
+// gitRoot 从dir向上查找包含 .git 的目录
+func gitRoot(dir string) (string, bool) {
+	for {
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// load 读取目录中的忽略文件，追加到该目录已有的规则之后
+func (m *ignoreMatcher) load(dir string, warn func(string, error)) {
+	for _, name := range m.names {
+		m.rules[dir] = append(m.rules[dir], readIgnoreFile(filepath.Join(dir, name), warn)...)
+	}
+}
+
+// readIgnoreFile 按 .gitignore 的语法读取规则，文件不存在时返回nil，读取失败时警告
+func readIgnoreFile(path string, warn func(string, error)) []ignoreRule {
+	file, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			warn(path, err)
+		}
+		return nil
+	}
+	defer file.Close()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(scanner.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		warn(path, err)
+	}
+	return rules
+}
+
+// parseIgnoreRule 解析一行规则：# 开头为注释，! 开头为重新包含，\# 和 \! 转义；
+// 以 / 结尾只匹配目录；开头或中间有 / 时相对于忽略文件所在目录，否则匹配任意层级的文件名
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, "\r")
+	if !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" || validGlob(line) != nil {
+		return ignoreRule{}, false
+	}
+	if strings.Contains(line, "/") {
+		rule.glob = strings.TrimPrefix(line, "/")
+	} else {
+		rule.glob = "**/" + line
+	}
+	return rule, true
+}
+
+// ignored 判断路径是否被忽略，调用前path的各级上层目录都应已经检查过并读取了忽略文件
+func (m *ignoreMatcher) ignored(path string, isDir bool) bool {
+	if m.git && isDir && filepath.Base(path) == ".git" {
+		return true
+	}
+	// 从最上层的目录开始应用规则，后匹配的规则覆盖之前的结果
+	var dirs []string
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == m.top || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		rules := m.rules[dirs[i]]
+		if len(rules) == 0 {
+			continue
+		}
+		rel, err := filepath.Rel(dirs[i], path)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		for _, rule := range rules {
+			if (!rule.dirOnly || isDir) && matchGlob(rule.glob, rel) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
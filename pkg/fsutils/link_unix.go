@@ -0,0 +1,23 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileKey 唯一标识一个文件（设备号和inode），用于识别硬链接
+type fileKey struct {
+	dev, ino uint64
+}
+
+// hardLinkKey 返回有多个硬链接的普通文件的标识，只有一个链接或无法获取时ok为false
+func hardLinkKey(info os.FileInfo) (key fileKey, ok bool) {
+	st, isStat := info.Sys().(*syscall.Stat_t)
+	if !isStat || !info.Mode().IsRegular() || st.Nlink < 2 {
+		return fileKey{}, false
+	}
+	return fileKey{dev: uint64(st.Dev), ino: uint64(st.Ino)}, true
+}
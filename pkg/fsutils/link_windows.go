@@ -0,0 +1,16 @@
+//go:build windows
+// +build windows
+
+package fsutils
+
+import "os"
+
+// fileKey 唯一标识一个文件，用于识别硬链接
+type fileKey struct {
+	dev, ino uint64
+}
+
+// hardLinkKey Windows上的 os.FileInfo 不包含文件ID，不识别硬链接
+func hardLinkKey(info os.FileInfo) (key fileKey, ok bool) {
+	return fileKey{}, false
+}
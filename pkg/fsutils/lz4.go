@@ -0,0 +1,444 @@
+package fsutils
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"toolbox/pkg/i18n"
+
+	lz4block "github.com/bkaradzic/go-lz4"
+)
+
+// LZ4帧格式（https://github.com/lz4/lz4/blob/dev/doc/lz4_Frame_format.md），与 lz4 命令行工具兼容。
+// 块压缩使用 go-lz4，它只提供块格式，帧的读写在这里实现。
+const (
+	lz4FrameMagic     = 0x184D2204
+	lz4LegacyMagic    = 0x184C2102
+	lz4SkippableMagic = 0x184D2A50 // 0x184D2A50-0x184D2A5F 为可跳过的帧
+	lz4BlockSize      = 4 << 20    // 写入时使用4MB的块，与 lz4 -B7 相同
+	lz4Uncompressed   = 1 << 31    // 块大小的最高位表示块未压缩
+	lz4HistorySize    = 64 << 10   // 匹配最远引用64KB之前的数据
+)
+
+// lz4Writer 将数据压缩为LZ4帧，写入独立的块并在末尾附加内容校验和
+type lz4Writer struct {
+	w        io.Writer
+	buf      []byte
+	checksum xxh32
+	started  bool
+}
+
+// newLz4Writer 创建LZ4帧写入器，Close 时写入帧结束标记，不会关闭w
+func newLz4Writer(w io.Writer) *lz4Writer {
+	return &lz4Writer{w: w, buf: make([]byte, 0, lz4BlockSize)}
+}
+
+func (zw *lz4Writer) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := copy(zw.buf[len(zw.buf):cap(zw.buf)], p)
+		zw.buf = zw.buf[:len(zw.buf)+n]
+		p = p[n:]
+		written += n
+		if len(zw.buf) == cap(zw.buf) {
+			if err := zw.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// writeHeader 写入帧头：版本01、独立块、内容校验和、4MB块
+func (zw *lz4Writer) writeHeader() error {
+	zw.started = true
+	header := []byte{0, 0, 0, 0, 0x64, 0x70, 0}
+	binary.LittleEndian.PutUint32(header, lz4FrameMagic)
+	var hc xxh32
+	hc.Write(header[4:6])
+	header[6] = byte(hc.Sum32() >> 8)
+	_, err := zw.w.Write(header)
+	return err
+}
+
+// flush 压缩并写入缓冲区中的数据，压缩后不变小时按原样存储
+func (zw *lz4Writer) flush() error {
+	if !zw.started {
+		if err := zw.writeHeader(); err != nil {
+			return err
+		}
+	}
+	if len(zw.buf) == 0 {
+		return nil
+	}
+	zw.checksum.Write(zw.buf)
+
+	// go-lz4 的输出以4字节的原始长度开头，其后才是标准的LZ4块
+	encoded, err := lz4block.Encode(nil, zw.buf)
+	if err != nil {
+		return err
+	}
+	block := encoded[4:]
+	size := uint32(len(block))
+	if len(block) >= len(zw.buf) {
+		block = zw.buf
+		size = uint32(len(block)) | lz4Uncompressed
+	}
+
+	var prefix [4]byte
+	binary.LittleEndian.PutUint32(prefix[:], size)
+	if _, err := zw.w.Write(prefix[:]); err != nil {
+		return err
+	}
+	if _, err := zw.w.Write(block); err != nil {
+		return err
+	}
+	zw.buf = zw.buf[:0]
+	return nil
+}
+
+// Close 写入剩余的数据、结束标记和内容校验和
+func (zw *lz4Writer) Close() error {
+	if err := zw.flush(); err != nil {
+		return err
+	}
+	var trailer [8]byte
+	binary.LittleEndian.PutUint32(trailer[4:], zw.checksum.Sum32())
+	_, err := zw.w.Write(trailer[:])
+	return err
+}
+
+// lz4Reader 解压LZ4帧，支持多个连续的帧、可跳过的帧、依赖前一块的块以及块和内容校验和
+type lz4Reader struct {
+	r   io.Reader
+	out []byte // 前64KB为历史数据，供依赖前一块的块引用
+	pos int    // out 中尚未读取的数据的起始位置
+
+	inFrame       bool
+	maxBlock      int
+	blockChecksum bool
+	hasChecksum   bool
+	checksum      xxh32
+}
+
+// newLz4Reader 创建LZ4帧读取器
+func newLz4Reader(r io.Reader) *lz4Reader {
+	return &lz4Reader{r: r}
+}
+
+// errLz4Corrupt 压缩数据损坏
+var errLz4Corrupt = i18n.NewError("lz4数据已损坏")
+
+func (zr *lz4Reader) Read(p []byte) (int, error) {
+	for zr.pos == len(zr.out) {
+		if err := zr.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, zr.out[zr.pos:])
+	zr.pos += n
+	return n, nil
+}
+
+// next 读取下一个块，到达帧末尾时校验内容并开始读取下一帧
+func (zr *lz4Reader) next() error {
+	if !zr.inFrame {
+		return zr.readHeader()
+	}
+
+	var prefix [4]byte
+	if _, err := io.ReadFull(zr.r, prefix[:]); err != nil {
+		return unexpectedEOF(err)
+	}
+	size := binary.LittleEndian.Uint32(prefix[:])
+	if size == 0 {
+		zr.inFrame = false
+		return zr.readTrailer()
+	}
+	compressed := size&lz4Uncompressed == 0
+	size &^= lz4Uncompressed
+	if int(size) > zr.maxBlock {
+		return errLz4Corrupt
+	}
+	block := make([]byte, size)
+	if _, err := io.ReadFull(zr.r, block); err != nil {
+		return unexpectedEOF(err)
+	}
+	if zr.blockChecksum {
+		var sum [4]byte
+		if _, err := io.ReadFull(zr.r, sum[:]); err != nil {
+			return unexpectedEOF(err)
+		}
+		var h xxh32
+		h.Write(block)
+		if h.Sum32() != binary.LittleEndian.Uint32(sum[:]) {
+			return i18n.Errorf("lz4块校验和不匹配")
+		}
+	}
+
+	// 只保留最后64KB作为历史数据
+	if len(zr.out) > lz4HistorySize {
+		zr.out = append(zr.out[:0], zr.out[len(zr.out)-lz4HistorySize:]...)
+	}
+	start := len(zr.out)
+	if compressed {
+		out, err := lz4DecodeBlock(zr.out, block, start+zr.maxBlock)
+		if err != nil {
+			return err
+		}
+		zr.out = out
+	} else {
+		zr.out = append(zr.out, block...)
+	}
+	zr.pos = start
+	if zr.hasChecksum {
+		zr.checksum.Write(zr.out[start:])
+	}
+	return nil
+}
+
+// readHeader 读取帧头，跳过可跳过的帧；输入结束时返回 io.EOF
+func (zr *lz4Reader) readHeader() error {
+	for skipped := true; skipped; {
+		var magic [4]byte
+		if _, err := io.ReadFull(zr.r, magic[:]); err != nil {
+			return err
+		}
+		switch m := binary.LittleEndian.Uint32(magic[:]); {
+		case m&0xFFFFFFF0 == lz4SkippableMagic:
+			var size [4]byte
+			if _, err := io.ReadFull(zr.r, size[:]); err != nil {
+				return unexpectedEOF(err)
+			}
+			if _, err := io.CopyN(io.Discard, zr.r, int64(binary.LittleEndian.Uint32(size[:]))); err != nil {
+				return unexpectedEOF(err)
+			}
+		case m == lz4LegacyMagic:
+			return i18n.Errorf("不支持旧版的lz4格式（lz4 -l），请使用 lz4 命令解压")
+		case m != lz4FrameMagic:
+			return i18n.Errorf("不是lz4格式的文件")
+		default:
+			skipped = false
+		}
+	}
+
+	var descriptor [2]byte
+	if _, err := io.ReadFull(zr.r, descriptor[:]); err != nil {
+		return unexpectedEOF(err)
+	}
+	flg, bd := descriptor[0], descriptor[1]
+	if flg>>6 != 1 {
+		return i18n.Errorf("不支持的lz4帧版本: %d", flg>>6)
+	}
+	if flg&0x01 != 0 {
+		return i18n.Errorf("不支持使用字典压缩的lz4文件")
+	}
+	switch (bd >> 4) & 0x07 {
+	case 4:
+		zr.maxBlock = 64 << 10
+	case 5:
+		zr.maxBlock = 256 << 10
+	case 6:
+		zr.maxBlock = 1 << 20
+	case 7:
+		zr.maxBlock = 4 << 20
+	default:
+		return errLz4Corrupt
+	}
+	zr.blockChecksum = flg&0x10 != 0
+	zr.hasChecksum = flg&0x04 != 0
+
+	// 可选的8字节内容大小，之后是帧头校验字节
+	rest := make([]byte, 1)
+	if flg&0x08 != 0 {
+		rest = make([]byte, 9)
+	}
+	if _, err := io.ReadFull(zr.r, rest); err != nil {
+		return unexpectedEOF(err)
+	}
+	var hc xxh32
+	hc.Write(descriptor[:])
+	hc.Write(rest[:len(rest)-1])
+	if byte(hc.Sum32()>>8) != rest[len(rest)-1] {
+		return i18n.Errorf("lz4帧头校验失败")
+	}
+
+	zr.inFrame = true
+	zr.checksum = xxh32{}
+	zr.out = zr.out[:0]
+	zr.pos = 0
+	return nil
+}
+
+// readTrailer 读取并检查帧末尾的内容校验和
+func (zr *lz4Reader) readTrailer() error {
+	if !zr.hasChecksum {
+		return nil
+	}
+	var sum [4]byte
+	if _, err := io.ReadFull(zr.r, sum[:]); err != nil {
+		return unexpectedEOF(err)
+	}
+	if zr.checksum.Sum32() != binary.LittleEndian.Uint32(sum[:]) {
+		return i18n.Errorf("lz4内容校验和不匹配，文件可能已损坏")
+	}
+	return nil
+}
+
+// unexpectedEOF 帧中间的 io.EOF 表示文件被截断
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// lz4DecodeBlock 解压一个LZ4块并追加到dst，匹配可以引用dst中已有的数据；输出不超过limit字节
+func lz4DecodeBlock(dst, src []byte, limit int) ([]byte, error) {
+	i := 0
+	readLength := func(length int) (int, error) {
+		if length != 15 {
+			return length, nil
+		}
+		for {
+			if i >= len(src) {
+				return 0, errLz4Corrupt
+			}
+			b := src[i]
+			i++
+			length += int(b)
+			if b != 255 {
+				return length, nil
+			}
+		}
+	}
+
+	for {
+		if i >= len(src) {
+			return nil, errLz4Corrupt
+		}
+		token := src[i]
+		i++
+
+		literals, err := readLength(int(token >> 4))
+		if err != nil {
+			return nil, err
+		}
+		if literals > len(src)-i || len(dst)+literals > limit {
+			return nil, errLz4Corrupt
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+		// 最后一个序列只有字面量
+		if i == len(src) {
+			return dst, nil
+		}
+
+		if i+2 > len(src) {
+			return nil, errLz4Corrupt
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, errLz4Corrupt
+		}
+		match, err := readLength(int(token & 0x0F))
+		if err != nil {
+			return nil, err
+		}
+		match += 4
+		if len(dst)+match > limit {
+			return nil, errLz4Corrupt
+		}
+
+		from := len(dst) - offset
+		if offset >= match {
+			dst = append(dst, dst[from:from+match]...)
+			continue
+		}
+		// 匹配与输出重叠时逐字节复制，用于重复的短模式
+		for k := 0; k < match; k++ {
+			dst = append(dst, dst[from+k])
+		}
+	}
+}
+
+// xxh32 流式计算XXH32哈希（种子为0），用于LZ4帧的校验
+type xxh32 struct {
+	v     [4]uint32
+	buf   [16]byte
+	n     int // buf 中的字节数
+	total uint64
+	init  bool
+}
+
+const (
+	xxhPrime1 uint32 = 2654435761
+	xxhPrime2 uint32 = 2246822519
+	xxhPrime3 uint32 = 3266489917
+	xxhPrime4 uint32 = 668265263
+	xxhPrime5 uint32 = 374761393
+)
+
+func xxhRound(acc, input uint32) uint32 {
+	return bits.RotateLeft32(acc+input*xxhPrime2, 13) * xxhPrime1
+}
+
+func (h *xxh32) Write(p []byte) {
+	if !h.init {
+		p1 := xxhPrime1
+		h.v = [4]uint32{p1 + xxhPrime2, xxhPrime2, 0, -p1}
+		h.init = true
+	}
+	h.total += uint64(len(p))
+	if h.n > 0 {
+		c := copy(h.buf[h.n:], p)
+		h.n += c
+		p = p[c:]
+		if h.n < 16 {
+			return
+		}
+		h.stripe(h.buf[:])
+		h.n = 0
+	}
+	for len(p) >= 16 {
+		h.stripe(p[:16])
+		p = p[16:]
+	}
+	h.n = copy(h.buf[:], p)
+}
+
+func (h *xxh32) stripe(p []byte) {
+	for i := range h.v {
+		h.v[i] = xxhRound(h.v[i], binary.LittleEndian.Uint32(p[i*4:]))
+	}
+}
+
+func (h *xxh32) Sum32() uint32 {
+	var sum uint32
+	if h.total >= 16 {
+		sum = bits.RotateLeft32(h.v[0], 1) + bits.RotateLeft32(h.v[1], 7) +
+			bits.RotateLeft32(h.v[2], 12) + bits.RotateLeft32(h.v[3], 18)
+	} else {
+		sum = xxhPrime5
+	}
+	sum += uint32(h.total)
+
+	p := h.buf[:h.n]
+	for len(p) >= 4 {
+		sum += binary.LittleEndian.Uint32(p) * xxhPrime3
+		sum = bits.RotateLeft32(sum, 17) * xxhPrime4
+		p = p[4:]
+	}
+	for _, b := range p {
+		sum += uint32(b) * xxhPrime5
+		sum = bits.RotateLeft32(sum, 11) * xxhPrime1
+	}
+
+	sum ^= sum >> 15
+	sum *= xxhPrime2
+	sum ^= sum >> 13
+	sum *= xxhPrime3
+	sum ^= sum >> 16
+	return sum
+}
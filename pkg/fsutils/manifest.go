@@ -0,0 +1,217 @@
+package fsutils
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// checksums 文件的SHA-256校验和清单，格式与 sha256sum 的输出相同（每行为 “校验和  文件名”），
+// 可以直接用 sha256sum -c 检查。压缩时记录源文件在压缩文件中的路径和压缩文件本身的文件名，
+// 解压缩时按清单检查每个文件的内容
+type checksums struct {
+	names   []string          // 按记录的顺序
+	sums    map[string]string // 文件名 → 十六进制的校验和
+	checked map[string]bool   // 解压缩时已经检查过的文件
+	mu      sync.Mutex        // 并行解压时保护 checked
+}
+
+func newChecksums() *checksums {
+	return &checksums{sums: make(map[string]string), checked: make(map[string]bool)}
+}
+
+// readChecksums 读取清单文件，支持 sha256sum 的文本和二进制（文件名前为 *）格式以及转义的文件名
+func readChecksums(path string) (*checksums, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errs.Wrap(err, "无法读取校验和清单: %v", err)
+	}
+	defer file.Close()
+
+	c := newChecksums()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		escaped := strings.HasPrefix(text, "\\")
+		text = strings.TrimPrefix(text, "\\")
+		sum, name, ok := strings.Cut(text, " ")
+		if !ok || len(sum) != sha256.Size*2 || len(name) < 2 || (name[0] != ' ' && name[0] != '*') {
+			return nil, i18n.Errorf("校验和清单第 %d 行格式错误，应为 sha256sum 的格式", line)
+		}
+		if _, err := hex.DecodeString(sum); err != nil {
+			return nil, i18n.Errorf("校验和清单第 %d 行的校验和无效", line)
+		}
+		name = name[1:]
+		if escaped {
+			name = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\r`, "\r").Replace(name)
+		}
+		c.add(strings.TrimPrefix(name, "./"), strings.ToLower(sum))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errs.Wrap(err, "无法读取校验和清单: %v", err)
+	}
+	return c, nil
+}
+
+func (c *checksums) add(name, sum string) {
+	if _, ok := c.sums[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.sums[name] = sum
+}
+
+// addFile 计算文件的校验和并以name记录，用于压缩文件本身
+func (c *checksums) addFile(name, path string) error {
+	sum, err := fileSum(path, nil)
+	if err != nil {
+		return err
+	}
+	c.add(name, sum)
+	return nil
+}
+
+// fileSum 计算文件的SHA-256校验和；p不为nil时读取前检查取消，但不计入进度
+func fileSum(path string, p *progress) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	var r io.Reader = file
+	if p != nil {
+		r = newProgress(p.ctx, nil, 0).reader(file)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// write 按 sha256sum 的格式写入清单文件，文件名中的反斜杠和换行按 sha256sum 的规则转义
+func (c *checksums) write(path string) error {
+	var b strings.Builder
+	for _, name := range c.names {
+		line := name
+		if strings.ContainsAny(name, "\\\n\r") {
+			line = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`).Replace(name)
+			b.WriteString("\\")
+		}
+		fmt.Fprintf(&b, "%s  %s\n", c.sums[name], line)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return errs.Wrap(err, "无法写入校验和清单: %v", err)
+	}
+	return nil
+}
+
+// record 返回读取r的同时计算校验和的reader，读完后将校验和记录为name；c为nil时直接返回r
+func (c *checksums) record(name string, r io.Reader) io.Reader {
+	if c == nil {
+		return r
+	}
+	return &hashReader{r: r, h: sha256.New(), done: func(sum string) error {
+		c.add(name, sum)
+		return nil
+	}}
+}
+
+// verify 返回读取r的同时计算校验和的reader，读完时与清单中name的校验和比较，不一致时返回错误而不是 io.EOF；
+// c为nil或清单中没有该文件时直接返回r
+func (c *checksums) verify(name string, r io.Reader) io.Reader {
+	if c == nil {
+		return r
+	}
+	name = strings.TrimPrefix(name, "./")
+	want, ok := c.sums[name]
+	if !ok {
+		return r
+	}
+	return &hashReader{r: r, h: sha256.New(), done: func(sum string) error {
+		c.mu.Lock()
+		c.checked[name] = true
+		c.mu.Unlock()
+		if sum != want {
+			return i18n.Errorf("SHA-256 校验和与清单不一致")
+		}
+		return nil
+	}}
+}
+
+// single 返回单文件格式的压缩文件中的文件在清单中的名称：清单中除压缩文件本身外只有一个文件时返回该文件名
+func (c *checksums) single() string {
+	var found []string
+	for _, name := range c.names {
+		if !isArchiveName(name) {
+			found = append(found, name)
+		}
+	}
+	if len(found) == 1 {
+		return found[0]
+	}
+	return ""
+}
+
+// missing 返回清单中有但压缩文件中没有的文件的错误，不包括压缩文件本身的记录
+func (c *checksums) missing() error {
+	if c == nil {
+		return nil
+	}
+	var names []string
+	for _, name := range c.names {
+		if !c.checked[name] && !isArchiveName(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	const limit = 5
+	list := strings.Join(names[:min(len(names), limit)], ", ")
+	if len(names) > limit {
+		list += i18n.Tf(" 等 %d 个文件", len(names))
+	}
+	return errs.NotFound("校验和清单中的文件不在压缩文件中: %s", list)
+}
+
+// zipVolumeExt 分卷zip除最后一个分卷外的扩展名
+var zipVolumeExt = regexp.MustCompile(`\.z[0-9]{2,}$`)
+
+// isArchiveName 判断清单中的记录是否为压缩文件本身：压缩时写在清单末尾，没有目录，扩展名为压缩格式或分卷zip
+func isArchiveName(name string) bool {
+	if strings.Contains(name, "/") {
+		return false
+	}
+	_, ok := formatFromName(name)
+	return ok || zipVolumeExt.MatchString(name)
+}
+
+// hashReader 读取的同时计算校验和，读完时调用done
+type hashReader struct {
+	r    io.Reader
+	h    hash.Hash
+	done func(sum string) error
+}
+
+func (hr *hashReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.h.Write(p[:n])
+	if err == io.EOF {
+		if doneErr := hr.done(hex.EncodeToString(hr.h.Sum(nil))); doneErr != nil {
+			return n, doneErr
+		}
+	}
+	return n, err
+}
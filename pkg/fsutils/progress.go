@@ -0,0 +1,134 @@
+package fsutils
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+// ProgressFunc 报告压缩或解压缩的进度，current 和 total 为已处理和总共的字节数，path 为正在处理的文件。
+// 压缩时按源文件的大小计算，解压缩时按已读取的压缩文件大小计算。
+// 每开始处理一个文件以及每读取一块数据（约32KB）时调用，需要限制刷新频率的调用方应自行节流。
+// 流式解压缩（DecompressFrom）无法预先得知总大小，total 为0。
+type ProgressFunc func(current, total int64, path string)
+
+// progress 累计已处理的字节数并调用 ProgressFunc；fn 为nil时不做任何事。
+// 压缩和解压缩的数据都经过它包装的reader，所以也在每次读取前检查ctx是否已取消。
+// 并行解压时多个goroutine同时读取，mu 保证按顺序调用 ProgressFunc
+type progress struct {
+	ctx     context.Context
+	fn      ProgressFunc
+	mu      sync.Mutex
+	current int64
+	total   int64
+	path    string
+}
+
+// newProgress 创建进度统计，fn 为nil时返回的progress也可以正常使用
+func newProgress(ctx context.Context, fn ProgressFunc, total int64) *progress {
+	return &progress{ctx: ctx, fn: fn, total: total}
+}
+
+// passthrough 既不需要报告进度也不会被取消时，reader不需要包装
+func (p *progress) passthrough() bool {
+	return p.fn == nil && p.ctx.Done() == nil
+}
+
+// start 开始处理一个文件
+func (p *progress) start(path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.path = path
+	p.report()
+}
+
+// add 增加已处理的字节数
+func (p *progress) add(n int64) {
+	if n <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current += n
+	p.report()
+}
+
+// done 报告已全部完成；tar等格式读到结束标记后不会读取压缩文件末尾的校验数据
+func (p *progress) done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.total > 0 {
+		p.current = p.total
+	}
+	p.report()
+}
+
+// report 调用 ProgressFunc，调用方需持有 mu
+func (p *progress) report() {
+	if p.fn == nil {
+		return
+	}
+	// 压缩过程中文件可能变大，或解压缩时重复读取了压缩文件的某些部分
+	current := p.current
+	if p.total > 0 && current > p.total {
+		current = p.total
+	}
+	p.fn(current, p.total, p.path)
+}
+
+// reader 返回读取时累计进度并检查取消的reader
+func (p *progress) reader(r io.Reader) io.Reader {
+	if p.passthrough() {
+		return r
+	}
+	return &progressReader{r: r, p: p}
+}
+
+// readerAt 返回读取时累计进度并检查取消的ReaderAt，用于需要随机访问的zip和7z
+func (p *progress) readerAt(r io.ReaderAt) io.ReaderAt {
+	if p.passthrough() {
+		return r
+	}
+	return &progressReaderAt{r: r, p: p}
+}
+
+type progressReader struct {
+	r io.Reader
+	p *progress
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	if err := pr.p.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := pr.r.Read(b)
+	pr.p.add(int64(n))
+	return n, err
+}
+
+type progressReaderAt struct {
+	r io.ReaderAt
+	p *progress
+}
+
+func (pr *progressReaderAt) ReadAt(b []byte, off int64) (int, error) {
+	if err := pr.p.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := pr.r.ReadAt(b, off)
+	pr.p.add(int64(n))
+	return n, err
+}
+
+// sourceSize 计算要压缩的普通文件的总大小，跳过排除的文件
+func sourceSize(src string, isDir bool, filter *sourceFilter) (int64, error) {
+	var total int64
+	err := walkSource(src, isDir, filter, func(path, name string, info os.FileInfo) error {
+		if info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
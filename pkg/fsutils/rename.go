@@ -0,0 +1,252 @@
+package fsutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"unicode"
+)
+
+// 重命名时的大小写转换
+const (
+	RenameCaseUpper = "upper" // 全部大写
+	RenameCaseLower = "lower" // 全部小写
+	RenameCaseTitle = "title" // 每个单词首字母大写，其余小写
+)
+
+// RenameOptions 批量重命名的选项
+type RenameOptions struct {
+	IgnoreCase bool   // 匹配时忽略大小写
+	Dirs       bool   // 同时重命名目录，默认只重命名文件
+	Case       string // 对替换后的名称做大小写转换：upper、lower、title，为空时不转换
+	Start      int    // 替换文本中 {n} 的起始值
+	DryRun     bool   // 只计算新名称并检查冲突，不实际重命名
+}
+
+// Rename 一项重命名，名称都是目录中的文件名
+type Rename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// BatchRename 将目录中文件名匹配正则表达式pattern的文件重命名，新名称为将所有匹配替换为replacement的结果。
+// replacement 中可以使用 $1、${name} 引用捕获组，{n} 为按文件名排序的序号（从 Start 开始），
+// {n:3} 将序号补零到3位。所有新名称先检查是否重复、是否与不参与重命名的文件同名，有冲突时不做任何修改；
+// 互相交换名称的文件先改为临时名称。重命名中途失败时撤销已完成的重命名。
+// 返回名称有变化的文件，新名称与原名称相同的文件不包括在内
+func BatchRename(dir, pattern, replacement string, opts RenameOptions) ([]Rename, error) {
+	if opts.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errs.InvalidInput("无效的正则表达式: %v", err)
+	}
+	switch opts.Case {
+	case "", RenameCaseUpper, RenameCaseLower, RenameCaseTitle:
+	default:
+		return nil, errs.InvalidInput("无效的大小写转换 %q，可选 upper、lower、title", opts.Case)
+	}
+	template, err := parseRenameTemplate(replacement)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errs.Wrap(err, "无法读取目录 %s: %v", dir, err)
+	}
+
+	var renames []Rename
+	existing := make(map[string]bool, len(entries))
+	seq := opts.Start
+	for _, entry := range entries {
+		existing[entry.Name()] = true
+		if entry.IsDir() && !opts.Dirs {
+			continue
+		}
+		matches := re.FindAllStringSubmatchIndex(entry.Name(), -1)
+		if matches == nil {
+			continue
+		}
+		name := template.expand(re, entry.Name(), matches, seq)
+		seq++
+		name = convertCase(name, opts.Case)
+		if name == entry.Name() {
+			continue
+		}
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/`+string(filepath.Separator)) {
+			return nil, errs.InvalidInput("%s 的新名称 %q 不是有效的文件名", entry.Name(), name)
+		}
+		renames = append(renames, Rename{From: entry.Name(), To: name})
+	}
+
+	if err := checkRenameConflicts(dir, renames, existing); err != nil {
+		return nil, err
+	}
+	if opts.DryRun || len(renames) == 0 {
+		return renames, nil
+	}
+	if err := applyRenames(dir, renames); err != nil {
+		return nil, err
+	}
+	return renames, nil
+}
+
+// renameTemplate 按 {n} 拆分的替换文本，每段分别展开捕获组，避免 $1{n} 中的序号被当作捕获组的编号
+type renameTemplate struct {
+	parts []string // {n} 之间的替换文本，比 width 多一项
+	width []int    // 每个 {n} 补零后的宽度，0表示不补零
+}
+
+var renameCounter = regexp.MustCompile(`\{n(?::(\d+))?\}`)
+
+func parseRenameTemplate(replacement string) (*renameTemplate, error) {
+	t := &renameTemplate{}
+	last := 0
+	for _, loc := range renameCounter.FindAllStringSubmatchIndex(replacement, -1) {
+		t.parts = append(t.parts, replacement[last:loc[0]])
+		width := 0
+		if loc[2] >= 0 {
+			width, _ = strconv.Atoi(replacement[loc[2]:loc[3]])
+			if width > 20 {
+				return nil, errs.InvalidInput("序号宽度 %d 过大", width)
+			}
+		}
+		t.width = append(t.width, width)
+		last = loc[1]
+	}
+	t.parts = append(t.parts, replacement[last:])
+	return t, nil
+}
+
+// expand 将name中的每个匹配替换为展开后的替换文本，seq 为该文件的序号
+func (t *renameTemplate) expand(re *regexp.Regexp, name string, matches [][]int, seq int) string {
+	var b []byte
+	last := 0
+	for _, match := range matches {
+		b = append(b, name[last:match[0]]...)
+		for i, part := range t.parts {
+			b = re.ExpandString(b, part, name, match)
+			if i < len(t.width) {
+				b = append(b, fmt.Sprintf("%0*d", t.width[i], seq)...)
+			}
+		}
+		last = match[1]
+	}
+	return string(append(b, name[last:]...))
+}
+
+// convertCase 按mode转换name的大小写
+func convertCase(name, mode string) string {
+	switch mode {
+	case RenameCaseUpper:
+		return strings.ToUpper(name)
+	case RenameCaseLower:
+		return strings.ToLower(name)
+	case RenameCaseTitle:
+		runes := []rune(name)
+		start := true
+		for i, r := range runes {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				if start {
+					runes[i] = unicode.ToUpper(r)
+				} else {
+					runes[i] = unicode.ToLower(r)
+				}
+				start = false
+			} else {
+				start = r != '\''
+			}
+		}
+		return string(runes)
+	}
+	return name
+}
+
+// checkRenameConflicts 检查新名称是否重复，或与目录中不参与重命名的文件同名。
+// 只改变大小写时在不区分大小写的文件系统上目标“已存在”，但与原文件是同一个文件，不算冲突
+func checkRenameConflicts(dir string, renames []Rename, existing map[string]bool) error {
+	sources := make(map[string]bool, len(renames))
+	for _, r := range renames {
+		sources[r.From] = true
+	}
+
+	var conflicts []string
+	targets := make(map[string]string, len(renames))
+	for _, r := range renames {
+		if other, ok := targets[r.To]; ok {
+			conflicts = append(conflicts, i18n.Tf("%s 和 %s 都将重命名为 %s", other, r.From, r.To))
+			continue
+		}
+		targets[r.To] = r.From
+		if sources[r.To] {
+			continue
+		}
+		if existing[r.To] {
+			conflicts = append(conflicts, i18n.Tf("%s 的新名称 %s 已存在", r.From, r.To))
+			continue
+		}
+		target, err := os.Lstat(filepath.Join(dir, r.To))
+		if err != nil {
+			continue
+		}
+		if source, err := os.Lstat(filepath.Join(dir, r.From)); err == nil && os.SameFile(source, target) {
+			continue
+		}
+		conflicts = append(conflicts, i18n.Tf("%s 的新名称 %s 已存在", r.From, r.To))
+	}
+	if len(conflicts) > 0 {
+		return errs.InvalidInput("重命名冲突，没有修改任何文件:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+	return nil
+}
+
+// applyRenames 执行重命名。新名称被另一个参与重命名的文件占用时，先将该文件改为临时名称；
+// 出错时按相反的顺序撤销已完成的重命名
+func applyRenames(dir string, renames []Rename) error {
+	targets := make(map[string]bool, len(renames))
+	for _, r := range renames {
+		targets[r.To] = true
+	}
+
+	type move struct{ from, to string }
+	var done []move
+	rename := func(from, to string) error {
+		if err := os.Rename(filepath.Join(dir, from), filepath.Join(dir, to)); err != nil {
+			return err
+		}
+		done = append(done, move{from, to})
+		return nil
+	}
+	rollback := func(err error) error {
+		for i := len(done) - 1; i >= 0; i-- {
+			os.Rename(filepath.Join(dir, done[i].to), filepath.Join(dir, done[i].from))
+		}
+		return errs.Wrap(err, "重命名失败，已撤销所有修改: %v", err)
+	}
+
+	current := make([]string, len(renames))
+	for i, r := range renames {
+		current[i] = r.From
+		if !targets[r.From] {
+			continue
+		}
+		temp := fmt.Sprintf(".%s.rename-%d-%d", r.From, os.Getpid(), i)
+		if err := rename(r.From, temp); err != nil {
+			return rollback(err)
+		}
+		current[i] = temp
+	}
+	for i, r := range renames {
+		if err := rename(current[i], r.To); err != nil {
+			return rollback(err)
+		}
+	}
+	return nil
+}
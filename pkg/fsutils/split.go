@@ -1,32 +1,59 @@
 package fsutils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // SplitOptions 分片选项
 type SplitOptions struct {
-	SourceDir    string         // 源目录
-	OutputDir    string         // 输出目录
-	ChunkSize    int64          // 分片大小（字节）
-	CompressType CompressFormat // 压缩类型
-	ThreadCount  int            // 线程数
-	DeleteSource bool           // 是否删除源文件
+	SourceDir    string            // 源目录
+	OutputDir    string            // 输出目录
+	ChunkSize    int64             // 分片大小（字节）
+	CompressType CompressFormat    // 压缩类型
+	ThreadCount  int               // tar.gz 并行压缩的线程数
+	DeleteSource bool              // 是否删除源文件
+	Progress     SplitProgressFunc // 进度回调，为nil时不报告进度
 }
 
+// SplitPhase 分片所处的阶段
+type SplitPhase string
+
+const (
+	SplitPhaseCompress SplitPhase = "compress" // 打包压缩并写入分片
+	SplitPhaseDelete   SplitPhase = "delete"   // 全部分片完成后删除源目录
+)
+
+// SplitProgress 分片的进度
+type SplitProgress struct {
+	Phase      SplitPhase
+	Current    int64  // 已读取的源文件的字节数
+	Total      int64  // 源目录中要压缩的文件的总大小
+	Compressed int64  // 已写入分片的压缩数据的字节数
+	Chunks     int    // 已写完的分片数量
+	Path       string // 正在压缩的文件
+}
+
+// SplitProgressFunc 报告分片的进度。与 ProgressFunc 一样每处理一块数据调用一次，
+// 需要限制刷新频率的调用方应自行节流
+type SplitProgressFunc func(SplitProgress)
+
 // validateSplitOptions 验证分片选项
 func validateSplitOptions(opts *SplitOptions) error {
 	// 检查源目录
 	if opts.SourceDir == "" {
-		return fmt.Errorf("源目录不能为空")
+		return errs.InvalidInput("源目录不能为空")
 	}
 	if _, err := os.Stat(opts.SourceDir); err != nil {
-		return fmt.Errorf("源目录不存在: %v", err)
+		return errs.Wrap(err, "源目录不存在: %v", err)
 	}
 
 	// 检查输出目录
@@ -36,7 +63,7 @@ func validateSplitOptions(opts *SplitOptions) error {
 
 	// 检查分片大小
 	if opts.ChunkSize <= 0 {
-		return fmt.Errorf("分片大小必须大于0")
+		return errs.InvalidInput("分片大小必须大于0")
 	}
 
 	// 检查线程数
@@ -50,6 +77,43 @@ func validateSplitOptions(opts *SplitOptions) error {
 	return nil
 }
 
+// SplitPlan 分片前对源目录的统计，用于预演
+type SplitPlan struct {
+	SourceDir string // 源目录
+	OutputDir string // 输出目录
+	FileCount int    // 源目录中的文件数
+	TotalSize int64  // 源目录中文件的总大小（字节）
+	MaxChunks int64  // 分片数量上限，按未压缩大小估算
+}
+
+// PlanSplit 验证分片选项并统计源目录，不创建或删除任何文件
+func PlanSplit(opts *SplitOptions) (*SplitPlan, error) {
+	if err := validateSplitOptions(opts); err != nil {
+		return nil, err
+	}
+
+	plan := &SplitPlan{SourceDir: opts.SourceDir, OutputDir: opts.OutputDir}
+	err := filepath.Walk(opts.SourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			plan.FileCount++
+			plan.TotalSize += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errs.Wrap(err, "统计源目录失败: %v", err)
+	}
+
+	plan.MaxChunks = (plan.TotalSize + opts.ChunkSize - 1) / opts.ChunkSize
+	if plan.MaxChunks == 0 {
+		plan.MaxChunks = 1
+	}
+	return plan, nil
+}
+
 // SplitArchive 将目录打包并分片
 func SplitArchive(opts *SplitOptions) error {
 	// 验证选项
@@ -60,32 +124,27 @@ func SplitArchive(opts *SplitOptions) error {
 	// 获取输出目录的绝对路径
 	outputAbs, err := filepath.Abs(opts.OutputDir)
 	if err != nil {
-		return fmt.Errorf("获取输出目录绝对路径失败: %v", err)
+		return i18n.Errorf("获取输出目录绝对路径失败: %v", err)
 	}
 
 	// 创建输出目录
 	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
-		return fmt.Errorf("创建输出目录失败: %v", err)
+		return errs.Wrap(err, "创建输出目录失败: %v", err)
 	}
 
-	// 生成临时压缩文件名和基础文件名
-	tempArchive := filepath.Join(opts.OutputDir, "temp_archive")
+	// 生成分片的基础文件名
 	baseFileName := filepath.Base(opts.SourceDir)
 	switch opts.CompressType {
 	case ZIP:
-		tempArchive += ".zip"
 		baseFileName += ".zip"
 	case TARGZ:
-		tempArchive += ".tar.gz"
 		baseFileName += ".tar.gz"
 	case TARBZ2:
-		tempArchive += ".tar.bz2"
 		baseFileName += ".tar.bz2"
 	case TARXZ:
-		tempArchive += ".tar.xz"
 		baseFileName += ".tar.xz"
 	default:
-		return fmt.Errorf("不支持的压缩格式: %v", opts.CompressType)
+		return errs.InvalidInput("不支持的压缩格式: %v", opts.CompressType)
 	}
 
 	// 创建一个自定义的压缩选项，排除输出目录
@@ -93,23 +152,89 @@ func SplitArchive(opts *SplitOptions) error {
 		Format:       opts.CompressType,
 		Level:        6,                   // 使用默认压缩级别
 		ExcludePaths: []string{outputAbs}, // 排除输出目录
+		Parallel:     opts.ThreadCount,
+	}
+
+	report := newSplitReporter(opts.Progress)
+	if opts.Progress != nil {
+		compressOpts.Progress = func(current, total int64, path string) {
+			report.update(func(s *SplitProgress) {
+				s.Phase, s.Current, s.Total, s.Path = SplitPhaseCompress, current, total, path
+			})
+		}
 	}
 
-	// 先将目录压缩
-	if err := Compress(opts.SourceDir, tempArchive, compressOpts); err != nil {
-		return fmt.Errorf("压缩失败: %v", err)
+	// 上次中断时记录的已完成的分片，再次写入时只比较不重写相同的部分
+	srcInfo, err := os.Stat(opts.SourceDir)
+	if err != nil {
+		return errs.Wrap(err, "源目录不存在: %v", err)
+	}
+	state, err := loadSplitState(opts.OutputDir, baseFileName, srcInfo, opts.ChunkSize)
+	if err != nil {
+		return err
+	}
+
+	// 压缩的数据直接写入分片，不需要先写入完整的临时文件；出错时保留已完成的分片，再次分片时可以继续
+	cw := newChunkWriter(opts.OutputDir, baseFileName, opts.ChunkSize, state, report)
+	if err := CompressTo(opts.SourceDir, cw, compressOpts); err != nil {
+		cw.close()
+		return i18n.Errorf("压缩失败: %v", err)
+	}
+	if err := cw.finish(); err != nil {
+		return err
+	}
+
+	// 如果需要删除源目录
+	if opts.DeleteSource {
+		report.update(func(s *SplitProgress) { s.Phase, s.Path = SplitPhaseDelete, "" })
+		if err := os.RemoveAll(opts.SourceDir); err != nil {
+			return i18n.Errorf("删除源目录失败: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// SplitFile 将已有的文件（如ISO镜像、数据库备份）直接切分为编号的分片，不打包也不压缩。
+// 分片写入outDir（为空时为 path_chunks），命名为 文件名.001、文件名.002 …，可以用 MergeChunks 合并还原；
+// 空文件也会生成一个空的分片
+func SplitFile(path string, chunkSize int64, outDir string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return errs.Wrap(err, "无法访问文件: %v", err)
+	}
+	if !info.Mode().IsRegular() {
+		return errs.InvalidInput("%s 不是普通文件", path)
+	}
+	if chunkSize <= 0 {
+		return errs.InvalidInput("分片大小必须大于0")
+	}
+	if outDir == "" {
+		outDir = path + "_chunks"
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return errs.Wrap(err, "创建输出目录失败: %v", err)
 	}
-	defer os.Remove(tempArchive) // 最后清理临时文件
+	return splitFileChunks(path, outDir, filepath.Base(path), chunkSize, runtime.NumCPU())
+}
 
-	// 获取压缩文件大小
-	stat, err := os.Stat(tempArchive)
+// splitFileChunks 用多个线程将文件按chunkSize切分为 baseFileName.001 等分片，
+// 完成后在输出目录中写入记录每个分片和整个文件的校验和的清单。
+// 切分时在输出目录中记录已完成的分片，中断后再次切分同一文件时跳过已存在且校验和一致的分片
+func splitFileChunks(srcFile, outDir, baseFileName string, chunkSize int64, threads int) error {
+	// 获取文件大小
+	stat, err := os.Stat(srcFile)
+	if err != nil {
+		return i18n.Errorf("获取文件大小失败: %v", err)
+	}
+	state, err := loadSplitState(outDir, baseFileName, stat, chunkSize)
 	if err != nil {
-		return fmt.Errorf("获取压缩文件大小失败: %v", err)
+		return err
 	}
 
-	// 计算分片数量
+	// 计算分片数量，空文件也生成一个分片，合并时才能找到
 	totalSize := stat.Size()
-	chunkCount := (totalSize + opts.ChunkSize - 1) / opts.ChunkSize
+	chunkCount := max((totalSize+chunkSize-1)/chunkSize, 1)
 
 	// 准备任务通道
 	type chunkTask struct {
@@ -120,25 +245,35 @@ func SplitArchive(opts *SplitOptions) error {
 	tasks := make(chan chunkTask, chunkCount)
 	errors := make(chan error, chunkCount)
 	var wg sync.WaitGroup
+	sums := make([]splitChunkSum, chunkCount) // 每个线程只写入自己处理的分片
 
 	// 创建工作线程
-	for i := 0; i < opts.ThreadCount; i++ {
+	for i := 0; i < threads; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			for task := range tasks {
-				if err := splitChunk(tempArchive, opts.OutputDir, baseFileName, task.index, task.start, task.size); err != nil {
-					errors <- fmt.Errorf("分片 %d 处理失败: %v", task.index, err)
+				if sum, ok := state.completed(outDir, task.index); ok {
+					sums[task.index-1] = sum
+					continue
+				}
+				sum, err := splitChunk(srcFile, outDir, baseFileName, task.index, task.start, task.size)
+				if err == nil {
+					err = state.record(task.index, sum)
+				}
+				if err != nil {
+					errors <- i18n.Errorf("分片 %d 处理失败: %v", task.index, err)
 					return
 				}
+				sums[task.index-1] = sum
 			}
 		}()
 	}
 
 	// 分发任务
 	for i := int64(0); i < chunkCount; i++ {
-		start := i * opts.ChunkSize
-		size := opts.ChunkSize
+		start := i * chunkSize
+		size := chunkSize
 		if start+size > totalSize {
 			size = totalSize - start
 		}
@@ -159,100 +294,166 @@ func SplitArchive(opts *SplitOptions) error {
 		return err
 	}
 
-	// 如果需要删除源目录
-	if opts.DeleteSource {
-		if err := os.RemoveAll(opts.SourceDir); err != nil {
-			return fmt.Errorf("删除源目录失败: %v", err)
-		}
+	// 各分片并行写入，整个文件的校验和需要再顺序读取一遍
+	total, err := fileSum(srcFile, nil)
+	if err != nil {
+		return i18n.Errorf("计算校验和失败: %v", err)
 	}
-
+	manifest := &splitManifest{Name: baseFileName, Size: totalSize, SHA256: total, ChunkSize: chunkSize, Chunks: sums}
+	if err := manifest.write(outDir); err != nil {
+		return err
+	}
+	state.remove()
 	return nil
 }
 
-// splitChunk 处理单个分片
-func splitChunk(srcFile, outDir, baseFileName string, index int, start, size int64) error {
+// splitChunk 处理单个分片，返回分片的名称、大小和写入时计算的校验和
+func splitChunk(srcFile, outDir, baseFileName string, index int, start, size int64) (splitChunkSum, error) {
 	// 打开源文件
 	src, err := os.Open(srcFile)
 	if err != nil {
-		return err
+		return splitChunkSum{}, err
 	}
 	defer src.Close()
 
 	// 创建分片文件
-	chunkFile := filepath.Join(outDir, fmt.Sprintf("%s.%03d", baseFileName, index))
-	dst, err := os.Create(chunkFile)
+	name := fmt.Sprintf("%s.%03d", baseFileName, index)
+	dst, err := os.Create(filepath.Join(outDir, name))
 	if err != nil {
-		return err
+		return splitChunkSum{}, err
 	}
 	defer dst.Close()
 
 	// 定位到起始位置
 	if _, err := src.Seek(start, 0); err != nil {
-		return err
+		return splitChunkSum{}, err
 	}
 
 	// 复制指定大小的数据
-	written, err := io.CopyN(dst, src, size)
+	h := sha256.New()
+	written, err := io.CopyN(io.MultiWriter(dst, h), src, size)
 	if err != nil && err != io.EOF {
-		return err
+		return splitChunkSum{}, err
 	}
 	if written != size {
-		return fmt.Errorf("写入大小不匹配：期望 %d，实际 %d", size, written)
+		return splitChunkSum{}, i18n.Errorf("写入大小不匹配：期望 %d，实际 %d", size, written)
 	}
 
-	return nil
+	return splitChunkSum{Name: name, Size: size, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
 }
 
-// MergeChunks 合并分片文件
+// MergeChunks 合并分片文件。分片目录中有分片时写入的清单时，先检查清单中的分片是否都存在且大小一致，
+// 合并时检查每个分片和合并后的文件的校验和，有问题时删除输出文件并返回列出所有问题的错误；
+// 没有清单时按序号合并目录中的所有分片。
+// 合并时在输出文件旁记录已合并的分片，中断后再次合并同样的分片到同一文件时从下一个分片继续
 func MergeChunks(chunksDir string, outputFile string, deleteChunks bool) error {
-	// 打开输出文件
-	dst, err := os.Create(outputFile)
+	manifest, err := readSplitManifest(chunksDir)
 	if err != nil {
-		return fmt.Errorf("创建输出文件失败: %v", err)
+		return err
 	}
-	defer dst.Close()
 
 	// 获取所有分片文件
-	pattern := filepath.Join(chunksDir, "*.[0-9][0-9][0-9]")
-	chunks, err := filepath.Glob(pattern)
-	if err != nil {
-		return fmt.Errorf("查找分片文件失败: %v", err)
-	}
-	if len(chunks) == 0 {
-		return fmt.Errorf("未找到分片文件")
+	var chunks []string
+	if manifest != nil {
+		if problems := manifest.checkChunkFiles(chunksDir); len(problems) > 0 {
+			return chunkReport(problems)
+		}
+		for _, chunk := range manifest.Chunks {
+			chunks = append(chunks, filepath.Join(chunksDir, chunk.Name))
+		}
+	} else {
+		pattern := filepath.Join(chunksDir, "*.[0-9][0-9][0-9]")
+		chunks, err = filepath.Glob(pattern)
+		if err != nil {
+			return i18n.Errorf("查找分片文件失败: %v", err)
+		}
+		if len(chunks) == 0 {
+			return errs.NotFound("未找到分片文件")
+		}
+
+		// 按序号排序分片文件
+		sortChunks(chunks)
 	}
 
-	// 按序号排序分片文件
-	sortChunks(chunks)
+	// 打开输出文件，有上次中断的进度时截掉最后一个未合并完的分片，从下一个分片继续
+	merged, total, offset := readMergeState(outputFile, chunks)
+	var dst *os.File
+	if merged > 0 {
+		dst, err = os.OpenFile(outputFile, os.O_WRONLY, 0)
+		if err == nil {
+			err = dst.Truncate(offset)
+		}
+		if err == nil {
+			_, err = dst.Seek(offset, io.SeekStart)
+		}
+	} else {
+		dst, err = os.Create(outputFile)
+	}
+	if err != nil {
+		return errs.Wrap(err, "创建输出文件失败: %v", err)
+	}
+	defer dst.Close()
 
-	// 依次合并分片
+	// 依次合并分片，同时计算每个分片和整个文件的校验和
+	var problems []string
 	buffer := make([]byte, 1024*1024) // 1MB缓冲区
-	for _, chunk := range chunks {
+	for i := merged; i < len(chunks); i++ {
+		chunk := chunks[i]
 		// 打开分片文件
 		src, err := os.Open(chunk)
 		if err != nil {
-			return fmt.Errorf("打开分片文件失败: %v", err)
+			return errs.Wrap(err, "打开分片文件失败: %v", err)
 		}
 
 		// 复制内容
-		_, err = io.CopyBuffer(dst, src, buffer)
+		h := sha256.New()
+		n, err := io.CopyBuffer(io.MultiWriter(dst, total, h), src, buffer)
 		src.Close()
 		if err != nil {
-			return fmt.Errorf("合并分片失败: %v", err)
+			return i18n.Errorf("合并分片失败: %v", err)
+		}
+		offset += n
+		if manifest != nil && hex.EncodeToString(h.Sum(nil)) != manifest.Chunks[i].SHA256 {
+			problems = append(problems, i18n.Tf("分片已损坏（校验和不一致）: %s", manifest.Chunks[i].Name))
 		}
 
-		// 如果需要删除分片
-		if deleteChunks {
-			if err := os.Remove(chunk); err != nil {
-				return fmt.Errorf("删除分片文件失败: %v", err)
+		// 只记录没有问题的进度，确保继续合并时之前的分片都已检查过
+		if len(problems) == 0 {
+			if err := dst.Sync(); err != nil {
+				return i18n.Errorf("合并分片失败: %v", err)
+			}
+			if err := writeMergeState(outputFile, chunks, i+1, total, offset); err != nil {
+				return err
 			}
 		}
 	}
+	os.Remove(mergeStatePath(outputFile))
+
+	if manifest != nil {
+		if len(problems) == 0 && hex.EncodeToString(total.Sum(nil)) != manifest.SHA256 {
+			problems = append(problems, i18n.Tf("合并后的文件 %s 的校验和与清单不一致", manifest.Name))
+		}
+		if len(problems) > 0 {
+			dst.Close()
+			os.Remove(outputFile)
+			return chunkReport(problems)
+		}
+	}
 
-	// 如果需要删除分片目录
+	// 校验通过后再删除分片
 	if deleteChunks {
+		for _, chunk := range chunks {
+			if err := os.Remove(chunk); err != nil {
+				return i18n.Errorf("删除分片文件失败: %v", err)
+			}
+		}
+		if manifest != nil {
+			if err := os.Remove(filepath.Join(chunksDir, manifest.Name+splitManifestSuffix)); err != nil {
+				return i18n.Errorf("删除分片清单失败: %v", err)
+			}
+		}
 		if err := os.Remove(chunksDir); err != nil {
-			return fmt.Errorf("删除分片目录失败: %v", err)
+			return i18n.Errorf("删除分片目录失败: %v", err)
 		}
 	}
 
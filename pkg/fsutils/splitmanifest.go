@@ -0,0 +1,97 @@
+package fsutils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// splitManifestSuffix 分片清单的文件名后缀，清单与分片在同一目录，命名为 合并后的文件名.manifest.json
+const splitManifestSuffix = ".manifest.json"
+
+// splitManifest 分片清单，记录每个分片和合并后的文件的大小和SHA-256校验和，合并时据此检查
+type splitManifest struct {
+	Name      string          `json:"name"`       // 合并后的文件名
+	Size      int64           `json:"size"`       // 合并后的文件大小
+	SHA256    string          `json:"sha256"`     // 合并后的文件的校验和
+	ChunkSize int64           `json:"chunk_size"` // 分片大小
+	Chunks    []splitChunkSum `json:"chunks"`     // 按合并的顺序
+}
+
+// splitChunkSum 清单中的一个分片
+type splitChunkSum struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// write 将清单写入分片目录
+func (m *splitManifest) write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, m.Name+splitManifestSuffix), append(data, '\n'), 0644); err != nil {
+		return errs.Wrap(err, "写入分片清单失败: %v", err)
+	}
+	return nil
+}
+
+// readSplitManifest 读取分片目录中的清单，没有清单时返回nil；目录中有多个清单时无法确定合并哪一组分片，返回错误
+func readSplitManifest(dir string) (*splitManifest, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+splitManifestSuffix))
+	if err != nil {
+		return nil, err
+	}
+	switch len(paths) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, errs.InvalidInput("分片目录中有 %d 个分片清单，无法确定要合并的分片", len(paths))
+	}
+
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		return nil, errs.Wrap(err, "读取分片清单失败: %v", err)
+	}
+	m := &splitManifest{}
+	if err := json.Unmarshal(data, m); err != nil || m.Name == "" || len(m.Chunks) == 0 {
+		return nil, errs.InvalidInput("分片清单 %s 格式错误", filepath.Base(paths[0]))
+	}
+	return m, nil
+}
+
+// ChunkedFileName 返回分片目录中的清单记录的合并后的文件名，没有清单时ok为false
+func ChunkedFileName(chunksDir string) (name string, ok bool) {
+	m, err := readSplitManifest(chunksDir)
+	if err != nil || m == nil {
+		return "", false
+	}
+	return m.Name, true
+}
+
+// checkChunkFiles 合并前检查清单中的分片是否都存在且大小一致，返回所有问题
+func (m *splitManifest) checkChunkFiles(dir string) []string {
+	var problems []string
+	for _, chunk := range m.Chunks {
+		info, err := os.Stat(filepath.Join(dir, chunk.Name))
+		switch {
+		case os.IsNotExist(err):
+			problems = append(problems, i18n.Tf("缺少分片: %s", chunk.Name))
+		case err != nil:
+			problems = append(problems, i18n.Tf("无法访问分片 %s: %v", chunk.Name, err))
+		case info.Size() != chunk.Size:
+			problems = append(problems, i18n.Tf("分片大小不一致: %s（应为 %d 字节，实际为 %d 字节）", chunk.Name, chunk.Size, info.Size()))
+		}
+	}
+	return problems
+}
+
+// chunkReport 将分片的问题列表转换为错误
+func chunkReport(problems []string) error {
+	return errs.InvalidInput("分片校验失败，共 %d 个问题:\n  %s", len(problems), strings.Join(problems, "\n  "))
+}
@@ -0,0 +1,197 @@
+package fsutils
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/json"
+	"hash"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+	"toolbox/pkg/i18n"
+)
+
+// splitStateSuffix 切分进度文件的后缀，与分片在同一目录，全部完成后删除
+const splitStateSuffix = ".split-state.json"
+
+// splitState 切分的进度：被切分的文件（打包分片时为源目录）和已经写完的分片。
+// 中断后再次切分同一文件时跳过校验和仍然一致的分片，再次打包分片时只重写内容不同的部分
+type splitState struct {
+	Size      int64                 `json:"size"`       // 被切分的文件的大小
+	ModTime   time.Time             `json:"mod_time"`   // 被切分的文件的修改时间
+	ChunkSize int64                 `json:"chunk_size"` // 分片大小
+	Done      map[int]splitChunkSum `json:"done"`       // 已完成的分片序号 → 分片的校验和
+
+	path string
+	mu   sync.Mutex // 多个线程完成分片时保护 Done 和文件
+}
+
+// splitStatePath 返回切分进度文件的路径
+func splitStatePath(outDir, baseFileName string) string {
+	return filepath.Join(outDir, baseFileName+splitStateSuffix)
+}
+
+// readSplitState 读取切分进度，进度不存在或不是切分同一文件（大小、修改时间或分片大小不同）时ok为false
+func readSplitState(outDir, baseFileName string, src os.FileInfo, chunkSize int64) (*splitState, bool) {
+	path := splitStatePath(outDir, baseFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	s := &splitState{}
+	if json.Unmarshal(data, s) != nil || s.Size != src.Size() || !s.ModTime.Equal(src.ModTime()) || s.ChunkSize != chunkSize {
+		return nil, false
+	}
+	if s.Done == nil {
+		s.Done = make(map[int]splitChunkSum)
+	}
+	s.path = path
+	return s, true
+}
+
+// loadSplitState 返回可以继续的切分进度，没有时创建新的进度并立即写入文件
+func loadSplitState(outDir, baseFileName string, src os.FileInfo, chunkSize int64) (*splitState, error) {
+	if s, ok := readSplitState(outDir, baseFileName, src, chunkSize); ok {
+		return s, nil
+	}
+	s := &splitState{
+		Size:      src.Size(),
+		ModTime:   src.ModTime(),
+		ChunkSize: chunkSize,
+		Done:      make(map[int]splitChunkSum),
+		path:      splitStatePath(outDir, baseFileName),
+	}
+	return s, s.save()
+}
+
+// completed 返回已经完成且文件的大小和校验和仍与记录一致的分片
+func (s *splitState) completed(outDir string, index int) (splitChunkSum, bool) {
+	s.mu.Lock()
+	sum, ok := s.Done[index]
+	s.mu.Unlock()
+	if !ok {
+		return splitChunkSum{}, false
+	}
+	path := filepath.Join(outDir, sum.Name)
+	info, err := os.Stat(path)
+	if err != nil || info.Size() != sum.Size {
+		return splitChunkSum{}, false
+	}
+	actual, err := fileSum(path, nil)
+	if err != nil || actual != sum.SHA256 {
+		return splitChunkSum{}, false
+	}
+	return sum, true
+}
+
+// recorded 分片是否记录为已完成
+func (s *splitState) recorded(index int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.Done[index]
+	return ok
+}
+
+// indexes 返回记录为已完成的分片序号
+func (s *splitState) indexes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indexes := make([]int, 0, len(s.Done))
+	for index := range s.Done {
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// record 记录完成的分片并写入进度文件
+func (s *splitState) record(index int, sum splitChunkSum) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Done[index] = sum
+	return s.save()
+}
+
+// save 写入进度文件，先写临时文件再重命名，中断时不会留下不完整的进度
+func (s *splitState) save() error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return i18n.Errorf("写入切分进度失败: %v", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// remove 全部完成后删除进度文件
+func (s *splitState) remove() {
+	os.Remove(s.path)
+}
+
+// mergeStateSuffix 合并进度文件的后缀，与输出文件在同一目录，合并完成或失败后删除
+const mergeStateSuffix = ".merge-state"
+
+// mergeState 合并的进度：已经合并的分片和此时输出文件的大小，以及整个文件校验和的中间状态，
+// 中断后再次合并同样的分片到同一文件时从下一个分片继续
+type mergeState struct {
+	Chunks []string `json:"chunks"` // 要合并的分片的文件名，按合并的顺序
+	Merged int      `json:"merged"` // 已经合并的分片数量
+	Offset int64    `json:"offset"` // 已合并部分的大小
+	Hash   []byte   `json:"hash"`   // 已合并部分的SHA-256的中间状态
+}
+
+// mergeStatePath 返回合并进度文件的路径
+func mergeStatePath(outputFile string) string {
+	return outputFile + mergeStateSuffix
+}
+
+// readMergeState 读取可以继续的合并进度，返回已合并的分片数量、恢复了中间状态的校验和以及已合并部分的大小；
+// 没有进度、分片列表不同或输出文件比记录的短时merged为0
+func readMergeState(outputFile string, chunks []string) (merged int, total hash.Hash, offset int64) {
+	total = sha256.New()
+	data, err := os.ReadFile(mergeStatePath(outputFile))
+	if err != nil {
+		return 0, total, 0
+	}
+	var s mergeState
+	if json.Unmarshal(data, &s) != nil || !slices.Equal(s.Chunks, chunkNames(chunks)) || s.Merged > len(chunks) {
+		return 0, total, 0
+	}
+	info, err := os.Stat(outputFile)
+	if err != nil || info.Size() < s.Offset {
+		return 0, total, 0
+	}
+	if err := total.(encoding.BinaryUnmarshaler).UnmarshalBinary(s.Hash); err != nil {
+		return 0, sha256.New(), 0
+	}
+	return s.Merged, total, s.Offset
+}
+
+// writeMergeState 记录合并的进度
+func writeMergeState(outputFile string, chunks []string, merged int, total hash.Hash, offset int64) error {
+	h, err := total.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(mergeState{Chunks: chunkNames(chunks), Merged: merged, Offset: offset, Hash: h})
+	if err != nil {
+		return err
+	}
+	path := mergeStatePath(outputFile)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return i18n.Errorf("写入合并进度失败: %v", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// chunkNames 返回分片的文件名，不含目录
+func chunkNames(chunks []string) []string {
+	names := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		names[i] = filepath.Base(chunk)
+	}
+	return names
+}
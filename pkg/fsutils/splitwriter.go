@@ -0,0 +1,222 @@
+package fsutils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// chunkWriter 将压缩数据依次写入 base.001、base.002 …，每个分片写满 size 字节后换到下一个分片，
+// 同时计算每个分片和全部数据的校验和，不需要先把整个压缩文件写入临时文件。
+// 继续中断的分片时，进度中记录为已完成的分片先与写入的数据比较，相同的部分不再写入，
+// 从第一个不同的字节起截断并改为写入
+type chunkWriter struct {
+	dir    string
+	base   string
+	size   int64
+	state  *splitState
+	report *splitReporter
+
+	file    *os.File
+	name    string    // 当前分片的文件名
+	index   int       // 当前分片的序号，从1开始
+	used    int64     // 当前分片已写入的大小
+	compare bool      // 当前分片是已完成的分片，写入的数据先与文件中的内容比较
+	buf     []byte    // 比较时读取分片文件的缓冲区
+	chunk   hash.Hash // 当前分片的校验和
+	total   hash.Hash // 全部数据的校验和
+	written int64
+	sums    []splitChunkSum
+}
+
+func newChunkWriter(dir, base string, size int64, state *splitState, report *splitReporter) *chunkWriter {
+	return &chunkWriter{dir: dir, base: base, size: size, state: state, report: report, total: sha256.New()}
+}
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if cw.file == nil || cw.used == cw.size {
+			if err := cw.next(); err != nil {
+				return n, err
+			}
+		}
+		b := p
+		if rest := cw.size - cw.used; int64(len(b)) > rest {
+			b = b[:rest]
+		}
+		m, err := cw.writeChunk(b)
+		cw.chunk.Write(b[:m])
+		cw.total.Write(b[:m])
+		n += m
+		cw.used += int64(m)
+		cw.written += int64(m)
+		p = p[m:]
+		if err != nil {
+			return n, err
+		}
+	}
+	cw.report.update(func(s *SplitProgress) { s.Compressed = cw.written })
+	return n, nil
+}
+
+// writeChunk 写入当前分片；比较已完成的分片时跳过相同的部分
+func (cw *chunkWriter) writeChunk(b []byte) (int, error) {
+	if !cw.compare {
+		return cw.file.Write(b)
+	}
+	if cap(cw.buf) < len(b) {
+		cw.buf = make([]byte, len(b))
+	}
+	old := cw.buf[:len(b)]
+	m, err := io.ReadFull(cw.file, old)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return 0, err
+	}
+	if m == len(b) && bytes.Equal(old, b) {
+		return len(b), nil
+	}
+
+	// 数据与上次不同（源目录有修改）或分片文件不完整，之后的数据直接写入
+	same := 0
+	for same < m && old[same] == b[same] {
+		same++
+	}
+	cw.compare = false
+	offset := cw.used + int64(same)
+	if err := cw.file.Truncate(offset); err != nil {
+		return 0, err
+	}
+	if _, err := cw.file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	written, err := cw.file.Write(b[same:])
+	return same + written, err
+}
+
+// next 写完当前分片并创建下一个分片，进度中记录为已完成的分片打开已有的文件用于比较
+func (cw *chunkWriter) next() error {
+	if err := cw.closeChunk(); err != nil {
+		return err
+	}
+	cw.index++
+	cw.name = fmt.Sprintf("%s.%03d", cw.base, cw.index)
+	path := filepath.Join(cw.dir, cw.name)
+
+	cw.compare = false
+	if cw.state.recorded(cw.index) {
+		if file, err := os.OpenFile(path, os.O_RDWR, 0); err == nil {
+			cw.file, cw.compare = file, true
+		}
+	}
+	if !cw.compare {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		cw.file = file
+	}
+	cw.used = 0
+	cw.chunk = sha256.New()
+	return nil
+}
+
+// closeChunk 关闭当前分片并记录到进度中
+func (cw *chunkWriter) closeChunk() error {
+	if cw.file == nil {
+		return nil
+	}
+	// 比较的分片文件可能比这次写入的数据长
+	if cw.compare {
+		info, err := cw.file.Stat()
+		if err == nil && info.Size() > cw.used {
+			err = cw.file.Truncate(cw.used)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err := cw.file.Close()
+	cw.file = nil
+	if err != nil {
+		return err
+	}
+	sum := splitChunkSum{Name: cw.name, Size: cw.used, SHA256: hex.EncodeToString(cw.chunk.Sum(nil))}
+	cw.sums = append(cw.sums, sum)
+	if err := cw.state.record(cw.index, sum); err != nil {
+		return err
+	}
+	cw.report.update(func(s *SplitProgress) { s.Chunks = len(cw.sums) })
+	return nil
+}
+
+// finish 写完最后一个分片，删除上次分片时多出的分片，写入清单并删除进度
+func (cw *chunkWriter) finish() error {
+	if cw.file == nil {
+		if err := cw.next(); err != nil {
+			return err
+		}
+	}
+	if err := cw.closeChunk(); err != nil {
+		return err
+	}
+	for _, index := range cw.state.indexes() {
+		if index > cw.index {
+			os.Remove(filepath.Join(cw.dir, fmt.Sprintf("%s.%03d", cw.base, index)))
+		}
+	}
+
+	manifest := &splitManifest{
+		Name:      cw.base,
+		Size:      cw.written,
+		SHA256:    hex.EncodeToString(cw.total.Sum(nil)),
+		ChunkSize: cw.size,
+		Chunks:    cw.sums,
+	}
+	if err := manifest.write(cw.dir); err != nil {
+		return err
+	}
+	cw.state.remove()
+	return nil
+}
+
+// close 出错时关闭正在写入的分片，已完成的分片和进度保留，再次分片时可以继续
+func (cw *chunkWriter) close() {
+	if cw.file != nil {
+		cw.file.Close()
+		cw.file = nil
+	}
+}
+
+// splitReporter 累计分片的进度并调用 SplitProgressFunc；为nil时不做任何事。
+// 并行压缩时可能从多个goroutine调用，mu 保证按顺序调用
+type splitReporter struct {
+	fn    SplitProgressFunc
+	mu    sync.Mutex
+	state SplitProgress
+}
+
+// newSplitReporter 创建进度统计，fn 为nil时返回nil
+func newSplitReporter(fn SplitProgressFunc) *splitReporter {
+	if fn == nil {
+		return nil
+	}
+	return &splitReporter{fn: fn}
+}
+
+// update 修改进度并报告
+func (r *splitReporter) update(f func(s *SplitProgress)) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f(&r.state)
+	r.fn(r.state)
+}
@@ -0,0 +1,637 @@
+package fsutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// 分卷zip的格式见 PKWARE APPNOTE 8.5：数据依次写入 name.z01、name.z02……，最后一个分卷为 name.zip，
+// 中央目录中记录每个文件的本地文件头所在的分卷序号和在该分卷中的偏移。
+// 本地文件头、中央目录的每条记录和结束记录都不能跨越分卷，7-Zip、WinRAR 和 Info-ZIP 都可以解压
+
+// MinVolumeSize 分卷zip每个分卷的最小大小
+const MinVolumeSize = 64 << 10
+
+const (
+	zipLocalHeaderLen = 30
+	zipDirHeaderLen   = 46
+	zipDirEndLen      = 22
+	zip64DirEndLen    = 56
+	zip64LocatorLen   = 20
+	zip64ExtraID      = 0x0001
+	zipMax16          = 0xFFFF
+	zipMax32          = 0xFFFFFFFF
+)
+
+var (
+	zipLocalSig     = []byte("PK\x03\x04")
+	zipDirSig       = []byte("PK\x01\x02")
+	zipDirEndSig    = []byte("PK\x05\x06")
+	zip64DirEndSig  = []byte("PK\x06\x06")
+	zip64LocatorSig = []byte("PK\x06\x07")
+	splitZipMagic   = []byte("PK\x07\x08") // 分卷zip第一个分卷开头的标记
+	unsplitZipMagic = []byte("PK00")       // 按分卷写入但只有一个分卷时的标记
+)
+
+// zipVolumeName 返回分卷zip第n个分卷（从1开始，不包括最后一个分卷）的路径
+func zipVolumeName(base string, n int) string {
+	return fmt.Sprintf("%s.z%02d", base, n)
+}
+
+// volumeWriter 将数据依次写入各个分卷，每个分卷不超过size字节
+type volumeWriter struct {
+	base   string // 去掉扩展名的路径，分卷为 base.z01、base.z02……
+	dst    string // 最后一个分卷的路径
+	size   int64
+	file   *os.File
+	paths  []string
+	starts []int64       // 每个分卷第一个字节在整个数据中的位置
+	pos    int64         // 已写入的总大小
+	used   int64         // 当前分卷已写入的大小
+	buffer *bytes.Buffer // 不为nil时写入内存而不是分卷
+}
+
+func newVolumeWriter(dst string, size int64) *volumeWriter {
+	return &volumeWriter{base: strings.TrimSuffix(dst, filepath.Ext(dst)), dst: dst, size: size}
+}
+
+func (vw *volumeWriter) Write(p []byte) (int, error) {
+	if vw.buffer != nil {
+		return vw.buffer.Write(p)
+	}
+	n := 0
+	for len(p) > 0 {
+		if vw.file == nil || vw.used == vw.size {
+			if err := vw.next(); err != nil {
+				return n, err
+			}
+		}
+		chunk := p
+		if rest := vw.size - vw.used; int64(len(chunk)) > rest {
+			chunk = chunk[:rest]
+		}
+		m, err := vw.file.Write(chunk)
+		n += m
+		vw.used += int64(m)
+		vw.pos += int64(m)
+		p = p[m:]
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// next 关闭当前分卷并创建下一个分卷，第一个分卷以分卷标记开头
+func (vw *volumeWriter) next() error {
+	if vw.file != nil {
+		err := vw.file.Close()
+		vw.file = nil
+		if err != nil {
+			return err
+		}
+	}
+	path := zipVolumeName(vw.base, len(vw.paths)+1)
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	vw.file = file
+	vw.paths = append(vw.paths, path)
+	vw.starts = append(vw.starts, vw.pos)
+	vw.used = 0
+	if len(vw.paths) == 1 {
+		if _, err := file.Write(splitZipMagic); err != nil {
+			return err
+		}
+		vw.used += int64(len(splitZipMagic))
+		vw.pos += int64(len(splitZipMagic))
+	}
+	return nil
+}
+
+// reserve 确保接下来的n字节写在同一个分卷中，当前分卷剩余的空间不足时换到下一个分卷
+func (vw *volumeWriter) reserve(n int64) error {
+	if vw.file == nil || vw.size-vw.used < n {
+		return vw.next()
+	}
+	return nil
+}
+
+// disk 返回当前分卷的序号（从0开始）
+func (vw *volumeWriter) disk() int {
+	return len(vw.paths) - 1
+}
+
+// locate 返回数据中的位置所在的分卷序号和在该分卷中的偏移
+func (vw *volumeWriter) locate(pos int64) (int, int64) {
+	disk := sort.Search(len(vw.starts), func(i int) bool { return vw.starts[i] > pos }) - 1
+	return disk, pos - vw.starts[disk]
+}
+
+// finish 关闭最后一个分卷并重命名为dst；只有一个分卷时改为普通zip可以识别的标记
+func (vw *volumeWriter) finish() error {
+	err := vw.file.Close()
+	vw.file = nil
+	if err != nil {
+		return err
+	}
+	last := len(vw.paths) - 1
+	if err := os.Rename(vw.paths[last], vw.dst); err != nil {
+		return err
+	}
+	vw.paths[last] = vw.dst
+	if last > 0 {
+		return nil
+	}
+	file, err := os.OpenFile(vw.dst, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteAt(unsplitZipMagic, 0); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// abort 关闭并删除已写入的所有分卷
+func (vw *volumeWriter) abort() {
+	if vw.file != nil {
+		vw.file.Close()
+	}
+	for _, path := range vw.paths {
+		os.Remove(path)
+	}
+}
+
+// compressSplitZip 将src压缩为分卷zip写入vw
+func compressSplitZip(src string, vw *volumeWriter, isDir bool, options CompressOptions, p *progress) error {
+	create, archive := newZipWriter(vw, options.Password)
+	// zip.Writer 计算的偏移不包括第一个分卷开头的标记
+	archive.SetOffset(int64(len(splitZipMagic)))
+	split := func(name string, info os.FileInfo) (io.Writer, error) {
+		// 创建条目时 zip.Writer 先写完上一个文件剩余的压缩数据，再写入本地文件头；
+		// 这些数据先写入内存，从末尾找到本地文件头，确保它写在同一个分卷中
+		if err := archive.Flush(); err != nil {
+			return nil, err
+		}
+		var pending bytes.Buffer
+		vw.buffer = &pending
+		w, err := create(name, info)
+		if err == nil {
+			err = archive.Flush()
+		}
+		vw.buffer = nil
+		if err != nil {
+			return nil, err
+		}
+		data := pending.Bytes()
+		i := localHeaderStart(data, name)
+		if i < 0 {
+			return nil, i18n.Errorf("找不到 %s 的本地文件头", name)
+		}
+		if _, err := vw.Write(data[:i]); err != nil {
+			return nil, err
+		}
+		if err := vw.reserve(int64(len(data) - i)); err != nil {
+			return nil, err
+		}
+		if _, err := vw.Write(data[i:]); err != nil {
+			return nil, err
+		}
+		return w, nil
+	}
+	if err := writeZip(split, src, isDir, options, p); err != nil {
+		archive.Close()
+		return err
+	}
+
+	// zip.Writer 写入的中央目录按单个文件记录偏移，先写入内存，换算为分卷序号和分卷内的偏移后再写入分卷
+	if err := archive.Flush(); err != nil {
+		return err
+	}
+	var tail bytes.Buffer
+	start := vw.pos
+	vw.buffer = &tail
+	err := archive.Close()
+	vw.buffer = nil
+	if err != nil {
+		return err
+	}
+
+	// Close 先写完最后一个文件剩余的数据，之后才是中央目录，根据结束记录中的偏移找到中央目录
+	data := tail.Bytes()
+	end, zip64Disk, zip64Offset, ok := readZipDirEnd(bytes.NewReader(data), int64(len(data)))
+	if ok && zip64Disk >= 0 {
+		end, err = readZip64DirEnd(bytes.NewReader(data), zip64Offset-start)
+	}
+	if !ok || err != nil || end.dirOffset < start || end.dirOffset-start > int64(len(data)) {
+		return errs.InvalidInput("无法解析zip中央目录")
+	}
+	if _, err := vw.Write(data[:end.dirOffset-start]); err != nil {
+		return err
+	}
+	return writeSplitDirectory(vw, data[end.dirOffset-start:])
+}
+
+// localHeaderStart 返回data末尾名为name的本地文件头的开始位置，找不到时返回-1
+func localHeaderStart(data []byte, name string) int {
+	le := binary.LittleEndian
+	for i := len(data) - zipLocalHeaderLen - len(name); i >= 0; i-- {
+		b := data[i:]
+		if bytes.HasPrefix(b, zipLocalSig) && int(le.Uint16(b[26:])) == len(name) &&
+			zipLocalHeaderLen+len(name)+int(le.Uint16(b[28:])) == len(b) &&
+			string(b[zipLocalHeaderLen:zipLocalHeaderLen+len(name)]) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// writeSplitDirectory 改写 zip.Writer 生成的中央目录中每条记录的位置后写入分卷，最后写入新的结束记录
+func writeSplitDirectory(vw *volumeWriter, data []byte) error {
+	var end zipDirEnd
+	for bytes.HasPrefix(data, zipDirSig) {
+		entry, n, err := parseZipDirEntry(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+		entry.disk, entry.offset = vw.locate(entry.offset)
+		record := entry.bytes()
+
+		if err := vw.reserve(int64(len(record))); err != nil {
+			return err
+		}
+		if end.entries == 0 {
+			end.dirDisk, end.dirOffset = vw.disk(), vw.used
+		}
+		if vw.disk() != end.disk {
+			end.disk, end.diskEntries = vw.disk(), 0
+		}
+		if _, err := vw.Write(record); err != nil {
+			return err
+		}
+		end.entries++
+		end.diskEntries++
+		end.dirSize += int64(len(record))
+	}
+
+	// 结束记录写在最后一个分卷中，没有文件时中央目录为空，也从结束记录的位置开始
+	if err := vw.reserve(end.length()); err != nil {
+		return err
+	}
+	if vw.disk() != end.disk {
+		end.disk, end.diskEntries = vw.disk(), 0
+	}
+	if end.entries == 0 {
+		end.dirDisk, end.dirOffset = vw.disk(), vw.used
+	}
+	end.offset = vw.used
+	_, err := vw.Write(end.bytes())
+	return err
+}
+
+// zipDirEntry 中央目录中的一条记录，只解析需要改写的分卷序号和偏移
+type zipDirEntry struct {
+	header  []byte // 固定长度的部分
+	name    []byte
+	extra   []byte // zip64以外的扩展字段
+	comment []byte
+	sizes   []byte // zip64扩展字段中的解压后大小和压缩后大小，只有32位字段为最大值时才有
+	disk    int    // 本地文件头所在的分卷
+	offset  int64  // 本地文件头在分卷中的偏移
+}
+
+// parseZipDirEntry 解析b开头的中央目录记录，返回记录和记录的长度
+func parseZipDirEntry(b []byte) (zipDirEntry, int, error) {
+	le := binary.LittleEndian
+	if len(b) < zipDirHeaderLen || !bytes.HasPrefix(b, zipDirSig) {
+		return zipDirEntry{}, 0, errs.InvalidInput("zip中央目录已损坏")
+	}
+	nameLen, extraLen, commentLen := int(le.Uint16(b[28:])), int(le.Uint16(b[30:])), int(le.Uint16(b[32:]))
+	extraStart := zipDirHeaderLen + nameLen
+	n := extraStart + extraLen + commentLen
+	if len(b) < n {
+		return zipDirEntry{}, 0, errs.InvalidInput("zip中央目录已损坏")
+	}
+	e := zipDirEntry{
+		header:  append([]byte(nil), b[:zipDirHeaderLen]...),
+		name:    b[zipDirHeaderLen:extraStart],
+		comment: b[extraStart+extraLen : n],
+		disk:    int(le.Uint16(b[34:])),
+		offset:  int64(le.Uint32(b[42:])),
+	}
+
+	sizeLen := 0
+	if le.Uint32(b[24:]) == zipMax32 {
+		sizeLen += 8
+	}
+	if le.Uint32(b[20:]) == zipMax32 {
+		sizeLen += 8
+	}
+	for extra := b[extraStart : extraStart+extraLen]; len(extra) >= 4; {
+		id, size := le.Uint16(extra), int(le.Uint16(extra[2:]))
+		if 4+size > len(extra) {
+			break
+		}
+		if field := extra[4 : 4+size]; id == zip64ExtraID && len(field) >= sizeLen {
+			e.sizes, field = field[:sizeLen], field[sizeLen:]
+			if e.offset == zipMax32 && len(field) >= 8 {
+				e.offset, field = int64(le.Uint64(field)), field[8:]
+			}
+			if e.disk == zipMax16 && len(field) >= 4 {
+				e.disk = int(le.Uint32(field))
+			}
+		} else {
+			e.extra = append(e.extra, extra[:4+size]...)
+		}
+		extra = extra[4+size:]
+	}
+	return e, n, nil
+}
+
+// bytes 按当前的分卷序号和偏移生成记录，超出范围的值写入zip64扩展字段
+func (e zipDirEntry) bytes() []byte {
+	le := binary.LittleEndian
+	header := append([]byte(nil), e.header...)
+	zip64 := append([]byte(nil), e.sizes...)
+	if e.offset >= zipMax32 {
+		zip64 = le.AppendUint64(zip64, uint64(e.offset))
+		le.PutUint32(header[42:], zipMax32)
+	} else {
+		le.PutUint32(header[42:], uint32(e.offset))
+	}
+	if e.disk >= zipMax16 {
+		zip64 = le.AppendUint32(zip64, uint32(e.disk))
+		le.PutUint16(header[34:], zipMax16)
+	} else {
+		le.PutUint16(header[34:], uint16(e.disk))
+	}
+
+	extra := e.extra
+	if len(zip64) > 0 {
+		extra = le.AppendUint16(nil, zip64ExtraID)
+		extra = le.AppendUint16(extra, uint16(len(zip64)))
+		extra = append(append(extra, zip64...), e.extra...)
+		// 使用zip64需要的解压版本为4.5
+		if le.Uint16(header[6:]) < 45 {
+			le.PutUint16(header[6:], 45)
+		}
+	}
+	le.PutUint16(header[30:], uint16(len(extra)))
+
+	b := append(header, e.name...)
+	b = append(b, extra...)
+	return append(b, e.comment...)
+}
+
+// zipDirEnd 中央目录结束记录中的字段
+type zipDirEnd struct {
+	disk        int   // 结束记录所在的分卷，即最后一个分卷的序号
+	dirDisk     int   // 中央目录开始的分卷
+	diskEntries int   // 最后一个分卷中的中央目录记录数
+	entries     int   // 中央目录记录总数
+	dirSize     int64 // 中央目录的大小
+	dirOffset   int64 // 中央目录在开始的分卷中的偏移
+	offset      int64 // 结束记录（使用zip64时从zip64结束记录开始）在最后一个分卷中的偏移
+}
+
+// zip64 判断是否有字段超出结束记录的范围，需要使用zip64结束记录
+func (e zipDirEnd) zip64() bool {
+	return e.disk >= zipMax16 || e.dirDisk >= zipMax16 || e.entries >= zipMax16 ||
+		e.dirSize >= zipMax32 || e.dirOffset >= zipMax32
+}
+
+func (e zipDirEnd) length() int64 {
+	if e.zip64() {
+		return zip64DirEndLen + zip64LocatorLen + zipDirEndLen
+	}
+	return zipDirEndLen
+}
+
+// bytes 生成结束记录，需要时在前面加上zip64结束记录和定位记录
+func (e zipDirEnd) bytes() []byte {
+	le := binary.LittleEndian
+	var b []byte
+	if e.zip64() {
+		b = append(b, zip64DirEndSig...)
+		b = le.AppendUint64(b, zip64DirEndLen-12) // 不包括签名和本字段
+		b = le.AppendUint16(b, 45)                // 压缩时的版本
+		b = le.AppendUint16(b, 45)                // 解压需要的版本
+		b = le.AppendUint32(b, uint32(e.disk))
+		b = le.AppendUint32(b, uint32(e.dirDisk))
+		b = le.AppendUint64(b, uint64(e.diskEntries))
+		b = le.AppendUint64(b, uint64(e.entries))
+		b = le.AppendUint64(b, uint64(e.dirSize))
+		b = le.AppendUint64(b, uint64(e.dirOffset))
+
+		b = append(b, zip64LocatorSig...)
+		b = le.AppendUint32(b, uint32(e.disk))
+		b = le.AppendUint64(b, uint64(e.offset))
+		b = le.AppendUint32(b, uint32(e.disk+1))
+	}
+	b = append(b, zipDirEndSig...)
+	b = le.AppendUint16(b, uint16(min(e.disk, zipMax16)))
+	b = le.AppendUint16(b, uint16(min(e.dirDisk, zipMax16)))
+	b = le.AppendUint16(b, uint16(min(e.diskEntries, zipMax16)))
+	b = le.AppendUint16(b, uint16(min(e.entries, zipMax16)))
+	b = le.AppendUint32(b, uint32(min(e.dirSize, zipMax32)))
+	b = le.AppendUint32(b, uint32(min(e.dirOffset, zipMax32)))
+	return le.AppendUint16(b, 0) // 注释长度
+}
+
+// readZipDirEnd 读取zip文件末尾的结束记录；有zip64定位记录时返回其中的zip64结束记录所在的分卷和偏移，
+// 此时结束记录中的字段可能不完整，需要再读取zip64结束记录
+func readZipDirEnd(r io.ReaderAt, size int64) (end zipDirEnd, zip64Disk int, zip64Offset int64, ok bool) {
+	le := binary.LittleEndian
+	// 结束记录在末尾，之后最多有65535字节的注释
+	tailLen := min(size, zipDirEndLen+zipMax16)
+	tail := make([]byte, tailLen)
+	if _, err := r.ReadAt(tail, size-tailLen); err != nil {
+		return end, 0, 0, false
+	}
+	i := len(tail) - zipDirEndLen
+	for ; i >= 0; i-- {
+		if bytes.HasPrefix(tail[i:], zipDirEndSig) && i+zipDirEndLen+int(le.Uint16(tail[i+20:])) == len(tail) {
+			break
+		}
+	}
+	if i < 0 {
+		return end, 0, 0, false
+	}
+	b := tail[i:]
+	end = zipDirEnd{
+		disk:        int(le.Uint16(b[4:])),
+		dirDisk:     int(le.Uint16(b[6:])),
+		diskEntries: int(le.Uint16(b[8:])),
+		entries:     int(le.Uint16(b[10:])),
+		dirSize:     int64(le.Uint32(b[12:])),
+		dirOffset:   int64(le.Uint32(b[16:])),
+	}
+
+	zip64Disk = -1
+	if loc := size - tailLen + int64(i) - zip64LocatorLen; loc >= 0 {
+		b := make([]byte, zip64LocatorLen)
+		if _, err := r.ReadAt(b, loc); err == nil && bytes.HasPrefix(b, zip64LocatorSig) {
+			zip64Disk, zip64Offset = int(le.Uint32(b[4:])), int64(le.Uint64(b[8:]))
+			end.disk = int(le.Uint32(b[16:])) - 1
+		}
+	}
+	return end, zip64Disk, zip64Offset, true
+}
+
+// readZip64DirEnd 读取zip64结束记录中的字段
+func readZip64DirEnd(r io.ReaderAt, offset int64) (zipDirEnd, error) {
+	le := binary.LittleEndian
+	b := make([]byte, zip64DirEndLen)
+	if _, err := r.ReadAt(b, offset); err != nil || !bytes.HasPrefix(b, zip64DirEndSig) {
+		return zipDirEnd{}, errs.InvalidInput("zip64结束记录已损坏")
+	}
+	return zipDirEnd{
+		disk:        int(le.Uint32(b[16:])),
+		dirDisk:     int(le.Uint32(b[20:])),
+		diskEntries: int(le.Uint64(b[24:])),
+		entries:     int(le.Uint64(b[32:])),
+		dirSize:     int64(le.Uint64(b[40:])),
+		dirOffset:   int64(le.Uint64(b[48:])),
+	}, nil
+}
+
+// openZipVolumes 打开zip文件，file 为最后一个分卷（.zip）。是分卷zip时依次打开同目录下同名的 .z01、.z02……，
+// 将所有分卷拼接起来，并在末尾附加换算为整体偏移的中央目录，返回的ReaderAt可以直接用 zip.NewReader 读取。
+// 不是分卷zip时返回file本身；closeAll 关闭打开的其他分卷
+func openZipVolumes(file *os.File, path string, size int64) (r io.ReaderAt, total int64, closeAll func(), err error) {
+	var files []*os.File
+	closeAll = func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	// 不是有效的zip时交给 zip.NewReader 报告错误
+	end, zip64Disk, zip64Offset, ok := readZipDirEnd(file, size)
+	if !ok || end.disk <= 0 {
+		return file, size, closeAll, nil
+	}
+
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	parts := make([]io.ReaderAt, 0, end.disk+2)
+	sizes := make([]int64, 0, end.disk+2)
+	for n := 1; n <= end.disk; n++ {
+		name := zipVolumeName(base, n)
+		f, err := os.Open(name)
+		if err != nil {
+			closeAll()
+			return nil, 0, nil, errs.Wrap(err, "无法打开分卷 %s: %v", filepath.Base(name), err)
+		}
+		files = append(files, f)
+		info, err := f.Stat()
+		if err != nil {
+			closeAll()
+			return nil, 0, nil, err
+		}
+		parts = append(parts, f)
+		sizes = append(sizes, info.Size())
+	}
+	parts = append(parts, file)
+	sizes = append(sizes, size)
+	volumes := newMultiReaderAt(parts, sizes)
+
+	corrupt := func() (io.ReaderAt, int64, func(), error) {
+		closeAll()
+		return nil, 0, nil, errs.InvalidInput("分卷zip的中央目录已损坏: %s", filepath.Base(path))
+	}
+	if zip64Disk >= 0 {
+		if zip64Disk >= len(parts) {
+			return corrupt()
+		}
+		if end, err = readZip64DirEnd(volumes, volumes.starts[zip64Disk]+zip64Offset); err != nil {
+			return corrupt()
+		}
+	}
+	if end.dirDisk >= len(parts) {
+		return corrupt()
+	}
+	dirStart := volumes.starts[end.dirDisk] + end.dirOffset
+	if end.dirSize < 0 || dirStart+end.dirSize > volumes.size() {
+		return corrupt()
+	}
+	data := make([]byte, end.dirSize)
+	if _, err := volumes.ReadAt(data, dirStart); err != nil {
+		closeAll()
+		return nil, 0, nil, err
+	}
+
+	// 把每个文件的位置换算为在拼接后的整体中的偏移
+	var dir []byte
+	for i := 0; i < end.entries; i++ {
+		entry, n, err := parseZipDirEntry(data)
+		if err != nil || entry.disk >= len(parts) {
+			return corrupt()
+		}
+		data = data[n:]
+		entry.offset += volumes.starts[entry.disk]
+		entry.disk = 0
+		dir = append(dir, entry.bytes()...)
+	}
+	dirEnd := zipDirEnd{
+		entries:     end.entries,
+		diskEntries: end.entries,
+		dirSize:     int64(len(dir)),
+		dirOffset:   volumes.size(),
+		offset:      volumes.size() + int64(len(dir)),
+	}
+	dir = append(dir, dirEnd.bytes()...)
+
+	whole := newMultiReaderAt(append(parts, bytes.NewReader(dir)), append(sizes, int64(len(dir))))
+	return whole, whole.size(), closeAll, nil
+}
+
+// multiReaderAt 将多个ReaderAt依次拼接为一个整体
+type multiReaderAt struct {
+	parts  []io.ReaderAt
+	starts []int64 // 每部分在整体中的位置，最后一个元素为总大小
+}
+
+func newMultiReaderAt(parts []io.ReaderAt, sizes []int64) *multiReaderAt {
+	starts := make([]int64, len(sizes)+1)
+	for i, size := range sizes {
+		starts[i+1] = starts[i] + size
+	}
+	return &multiReaderAt{parts: parts, starts: starts}
+}
+
+func (m *multiReaderAt) size() int64 {
+	return m.starts[len(m.starts)-1]
+}
+
+func (m *multiReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if off >= m.size() {
+			return n, io.EOF
+		}
+		i := sort.Search(len(m.parts), func(i int) bool { return m.starts[i+1] > off })
+		chunk := p
+		if rest := m.starts[i+1] - off; int64(len(chunk)) > rest {
+			chunk = chunk[:rest]
+		}
+		k, err := m.parts[i].ReadAt(chunk, off-m.starts[i])
+		n += k
+		off += int64(k)
+		p = p[k:]
+		if err != nil && !(err == io.EOF && k == len(chunk)) {
+			return n, err
+		}
+	}
+	return n, nil
+}
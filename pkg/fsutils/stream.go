@@ -0,0 +1,107 @@
+package fsutils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"toolbox/pkg/errs"
+)
+
+// CompressTo 压缩文件或目录，将压缩后的数据写入w而不是文件，可以直接写入网络连接、上传流等，
+// 不需要临时文件。options.Format 必须指定；w不会被关闭
+func CompressTo(src string, w io.Writer, options CompressOptions) error {
+	return CompressToContext(context.Background(), src, w, options)
+}
+
+// CompressToContext 与 CompressTo 相同，ctx取消时在读取下一块数据前停止并返回ctx的错误，
+// 此时已写入w的数据不是完整的压缩文件
+func CompressToContext(ctx context.Context, src string, w io.Writer, options CompressOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return errs.Wrap(err, "无法访问源文件/目录: %v", err)
+	}
+	if err := checkCompressFormat(options, srcInfo.IsDir()); err != nil {
+		return err
+	}
+	if options.VolumeSize > 0 {
+		return errs.InvalidInput("分卷压缩需要写入多个文件，不能写入数据流")
+	}
+	if options.filter, err = newSourceFilter(options); err != nil {
+		return err
+	}
+	if options.Manifest != "" {
+		options.sums = newChecksums()
+	}
+
+	err = compressTo(ctx, src, srcInfo, w, options)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	if options.sums != nil {
+		return options.sums.write(options.Manifest)
+	}
+	return nil
+}
+
+// DecompressFrom 从r读取压缩数据并解压缩到dst，options.Format 为空时根据数据开头的特征字节判断格式。
+//
+// tar和gz、bz2等流式格式边读取边解压，不需要临时文件；rar也可以顺序读取。
+// zip和7z的文件列表在末尾，需要随机访问，先将r的全部内容写入临时目录中的文件再解压，解压后删除。
+// 流式格式无法预先得知压缩数据的大小，报告进度时 total 为0
+func DecompressFrom(r io.Reader, dst string, options DecompressOptions) (DecompressResult, error) {
+	return DecompressFromContext(context.Background(), r, dst, options)
+}
+
+// DecompressFromContext 与 DecompressFrom 相同，ctx取消时停止并返回ctx的错误，已经解压完成的文件会保留
+func DecompressFromContext(ctx context.Context, r io.Reader, dst string, options DecompressOptions) (DecompressResult, error) {
+	// 没有指定格式时预读开头的数据判断格式，之后从缓冲区继续读取
+	if options.Format == "" {
+		br := bufio.NewReaderSize(r, sniffSize)
+		head, _ := br.Peek(sniffSize)
+		format, ok := sniffFormat(bytes.NewReader(head))
+		if !ok {
+			return DecompressResult{}, errs.InvalidInput("无法识别的压缩格式，请指定格式")
+		}
+		options.Format, r = format, br
+	}
+
+	switch options.Format {
+	case ZIP, SEVENZIP:
+		// 写入临时文件时不报告进度，但同样检查取消
+		file, size, err := spoolTemp(newProgress(ctx, nil, 0).reader(r))
+		if err != nil {
+			if ctx.Err() != nil {
+				return DecompressResult{}, ctx.Err()
+			}
+			return DecompressResult{}, errs.Wrap(err, "无法写入临时文件: %v", err)
+		}
+		defer os.Remove(file.Name())
+		defer file.Close()
+		return decompressResult(ctx, file, file, size, dst, options)
+	}
+	return decompressResult(ctx, r, nil, 0, dst, options)
+}
+
+// spoolTemp 将r的全部内容写入临时文件，返回的文件由调用方关闭并删除
+func spoolTemp(r io.Reader) (*os.File, int64, error) {
+	file, err := os.CreateTemp("", "toolbox-archive-*")
+	if err != nil {
+		return nil, 0, err
+	}
+	size, err := io.Copy(file, r)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, 0, err
+	}
+	return file, size, nil
+}
@@ -0,0 +1,388 @@
+package fsutils
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// SyncOptions 目录同步的选项
+type SyncOptions struct {
+	// Checksum 大小相同的文件再按SHA-256比较内容，默认大小和修改时间（精确到秒）都相同就认为没有变化
+	Checksum bool
+	// Delete 删除目标目录中源目录没有的文件和目录，被排除的不删除
+	Delete bool
+	// DryRun 只比较并报告将要进行的修改，不修改目标目录
+	DryRun bool
+	// Exclude 排除匹配的文件和目录，语法与 CompressOptions.ExcludePatterns 相同，匹配相对于源目录的路径
+	Exclude []string
+
+	OnChange func(change SyncChange)      // 每项修改完成后（DryRun 时为比较后）调用，为nil时不报告
+	OnError  func(path string, err error) // 单个文件同步失败时调用并继续，为nil时停止并返回错误
+}
+
+// SyncAction 同步时对目标目录中的一项修改
+type SyncAction string
+
+const (
+	SyncCreate SyncAction = "create" // 复制目标目录中没有的文件或创建目录
+	SyncUpdate SyncAction = "update" // 覆盖有变化的文件
+	SyncDelete SyncAction = "delete" // 删除源目录中没有的文件或目录
+)
+
+// SyncChange 同步时的一项修改
+type SyncChange struct {
+	Action SyncAction `json:"action"`
+	Path   string     `json:"path"` // 相对于目录的路径，以 / 分隔
+	IsDir  bool       `json:"is_dir"`
+	Size   int64      `json:"size"` // 复制或删除的文件的大小，目录为0
+}
+
+// SyncResult 同步的统计
+type SyncResult struct {
+	Created   int   `json:"created"`   // 新复制的文件和创建的目录
+	Updated   int   `json:"updated"`   // 覆盖的文件
+	Deleted   int   `json:"deleted"`   // 删除的文件和目录（目录中的文件不单独计算）
+	Unchanged int   `json:"unchanged"` // 没有变化的文件
+	Failed    int   `json:"failed"`    // 交给 OnError 的失败的文件
+	Bytes     int64 `json:"bytes"`     // 复制的字节数
+}
+
+// Changed 是否有任何修改
+func (r SyncResult) Changed() bool {
+	return r.Created+r.Updated+r.Deleted > 0
+}
+
+// SyncDirs 将src目录单向同步到dst，与 rsync -a 类似：复制新增和有变化的文件，保留权限和修改时间，
+// 符号链接按链接本身复制；dst不存在时创建。文件先写入目标目录中的临时文件再重命名，中断时不会留下不完整的文件。
+// src和dst相同或一个在另一个之中时返回错误
+func SyncDirs(src, dst string, options SyncOptions) (SyncResult, error) {
+	return SyncDirsContext(context.Background(), src, dst, options)
+}
+
+// SyncDirsContext 与 SyncDirs 相同，ctx取消时在复制下一块数据前停止并返回ctx的错误，已经同步的文件会保留
+func SyncDirsContext(ctx context.Context, src, dst string, options SyncOptions) (SyncResult, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return SyncResult{}, errs.Wrap(err, "无法访问源目录: %v", err)
+	}
+	if !info.IsDir() {
+		return SyncResult{}, errs.InvalidInput("%s 不是一个目录", src)
+	}
+	if dstInfo, err := os.Stat(dst); err == nil && !dstInfo.IsDir() {
+		return SyncResult{}, errs.InvalidInput("%s 不是一个目录", dst)
+	}
+
+	s := &syncer{ctx: ctx, src: src, dst: dst, options: options}
+	for _, pattern := range options.Exclude {
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return SyncResult{}, err
+		}
+		s.exclude = append(s.exclude, glob)
+	}
+
+	if err := checkSyncOverlap(src, dst); err != nil {
+		return SyncResult{}, err
+	}
+
+	if !options.DryRun {
+		if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+			return SyncResult{}, errs.Wrap(err, "创建目标目录失败: %v", err)
+		}
+	}
+	_, err = os.Stat(dst)
+	err = s.dir("", err == nil)
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return s.result, err
+}
+
+// checkSyncOverlap 按实际路径（解析符号链接后）检查src和dst是否相同或互相包含：
+// dst在src中时会把同步的结果再同步一遍，src在dst中时 Delete 会删除源目录本身
+func checkSyncOverlap(src, dst string) error {
+	srcAbs, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	dstAbs, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	srcReal, err := realPath(srcAbs)
+	if err != nil {
+		return errs.Wrap(err, "无法解析源目录: %v", err)
+	}
+	dstReal, err := realPath(dstAbs)
+	if err != nil {
+		return errs.Wrap(err, "无法解析目标目录: %v", err)
+	}
+	switch {
+	case srcReal == dstReal:
+		return errs.InvalidInput("源目录和目标目录相同")
+	case withinDir(dstReal, srcReal):
+		return errs.InvalidInput("目标目录 %s 在源目录 %s 中", dst, src)
+	case withinDir(srcReal, dstReal):
+		return errs.InvalidInput("源目录 %s 在目标目录 %s 中", src, dst)
+	}
+	return nil
+}
+
+// syncer 同步时的状态
+type syncer struct {
+	ctx     context.Context
+	src     string
+	dst     string
+	options SyncOptions
+	exclude []string // 转换后的排除模式
+	result  SyncResult
+}
+
+// dir 同步相对路径为rel的目录中的条目；dstExists 为false时（新建的目录或 DryRun）目标按空目录比较
+func (s *syncer) dir(rel string, dstExists bool) error {
+	srcEntries, err := os.ReadDir(filepath.Join(s.src, filepath.FromSlash(rel)))
+	if err != nil {
+		return s.fail(rel, err)
+	}
+	var dstEntries []os.DirEntry
+	if dstExists {
+		if dstEntries, err = os.ReadDir(filepath.Join(s.dst, filepath.FromSlash(rel))); err != nil {
+			return s.fail(rel, err)
+		}
+	}
+
+	names := make(map[string]bool, len(srcEntries))
+	for _, entry := range srcEntries {
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		name := path.Join(rel, entry.Name())
+		names[entry.Name()] = true
+		if s.excluded(name) {
+			continue
+		}
+		if err := s.entry(name); err != nil {
+			return err
+		}
+	}
+
+	if !s.options.Delete {
+		return nil
+	}
+	for _, entry := range dstEntries {
+		name := path.Join(rel, entry.Name())
+		if names[entry.Name()] || s.excluded(name) {
+			continue
+		}
+		if err := s.remove(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// excluded 是否排除相对路径为name的文件或目录
+func (s *syncer) excluded(name string) bool {
+	for _, glob := range s.exclude {
+		if matchGlob(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// entry 同步一个文件、符号链接或目录
+func (s *syncer) entry(name string) error {
+	srcPath := filepath.Join(s.src, filepath.FromSlash(name))
+	dstPath := filepath.Join(s.dst, filepath.FromSlash(name))
+	info, err := os.Lstat(srcPath)
+	if err != nil {
+		return s.fail(name, err)
+	}
+	dstInfo, err := os.Lstat(dstPath)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return s.fail(name, err)
+	}
+
+	// 类型不同（如文件变为目录）时先删除目标，再按覆盖处理
+	replaced := false
+	if exists && syncType(info) != syncType(dstInfo) {
+		if !s.options.DryRun {
+			if err := os.RemoveAll(dstPath); err != nil {
+				return s.fail(name, err)
+			}
+		}
+		exists, replaced = false, true
+	}
+
+	switch {
+	case info.IsDir():
+		if !exists {
+			if !s.options.DryRun {
+				if err := os.Mkdir(dstPath, info.Mode().Perm()); err != nil {
+					return s.fail(name, err)
+				}
+			}
+			s.changed(SyncChange{Path: name, IsDir: true}, replaced)
+		}
+		return s.dir(name, exists)
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(srcPath)
+		if err != nil {
+			return s.fail(name, err)
+		}
+		if exists {
+			if current, err := os.Readlink(dstPath); err == nil && current == target {
+				s.result.Unchanged++
+				return nil
+			}
+		}
+		if !s.options.DryRun {
+			os.Remove(dstPath)
+			if err := os.Symlink(target, dstPath); err != nil {
+				return s.fail(name, err)
+			}
+		}
+		s.changed(SyncChange{Path: name}, exists || replaced)
+	case info.Mode().IsRegular():
+		if exists {
+			same, err := s.sameFile(srcPath, dstPath, info, dstInfo)
+			if err != nil {
+				return s.fail(name, err)
+			}
+			if same {
+				// 按内容比较时只是修改时间不同，更新修改时间，下次不按大小和时间比较时也认为相同
+				if s.options.Checksum && !s.options.DryRun && !info.ModTime().Equal(dstInfo.ModTime()) {
+					os.Chtimes(dstPath, info.ModTime(), info.ModTime())
+				}
+				s.result.Unchanged++
+				return nil
+			}
+		}
+		if !s.options.DryRun {
+			if err := s.copyFile(srcPath, dstPath, info); err != nil {
+				if s.ctx.Err() != nil {
+					return s.ctx.Err()
+				}
+				return s.fail(name, err)
+			}
+		}
+		s.result.Bytes += info.Size()
+		s.changed(SyncChange{Path: name, Size: info.Size()}, exists || replaced)
+	}
+	// 设备文件、命名管道等不同步
+	return nil
+}
+
+// syncType 比较时的文件类型：目录、符号链接或其他
+func syncType(info os.FileInfo) os.FileMode {
+	return info.Mode() & (os.ModeDir | os.ModeSymlink)
+}
+
+// sameFile 判断目标文件是否与源文件相同：大小和修改时间（精确到秒）都相同，
+// Checksum 时大小相同的文件比较内容
+func (s *syncer) sameFile(srcPath, dstPath string, info, dstInfo os.FileInfo) (bool, error) {
+	if info.Size() != dstInfo.Size() {
+		return false, nil
+	}
+	if !s.options.Checksum {
+		return info.ModTime().Truncate(time.Second).Equal(dstInfo.ModTime().Truncate(time.Second)), nil
+	}
+	p := newProgress(s.ctx, nil, 0)
+	srcSum, err := fileSum(srcPath, p)
+	if err != nil {
+		return false, err
+	}
+	dstSum, err := fileSum(dstPath, p)
+	if err != nil {
+		return false, err
+	}
+	return srcSum == dstSum, nil
+}
+
+// copyFile 复制文件到目标目录中的临时文件，设置权限和修改时间后重命名为目标文件
+func (s *syncer) copyFile(srcPath, dstPath string, info os.FileInfo) error {
+	in, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dstPath), "."+filepath.Base(dstPath)+".sync-*")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tmp, newProgress(s.ctx, nil, 0).reader(in))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), info.Mode().Perm())
+	}
+	if err == nil {
+		err = os.Chtimes(tmp.Name(), info.ModTime(), info.ModTime())
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dstPath)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// remove 删除目标目录中源目录没有的文件或目录
+func (s *syncer) remove(name string) error {
+	dstPath := filepath.Join(s.dst, filepath.FromSlash(name))
+	info, err := os.Lstat(dstPath)
+	if err != nil {
+		return s.fail(name, err)
+	}
+	if !s.options.DryRun {
+		if err := os.RemoveAll(dstPath); err != nil {
+			return s.fail(name, err)
+		}
+	}
+	change := SyncChange{Action: SyncDelete, Path: name, IsDir: info.IsDir()}
+	if !info.IsDir() {
+		change.Size = info.Size()
+	}
+	s.report(change)
+	s.result.Deleted++
+	return nil
+}
+
+// changed 记录新建或覆盖的文件、目录
+func (s *syncer) changed(change SyncChange, update bool) {
+	change.Action = SyncCreate
+	if update {
+		change.Action = SyncUpdate
+		s.result.Updated++
+	} else {
+		s.result.Created++
+	}
+	s.report(change)
+}
+
+func (s *syncer) report(change SyncChange) {
+	if s.options.OnChange != nil {
+		s.options.OnChange(change)
+	}
+}
+
+// fail 处理单个文件的错误：有 OnError 时报告并继续，否则返回错误
+func (s *syncer) fail(name string, err error) error {
+	if s.options.OnError == nil {
+		return i18n.Errorf("同步 %s 失败: %v", name, err)
+	}
+	s.result.Failed++
+	s.options.OnError(name, err)
+	return nil
+}
@@ -0,0 +1,58 @@
+package fsutils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"toolbox/pkg/errs"
+)
+
+func TestSyncDirsRejectsOverlap(t *testing.T) {
+	base := t.TempDir()
+	outer := filepath.Join(base, "sy")
+	inner := filepath.Join(outer, "a")
+	if err := os.MkdirAll(inner, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(inner, "f"), []byte("data"), 0644)
+	alias := filepath.Join(base, "alias")
+	if err := os.Symlink(outer, alias); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		src, dst string
+	}{
+		{"源目录在目标目录中", inner, outer},
+		{"目标目录在源目录中", outer, inner},
+		{"目标目录不存在但在源目录中", outer, filepath.Join(inner, "new", "dir")},
+		{"通过符号链接指向同一目录", outer, alias},
+		{"通过符号链接指向源目录的父目录", inner, alias},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := SyncDirs(c.src, c.dst, SyncOptions{Delete: true})
+			if !errors.Is(err, errs.ErrInvalidInput) {
+				t.Fatalf("SyncDirs(%s, %s) 应返回参数错误，实际为 %v", c.src, c.dst, err)
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(inner, "f")); err != nil {
+		t.Fatalf("源目录中的文件被删除: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(inner, "new")); err == nil {
+		t.Fatal("拒绝同步时不应创建目标目录")
+	}
+
+	dst := filepath.Join(base, "copy")
+	result, err := SyncDirs(outer, dst, SyncOptions{Delete: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created == 0 {
+		t.Fatal("不重叠的目录应正常同步")
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // TreeOptions 表示目录树显示的选项
@@ -16,102 +19,269 @@ type TreeOptions struct {
 	OnlyDirs      bool // 是否只显示目录
 	FollowSymlink bool // 是否跟踪符号链接
 	ShowSize      bool // 是否显示文件大小
+
+	// DirSizes 计算每个目录中所有文件大小之和并显示在目录后，与 du --apparent-size 类似但不计目录本身：
+	// 包括隐藏的、超出最大深度的和只显示目录时不显示的文件，多个硬链接只计算一次
+	DirSizes bool
+	// SortBySize 同一目录中的条目按大小从大到小排序，目录按累计的大小（需要 DirSizes）
+	SortBySize bool
+	// NoColor 不按文件类型给名称加颜色（目录、符号链接、可执行文件、压缩文件等，可以通过 LS_COLORS 修改）。
+	// 颜色使用 fatih/color 输出，输出不是终端或全局关闭颜色时同样不加颜色
+	NoColor bool
 }
 
 // TreeResult 表示目录树显示的结果
 type TreeResult struct {
-	DirCount  int // 目录数量
-	FileCount int // 文件数量
+	DirCount  int   // 目录数量
+	FileCount int   // 文件数量
+	TotalSize int64 // DirSizes 时根目录中所有文件的大小之和
 }
 
-// DisplayTree 显示指定目录的文件树结构
-func DisplayTree(root string, writer io.Writer, options TreeOptions) (TreeResult, error) {
-	result := TreeResult{
-		DirCount:  0,
-		FileCount: 0,
-	}
+// TreeNode 目录树中的一个文件或目录，JSON字段名供其他工具和图形界面使用
+type TreeNode struct {
+	Name       string      `json:"name"`
+	Path       string      `json:"path"`
+	Type       string      `json:"type"` // file, dir, symlink；跟随的指向目录的符号链接为 dir
+	Size       int64       `json:"size"` // 目录在 DirSizes 时为累计的大小，否则为0
+	Mode       string      `json:"mode"`
+	ModTime    time.Time   `json:"mod_time"`
+	LinkTarget string      `json:"link_target,omitempty"` // 符号链接指向的路径
+	Error      string      `json:"error,omitempty"`       // 无法读取目录时的错误
+	Children   []*TreeNode `json:"children,omitempty"`    // 按名称排序的子节点
+
+	mode os.FileMode
+}
+
+// IsDir 是否为目录，包括跟随的指向目录的符号链接
+func (n *TreeNode) IsDir() bool {
+	return n.Type == "dir"
+}
+
+// BuildTree 读取目录结构，返回以root为根的节点树和目录、文件的数量。
+// 节点的路径为绝对路径；无法读取的子目录记录在节点的 Error 中，不会中止
+func BuildTree(root string, options TreeOptions) (*TreeNode, TreeResult, error) {
+	var result TreeResult
 
 	// 检查目录是否存在
 	fi, err := os.Stat(root)
 	if err != nil {
-		return result, fmt.Errorf("无法访问目录 %s: %v", root, err)
+		return nil, result, errs.Wrap(err, "无法访问目录 %s: %v", root, err)
 	}
 
 	if !fi.IsDir() {
-		return result, fmt.Errorf("%s 不是一个目录", root)
+		return nil, result, errs.InvalidInput("%s 不是一个目录", root)
 	}
 
-	// 显示根目录
 	absPath, err := filepath.Abs(root)
 	if err != nil {
 		absPath = root
 	}
-	fmt.Fprintf(writer, "%s\n", absPath)
+	node := newTreeNode(absPath, fi)
+	node.Type = "dir"
 
-	// 存储已访问的目录路径，避免符号链接造成的循环
-	visited := make(map[string]bool)
-	visited[absPath] = true
+	b := &treeBuilder{options: options, visited: make(map[string]bool), linked: make(map[fileKey]bool)}
+	if realPath, err := filepath.EvalSymlinks(absPath); err == nil {
+		b.visited[realPath] = true
+	}
 
-	// 开始递归显示目录树
-	err = displayTreeNode(root, "", writer, options, &result, 1, visited)
+	// 开始递归读取目录树
+	if err := b.build(node, 1); err != nil {
+		return nil, b.result, err
+	}
+	if options.DirSizes {
+		b.result.TotalSize = node.Size
+	}
+	return node, b.result, nil
+}
+
+// DisplayTree 显示指定目录的文件树结构
+func DisplayTree(root string, writer io.Writer, options TreeOptions) (TreeResult, error) {
+	node, result, err := BuildTree(root, options)
 	if err != nil {
 		return result, err
 	}
 
+	var colors *treeColors
+	rootName := node.Path
+	if !options.NoColor {
+		colors = newTreeColors()
+		rootName = colors.dir.Sprint(rootName)
+	}
+
+	// 显示根目录
+	if options.DirSizes {
+		fmt.Fprintf(writer, "%s [%s]\n", rootName, treeSize(node.Size))
+	} else {
+		fmt.Fprintf(writer, "%s\n", rootName)
+	}
+	writeTreeChildren(writer, node, "", options, colors)
+
 	// 显示统计信息
-	fmt.Fprintf(writer, "\n%d 个目录", result.DirCount)
+	fmt.Fprint(writer, i18n.Tf("\n%d 个目录", result.DirCount))
 	if !options.OnlyDirs {
-		fmt.Fprintf(writer, "，%d 个文件", result.FileCount)
+		fmt.Fprint(writer, i18n.Tf("，%d 个文件", result.FileCount))
+	}
+	if options.DirSizes {
+		fmt.Fprint(writer, i18n.Tf("，共 %s", treeSize(result.TotalSize)))
 	}
 	fmt.Fprintln(writer)
 
 	return result, nil
 }
 
-// displayTreeNode 递归显示目录树节点
-func displayTreeNode(path string, prefix string, writer io.Writer, options TreeOptions, result *TreeResult, depth int, visited map[string]bool) error {
-	// 检查最大深度限制
+// newTreeNode 根据文件信息创建节点
+func newTreeNode(path string, info os.FileInfo) *TreeNode {
+	node := &TreeNode{
+		Name:    filepath.Base(path),
+		Path:    path,
+		Type:    "file",
+		Size:    info.Size(),
+		Mode:    info.Mode().String(),
+		ModTime: info.ModTime(),
+		mode:    info.Mode(),
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		node.Type = "symlink"
+		if target, err := os.Readlink(path); err == nil {
+			node.LinkTarget = target
+		}
+	case info.IsDir():
+		node.Type = "dir"
+		node.Size = 0
+	}
+	return node
+}
+
+// treeBuilder 读取目录树时的状态
+type treeBuilder struct {
+	options TreeOptions
+	result  TreeResult
+	visited map[string]bool  // 已访问的目录的真实路径，避免符号链接造成的循环
+	linked  map[fileKey]bool // DirSizes 时已经计算过大小的硬链接
+}
+
+// build 递归读取目录节点的子节点，DirSizes 时同时累计目录的大小
+func (b *treeBuilder) build(node *TreeNode, depth int) error {
+	options := b.options
+
+	// 检查最大深度限制，超出的部分只计算大小
 	if options.MaxDepth > 0 && depth > options.MaxDepth {
+		if options.DirSizes {
+			node.Size = b.dirSize(node.Path)
+		}
 		return nil
 	}
 
 	// 读取目录内容
-	entries, err := os.ReadDir(path)
+	entries, err := os.ReadDir(node.Path)
 	if err != nil {
-		return fmt.Errorf("无法读取目录 %s: %v", path, err)
+		return i18n.Errorf("无法读取目录 %s: %v", node.Path, err)
 	}
 
-	// 过滤和排序条目
-	var filteredEntries []os.DirEntry
+	// 按名称排序
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
 	for _, entry := range entries {
 		name := entry.Name()
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(node.Path, name)
 
-		// 是否跳过隐藏文件
+		// 是否跳过隐藏文件，DirSizes 时仍计入目录的大小
 		if !options.ShowHidden && strings.HasPrefix(name, ".") {
+			if options.DirSizes {
+				node.Size += b.entrySize(path, info)
+			}
 			continue
 		}
 
-		// 是否只显示目录
-		if options.OnlyDirs && !entry.IsDir() {
+		child := newTreeNode(path, info)
+
+		// 跟随指向目录的符号链接，避免循环引用
+		isDir := entry.IsDir()
+		if child.Type == "symlink" && options.FollowSymlink {
+			if target, err := os.Stat(child.Path); err == nil && target.IsDir() {
+				realPath, err := filepath.EvalSymlinks(child.Path)
+				isDir = err == nil && !b.visited[realPath]
+				if isDir {
+					b.visited[realPath] = true
+					child.Type, child.Size = "dir", 0
+				}
+			}
+		}
+
+		if !isDir {
+			if options.DirSizes {
+				node.Size += b.fileSize(info)
+			}
+			// 是否只显示目录
+			if options.OnlyDirs {
+				continue
+			}
+			node.Children = append(node.Children, child)
+			b.result.FileCount++
 			continue
 		}
 
-		filteredEntries = append(filteredEntries, entry)
+		node.Children = append(node.Children, child)
+		b.result.DirCount++
+		if err := b.build(child, depth+1); err != nil {
+			// 继续处理其他目录
+			child.Error = err.Error()
+		}
+		node.Size += child.Size
 	}
 
-	// 按名称排序
-	sort.Slice(filteredEntries, func(i, j int) bool {
-		return filteredEntries[i].Name() < filteredEntries[j].Name()
-	})
+	if options.SortBySize {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return node.Children[i].Size > node.Children[j].Size
+		})
+	}
+	return nil
+}
 
-	// 处理每个条目
-	for i, entry := range filteredEntries {
-		// 确定是否是最后一个条目
-		isLast := i == len(filteredEntries)-1
+// fileSize 返回文件计入目录的大小，同一文件的其他硬链接已经计算过时为0
+func (b *treeBuilder) fileSize(info os.FileInfo) int64 {
+	if key, ok := hardLinkKey(info); ok {
+		if b.linked[key] {
+			return 0
+		}
+		b.linked[key] = true
+	}
+	return info.Size()
+}
+
+// entrySize 返回不显示的文件或目录的大小，目录为其中所有文件的大小之和
+func (b *treeBuilder) entrySize(path string, info os.FileInfo) int64 {
+	if info.IsDir() {
+		return b.dirSize(path)
+	}
+	return b.fileSize(info)
+}
+
+// dirSize 计算目录中所有文件的大小之和，不跟随符号链接，无法访问的文件忽略
+func (b *treeBuilder) dirSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += b.fileSize(info)
+		}
+		return nil
+	})
+	return size
+}
 
+// writeTreeChildren 以树状图形输出节点的子节点，colors 为nil时不加颜色
+func writeTreeChildren(writer io.Writer, node *TreeNode, prefix string, options TreeOptions, colors *treeColors) {
+	for i, child := range node.Children {
 		// 确定当前行的前缀和下一级的前缀
 		var currentPrefix, nextPrefix string
-		if isLast {
+		if i == len(node.Children)-1 {
 			currentPrefix = prefix + "└── "
 			nextPrefix = prefix + "    "
 		} else {
@@ -119,69 +289,38 @@ func displayTreeNode(path string, prefix string, writer io.Writer, options TreeO
 			nextPrefix = prefix + "│   "
 		}
 
-		// 获取完整路径
-		entryPath := filepath.Join(path, entry.Name())
-
 		// 处理符号链接
-		isSymlink := entry.Type()&os.ModeSymlink != 0
 		var linkTarget string
-		if isSymlink {
-			if target, err := os.Readlink(entryPath); err == nil {
-				linkTarget = " -> " + target
-			}
+		if child.LinkTarget != "" {
+			linkTarget = " -> " + child.LinkTarget
 		}
 
-		// 显示大小
+		// 显示大小，DirSizes 时目录显示累计的大小
 		sizeStr := ""
-		if options.ShowSize && !entry.IsDir() {
-			if info, err := entry.Info(); err == nil {
-				size := info.Size()
-				if size < 1024 {
-					sizeStr = fmt.Sprintf(" [%d B]", size)
-				} else if size < 1024*1024 {
-					sizeStr = fmt.Sprintf(" [%.1f KB]", float64(size)/1024)
-				} else if size < 1024*1024*1024 {
-					sizeStr = fmt.Sprintf(" [%.1f MB]", float64(size)/(1024*1024))
-				} else {
-					sizeStr = fmt.Sprintf(" [%.1f GB]", float64(size)/(1024*1024*1024))
-				}
-			}
+		if size, ok := exportSize(child, options); ok {
+			sizeStr = " [" + size + "]"
 		}
 
-		// 输出当前节点
-		fmt.Fprintf(writer, "%s%s%s%s\n", currentPrefix, entry.Name(), linkTarget, sizeStr)
-
-		// 递归处理子目录
-		if entry.IsDir() {
-			result.DirCount++
-
-			// 处理符号链接指向的目录
-			if isSymlink && options.FollowSymlink {
-				realPath, err := filepath.EvalSymlinks(entryPath)
-				if err != nil {
-					continue
-				}
-
-				// 避免循环引用
-				if visited[realPath] {
-					continue
-				}
-				visited[realPath] = true
-
-				if err := displayTreeNode(realPath, nextPrefix, writer, options, result, depth+1, visited); err != nil {
-					// 继续处理，忽略错误
-					continue
-				}
-			} else if !isSymlink {
-				if err := displayTreeNode(entryPath, nextPrefix, writer, options, result, depth+1, visited); err != nil {
-					// 继续处理，忽略错误
-					continue
-				}
-			}
-		} else if !entry.IsDir() && !options.OnlyDirs {
-			result.FileCount++
+		name := child.Name
+		if colors != nil {
+			name = colors.paint(child)
 		}
+
+		// 输出当前节点
+		fmt.Fprintf(writer, "%s%s%s%s\n", currentPrefix, name, linkTarget, sizeStr)
+		writeTreeChildren(writer, child, nextPrefix, options, colors)
 	}
+}
 
-	return nil
+// treeSize 格式化目录树中显示的大小
+func treeSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	case size < 1024*1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	}
+	return fmt.Sprintf("%.1f GB", float64(size)/(1024*1024*1024))
 }
@@ -0,0 +1,98 @@
+package fsutils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// treeColors 目录树中按文件类型使用的颜色，默认与 ls --color 相近，可以通过 LS_COLORS 环境变量修改
+type treeColors struct {
+	dir     *color.Color
+	link    *color.Color
+	orphan  *color.Color // 目标不存在的符号链接
+	exec    *color.Color
+	archive *color.Color            // 压缩文件
+	exts    map[string]*color.Color // LS_COLORS 中按扩展名（小写，含 .）指定的颜色
+}
+
+// newTreeColors 返回默认颜色，再按 LS_COLORS 中的 di、ln、or、ex 和 *.扩展名 覆盖
+func newTreeColors() *treeColors {
+	c := &treeColors{
+		dir:     color.New(color.FgBlue, color.Bold),
+		link:    color.New(color.FgCyan, color.Bold),
+		orphan:  color.New(color.FgRed, color.Bold),
+		exec:    color.New(color.FgGreen, color.Bold),
+		archive: color.New(color.FgRed, color.Bold),
+		exts:    make(map[string]*color.Color),
+	}
+	for _, item := range strings.Split(os.Getenv("LS_COLORS"), ":") {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		attrs, ok := parseSGR(value)
+		if !ok {
+			continue
+		}
+		switch {
+		case key == "di":
+			c.dir = color.New(attrs...)
+		case key == "ln" && value != "target":
+			c.link = color.New(attrs...)
+		case key == "or":
+			c.orphan = color.New(attrs...)
+		case key == "ex":
+			c.exec = color.New(attrs...)
+		case strings.HasPrefix(key, "*."):
+			c.exts[strings.ToLower(key[1:])] = color.New(attrs...)
+		}
+	}
+	return c
+}
+
+// parseSGR 解析 LS_COLORS 中以分号分隔的颜色代码，如 01;34
+func parseSGR(value string) ([]color.Attribute, bool) {
+	var attrs []color.Attribute
+	for _, code := range strings.Split(value, ";") {
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			return nil, false
+		}
+		attrs = append(attrs, color.Attribute(n))
+	}
+	return attrs, len(attrs) > 0
+}
+
+// paint 按节点的类型给名称加上颜色；颜色被全局关闭（如输出不是终端）时原样返回
+func (c *treeColors) paint(node *TreeNode) string {
+	name := node.Name
+	var nc *color.Color
+	switch {
+	case node.IsDir():
+		nc = c.dir
+	case node.Type == "symlink":
+		nc = c.link
+		if _, err := os.Stat(node.Path); err != nil {
+			nc = c.orphan
+		}
+	case node.mode.IsRegular() && node.mode&0111 != 0:
+		nc = c.exec
+	default:
+		lower := strings.ToLower(name)
+		if i := strings.LastIndex(lower, "."); i >= 0 {
+			nc = c.exts[lower[i:]]
+		}
+		if nc == nil {
+			if _, ok := formatFromName(lower); ok {
+				nc = c.archive
+			}
+		}
+	}
+	if nc == nil {
+		return name
+	}
+	return nc.Sprint(name)
+}
@@ -0,0 +1,115 @@
+package fsutils
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// WriteTreeMarkdown 将目录树写为Markdown的嵌套列表，目录名加粗并以 / 结尾，便于嵌入文档。
+// options 中的 ShowSize 和 DirSizes 决定是否在名称后显示大小
+func WriteTreeMarkdown(w io.Writer, node *TreeNode, options TreeOptions) error {
+	bw := bufio.NewWriter(w)
+	writeMarkdownNode(bw, node, 0, options)
+	return bw.Flush()
+}
+
+func writeMarkdownNode(w *bufio.Writer, node *TreeNode, level int, options TreeOptions) {
+	name := escapeMarkdown(node.Name)
+	if node.IsDir() {
+		name = "**" + name + "/**"
+	}
+	fmt.Fprintf(w, "%s- %s", strings.Repeat("  ", level), name)
+	if node.LinkTarget != "" {
+		fmt.Fprintf(w, " → %s", escapeMarkdown(node.LinkTarget))
+	}
+	if size, ok := exportSize(node, options); ok {
+		fmt.Fprintf(w, " (%s)", size)
+	}
+	w.WriteString("\n")
+	for _, child := range node.Children {
+		writeMarkdownNode(w, child, level+1, options)
+	}
+}
+
+// escapeMarkdown 转义文件名中会被当作Markdown格式的字符
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("\\`*_[]<>|~", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// treeHTMLStyle 导出的HTML页面的样式
+const treeHTMLStyle = `body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; }
+ul { list-style: none; margin: 0; padding-left: 1.4em; }
+li { margin: 0.15em 0; }
+summary { cursor: pointer; font-weight: bold; }
+.dir { color: #1f5fbf; }
+.link { color: #11808a; }
+.size { color: #888; margin-left: 0.5em; font-size: 0.9em; }
+.error { color: #c0392b; margin-left: 0.5em; font-size: 0.9em; }`
+
+// WriteTreeHTML 将目录树写为独立的HTML页面，目录使用 <details> 可以点击折叠和展开，不需要脚本。
+// 根目录和第一层目录默认展开；options 中的 ShowSize 和 DirSizes 决定是否显示大小
+func WriteTreeHTML(w io.Writer, node *TreeNode, options TreeOptions) error {
+	bw := bufio.NewWriter(w)
+	title := html.EscapeString(node.Path)
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n", title, treeHTMLStyle)
+	bw.WriteString("<ul>\n")
+	writeHTMLNode(bw, node, 0, options)
+	bw.WriteString("</ul>\n</body>\n</html>\n")
+	return bw.Flush()
+}
+
+func writeHTMLNode(w *bufio.Writer, node *TreeNode, level int, options TreeOptions) {
+	indent := strings.Repeat("  ", level+1)
+	label := html.EscapeString(node.Name)
+	if level == 0 {
+		label = html.EscapeString(node.Path)
+	}
+	if node.IsDir() {
+		label += "/"
+	}
+	if node.LinkTarget != "" {
+		label = fmt.Sprintf(`<span class="link">%s</span> → %s`, label, html.EscapeString(node.LinkTarget))
+	}
+	if size, ok := exportSize(node, options); ok {
+		label += fmt.Sprintf(` <span class="size">%s</span>`, size)
+	}
+	if node.Error != "" {
+		label += fmt.Sprintf(` <span class="error">%s</span>`, html.EscapeString(node.Error))
+	}
+
+	if !node.IsDir() {
+		fmt.Fprintf(w, "%s<li>%s</li>\n", indent, label)
+		return
+	}
+	if len(node.Children) == 0 {
+		fmt.Fprintf(w, "%s<li><span class=\"dir\">%s</span></li>\n", indent, label)
+		return
+	}
+	open := ""
+	if level < 2 {
+		open = " open"
+	}
+	fmt.Fprintf(w, "%s<li><details%s><summary class=\"dir\">%s</summary>\n%s<ul>\n", indent, open, label, indent)
+	for _, child := range node.Children {
+		writeHTMLNode(w, child, level+1, options)
+	}
+	fmt.Fprintf(w, "%s</ul></details></li>\n", indent)
+}
+
+// exportSize 返回导出时在名称后显示的大小：ShowSize 时显示文件的大小，DirSizes 时还显示目录累计的大小
+func exportSize(node *TreeNode, options TreeOptions) (string, bool) {
+	if (options.ShowSize && !node.IsDir()) || options.DirSizes {
+		return treeSize(node.Size), true
+	}
+	return "", false
+}
@@ -0,0 +1,72 @@
+package fsutils
+
+import (
+	"context"
+	"io"
+)
+
+// VerifyResult 校验压缩文件的结果
+type VerifyResult struct {
+	Files int   // 校验通过的文件数，不包括目录
+	Size  int64 // 校验通过的文件解压后的总大小
+}
+
+// VerifyArchive 检查压缩文件的完整性：解压每个文件的内容但不写入磁盘，由各格式校验CRC等校验值，
+// 数据损坏、截断或密码错误时返回错误。
+//
+// 使用 options 中的 Format、Progress、Password、Include、OnError 和 ContinueOnError，与解压缩时的含义相同；
+// OnError 返回nil或设置了 ContinueOnError 时继续校验其余文件。tar、7z等整体压缩的格式中某个文件损坏后，之后的数据通常也无法读取。
+// 设置了 Manifest 时还按清单检查每个文件的SHA-256校验和
+func VerifyArchive(path string, options DecompressOptions) (VerifyResult, error) {
+	return VerifyArchiveContext(context.Background(), path, options)
+}
+
+// VerifyArchiveContext 与 VerifyArchive 相同，ctx取消时在读取下一块数据前停止并返回ctx的错误
+func VerifyArchiveContext(ctx context.Context, path string, options DecompressOptions) (result VerifyResult, err error) {
+	if options.include, err = newIncludeFilter(options.Include); err != nil {
+		return result, err
+	}
+	if err := options.loadManifest(); err != nil {
+		return result, err
+	}
+	// 单文件格式中的文件名来自压缩文件名，可能与压缩前不同，按清单中唯一的文件检查
+	manifestName := func(name string) string { return name }
+	if options.sums != nil {
+		if options.Format == "" {
+			if options.Format, err = DetectFormat(path); err != nil {
+				return result, err
+			}
+		}
+		if isSingleFile(options.Format) {
+			manifestName = func(string) string { return options.sums.single() }
+		}
+	}
+
+	err = walkArchive(ctx, path, options, func(entry ArchiveEntry, open entryOpener) error {
+		if entry.IsDir || !options.included(entry.Name) {
+			return nil
+		}
+		r, err := open()
+		var n int64
+		if err == nil {
+			n, err = io.Copy(io.Discard, options.sums.verify(manifestName(entry.Name), r))
+		}
+		if err != nil {
+			// rar的密码错误在读取内容时才发现
+			return options.entryFailed(entry.Name, rarPasswordError(err))
+		}
+		result.Files++
+		result.Size += n
+		return nil
+	})
+	if err == nil {
+		err = options.include.unmatched()
+	}
+	if err == nil {
+		err = options.manifestMissing()
+	}
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return result, err
+}
@@ -0,0 +1,244 @@
+package fsutils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle 文件最后一次变化后等待的时间，写入过程中的多次修改只报告一次
+const watchSettle = 200 * time.Millisecond
+
+// WatchFind 监视root下新建和修改的文件，对满足 options 筛选条件的文件调用fn，直到fn返回错误或出错。
+// 启动前已存在的文件不会报告，见 WatchFindContext
+func WatchFind(root string, options FindOptions, fn func(FindResult) error) error {
+	return WatchFindContext(context.Background(), root, options, fn)
+}
+
+// WatchFindContext 与 WatchFind 相同，ctx取消时停止并返回ctx的错误。
+//
+// 新建的子目录会自动加入监视，其中已有的匹配文件（包括目录本身）立即报告。同一文件在 200ms 内的多次变化
+// 只报告一次，写入大文件的过程中不会重复报告。设置了 Action 时先调用 Action 再调用fn，
+// 达到 Limit 个结果后返回nil。ExcludeDirs、MaxDepth 和忽略文件排除的目录不会监视；
+// IncludeDirs、Delete 和 SortBy 不能用于监视
+func WatchFindContext(ctx context.Context, root string, options FindOptions, fn func(FindResult) error) error {
+	if len(options.IncludeDirs) > 0 || options.Delete || options.SortBy != "" {
+		return errs.InvalidInput("监视模式不支持指定包含目录、删除和排序")
+	}
+	matcher, err := newFindMatcher(options)
+	if err != nil {
+		return err
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return i18n.Errorf("无法获取绝对路径: %v", err)
+	}
+	if info, err := os.Stat(root); err != nil {
+		return errs.Wrap(err, "无法访问目录 %s: %v", root, err)
+	} else if !info.IsDir() {
+		return errs.InvalidInput("%s 不是目录", root)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errs.Wrap(err, "无法创建文件监视: %v", err)
+	}
+	defer watcher.Close()
+
+	w := &findWatch{
+		ctx:     ctx,
+		root:    root,
+		options: options,
+		matcher: matcher,
+		watcher: watcher,
+		fn:      fn,
+		pending: make(map[string]time.Time),
+	}
+	w.ignores = newIgnoreMatcher(root, options, w.warn)
+	if err := w.addTree(root, false); err != nil {
+		return w.finish(err)
+	}
+	return w.finish(w.run())
+}
+
+// errWatchLimit 达到 Limit 后停止监视
+var errWatchLimit = i18n.NewError("已达到结果数量")
+
+// findWatch WatchFind 的状态
+type findWatch struct {
+	ctx     context.Context
+	root    string
+	options FindOptions
+	matcher *findMatcher
+	ignores *ignoreMatcher
+	watcher *fsnotify.Watcher
+	fn      func(FindResult) error
+	pending map[string]time.Time // 等待文件稳定后再检查的路径 → 最后一次变化的时间
+	count   int
+}
+
+// finish 将达到 Limit 转换为正常结束
+func (w *findWatch) finish(err error) error {
+	if err == errWatchLimit {
+		return nil
+	}
+	return err
+}
+
+func (w *findWatch) warn(path string, err error) {
+	if w.options.OnError != nil {
+		w.options.OnError(path, err)
+	}
+}
+
+// run 处理文件变化事件，定时检查已经稳定的文件
+func (w *findWatch) run() error {
+	ticker := time.NewTicker(watchSettle / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return w.ctx.Err()
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.warn(w.root, i18n.Errorf("监视时出错: %v", err))
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			// 新建的目录立即加入监视，避免漏掉其中紧接着创建的文件
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Lstat(event.Name); err == nil && info.IsDir() {
+					if err := w.addTree(event.Name, true); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			w.pending[event.Name] = time.Now()
+
+		case now := <-ticker.C:
+			for path, changed := range w.pending {
+				if now.Sub(changed) < watchSettle {
+					continue
+				}
+				delete(w.pending, path)
+				info, err := os.Lstat(path)
+				if err != nil {
+					// 已经删除或重命名
+					continue
+				}
+				if err := w.check(path, info); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// addTree 监视dir及其子目录，被排除、忽略或超过最大深度的目录不监视。
+// report 为true时报告其中已有的匹配文件，用于监视开始后新建的目录
+func (w *findWatch) addTree(dir string, report bool) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := w.ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			w.warn(path, i18n.Errorf("访问时出错: %v", err))
+			return nil
+		}
+		depth := w.depth(path)
+		if path != w.root {
+			if info.IsDir() && isExcludedDir(path, w.options.ExcludeDirs) {
+				return filepath.SkipDir
+			}
+			if w.ignores != nil && w.ignores.ignored(path, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if report {
+			if err := w.check(path, info); err != nil {
+				return err
+			}
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		if w.ignores != nil {
+			w.ignores.load(path, w.warn)
+		}
+		// 最大深度的目录中的文件超过了最大深度，不需要监视
+		if w.options.MaxDepth > 0 && depth >= w.options.MaxDepth {
+			return filepath.SkipDir
+		}
+		if err := w.watcher.Add(path); err != nil {
+			w.warn(path, i18n.Errorf("无法监视目录: %v", err))
+		}
+		return nil
+	})
+}
+
+// depth 计算相对于起始目录的深度
+func (w *findWatch) depth(path string) int {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return len(strings.Split(filepath.ToSlash(rel), "/"))
+}
+
+// check 检查变化的文件是否满足筛选条件，满足时调用 Action 和fn
+func (w *findWatch) check(path string, info os.FileInfo) error {
+	depth := w.depth(path)
+	if w.options.MinDepth > 0 && depth < w.options.MinDepth {
+		return nil
+	}
+	if w.options.MaxDepth > 0 && depth > w.options.MaxDepth {
+		return nil
+	}
+	if w.ignores != nil && path != w.root && w.ignores.ignored(path, info.IsDir()) {
+		return nil
+	}
+	matched, err := w.matcher.matchFile(w.ctx, path, info)
+	if err != nil {
+		if w.ctx.Err() != nil {
+			return w.ctx.Err()
+		}
+		w.warn(path, err)
+		return nil
+	}
+	if !matched {
+		return nil
+	}
+
+	result := FindResult{Path: path, FileInfo: info, Depth: depth}
+	if w.options.Action != nil {
+		if err := w.options.Action(result); err != nil && err != filepath.SkipDir {
+			return err
+		}
+	}
+	if err := w.fn(result); err != nil {
+		return err
+	}
+	if w.count++; w.options.Limit > 0 && w.count >= w.options.Limit {
+		return errWatchLimit
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package fsutils
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+	"toolbox/pkg/i18n"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// zip加密相关的常量，见 APPNOTE.TXT 和 WinZip 的 AES 加密规范
+const (
+	zipFlagEncrypted      = 0x1
+	zipFlagDataDescriptor = 0x8
+	zipMethodAES          = 99
+	zipExtraAES           = 0x9901
+	zipCryptoHeaderLen    = 12
+	zipAESVerifierLen     = 2
+	zipAESAuthCodeLen     = 10
+	zipAESIterations      = 1000
+)
+
+// openZipEntry 打开zip中的文件，加密的文件用password解密，支持WinZip AES和传统的ZipCrypto加密
+func openZipEntry(file *zip.File, password string) (io.ReadCloser, error) {
+	if file.Flags&zipFlagEncrypted == 0 {
+		return file.Open()
+	}
+	if password == "" {
+		return nil, ErrPasswordRequired
+	}
+
+	raw, err := file.OpenRaw()
+	if err != nil {
+		return nil, err
+	}
+	if file.Method == zipMethodAES {
+		return openZipAES(file, raw, password)
+	}
+	return openZipCrypto(file, raw, password)
+}
+
+// openZipAES 解密WinZip AES加密的文件；数据格式为 盐 + 2字节密码校验值 + 密文 + 10字节HMAC-SHA1
+func openZipAES(file *zip.File, raw io.Reader, password string) (io.ReadCloser, error) {
+	version, keyLen, method, err := parseZipAESExtra(file.Extra)
+	if err != nil {
+		return nil, err
+	}
+	saltLen := keyLen / 2
+	dataLen := int64(file.CompressedSize64) - int64(saltLen+zipAESVerifierLen+zipAESAuthCodeLen)
+	if dataLen < 0 {
+		return nil, i18n.Errorf("AES加密数据已损坏")
+	}
+
+	header := make([]byte, saltLen+zipAESVerifierLen)
+	if _, err := io.ReadFull(raw, header); err != nil {
+		return nil, err
+	}
+	// 派生出加密密钥、认证密钥和密码校验值
+	keys := pbkdf2.Key([]byte(password), header[:saltLen], zipAESIterations, 2*keyLen+zipAESVerifierLen, sha1.New)
+	if !bytes.Equal(keys[2*keyLen:], header[saltLen:]) {
+		return nil, ErrWrongPassword
+	}
+	block, err := aes.NewCipher(keys[:keyLen])
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &zipAESReader{
+		r:     io.LimitReader(raw, dataLen),
+		block: block,
+		mac:   hmac.New(sha1.New, keys[keyLen:2*keyLen]),
+		pos:   aes.BlockSize,
+	}
+	verify := func() error {
+		// 解压缩可能没有读到密文末尾，剩余的数据也要计入HMAC
+		if _, err := io.Copy(io.Discard, ar); err != nil {
+			return err
+		}
+		authCode := make([]byte, zipAESAuthCodeLen)
+		if _, err := io.ReadFull(raw, authCode); err != nil {
+			return err
+		}
+		if !hmac.Equal(authCode, ar.mac.Sum(nil)[:zipAESAuthCodeLen]) {
+			return i18n.Errorf("文件已损坏（AES认证码不匹配）")
+		}
+		return nil
+	}
+	// AE-2 不保存CRC，完整性由HMAC保证
+	return newZipEntryReader(ar, method, file.CRC32, version == 1, verify)
+}
+
+// parseZipAESExtra 从扩展字段中读取AES版本（AE-1或AE-2）、密钥长度和实际的压缩方法
+func parseZipAESExtra(extra []byte) (version, keyLen int, method uint16, err error) {
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra)
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		extra = extra[4:]
+		if size > len(extra) {
+			break
+		}
+		if id == zipExtraAES && size >= 7 {
+			field := extra[:size]
+			version = int(binary.LittleEndian.Uint16(field))
+			switch field[4] {
+			case 1:
+				keyLen = 16
+			case 2:
+				keyLen = 24
+			case 3:
+				keyLen = 32
+			default:
+				return 0, 0, 0, i18n.Errorf("不支持的AES密钥长度: %d", field[4])
+			}
+			return version, keyLen, binary.LittleEndian.Uint16(field[5:]), nil
+		}
+		extra = extra[size:]
+	}
+	return 0, 0, 0, i18n.Errorf("缺少AES加密信息")
+}
+
+// zipAESReader 计算密文的HMAC并用AES-CTR解密，计数器为从1开始的小端序整数
+type zipAESReader struct {
+	r       io.Reader
+	block   cipher.Block
+	mac     hash.Hash
+	counter [aes.BlockSize]byte
+	stream  [aes.BlockSize]byte
+	pos     int
+}
+
+func (ar *zipAESReader) Read(b []byte) (int, error) {
+	n, err := ar.r.Read(b)
+	ar.mac.Write(b[:n])
+	for i := 0; i < n; i++ {
+		if ar.pos == aes.BlockSize {
+			for j := range ar.counter {
+				ar.counter[j]++
+				if ar.counter[j] != 0 {
+					break
+				}
+			}
+			ar.block.Encrypt(ar.stream[:], ar.counter[:])
+			ar.pos = 0
+		}
+		b[i] ^= ar.stream[ar.pos]
+		ar.pos++
+	}
+	return n, err
+}
+
+// openZipCrypto 解密传统ZipCrypto加密的文件；数据前有12字节的加密头，最后一个字节用于校验密码
+func openZipCrypto(file *zip.File, raw io.Reader, password string) (io.ReadCloser, error) {
+	dataLen := int64(file.CompressedSize64) - zipCryptoHeaderLen
+	if dataLen < 0 {
+		return nil, i18n.Errorf("加密数据已损坏")
+	}
+
+	zc := newZipCrypto([]byte(password))
+	header := make([]byte, zipCryptoHeaderLen)
+	if _, err := io.ReadFull(raw, header); err != nil {
+		return nil, err
+	}
+	zc.decrypt(header)
+	// 使用数据描述符时CRC写在数据之后，加密时改用修改时间的高字节校验
+	check := byte(file.CRC32 >> 24)
+	if file.Flags&zipFlagDataDescriptor != 0 {
+		check = byte(file.ModifiedTime >> 8)
+	}
+	if header[zipCryptoHeaderLen-1] != check {
+		return nil, ErrWrongPassword
+	}
+
+	cr := &zipCryptoReader{r: io.LimitReader(raw, dataLen), zc: zc}
+	return newZipEntryReader(cr, file.Method, file.CRC32, true, nil)
+}
+
+// zipCrypto 传统PKWARE加密的密钥状态
+type zipCrypto struct {
+	keys [3]uint32
+}
+
+func newZipCrypto(password []byte) *zipCrypto {
+	zc := &zipCrypto{keys: [3]uint32{0x12345678, 0x23456789, 0x34567890}}
+	for _, b := range password {
+		zc.update(b)
+	}
+	return zc
+}
+
+func (zc *zipCrypto) update(b byte) {
+	zc.keys[0] = crc32Update(zc.keys[0], b)
+	zc.keys[1] = (zc.keys[1]+zc.keys[0]&0xff)*134775813 + 1
+	zc.keys[2] = crc32Update(zc.keys[2], byte(zc.keys[1]>>24))
+}
+
+func (zc *zipCrypto) decrypt(b []byte) {
+	for i := range b {
+		t := uint16(zc.keys[2] | 2)
+		b[i] ^= byte((uint32(t) * uint32(t^1)) >> 8)
+		zc.update(b[i])
+	}
+}
+
+// crc32Update 用一个字节更新CRC-32，不做取反，与ZipCrypto的定义一致
+func crc32Update(crc uint32, b byte) uint32 {
+	return crc32.IEEETable[byte(crc)^b] ^ crc>>8
+}
+
+type zipCryptoReader struct {
+	r  io.Reader
+	zc *zipCrypto
+}
+
+func (cr *zipCryptoReader) Read(b []byte) (int, error) {
+	n, err := cr.r.Read(b)
+	cr.zc.decrypt(b[:n])
+	return n, err
+}
+
+// zipEntryReader 解压缩解密后的数据，读到末尾时校验CRC和（AES的）认证码
+type zipEntryReader struct {
+	r        io.Reader
+	closer   io.Closer
+	crc      hash.Hash32
+	want     uint32
+	checkCRC bool
+	verify   func() error
+	eof      bool
+}
+
+func newZipEntryReader(r io.Reader, method uint16, crc uint32, checkCRC bool, verify func() error) (io.ReadCloser, error) {
+	er := &zipEntryReader{crc: crc32.NewIEEE(), want: crc, checkCRC: checkCRC, verify: verify}
+	switch method {
+	case zip.Store:
+		er.r = r
+	case zip.Deflate:
+		fr := flate.NewReader(r)
+		er.r, er.closer = fr, fr
+	default:
+		return nil, i18n.Errorf("不支持的压缩方法: %d", method)
+	}
+	return er, nil
+}
+
+func (er *zipEntryReader) Read(b []byte) (int, error) {
+	n, err := er.r.Read(b)
+	er.crc.Write(b[:n])
+	if err == io.EOF && !er.eof {
+		er.eof = true
+		if er.checkCRC && er.crc.Sum32() != er.want {
+			return n, zip.ErrChecksum
+		}
+		if er.verify != nil {
+			if verr := er.verify(); verr != nil {
+				return n, verr
+			}
+		}
+	}
+	return n, err
+}
+
+func (er *zipEntryReader) Close() error {
+	if er.closer != nil {
+		return er.closer.Close()
+	}
+	return nil
+}
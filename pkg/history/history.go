@@ -0,0 +1,206 @@
+// Package history 记录执行过的工具箱命令，供查看和重新执行
+//
+// 历史记录以每行一个JSON对象的格式保存在配置目录下的 history.jsonl 中，
+// 超过 MaxEntries 条时只保留最近的记录。
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"toolbox/pkg/config"
+	"toolbox/pkg/errs"
+)
+
+// 环境变量
+const (
+	PathEnv    = "TOOLBOX_HISTORY"    // 指定历史文件路径
+	DisableEnv = "TOOLBOX_NO_HISTORY" // 非空时不记录历史
+)
+
+// MaxEntries 最多保留的历史记录数
+const MaxEntries = 1000
+
+// RedactedValue 记录时替换密码、密钥等敏感参数的值
+const RedactedValue = "******"
+
+// Entry 一条历史记录
+type Entry struct {
+	ID         int       `json:"id"`          // 序号，从1开始递增
+	Time       time.Time `json:"time"`        // 开始执行的时间
+	Command    string    `json:"command"`     // 命令路径，如 "network ping"
+	Args       []string  `json:"args"`        // 完整的命令行参数（不含程序名）
+	Dir        string    `json:"dir"`         // 执行时的工作目录
+	DurationMs int64     `json:"duration_ms"` // 耗时（毫秒）
+	ExitCode   int       `json:"exit_code"`   // 退出码
+}
+
+// Redacted 是否有参数的值在记录时被隐藏，这样的记录不能按原样重新执行
+func (e Entry) Redacted() bool {
+	for _, arg := range e.Args {
+		if strings.HasSuffix(arg, RedactedValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// CommandLine 返回用于显示的命令行参数
+func (e Entry) CommandLine() string {
+	parts := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'\\$") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
+// Enabled 是否记录历史
+func Enabled() bool {
+	return os.Getenv(DisableEnv) == ""
+}
+
+// Path 返回历史文件路径
+func Path() (string, error) {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path, nil
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// Load 读取所有历史记录，按执行顺序排列，历史文件不存在时返回空列表
+func Load() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "读取历史记录失败: %v", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		// 忽略损坏的行，例如写入中途被中断
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errs.Wrap(err, "读取历史记录失败: %v", err)
+	}
+	return entries, nil
+}
+
+// Find 按序号查找历史记录，id为负数时表示倒数第几条（-1为最近一条）
+func Find(id int) (*Entry, error) {
+	entries, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if id < 0 {
+		if -id > len(entries) {
+			return nil, errs.NotFound("没有第 %d 条最近的历史记录", -id)
+		}
+		return &entries[len(entries)+id], nil
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, errs.NotFound("历史记录 %d 不存在", id)
+}
+
+// Append 追加一条历史记录，自动分配序号
+func Append(entry Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errs.Wrap(err, "创建历史记录目录失败: %v", err)
+	}
+
+	entries, err := Load()
+	if err != nil {
+		return err
+	}
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[len(entries)-1].ID + 1
+	}
+
+	// 超过上限时重写文件，只保留最近的记录
+	if len(entries) >= MaxEntries {
+		entries = append(entries[len(entries)-MaxEntries+1:], entry)
+		return write(path, entries)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errs.Wrap(err, "写入历史记录失败: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return errs.Wrap(err, "写入历史记录失败: %v", err)
+	}
+	return nil
+}
+
+// Clear 清空历史记录
+func Clear() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errs.Wrap(err, "清空历史记录失败: %v", err)
+	}
+	return nil
+}
+
+// write 用指定的记录重写历史文件
+func write(path string, entries []Entry) error {
+	var b strings.Builder
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, []byte(b.String()), 0600); err != nil {
+		return errs.Wrap(err, "写入历史记录失败: %v", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		os.Remove(tempFile)
+		return errs.Wrap(err, "写入历史记录失败: %v", err)
+	}
+	return nil
+}
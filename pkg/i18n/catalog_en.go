@@ -0,0 +1,2137 @@
+package i18n
+
+func init() {
+	Register(LangEN, catalogEN)
+}
+
+// catalogEN 英文消息目录
+//
+// 命令的简短说明和标志说明以中文原文为键；详细说明（Long）较长且经常调整，
+// 以 "long:" 加命令路径（不含程序名）为键，例如 "long:network"。
+// 修改中文说明后键随之变化，需要同步修改这里的翻译，cmd/cli/cmd 中的 TestHelpTranslated 会检查遗漏。
+// 运行时输出的提示信息、表头和错误信息见 messages_en.go 中的 messagesEN。
+var catalogEN = map[string]string{
+	// 根命令与全局标志
+	"一个功能丰富的命令行工具箱":                "A feature-rich command line toolbox",
+	"输出格式 (table, json, yaml)":     "Output format (table, json, yaml)",
+	"输出调试日志":                       "Print debug logs",
+	"只输出错误日志":                      "Only print error logs",
+	"将日志写入指定文件而不是标准错误":             "Write logs to the given file instead of stderr",
+	"界面语言 (zh, en)，默认根据LANG环境变量选择": "Interface language (zh, en), detected from LANG by default",
+	"禁用彩色输出（也可设置NO_COLOR环境变量）":     "Disable colored output (or set the NO_COLOR environment variable)",
+	"只显示将要执行的操作，不做实际修改":            "Show the planned actions without changing anything",
+	"通过SSH在远程主机上执行（user@host[:port] 或配置文件中的主机名，多个用逗号分隔）": "Run on remote hosts over SSH (user@host[:port] or a host name from the config file, comma separated)",
+	"将命令的输出同时复制到剪贴板":                                               "Also copy the command output to the clipboard",
+	"生成shell自动补全脚本":                                                "Generate shell completion scripts",
+	"交互式终端界面":                                                      "Interactive terminal UI",
+	"显示版本信息":                                                       "Show version information",
+	"按任务文件批量执行命令":                                                  "Run a batch of commands from a task file",
+	"步骤失败后继续执行后续步骤":                                                "Keep running the remaining steps after a step fails",
+	"设置变量，格式为 name=value，可多次指定":                                    "Set a variable as name=value, may be repeated",
+	"查看执行过的命令":                                                     "Show previously executed commands",
+	"显示最近的条数，0表示全部":                                                "Number of recent entries to show, 0 for all",
+	"只显示包含指定文本的命令":                                                 "Only show commands containing the given text",
+	"只显示执行失败的命令":                                                   "Only show commands that failed",
+	"清空历史记录":                                                       "Clear the history",
+	"重新执行历史记录中的命令":                                                 "Re-run a command from the history",
+	"在当前目录而不是原工作目录中执行":                                             "Run in the current directory instead of the original one",
+	"查看命令的耗时和资源占用统计":                                               "Show timing and resource usage statistics for commands",
+	"列出耗时最长的执行次数，0表示全部":                                            "Number of slowest runs to list, 0 for all",
+	"只统计最近一段时间，如 24h、7d，纯数字表示天":                                    "Only include recent runs, e.g. 24h or 7d (plain numbers are days)",
+	"只统计指定命令及其子命令，如 \"fs find\"":                                   "Only include the given command and its subcommands, e.g. \"fs find\"",
+	"清空指标记录":                                                       "Clear the recorded metrics",
+	"周期性执行子命令并高亮变化":                                                "Run a subcommand periodically and highlight changes",
+	"两次执行之间的间隔，如 500ms、1m（纯数字表示秒）":                                 "Interval between runs, e.g. 500ms or 1m (plain numbers are seconds)",
+	"最多执行的次数，0表示不限制":                                               "Maximum number of runs, 0 for unlimited",
+	"子命令以指定的退出码退出时停止":                                              "Stop when the subcommand exits with the given code",
+	"输出匹配正则表达式时停止":                                                 "Stop when the output matches the regular expression",
+	"不高亮变化的内容":                                                     "Do not highlight changes",
+	"不清屏，依次追加每次的输出":                                                "Do not clear the screen, append each run's output",
+	"对多个目标并发执行同一条命令":                                               "Run the same command against many targets in parallel",
+	"目标列表文件，每行一个目标，- 表示标准输入":                                       "File listing one target per line, - for stdin",
+	"直接指定目标，多个用逗号分隔":                                               "Targets given directly, comma separated",
+	"最大并发数":                                                        "Maximum number of concurrent runs",
+	"每个目标的超时时间，如 30s，0表示不限制":                                       "Timeout per target, e.g. 30s, 0 for none",
+	"不输出每个目标的内容，只显示状态":                                             "Do not print each target's output, only its status",
+	"复制到剪贴板或从剪贴板粘贴":                                                "Copy to or paste from the clipboard",
+	"将剪贴板内容输出到标准输出":                                                "Print the clipboard content to stdout",
+	"编码和解码base64、hex、URL等格式":                                       "Encode and decode base64, hex, URL and more",
+	"将文本或文件编码为指定格式":                                                "Encode text or files in the given format",
+	"解码文本或文件，可自动识别格式":                                              "Decode text or files, detecting the format automatically",
+	"编码格式: base64、base64url、hex、url、html、qp":                       "Encoding: base64, base64url, hex, url, html, qp",
+	"编码格式: auto（自动识别）、base64、base64url、hex、url、html、qp":            "Encoding: auto (detect), base64, base64url, hex, url, html, qp",
+	"直接指定要处理的文本，而不是读取文件或标准输入":                                      "Text to process instead of reading files or stdin",
+	"计算和校验摘要":                                                      "Compute and verify digests",
+	"计算或校验HMAC":                                                    "Compute or verify an HMAC",
+	"摘要算法: md5、sha1、sha224、sha256、sha384、sha512、sha3-256、sha3-512": "Digest algorithm: md5, sha1, sha224, sha256, sha384, sha512, sha3-256, sha3-512",
+	"密钥，支持 @文件、env:变量名、hex:、base64: 前缀":                            "Key; accepts @file, env:NAME, hex: and base64: prefixes",
+	"与给定的签名（十六进制或base64）比较":                                        "Compare with the given signature (hex or base64)",
+	"以base64而不是十六进制输出":                                             "Print base64 instead of hex",
+	"生成和解析UUID、ULID、Snowflake ID":                                  "Generate and inspect UUID, ULID and Snowflake IDs",
+	"生成UUID":         "Generate UUIDs",
+	"生成ULID":         "Generate ULIDs",
+	"生成Snowflake ID": "Generate Snowflake IDs",
+	"解析ID的类型、版本和时间戳": "Show the type, version and timestamp of IDs",
+	"生成的数量":          "Number of IDs to generate",
+	"UUID版本: 4（随机）或 7（时间有序）": "UUID version: 4 (random) or 7 (time ordered)",
+	"使用大写字母":                    "Use upper case",
+	"不带连字符":                     "Omit the hyphens",
+	"使用小写字母":                    "Use lower case",
+	"节点号，0-1023":                "Node number, 0-1023",
+	"起始时间（Unix毫秒）":              "Epoch in Unix milliseconds",
+	"Snowflake ID的起始时间（Unix毫秒）": "Epoch of Snowflake IDs in Unix milliseconds",
+	"时间戳、时区和时长转换":               "Convert timestamps, time zones and durations",
+	"以多种格式显示当前时间":               "Show the current time in several formats",
+	"转换时间戳或时间文本":                "Convert timestamps or time strings",
+	"解析时长":                      "Parse durations",
+	"输出时区，多个用逗号分隔，如 UTC,Asia/Shanghai,+05:30":    "Output time zones, comma-separated, e.g. UTC,Asia/Shanghai,+05:30",
+	"解释不含时区的时间时使用的时区":                            "Time zone for inputs without an offset",
+	"时间戳单位: auto、s、ms、us、ns":                     "Timestamp unit: auto, s, ms, us, ns",
+	"纯数字时长的单位: ms、s、m、h、d、w":                     "Unit for bare numbers: ms, s, m, h, d, w",
+	"按schema生成测试数据":                              "Generate test data from a schema",
+	"列出支持的字段类型":                                  "List supported field types",
+	"schema文件路径，- 表示从标准输入读取":                     "Schema file path; - reads from stdin",
+	"生成的记录数":                                     "Number of records to generate",
+	"输出格式: jsonl、json、csv":                       "Output format: jsonl, json, csv",
+	"随机数种子，指定后生成的数据可以复现":                         "Random seed; makes the output reproducible",
+	"美化JSON输出":                                   "Pretty-print JSON output",
+	"使用口令加密和解密文件":                                "Encrypt and decrypt files with a passphrase",
+	"加密文件":                                       "Encrypt files",
+	"解密文件":                                       "Decrypt files",
+	"口令，支持 @文件路径、env:变量名 或原文":                    "Passphrase: @file, env:NAME or literal text",
+	"输出文件，- 表示标准输出（仅单个输入时可用）":                    "Output file, - for stdout (single input only)",
+	"成功后删除源文件":                                   "Remove the source file on success",
+	"覆盖已存在的输出文件":                                 "Overwrite existing output files",
+	"密钥派生算法: scrypt 或 argon2id":                  "Key derivation function: scrypt or argon2id",
+	"测试和解释正则表达式":                                 "Test and explain regular expressions",
+	"显示匹配的位置和捕获组":                                "Show match positions and capture groups",
+	"将正则表达式拆分为带说明的组成部分":                          "Break a regular expression into annotated parts",
+	"^ 和 $ 匹配每行的开头和结尾":                           "Make ^ and $ match at line boundaries",
+	". 匹配换行符":                                    "Let . match newlines",
+	"从文件读取文本，- 表示标准输入":                           "Read text from a file; - reads from stdin",
+	"最多显示的匹配数，0 表示不限制":                           "Maximum matches to show, 0 for no limit",
+	"换算单位、进制和位标志":                                "Convert units, number bases and bit flags",
+	"在十进制、十六进制、八进制和二进制之间转换":                      "Convert between decimal, hex, octal and binary",
+	"分解或组合位标志":                                   "Decompose or compose bit flags",
+	"列出支持的单位":                                    "List supported units",
+	"输入的进制（2-36），0 表示按前缀识别":                      "Base of the input (2-36), 0 to detect from the prefix",
+	"标志类型: mode、tcp、bits":                        "Flag type: mode, tcp, bits",
+	"列出该类型的全部标志位，而不只是已设置的":                       "List all flags of the type, not only the ones that are set",
+	"显示操作系统、CPU、内存、磁盘和网络接口信息":                    "Show OS, CPU, memory, disk and network interface information",
+	"包括 tmpfs、overlay 等虚拟文件系统":                   "Include virtual file systems such as tmpfs and overlay",
+	"采样CPU使用率的时长，如 1s、500ms，0 表示不采样":             "How long to sample CPU usage, e.g. 1s, 500ms, 0 to skip",
+	"检查磁盘健康状态":                                   "Check disk health",
+	"显示磁盘的SMART健康信息":                             "Show SMART health information of disks",
+	"传给 smartctl -d 的设备类型，如 sat、nvme、megaraid,0": "Device type passed to smartctl -d, e.g. sat, nvme, megaraid,0",
+	"温度达到该值（摄氏度）时给出警告，0 表示不检查":                   "Warn when the temperature (Celsius) reaches this value, 0 to disable",
+	"显示每块磁盘的SMART属性":                             "Show the SMART attributes of each disk",
+	"查看和控制系统服务":                                  "Inspect and control system services",
+	"显示服务状态":                                     "Show service status",
+	"启动服务":                                       "Start a service",
+	"停止服务":                                       "Stop a service",
+	"重启服务":                                       "Restart a service",
+	"查看服务日志":                                     "Show service logs",
+	"显示最后几行，0 表示全部":                              "Number of last lines to show, 0 for all",
+	"持续输出新的日志":                                   "Keep printing new log entries",
+	"只显示最近一段时间的日志，如 30m、2h，纯数字表示分钟":              "Only show logs from a recent period, e.g. 30m, 2h, plain numbers are minutes",
+	"解析cron表达式":                                  "Parse cron expressions",
+	"说明cron表达式并列出接下来的执行时间":                       "Describe a cron expression and list its next run times",
+	"列出的执行次数":                                    "Number of run times to list",
+	"计算执行时间使用的时区，如 UTC、Asia/Shanghai、+08:00":     "Time zone for the run times, e.g. UTC, Asia/Shanghai, +08:00",
+	"从该时间之后开始计算，默认为当前时间":                         "Compute run times after this time, defaults to now",
+	"查看和跟踪日志文件":                                  "View and follow log files",
+	"同时跟踪多个日志文件，按正则表达式过滤和高亮":                     "Follow multiple log files with regex filtering and highlighting",
+	"开始时每个文件显示的最后几行（过滤后），-1 表示全部":                "Number of last lines (after filtering) to show from each file at start, -1 for all",
+	"持续输出新写入的行":                                  "Keep printing newly written lines",
+	"只显示匹配该正则表达式的行，可重复指定，满足任意一个即可":               "Only show lines matching this regex; repeatable, any match is enough",
+	"不显示匹配该正则表达式的行，可重复指定":                        "Hide lines matching this regex; repeatable",
+	"高亮匹配该正则表达式的部分，可重复指定":                        "Highlight parts matching this regex; repeatable",
+	"正则表达式忽略大小写":                                 "Ignore case in regular expressions",
+	"跳过时间早于该时长之前的行，如 10m、2h，纯数字表示分钟":             "Skip lines timestamped earlier than this long ago, e.g. 10m, 2h; plain numbers are minutes",
+	"解释日志中不含时区的时间使用的时区":                          "Time zone for log timestamps without a zone",
+	"通过HTTP提供目录中的静态文件":                           "Serve static files from a directory over HTTP",
+	"监听地址，如 :8000、127.0.0.1:9000，端口为0时随机选择":      "Listen address, e.g. :8000, 127.0.0.1:9000; port 0 picks a random port",
+	"启用Basic认证，格式为 用户名:密码":                       "Enable basic auth, in the form user:password",
+	"允许上传文件":                                     "Allow file uploads",
+	"单次上传的最大大小，如 500MB，纯数字表示MB，0 表示不限制":          "Maximum size of one upload, e.g. 500MB; plain numbers are MB, 0 for no limit",
+	"提供以 . 开头的文件和目录":                             "Serve files and directories whose names start with .",
+	"使用临时生成的自签名证书启用HTTPS":                        "Enable HTTPS with a temporary self-signed certificate",
+	"HTTPS使用的证书文件（PEM）":                          "Certificate file for HTTPS (PEM)",
+	"HTTPS使用的私钥文件（PEM）":                          "Private key file for HTTPS (PEM)",
+	"Markdown文档工具":                               "Markdown document tools",
+	"将Markdown渲染为终端文本或HTML":                      "Render Markdown as terminal text or HTML",
+	"输出格式: terminal、html":                        "Output format: terminal, html",
+	"终端文本自动换行的宽度，0 表示使用终端宽度":                     "Wrap width for terminal text, 0 to use the terminal width",
+	"HTML页面标题，默认为第一个标题的文字":                       "HTML page title, defaults to the text of the first heading",
+	"只输出HTML正文片段，不包含页面框架和样式":                     "Only output the HTML body fragment, without page skeleton and styles",
+	"保留文档中的原始HTML":                               "Keep raw HTML from the document",
+	"查看、检查和比较环境变量":                               "Inspect, check and compare environment variables",
+	"列出环境变量，可按名称过滤":                              "List environment variables, optionally filtered by name",
+	"检查PATH等路径列表中重复、不存在的目录和被覆盖的命令":               "Check PATH-like lists for duplicate or missing directories and shadowed commands",
+	"比较当前环境与 .env 文件":                            "Compare the current environment with a .env file",
+	"以 .env 或JSON格式导出环境变量":                       "Export environment variables as .env or JSON",
+	"只输出变量名":                                     "Only print variable names",
+	"取值中包含模式的变量也列出":                              "Also list variables whose value contains the pattern",
+	"显示敏感变量的原值":                                  "Show the real values of sensitive variables",
+	"检查所有名称以 PATH 或 _DIRS 结尾且取值为目录列表的变量":         "Check every variable whose name ends with PATH or _DIRS and whose value is a directory list",
+	"同时列出取值相同的变量":                                "Also list variables with identical values",
+	"同时列出只存在于当前环境中的变量":                           "Also list variables that only exist in the current environment",
+	"导出格式（dotenv, json）":                         "Export format (dotenv, json)",
+	"导出敏感变量的原值":                                  "Export the real values of sensitive variables",
+	"查看Docker容器及其在宿主机上的进程":                       "Inspect Docker containers and their processes on the host",
+	"列出容器及主进程PID，可以查找进程所在的容器":                    "List containers with their main process PID, or find the container of a process",
+	"显示容器的CPU、内存、网络和磁盘IO使用情况":                    "Show CPU, memory, network and disk IO usage of containers",
+	"输出容器日志":                                     "Print container logs",
+	"Docker守护进程地址，如 unix:///var/run/docker.sock、tcp://10.0.0.5:2375，默认使用 DOCKER_HOST": "Docker daemon address, e.g. unix:///var/run/docker.sock, tcp://10.0.0.5:2375; defaults to DOCKER_HOST",
+	"同时列出已停止的容器":                             "Also list stopped containers",
+	"按名称、镜像或ID过滤":                            "Filter by name, image or ID",
+	"列出每个容器内的进程":                             "List the processes inside each container",
+	"查找宿主机上该PID的进程所在的容器":                     "Find the container that the host process with this PID belongs to",
+	"排序方式（name, cpu, memory）":                "Sort by (name, cpu, memory)",
+	"只输出最后几行，-1 表示全部":                        "Only print the last N lines, -1 for all",
+	"每行前显示时间戳":                               "Show a timestamp before each line",
+	"只输出该时长之内的日志，如 10m、2h，纯数字表示分钟":           "Only print logs from this long ago, e.g. 10m, 2h; plain numbers are minutes",
+	"生成TOTP两步验证码":                            "Generate TOTP two-factor authentication codes",
+	"base32编码的密钥，支持 @文件路径 和 env:变量名":         "Base32-encoded secret; supports @file and env:NAME",
+	"otpauth://totp/ URI，支持 @文件路径 和 env:变量名": "otpauth://totp/ URI; supports @file and env:NAME",
+	"发行方，显示在验证器应用中":                          "Issuer shown in authenticator apps",
+	"账号名称，生成二维码时必需":                          "Account name, required for QR codes",
+	"哈希算法（SHA1、SHA256、SHA512）":               "Hash algorithm (SHA1, SHA256, SHA512)",
+	"验证码位数（6-8）":                             "Number of code digits (6-8)",
+	"时间步长（秒）":                                "Time step in seconds",
+	"在终端中显示可供验证器应用扫描的二维码":                    "Show a QR code in the terminal for authenticator apps to scan",
+	"将二维码保存为PNG图片":                           "Save the QR code as a PNG image",
+	"tar.gz和gz并行压缩的线程数，-1 表示使用全部CPU核心，默认不并行": "Number of threads for parallel tar.gz and gz compression, -1 for all CPU cores; not parallel by default",
+	"压缩时加密zip（AES-256），解压缩时解密zip、rar、7z的密码，支持 @文件路径、env:变量名 或原文": "Password to encrypt zip archives (AES-256) when compressing, or to decrypt zip, rar and 7z archives when decompressing; supports @file, env:NAME or plain text",
+	"查看和转换压缩文件":                                          "Inspect and convert archives",
+	"列出压缩文件中的文件和目录":                                      "List files and directories in an archive",
+	"只压缩或解压匹配的文件（支持通配符和 **），可以多次指定":                      "Only compress or extract matching files (wildcards and ** supported); can be repeated",
+	"压缩时跳过匹配的文件和目录（支持通配符和 **），可以多次指定":                    "Skip matching files and directories when compressing (wildcards and ** supported); can be repeated",
+	"压缩时只包含不小于该大小的文件，如 1K、10M":                           "Only include files at least this large when compressing, e.g. 1K, 10M",
+	"压缩时只包含不大于该大小的文件，如 100M、1G":                          "Only include files at most this large when compressing, e.g. 100M, 1G",
+	"压缩时只包含最近修改的文件，如 24h、7d，纯数字表示天":                      "Only include files modified within this period when compressing, e.g. 24h, 7d; plain numbers are days",
+	"压缩时只包含修改时间早于该时长之前的文件，如 30d，纯数字表示天":                  "Only include files last modified longer ago than this when compressing, e.g. 30d; plain numbers are days",
+	"将zip分卷，每个分卷的大小，如 100M、4G（不小于64K）":                   "Split the zip into volumes of this size, e.g. 100M, 4G (at least 64K)",
+	"压缩时生成SHA-256校验和清单，解压缩和校验时按清单检查文件内容":                 "Write a SHA-256 checksum manifest when compressing; check file contents against it when decompressing or verifying",
+	"压缩tar时内容相同的文件只保存一次，其余写为硬链接":                         "Store files with identical content only once when creating a tar, writing the rest as hard links",
+	"tar.gz和gz并行压缩的线程数或zip并行解压的文件数，-1 表示使用全部CPU核心，默认不并行": "Number of threads for parallel tar.gz and gz compression, or files extracted in parallel from zip; -1 for all CPU cores; not parallel by default",
+	"解压缩时恢复权限、修改时间和（以root运行时）所有者":                        "Restore permissions, modification times and (when running as root) ownership when decompressing",
+	"将压缩文件转换为另一种格式":                                      "Convert an archive to another format",
+	"目标压缩格式，不指定时根据目标文件的扩展名判断":                            "Target archive format, detected from the target file extension when omitted",
+	"源压缩文件（zip、rar、7z）的密码，支持 @文件路径、env:变量名 或原文":          "Password of the source archive (zip, rar, 7z); accepts @file, env:VAR or the literal value",
+	"只转换匹配的文件（支持通配符和 **），可以多次指定":                         "Only convert matching files (wildcards and ** supported), may be repeated",
+	"去掉末尾的换行符": "Strip trailing newlines",
+
+	// 全局消息
+	"不支持的输出格式: %s（可选: table, json, yaml）": "unsupported output format: %s (choose from: table, json, yaml)",
+	"--verbose 和 --quiet 不能同时使用":          "--verbose and --quiet cannot be used together",
+	"打开日志文件失败: %v":                        "failed to open log file: %v",
+	"不支持的语言: %s（可选: zh, en）":              "unsupported language: %s (choose from: zh, en)",
+	"编码JSON失败: %v":                        "failed to encode JSON: %v",
+	"编码YAML失败: %v":                        "failed to encode YAML: %v",
+	"错误: %v":                              "Error: %v",
+	"运行 '%s -h' 查看帮助":                     "Run '%s -h' for help",
+
+	// fmt
+	"格式化数据文件或文本内容":                   "Format data files or text content",
+	"彩色输出":                           "Colorize output",
+	"压缩输出（仅JSON/XML）":                "Compact output (JSON/XML only)",
+	"指定包围内容的分隔符，如 # 或 --- 等":         "Delimiter surrounding the content, such as # or ---",
+	"指定格式 (json, xml, yaml)":         "Data format (json, xml, yaml)",
+	"缩进空格数 (默认: json/xml=4, yaml=2)": "Indent width in spaces (default: json/xml=4, yaml=2)",
+	"输出到文件而非标准输出":                    "Write to a file instead of stdout",
+	"美化输出":                           "Pretty-print output",
+	"将参数作为字符串内容而非文件路径":               "Treat the argument as content instead of a file path",
+
+	// fs
+	"文件系统工具集":   "File system tools",
+	"压缩或解压缩文件":  "Compress or decompress files",
+	"压缩级别（1-9）": "Compression level (1-9)",
+	"操作模式（compress、decompress 或 verify）(解压缩额外支持rar、7z)": "Operation mode (compress, decompress or verify); decompression also supports rar and 7z",
+	"压缩格式（可选值：zip, tar.gz, tar.bz2, tar.xz, tar.zst, tar.lz4, tar.br, gz, bz2, xz, zst, lz4, br）\n压缩时如果不指定，将根据目标文件扩展名自动检测，写入标准输出时必须指定；解压缩时根据文件内容自动识别": "Archive format (zip, tar.gz, tar.bz2, tar.xz, tar.zst, tar.lz4, tar.br, gz, bz2, xz, zst, lz4, br)\nwhen compressing, detected from the target file extension if omitted and required when writing to stdout; detected from the content when decompressing",
+	"搜索文件和目录":                                         "Search for files and directories",
+	"查找内容相同的重复文件":                                     "Find duplicate files with identical content",
+	"只比较文件名匹配的文件（支持通配符）":                              "Only compare files whose name matches (wildcards supported)",
+	"只比较不小于该大小的文件，如 1M":                               "Only compare files at least this size, e.g. 1M",
+	"将重复的文件替换为指向第一个文件的硬链接":                            "Replace duplicates with hard links to the first file",
+	"删除每组中除第一个以外的重复文件":                                "Delete all duplicates except the first in each set",
+	"排除的目录（可多次使用）":                                    "Directories to exclude (repeatable)",
+	"跟随符号链接":                                          "Follow symbolic links",
+	"直接切分单个文件，不打包也不压缩":                                "Split a single file as-is without archiving or compressing",
+	"持续监视并输出新建或修改的匹配文件":                               "Keep watching and print newly created or modified matching files",
+	"排序方式 (name, path, size, mtime)":                  "Sort by (name, path, size, mtime)",
+	"按降序排序":                                           "Sort in descending order",
+	"最多输出的结果数量，0表示全部":                                 "Maximum number of results, 0 for all",
+	"按 Go 模板输出每个结果，如 \"{{.Path}}\\t{{.Size}}\"":       "Print each result with a Go template, e.g. \"{{.Path}}\\t{{.Size}}\"",
+	"以NUL而不是换行分隔每个结果（配合 xargs -0）":                    "Separate results with NUL instead of newline (for xargs -0)",
+	"每行输出一个JSON对象（路径、大小、修改时间、权限等）":                    "Print one JSON object per line (path, size, mtime, mode, ...)",
+	"以带表头的CSV格式输出路径、大小、修改时间、权限等":                      "Print path, size, mtime, mode, ... as CSV with a header",
+	"跳过 .gitignore 和 .ignore 中忽略的文件":                  "Skip files ignored by .gitignore and .ignore",
+	"额外读取的忽略文件名，语法与 .gitignore 相同（可多次使用）":             "Additional ignore file names in .gitignore syntax (repeatable)",
+	"只搜索空文件和空目录":                                      "Only match empty files and empty directories",
+	"只保留内容包含该字符串的文件":                                  "Only keep files whose content contains this string",
+	"只保留内容匹配该正则表达式的文件":                                "Only keep files whose content matches this regular expression",
+	"删除匹配的文件和空目录（可以先用 --dry-run 预览）":                  "Delete matching files and empty directories (preview with --dry-run first)",
+	"配合 --delete 使用，不要求确认直接删除":                        "With --delete, delete without asking for confirmation",
+	"对每个匹配的文件执行命令，{} 替换为文件路径（如 \"chmod 644 {}\"）":     "Run a command for each match, {} is replaced by the file path (e.g. \"chmod 644 {}\")",
+	"只在指定目录中搜索（可多次使用）":                                "Only search in these directories (repeatable)",
+	"最大搜索深度":                                          "Maximum search depth",
+	"最大文件大小 (例如: 10M, 1G)":                            "Maximum file size (e.g. 10M, 1G)",
+	"最小搜索深度":                                          "Minimum search depth",
+	"最小文件大小 (例如: 1M, 500K)":                           "Minimum file size (e.g. 1M, 500K)",
+	"按修改时间搜索（如 7、2h，纯数字表示天数，负数表示之内，正数表示之前）":           "Filter by modification time, e.g. 7 or 2h; plain numbers are days (negative: within, positive: before)",
+	"按文件名搜索（支持通配符）":                                   "Match file names (wildcards supported)",
+	"使用正则表达式匹配文件名":                                    "Match file names with a regular expression",
+	"按类型搜索 (f:文件, d:目录, l:符号链接)":                      "Filter by type (f: file, d: directory, l: symlink)",
+	"将目录打包并分片":                                        "Archive a directory and split it into chunks",
+	"压缩格式（zip, tar.gz/tgz, tar.bz2/tbz2, tar.xz/txz）": "Archive format (zip, tar.gz/tgz, tar.bz2/tbz2, tar.xz/txz)",
+	"合并模式（将指定目录中的分片合并）":                               "Merge mode (join the chunks in the given directory)",
+	"输出目录（默认为源目录名_chunks）":                            "Output directory (default: <source>_chunks)",
+	"完成后删除源目录":                                        "Remove the source directory when done",
+	"分片大小（例如：100M, 1.5G，纯数字表示MB）":                     "Chunk size (e.g. 100M, 1.5G; plain numbers are MB)",
+	"tar.gz并行压缩的线程数（默认为CPU核心数）":                       "Number of threads for parallel tar.gz compression (default: number of CPU cores)",
+	"显示目录结构":                                          "Show directory structure",
+	"显示所有文件，包括隐藏文件":                                   "Show all files, including hidden ones",
+	"最大显示深度 (0表示无限制)":                                 "Maximum depth (0 for unlimited)",
+	"只显示目录":                                           "Only show directories",
+	"显示文件大小":                                          "Show file sizes",
+	"生成和校验目录的校验和清单":                                   "Create and verify checksum manifests for a directory",
+	"为目录中的所有文件生成校验和清单":                                "Create a checksum manifest for all files in a directory",
+	"按校验和清单检查目录中的文件":                                  "Verify files in a directory against a checksum manifest",
+	"清单文件的路径（默认为目录中的 SHA256SUMS）":                     "Path of the manifest file (default: SHA256SUMS in the directory)",
+	"同时计算校验和的文件数量（默认为CPU核心数）":                         "Number of files to hash concurrently (default: number of CPU cores)",
+	"目录中有清单中没有的文件时也视为失败":                              "Also fail when the directory has files not in the manifest",
+	"按正则表达式批量重命名文件":                                   "Batch rename files with a regular expression",
+	"匹配文件名时忽略大小写":                                     "Ignore case when matching file names",
+	"同时重命名目录":                                         "Rename directories as well",
+	"转换新文件名的大小写：upper、lower、title":                    "Change the case of new names: upper, lower, title",
+	"序号 {n} 的起始值":                                     "Starting value of the {n} counter",
+	"分析目录的磁盘占用":                                       "Analyze disk usage of a directory",
+	"列出最大的目录和文件各多少个":                                  "Number of largest directories and files to list",
+	"只列出不超过该深度的目录，0表示不限制":                             "Only list directories up to this depth, 0 for unlimited",
+	"按文件大小统计，而不是实际占用的磁盘空间":                            "Count file sizes instead of allocated disk space",
+	"同时读取的目录数量（默认为CPU核心数的2倍）":                         "Number of directories read concurrently (default: twice the number of CPU cores)",
+	"将目录单向同步到另一个目录":                                   "Synchronize a directory one way into another",
+	"大小相同的文件按SHA-256比较内容，而不是修改时间":                     "Compare files of equal size by SHA-256 instead of modification time",
+	"删除目标目录中源目录没有的文件和目录":                              "Delete files and directories in the destination that are not in the source",
+	"排除匹配的文件和目录（支持通配符，可多次使用）":                         "Exclude matching files and directories (wildcards supported, repeatable)",
+	"以Markdown嵌套列表输出目录树":                              "Print the tree as a nested Markdown list",
+	"输出可折叠目录的HTML页面":                                  "Print an HTML page with collapsible directories",
+	"显示每个目录中所有文件的累计大小":                                "Show the cumulative size of each directory",
+	"按大小从大到小排序（包含 --du）":                              "Sort by size, largest first (implies --du)",
+	"以嵌套的JSON结构输出目录树":                                 "Print the tree as nested JSON",
+
+	// network
+	"网络诊断工具集":    "Network diagnostic tools",
+	"证书工具":       "Certificate tools",
+	"本地CA管理":     "Manage a local CA",
+	"CA目录":       "CA directory",
+	"生成证书吊销列表":   "Generate a certificate revocation list",
+	"CRL有效期（天）":  "CRL validity in days",
+	"额外输出CRL的文件": "Additional file to write the CRL to",
+	"初始化CA":      "Initialize a CA",
+	"国家代码":       "Country code",
+	"根CA有效期（天）":  "Root CA validity in days",
+	"同时创建中间CA":   "Also create an intermediate CA",
+	"中间CA有效期（天）": "Intermediate CA validity in days",
+	"密钥类型 (rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519)": "Key type (rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519)",
+	"根CA的通用名称": "Common name of the root CA",
+	"组织名称":     "Organization name",
+	"由CA签发证书":  "Issue a certificate from the CA",
+	"证书有效期（天）": "Certificate validity in days",
+	"额外的DNS名称": "Additional DNS names",
+	"IP地址":     "IP addresses",
+	"私钥输出文件（默认为 <名称>.key）":             "Private key output file (default: <name>.key)",
+	"证书输出文件（默认为 <名称>.crt）":             "Certificate output file (default: <name>.crt)",
+	"证书用途 (server, client, both)":      "Certificate usage (server, client, both)",
+	"列出CA签发的证书":                        "List certificates issued by the CA",
+	"吊销证书":                             "Revoke a certificate",
+	"吊销原因":                             "Revocation reason",
+	"由CA签发证书签名请求":                      "Sign a certificate signing request with the CA",
+	"证书输出文件（默认为CSR文件名加 .crt）":          "Certificate output file (default: CSR file name with .crt)",
+	"检查证书文件或远程主机证书":                    "Check a certificate file or a remote host's certificate",
+	"TLS握手时声明的ALPN协议（例如: h2,http/1.1）": "ALPN protocols to offer in the TLS handshake (e.g. h2,http/1.1)",
+	"额外信任的根证书文件（PEM）":                  "Additional trusted root certificate files (PEM)",
+	"额外的中间证书文件（PEM）":                   "Additional intermediate certificate files (PEM)",
+	"仅显示证书问题":                          "Only show certificate issues",
+	"将参数视为远程主机地址":                      "Treat the argument as a remote host address",
+	"TLS握手使用的服务器名称（默认为主机名）":            "Server name for the TLS handshake (default: host name)",
+	"只信任 --ca-bundle 指定的根证书，不使用系统根证书":  "Only trust roots from --ca-bundle, ignoring the system roots",
+	"连接远程主机的超时时间":                      "Timeout for connecting to the remote host",
+	"转换证书和私钥格式":                        "Convert certificate and key formats",
+	"只显示文件内容，不进行转换":                    "Only show the file contents without converting",
+	"输出文件（默认替换输入文件的扩展名）":               "Output file (default: input file with the new extension)",
+	"目标格式 (pem, der)":                  "Target format (pem, der)",
+	"生成证书签名请求（CSR）":                    "Generate a certificate signing request (CSR)",
+	"私钥输出文件（默认为 <域名>.key）":             "Private key output file (default: <domain>.key)",
+	"城市": "Locality",
+	"CSR输出文件（默认为 <域名>.csr）": "CSR output file (default: <domain>.csr)",
+	"省份": "State or province",
+	"将PEM证书和私钥导出为PKCS#12（.pfx）":           "Export a PEM certificate and key as PKCS#12 (.pfx)",
+	"额外加入的CA证书文件":                         "Additional CA certificate files to include",
+	"PEM证书文件（可包含证书链）":                     "PEM certificate file (may contain the chain)",
+	"PEM私钥文件":                             "PEM private key file",
+	"使用旧版加密算法，兼容旧版Windows和Java":           "Use legacy encryption for older Windows and Java",
+	"输出文件（默认为证书文件名加 .pfx）":                "Output file (default: certificate file name with .pfx)",
+	"PKCS#12保护密码":                         "PKCS#12 password",
+	"生成自签名证书":                             "Generate a self-signed certificate",
+	"使用默认值（不进行交互）":                        "Use defaults without prompting",
+	"将PKCS#12（.pfx）拆分为PEM证书和私钥":           "Split a PKCS#12 (.pfx) file into a PEM certificate and key",
+	"证书输出文件（默认为 <文件名>.crt）":               "Certificate output file (default: <file>.crt)",
+	"私钥输出文件（默认为 <文件名>.key）":               "Private key output file (default: <file>.key)",
+	"不将CA证书写入证书文件":                        "Do not write CA certificates to the certificate file",
+	"使用CA签发证书签名请求":                        "Sign a certificate signing request with a CA",
+	"CA证书文件":                              "CA certificate file",
+	"CA私钥文件":                              "CA private key file",
+	"中间CA允许的下级CA层数，-1表示不限制":               "Path length for an intermediate CA, -1 for unlimited",
+	"证书用途 (server, client, both, ca)":     "Certificate usage (server, client, both, ca)",
+	"批量监控证书有效期":                           "Monitor certificate expiry in bulk",
+	"并发检查数量":                              "Number of concurrent checks",
+	"以JSON格式输出报告（等同于 --output json）":      "Print the report as JSON (same as --output json)",
+	"监控目标文件（YAML）":                        "Target list file (YAML)",
+	"告警阈值（天）":                             "Warning threshold in days",
+	"执行DNS查询":                             "Run DNS queries",
+	"指定DNS服务器 (例如: 8.8.8.8 或 8.8.8.8:53)": "DNS server to use (e.g. 8.8.8.8 or 8.8.8.8:53)",
+	"DNS记录类型 (ip, mx, ns, txt, all)":      "DNS record type (ip, mx, ns, txt, all)",
+	"TCP/UDP端口转发":                         "TCP/UDP port forwarding",
+	"连接目标的超时时间":                           "Timeout for connecting to the target",
+	"连接空闲超时时间 (例如: 30s, 5m)，0表示不超时（UDP会话为60秒）": "Idle connection timeout (e.g. 30s, 5m), 0 to disable (60s for UDP sessions)",
+	"本地监听地址 (例如: :8080 或 127.0.0.1:8080)":      "Local listen address (e.g. :8080 or 127.0.0.1:8080)",
+	"最大并发连接数，0表示不限制":                           "Maximum concurrent connections, 0 for unlimited",
+	"转发协议 (tcp, udp, both)":                    "Protocol to forward (tcp, udp, both)",
+	"转发目标地址 (例如: 10.0.0.5:80)":                 "Target address (e.g. 10.0.0.5:80)",
+	"检查HTTP/3与QUIC支持":                          "Check HTTP/3 and QUIC support",
+	"跳过证书校验":                                   "Skip certificate verification",
+	"每个检查阶段的超时时间":                              "Timeout for each check stage",
+	"获取IP地址信息":                                 "Look up IP address information",
+	"探测路径MTU":                                  "Discover the path MTU",
+	"探测的最大MTU":                                 "Largest MTU to probe",
+	"探测的最小MTU":                                 "Smallest MTU to probe",
+	"不显示每个探测包的结果":                              "Do not show each probe result",
+	"探测包超时后的重试次数":                              "Retries after a probe times out",
+	"每个探测包的超时时间":                               "Timeout for each probe",
+	"执行Ping测试":                                 "Run a ping test",
+	"要发送的Ping包数量":                              "Number of ping packets to send",
+	"Ping的间隔时间，如 500ms、2s（纯数字表示秒）":             "Interval between pings, e.g. 500ms or 2s (plain numbers are seconds)",
+	"执行端口扫描":                                   "Run a port scan",
+	"仅扫描常见端口":                                  "Only scan common ports",
+	"并发连接数":                                    "Number of concurrent connections",
+	"结束端口号":                                    "Last port to scan",
+	"一组非连续的端口，用逗号分隔":                           "Comma-separated list of ports",
+	"起始端口号":                                    "First port to scan",
+	"连接超时，如 500ms、2s（纯数字表示毫秒）":                 "Connection timeout, e.g. 500ms or 2s (plain numbers are milliseconds)",
+	"执行网络抓包":                                   "Capture network packets",
+	"要捕获的包数量，0表示无限制":                           "Number of packets to capture, 0 for unlimited",
+	"设置过滤规则，如 'tcp and port 80'":               "Capture filter, e.g. 'tcp and port 80'",
+	"列出可用的网络接口":                                "List available network interfaces",
+	"输出捕获结果到文本文件":                              "Write captured packets to a text file",
+	"显示的载荷长度，0表示不显示":                           "Payload bytes to show, 0 to hide",
+	"保存捕获结果为pcap文件":                            "Save captured packets to a pcap file",
+	"启用混杂模式":                                   "Enable promiscuous mode",
+	"捕获的数据包大小限制":                               "Snapshot length in bytes",
+	"显示统计信息":                                   "Show statistics",
+	"捕获超时时间，如 30s、5m（纯数字表示秒），0表示一直捕获直到中断": "Capture duration, e.g. 30s or 5m (plain numbers are seconds), 0 to run until interrupted",
+	"显示详细的包信息":                           "Show detailed packet information",
+	"执行网络速度测试":                           "Run a network speed test",
+	"服务器绑定的主机地址":                         "Host address for the server to bind",
+	"服务器监听的端口":                           "Port for the server to listen on",
+	"以服务器模式运行":                           "Run in server mode",
+	"用于测试的数据大小(MB)":                      "Test data size in MB",
+	"SSH密钥工具":                            "SSH key tools",
+	"计算SSH公钥指纹":                          "Compute SSH public key fingerprints",
+	"指纹算法 (sha256, md5, all)":            "Fingerprint hash (sha256, md5, all)",
+	"扫描时输出known_hosts格式的条目":              "Print known_hosts entries when scanning",
+	"扫描远程主机的公钥 (host[:port])":            "Scan the host keys of a remote host (host[:port])",
+	"生成SSH密钥对":                           "Generate an SSH key pair",
+	"密钥长度（RSA默认3072，ECDSA可选256、384、521）": "Key size (RSA default 3072; ECDSA 256, 384 or 521)",
+	"公钥注释（默认为 用户名@主机名）":                  "Public key comment (default: user@host)",
+	"私钥文件（默认为 ~/.ssh/id_<类型>）":           "Private key file (default: ~/.ssh/id_<type>)",
+	"覆盖已存在的文件":                           "Overwrite existing files",
+	"私钥保护密码":                             "Passphrase for the private key",
+	"密钥类型 (ed25519, rsa, ecdsa)":         "Key type (ed25519, rsa, ecdsa)",
+	"执行路由跟踪":                             "Trace the route to a host",
+	"Paris模式使用的流标识，0表示自动生成":              "Flow ID for Paris mode, 0 to generate one",
+	"Paris模式下探测的流数量，大于1时枚举备选路径":          "Number of flows in Paris mode; more than 1 enumerates alternative paths",
+	"最大跳数":                               "Maximum number of hops",
+	"数据包大小(字节)":                          "Packet size in bytes",
+	"使用Paris-traceroute模式（保持流标识一致）":      "Use Paris traceroute (keep the flow identifier constant)",
+	"超时时间":                               "Timeout",
+
+	// process
+	"进程管理工具":                  "Process management tools",
+	"列出指定进程的子进程":              "List the children of a process",
+	"显示完整命令行":                 "Show the full command line",
+	"显示进程详情":                  "Show process details",
+	"终止指定进程":                  "Kill a process",
+	"列出系统进程":                  "List system processes",
+	"按进程名称过滤":                 "Filter by process name",
+	"不显示没有名称的进程":              "Hide processes without a name",
+	"显示系统进程":                  "Show system processes",
+	"排序方式 (pid, cpu, memory)": "Sort by (pid, cpu, memory)",
+	"只显示前N个进程":                "Only show the first N processes",
+	"以树形结构显示进程":               "Show processes as a tree",
+	"显示详细信息，包括内存和CPU使用情况":     "Show details including memory and CPU usage",
+
+	// text
+	"文本处理工具":        "Text processing tools",
+	"过滤文本行":         "Filter lines of text",
+	"字段分隔符":         "Field separator",
+	"输出格式模式":        "Output format pattern",
+	"搜索文本内容":        "Search text",
+	"彩色输出匹配部分":      "Colorize matches",
+	"显示匹配行前后的上下文行数": "Lines of context around each match",
+	"只显示匹配的行数":      "Only print the number of matching lines",
+	"排除的目录名（可重复使用此选项指定多个目录）": "Directory names to exclude (repeatable)",
+	"文件名匹配模式（正则表达式）":         "File name pattern (regular expression)",
+	"忽略大小写":         "Ignore case",
+	"反向匹配（显示不匹配的行）": "Invert the match (show non-matching lines)",
+	"显示行号":          "Show line numbers",
+	"递归搜索目录":        "Search directories recursively",
+	"替换文本内容":        "Replace text",
+	"创建备份，指定备份后缀":   "Create a backup with the given suffix",
+	"全局替换（每行多次）":    "Replace all occurrences on each line",
+	"原地修改文件":        "Edit files in place",
+
+	// 详细说明
+	"long:": `Toolbox is a command line toolbox with many handy utilities. Use -h on any command for details.`,
+	"long:network": `Network diagnostic tools, including ping, port scanning, DNS lookups and more.
+
+Examples:
+  %[1]s network ping example.com
+  %[1]s network portscan example.com --start-port 80 --end-port 100
+  %[1]s network dns example.com --type mx
+  %[1]s network traceroute example.com
+  %[1]s network speedtest
+  %[1]s network ipinfo 8.8.8.8
+  %[1]s network sniff eth0 --filter "tcp and port 80"
+  %[1]s network forward --listen :8080 --target 10.0.0.5:80
+  %[1]s network http3 https://cloudflare.com
+  %[1]s network mtu example.com
+  %[1]s network ssh fingerprint --scan github.com`,
+	"long:process": `Process tools for listing, inspecting and killing system processes.
+
+Examples:
+  %[1]s process list                  # list all processes
+  %[1]s process list --filter chrome  # list processes whose name contains 'chrome'
+  %[1]s process info 1234             # show details of PID 1234
+  %[1]s process kill 1234             # kill PID 1234
+  %[1]s process children 1234         # list the children of PID 1234`,
+	"long:fs": `File system tools for working with files and directories.
+
+Examples:
+  %[1]s fs tree                       # show the current directory as a tree
+  %[1]s fs tree /path/to/dir -d 2 -a  # depth 2, including hidden files
+  %[1]s fs find . -n "*.go"           # find Go source files`,
+	"long:text": `Text processing tools similar to sed, grep and awk.
+
+Subcommands:
+  grep    - search text
+  replace - replace text
+  filter  - filter lines`,
+	"long:fmt": `Format data files or text, pretty-printing or compacting JSON, XML and YAML.
+
+Examples:
+  %[1]s fmt data.json --pretty --color  # pretty-print and colorize a JSON file
+  %[1]s fmt data.xml --pretty           # pretty-print an XML file
+  %[1]s fmt data.json --compact         # compact a JSON file
+  %[1]s fmt -s '<root><item>1</item></root>' --format xml --pretty`,
+	"long:version": `Show version, build and author information.`,
+	"long:tui": `Use the most common tools from an interactive terminal UI without remembering flags.
+
+Panels:
+1. Process monitor: live process list sorted by CPU, memory or PID
+2. Port scan: scan a host for open ports
+3. Ping/traceroute: live output of ping or traceroute
+4. File search: find files by name pattern
+
+Keys:
+  Up/Down or k/j   select a panel
+  Enter            open a panel or run the action
+  Esc              back to the main menu
+  q / Ctrl+C       quit
+
+Examples:
+  %[1]s tui`,
+	"long:run": `Run a sequence of toolbox commands and external commands from a YAML task file
+and print a summary at the end.
+
+Use it to capture recurring workflows such as compress, split, checksum and upload
+without writing shell scripts. By default the run stops at the first failed step and
+the remaining steps are marked as skipped; set continue_on_error in the task file or
+on a single step, or pass --continue-on-error, to keep going.
+If any step fails, the command exits with the exit code of the first failed step.
+
+Task file:
+  name: nightly backup
+  vars:
+    src: ./data
+    archive: backup.tar.gz
+  continue_on_error: false
+  steps:
+    - name: compress
+      run: fs compress ${src} ${archive}
+    - name: split
+      run: fs split ${src} --size 100M -o ${src}_chunks
+      timeout: 10m
+    - name: checksum
+      shell: sha256sum ${archive} > ${archive}.sha256
+      continue_on_error: true
+    - name: upload
+      shell: scp ${archive} ${archive}.sha256 backup@server:/backups/
+      env:
+        SSH_AUTH_SOCK: /tmp/agent.sock
+
+Step fields:
+  run                 toolbox subcommand and arguments (without the program name)
+  args                extra arguments appended to run, one per item, no quoting needed
+  shell               external command run by the system shell, instead of run
+  dir                 working directory
+  env                 extra environment variables
+  timeout             time limit such as 30s or 10m
+  continue_on_error   keep running the remaining steps if this one fails
+
+${name} in commands is taken from --var, vars and the environment, in that order,
+and is an error when undefined; $name is left alone for the shell. A step may also be
+a plain string, which is used as its run value.
+
+Examples:
+  %[1]s run tasks.yaml
+  %[1]s run tasks.yaml --var src=/var/www --var archive=www.tar.gz
+  %[1]s run tasks.yaml --continue-on-error
+  %[1]s run tasks.yaml --output json`,
+	"long:history": `Show recently executed toolbox commands with their start time, duration and exit code.
+
+Every command is recorded with its full arguments and working directory, so it can be
+re-run by number with rerun, which is handy for repeating long diagnostic commands.
+history, rerun, completion and task file steps are not recorded.
+
+The history is stored in toolbox/history.jsonl under the user config directory and keeps
+at most 1000 entries. Set TOOLBOX_HISTORY to use another file, or TOOLBOX_NO_HISTORY=1 to
+disable recording. Note that sensitive arguments such as passwords are recorded too; use
+--clear to wipe the history when needed.
+
+Examples:
+  %[1]s history                  # Show the last 20 commands
+  %[1]s history -n 0             # Show all commands
+  %[1]s history --filter ping    # Only show commands containing ping
+  %[1]s history --failed         # Only show failed commands
+  %[1]s history --clear          # Clear the history
+  %[1]s rerun 42                 # Re-run command 42`,
+	"long:rerun": `Re-run a command by its history number; last means the most recent one.
+
+The command runs with the same arguments in its original working directory, or in the
+current directory when that no longer exists or --here is given. The re-run is recorded
+as a new history entry and rerun exits with its exit code.
+Values of passwords, keys and other secrets are not recorded in history (they show as ******),
+so commands containing them cannot be re-run.
+
+Examples:
+  %[1]s rerun 42          # Re-run command 42
+  %[1]s rerun last        # Re-run the most recent command
+  %[1]s rerun 42 --here   # Re-run in the current directory`,
+	"long:stats": `Summarize execution time, CPU time and peak memory per command and list the slowest
+runs, to find which commands or argument combinations need optimization.
+
+Metrics are off by default. Set TOOLBOX_METRICS=1 or add metrics: true to the config file and
+every command's timing and resource usage is appended to toolbox/metrics.jsonl in the user
+config directory, keeping at most 5000 entries. The data stays local. TOOLBOX_METRICS_FILE
+overrides the file path. Resource usage includes child processes, so the figures for run,
+watch and map include the subcommands they execute.
+
+Examples:
+  %[1]s stats                        # Summary of all records and the 10 slowest runs
+  %[1]s stats --since 7d             # Only the last 7 days
+  %[1]s stats --command "fs find"    # Only fs find and its subcommands
+  %[1]s stats -n 20 --output json    # The 20 slowest runs as JSON
+  %[1]s stats --clear                # Clear the recorded metrics`,
+	"long:watch": `Run a toolbox subcommand at a fixed interval, redraw the screen with its latest output
+and highlight the characters that changed since the previous run.
+
+Stop conditions can be given: the subcommand exits with a given code (--until-exit) or its
+output matches a regular expression (--until-match). --count limits the number of runs and
+Ctrl+C stops at any time. When stdout is not a terminal or --no-clear is given, the screen is
+not cleared and each run's output is appended.
+Exits with 0 when a stop condition is met or on Ctrl+C, and with the last run's exit code
+when --count is reached.
+
+Examples:
+  %[1]s watch -- process list --sort cpu --top 10
+  %[1]s watch -n 5 -- network portscan example.com -p 443
+  %[1]s watch -n 10 --until-exit 0 -- network ping 10.0.0.1 -c 1    # Wait for the host to come back
+  %[1]s watch --until-match 'open' -- network portscan db -p 5432       # Wait for the port to open
+  %[1]s watch -n 60 --count 5 --no-clear -- fs find /var/log -m -1`,
+	"long:map": `Run the same toolbox subcommand concurrently for every target in a list and summarize
+each target's status, exit code and duration.
+
+{} in the command arguments is replaced with the target; without {} the target is appended
+as the last argument. The targets file holds one target per line, ignoring blank lines and
+lines starting with #, and - reads it from stdin; --target gives targets directly.
+Each target's result is printed as it finishes, followed by a summary. With --output json/yaml
+only the summary, including every target's output, is printed for scripts to consume.
+If any target fails, the command exits with the exit code of the first failed target in list order.
+
+Examples:
+  %[1]s map --targets hosts.txt -- network ping {} -c 2
+  %[1]s map --targets hosts.txt -j 20 --timeout 30s -- network portscan {} -p 22,443
+  %[1]s map -t 8.8.8.8,1.1.1.1 -- network traceroute
+  %[1]s map --targets hosts.txt --summary-only -- network ping {} -c 1
+  cat domains.txt | %[1]s map --targets - --output json -- network dns {}`,
+	"long:clip": `Copy text from stdin or the arguments to the system clipboard, or print the clipboard
+content to stdout with --paste.
+
+Works out of the box on Windows and macOS; Linux needs xclip, xsel or wl-clipboard (Wayland).
+Where no clipboard tool is available, e.g. over SSH, copying falls back to the OSC 52 escape
+sequence, which terminals such as iTerm2, Windows Terminal, kitty and tmux write to the local
+clipboard.
+
+Other commands accept the global --clipboard flag to also copy their output to the clipboard.
+
+Examples:
+  echo hello | %[1]s clip              # Copy stdin
+  %[1]s clip "some text"               # Copy the arguments
+  %[1]s clip --paste > note.txt        # Paste into a file
+  %[1]s clip --paste | %[1]s text grep error   # Search the clipboard content
+  %[1]s network ipinfo --clipboard     # Print and copy to the clipboard`,
+	"long:enc": `Encode and decode text or files in common formats, handy for inspecting API payloads,
+logs and email.
+
+Supported formats:
+  base64     standard base64
+  base64url  URL-safe base64 (unpadded)
+  hex        hexadecimal; decoding accepts a 0x prefix, spaces and colon separators
+  url        URL (percent) encoding
+  html       HTML entities
+  qp         quoted-printable
+
+Subcommands:
+  encode - encode
+  decode - decode, detecting the format when none is given
+
+Examples:
+  %[1]s enc encode -t hex -s hello
+  %[1]s enc decode -s aGVsbG8=`,
+	"long:hash": `Digest tools for checking files and debugging webhook signatures.
+
+Subcommands:
+  hmac - compute or verify an HMAC
+
+Examples:
+  %[1]s hash hmac -k secret -s hello`,
+	"long:id": `Generate UUIDs, ULIDs and Snowflake IDs, or inspect the version, timestamp and other
+fields of existing IDs; handy for creating fixtures and debugging ID collisions.
+
+Subcommands:
+  uuid - generate UUIDs (v4 or v7)
+  ulid - generate ULIDs
+  snowflake - generate Snowflake IDs
+  inspect - show the type, version and timestamp of IDs
+
+Examples:
+  %[1]s id uuid -v 7
+  %[1]s id inspect 01ARZ3NDEKTSV4RRFFQ69G5FAV`,
+	"long:time": `Convert between Unix timestamps, RFC3339 and other common time formats and time zones,
+and parse human-readable durations; handy for reading timestamps in logs.
+
+Subcommands:
+  now - show the current time in several formats
+  convert - convert timestamps or time strings
+  duration - parse durations
+
+Examples:
+  %[1]s time now --to UTC
+  %[1]s time convert 1700000000123 --to Asia/Shanghai
+  %[1]s time duration 1h30m`,
+	"long:mock": `Generate fake names, emails, IPs, timestamps, enums and more from a schema file, as
+JSON Lines, a JSON array or CSV, to quickly build test datasets.
+
+The schema is a JSON file listing the fields in order, for example:
+  {
+    "fields": [
+      {"name": "id", "type": "seq"},
+      {"name": "name", "type": "name"},
+      {"name": "email", "type": "email", "domain": "corp.example"},
+      {"name": "ip", "type": "ipv4"},
+      {"name": "status", "type": "enum", "values": ["active", "locked", "deleted"]},
+      {"name": "score", "type": "float", "min": 0, "max": 100, "decimals": 1},
+      {"name": "created_at", "type": "timestamp", "start": "2024-01-01", "end": "2024-12-31"},
+      {"name": "remark", "type": "sentence", "null_rate": 0.3}
+    ]
+  }
+
+Run mock types to list field types and their options. Output is reproducible with --seed.
+--pretty runs JSON output through the formatter, just like the fmt command.
+
+Examples:
+  %[1]s mock --schema schema.json -n 1000 > users.jsonl
+  %[1]s mock --schema schema.json -n 1000 -f csv -o users.csv
+  %[1]s mock --schema schema.json -n 3 -f json --pretty --color
+  %[1]s mock --schema schema.json -n 100 --seed 42`,
+	"long:crypt": `Encrypt and decrypt files with a passphrase. The key is derived from the passphrase with
+scrypt (default) or argon2id, and data is encrypted in chunks with AES-256-GCM, so large files
+are streamed rather than loaded into memory. Decryption fails if chunks are modified, reordered
+or the file is truncated.
+
+Combine with fs split to encrypt chunks one by one before transfer, then decrypt and merge them
+on the other side.
+
+Subcommands:
+  encrypt - encrypt files
+  decrypt - decrypt files
+
+Examples:
+  %[1]s crypt encrypt backup.tar.gz
+  %[1]s crypt decrypt backup.tar.gz.enc`,
+	"long:regex": `Test and explain regular expressions, to debug patterns before using them with text grep,
+text replace and text filter. Uses Go's RE2 syntax, like the text commands.
+
+Subcommands:
+  test - show match positions and capture groups
+  explain - break a regular expression into annotated parts
+
+Examples:
+  %[1]s regex test '\d{4}-\d{2}' --input app.log
+  %[1]s regex explain '^(?P<user>\w+)@(\w+\.)+com$'`,
+	"long:conv": `Convert data size, duration and transfer rate units, convert integers between number bases,
+and decompose bit flags such as file modes and TCP flags.
+
+With arguments it converts units, written as "<value><unit> to <unit>"; to may also be written as in
+or omitted. Separate several target units with commas; without a target unit all units of the same
+kind are listed. KB, MB and GB are powers of 1000, KiB, MiB and GiB powers of 1024, and bare K, M and G
+are powers of 1024 like the size flags of other commands. Mb and Mbit are megabits, MB is megabytes.
+
+Subcommands:
+  base - convert between decimal, hex, octal and binary
+  flags - decompose or compose bit flags
+  units - list supported units
+
+Examples:
+  %[1]s conv 1.5GiB to MB
+  %[1]s conv 100Mbps in MB/s
+  %[1]s conv 90min to h,s
+  %[1]s conv 4096 KiB
+  %[1]s conv base 0xff 0755 0b1010
+  %[1]s conv flags 0755 -t mode`,
+	"long:sysinfo": `Show a summary of this machine:
+  Host - hostname, distribution, kernel version, virtualization, boot time and uptime
+  CPU - model, physical and logical cores, frequency, usage and load average
+  Memory - memory and swap usage
+  Disks - capacity and usage of mounted file systems
+  Network interfaces - state, MAC address, MTU and IP addresses
+
+CPU usage is sampled over a short period, 500ms by default; --cpu-sample 0 skips sampling.
+Parts that are not available on the current platform are reported as warnings without affecting
+the others. Combine with --host to inspect remote hosts.
+
+Examples:
+  %[1]s sysinfo
+  %[1]s sysinfo --output json
+  %[1]s sysinfo --all-disks --cpu-sample 2s
+  %[1]s sysinfo --host web1,web2 --output json`,
+	"long:disk": `Check the health of local disks.
+
+Subcommands:
+  smart - show SMART health information of disks
+
+Examples:
+  %[1]s disk smart
+  %[1]s disk smart /dev/sda --attributes`,
+	"long:service": `Inspect and control system services through systemd (systemctl, journalctl) on Linux and the
+Service Control Manager on Windows, with the same commands on every platform.
+
+Subcommands:
+  status - show service status
+  start - start a service
+  stop - stop a service
+  restart - restart a service
+  logs - show service logs
+
+Examples:
+  %[1]s service status nginx sshd
+  sudo %[1]s service restart nginx
+  %[1]s service logs nginx -n 100 -f`,
+	"long:cron": `Parse standard 5-field cron expressions (minute hour day month weekday) to check when a job
+will run before putting it into a crontab or scheduler configuration.
+
+Subcommands:
+  explain - describe a cron expression and list its next run times
+
+Examples:
+  %[1]s cron explain "*/15 2 * * 1-5"
+  %[1]s cron explain "0 9 1 * *" -n 10 --tz Asia/Shanghai`,
+	"long:log": `View and follow log files, recognizing the timestamp of each line on top of what tail and grep offer.
+
+Subcommands:
+  tail - follow multiple log files with regex filtering and highlighting
+
+Examples:
+  %[1]s log tail app.log err.log -f
+  %[1]s log tail app.log --grep ERROR --highlight 'timeout|5xx' --since 10m`,
+	"long:serve-dir": `Start a static file HTTP server for the current directory by default, to share files temporarily
+or preview frontend builds; a replacement for python -m http.server.
+
+  - Serves index.html when a directory has one, otherwise shows a directory listing
+  - Supports Range requests for resumable downloads and seeking in audio/video
+  - --auth user:pass enables basic auth
+  - --tls enables HTTPS with a temporary self-signed certificate, or use --cert/--key
+  - --upload allows uploads from the listing page or with curl -T; existing files are never overwritten
+  - Files and directories starting with . (such as .git, .env) are not served unless --hidden is given
+
+Each request prints one access log line. Press Ctrl+C to stop.
+
+Examples:
+  %[1]s serve-dir
+  %[1]s serve-dir ./dist --listen :8000
+  %[1]s serve-dir /data --listen 127.0.0.1:9000 --auth admin:secret --tls
+  %[1]s serve-dir ./inbox --upload --max-upload 2GB
+  curl -T report.pdf http://host:8000/report.pdf          # upload with --upload`,
+	"long:md": `Work with Markdown documents, making docs and runbooks easy to read in the terminal.
+
+Subcommands:
+  render - render Markdown as terminal text or HTML
+
+Examples:
+  %[1]s md render README.md
+  %[1]s md render runbook.md --format html -o runbook.html`,
+	"long:env": `Inspect the environment variables of the current process, check PATH-like lists for problems,
+and compare with or export to .env/JSON files.
+Variables whose name or value looks like a password, token or key (e.g. *_TOKEN, *_SECRET, URLs with
+passwords) have their values masked; use --show-secrets to show the real values.
+
+Subcommands:
+  list   - list environment variables, optionally filtered by name
+  path   - check PATH-like lists for duplicate or missing directories and shadowed commands
+  diff   - compare the current environment with a .env file
+  export - export environment variables as .env or JSON
+
+Examples:
+  %[1]s env list AWS_
+  %[1]s env path
+  %[1]s env diff .env
+  %[1]s env export 'APP_*' --format json -o env.json`,
+	"long:docker": `Inspect local containers through the Docker Engine API, without the docker CLI; Podman's
+Docker-compatible socket works as well.
+Container main processes and processes inside containers are shown with their host PIDs, which can be
+used directly with process info/kill/tree; in the other direction, process info shows the container
+a process belongs to.
+
+Connects to DOCKER_HOST by default; when unset, tries /var/run/docker.sock, rootless Docker and Podman
+sockets, and npipe:////./pipe/docker_engine on Windows. Docker Desktop runs containers inside a VM,
+so their PIDs cannot be matched with host processes.
+
+Subcommands:
+  ps    - list containers with their main process PID, or find the container of a process
+  stats - show CPU, memory, network and disk IO usage of containers
+  logs  - print container logs
+
+Examples:
+  %[1]s docker ps
+  %[1]s docker ps --pid 4321
+  %[1]s docker stats --sort memory
+  %[1]s docker logs web -f -n 100`,
+	"long:totp": `Generate TOTP (RFC 6238) two-factor codes from a secret, matching authenticator apps such as
+Google Authenticator and 1Password; useful for logging in from headless servers or testing accounts
+with two-factor authentication in CI.
+
+Prints the current code, the seconds it remains valid and the next code, so the next one can be used
+right away when little time is left.
+Use --secret for a base32-encoded secret, or --from for an otpauth://totp/ URI as used by authenticator
+apps (usually the content of the QR code, including issuer, account, algorithm and so on).
+
+The values of --secret and --from can be written as:
+  @path       read from a file, stripping trailing newlines
+  env:NAME    read from an environment variable, keeping the secret out of shell history
+
+--qr shows a QR code in the terminal and --qr-out saves it as a PNG image, which can be scanned by an
+authenticator app on a phone.
+--algorithm, --digits and --period override the parameters in the URI.
+
+Examples:
+  %[1]s totp --secret JBSWY3DPEHPK3PXP
+  %[1]s totp --secret env:GITHUB_TOTP_SECRET
+  %[1]s totp --from 'otpauth://totp/GitHub:alice?secret=JBSWY3DPEHPK3PXP&issuer=GitHub'
+  %[1]s totp --from @github.otpauth --output json
+  %[1]s totp --secret @secret.txt --issuer GitHub --account alice --qr
+  %[1]s totp --secret JBSWY3DPEHPK3PXP --account ci-bot --qr-out ci-bot.png
+  %[1]s watch -n 1 -- totp --secret env:TOTP_SECRET          # refresh continuously`,
+	"long:completion": `Generate a shell completion script for the given shell.
+
+Besides commands and flags, some arguments are completed dynamically:
+  - network sniff completes network interface names
+  - process info/kill/children/tree complete PIDs (with process names)
+  - fs compress --type and fs split --format complete archive formats
+
+Bash:
+  source <(%[1]s completion bash)
+  %[1]s completion bash > /etc/bash_completion.d/%[1]s
+
+Zsh:
+  %[1]s completion zsh > "${fpath[1]}/_%[1]s"
+
+Fish:
+  %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+
+PowerShell:
+  %[1]s completion powershell | Out-String | Invoke-Expression`,
+
+	// 子命令的详细说明
+	"long:conv base": `Convert integers to decimal, hexadecimal, octal and binary, and show the character when the value is
+the code point of a printable character.
+
+By default the base is detected from the prefix: 0x is hexadecimal, 0o or a leading 0 is octal, 0b is binary
+and anything else is decimal; the prefix can be omitted when the base is given with --from. Digits may be
+separated with _, integers of any size and negative numbers are supported, negative numbers must come after --
+so they are not taken as flags.
+
+Examples:
+  %[1]s conv base 255 0xff 0755 0b1010
+  %[1]s conv base ff 7fffffff --from 16
+  %[1]s conv base 0x4e2d --output json
+  %[1]s conv base -- -42`,
+	"long:conv flags": `Decompose an integer into the flags that are set, or combine flag names into an integer.
+
+--type selects the kind of flags:
+  mode - Unix file permissions, numbers without a prefix are octal, such as 755 or 4755, or written as rwxr-xr-x
+  tcp - flags in a TCP header, such as 0x12, or written as SYN|ACK or syn,ack
+  bits - any integer, listing the numbers of the bits that are set, or written as comma separated bit numbers such as 0,4,31
+
+Examples:
+  %[1]s conv flags 0755 -t mode
+  %[1]s conv flags rwsr-x--- -t mode
+  %[1]s conv flags 0x12 -t tcp
+  %[1]s conv flags SYN,ACK -t tcp --all
+  %[1]s conv flags 0x80000011`,
+	"long:conv units": `List all units supported by unit conversion and their factors; only units of the same kind can be converted.
+Data sizes are based on bytes, durations on seconds and transfer rates on bytes per second.`,
+	"long:cron explain": `Validate a cron expression, describe it and list the next run times; for an invalid expression, point out the
+field in error and suggest a fix.
+
+The fields are minute (0-59), hour (0-23), day of month (1-31), month (1-12 or JAN-DEC) and day of week
+(0-7 or SUN-SAT, both 0 and 7 are Sunday). *, lists 1,3, ranges 1-5, steps */15 and 1-30/5 are supported,
+as well as @hourly, @daily, @weekly, @monthly and @yearly. As in Vixie cron, when neither day of month nor
+day of week is *, the job runs when either matches. The expression may also be passed as several arguments.
+
+Examples:
+  %[1]s cron explain "*/15 2 * * 1-5"
+  %[1]s cron explain "0 9 1,15 * *" -n 10
+  %[1]s cron explain @weekly --tz America/New_York
+  %[1]s cron explain "30 8 * * MON-FRI" --from "2025-01-01 00:00:00" --output json`,
+	"long:crypt decrypt": `Decrypt files encrypted by crypt encrypt. The output defaults to the file name without .enc (or with .dec
+added when it has no such extension). Without files, read from standard input and write to standard output.
+
+Decryption writes to a temporary file and only replaces the target once all data has been verified, so a
+wrong passphrase or a corrupted file never leaves a partial result behind; when decrypting from standard
+input to standard output, data written before a verification failure should be discarded.
+
+Examples:
+  %[1]s crypt decrypt backup.tar.gz.enc
+  %[1]s crypt decrypt mydir_chunks/*.enc -p env:BACKUP_PASS --remove
+  %[1]s fs split mydir_chunks --merge -o mydir.zip
+  %[1]s crypt decrypt data.tgz.enc -p @pass.txt -o - | tar xzf -`,
+	"long:crypt encrypt": `Encrypt files with a passphrase. The output defaults to the file name with .enc added. Without files, read
+from standard input and write to standard output.
+
+The passphrase is given like the key of hash hmac: @path, env:NAME or the literal value;
+without --passphrase you are prompted for it in the terminal (without echo).
+
+Examples:
+  %[1]s crypt encrypt backup.tar.gz
+  %[1]s crypt encrypt mydir_chunks/* -p env:BACKUP_PASS --remove
+  %[1]s crypt encrypt secret.txt -o secret.bin --kdf argon2id
+  tar czf - ./data | %[1]s crypt encrypt -p @pass.txt > data.tgz.enc`,
+	"long:disk smart": `List the model, capacity, overall SMART assessment, temperature and power-on time of disks, and warn about:
+  - a failed overall SMART assessment, or attributes that have dropped below their threshold
+  - reallocated, pending or uncorrectable sectors
+  - NVMe critical warnings, low spare space, worn-out endurance or media errors
+  - temperatures reaching --temp-warn (55°C by default)
+
+Without devices, all disks are checked. Full details need smartctl from smartmontools 7.0 or later and
+usually root or administrator privileges; without it, only the model, capacity and some temperatures can be
+read from /sys/block on Linux. Exits with code 1 when any disk has warnings or cannot be read, which is
+handy in scripts and scheduled jobs.
+
+Examples:
+  sudo %[1]s disk smart
+  sudo %[1]s disk smart /dev/sda --attributes
+  sudo %[1]s disk smart /dev/sdb -d sat --temp-warn 50
+  sudo %[1]s disk smart --output json`,
+	"long:docker logs": `Print the standard output and standard error of a container to the standard output and standard error of
+this command, so they can be filtered further in a pipeline.
+The container can be a name, a full ID or an ID prefix. With --follow, new logs keep being printed until the
+container exits or Ctrl+C is pressed.
+
+Examples:
+  %[1]s docker logs web
+  %[1]s docker logs web -f -n 100
+  %[1]s docker logs 3f2a --since 30m --timestamps
+  %[1]s docker logs api 2>&1 | %[1]s text grep ERROR`,
+	"long:docker ps": `List running containers (--all includes stopped ones). The PID column is the host PID of the container's main
+process and the process column is the process name as seen on the host.
+--processes also lists every process inside each container with its CPU and memory usage;
+--pid finds which container a host process belongs to (for example a process using too much in process list)
+and lists the processes of that container.
+
+Examples:
+  %[1]s docker ps
+  %[1]s docker ps -a --filter nginx
+  %[1]s docker ps --processes
+  %[1]s docker ps --pid 4321
+  %[1]s docker ps --output json`,
+	"long:docker stats": `Show the resource usage of running containers, all running containers by default, or the given container
+names or ID prefixes.
+CPU%% is relative to a single CPU and may exceed 100%% on multiple cores; memory excludes reclaimable page
+cache, as in docker stats. PID is the host PID of the container's main process. Sampling CPU usage takes
+about one second; use the watch command to keep refreshing.
+
+Examples:
+  %[1]s docker stats
+  %[1]s docker stats web db --sort memory
+  %[1]s watch -n 5 -- docker stats --sort cpu`,
+	"long:enc decode": `Decode a file, standard input or the text given with --string, and print the decoded data as is.
+
+Without --type the format is detected from the content and the result is reported on standard error; URL
+encoding, HTML entities, quoted-printable, hexadecimal and base64 are checked in that order, and content that
+is valid as both hexadecimal and base64 is decoded as hexadecimal. Use --type when the detection is wrong.
+
+Examples:
+  %[1]s enc decode -s aGVsbG8gd29ybGQ=          # detected as base64
+  %[1]s enc decode -s "a%%3D1%%26b%%3D2"            # detected as URL encoding
+  %[1]s enc decode -t hex -s "de:ad:be:ef" | xxd
+  %[1]s enc decode -t base64 payload.txt > payload.bin
+  %[1]s clip --paste | %[1]s enc decode`,
+	"long:enc encode": `Encode a file, standard input or the text given with --string in the given format, followed by a newline.
+With several files, the result for each file is printed on its own line.
+
+Examples:
+  %[1]s enc encode -s "hello world"            # base64
+  %[1]s enc encode -t hex -s hello             # hexadecimal
+  %[1]s enc encode -t url -s "a=1&b=中文"      # URL encoding
+  %[1]s enc encode -t base64url key.bin        # encode a file
+  printf 'x<y' | %[1]s enc encode -t html      # encode standard input`,
+	"long:env diff": `Check whether the variables in a .env file exist in the current environment with the same values, to confirm
+that a configuration has been loaded or that a deployment matches the local configuration. By default only
+different and missing variables are listed; --all also lists equal ones and --extra also lists variables that
+only exist in the current environment.
+
+.env files support # comments, the export prefix, single and double quotes (which may span lines); ${VAR}
+references are not expanded. Exits with code 1 when any variable is different or missing.
+
+Examples:
+  %[1]s env diff .env
+  %[1]s env diff .env.production --all
+  %[1]s env diff .env --show-secrets --output json`,
+	"long:env export": `Export the current environment variables in .env format (the default) or as a JSON object; patterns match as
+in env list. Values of sensitive variables are masked by default, use --show-secrets to export the original
+values; files are written with permission 0600.
+
+Examples:
+  %[1]s env export > env.txt
+  %[1]s env export 'APP_*' -o .env.local --show-secrets
+  %[1]s env export --format json | jq .PATH`,
+	"long:env list": `List the environment variables of the current process sorted by name; values of sensitive variables are masked
+by default.
+
+Patterns are case-insensitive and match a substring of the name by default; with * or ? they are wildcards
+matching the whole name. With --values, variables whose value contains the pattern are listed as well.
+
+Examples:
+  %[1]s env list
+  %[1]s env list proxy
+  %[1]s env list 'GO*' --names
+  %[1]s env list /opt --values
+  %[1]s env list AWS_ --show-secrets --output json`,
+	"long:env path": `List the directories of a path list variable one by one and point out problems, checking PATH by default:
+  - duplicates: the same as an earlier entry, or the same directory through a symbolic link
+  - entries that do not exist or are not directories
+  - relative paths and empty entries: they change with the current directory, and an empty entry means the
+    current directory on Unix, which is a security risk
+
+When checking PATH, shadowed commands are listed as well, that is executables with the same name in several
+directories of which only the first one runs, which helps with "a new version is installed but the old one
+still runs". --all checks every variable whose name ends in PATH or _DIRS and whose value is a directory
+list, such as PYTHONPATH, LD_LIBRARY_PATH and XDG_DATA_DIRS.
+
+Examples:
+  %[1]s env path
+  %[1]s env path PYTHONPATH LD_LIBRARY_PATH
+  %[1]s env path --all
+  %[1]s env path --output json`,
+	"long:fmt fmt": `Format data files or text, pretty-printing or compacting JSON, XML and YAML.
+
+Examples:
+  %[1]s fmt data.json --pretty --color    # pretty-print and colorize a JSON file
+  %[1]s fmt data.xml --pretty             # pretty-print an XML file
+  %[1]s fmt data.json --compact           # compact a JSON file
+  %[1]s fmt data.yaml --pretty            # pretty-print a YAML file
+  %[1]s fmt '{"name":"John"}' --format json --pretty  # pretty-print JSON text
+  %[1]s fmt -s '<root><item>1</item></root>' --format xml --pretty  # pretty-print XML text
+  %[1]s fmt -s '#{"name":"toolbox"}#' --format json --pretty --delimiter '#'  # use a custom delimiter`,
+	"long:fs archive": `Inspect archives or convert them to another format without extracting to disk. All formats that fs compress
+can extract are supported.
+
+Subcommands:
+  list    - list the files and directories in an archive
+  convert - convert an archive to another format
+
+Examples:
+  %[1]s fs archive list backup.tar.gz
+  %[1]s fs archive list release.zip --output json
+  %[1]s fs archive convert release.zip release.tar.zst`,
+	"long:fs archive convert": `Convert an archive to another format, reading the files of the source archive one by one and writing them
+straight into the target archive without extracting to disk first; paths, permissions, modification times and
+symbolic links are kept.
+
+The source can be any format fs compress can extract. The target format is detected from the extension or
+given with --type: zip, tar.gz and the other tar formats, and gz, bz2, xz, zst, lz4 or br when the archive
+contains a single file. zip does not support hard links, so a tar with hard links can only be converted to a
+tar format. Files in 7z archives do not record their extracted size, so they are written to temporary files
+one by one when converting to a tar format.
+
+Examples:
+  %[1]s fs archive convert release.zip release.tar.zst
+  %[1]s fs archive convert backup.tar.gz backup.tar.xz -l 9
+  %[1]s fs archive convert photos.7z photos.zip
+  %[1]s fs archive convert secret.zip data.tar.gz --password env:ZIP_PASSWORD
+  %[1]s fs archive convert site.tar.gz html.zip --include '*.html'`,
+	"long:fs archive list": `List the files and directories in an archive, including size, compressed size, permissions and modification
+time.
+
+zip and rar headers are read directly, which is fast; tar formats decompress the whole stream (without writing
+to disk); 7z and single-file formats (gz, bz2, xz, zst, lz4, br) must be fully decompressed to learn the
+extracted size. For formats compressed as a whole, such as tar and 7z, the compressed size of a single file
+is unknown and shown as -.
+
+Examples:
+  %[1]s fs archive list backup.tar.gz
+  %[1]s fs archive list release.zip --output json
+  %[1]s fs archive list app.log.zst`,
+	"long:fs checksum": `Create a checksum manifest in SHA256SUMS format for a whole directory, and later check the files against it to
+find modified or corrupted files, e.g. to check archives and backups regularly.
+
+File names in the manifest are paths relative to the directory in the same format as sha256sum, so the
+manifest can also be checked with sha256sum -c in that directory.
+
+Examples:
+  %[1]s fs checksum create /mnt/backup        # write /mnt/backup/SHA256SUMS
+  %[1]s fs checksum verify /mnt/backup        # check against the manifest`,
+	"long:fs checksum create": `Compute SHA-256 checksums of all files in a directory in parallel and write them sorted by path to the manifest,
+SHA256SUMS in the directory by default.
+Symbolic links are not followed and the manifest itself is skipped; files that cannot be read are reported as
+warnings and left out of the manifest.
+
+Examples:
+  %[1]s fs checksum create /mnt/backup                           # write /mnt/backup/SHA256SUMS
+  %[1]s fs checksum create ./release -m release.sha256           # use another manifest file
+  %[1]s fs checksum create ~/photos --exclude .cache -e "*.tmp"  # exclude directories and files`,
+	"long:fs checksum verify": `Compute the checksum of every file listed in the manifest (SHA256SUMS in the directory by default) in parallel
+and report files whose content differs, that no longer exist or that cannot be read, as well as new files in
+the directory that are not in the manifest. The manifest may come from fs checksum create or sha256sum.
+
+Exits with code 1 when any file differs, is missing or cannot be read; new files are only listed by default
+and count as failures with --strict.
+
+Examples:
+  %[1]s fs checksum verify /mnt/backup                    # check against /mnt/backup/SHA256SUMS
+  %[1]s fs checksum verify ./release -m release.sha256    # use another manifest file
+  %[1]s fs checksum verify /data --strict --output json   # new files fail too, output as JSON`,
+	"long:fs compress": `Compress or decompress files and directories.
+
+Modes:
+  - compress:   compress (the default)
+  - decompress: decompress
+  - verify:     extract every file without writing to disk and check CRCs and other checksums; only the
+                archive needs to be given
+
+Supported formats:
+  - zip:     ZIP archive (directories supported)
+  - tar.gz:  TAR+GZIP archive (directories supported, or .tgz)
+  - tar.bz2: TAR+BZIP2 archive (directories supported, or .tbz2)
+  - tar.xz:  TAR+XZ archive (directories supported, or .txz)
+  - tar.zst: TAR+Zstandard archive (directories supported, or .tzst)
+  - tar.lz4: TAR+LZ4 archive (directories supported)
+  - tar.br:  TAR+Brotli archive (directories supported)
+  - gz:      GZIP file (single files only)
+  - bz2:     BZIP2 file (single files only)
+  - xz:      XZ file (single files only)
+  - zst:     Zstandard file (single files only, fast)
+  - lz4:     LZ4 file (single files only, fastest with a lower ratio, the level is ignored)
+  - br:      Brotli file (single files only, good for web assets, -l 9 gives the best ratio)
+  - 7z:      7-Zip archive (directories supported)
+  - rar:     RAR archive (decompression only)
+
+--password encrypts the contents of files in a zip with AES-256 when compressing (WinZip AES format, which
+7-Zip, WinRAR, the macOS Archive Utility and others can extract, but the system unzip command cannot); file
+names are not encrypted. When decompressing it is used for encrypted zip (AES or traditional encryption),
+rar and 7z archives; 7z archives with encrypted file lists are not supported.
+
+tar formats keep symbolic and hard links; when extracting, link targets must stay inside the target
+directory, and links pointing outside are skipped and reported.
+By default extracted files get the permissions recorded in the archive (subject to umask) and the current
+time as modification time; --preserve restores permissions and modification times, and also the owners
+recorded in a tar when running as root.
+
+--include only compresses or extracts matching files and --exclude skips matching files and directories when
+compressing; both can be repeated or comma separated. Patterns match the path in the archive (relative to the
+source directory when compressing) with shell wildcard syntax, ** matches any number of directories; patterns
+without / match file names at any level (such as *.log or node_modules), and matching a directory includes
+all files below it. zip extraction only reads the matching files, which is fast; tar and other formats still
+decompress the whole stream, but only matching files are written to disk. Extraction fails when a pattern
+matches no file.
+
+When compressing a directory, files can also be selected by size (--min-size, --max-size) and modification
+time (--since for recently modified, --before for older files); only files meeting all conditions are
+compressed.
+
+--volume-size splits a zip into volumes: every volume except the last has the given size and is written to
+name.z01, name.z02 and so on, the last volume is name.zip. This is the same format as volumes created by
+7-Zip, WinRAR and zip -s, so they can extract each other's volumes. To decompress, verify or list a split
+zip, give the .zip file; the other volumes must be in the same directory.
+
+--manifest writes a SHA-256 manifest when compressing (in the same format as sha256sum) that records every
+source file under its path in the archive, followed by the archive itself (every volume of a split zip), so
+sha256sum -c in the archive's directory checks that the archive is intact. When decompressing or verifying
+with a manifest, the content of every file is checked against the original and files in the manifest but
+not in the archive are reported, which suits long-term backups.
+
+--dedupe computes checksums when compressing to a tar format and stores only the first of several files with
+the same content and permissions, writing the others as hard links to it; useful for build output with many
+duplicate files. After extraction these files are hard links of the same file, so changing one changes the
+others.
+
+-j/--parallel sets the number of threads for parallel tar.gz and gz compression; when decompressing a zip it
+sets the number of files extracted at the same time, which speeds up extracting many small files to an SSD
+noticeably. Other formats can only be extracted sequentially.
+
+When extracting, a file that fails (e.g. cannot be written) is skipped and the remaining files are still
+extracted, with the number of failures summarized at the end; a wrong password stops immediately. Verify mode
+likewise reports all corrupted files, but for formats compressed as a whole such as tar and 7z, the files
+after a corrupted spot usually cannot be read.
+
+When decompressing, the format is detected from the magic bytes at the start of the file, so non-standard
+extensions (such as backup.bak) work too; the extension is used when detection fails, or use --type.
+Brotli has no magic bytes and is only recognized by extension (.br, .tar.br); --type is required when
+decompressing Brotli from standard input.
+
+A target of - writes the compressed data to standard output (the format must be given with --type), and a
+source of - decompresses from standard input, so data can be piped through ssh, nc and the like without
+temporary files. zip and 7z from standard input are written to a temporary file first (both formats need
+random access).
+
+When more than 16MB is processed, progress and the current file are shown in the terminal. Ctrl+C cancels and
+removes the unfinished archive or the file being extracted.
+
+Examples:
+  # compress (the default mode)
+  %[1]s fs compress myfile.txt myfile.txt.gz
+  %[1]s fs compress mydir mydir.zip --type zip
+  %[1]s fs compress mydir output.7z --type 7z
+  %[1]s fs compress mydir output --type tar.gz -l 9 -k
+  %[1]s fs compress /data data.tar.gz -j -1        # compress in parallel on all CPU cores
+  %[1]s fs compress logs logs.zip --password env:ZIP_PASSWORD
+  %[1]s fs compress mydir mydir.tar.zst
+  %[1]s fs compress app.log app.log.lz4
+  %[1]s fs compress dist/app.js dist/app.js.br -l 9
+  %[1]s fs compress project project.tar.zst --exclude node_modules,.git,'*.log'
+  %[1]s fs compress /var/log recent-logs.tar.gz --include '*.log' --since 7d --max-size 100M
+  %[1]s fs compress photos photos.zip --volume-size 4G   # volumes photos.z01, photos.z02, ... and photos.zip
+  %[1]s fs compress /data backup.tar.zst --manifest backup.sha256
+  %[1]s fs compress build build.tar.zst --dedupe
+
+  # decompress
+  %[1]s fs compress myfile.txt.gz myfile.txt --mode decompress
+  %[1]s fs compress mydir.zip extracted/ --mode decompress
+  %[1]s fs compress mydir.tar.zst extracted/ --mode decompress
+  %[1]s fs compress mydir.tar.lz4 extracted/ --mode decompress
+  %[1]s fs compress app.js.br app.js --mode decompress
+  %[1]s fs compress mydir.7z extracted/ --mode decompress
+  %[1]s fs compress secret.zip extracted/ --mode decompress --password env:ZIP_PASSWORD
+  %[1]s fs compress backup.tar.gz restore/ --mode decompress --include etc/nginx/nginx.conf
+  sudo %[1]s fs compress rootfs.tar.xz /srv/rootfs --mode decompress --preserve
+  %[1]s fs compress site.zip out/ --mode decompress --include '*.html,assets/**/*.css'
+  %[1]s fs compress backup.bak restore/ --mode decompress
+  %[1]s fs compress node_modules.zip out/ --mode decompress -j -1   # extract in parallel on all CPU cores
+
+  # check that an archive is intact
+  %[1]s fs compress backup.tar.zst --mode verify
+  %[1]s fs compress secret.zip --mode verify --password env:ZIP_PASSWORD
+  %[1]s fs compress backup.tar.zst --mode verify --manifest backup.sha256
+
+  # pipes
+  %[1]s fs compress /data - --type tar.zst | ssh backup-host 'cat > data.tar.zst'
+  ssh backup-host 'cat data.tar.zst' | %[1]s fs compress - restore/ --mode decompress`,
+	"long:fs du": `Sum up the size and number of files below every subdirectory and list the directories and files that use
+the most space.
+
+Directories are read in parallel, so large trees finish quickly too. Like du, the disk space actually used is
+counted by default (including the directories themselves); --apparent-size counts file sizes instead.
+Symbolic links are not followed and several hard links to the same file are counted once.
+--depth only lists directories up to that depth (1 compares the direct subdirectories of the root), while
+sizes still include deeper files.
+
+Use the global --output json to get the full statistics, e.g. for a monitoring dashboard.
+
+Examples:
+  %[1]s fs du                                  # analyze the current directory
+  %[1]s fs du /var --top 20                    # list the 20 largest directories and files
+  %[1]s fs du ~ --depth 1                      # compare the directories in your home directory
+  %[1]s fs du . --exclude .git -e node_modules # exclude directories
+  %[1]s fs du /data --output json              # output as JSON`,
+	"long:fs dupes": `Find files with the same content in a directory and report every group of duplicates and the space wasted.
+
+Files are first grouped by size, files of the same size are compared by a checksum of their first 4KB, and
+only those that still match are read in full to compute SHA-256, so only a few files need to be read even in
+large directories. Empty files and files that are already hard links of each other do not count as
+duplicates.
+
+--link replaces every file in a group except the first (sorted by path) with a hard link to the first one,
+freeing space but keeping all paths; the files must be on the same file system. --delete removes every file
+except the first. Both can be previewed with --dry-run. After linking, changing one file changes every file
+in its group.
+
+Examples:
+  %[1]s fs dupes ~/Downloads                     # find duplicate files
+  %[1]s fs dupes . --name "*.jpg" --min-size 1M   # only compare jpg files of 1MB or more
+  %[1]s fs dupes build --exclude node_modules     # exclude directories
+  %[1]s fs dupes photos --delete --dry-run        # preview which duplicates would be deleted
+  %[1]s fs dupes build --link                     # replace duplicates with hard links
+  %[1]s fs dupes . --output json                  # output as JSON`,
+	"long:fs find": `Search for files and directories in a directory with a range of conditions.
+
+--content and --content-regex filter by file content, keeping only regular files that contain the string or
+match the regular expression. Files are only read when all other conditions match, so find and grep are done
+in a single pass. ^ and $ in the regular expression match the start and end of each line.
+
+--gitignore skips files and directories ignored by the .gitignore and .ignore files at every level (such as
+node_modules and build output), as well as .git directories; when searching a subdirectory of a git
+repository, the ignore rules of the parent directories apply too. --ignore-file reads additional ignore files
+with the given name (such as .fdignore), with the same syntax as .gitignore.
+
+--json prints the path, type, size, permissions, modification time and depth of every result as it is found,
+one JSON object per line, ready for jq; --csv prints CSV with a header, ready for a spreadsheet. The global
+--output json collects all results and prints a single JSON array.
+
+--template prints every result with a Go template; the fields are .Path, .Name, .Type, .Size, .Mode,
+.ModTime and .Depth, {{size .Size}} prints a human-readable size, and \t, \n and \0 in the template stand for
+tab, newline and NUL. --print0 separates results with NUL instead of newlines, so file names with spaces or
+newlines can be passed safely to xargs -0.
+
+--sort sorts the results by name (the file name), path, size or mtime, --desc sorts in descending order and
+--limit keeps only the first N results. Sorting has to find all files before printing anything; without
+sorting, the search stops as soon as N results are found.
+
+--watch keeps watching the directory and prints matching files that are created or modified afterwards
+(files that existed before are not printed) until Ctrl+C. New subdirectories are watched automatically and
+consecutive writes to the same file are printed once; it works with --json, --template, --exec and so on,
+e.g. to follow the files produced in a build output directory.
+
+--exec runs a command once for every matching file, with {} replaced by the path (quoted with shell rules),
+through sh -c (cmd /C on Windows), so pipes and redirections can be used. A failing command is reported and
+the remaining files are still processed; a matched directory removed by the command is not entered. Matching
+paths are not printed with --exec.
+
+--delete removes matching files and directories: directories are removed after the files in them have been
+handled, only empty directories can be removed, and the starting directory itself is never removed. Unlike
+find -delete, matching is decided before anything is deleted, so --empty --delete only removes files and
+directories that were empty to begin with; parent directories that become empty during the run are kept, run
+the command again if needed. Preview with --delete --dry-run first. Without --yes the number of entries to be
+deleted is shown and confirmation is asked for on a terminal; when not running on a terminal (e.g. in a script)
+deletion is refused.
+
+Examples:
+  %[1]s fs find .                         # list all files in the current directory
+  %[1]s fs find /path -name "*.go"        # find Go source files
+  %[1]s fs find . -type f                 # regular files only
+  %[1]s fs find . -type d                 # directories only
+  %[1]s fs find . --minsize 1.5M         # files of 1.5MB or more
+  %[1]s fs find . -m -7                  # files modified in the last 7 days
+  %[1]s fs find . -m -2h                 # files modified in the last 2 hours
+  %[1]s fs find . -regex ".*\\.txt$"     # find txt files with a regular expression
+  %[1]s fs find . -maxdepth 2            # search at most 2 levels deep
+  %[1]s fs find . -exclude "node_modules" # exclude the node_modules directory
+  %[1]s fs find . -include "src,lib"     # only search in src and lib
+  %[1]s fs find . --gitignore -name "*.js"  # skip files ignored by .gitignore
+  %[1]s fs find . --ignore-file .fdignore  # skip files listed in a custom ignore file
+  %[1]s fs find . -type f --json | jq -r 'select(.size > 1048576) | .path'  # filter JSON records with jq
+  %[1]s fs find . -type f --csv > files.csv  # export as CSV
+  %[1]s fs find . -name "*.log" --print0 | xargs -0 rm  # handles file names with spaces
+  %[1]s fs find . -type f --template "{{.Path}}\t{{.Size}}"  # print path and size with a template
+  %[1]s fs find /var -type f --sort size --desc --limit 20  # the 20 largest files
+  %[1]s fs find . -type f --sort mtime --desc --limit 10   # the 10 most recently modified files
+  %[1]s fs find build -name "*.o" --watch  # watch for new files in the build output
+  %[1]s fs find logs -name "*.log" --watch --exec "gzip -9 {}"  # compress logs as they are written
+  %[1]s fs find . --empty                # find empty files and directories
+  %[1]s fs find . --empty -type d --delete --yes  # remove empty directories without asking
+  %[1]s fs find . -name "*.go" --content "TODO"               # Go source files containing TODO
+  %[1]s fs find /etc --content-regex "^\s*PermitRootLogin\s+yes"  # search file content with a regular expression
+  %[1]s fs find /var/log -name "*.gz" --host web1  # search on a remote host (needs GNU find)
+  %[1]s fs find . -name "*.sh" --exec "chmod +x {}"  # run a command for every matching file
+  %[1]s fs find . -name "*.log" --exec "gzip -9"     # without {}, the path is appended to the command
+  %[1]s fs find . -name "*.tmp" --delete --dry-run   # preview which files would be deleted
+  %[1]s fs find . -name "*.tmp" --delete             # delete matching files after confirmation`,
+	"long:fs rename": `Rename the files in a directory whose names match a regular expression, replacing every match in the name
+with the replacement.
+
+The replacement may contain:
+  $1, ${name}   capture groups (write ${1} when followed by a letter, digit or underscore)
+  {n}           a counter increasing in file name order, starting at --start
+  {n:3}         the counter padded with zeros to 3 digits, such as 001
+
+--case changes the case of the whole new name: upper for upper case, lower for lower case, title to
+capitalize every word. Only files are renamed by default, --dirs renames directories too; subdirectories are
+not entered.
+
+All new names are checked before renaming: when two files would get the same name, or a new name is taken by
+a file that is not being renamed, the conflicts are reported and nothing is changed. Files that swap names
+are renamed correctly, and renames already done are undone when one fails. Preview with --dry-run first.
+
+Examples:
+  %[1]s fs rename '^IMG_(\d+)\.JPG$' 'photo_$1.jpg' ~/Pictures    # use a capture group
+  %[1]s fs rename '.*\.jpg$' 'trip-{n:3}.jpg' . --start 1          # number as trip-001.jpg, ...
+  %[1]s fs rename '\.jpeg$' '.jpg' -i                              # ignore case, change the extension
+  %[1]s fs rename ' ' '_' docs --case lower --dry-run              # spaces to underscores, lower case, preview only
+  %[1]s fs rename '^(\w+)-(\w+)$' '${2}-${1}' . --dirs             # swap the two parts of names, including directories`,
+	"long:fs split": `Pack a directory and split it into chunks:
+1. pack the directory (several compression formats are supported)
+2. split the packed file into chunks of the given size
+3. compress tar.gz with several threads in parallel
+4. merge chunks to restore the file, checking every chunk and the merged file against the chunk manifest
+5. with --raw, split an existing file (such as an ISO image or a database dump) without packing or compressing
+
+When splitting, a manifest (name.manifest.json) is written to the output directory with the size and SHA-256
+of every chunk and the checksum of the merged file. When merging a directory with a manifest, the chunks are
+first checked for completeness and size and their checksums are verified while merging; missing or corrupted
+chunks are all listed and the incomplete output is removed. Without --output the file name from the manifest
+is used.
+
+Packed data is written straight into the chunks without creating the complete archive first, so the output
+directory only needs room for the chunks.
+
+After an interruption, running the same command again resumes where it stopped: packing recompresses, but
+parts of completed chunks with the same content are not rewritten; --raw skips chunks that are complete with
+a matching checksum; merging continues after the last merged chunk.
+
+Examples:
+  # split with the defaults (100M, zip)
+  %[1]s fs split ./mydir
+
+  # set the chunk size and the format
+  %[1]s fs split ./mydir --size 1G --format tar.gz
+
+  # set the output directory and the number of threads
+  %[1]s fs split ./mydir --output ./chunks --threads 4
+
+  # split a single file as is, into backup.sql_chunks
+  %[1]s fs split backup.sql --raw --size 1G
+
+  # merge chunks
+  %[1]s fs split ./mydir_chunks --merge mydir.zip
+
+  # preview splitting and then removing the source directory
+  %[1]s fs split ./mydir --remove --dry-run`,
+	"long:fs sync": `Synchronize a source directory one way into a target directory, like rsync -a, for backups:
+only files that are missing or changed in the target are copied, permissions and modification times are kept
+and symbolic links are copied as links.
+
+By default files with the same size and modification time (to the second) are considered unchanged; with
+--checksum, files of the same size are also compared by SHA-256, which is more accurate but reads the files on
+both sides. --delete removes files and directories from the target that are not in the source, so both sides
+match exactly. Files excluded with --exclude are neither copied nor deleted from the target.
+
+Files are written to a temporary file and then renamed, so an interrupted sync never leaves incomplete files
+in the target, and the next sync continues with the files not yet synchronized.
+
+Examples:
+  %[1]s fs sync ~/photos /mnt/backup/photos                   # copy new and modified files
+  %[1]s fs sync ./site /srv/www --delete                      # remove extra files from the target
+  %[1]s fs sync . ../backup --exclude node_modules -e "*.log" # exclude directories and files
+  %[1]s fs sync ./docs /mnt/docs --delete --dry-run           # preview the changes
+  %[1]s fs sync ./data /mnt/data --checksum --output json     # compare by content, output as JSON`,
+	"long:fs tree": `Show the files and subdirectories of a directory as a tree, like the tree command on Linux and Windows,
+with options for the depth, filters and more.
+
+--du computes and shows the total size of all files in every directory (including hidden files and files
+beyond the display depth, counting hard links once), so there is no need to run du separately; --sort-size
+sorts the entries of each directory from largest to smallest to find what uses the most space quickly.
+
+In a terminal, names are colored by file type: directories, symbolic links, executables and archives. The
+colors can be changed with the LS_COLORS environment variable (di, ln, or, ex and *.ext are supported); use
+the global --no-color to turn them off.
+
+--json prints a nested JSON structure in which every node has the name, path, type, size, permissions,
+modification time and children, for use by other tools; the global --output json or --output yaml works too.
+--markdown prints a nested Markdown list and --html prints an HTML page with collapsible directories, for
+showing the layout in documents and wikis.
+
+Examples:
+  %[1]s fs tree                   # show the current directory
+  %[1]s fs tree /path/to/dir      # show the given directory
+  %[1]s fs tree -d 2              # only two levels deep
+  %[1]s fs tree -a                # show hidden files
+  %[1]s fs tree -L                # follow symbolic links
+  %[1]s fs tree -D                # directories only
+  %[1]s fs tree -s                # show file sizes
+  %[1]s fs tree src --json        # output the tree as JSON
+  %[1]s fs tree src -d 2 --markdown > layout.md  # export as a Markdown list
+  %[1]s fs tree . --du --html > tree.html  # export as a collapsible HTML page
+  %[1]s fs tree --du -D -d 2      # two levels of directories with their total sizes
+  %[1]s fs tree ~ --sort-size -D -d 1  # the directories in your home directory sorted by size`,
+	"long:hash hmac": `Compute the HMAC of files, standard input or the text given with --string, in the same format as sha256sum;
+with --verify, compare it with the given signature, e.g. to debug webhook signatures.
+
+The key can be written as:
+  @path         read from a file, stripping trailing newlines
+  env:NAME      read from an environment variable, keeping the key out of shell history
+  hex:HEX  base64:TEXT   decoded and used as the key
+  anything else used literally as the key
+
+The --verify signature can be hexadecimal or base64, with an optional prefix such as sha256= (the GitHub
+X-Hub-Signature-256 format). Exits with code 1 when the signature does not match.
+
+Examples:
+  %[1]s hash hmac -k @secret.txt payload.json
+  %[1]s hash hmac -a sha1 -k env:WEBHOOK_SECRET --base64 -s '{"id":1}'
+  cat body.json | %[1]s hash hmac -k @secret.txt --verify "sha256=5d1f..."
+  %[1]s hash hmac -k hex:0b0b0b0b -a sha512 *.bin --output json`,
+	"long:id inspect": `Detect the type of an ID and decode the information in it: the version, variant and timestamp (v1, v6, v7)
+of a UUID, the timestamp and random part of a ULID, and the timestamp, node and sequence of a Snowflake ID.
+
+Numbers are decoded as Snowflake IDs, and --epoch must match the system that generated the ID (Twitter's
+epoch by default); 26 characters are decoded as a ULID and anything else as a UUID, which may be written
+without hyphens or with a urn:uuid: prefix.
+
+Examples:
+  %[1]s id inspect 0190b5c4-5a1e-7cc2-9f5e-3c1d2b7a8e41
+  %[1]s id inspect 01ARZ3NDEKTSV4RRFFQ69G5FAV
+  %[1]s id inspect 1541815603606036480 --epoch 1288834974657
+  %[1]s id inspect $(%[1]s id uuid -n 3 -v 7) --output json`,
+	"long:id snowflake": `Generate Snowflake IDs: 64-bit integers made of a 41-bit millisecond timestamp, a 10-bit node number and a
+12-bit sequence. Twitter's epoch (2010-11-04) is used by default; use --epoch to match the epoch of your
+system.
+
+Examples:
+  %[1]s id snowflake -n 5
+  %[1]s id snowflake --node 12 --epoch 1577836800000`,
+	"long:id ulid": `Generate ULIDs: 26 characters of Crockford base32 whose first 10 characters are a millisecond timestamp, so
+sorting them lexically sorts them by creation time.
+
+Examples:
+  %[1]s id ulid
+  %[1]s id ulid -n 5 --lower`,
+	"long:id uuid": `Generate UUIDs, random v4 by default; v7 starts with a millisecond timestamp and sorts by creation time,
+which suits database primary keys.
+
+Examples:
+  %[1]s id uuid                  # one v4 UUID
+  %[1]s id uuid -n 10 -v 7       # ten v7 UUIDs
+  %[1]s id uuid --upper --no-hyphens`,
+	"long:log tail": `Print the last lines of one or more log files; with --follow, keep printing new lines until Ctrl+C.
+With several files, every line is prefixed with its file name, in a different color per file.
+
+--grep keeps only lines matching any of the patterns and --exclude drops matching lines, both repeatable;
+the parts matched by --grep are shown in red and those matched by --highlight on a yellow background,
+without affecting filtering. --since skips earlier entries based on the time in the line (ISO 8601, access
+log, syslog and other formats); lines without a time (such as stack traces) take the time of the previous
+line. With --since but without --lines, all lines after that time are shown.
+Truncated and rotated files are handled while following.
+
+Examples:
+  %[1]s log tail app.log -n 50
+  %[1]s log tail app.log err.log -f
+  %[1]s log tail app.log err.log --grep ERROR --highlight 'timeout|5xx' --since 10m
+  %[1]s log tail access.log -f -v 'GET /health' --highlight ' 5[0-9]{2} '`,
+	"long:md render": `Render a Markdown document as styled, colored terminal text or as a standalone HTML page with styles.
+GitHub-style tables, strikethrough, task lists and autolinks are supported. Without a file, or with -, read
+from standard input.
+
+Terminal text is wrapped to the terminal width by default; use --width to change it.
+HTML is written by default when --out ends in .html or .htm. Raw HTML in the document is left out of the
+page by default; keep it with --allow-html when the source is trusted.
+
+Examples:
+  %[1]s md render README.md
+  %[1]s md render README.md --width 100 | less -R
+  %[1]s md render runbook.md -o runbook.html
+  %[1]s md render notes.md --format html --fragment
+  curl -s https://example.com/README.md | %[1]s md render`,
+	"long:mock types": `List the field types available in a schema and their options.
+
+Every type supports the null_rate option (0-1), which outputs null at that rate.`,
+	"long:network cert": `Certificate tools for inspecting and generating certificates.
+
+Features:
+1. inspect certificates (validity, issuer, chain and more) from local files or remote hosts
+2. generate self-signed certificates (for development and testing)
+3. generate certificate signing requests (CSRs) and sign them with a CA
+4. manage a local CA (issue and revoke certificates, generate CRLs)
+5. convert between PEM and PKCS#12 (.pfx)
+6. monitor the expiry of many certificates
+7. convert between PEM, DER and PKCS#7`,
+	"long:network cert ca": `Manage a local certificate authority (CA) for labs and development environments.
+
+CA directory layout:
+  root.crt / root.key                  root CA certificate and private key
+  intermediate.crt / intermediate.key  intermediate CA certificate and private key (optional)
+  index.json                           issued certificates
+  certs/                               copies of issued certificates
+  crl.pem                              certificate revocation list
+
+Examples:
+  %[1]s network cert ca init --intermediate
+  %[1]s network cert ca issue example.com --dns www.example.com
+  %[1]s network cert ca issue alice --profile client
+  %[1]s network cert ca list
+  %[1]s network cert ca revoke 3F2A --reason keyCompromise
+  %[1]s network cert ca crl`,
+	"long:network cert ca crl": `Generate a certificate revocation list (CRL) from the revocation records and save it as crl.pem in the CA
+directory.
+
+Examples:
+  %[1]s network cert ca crl
+  %[1]s network cert ca crl --days 7 -o /var/www/pki/crl.pem`,
+	"long:network cert ca init": `Create a root CA in the CA directory, and optionally an intermediate CA signed by the root CA.
+Once an intermediate CA exists, certificates are issued by it.
+
+Examples:
+  %[1]s network cert ca init
+  %[1]s network cert ca init --name "Example Root CA" --org "Example Inc" --intermediate
+  %[1]s network cert ca init --dir /srv/pki --key-type ecdsa-p384 --days 7300`,
+	"long:network cert ca issue": `Generate a private key and issue a server or client certificate signed by the CA.
+
+Examples:
+  %[1]s network cert ca issue example.com
+  %[1]s network cert ca issue example.com --dns www.example.com --ip 10.0.0.5 --days 90
+  %[1]s network cert ca issue alice --profile client --key-type ed25519`,
+	"long:network cert ca list": `List all certificates issued by the CA and their status.
+
+Examples:
+  %[1]s network cert ca list
+  %[1]s network cert ca list --dir /srv/pki`,
+	"long:network cert ca revoke": `Revoke a certificate issued by the CA; a unique prefix of the serial number is enough. Regenerate the CRL
+afterwards.
+
+Revocation reasons (--reason):
+  unspecified, keyCompromise, caCompromise, affiliationChanged,
+  superseded, cessationOfOperation
+
+Examples:
+  %[1]s network cert ca revoke 3F2A9C
+  %[1]s network cert ca revoke 3F2A9C --reason keyCompromise`,
+	"long:network cert ca sign": `Sign a certificate signing request (CSR) submitted from elsewhere with the CA and record it in the CA index.
+
+Examples:
+  %[1]s network cert ca sign example.com.csr
+  %[1]s network cert ca sign client.csr --profile client -o client.crt`,
+	"long:network cert check": `Inspect a certificate file in detail, including validity, issuer and chain.
+Files with a single certificate or a full chain are supported.
+
+A remote address (host:port) can be given as well: the chain presented by the server is fetched with a TLS
+handshake and gets the same validity and trust checks, and the certificate is also checked against the host
+name.
+
+Examples:
+  # inspect a single certificate file
+  %[1]s network cert check server.crt
+
+  # inspect a file with a chain
+  %[1]s network cert check fullchain.pem
+
+  # only show problems
+  %[1]s network cert check server.crt --issues-only
+
+  # inspect the certificate of a remote host
+  %[1]s network cert check example.com:443
+
+  # set SNI and ALPN
+  %[1]s network cert check 10.0.0.5:8443 --sni api.example.com --alpn h2,http/1.1
+
+  # verify against a private CA (only trust the given root)
+  %[1]s network cert check server.crt --ca-bundle ca/root.crt --intermediates ca/intermediate.crt --strict-ca`,
+	"long:network cert convert": `Convert certificates, private keys, CSRs and CRLs between PEM and DER, and unpack PKCS#7 (.p7b) chains.
+The input format is detected automatically. A DER file holds a single object, so input with several objects
+(such as a chain) is written to several numbered files.
+
+Examples:
+  # DER certificate to PEM
+  %[1]s network cert convert server.der --to pem
+
+  # PEM private key to DER
+  %[1]s network cert convert server.key --to der -o server.key.der
+
+  # unpack a PKCS#7 chain to PEM
+  %[1]s network cert convert chain.p7b --to pem -o chain.pem
+
+  # show the content without converting
+  %[1]s network cert convert chain.p7b --info`,
+	"long:network cert csr": `Generate a private key and a certificate signing request (CSR), to submit to an external CA or to sign with
+the cert sign command.
+
+Examples:
+  # CSR and private key for a domain
+  %[1]s network cert csr example.com
+
+  # add DNS names and IP addresses
+  %[1]s network cert csr example.com --dns www.example.com --ip 10.0.0.5
+
+  # ECDSA key with organization details
+  %[1]s network cert csr example.com --key-type ecdsa-p256 --org "Example Inc" --country CN`,
+	"long:network cert export-p12": `Pack a PEM certificate and private key into a PKCS#12 (.pfx/.p12) file, for Windows/IIS, Java keystores and
+other places that need PKCS#12.
+
+If the certificate file contains a full chain, the other certificates are included too.
+Without --password you are prompted for one.
+
+Examples:
+  %[1]s network cert export-p12 --cert server.crt --key server.key -o server.pfx
+  %[1]s network cert export-p12 --cert server.crt --key server.key --ca ca.crt -o server.pfx --password secret
+  %[1]s network cert export-p12 --cert server.crt --key server.key -o server.pfx --legacy`,
+	"long:network cert generate": `Generate a self-signed certificate for development and testing.
+
+The certificate details are asked for interactively; press Enter to accept a default when unsure.
+
+Examples:
+  # start the interactive wizard
+  %[1]s network cert generate
+
+  # start the wizard for a domain
+  %[1]s network cert generate example.com
+
+  # use all defaults (not recommended)
+  %[1]s network cert generate example.com --no-interactive
+
+  # ECDSA P-256 certificate
+  %[1]s network cert generate example.com --key-type ecdsa-p256 --no-interactive`,
+	"long:network cert import-p12": `Split a PKCS#12 (.pfx/.p12) file into a PEM certificate and private key.
+CA certificates are appended to the certificate file by default to form a full chain.
+Without --password you are prompted for one.
+
+Examples:
+  %[1]s network cert import-p12 server.pfx
+  %[1]s network cert import-p12 server.pfx --cert server.crt --key server.key --password secret
+  %[1]s network cert import-p12 server.pfx --no-chain`,
+	"long:network cert sign": `Sign a certificate signing request (CSR) with the given CA certificate and private key.
+
+Certificate profiles (--profile):
+  server  server certificate (default)
+  client  client certificate
+  both    server and client authentication
+  ca      intermediate CA certificate
+
+Examples:
+  # issue a server certificate
+  %[1]s network cert sign example.com.csr --ca-cert ca.crt --ca-key ca.key
+
+  # issue a client certificate valid for 90 days
+  %[1]s network cert sign client.csr --ca-cert ca.crt --ca-key ca.key --profile client --days 90
+
+  # issue an intermediate CA certificate
+  %[1]s network cert sign intermediate.csr --ca-cert root.crt --ca-key root.key --profile ca -o intermediate.crt`,
+	"long:network cert watch": `Check the expiry of many certificate files and remote hosts and print a report sorted by days left.
+
+The command exits with a non-zero status when any certificate is below the warning threshold, has expired or
+could not be checked, which suits CI and cron.
+
+Targets file (YAML) format:
+  threshold: 30
+  targets:
+    - example.com:443
+    - /etc/ssl/certs/server.crt
+    - name: internal API
+      address: 10.0.0.5:8443
+      sni: api.internal
+      ca_bundle: [ca/root.crt]
+      strict_ca: true
+
+It can also be just a list of targets:
+  - example.com:443
+  - /etc/ssl/certs/server.crt
+
+Examples:
+  %[1]s network cert watch --targets targets.yaml
+  %[1]s network cert watch --targets targets.yaml --threshold 14
+  %[1]s network cert watch --targets targets.yaml --json`,
+	"long:network dns": `Look up the DNS records of a domain.
+
+The record type can be given, such as A/AAAA (IP), MX, NS or TXT; A and AAAA records (IP addresses) are
+looked up by default.
+
+The DNS server to query can be given as IP:port, such as 8.8.8.8:53; without it, the system resolver is
+used.
+
+Examples:
+  %[1]s network dns example.com
+  %[1]s network dns example.com --type mx
+  %[1]s network dns example.com --type ns
+  %[1]s network dns example.com --dns-server 8.8.8.8
+  %[1]s network dns example.com --dns-server 8.8.8.8:53 --type all`,
+	"long:network forward": `Listen on a local port and forward connections to a target address, like a simple socat.
+
+TCP and UDP are supported, with a limit on concurrent connections and an idle timeout. Every connection is
+logged when it opens and closes, unless the global --quiet is given.
+The idle timeout counts traffic in both directions, so a one-way download or upload is not interrupted;
+after one side closes its write half, the other direction can keep transferring.
+UDP has no connection state: without --idle-timeout a UDP session is reclaimed after 60 seconds of inactivity,
+and later packets from the same client start a new session.
+Press Ctrl+C to stop forwarding.
+
+Examples:
+  %[1]s network forward --listen :8080 --target 10.0.0.5:80
+  %[1]s network forward --listen :5353 --target 8.8.8.8:53 --protocol udp
+  %[1]s network forward -l 127.0.0.1:3306 -T db.internal:3306 --max-conn 20 --idle-timeout 5m
+  %[1]s network forward -l :9000 -T 10.0.0.5:9000 --protocol both`,
+	"long:network http3": `Check whether an endpoint supports HTTP/3 (QUIC).
+
+The command:
+1. sends an HTTP/2 request over TCP and reads the h3 advertisement in the Alt-Svc header
+2. attempts a QUIC handshake and reports the negotiated QUIC version and ALPN
+3. sends a request over HTTP/3 and compares its latency with HTTP/2 over TCP
+
+Examples:
+  %[1]s network http3 https://cloudflare.com
+  %[1]s network http3 www.google.com
+  %[1]s network http3 https://localhost:8443 --insecure`,
+	"long:network ipinfo": `Look up the geolocation and related information of an IP address.
+
+Without an IP address, the public IP of this machine is looked up.
+The command uses the ipinfo.io API.
+
+Examples:
+  %[1]s network ipinfo
+  %[1]s network ipinfo 8.8.8.8`,
+	"long:network mtu": `Find the path MTU to a host with a binary search of ICMP probes that have the DF (don't fragment) bit set.
+
+The output includes the path MTU, the router that started requiring fragmentation, and the tunnel MTU and
+TCP MSS recommended for common VPN and tunnel setups. The command needs root or the CAP_NET_RAW capability.
+
+Examples:
+  %[1]s network mtu example.com
+  %[1]s network mtu 8.8.8.8 --max 9000
+  %[1]s network mtu 10.0.0.1 --min 1200 --max 1500 --timeout 1s --retries 2`,
+	"long:network ping": `Ping a host to check connectivity and measure latency.
+The command sends ICMP echo requests to the host and shows the results.
+
+Examples:
+  %[1]s network ping example.com
+  %[1]s network ping 8.8.8.8 --count 10
+  %[1]s network ping example.com --interval 2`,
+	"long:network portscan": `Scan the ports of a host to find open ports and services.
+
+A port range can be given, or only common ports scanned; a comma separated set of ports that are not
+contiguous can be scanned as well.
+
+Examples:
+  %[1]s network portscan example.com
+  %[1]s network portscan example.com --start-port 80 --end-port 100
+  %[1]s network portscan example.com --common-ports
+  %[1]s network portscan example.com --ports 22,80,443,3306,8080`,
+	"long:network sniff": `Capture and analyze packets, like tcpdump.
+The command captures packets on a network interface and shows them according to the filter.
+Captures can be saved as pcap files for Wireshark and other tools.
+
+Examples:
+  %[1]s network sniff eth0
+  %[1]s network sniff eth0 --filter "tcp and port 80"
+  %[1]s network sniff eth0 --output capture.txt
+  %[1]s network sniff eth0 --pcap capture.pcap
+  %[1]s network sniff --list-interfaces`,
+	"long:network speedtest": `Run a network speed test measuring download speed, upload speed and latency.
+
+The command has two modes:
+1. server mode: start a local test server
+2. test mode: run the speed test
+
+In test mode, the local test server (http://localhost:8080 by default) must be running.
+
+Examples:
+  # start the local test server
+  %[1]s network speedtest --server --port 8080
+
+  # run the speed test
+  %[1]s network speedtest`,
+	"long:network ssh": `SSH key tools for generating SSH keys and computing public key fingerprints.
+
+Features:
+1. generate ed25519, RSA or ECDSA key pairs, optionally protected by a passphrase
+2. compute MD5/SHA256 fingerprints of public keys, authorized_keys and known_hosts
+3. scan the public keys of a remote host and generate known_hosts entries`,
+	"long:network ssh fingerprint": `Compute the MD5 or SHA256 fingerprint of SSH public keys.
+Single public key files, authorized_keys, known_hosts and unencrypted private key files are supported.
+
+With --scan, connect to a remote host to fetch its host keys (like ssh-keyscan) and print entries that can
+be added to known_hosts.
+
+Examples:
+  %[1]s network ssh fingerprint ~/.ssh/id_ed25519.pub
+  %[1]s network ssh fingerprint ~/.ssh/authorized_keys --hash md5
+  %[1]s network ssh fingerprint --scan github.com
+  %[1]s network ssh fingerprint --scan 10.0.0.5:2222 --known-hosts >> ~/.ssh/known_hosts`,
+	"long:network ssh keygen": `Generate an SSH key pair in OpenSSH format, writing the private key to the given file and the public key to
+the file of the same name with .pub. Without --passphrase you are prompted for one; press Enter for no
+encryption.
+
+Examples:
+  %[1]s network ssh keygen
+  %[1]s network ssh keygen -t rsa -b 4096 -f ~/.ssh/id_rsa_work -C work@example.com
+  %[1]s network ssh keygen -t ecdsa -b 384 --passphrase ""`,
+	"long:network traceroute": `Trace the route packets take from this machine to a host.
+
+Every hop on the way is shown with its IP address, host name and latency.
+
+--paris enables Paris traceroute: all probes keep the same flow identifier, so per-flow load balancing cannot
+send the probes for different hops along different paths and scramble the result.
+--flows N probes with N different flow identifiers to enumerate the alternative paths behind load balancers.
+
+Examples:
+  %[1]s network traceroute example.com
+  %[1]s network traceroute 8.8.8.8 --max-hops 20
+  %[1]s network traceroute 8.8.8.8 --paris
+  %[1]s network traceroute 8.8.8.8 --paris --flows 8`,
+	"long:process children": `List all children of the process with the given PID.
+
+Examples:
+  %[1]s process children 1234     # list the children of PID 1234`,
+	"long:process info": `Show details of the process with the given PID, including CPU usage, memory usage and start time.
+
+Examples:
+  %[1]s process info 1234     # show details of PID 1234`,
+	"long:process kill": `Kill the process with the given PID, trying a graceful termination first and forcing it if that fails.
+
+Examples:
+  %[1]s process kill 1234              # kill PID 1234
+  %[1]s process kill 1234 --dry-run    # only show the process that would be killed`,
+	"long:process list": `List the processes on the system, with filtering and sorting.
+
+Examples:
+  %[1]s process list                # list all processes
+  %[1]s process list --filter chrome  # list processes whose name contains 'chrome'
+  %[1]s process list --sort cpu     # sort by CPU usage
+  %[1]s process list --sort memory  # sort by memory usage
+  %[1]s process list --show-system  # show system processes
+  %[1]s process list --no-empty     # hide processes without a name
+  %[1]s process list --full-cmd     # show the full command line
+  %[1]s process list --sort cpu --top 10 --host web1,web2  # top CPU processes on remote hosts`,
+	"long:process tree": `Show processes and their children as a tree.
+With a PID, show the tree of that process and its children; without one, show the tree of all processes.
+
+Examples:
+  %[1]s process tree       # tree of all processes
+  %[1]s process tree 1234  # tree of PID 1234 and its children`,
+	"long:regex explain": `Parse a regular expression and list its parts level by level with their meaning, such as character classes,
+repetitions, capture groups and alternations.
+
+The pattern shown is the parsed canonical form, e.g. \d is shown as [0-9] and alternatives with a common
+prefix may be merged.
+
+Examples:
+  %[1]s regex explain '\d{4}-\d{2}'
+  %[1]s regex explain '^(?P<user>[\w.]+)@([a-z0-9-]+\.)+[a-z]{2,}$'
+  %[1]s regex explain -i 'error|warn(ing)?'`,
+	"long:regex test": `Find all matches of a regular expression in text and show the line, column, matched text and capture groups
+of each match.
+
+The text can come from arguments (several arguments are joined as lines), the file given with --input or
+standard input. Matching runs over the whole text, so patterns can span lines; use -m to make ^ and $ match
+at every line. Exits with code 1 when nothing matches.
+
+Examples:
+  %[1]s regex test '\d{4}-\d{2}' --input app.log
+  %[1]s regex test '(?P<key>\w+)=(?P<value>[^&]*)' 'a=1&b=2&c='
+  %[1]s regex test -i '^error' -m --input app.log -n 20
+  cat access.log | %[1]s regex test '" (\d{3}) ' --output json`,
+	"long:service logs": `Show the recent logs of a service, read from the systemd journal (journalctl) on Linux; Ctrl+C ends
+--follow. Reading the logs of other users' services usually needs root or membership in the systemd-journal
+group. Windows services have no common log; use the Event Viewer instead.
+
+Examples:
+  %[1]s service logs nginx
+  %[1]s service logs nginx -n 200 --since 1h
+  %[1]s service logs nginx -f`,
+	"long:service restart": `Restart a service, or start it if it is not running, and show its status afterwards. Usually needs root or
+administrator privileges.
+
+Examples:
+  sudo %[1]s service restart nginx
+  %[1]s service restart nginx --dry-run`,
+	"long:service start": `Start a service and show its status afterwards. Usually needs root or administrator privileges.
+
+Examples:
+  sudo %[1]s service start nginx
+  %[1]s service start nginx --dry-run`,
+	"long:service status": `Show the state, startup type, main PID, time since the current state and memory usage of services.
+Exits with code 1 when any service is not running and with code 3 when a service does not exist.
+
+Examples:
+  %[1]s service status nginx
+  %[1]s service status nginx sshd docker --output json`,
+	"long:service stop": `Stop a service and show its status afterwards. Usually needs root or administrator privileges.
+On Windows, waits up to 30 seconds for the service to stop.
+
+Examples:
+  sudo %[1]s service stop nginx
+  %[1]s service stop nginx --dry-run`,
+	"long:text filter": `Filter lines of text by conditions, like awk.
+
+Basic conditional expressions and field selection are supported.
+
+Examples:
+  %[1]s text filter '$1 > 100' data.txt               # lines whose first field is greater than 100
+  %[1]s text filter -F, '$2 == "ERROR"' log.csv       # comma separated, lines whose second field is ERROR
+  %[1]s text filter 'length($0) > 80' file.txt        # lines longer than 80 characters
+  cat file.txt | %[1]s text filter '$3 ~ /pattern/'   # lines whose third field matches a regular expression
+  %[1]s text filter -p '${1} ${3}' data.txt           # only print the first and third fields`,
+	"long:text grep": `Search files or standard input for lines matching a pattern.
+
+Regular expressions are supported, with highlighting of matches, counting matching lines and more.
+
+Examples:
+  %[1]s text grep "error" log.txt           # lines in log.txt containing "error"
+  %[1]s text grep "^[0-9]+" file.txt        # lines starting with digits, using a regular expression
+  cat file.txt | %[1]s text grep "pattern"  # search standard input
+  %[1]s text grep -n "pattern" file.txt     # show line numbers
+  %[1]s text grep -i "pattern" file.txt     # ignore case
+  %[1]s text grep -r "pattern" ./src        # search a directory recursively
+  %[1]s text grep -r -f "*.go" "func" ./src # search go files in a directory recursively`,
+	"long:text replace": `Find and replace text in files or standard input.
+
+Regular expressions and references to capture groups are supported.
+
+Examples:
+  %[1]s text replace "old" "new" file.txt                # replace "old" with "new" in file.txt
+  %[1]s text replace "User-(\\d+)" "ID-$1" users.txt     # regular expression with a reference
+  cat file.txt | %[1]s text replace "pattern" "new" -    # replace standard input to standard output
+  %[1]s text replace -i "error" "warning" log.txt        # ignore case
+  %[1]s text replace -g "pattern" "new" file.txt         # replace all occurrences on each line
+  %[1]s text replace -I "old" "new" *.txt --dry-run      # preview which files an in-place replace would change`,
+	"long:time convert": `Recognize Unix timestamps or the time formats common in logs and print them in several formats and time
+zones.
+
+The unit of a timestamp is guessed from its digits by default: up to 11 digits are seconds, 12-14
+milliseconds, 15-17 microseconds and longer nanoseconds, and decimals are seconds; use --unit to set it.
+Supported formats include RFC3339, 2006-01-02 15:04:05 (with optional milliseconds and time zone),
+2006-01-02, RFC1123, RFC822, ANSIC, 02/Jan/2006:15:04:05 -0700 from Apache logs and Jan 2 15:04:05 from
+syslog (using the current year).
+
+Times without a zone are interpreted in the --tz zone (local by default); the result is shown in the first
+--to zone, and the other zones are listed too. A zone can be local, UTC, an IANA name (Asia/Shanghai) or a
+fixed offset (+08:00).
+
+Examples:
+  %[1]s time convert 1700000000
+  %[1]s time convert 1700000000123 --to UTC
+  %[1]s time convert "2024-03-01 12:00:00" --tz Asia/Shanghai --to UTC,America/New_York
+  %[1]s time convert "10/Oct/2023:13:55:36 -0700" --to local
+  %[1]s time convert 1700000000 --unit ms`,
+	"long:time duration": `Parse human-readable durations such as 1h30m, 1.5d, 2w3d and 300ms, and print the normalized form, seconds
+and milliseconds. The units are ms, s, m, h, d and w; plain numbers use the unit given with --unit (seconds
+by default).
+
+Examples:
+  %[1]s time duration 1h30m
+  %[1]s time duration 90 5400000 --unit ms
+  %[1]s time duration "2d 4h" --output json`,
+	"long:time now": `Show the current time as RFC3339, Unix seconds, milliseconds, microseconds and nanoseconds, RFC1123 and
+other formats; --to also shows the time in other time zones.
+
+Examples:
+  %[1]s time now
+  %[1]s time now --to UTC,America/New_York,+05:30
+  %[1]s time now --output json`,
+}
@@ -0,0 +1,135 @@
+// Package i18n 提供简单的消息翻译
+//
+// 消息以中文原文作为键（gettext风格），未找到翻译时原样返回，
+// 因此未翻译的消息始终可以正常显示。
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// 支持的语言
+const (
+	LangZH = "zh" // 中文（默认）
+	LangEN = "en" // 英文
+)
+
+var (
+	mu       sync.RWMutex
+	current  = LangZH
+	catalogs = map[string]map[string]string{}
+)
+
+// Register 注册指定语言的消息目录，键为中文原文
+func Register(lang string, catalog map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if catalogs[lang] == nil {
+		catalogs[lang] = make(map[string]string, len(catalog))
+	}
+	for key, value := range catalog {
+		catalogs[lang][key] = value
+	}
+}
+
+// ParseLanguage 将语言名称或区域设置（如 en_US.UTF-8）规范化为支持的语言
+func ParseLanguage(name string) (string, error) {
+	lang := strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(lang, "_.-@"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "zh", "cn", "chinese":
+		return LangZH, nil
+	case "en", "english":
+		return LangEN, nil
+	default:
+		return "", Errorf("不支持的语言: %s（可选: zh, en）", name)
+	}
+}
+
+// SetLanguage 设置当前语言
+func SetLanguage(name string) error {
+	lang, err := ParseLanguage(name)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	current = lang
+	mu.Unlock()
+	return nil
+}
+
+// Language 返回当前语言
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// FromEnv 根据 LC_ALL、LC_MESSAGES、LANG 环境变量推断语言
+//
+// 未设置或为 C/POSIX 时返回中文；设置为其他不支持的语言时返回英文。
+func FromEnv() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		if value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+			return LangZH
+		}
+		if lang, err := ParseLanguage(value); err == nil {
+			return lang
+		}
+		return LangEN
+	}
+	return LangZH
+}
+
+// Lookup 查找当前语言的翻译，不存在时返回false
+func Lookup(key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == LangZH {
+		return "", false
+	}
+	value, ok := catalogs[current][key]
+	return value, ok
+}
+
+// T 翻译消息，不存在翻译时返回原文
+func T(msg string) string {
+	if value, ok := Lookup(msg); ok {
+		return value
+	}
+	return msg
+}
+
+// Tf 翻译格式字符串后格式化
+func Tf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf 翻译格式字符串后创建错误
+func Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// messageError 输出时才翻译的错误
+type messageError struct {
+	msg string
+}
+
+func (e *messageError) Error() string {
+	return T(e.msg)
+}
+
+// NewError 创建在输出时才按当前界面语言翻译的错误，用于包级别的错误变量：
+// 这些变量在确定界面语言之前就已初始化，不能在创建时翻译
+func NewError(msg string) error {
+	return &messageError{msg: msg}
+}
@@ -0,0 +1,1640 @@
+package i18n
+
+func init() {
+	Register(LangEN, messagesEN)
+}
+
+// messagesEN 运行时输出的英文消息目录：提示信息、表头、错误信息和 Use 中的参数说明
+//
+// 与 catalogEN 一样以中文原文为键，格式字符串的翻译需要保留相同的格式化动词，
+// 语序不同时使用 %[n]d 这样的显式参数索引。cmd/cli/cmd 中的 TestMessagesTranslated 会检查遗漏。
+var messagesEN = map[string]string{
+	// 剪贴板
+	"[文本...]": "[text...]",
+	"--paste 不能与文本参数同时使用":   "--paste cannot be used with text arguments",
+	"请通过管道输入要复制的内容，或直接指定文本": "Pipe the content to copy, or pass it as arguments",
+	"读取标准输入失败: %v":          "failed to read standard input: %v",
+	"已复制 %d 个字符到剪贴板\n":      "Copied %d characters to the clipboard\n",
+	"%s 不支持 --clipboard":    "%s does not support --clipboard",
+	"创建管道失败: %v":            "failed to create pipe: %v",
+	"已复制输出到剪贴板":             "Copied the output to the clipboard",
+
+	// 进制、单位和标志位转换
+	"<整数...>": "<integer...>",
+	"无效的进制: %d，应为2到36之间的整数": "invalid base: %d, must be an integer between 2 and 36",
+	"二进制":            "Binary",
+	"位数":             "Bits",
+	"八进制":            "Octal",
+	"十六进制":           "Hex",
+	"十进制":            "Decimal",
+	"字符":             "Char",
+	"输入":             "Input",
+	"<数值> [to <单位>]": "<value> [to <unit>]",
+	"单位":             "Unit",
+	"数值":             "Value",
+	"其他写法":           "Aliases",
+	"换算系数":           "Factor",
+	"类别":             "Category",
+	"数据大小":           "Data size",
+	"时长":             "Duration",
+	"传输速率":           "Transfer rate",
+	"<值>":            "<value>",
+	"(无)":            "(none)",
+	"%s  十进制 %d，十六进制 %s，八进制 %s\n": "%s  decimal %d, hex %s, octal %s\n",
+	"值":          "Value",
+	"已设置":        "Set",
+	"标志":         "Flag",
+	"说明":         "Description",
+	"否":          "no",
+	"是":          "yes",
+	"未知的位: %s\n": "Unknown bits: %s\n",
+
+	// cron 表达式
+	"周一":            "Mon",
+	"周三":            "Wed",
+	"周二":            "Tue",
+	"周五":            "Fri",
+	"周六":            "Sat",
+	"周四":            "Thu",
+	"周日":            "Sun",
+	"<表达式>":         "<expression>",
+	"--count 不能为负数": "--count must not be negative",
+	"该表达式永远不会执行，检查日期和月份是否存在（如2月30日）": "the expression never fires, check that the day exists in the month (e.g. February 30)",
+	"接下来的执行时间（%s）:\n":                "Next run times (%s):\n",
+	"时间":                             "Time",
+	"星期":                             "Weekday",
+	"距现在":                            "From now",
+	"后":                              " from now",
+	"前":                              " ago",
+	"%d天%d小时%s":                      "%dd %dh%s",
+	"%d小时%d分钟%s":                     "%dh %dm%s",
+	"%d分钟%s":                         "%dm%s",
+	"不到1分钟":                          "less than a minute",
+
+	// 文件加密
+	"[文件...]": "[file...]",
+	"请指定文件或通过管道输入内容":    "Specify files or pipe the content",
+	"--out 只能用于单个输入文件":  "--out can only be used with a single input file",
+	"输出文件不能与输入文件相同: %s": "output file must differ from the input file: %s",
+	"处理 %s 失败: %v":      "failed to process %s: %v",
+	"删除源文件失败: %v":       "failed to remove the source file: %v",
+	"大小":                "Size",
+	"耗时":                "Elapsed",
+	"输出":                "Output",
+	"%s 是目录":            "%s is a directory",
+	"输出文件已存在: %s（使用 --force 覆盖）": "output file already exists: %s (use --force to overwrite)",
+	"口令不能为空": "passphrase must not be empty",
+	"非交互模式下必须使用 --passphrase 指定口令": "--passphrase is required in non-interactive mode",
+	"请输入口令: ":    "Enter passphrase: ",
+	"读取口令失败: %v": "failed to read passphrase: %v",
+	"请再次输入口令: ":  "Enter passphrase again: ",
+	"两次输入的口令不一致": "passphrases do not match",
+
+	// 磁盘健康
+	"[设备...]":    "[device...]",
+	"没有找到可检查的磁盘": "no disks found to check",
+	"健康":         "Health",
+	"型号":         "Model",
+	"容量":         "Capacity",
+	"温度":         "Temperature",
+	"类型":         "Type",
+	"设备":         "Device",
+	"通电时间":       "Power-on time",
+	"未知":         "unknown",
+	"读取失败":       "read failed",
+	"通过":         "PASSED",
+	"未通过":        "FAILED",
+	"未找到smartctl，只显示了从 /sys/block 读取的信息，安装 smartmontools 后可查看完整的SMART信息": "smartctl not found, only showing information from /sys/block; install smartmontools for full SMART data",
+	"原始值":              "Raw",
+	"属性":               "Attribute",
+	"当前值":              "Value",
+	"最差值":              "Worst",
+	"阈值":               "Threshold",
+	"  严重警告: 0x%02x\n": "  Critical warning: 0x%02x\n",
+	"  备用空间: %d%%（阈值 %d%%）\n": "  Available spare: %d%% (threshold %d%%)\n",
+	"  已用寿命: %d%%\n":          "  Percentage used: %d%%\n",
+	"  介质错误: %d\n":            "  Media errors: %d\n",
+	"  异常断电: %d\n":            "  Unsafe shutdowns: %d\n",
+	"%d小时":                    "%d hours",
+	"%d小时（%d天）":               "%d hours (%d days)",
+
+	// Docker
+	"<容器>": "<container>",
+	"进程 %d 不在任何运行中的容器内": "process %d is not in any running container",
+	"没有找到容器\n":          "No containers found\n",
+	"名称":                "Name",
+	"容器ID":              "Container ID",
+	"状态":                "Status",
+	"端口":                "Ports",
+	"进程":                "Process",
+	"镜像":                "Image",
+	"内存":                "Memory",
+	"命令":                "Command",
+	"[容器...]":           "[container...]",
+	"无效的排序方式: %s（可选: name, cpu, memory）": "invalid sort order: %s (choices: name, cpu, memory)",
+	"没有运行中的容器\n":                         "No running containers\n",
+	"容器 %s 不存在或未运行":                      "container %s does not exist or is not running",
+	"内存%":                                "Mem%",
+	"磁盘 读/写":                             "Disk R/W",
+	"网络 收/发":                             "Net RX/TX",
+	"进程数":                                "PIDs",
+
+	// 预演模式
+	"创建": "Create",
+	"修改": "Modify",
+	"删除": "Delete",
+	"终止": "Kill",
+	"启动": "Start",
+	"停止": "Stop",
+	"重启": "Restart",
+	"预演模式，以下操作不会实际执行：": "Dry run, the following actions will not be performed:",
+	"  没有需要执行的操作":      "  Nothing to do",
+	"对象":               "Target",
+	"操作":               "Action",
+	"\n共 %d 项操作，去掉 --dry-run 后执行\n": "\n%d actions in total, run without --dry-run to apply\n",
+
+	// 编码
+	"识别为 %s 编码\n":                      "Detected %s encoding\n",
+	"编码时不能使用 auto，请指定格式":               "auto cannot be used for encoding, specify a format",
+	"--string 不能与文件参数同时使用":             "--string cannot be used with file arguments",
+	"请指定文件、通过管道输入内容，或使用 --string 指定文本": "Specify a file, pipe the content, or pass text with --string",
+	"读取文件 %s 失败: %v":                   "failed to read file %s: %v",
+	"自动识别":                             "detect automatically",
+
+	// 环境变量
+	"<.env文件>": "<.env file>",
+	"\n%d 个相同，%d 个不同，%d 个缺失": "\n%d same, %d different, %d missing",
+	"，%d 个只在当前环境中":           ", %d only in the current environment",
+	"读取 %s 失败: %v":           "failed to read %s: %v",
+	"    当前: %s\n":           "    current: %s\n",
+	"    文件: %s\n":           "    file:    %s\n",
+	"  当前环境中未设置，文件中为 %s\n":   "  not set in the current environment, %s in the file\n",
+	"  只在当前环境中，取值为 %s\n":     "  only in the current environment, value %s\n",
+	"[模式]": "[pattern]",
+	"不支持的导出格式: %s（可选: dotenv, json）":           "unsupported export format: %s (choices: dotenv, json)",
+	"已遮盖 %d 个敏感变量的取值，使用 --show-secrets 导出原值\n": "Masked the values of %d sensitive variables, use --show-secrets to export them\n",
+	"写入 %s 失败: %v":      "failed to write %s: %v",
+	"已导出 %d 个变量到 %s\n":  "Exported %d variables to %s\n",
+	"没有匹配 '%s' 的环境变量\n": "No environment variables match '%s'\n",
+	"\n已遮盖 %d 个敏感变量的取值，使用 --show-secrets 显示原值\n": "\nMasked the values of %d sensitive variables, use --show-secrets to show them\n",
+	"[变量名...]":    "[name...]",
+	"环境变量 %s 未设置": "environment variable %s is not set",
+	"%s（%d 项）\n":  "%s (%d entries)\n",
+	"目录":          "Directory",
+	"问题":          "Problem",
+	"没有发现问题":      "No problems found",
+	"%d 项有问题":     "%d entries have problems",
+	"\n被覆盖的命令（%d 个，只会执行第一个）:\n": "\nShadowed commands (%d, only the first one runs):\n",
+	"覆盖 ":       "shadows ",
+	"与第 %d 项重复": "duplicate of entry %d",
+	"不存在":       "does not exist",
+	"不是目录":      "not a directory",
+	"相对路径":      "relative path",
+	"空项（当前目录）":  "empty entry (current directory)",
+
+	// 批量执行
+	"--targets <文件> [选项] -- <子命令> [参数...]": "--targets <file> [options] -- <subcommand> [args...]",
+	"并发数必须大于0":                             "concurrency must be greater than 0",
+	"不能嵌套执行 map 命令":                        "map commands cannot be nested",
+	"请使用 --targets 或 --target 指定目标":        "Specify targets with --targets or --target",
+	"获取程序路径失败: %v":                         "failed to get the executable path: %v",
+	"成功":                                   "OK",
+	"失败: %s":                               "failed: %s",
+	"执行汇总":                                 "Summary",
+	"序号":                                   "#",
+	"目标":                                   "Target",
+	"退出码":                                  "Exit code",
+	"超时":                                   "timeout",
+	"失败":                                   "failed",
+	"\n共 %d 个目标：成功 %d，失败 %d，总耗时 %s\n": "\n%d targets: %d succeeded, %d failed, total time %s\n",
+	"时长不能为负数: %s":                     "duration must not be negative: %s",
+
+	// 格式化
+	"[文件路径|文本内容]":                                  "[file|text]",
+	"使用 --string 选项时必须提供文本内容":                      "--string requires text content",
+	"已从分隔符 '%s' 中提取内容\n":                           "Extracted the content between '%s' delimiters\n",
+	"警告: 未找到使用分隔符 '%s' 包围的内容\n":                    "Warning: no content enclosed in '%s' delimiters found\n",
+	"处理文本内容时必须使用 --format 指定格式":                    "--format is required when formatting text",
+	"必须指定数据文件路径或使用 --string 选项":                    "Specify a data file or use --string",
+	"无法从文件名推断格式，请使用 --format 指定格式":                 "cannot infer the format from the file name, specify it with --format",
+	"格式化文件: %s\n":                                  "Formatting file: %s\n",
+	"格式化失败: %v":                                    "formatting failed: %v",
+	"格式化文本内容":                                      "Formatting text",
+	"处理前的内容: %s\n":                                 "Input before processing: %s\n",
+	"提示: 您的输入似乎是未正确格式化的JSON。请确保：":                  "Hint: the input looks like malformed JSON. Make sure that:",
+	"1. 所有的键名和字符串值都使用双引号":                          "1. All keys and string values use double quotes",
+	"2. Windows PowerShell中使用双引号包裹JSON字符串，并转义内部引号": "2. In Windows PowerShell, wrap the JSON in double quotes and escape the inner quotes",
+	"例如: '{\"name\":\"值\",\"array\":[1,2,3]}'":     "For example: '{\"name\":\"value\",\"array\":[1,2,3]}'",
+	"或使用 PowerShell 的 @\"...\"@ 语法避免转义：":           "Or use the PowerShell @\"...\"@ syntax to avoid escaping:",
+	"{\"name\":\"值\",\"array\":[1,2,3]}":           "{\"name\":\"value\",\"array\":[1,2,3]}",
+	"请检查输入格式是否正确，特别是JSON中的引号、括号和逗号。":               "Check the input, especially the quotes, brackets and commas in the JSON.",
+	"模式: 美化":                 "Mode: pretty",
+	"模式: 压缩":                 "Mode: compact",
+	"模式: 标准":                 "Mode: standard",
+	"保存结果失败: %v":             "failed to save the result: %v",
+	"已保存到: %s (大小: %d 字节)\n": "Saved to: %s (size: %d bytes)\n",
+	"\n------ 格式化结果 ------":  "\n------ Result ------",
+	"\n------ 结果统计 ------\n": "\n------ Statistics ------\n",
+	"输入大小: %d 字节\n":          "Input size: %d bytes\n",
+	"输出大小: %d 字节\n":          "Output size: %d bytes\n",
+	"处理耗时: %s\n":             "Elapsed: %s\n",
+
+	// 文件工具：压缩包、校验和、压缩
+	"<压缩文件>":           "<archive>",
+	"读取压缩文件失败: %v":     "failed to read the archive: %v",
+	"<源压缩文件> <目标压缩文件>": "<source archive> <target archive>",
+	"转换":               "convert",
+	"转换已取消":            "conversion canceled",
+	"修改时间":             "Modified",
+	"压缩后":              "Compressed",
+	"权限":               "Mode",
+	"\n共 %d 个文件，%d 个目录，解压后 %s\n": "\n%d files, %d directories, %s uncompressed\n",
+	"[目录路径]":                   "[directory]",
+	"计算校验和":                    "checksum",
+	"已取消，没有写入清单":               "canceled, no manifest written",
+	"已将 %d 个文件（%s）的校验和写入 %s\n": "Wrote checksums of %d files (%s) to %s\n",
+	"%d 个文件或目录无法读取，没有写入清单":     "%d files or directories could not be read, no manifest written",
+	"校验":           "verify",
+	"校验已取消":        "verification canceled",
+	"警告: %s: %v\n": "Warning: %s: %v\n",
+	"不一致":          "MISMATCH",
+	"无法读取":         "UNREADABLE",
+	"新增":           "NEW",
+	"清单中 %d 个文件，%d 个一致，%d 个不一致，%d 个不存在，%d 个无法读取；新增 %d 个文件": "%d files in the manifest: %d match, %d mismatched, %d missing, %d unreadable; %d new files",
+	"[源文件/目录] [目标路径]":       "[source] [target]",
+	"校验模式只需要指定压缩文件":         "verify mode takes only the archive",
+	"需要指定源路径和目标路径":          "source and target paths are required",
+	"解压":                    "extract",
+	"解压缩":                   "decompress",
+	"%s失败: %v\n":            "failed to %s: %v\n",
+	"跳过可疑路径: %s\n":          "Skipping suspicious path: %s\n",
+	"%s已取消":                 "%s canceled",
+	"%d 个文件%s失败":            "failed to %[2]s %[1]d files",
+	"校验通过: %d 个文件，解压后 %s\n": "Verified: %d files, %s uncompressed\n",
+	"无法从文件扩展名识别压缩格式，请使用 --type 选项指定压缩格式":                                "cannot detect the format from the file extension, specify it with --type",
+	"不能将压缩数据写入终端，请重定向标准输出":                                              "refusing to write compressed data to a terminal, redirect standard output",
+	"无法访问源文件/目录: %v":                                                    "cannot access the source: %v",
+	"%s 格式不支持压缩目录，请使用 zip、tar.gz、tar.bz2、tar.xz、tar.zst、tar.lz4、tar.br": "%s cannot compress directories, use zip, tar.gz, tar.bz2, tar.xz, tar.zst, tar.lz4 or tar.br",
+	"--include 和 --exclude 只能用于压缩目录":                                    "--include and --exclude only apply when compressing directories",
+	"分卷压缩不能写入标准输出":                                                      "split archives cannot be written to standard output",
+	"压缩":                                                                "compress",
+	"压缩已取消":                                                             "compression canceled",
+	"不支持的压缩格式: %s":                                                      "unsupported compression format: %s",
+	"密码不能为空":                                                            "password must not be empty",
+
+	// 磁盘占用、重复文件
+	"--top 和 --depth 不能为负数":  "--top and --depth must not be negative",
+	"分析已取消":                  "analysis canceled",
+	"%s: %s，%d 个文件，%d 个目录\n": "%s: %s, %d files, %d directories\n",
+	"\n最大的目录:":               "\nLargest directories:",
+	"占比":                     "Share",
+	"文件数":                    "Files",
+	"\n最大的文件:":               "\nLargest files:",
+	"文件":                     "File",
+	"\n%d 个目录或文件无法读取，未计入统计\n":  "\n%d directories or files could not be read and were not counted\n",
+	"--link 和 --delete 不能同时使用": "--link and --delete cannot be used together",
+	"查找已取消":                    "search canceled",
+	"%d 个相同的文件，每个 %s，浪费 %s:\n": "%d identical files, %s each, %s wasted:\n",
+	"共 %d 组重复文件，浪费 %s\n":       "%d groups of duplicates, %s wasted\n",
+	"替换为指向 %s 的硬链接":            "replace with a hard link to %s",
+	"与 %s 相同":                  "same as %s",
+	"处理 %s 失败: %v\n":           "Failed to process %s: %v\n",
+	"已释放 %s\n":                 "Freed %s\n",
+	"%d 个文件处理失败":               "%d files failed",
+
+	// 查找文件
+	"--dry-run 只能用于预览 --delete，不能与 --exec 一起使用":                 "--dry-run only previews --delete and cannot be used with --exec",
+	"--delete 不能用于远程主机":                                         "--delete cannot be used on remote hosts",
+	"--watch 不能用于远程主机、--output 和 --delete，需要JSON记录时可以使用 --json": "--watch cannot be used with remote hosts, --output or --delete; use --json for JSON records",
+	"--exec 和 --delete 不能用于远程主机或结构化输出":                          "--exec and --delete cannot be used with remote hosts or structured output",
+	"%d 个文件执行命令失败":                                              "command failed for %d files",
+	"--json 和 --csv 不能同时使用":                                     "--json and --csv cannot be used together",
+	"--json 和 --csv 不能用于远程主机，也不能与 --output 一起使用":                "--json and --csv cannot be used with remote hosts or --output",
+	"--template 和 --print0 不能用于远程主机，也不能与 --output 一起使用":         "--template and --print0 cannot be used with remote hosts or --output",
+	"执行失败: %s: %v\n":                                            "Command failed: %s: %v\n",
+	"--delete 会删除匹配的文件，请先用 --dry-run 预览，确认后加上 --yes 执行":         "--delete removes the matched files; preview with --dry-run first, then add --yes to run it",
+	"将删除 %d 个匹配的文件和目录（可以用 --dry-run 查看列表），是否继续？[y/N]: ":         "Delete %d matched files and directories (use --dry-run to list them)? [y/N]: ",
+	"已取消，没有删除任何文件":                                              "canceled, nothing was deleted",
+	"目录（删除其中的文件后为空时才会删除）":                                       "directory (removed only if empty after its files are deleted)",
+	"按修改时间排序":                                                   "sort by modification time",
+	"按大小排序":                                                     "sort by size",
+	"按文件名排序":                                                    "sort by file name",
+	"按路径排序":                                                     "sort by path",
+
+	// 重命名、分片、同步
+	"<模式> <替换> [目录路径]": "<pattern> <replacement> [directory]",
+	"重命名为 %s":          "rename to %s",
+	"已重命名 %d 个文件\n":    "Renamed %d files\n",
+	"[目录或文件]":          "[directory or file]",
+	"合并 %s 中的分片":       "merge the parts in %s",
+	"合并分片失败: %v":       "failed to merge parts: %v",
+	"分片已合并到：%s\n":      "Parts merged into: %s\n",
+	"分片大小必须大于0":        "part size must be greater than 0",
+	"不支持的压缩格式：%s（支持的格式：zip, tar.gz/tgz, tar.bz2/tbz2, tar.xz/txz）": "unsupported archive format: %s (supported: zip, tar.gz/tgz, tar.bz2/tbz2, tar.xz/txz)",
+	"分片失败: %v":       "split failed: %v",
+	"分片完成，输出目录：%s\n": "Split complete, output directory: %s\n",
+	"将 %d 个文件（%s）打包为 %s，按 %s 分片，最多 %d 片":     "pack %d files (%s) as %s in parts of %s, at most %d parts",
+	"分片完成后删除源目录及其中的 %d 个文件":                  "remove the source directory and its %d files after splitting",
+	"\r\x1b[K删除源目录...":                       "\r\x1b[KRemoving the source directory...",
+	"分片 [%s] %5.1f%% %s / %s，已写入 %s（%d 个分片）": "Splitting [%s] %5.1f%% %s / %s, wrote %s (%d parts)",
+	"，剩余 ":       ", remaining ",
+	"无法访问文件: %v": "cannot access the file: %v",
+	"%s 不是普通文件，--raw 只能切分单个文件":          "%s is not a regular file, --raw only splits a single file",
+	"将 %s（%s）按 %s 切分为 %d 片":             "split %s (%s) into %[4]d parts of %[3]s",
+	"切分完成后删除源文件":                        "remove the source file after splitting",
+	"<源目录> <目标目录>":                      "<source dir> <target dir>",
+	"同步已取消，已同步的文件会保留":                   "sync canceled, files already synced are kept",
+	"新建 %d，更新 %d，删除 %d，未变化 %d，共复制 %s\n": "%d created, %d updated, %d deleted, %d unchanged, %s copied\n",
+	"%d 个文件同步失败":                        "%d files failed to sync",
+	"源目录中没有":                            "not in the source directory",
+	"替换为目录":                             "replace with a directory",
+	"创建目录":                              "create directory",
+	"复制 %s":                             "copy %s",
+	"覆盖 %s":                             "overwrite %s",
+	"--markdown 和 --html 不能同时使用":        "--markdown and --html cannot be used together",
+
+	// HMAC、历史记录、远程主机
+	"必须使用 --key 指定密钥": "--key is required",
+	"密钥不能为空":          "key must not be empty",
+	"无法打开文件 %s: %v":   "cannot open file %s: %v",
+	"签名一致":            "Signature matches",
+	"签名不一致":           "Signature does not match",
+	"历史记录已清空\n":       "History cleared\n",
+	"没有历史记录":          "No history",
+	"<序号|last>":       "<number|last>",
+	"无效的序号: %s":       "invalid number: %s",
+	"第 %d 条命令中的密码、密钥等参数没有记录（显示为 %s），无法重新执行，请直接运行该命令": "passwords, keys and similar arguments of entry %d were not recorded (shown as %s), so it cannot be rerun; run the command directly",
+	"原工作目录 %s 不存在，在当前目录执行\n":                         "Original working directory %s does not exist, running in the current directory\n",
+	"执行命令失败: %v":               "failed to run the command: %v",
+	"%s 不支持 --host（支持的命令: %s）": "%s does not support --host (supported commands: %s)",
+	"错误: %s: %v\n":             "Error: %s: %v\n",
+
+	// ID
+	"只支持生成v4和v7 UUID": "only v4 and v7 UUIDs can be generated",
+	"生成数量必须大于0":       "count must be greater than 0",
+	"生成ID失败: %v":      "failed to generate ID: %v",
+	"时钟序列":            "Clock sequence",
+	"节点":              "Node",
+	"随机部分":            "Random",
+	"UUID形式":          "As UUID",
+	"数据中心/机器":         "Datacenter/worker",
+	"序列号":             "Sequence",
+	"起始时间":            "Epoch",
+	"字段":              "Field",
+	"版本":              "Version",
+	"变体":              "Variant",
+	"%s（%s）":          "%s (%s)",
+
+	// 日志、Markdown、模拟数据
+	"<文件...>":         "<file...>",
+	"无效的正则表达式 %q: %v": "invalid regular expression %q: %v",
+	"[文件]":            "[file]",
+	"不支持的格式: %s（支持 terminal、html）":  "unsupported format: %s (supported: terminal, html)",
+	"渲染HTML失败: %v":                  "failed to render HTML: %v",
+	"写入文件失败: %v":                    "failed to write file: %v",
+	"已写入 %s\n":                      "Wrote %s\n",
+	"未指定输入文件，且无标准输入":                "no input file given and standard input is empty",
+	"读取文件失败: %v":                    "failed to read file: %v",
+	"必须使用 --schema 指定schema文件":      "--schema is required",
+	"--pretty 只能用于 json 和 jsonl 格式": "--pretty only applies to json and jsonl",
+	"创建输出文件失败: %v":                  "failed to create the output file: %v",
+	"写入数据失败: %v":                    "failed to write data: %v",
+	"已生成 %d 条记录: %s\n":              "Generated %d records: %s\n",
+	"选项":                            "Options",
+
+	// 证书
+	"[证书文件|主机:端口]":      "[cert file|host:port]",
+	"检查证书失败: %v":        "failed to check the certificate: %v",
+	"验证证书失败: %v":        "failed to verify the certificate: %v",
+	"证书有效，未发现问题":        "Certificate is valid, no problems found",
+	"发现以下问题：":           "Problems found:",
+	"连接信息：":             "Connection:",
+	"地址: %s\n":          "Address: %s\n",
+	"TLS版本: %s\n":       "TLS version: %s\n",
+	"密码套件: %s\n":        "Cipher suite: %s\n",
+	"主机名匹配: %v\n":       "Hostname matches: %v\n",
+	"\n证书 #%d:\n":       "\nCertificate #%d:\n",
+	"证书信息：":             "Certificate:",
+	"主体: %s\n":          "Subject: %s\n",
+	"颁发者: %s\n":         "Issuer: %s\n",
+	"生效时间: %s\n":        "Not before: %s\n",
+	"过期时间: %s\n":        "Not after: %s\n",
+	"剩余天数: %d\n":        "Days left: %d\n",
+	"序列号: %s\n":         "Serial: %s\n",
+	"签名算法: %s\n":        "Signature algorithm: %s\n",
+	"公钥算法: %s\n":        "Public key algorithm: %s\n",
+	"证书版本: %d\n":        "Version: %d\n",
+	"是否为CA: %v\n":       "Is CA: %v\n",
+	"是否由受信任的CA颁发: %v\n": "Issued by a trusted CA: %v\n",
+	"DNS名称: %s\n":       "DNS names: %s\n",
+	"[名称]":              "[name]",
+	"不支持的密钥类型: %s":      "unsupported key type: %s",
+	"欢迎使用证书生成向导！":       "Welcome to the certificate wizard!",
+	"请回答以下问题，或直接按回车使用默认值。":                                       "Answer the following questions, or press Enter to accept the defaults.",
+	"请输入域名（例如：example.com）":                                      "Domain name (e.g. example.com)",
+	"是否需要通配符证书（可用于所有子域名）":                                        "Issue a wildcard certificate (valid for all subdomains)",
+	"是否添加其他DNS名称":                                                "Add more DNS names",
+	"请输入其他DNS名称，每行一个，留空结束：":                                      "Enter additional DNS names, one per line, empty line to finish:",
+	"是否添加IP地址":                                                   "Add IP addresses",
+	"请输入IP地址，每行一个，留空结束：":                                         "Enter IP addresses, one per line, empty line to finish:",
+	"是否使用默认有效期（10年）":                                             "Use the default validity period (10 years)",
+	"请输入有效期（天数）":                                                 "Validity period (days)",
+	"请输入大于0的数字！":                                                 "Please enter a number greater than 0!",
+	"是否使用默认密钥类型（RSA 2048位）":                                      "Use the default key type (RSA 2048-bit)",
+	"请输入密钥类型（rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519）": "Key type (rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519)",
+	"不支持的密钥类型！":                                                  "Unsupported key type!",
+	"请输入证书文件名":                                                   "Certificate file name",
+	"请输入私钥文件名":                                                   "Private key file name",
+	"创建证书目录失败: %v":                                               "failed to create the certificate directory: %v",
+	"创建私钥目录失败: %v":                                               "failed to create the private key directory: %v",
+	"生成证书失败: %v":                                                 "failed to generate the certificate: %v",
+	"\n证书已生成：\n证书文件：%s\n私钥文件：%s\n":                               "\nCertificate generated:\nCertificate: %s\nPrivate key: %s\n",
+	"非交互式模式下必须指定域名":                                              "a domain name is required in non-interactive mode",
+	"证书已生成：\n证书文件：%s\n私钥文件：%s\n":                                 "Certificate generated:\nCertificate: %s\nPrivate key: %s\n",
+	"初始化CA失败: %v":                                                "failed to initialize the CA: %v",
+	"CA已初始化：%s\n":                                                "CA initialized: %s\n",
+	"签发CA: %s\n":                                                 "Issuing CA: %s\n",
+	"请使用 init --intermediate 创建中间CA":                             "Use init --intermediate to create an intermediate CA",
+	"签发证书失败: %v":                                                 "failed to issue the certificate: %v",
+	"证书已签发：\n证书文件：%s\n私钥文件：%s\n":                                 "Certificate issued:\nCertificate: %s\nPrivate key: %s\n",
+	"CA证书: %s\n":                                                 "CA certificate: %s\n",
+	"[CSR文件]":                                                    "[CSR file]",
+	"读取证书签名请求失败: %v":                                             "failed to read the certificate signing request: %v",
+	"写入证书文件失败: %v":                                               "failed to write the certificate file: %v",
+	"证书已签发：%s\n":                                                 "Certificate issued: %s\n",
+	"CA尚未签发任何证书":                                                 "The CA has not issued any certificates",
+	"用途":                                                         "Usage",
+	"过期时间":                                                       "Expires",
+	"有效":                                                         "valid",
+	"已吊销":                                                        "revoked",
+	"[序列号]":                                                      "[serial]",
+	"不支持的吊销原因: %s":                                               "unsupported revocation reason: %s",
+	"吊销证书失败: %v":                                                 "failed to revoke the certificate: %v",
+	"证书已吊销：%s (%s)\n":                                            "Certificate revoked: %s (%s)\n",
+	"请执行 cert ca crl 重新生成证书吊销列表":                                 "Run cert ca crl to regenerate the certificate revocation list",
+	"写入CRL文件失败: %v":                                              "failed to write the CRL file: %v",
+	"CRL已生成，包含 %d 个吊销的证书\n":                                      "CRL generated with %d revoked certificates\n",
+	"[输入文件]":                                                     "[input file]",
+	"格式: %s，包含 %d 个对象\n":                                         "Format: %s, %d objects\n",
+	"必须通过 --to 指定目标格式 (pem, der)":                                "--to is required (pem, der)",
+	"输出文件不能与输入文件相同，请使用 -o 指定":                                    "output file must differ from the input file, specify it with -o",
+	"转换失败: %v":                                                   "conversion failed: %v",
+	"转换完成：":                                                      "Converted:",
+	"[域名]":                                                       "[domain]",
+	"生成CSR失败: %v":                                                "failed to generate the CSR: %v",
+	"写入CSR文件失败: %v":                                              "failed to write the CSR file: %v",
+	"CSR已生成：\nCSR文件：%s\n私钥文件：%s\n":                               "CSR generated:\nCSR: %s\nPrivate key: %s\n",
+	"必须同时指定 --ca-cert 和 --ca-key":                                "--ca-cert and --ca-key must be given together",
+	"必须同时指定 --cert 和 --key":                                      "--cert and --key must be given together",
+	"导出PKCS#12失败: %v":                                            "failed to export PKCS#12: %v",
+	"PKCS#12文件已生成：%s\n":                                          "PKCS#12 file generated: %s\n",
+	"[PKCS#12文件]":                                                "[PKCS#12 file]",
+	"导入PKCS#12失败: %v":                                            "failed to import PKCS#12: %v",
+	"已导出：\n证书文件：%s\n私钥文件：%s\n":                                   "Exported:\nCertificate: %s\nPrivate key: %s\n",
+	"请输入PKCS#12密码":                                               "PKCS#12 password",
+	"私钥类型: %s\n":                                                 "Private key type: %s\n",
+	"CA证书数量: %d\n":                                               "CA certificates: %d\n",
+	"必须指定 --targets":                                             "--targets is required",
+	"目标文件中没有监控目标":                                                "the targets file contains no targets",
+	"主体":                                                         "Subject",
+	"剩余天数":                                                       "Days left",
+	"正常":                                                         "OK",
+	"即将过期":                                                       "EXPIRING",
+	"已过期":                                                        "EXPIRED",
+	"检查失败":                                                       "ERROR",
+	"\n共 %d 个目标，告警阈值 %d 天：正常 %d，即将过期 %d，已过期 %d，检查失败 %d\n": "\n%d targets, warning threshold %d days: %d OK, %d expiring, %d expired, %d errors\n",
+
+	// DNS
+	"不支持的DNS记录类型: %s":     "unsupported DNS record type: %s",
+	"正在查询 %s 的DNS记录...\n": "Querying DNS records for %s...\n",
+	"使用DNS服务器: %s\n":      "Using DNS server: %s\n",
+	"%s记录查询失败: %s\n":      "%s lookup failed: %s\n",
+	"未找到%s记录。\n":          "No %s records found.\n",
+	"%s记录 (查询方式: %s):\n":  "%s records (via %s):\n",
+	"类型: %s, 值: %s\n":     "Type: %s, value: %s\n",
+	"DNS查询失败: %v":         "DNS lookup failed: %v",
+	"系统DNS":               "system DNS",
+	"系统DNS服务器 (%s)":       "system DNS server (%s)",
+	"自定义DNS服务器 (%s)":      "custom DNS server (%s)",
+
+	// 端口转发
+	"必须同时指定 --listen 和 --target": "--listen and --target must be given together",
+	"开始转发 %s -> %s (%s)\n":       "Forwarding %s -> %s (%s)\n",
+	"按 Ctrl+C 停止转发":              "Press Ctrl+C to stop",
+	"端口转发失败: %v":                 "port forwarding failed: %v",
+	"\n---- 转发统计信息 ----":         "\n---- Forwarding statistics ----",
+	"累计连接数: %d\n":                "Connections: %d\n",
+	"拒绝连接数: %d\n":                "Rejected: %d\n",
+	"发送字节数: %d\n":                "Bytes sent: %d\n",
+	"接收字节数: %d\n":                "Bytes received: %d\n",
+
+	// HTTP/3
+	"正在检查 %s 的HTTP/3支持...\n\n":  "Checking HTTP/3 support of %s...\n\n",
+	"检查失败: %v":                  "check failed: %v",
+	"Alt-Svc 广告:":               "Alt-Svc advertisement:",
+	"  无法获取（TCP请求失败）\n":         "  unavailable (TCP request failed)\n",
+	"  未返回Alt-Svc响应头\n":         "  no Alt-Svc response header\n",
+	"  已通过 %s 声明支持HTTP/3\n":     "  HTTP/3 advertised via %s\n",
+	"  未声明h3\n":                 "  h3 not advertised\n",
+	"QUIC 握手:":                  "QUIC handshake:",
+	"  握手成功\n":                  "  handshake succeeded\n",
+	"  QUIC版本: %s\n":            "  QUIC version: %s\n",
+	"  TLS版本: %s\n":             "  TLS version: %s\n",
+	"  握手耗时: %s\n":              "  handshake time: %s\n",
+	"  握手失败: %s\n":              "  handshake failed: %s\n",
+	"延迟对比（首次请求，含握手）:":           "Latency (first request, including handshake):",
+	"  HTTP/3:  请求失败: %s\n":     "  HTTP/3:  request failed: %s\n",
+	"  HTTP/3:  %-10s 状态码 %d\n": "  HTTP/3:  %-10s status %d\n",
+	"  HTTP/3:  不可用":            "  HTTP/3:  unavailable",
+	"  TCP:     请求失败: %s\n":     "  TCP:     request failed: %s\n",
+	"  %-8s %-10s 状态码 %d\n":     "  %-8s %-10s status %d\n",
+	"  HTTP/3 快 %s (%.1f%%)\n":  "  HTTP/3 is faster by %s (%.1f%%)\n",
+	"  HTTP/3 慢 %s (%.1f%%)\n":  "  HTTP/3 is slower by %s (%.1f%%)\n",
+
+	// IP信息
+	"[IP地址]":              "[IP address]",
+	"正在获取本机公网IP信息...\n":   "Fetching public IP information...\n",
+	"正在获取IP %s 的信息...\n":  "Fetching information for IP %s...\n",
+	"获取IP信息失败: %v":        "failed to get IP information: %v",
+	"获取本地网络接口信息失败: %v":    "failed to get local network interfaces: %v",
+	"IP信息:\n":             "IP information:\n",
+	"IP地址: %s\n":          "IP address: %s\n",
+	"位置: %s, %s, %s\n":    "Location: %s, %s, %s\n",
+	"邮政编码: %s\n":          "Postal code: %s\n",
+	"时区: %s\n":            "Time zone: %s\n",
+	"\n本地网络接口信息:":         "\nLocal network interfaces:",
+	"[%d] 接口: %s\n":       "[%d] Interface: %s\n",
+	"    IP地址: %s (%s)\n": "    IP address: %s (%s)\n",
+	"    MAC地址: %s\n":     "    MAC address: %s\n",
+	"    状态: %s\n\n":      "    Status: %s\n\n",
+	"已连接":                 "up",
+	"已断开":                 "down",
+
+	// 路径MTU
+	"[主机名或IP]": "[host or IP]",
+	"正在探测到 %s 的路径MTU (范围 %d-%d)...\n\n": "Probing the path MTU to %s (range %d-%d)...\n\n",
+	"路径MTU探测失败: %v":                     "path MTU discovery failed: %v",
+	"\n---- 路径MTU探测结果 ----":             "\n---- Path MTU result ----",
+	"目标: %s (%s)\n":                     "Target: %s (%s)\n",
+	"路径MTU: %d 字节\n":                    "Path MTU: %d bytes\n",
+	"最大ICMP载荷: %d 字节\n":                 "Max ICMP payload: %d bytes\n",
+	"TCP MSS: %d 字节\n":                  "TCP MSS: %d bytes\n",
+	"探测包数量: %d\n":                       "Probes sent: %d\n",
+	"开始分片位置: %s (报告下一跳MTU %d)\n":        "Fragmentation starts at: %s (reported next-hop MTU %d)\n",
+	"开始分片位置: %s\n":                      "Fragmentation starts at: %s\n",
+	"开始分片位置: 本地接口":                      "Fragmentation starts at: local interface",
+	"开始分片位置: 未知 (路径上的设备丢弃了超长包但没有返回ICMP通知)\n": "Fragmentation starts at: unknown (a device on the path dropped oversized packets without sending ICMP)\n",
+	"开始分片位置: 在探测范围内未发生分片":                    "Fragmentation starts at: none within the probed range",
+	"\n---- VPN/隧道建议 ----":                   "\n---- VPN/tunnel suggestions ----",
+	"TCP MSS钳制":                              "TCP MSS clamp",
+	"封装开销":                                   "Overhead",
+	"隧道MTU":                                  "Tunnel MTU",
+	"隧道类型":                                   "Tunnel type",
+
+	// Ping、端口扫描
+	"正在Ping %s (%d次，间隔%.1f秒)...\n\n": "Pinging %s (%d times, %.1f s interval)...\n\n",
+	"Ping %s 失败: %s\n":               "Ping %s failed: %s\n",
+	"\nPing %s 失败: %s\n":             "\nPing %s failed: %s\n",
+	"\n---- Ping 统计信息 ----":          "\n---- Ping statistics ----",
+	"Ping %s 成功:\n":                  "Ping %s succeeded:\n",
+	"正在扫描 %s 的端口...\n":               "Scanning ports on %s...\n",
+	"扫描指定的端口列表...\n":                 "Scanning the given ports...\n",
+	"解析端口列表失败: %v":                   "failed to parse the port list: %v",
+	"仅扫描常见端口...\n":                   "Scanning common ports only...\n",
+	"扫描端口范围: %d-%d...\n":             "Scanning port range %d-%d...\n",
+	"端口扫描失败: %s":                     "port scan failed: %s",
+	"未发现开放的端口。\n":                    "No open ports found.\n",
+	"发现 %d 个开放的端口:\n":                "Found %d open ports:\n",
+	"端口\t状态\t服务":                     "PORT\tSTATE\tSERVICE",
+	"开放":                             "open",
+	"端口 '%s' 格式无效: %v":               "invalid port '%s': %v",
+	"端口 %d 超出有效范围 (1-65535)":         "port %d is out of range (1-65535)",
+	"未提供有效的端口":                       "no valid ports given",
+
+	// 抓包
+	"[接口名]": "[interface]",
+	"必须指定网络接口名称，可以使用 --list-interfaces 查看可用的网络接口": "an interface name is required, use --list-interfaces to list the available interfaces",
+	"可用的网络接口:":          "Available interfaces:",
+	"开始在接口 %s 上抓包...\n": "Capturing on interface %s...\n",
+	"过滤规则: %s\n":        "Filter: %s\n",
+	"按 Ctrl+C 停止抓包":     "Press Ctrl+C to stop capturing",
+	"抓包失败: %v":          "capture failed: %v",
+	"\n抓包结果已保存到: %s\n":  "\nCapture saved to: %s\n",
+	"PCAP文件已保存到: %s\n":  "PCAP file saved to: %s\n",
+
+	// 测速
+	"服务器模式不支持 --output %s":      "server mode does not support --output %s",
+	"正在进行网络速度测试...\n":           "Running the speed test...\n",
+	"速度测试失败: %s":                "speed test failed: %s",
+	"速度测试完成(服务器: %s):\n":        "Speed test complete (server: %s):\n",
+	"下载速度: %.2f Mbps\n":         "Download: %.2f Mbps\n",
+	"上传速度: %.2f Mbps\n":         "Upload: %.2f Mbps\n",
+	"延迟: %.0f ms\n":             "Latency: %.0f ms\n",
+	"测试延迟: %.0f ms":             "Latency: %.0f ms",
+	"测试下载: %.2f Mbps (%.1f MB)": "Download: %.2f Mbps (%.1f MB)",
+	"测试上传: %.2f Mbps (%.1f MB)": "Upload: %.2f Mbps (%.1f MB)",
+	"正在启动速度测试服务器 %s:%d...\n":    "Starting the speed test server on %s:%d...\n",
+	"启动服务器失败: %v":               "failed to start the server: %v",
+
+	// SSH
+	"无法获取用户目录: %v":             "cannot determine the home directory: %v",
+	"文件 %s 已存在，使用 --force 覆盖":  "file %s already exists, use --force to overwrite",
+	"创建目录失败: %v":               "failed to create directory: %v",
+	"请输入私钥保护密码（直接回车表示不加密）":     "Private key passphrase (press Enter for none)",
+	"生成SSH密钥失败: %v":            "failed to generate the SSH key: %v",
+	"私钥已保存到: %s\n":             "Private key saved to: %s\n",
+	"公钥已保存到: %s.pub\n":         "Public key saved to: %s.pub\n",
+	"密钥指纹:":                    "Key fingerprint:",
+	"[公钥文件...]":                "[public key file...]",
+	"不支持的指纹算法: %s":             "unsupported fingerprint algorithm: %s",
+	"扫描主机公钥失败: %v":             "failed to scan host keys: %v",
+	"请指定公钥文件或使用 --scan 扫描远程主机": "Specify public key files or use --scan to scan a remote host",
+
+	// 路由跟踪
+	"正在执行到 %s 的路由跟踪 (最大跳数: %d)...\n":      "Tracing the route to %s (max hops: %d)...\n",
+	"Paris模式: 每个流标识的探测包走同一路径，共探测 %d 个流\n": "Paris mode: probes of each flow follow the same path, %d flows\n",
+	"Traceroute 路由跟踪": "Traceroute",
+	"跳数":              "Hop",
+	"主机名":             "Hostname",
+	"延迟":              "Latency",
+	"\n路由跟踪完成: 共经过 %d 跳到达目标 %s\n":                       "\nTrace complete: reached %[2]s in %[1]d hops\n",
+	"\n路由跟踪未能到达目标，已达到最大跳数限制: %d\n":                      "\nTarget not reached, hit the maximum hop limit: %d\n",
+	"\n路由跟踪失败，未获取到任何路由信息\n":                             "\nTrace failed, no route information received\n",
+	"多路径探测结果 (共 %d 个流):\n":                              "Multipath results (%d flows):\n",
+	"  (%d条备选路径)":                                       "  (%d alternative paths)",
+	"%s 只能输出文本，不支持 --output %s":                         "%s only prints text and does not support --output %s",
+	"%s 的 --output 表示输出路径，不能指定输出格式；确实要输出到 %s 时请写成 ./%s": "--output of %s is an output path, not a format; write ./%[3]s to write to a file named %[2]s",
+
+	// 进程
+	"无效的PID: %v":                       "invalid PID: %v",
+	"获取进程信息失败: %v":                     "failed to get process information: %v",
+	"正在查找进程 %d (%s) 的子进程...\n\n":       "Looking up child processes of %d (%s)...\n\n",
+	"获取子进程失败: %v":                      "failed to get child processes: %v",
+	"进程 %d 没有子进程\n":                    "Process %d has no child processes\n",
+	"找到 %d 个子进程:\n\n":                  "Found %d child processes:\n\n",
+	"执行时间: %.2f秒\n":                    "Elapsed: %.2fs\n",
+	"==============进程详情==============": "==============Process details==============",
+	"进程ID: ":                           "PID: ",
+	"父进程ID: ":                          "PPID: ",
+	"进程名称: ":                           "Name: ",
+	"可执行文件: ":                          "Executable: ",
+	"用户: ":                             "User: ",
+	"状态: ":                             "Status: ",
+	"创建时间: ":                           "Started: ",
+	"%s (%s前)\n":                       "%s (%s ago)\n",
+	"CPU使用率: ":                         "CPU: ",
+	"内存使用: ":                           "Memory: ",
+	"%.2f%% (RSS: %s, 虚拟: %s)\n":       "%.2f%% (RSS: %s, virtual: %s)\n",
+	"线程数: ":                            "Threads: ",
+	"容器: ":                             "Container: ",
+	"命令行:":                             "Command line:",
+	"  [无法获取命令行]":                      "  [command line unavailable]",
+	"打开的文件:":                           "Open files:",
+	"%d天%d小时":                          "%dd %dh",
+	"%d小时%d分钟":                         "%dh %dm",
+	"%d分钟%d秒":                          "%dm %ds",
+	"%d秒":                              "%ds",
+	"先尝试优雅终止，失败时强制终止":       "try a graceful stop first, force kill if it fails",
+	"正在终止进程 %d (%s)...\n":   "Killing process %d (%s)...\n",
+	"终止进程失败: %v":            "failed to kill the process: %v",
+	"进程 %d 已成功终止\n":         "Process %d terminated\n",
+	"找到 %d 个匹配 '%s' 的进程\n":  "Found %d processes matching '%s'\n",
+	"按CPU使用率排序":             "sort by CPU usage",
+	"按PID排序":                "sort by PID",
+	"按内存使用率排序":              "sort by memory usage",
+	"用户":                    "User",
+	"[无名称]":                 "[unnamed]",
+	"\n共 %d 个进程\n":          "\n%d processes\n",
+	"构建进程树失败: %v":           "failed to build the process tree: %v",
+	"警告: %v, 尝试使用备用方法...\n": "Warning: %v, trying a fallback...\n",
+	"系统进程树:":                "System process tree:",
+	"进程 %d (%s) 的进程树:":      "Process tree of %d (%s):",
+	"渲染进程树失败: %v":           "failed to render the process tree: %v",
+	"记录历史失败: %v":            "failed to record history: %v",
+	"记录指标失败: %v":            "failed to record metrics: %v",
+
+	// 正则表达式
+	"<模式>":         "<pattern>",
+	"部分":           "Part",
+	"<模式> [文本...]": "<pattern> [text...]",
+	"--input 不能与文本参数同时使用":               "--input cannot be used with text arguments",
+	"请提供文本参数、使用 --input 指定文件，或通过管道输入内容": "Pass text as arguments, use --input to read a file, or pipe the content",
+	"没有匹配":     "No matches",
+	"位置":       "Position",
+	"匹配":       "Match",
+	"捕获组":      "Groups",
+	"(未匹配)":    "(unmatched)",
+	"共 %d 个匹配": "%d matches",
+	"显示前 %d 个匹配，使用 --limit 0 显示全部": "Showing the first %d matches, use --limit 0 to show all",
+
+	// 任务文件
+	"<任务文件>":                    "<task file>",
+	"<== 完成 (%s)\n\n":           "<== done (%s)\n\n",
+	"<== 失败: %s (%s)\n\n":       "<== failed: %s (%s)\n\n",
+	"无效的变量: %s（格式为 name=value）": "invalid variable: %s (expected name=value)",
+	"执行汇总: %s":                  "Summary: %s",
+	"步骤":                        "Step",
+	"跳过":                        "skipped",
+	"\n共 %d 个步骤：成功 %d，失败 %d，跳过 %d，总耗时 %s\n": "\n%d steps: %d succeeded, %d failed, %d skipped, total time %s\n",
+
+	// 文件服务器
+	"[目录]": "[directory]",
+	"--auth 的格式应为 用户名:密码":        "--auth must be user:password",
+	"--cert 和 --key 必须同时指定":      "--cert and --key must be given together",
+	"无法提供目录 %s: %v":              "cannot serve directory %s: %v",
+	"监听 %s 失败: %v":               "failed to listen on %s: %v",
+	"创建临时目录失败: %v":               "failed to create a temporary directory: %v",
+	"证书SHA-256指纹: %s\n":          "Certificate SHA-256 fingerprint: %s\n",
+	"服务器运行失败: %v":                "server failed: %v",
+	"\n服务器已停止":                   "\nServer stopped",
+	"提供目录 %s\n":                  "Serving directory %s\n",
+	"注意: 未启用TLS，Basic认证的密码以明文传输": "Note: TLS is disabled, Basic auth passwords are sent in clear text",
+	"注意: 已启用上传且未设置认证，网络中的任何人都可以上传文件": "Note: uploads are enabled without authentication, anyone on the network can upload files",
+
+	// 服务
+	"<服务名>":           "<service>",
+	"服务":              "Service",
+	"当前状态: %s，通过%s执行": "current state: %s, via %s",
+	"已%s服务 %s\n":      "%s service %s: done\n",
+	"<服务名...>":        "<service...>",
+	"启动方式":            "Startup",
+	"持续时间":            "Since",
+	"%s 已自动重启 %d 次\n": "%s has been restarted automatically %d times\n",
+
+	// 指标统计
+	"指标记录已清空\n": "Metrics cleared\n",
+	"没有指标记录":    "No metrics recorded",
+	"指标记录未开启，设置 TOOLBOX_METRICS=1 或在配置文件中加入 metrics: true 后开始记录": "Metrics recording is off, set TOOLBOX_METRICS=1 or add metrics: true to the config file to start recording",
+	"按命令汇总": "By command",
+	"内存峰值":  "Peak memory",
+	"平均CPU": "Avg CPU",
+	"平均耗时":  "Avg time",
+	"最长":    "Max",
+	"次数":    "Runs",
+	"最慢的执行": "Slowest runs",
+	"\n指标记录当前未开启，以上为之前记录的数据": "\nMetrics recording is currently off, the data above was recorded earlier",
+
+	// 系统信息
+	"主机":                       "Host",
+	"系统":                       "OS",
+	"内核":                       "Kernel",
+	"虚拟化":                      "Virtualization",
+	"%s（已运行 %s）":               "%s (up %s)",
+	"启动时间":                     "Boot time",
+	"%d 物理 / %d 逻辑":            "%d physical / %d logical",
+	"核数":                       "Cores",
+	"主频":                       "Frequency",
+	"使用率":                      "Usage",
+	"%.2f %.2f %.2f（1、5、15分钟）": "%.2f %.2f %.2f (1, 5, 15 min)",
+	"负载":                       "Load",
+	"%s / %s (%.1f%%)，可用 %s":   "%s / %s (%.1f%%), %s available",
+	"交换空间":                     "Swap",
+	"未启用":                      "disabled",
+	"磁盘":                       "Disks",
+	"可用":                       "Free",
+	"已用":                       "Used",
+	"挂载点":                      "Mount point",
+	"网络接口":                     "Network interfaces",
+	"地址":                       "Addresses",
+	"接口":                       "Interface",
+	"%d分钟":                     "%dm",
+
+	// 文本处理
+	"[表达式] [文件路径...]":     "[expression] [file...]",
+	"必须指定过滤表达式":           "a filter expression is required",
+	"标准输入":                "standard input",
+	"错误: %v\n":            "Error: %v\n",
+	"[模式] [文件路径...]":      "[pattern] [file...]",
+	"必须指定搜索模式":            "a search pattern is required",
+	"无法访问 %s: %v":         "cannot access %s: %v",
+	"[模式] [替换] [文件路径...]": "[pattern] [replacement] [file...]",
+	"必须指定搜索模式和替换文本":       "a search pattern and replacement text are required",
+	"无法对标准输入执行原地替换":       "cannot replace standard input in place",
+	"无法创建备份 %s: %v":       "cannot create backup %s: %v",
+	"无法创建临时文件: %v":        "cannot create a temporary file: %v",
+	"无法替换原文件: %v":         "cannot replace the original file: %v",
+	"已处理 %d 行，替换了 %d 处\n": "Processed %d lines, made %d replacements\n",
+	"备份 %s":               "back up %s",
+	"共 %d 行，替换 %d 处":      "%d lines, %d replacements",
+
+	// 时间
+	"<时间...>":          "<time...>",
+	"（识别为 %s，时区 %s）\n": "(parsed as %s, time zone %s)\n",
+	"格式":               "Format",
+	"不支持的时间戳单位: %s（支持 auto、s、ms、us、ns）": "unsupported timestamp unit: %s (supported: auto, s, ms, us, ns)",
+	"<时长...>": "<duration...>",
+	"Go写法":    "Go",
+	"毫秒":      "Milliseconds",
+	"秒":       "Seconds",
+	"规范写法":    "Canonical",
+
+	// TOTP
+	"生成二维码需要用 --account 指定账号名称":   "--account is required to generate a QR code",
+	"生成二维码失败: %v":                 "failed to generate the QR code: %v",
+	"保存二维码失败: %v":                 "failed to save the QR code: %v",
+	"二维码已保存到 %s\n":                "QR code saved to %s\n",
+	"必须指定 --secret 或 --from 中的一个": "specify one of --secret or --from",
+	"环境变量 %s 不存在":                 "environment variable %s does not exist",
+	"当前验证码: %s  剩余 %s\n":          "Current code: %s  expires in %s\n",
+	"下一个验证码: %s\n":                "Next code: %s\n",
+
+	// 交互式界面
+	"搜索的根目录":        "Root directory to search",
+	"支持通配符，例如 *.go": "Wildcards supported, e.g. *.go",
+	"文件名":           "File name",
+	"最大深度":          "Max depth",
+	"留空表示不限制":       "Leave empty for no limit",
+	"最大深度必须是非负整数":   "max depth must be a non-negative integer",
+	"Tab 切换输入框  Enter 搜索  PgUp/PgDn 翻页": "Tab switch field  Enter search  PgUp/PgDn page",
+	"正在搜索...\n":                    "Searching...\n",
+	"找到 %d 个文件":                    "Found %d files",
+	"（显示第 %d-%d 个）":                " (showing %d-%d)",
+	"实时查看进程的CPU和内存占用":              "Watch process CPU and memory usage live",
+	"进程监控":                         "Process monitor",
+	"扫描主机开放的端口":                    "Scan a host for open ports",
+	"端口扫描":                         "Port scan",
+	"Ping/路由跟踪":                    "Ping/traceroute",
+	"检查连通性并跟踪网络路径":                 "Check connectivity and trace the network path",
+	"按文件名在目录中搜索文件":                 "Search a directory for files by name",
+	"文件搜索":                         "File search",
+	"Toolbox 交互式工具箱":               "Toolbox interactive mode",
+	"↑/↓ 选择  Enter 打开  q 退出":       "↑/↓ select  Enter open  q quit",
+	"Esc 返回主菜单  Ctrl+C 退出":         "Esc main menu  Ctrl+C quit",
+	"例如 example.com 或 8.8.8.8":     "e.g. example.com or 8.8.8.8",
+	"请输入主机":                        "Enter a host",
+	"Enter 执行Ping  Ctrl+T 路由跟踪":    "Enter ping  Ctrl+T traceroute",
+	"正在执行 %s...\n":                 "Running %s...\n",
+	"例如 example.com 或 192.168.1.1": "e.g. example.com or 192.168.1.1",
+	"例如 22,80,8000-8100，留空扫描常见端口":  "e.g. 22,80,8000-8100, leave empty for common ports",
+	"Tab 切换输入框  Enter 开始扫描":        "Tab switch field  Enter start scan",
+	"正在扫描...\n":                    "Scanning...\n",
+	"发现 %d 个开放端口（耗时 %.1f 秒）\n\n":   "Found %d open ports (%.1f s)\n\n",
+	"端口 '%s' 格式无效":                 "invalid port '%s'",
+	"端口 '%s' 超出有效范围 (1-65535)":     "port '%s' is out of range (1-65535)",
+	"获取进程列表失败: %v":                 "failed to list processes: %v",
+	"正在采集进程信息...\n":                "Collecting process information...\n",
+	"共 %d 个进程  排序: %s  更新于 %s":     "%d processes  sort: %s  updated %s",
+	"  过滤: ":                       "  filter: ",
+	"s 切换排序  / 按名称过滤  r 立即刷新":      "s change sort  / filter by name  r refresh now",
+	"运行交互式界面失败: %v":                "failed to run the interactive UI: %v",
+
+	// 重复执行
+	"[选项] -- <子命令> [参数...]":  "[options] -- <subcommand> [args...]",
+	"间隔不能小于100ms":            "interval must be at least 100ms",
+	"不能监视 watch 命令本身":        "cannot watch the watch command itself",
+	"无效的正则表达式: %v":           "invalid regular expression: %v",
+	"退出码 %d":                 "exit code %d",
+	"每 %s: %s":               "Every %s: %s",
+	"    第 %d 次  %s  %s\n\n": "    run %d  %s  %s\n\n",
+	"\n满足停止条件: 退出码为 %d\n":    "\nStop condition met: exit code %d\n",
+	"\n满足停止条件: 输出匹配 %s\n":    "\nStop condition met: output matches %s\n",
+	"\n已停止，共执行 %d 次\n":       "\nStopped after %d runs\n",
+
+	// pkg/clipboard、codec、config
+	"没有可用的剪贴板工具，请安装 xclip、xsel 或 wl-clipboard": "no clipboard tool available, install xclip, xsel or wl-clipboard",
+	"写入剪贴板失败: %v": "failed to write to the clipboard: %v",
+	"读取剪贴板失败: %v": "failed to read the clipboard: %v",
+	"不支持的编码格式: %s（支持 base64、base64url、hex、url、html、qp）": "unsupported encoding: %s (supported: base64, base64url, hex, url, html, qp)",
+	"不支持的编码格式: %s":             "unsupported encoding: %s",
+	"十六进制解码失败: %v":             "hex decoding failed: %v",
+	"URL解码失败: %v":              "URL decoding failed: %v",
+	"quoted-printable解码失败: %v": "quoted-printable decoding failed: %v",
+	"输入为空，无法识别编码格式":            "input is empty, cannot detect the encoding",
+	"无法识别编码格式，请使用 --type 指定":   "cannot detect the encoding, specify it with --type",
+	"base64解码失败: %v":           "base64 decoding failed: %v",
+	"获取用户配置目录失败: %v":           "failed to get the user config directory: %v",
+	"读取配置文件失败: %v":             "failed to read the config file: %v",
+	"解析配置文件 %s 失败: %v":         "failed to parse the config file %s: %v",
+
+	// pkg/convert
+	"无效的整数: %s":                      "invalid integer: %s",
+	"无效的%d进制整数: %s":                  "invalid base-%d integer: %s",
+	"Unix文件权限，如 0755、rwxr-xr-x":      "Unix file mode, e.g. 0755, rwxr-xr-x",
+	"以文件所有者身份执行":                     "execute as the file owner",
+	"以文件所属组身份执行，目录中新文件继承所属组":         "execute as the file group; new files in a directory inherit the group",
+	"目录中的文件只能由所有者删除":                 "files in the directory can only be deleted by their owners",
+	"所有者可读":                          "owner can read",
+	"所有者可写":                          "owner can write",
+	"所有者可执行":                         "owner can execute",
+	"所属组可读":                          "group can read",
+	"所属组可写":                          "group can write",
+	"所属组可执行":                         "group can execute",
+	"其他用户可读":                         "others can read",
+	"其他用户可写":                         "others can write",
+	"其他用户可执行":                        "others can execute",
+	"TCP报文头中的标志位，如 0x12、SYN|ACK":     "TCP header flags, e.g. 0x12, SYN|ACK",
+	"ECN随机数隐藏保护":                     "ECN nonce concealment protection",
+	"拥塞窗口已减小":                        "congestion window reduced",
+	"ECN回显":                          "ECN echo",
+	"紧急指针有效":                         "urgent pointer is valid",
+	"确认号有效":                          "acknowledgment number is valid",
+	"尽快交付给应用":                        "push data to the application",
+	"重置连接":                           "reset the connection",
+	"同步序号，建立连接":                      "synchronize sequence numbers to open a connection",
+	"发送方已无数据，关闭连接":                   "no more data from the sender, close the connection",
+	"任意整数，列出已设置的位号":                  "any integer, lists the set bit numbers",
+	"未知的标志类型: %s，可选值: %s":            "unknown flag kind: %s, choices: %s",
+	"标志值不能为空":                        "flag value must not be empty",
+	"标志值超出范围: %s":                    "flag value out of range: %s",
+	"无效的位号: %s，应为0到63之间的整数":          "invalid bit number: %s, must be an integer between 0 and 63",
+	"未知的%s标志: %s":                    "unknown %s flag: %s",
+	"无效的权限写法: %s，应为9个字符，如 rwxr-xr-x": "invalid mode string: %s, must be 9 characters such as rwxr-xr-x",
+	"无效的权限写法: %s":                    "invalid mode string: %s",
+	"未知的单位: %s":                      "unknown unit: %s",
+	"无效的数值: %s，应为数字加单位，如 1.5GiB、100Mbps、90min": "invalid quantity: %s, expected a number with a unit such as 1.5GiB, 100Mbps, 90min",
+	"缺少单位: %s": "missing unit: %s",
+	"不能将 %s 换算为 %s：单位类别不同": "cannot convert %s to %s: different unit categories",
+
+	// pkg/cronexpr
+	"分钟": "minutes",
+	"小时": "hours",
+	"日":  "day of month",
+	"月":  "month",
+	"@reboot 在系统启动时执行，没有固定的执行时间":                             "@reboot runs at system startup and has no fixed schedule",
+	"未知的宏: %s，可用的宏: @yearly、@monthly、@weekly、@daily、@hourly": "unknown macro: %s, available macros: @yearly, @monthly, @weekly, @daily, @hourly",
+	"表达式有6个字段，只支持标准的5个字段: 分钟 小时 日 月 星期；":                     "the expression has 6 fields, only the standard 5 fields are supported: minute hour day month weekday; ",
+	"如果第一个字段是秒（Quartz、Spring等格式），请去掉它":                       "if the first field is seconds (Quartz, Spring and similar formats), remove it",
+	"表达式有7个字段，看起来是包含秒和年的Quartz格式，只支持标准的5个字段: 分钟 小时 日 月 星期":   "the expression has 7 fields and looks like the Quartz format with seconds and years, only the standard 5 fields are supported: minute hour day month weekday",
+	"表达式应有5个字段: 分钟 小时 日 月 星期，实际有%d个，例如 */15 2 * * 1-5":       "the expression must have 5 fields: minute hour day month weekday, got %d, e.g. */15 2 * * 1-5",
+	"第%d个字段（%s）%q 无效: %v":                                    "field %d (%s) %q is invalid: %v",
+	"列表中有空项，检查是否多写了逗号":                                       "the list has an empty item, check for an extra comma",
+	"步长 %q 不是整数": "step %q is not an integer",
+	"步长必须大于0":    "step must be greater than 0",
+	"范围 %s 的起点大于终点，cron不支持跨越边界的范围，可以拆成两段，如 22-23,0-2": "range %s starts after it ends; cron does not support wrap-around ranges, split it in two, e.g. 22-23,0-2",
+	"不支持Quartz扩展语法 ?、L、W、#，请使用 * 或具体的取值":              "Quartz extensions ?, L, W and # are not supported, use * or explicit values",
+	"无法识别 %q，应为 %d-%d 的数字或英文缩写（%s）":                   "cannot parse %q, expected a number in %d-%d or an abbreviation (%s)",
+	"无法识别 %q，应为 %d-%d 的数字":                            "cannot parse %q, expected a number in %d-%d",
+	"，月份从1开始":         ", months start at 1",
+	"，日期从1开始":         ", days start at 1",
+	"，0和7都表示周日":       ", both 0 and 7 mean Sunday",
+	"，午夜请写0":          ", write midnight as 0",
+	"%d 超出范围 %d-%d%s": "%d is out of range %d-%d%s",
+	"的":               ", ",
+	"或":               " or ",
+	"每月":              "every month, ",
+	"每":               "every ",
+	"每天":              "every day",
+	"每分钟":             "every minute",
+	"整点":              "on the hour",
+	"第%s分钟":           "at minute %s",
+	"每小时的":            "every hour, ",
+	"%d分":             "minute %d",
+	"%d点":             "%d:00",
+	"%d号":             "day %d",
+	"%d月":             "month %d",
+	"%d月%d号":          "%d/%d",
+	"个月":              "months",
+	"天":               "days",
+	"每%d%s":           "every %d %s",
+	"到":               " to ",
+	"%s到%s每%d%s":      "%s to %s every %d %s",
+	"、":               ", ",
+
+	// pkg/crypt
+	"不是toolbox加密的文件":                       "not a file encrypted by toolbox",
+	"不支持的密钥派生算法: %s（可选: scrypt, argon2id）": "unsupported key derivation function: %s (choices: scrypt, argon2id)",
+	"块大小必须在 %d 到 %d 字节之间":                  "block size must be between %d and %d bytes",
+	"文件过大，超出加密格式支持的块数":                     "file too large, exceeds the number of blocks the format supports",
+	"数据已被截断":                               "data is truncated",
+	"口令错误或文件已损坏":                           "wrong passphrase or corrupted file",
+	"文件已损坏或被截断（第 %d 块校验失败）":                "file is corrupted or truncated (block %d failed authentication)",
+	"未知的密钥派生算法: %d":                        "unknown key derivation function: %d",
+	"文件头中的块大小无效: %d":                       "invalid block size in the header: %d",
+	"文件头中的scrypt参数无效":                      "invalid scrypt parameters in the header",
+	"文件头中的argon2id参数无效":                    "invalid argon2id parameters in the header",
+	"派生密钥失败: %v":                           "key derivation failed: %v",
+
+	// pkg/digest
+	"不支持的算法: %s（支持 %s）": "unsupported algorithm: %s (supported: %s)",
+	"读取密钥文件失败: %v":      "failed to read the key file: %v",
+	"十六进制密钥无效: %v":      "invalid hex key: %v",
+	"base64密钥无效: %v":    "invalid base64 key: %v",
+
+	// pkg/docker
+	"无效的Docker地址 %q: %v":                           "invalid Docker address %q: %v",
+	"不支持的Docker地址 %q，应为 unix://、npipe:// 或 tcp://": "unsupported Docker address %q, expected unix://, npipe:// or tcp://",
+	"加载Docker客户端证书失败: %v":                          "failed to load the Docker client certificate: %v",
+	"加载Docker CA证书失败: %v":                          "failed to load the Docker CA certificate: %v",
+	"解析Docker API响应失败: %v":                         "failed to parse the Docker API response: %v",
+	"没有权限访问 %s，将当前用户加入docker组或使用sudo: %v":          "permission denied on %s, add the current user to the docker group or use sudo: %v",
+	"无法连接Docker守护进程 %s，Docker未运行或未安装；可以用 DOCKER_HOST 或 --docker-host 指定地址": "cannot connect to the Docker daemon at %s, Docker is not running or not installed; set the address with DOCKER_HOST or --docker-host",
+	"无法连接Docker守护进程 %s: %v":    "cannot connect to the Docker daemon at %s: %v",
+	"Docker API错误（%d）: %s":     "Docker API error (%d): %s",
+	"Docker API返回的进程列表中没有PID列": "the process list returned by the Docker API has no PID column",
+	"命名管道 %s 只在Windows上可用":     "named pipe %s is only available on Windows",
+	"无法识别的日志流类型 %d":            "unknown log stream type %d",
+
+	// pkg/envvars
+	"第 %d 行: 缺少 =":           "line %d: missing =",
+	"第 %d 行: 无效的变量名 %q":      "line %d: invalid variable name %q",
+	"第 %d 行: 结束引号后有多余的内容 %q": "line %d: unexpected content %q after the closing quote",
+	"第 %d 行: 变量 %s 的引号没有结束":  "line %d: unterminated quote in variable %s",
+
+	// pkg/errs
+	"未找到":   "not found",
+	"权限不足":  "permission denied",
+	"无效的输入": "invalid input",
+
+	// pkg/fanout
+	"打开目标文件失败: %v": "failed to open the targets file: %v",
+	"读取目标文件失败: %v": "failed to read the targets file: %v",
+	"没有指定目标":       "no targets given",
+	"执行被中断: %v":    "interrupted: %v",
+	"执行超过 %s":      "timed out after %s",
+
+	// pkg/fileserver
+	"%s 不是目录":           "%s is not a directory",
+	"需要认证":              "authentication required",
+	"无效的路径":             "invalid path",
+	"未启用上传":             "uploads are not enabled",
+	"不支持的请求方法":          "method not allowed",
+	"只能上传到目录":           "uploads must target a directory",
+	"请求不是multipart表单: ": "request is not a multipart form: ",
+	"读取上传内容失败: ":        "failed to read the upload: ",
+	"无效的文件名: ":          "invalid file name: ",
+	"没有上传任何文件":          "no files uploaded",
+	"需要指定文件名":           "a file name is required",
+	"目录不存在":             "directory does not exist",
+	"已保存 %s（%d 字节）\n":   "Saved %s (%d bytes)\n",
+	"文件已存在":             "file already exists",
+	"没有访问权限":            "access denied",
+	"上传":                "Upload",
+	"加载证书失败: %v":        "failed to load the certificate: %v",
+
+	// pkg/formatter
+	"读取输入失败: %v":     "failed to read input: %v",
+	"解析JSON失败: %v":   "failed to parse JSON: %v",
+	"生成美化JSON失败: %v": "failed to pretty-print JSON: %v",
+	"压缩JSON失败: %v":   "failed to compact JSON: %v",
+	"解析XML失败: %v":    "failed to parse XML: %v",
+	"美化XML失败: %v":    "failed to pretty-print XML: %v",
+	"压缩XML失败: %v":    "failed to compact XML: %v",
+	"格式化XML失败: %v":   "failed to format XML: %v",
+	"解析YAML失败: %v":   "failed to parse YAML: %v",
+	"生成YAML失败: %v":   "failed to generate YAML: %v",
+	"不支持的格式: %s":     "unsupported format: %s",
+
+	// pkg/fsutils
+	"无法访问压缩文件: %v":           "cannot access the archive: %v",
+	"无法读取RAR文件: %v":          "cannot read the RAR file: %v",
+	"无法读取7z文件: %v":           "cannot read the 7z file: %v",
+	"无法访问目录 %s: %v":          "cannot access directory %s: %v",
+	"%s 不是一个目录":              "%s is not a directory",
+	"无法读取目录 %s: %v":          "cannot read directory %s: %v",
+	"无法计算压缩文件的校验和: %v":       "cannot checksum the archive: %v",
+	"%s 格式不支持密码，只有zip格式可以加密": "%s does not support passwords, only zip can be encrypted",
+	"%s 格式不支持分卷，只有zip格式可以分卷": "%s does not support split volumes, only zip can be split",
+	"%s 格式不支持去重，只有tar格式可以将重复的文件写为硬链接": "%s does not support dedupe, only tar can store duplicate files as hard links",
+	"分卷大小不能小于 %dKB":               "volume size must be at least %dKB",
+	"%s格式不支持压缩目录":                 "%s cannot compress directories",
+	"RAR格式仅支持解压缩，不支持压缩（因为是专有格式）":  "RAR is a proprietary format and can only be extracted, not created",
+	"没有指定压缩格式":                    "no compression format given",
+	"无法统计源文件大小: %v":               "cannot determine the source size: %v",
+	"单文件格式只包含一个文件，不支持按模式选择要解压的文件": "single-file formats contain one file, selecting files by pattern is not supported",
+	"无法创建目标目录: %v":                "cannot create the target directory: %v",
+	"当前版本暂不支持创建7z文件（因为使用的库仅支持解压缩），请使用其他格式如 zip 或 tar.gz": "creating 7z files is not supported (the library can only extract), use another format such as zip or tar.gz",
+	"非法的文件路径: %s":                               "illegal file path: %s",
+	"非法的文件路径: %v":                               "illegal file path: %v",
+	"无法从文件扩展名识别目标压缩格式: %s":                      "cannot detect the target format from the file extension: %s",
+	"转换压缩文件时不支持分卷":                              "split volumes are not supported when converting archives",
+	"转换压缩文件时不支持去重":                              "dedupe is not supported when converting archives",
+	"目标压缩文件不能与源压缩文件相同":                          "target archive must differ from the source archive",
+	"zip格式不支持硬链接（链接到 %s），请转换为tar格式":             "zip does not support hard links (link to %s), convert to tar instead",
+	"%s 格式只能包含一个文件，源压缩文件中有多个文件":                 "%s can only hold one file, but the source archive has several",
+	"符号链接没有目标":                                  "symbolic link has no target",
+	"符号链接的目标超过 %d 字节，不是有效的链接":                   "symbolic link target exceeds %d bytes and is not a valid link",
+	"符号链接的目标含有NUL字符，不是有效的链接":                    "symbolic link target contains a NUL character and is not a valid link",
+	"无法识别的压缩格式: %s":                             "unrecognized compression format: %s",
+	"读取时出错: %v":                                 "read error: %v",
+	"文件已加密，需要提供密码":                              "the file is encrypted, a password is required",
+	"密码错误":                                      "wrong password",
+	"链接目标 %s 无效: %v":                            "invalid link target %s: %v",
+	"链接目标 %s 不在目标目录内":                           "link target %s is outside the target directory",
+	"路径 %s 中不存在的部分含有 ..":                        "the missing part of path %s contains ..",
+	"路径经过符号链接指向目标目录外":                           "path escapes the target directory through a symbolic link",
+	"压缩文件中没有匹配 %s 的文件":                          "no files in the archive match %s",
+	"无效的匹配模式 %q: %v":                            "invalid pattern %q: %v",
+	"无效的文件大小范围: %d - %d":                        "invalid size range: %d - %d",
+	"无法获取绝对路径: %v":                              "cannot get the absolute path: %v",
+	"删除失败: %v":                                  "delete failed: %v",
+	"访问时出错: %v":                                 "access error: %v",
+	"%d 个文件或目录删除失败":                             "failed to delete %d files or directories",
+	"无效的内容正则表达式: %v":                            "invalid content regular expression: %v",
+	"读取内容时出错: %v":                               "error reading content: %v",
+	"不支持的排序方式: %s（可选: name, path, size, mtime）": "unsupported sort order: %s (choices: name, path, size, mtime)",
+	"无效的结果数量: %d":                               "invalid result limit: %d",
+	"不支持的搜索结果格式: %s（可选: text, json, csv）":       "unsupported result format: %s (choices: text, json, csv)",
+	"输出模板和NUL分隔只能用于文本格式":                        "output templates and NUL separators only apply to the text format",
+	"无效的输出模板: %v":                               "invalid output template: %v",
+	"执行输出模板失败: %v":                              "failed to execute the output template: %v",
+	"lz4数据已损坏":                                  "lz4 data is corrupted",
+	"lz4块校验和不匹配":                                "lz4 block checksum mismatch",
+	"不支持旧版的lz4格式（lz4 -l），请使用 lz4 命令解压":          "the legacy lz4 format (lz4 -l) is not supported, use the lz4 command to decompress",
+	"不是lz4格式的文件":                                "not an lz4 file",
+	"不支持的lz4帧版本: %d":                            "unsupported lz4 frame version: %d",
+	"不支持使用字典压缩的lz4文件":                           "lz4 files compressed with a dictionary are not supported",
+	"lz4帧头校验失败":                                 "lz4 frame header checksum mismatch",
+	"lz4内容校验和不匹配，文件可能已损坏":                       "lz4 content checksum mismatch, the file may be corrupted",
+	"无法读取校验和清单: %v":                             "cannot read the checksum manifest: %v",
+	"校验和清单第 %d 行格式错误，应为 sha256sum 的格式":          "malformed line %d in the checksum manifest, expected sha256sum format",
+	"校验和清单第 %d 行的校验和无效":                         "invalid checksum on line %d of the manifest",
+	"无法写入校验和清单: %v":                             "cannot write the checksum manifest: %v",
+	"SHA-256 校验和与清单不一致":                         "SHA-256 checksums do not match the manifest",
+	" 等 %d 个文件":                                 " and %d more files",
+	"校验和清单中的文件不在压缩文件中: %s":                      "files listed in the manifest are missing from the archive: %s",
+	"无效的大小写转换 %q，可选 upper、lower、title":          "invalid case conversion %q, choices: upper, lower, title",
+	"%s 的新名称 %q 不是有效的文件名":                       "new name %[2]q for %[1]s is not a valid file name",
+	"序号宽度 %d 过大":                                "counter width %d is too large",
+	"%s 和 %s 都将重命名为 %s":                         "%s and %s would both be renamed to %s",
+	"%s 的新名称 %s 已存在":                            "new name %[2]s for %[1]s already exists",
+	"重命名冲突，没有修改任何文件:\n  %s":                     "rename conflicts, nothing was changed:\n  %s",
+	"重命名失败，已撤销所有修改: %v":                         "rename failed, all changes were rolled back: %v",
+	"源目录不能为空":                                   "source directory must not be empty",
+	"源目录不存在: %v":                                "source directory does not exist: %v",
+	"统计源目录失败: %v":                               "failed to scan the source directory: %v",
+	"获取输出目录绝对路径失败: %v":                          "failed to get the absolute output path: %v",
+	"创建输出目录失败: %v":                              "failed to create the output directory: %v",
+	"不支持的压缩格式: %v":                              "unsupported compression format: %v",
+	"压缩失败: %v":                                  "compression failed: %v",
+	"删除源目录失败: %v":                               "failed to remove the source directory: %v",
+	"%s 不是普通文件":                                 "%s is not a regular file",
+	"获取文件大小失败: %v":                              "failed to get the file size: %v",
+	"分片 %d 处理失败: %v":                            "part %d failed: %v",
+	"计算校验和失败: %v":                               "failed to compute the checksum: %v",
+	"写入大小不匹配：期望 %d，实际 %d":                       "size mismatch: expected %d, wrote %d",
+	"查找分片文件失败: %v":                              "failed to find part files: %v",
+	"未找到分片文件":                                   "no part files found",
+	"打开分片文件失败: %v":                              "failed to open the part file: %v",
+	"分片已损坏（校验和不一致）: %s":                         "part is corrupted (checksum mismatch): %s",
+	"合并后的文件 %s 的校验和与清单不一致":                      "checksum of the merged file %s does not match the manifest",
+	"删除分片文件失败: %v":                              "failed to remove part files: %v",
+	"删除分片清单失败: %v":                              "failed to remove the part manifest: %v",
+	"删除分片目录失败: %v":                              "failed to remove the part directory: %v",
+	"写入分片清单失败: %v":                              "failed to write the part manifest: %v",
+	"分片目录中有 %d 个分片清单，无法确定要合并的分片":                "the directory has %d part manifests, cannot tell which parts to merge",
+	"读取分片清单失败: %v":                              "failed to read the part manifest: %v",
+	"分片清单 %s 格式错误":                              "malformed part manifest %s",
+	"缺少分片: %s":                                  "missing part: %s",
+	"无法访问分片 %s: %v":                             "cannot access part %s: %v",
+	"分片大小不一致: %s（应为 %d 字节，实际为 %d 字节）":           "part size mismatch: %s (expected %d bytes, got %d bytes)",
+	"分片校验失败，共 %d 个问题:\n  %s":                    "part verification failed with %d problems:\n  %s",
+	"写入切分进度失败: %v":                              "failed to save split progress: %v",
+	"写入合并进度失败: %v":                              "failed to save merge progress: %v",
+	"找不到 %s 的本地文件头":                             "local file header of %s not found",
+	"无法解析zip中央目录":                               "cannot parse the zip central directory",
+	"zip中央目录已损坏":                                "zip central directory is corrupted",
+	"zip64结束记录已损坏":                              "zip64 end record is corrupted",
+	"无法打开分卷 %s: %v":                             "cannot open volume %s: %v",
+	"分卷zip的中央目录已损坏: %s":                         "central directory of the split zip is corrupted: %s",
+	"分卷压缩需要写入多个文件，不能写入数据流":                      "split archives need multiple files and cannot be written to a stream",
+	"无法识别的压缩格式，请指定格式":                           "unrecognized compression format, specify the format",
+	"无法写入临时文件: %v":                              "cannot write the temporary file: %v",
+	"无法访问源目录: %v":                               "cannot access the source directory: %v",
+	"创建目标目录失败: %v":                              "failed to create the target directory: %v",
+	"无法解析源目录: %v":                               "cannot resolve the source directory: %v",
+	"无法解析目标目录: %v":                              "cannot resolve the target directory: %v",
+	"源目录和目标目录相同":                                "source and target directories are the same",
+	"目标目录 %s 在源目录 %s 中":                         "target directory %s is inside the source directory %s",
+	"源目录 %s 在目标目录 %s 中":                         "source directory %s is inside the target directory %s",
+	"同步 %s 失败: %v":                              "failed to sync %s: %v",
+	"\n%d 个目录":                                  "\n%d directories",
+	"，%d 个文件":                                   ", %d files",
+	"，共 %s":                                     ", %s total",
+	"监视模式不支持指定包含目录、删除和排序":                       "watch mode does not support including directories, deleting or sorting",
+	"无法创建文件监视: %v":                              "cannot create a file watcher: %v",
+	"已达到结果数量":                                   "result limit reached",
+	"监视时出错: %v":                                 "watch error: %v",
+	"无法监视目录: %v":                                "cannot watch the directory: %v",
+	"AES加密数据已损坏":                                "AES encrypted data is corrupted",
+	"文件已损坏（AES认证码不匹配）":                          "the file is corrupted (AES authentication code mismatch)",
+	"不支持的AES密钥长度: %d":                           "unsupported AES key length: %d",
+	"缺少AES加密信息":                                 "missing AES encryption information",
+	"加密数据已损坏":                                   "encrypted data is corrupted",
+	"不支持的压缩方法: %d":                              "unsupported compression method: %d",
+
+	// pkg/history、idgen、logger、logtail、markdown、metrics
+	"读取历史记录失败: %v":                              "failed to read history: %v",
+	"没有第 %d 条最近的历史记录":                           "there is no history entry %d back",
+	"历史记录 %d 不存在":                               "history entry %d does not exist",
+	"创建历史记录目录失败: %v":                            "failed to create the history directory: %v",
+	"写入历史记录失败: %v":                              "failed to write history: %v",
+	"清空历史记录失败: %v":                              "failed to clear history: %v",
+	"UUID格式无效: %s":                              "invalid UUID: %s",
+	"ULID格式无效: %s":                              "invalid ULID: %s",
+	"节点号必须在 0-%d 之间":                            "node number must be between 0 and %d",
+	"起始时间无效: %d":                                "invalid epoch: %d",
+	"Snowflake ID不能为负数: %d":                     "Snowflake ID must not be negative: %d",
+	"ID不能为空":                                    "ID must not be empty",
+	"无法识别的ID: %s（支持 UUID、ULID 和 Snowflake）":     "unrecognized ID: %s (supported: UUID, ULID and Snowflake)",
+	"节点为随机生成，不是MAC地址":                           "the node is random, not a MAC address",
+	"基于名称的MD5哈希，无法还原原始名称":                       "name-based MD5 hash, the original name cannot be recovered",
+	"随机生成，不含时间信息":                               "random, contains no time information",
+	"基于名称的SHA-1哈希，无法还原原始名称":                     "name-based SHA-1 hash, the original name cannot be recovered",
+	"Snowflake ID超出范围: %s":                      "Snowflake ID out of range: %s",
+	"无效的日志级别: %s（可选: debug, info, warn, error）": "invalid log level: %s (choices: debug, info, warn, error)",
+	"读取文件信息失败 %s: %v":                           "failed to stat %s: %v",
+	"读取文件失败 %s: %v":                             "failed to read %s: %v",
+	"重新打开文件 %s":                                 "reopening file %s",
+	"文件 %s 被截断，从头开始读取":                          "file %s was truncated, reading from the start",
+	"[图片]":         "[image]",
+	"[图片: ":        "[image: ",
+	"读取指标记录失败: %v": "failed to read metrics: %v",
+	"创建指标目录失败: %v": "failed to create the metrics directory: %v",
+	"写入指标记录失败: %v": "failed to write metrics: %v",
+	"清空指标记录失败: %v": "failed to clear metrics: %v",
+
+	// pkg/mockdata
+	"字段 %s 的 end 无效: %v":           "field %s has an invalid end: %v",
+	"字段 %s 的 start 无效: %v":         "field %s has an invalid start: %v",
+	"字段 %s 的 start 晚于 end":         "field %s has start after end",
+	"min（起始值，默认1）":                 "min (start value, default 1)",
+	"自增序号":                         "auto-increment sequence",
+	"min、max（默认0-1000）":            "min, max (default 0-1000)",
+	"随机整数":                         "random integer",
+	"min、max（默认0-1）、decimals（默认2）": "min, max (default 0-1), decimals (default 2)",
+	"随机小数":                         "random decimal",
+	"布尔值":                          "boolean",
+	"values（必填）":                   "values (required)",
+	"从候选值中随机选择":                    "random choice from the values",
+	"固定值":                          "constant",
+	"英文姓名":                         "English full name",
+	"英文名":                          "English first name",
+	"英文姓":                          "English last name",
+	"中文姓名":                         "Chinese full name",
+	"用户名":                          "username",
+	"domain（默认为 example.com 等保留域名）": "domain (defaults to reserved domains such as example.com)",
+	"邮箱地址":   "email address",
+	"手机号":    "mobile phone number",
+	"IPv4地址": "IPv4 address",
+	"IPv6地址（2001:db8::/32 文档地址段）": "IPv6 address (2001:db8::/32 documentation range)",
+	"MAC地址（本地管理地址）":               "MAC address (locally administered)",
+	"域名":                          "domain name",
+	"start、end（默认最近30天）、format（rfc3339、unix、unix_ms 或Go时间格式）": "start, end (default: the last 30 days), format (rfc3339, unix, unix_ms or a Go time layout)",
+	"start、end、format（默认 2006-01-02）":                         "start, end, format (default 2006-01-02)",
+	"日期":             "date",
+	"单词":             "word",
+	"句子":             "sentence",
+	"读取schema失败: %v": "failed to read the schema: %v",
+	"解析schema失败: %v": "failed to parse the schema: %v",
+	"schema中没有定义字段":  "the schema defines no fields",
+	"第%d个字段缺少名称":     "field %d has no name",
+	"字段名重复: %s":      "duplicate field name: %s",
+	"字段 %s 的类型无效: %q（支持的类型见 mock types）": "field %s has an invalid type: %q (see mock types for supported types)",
+	"enum字段 %s 缺少 values":                "enum field %s has no values",
+	"字段 %s 的 min 大于 max":                 "field %s has min greater than max",
+	"字段 %s 的 null_rate 必须在0到1之间":         "null_rate of field %s must be between 0 and 1",
+	"不支持的格式: %s（可选: jsonl, json, csv）":   "unsupported format: %s (choices: jsonl, json, csv)",
+	"编码字段 %s 失败: %v":                     "failed to encode field %s: %v",
+
+	// pkg/netdiag
+	"api方法失败, 回退到使用ipconfig命令": "API lookup failed, falling back to ipconfig",
+	"查询失败: %v":                      "lookup failed: %v",
+	"不支持的平台":                        "unsupported platform",
+	"WMI查询失败: %v":                   "WMI query failed: %v",
+	"未找到DNS服务器信息":                   "no DNS server information found",
+	"必须指定监听地址":                      "a listen address is required",
+	"必须指定转发目标地址":                    "a target address is required",
+	"不支持的协议: %s（支持 tcp, udp, both）": "unsupported protocol: %s (supported: tcp, udp, both)",
+	"监听TCP地址失败: %v":                 "failed to listen on TCP: %v",
+	"TCP转发已启动: %s -> %s":            "TCP forwarding started: %s -> %s",
+	"解析UDP监听地址失败: %v":               "failed to resolve the UDP listen address: %v",
+	"监听UDP地址失败: %v":                 "failed to listen on UDP: %v",
+	"UDP转发已启动: %s -> %s":            "UDP forwarding started: %s -> %s",
+	"接收TCP连接失败: %v":                 "failed to accept a TCP connection: %v",
+	"[TCP] 拒绝连接 %s: 已达到最大连接数 %d":    "[TCP] rejected %s: connection limit %d reached",
+	"[TCP] %s 连接目标 %s 失败: %v":       "[TCP] %s failed to connect to %s: %v",
+	"[TCP] 新连接 %s -> %s":            "[TCP] new connection %s -> %s",
+	"[TCP] 连接 %s 空闲超时":              "[TCP] connection %s idle timeout",
+	"[TCP] 连接关闭 %s，发送 %d 字节，接收 %d 字节，持续 %s": "[TCP] connection %s closed, sent %d bytes, received %d bytes, lasted %s",
+	"解析UDP目标地址失败: %v":                       "failed to resolve the UDP target address: %v",
+	"[UDP] 会话 %s 空闲超时":                      "[UDP] session %s idle timeout",
+	"读取UDP数据失败: %v":                         "failed to read UDP data: %v",
+	"[UDP] 丢弃来自 %s 的数据: 已达到最大会话数 %d":        "[UDP] dropped data from %s: session limit %d reached",
+	"[UDP] %s 连接目标 %s 失败: %v":               "[UDP] %s failed to connect to %s: %v",
+	"[UDP] 新会话 %s -> %s":                    "[UDP] new session %s -> %s",
+	"收到中断信号，正在停止转发...":                      "Interrupted, stopping forwarding...",
+	"无效的URL: %v":                            "invalid URL: %v",
+	"HTTP/3仅支持https协议":                      "HTTP/3 only supports https",
+	"无效的IP地址: %s":                           "invalid IP address: %s",
+	"最大MTU不能小于最小MTU":                        "maximum MTU must not be less than the minimum MTU",
+	"无法解析主机名: %v":                           "cannot resolve the host name: %v",
+	"创建ICMP套接字失败: %v":                       "failed to create an ICMP socket: %v",
+	"设置DF标志失败: %v":                          "failed to set the DF flag: %v",
+	"目标主机对 %d 字节的探测包无响应":                    "the target did not answer %d-byte probes",
+	"%5d 字节: 成功":                            "%5d bytes: ok",
+	"%5d 字节: 超过本地接口MTU":                     "%5d bytes: exceeds the local interface MTU",
+	"%5d 字节: 需要分片 (来自 %s, 下一跳MTU %d)":       "%5d bytes: fragmentation needed (from %s, next-hop MTU %d)",
+	"%5d 字节: 需要分片 (来自 %s)":                  "%5d bytes: fragmentation needed (from %s)",
+	"%5d 字节: 超时":                            "%5d bytes: timeout",
+	"%s 平台暂不支持设置DF标志":                       "setting the DF flag is not supported on %s",
+	"启动ping命令失败: %v":                        "failed to start ping: %v",
+	"ping命令执行失败: %v":                        "ping failed: %v",
+	"%s 没有响应":                               "%s did not respond",
+	"开始扫描主机 %s 的端口 %d":                      "scanning port %[2]d on %[1]s",
+	"扫描主机 %s 的端口 %d 失败: %v":                 "failed to scan port %[2]d on %[1]s: %[3]v",
+	"未知服务":                                  "unknown",
+	"无法解析主机名 %s: %v":                        "cannot resolve host name %s: %v",
+	"完成扫描主机 %s 从端口 %d 到 %d，共发现 %d 个开放端口":    "finished scanning %s ports %d to %d, found %d open ports",
+	"完成扫描主机 %s 的常用端口，共发现 %d 个开放端口":          "finished scanning common ports on %s, found %d open ports",
+	"完成扫描主机 %s 的指定端口列表，共发现 %d 个开放端口":        "finished scanning the given ports on %s, found %d open ports",
+	"启动速度测试服务器在 %s":                         "speed test server started on %s",
+	"读取上传数据失败":                              "failed to read the uploaded data",
+	"\n==== 网络抓包统计信息 ====":                  "\n==== Capture statistics ====",
+	"捕获时间: %s\n":                            "Duration: %s\n",
+	"数据包总数: %d\n":                           "Packets: %d\n",
+	"总字节数: %d bytes\n":                      "Bytes: %d bytes\n",
+	"平均速率: %.2f 包/秒, %.2f bytes/秒\n":        "Average rate: %.2f packets/s, %.2f bytes/s\n",
+	"\n协议分布:":                               "\nProtocols:",
+	"\n最活跃的源IP地址:":                          "\nTop source IPs:",
+	"\n最活跃的目标IP地址:":                         "\nTop destination IPs:",
+	"\n最活跃的端口:":                             "\nTop ports:",
+	"打开网络接口失败: %v":                          "failed to open the interface: %v",
+	"设置过滤器失败: %v":                           "failed to set the filter: %v",
+	"创建pcap文件失败: %v":                        "failed to create the pcap file: %v",
+	"写入pcap文件头失败: %v":                       "failed to write the pcap header: %v",
+	"开始抓包，接口: %s, 过滤器: %s":                  "capture started, interface: %s, filter: %s",
+	"写入pcap文件失败: %v":                        "failed to write the pcap file: %v",
+	"停止抓包...":                               "Stopping capture...",
+	"写入详细信息到文件失败: %v":                       "failed to write details to the file: %v",
+	"获取网络接口列表失败: %v":                        "failed to list network interfaces: %v",
+	"无描述":                                   "no description",
+	"本地测试服务器":                               "local test server",
+	"自定义测试服务器":                              "custom test server",
+	"速度测试已取消":                               "speed test canceled",
+	"%s失败: %v":                              "%s failed: %v",
+	"测试延迟":                                  "latency test",
+	"测试下载速度":                                "download test",
+	"测试上传速度":                                "upload test",
+	"创建原始套接字失败: %v":                         "failed to create a raw socket: %v",
+	"设置TTL失败: %v":                           "failed to set TTL: %v",
+	"发送ICMP包失败: %v":                         "failed to send the ICMP packet: %v",
+	"设置超时失败: %v":                            "failed to set the timeout: %v",
+	"创建连接失败: %v":                            "failed to create the connection: %v",
+	"序列化ICMP消息失败: %v":                       "failed to marshal the ICMP message: %v",
+
+	// pkg/netutils
+	"目录 %s 中已存在CA":               "a CA already exists in %s",
+	"创建CA目录失败: %v":               "failed to create the CA directory: %v",
+	"生成根CA私钥失败: %v":              "failed to generate the root CA key: %v",
+	"生成序列号失败: %v":                "failed to generate a serial number: %v",
+	"生成根CA证书失败: %v":              "failed to generate the root CA certificate: %v",
+	"解析根CA证书失败: %v":              "failed to parse the root CA certificate: %v",
+	"生成中间CA私钥失败: %v":             "failed to generate the intermediate CA key: %v",
+	"解析证书签名请求失败: %v":             "failed to parse the certificate signing request: %v",
+	"解析中间CA证书失败: %v":             "failed to parse the intermediate CA certificate: %v",
+	"目录 %s 中没有CA，请先执行初始化":        "no CA in %s, initialize it first",
+	"读取CA索引失败: %v":               "failed to read the CA index: %v",
+	"解析CA索引失败: %v":               "failed to parse the CA index: %v",
+	"生成私钥失败: %v":                 "failed to generate the private key: %v",
+	"解析签发的证书失败: %v":              "failed to parse the issued certificate: %v",
+	"序列号前缀 %s 匹配到多个证书":           "serial prefix %s matches more than one certificate",
+	"未找到序列号为 %s 的证书":             "no certificate with serial %s",
+	"证书 %s 已被吊销":                 "certificate %s is already revoked",
+	"无效的序列号: %s":                 "invalid serial: %s",
+	"序列号不能为空":                    "serial must not be empty",
+	"生成CRL失败: %v":                "failed to generate the CRL: %v",
+	"编码CA索引失败: %v":               "failed to encode the CA index: %v",
+	"写入CA索引失败: %v":               "failed to write the CA index: %v",
+	"读取中间证书 %s 失败: %v":           "failed to read intermediate certificate %s: %v",
+	"严格模式下必须指定根证书文件":             "a root certificate file is required in strict mode",
+	"读取根证书 %s 失败: %v":            "failed to read root certificate %s: %v",
+	"无法读取证书文件: %v":               "cannot read the certificate file: %v",
+	"解析证书失败: %v":                 "failed to parse the certificate: %v",
+	"未在文件中找到有效的证书":               "no valid certificate found in the file",
+	"无效的地址: %v":                  "invalid address: %v",
+	"TLS连接失败: %v":                "TLS connection failed: %v",
+	"远程主机未提供证书":                  "the remote host presented no certificate",
+	"证书 #%d: ":                   "Certificate #%d: ",
+	"%s已过期，过期时间：%v":              "%sexpired on %v",
+	"%s尚未生效，生效时间：%v":             "%snot valid until %v",
+	"%s即将过期，剩余 %d 天":             "%sexpires in %d days",
+	"%s不是由受信任的CA颁发":              "%snot issued by a trusted CA",
+	"证书与主机名 %s 不匹配: %s":          "certificate does not match host name %s: %s",
+	"创建证书文件失败: %v":               "failed to create the certificate file: %v",
+	"创建私钥文件失败: %v":               "failed to create the private key file: %v",
+	"编码私钥失败: %v":                 "failed to encode the private key: %v",
+	"写入私钥文件失败: %v":               "failed to write the private key file: %v",
+	"读取签名者证书失败: %v":              "failed to read the signer certificate: %v",
+	"解析签名者证书失败":                  "failed to parse the signer certificate",
+	"解析签名者证书失败: %v":              "failed to parse the signer certificate: %v",
+	"读取签名者私钥失败: %v":              "failed to read the signer key: %v",
+	"解析签名者私钥失败: %v":              "failed to parse the signer key: %v",
+	"未找到PEM格式的私钥":                "no PEM private key found",
+	"不支持的私钥类型: %T":               "unsupported private key type: %T",
+	"解析目标文件失败: %v":               "failed to parse the targets file: %v",
+	"第 %d 个目标未指定 file 或 address": "target %d has neither file nor address",
+	"不支持的PEM类型: %s":              "unsupported PEM type: %s",
+	"未找到有效的PEM内容":                "no valid PEM content found",
+	"无法识别的DER内容":                 "unrecognized DER content",
+	"解析PKCS#7失败: %v":             "failed to parse PKCS#7: %v",
+	"不支持的PKCS#7内容类型: %v":         "unsupported PKCS#7 content type: %v",
+	"解析PKCS#7 SignedData失败: %v":  "failed to parse PKCS#7 SignedData: %v",
+	"解析PKCS#7中的证书失败: %v":         "failed to parse certificates in PKCS#7: %v",
+	"PKCS#7中不包含证书":               "PKCS#7 contains no certificates",
+	"证书":                         "certificate",
+	"证书 ":                        "certificate ",
+	"证书吊销列表":                     "certificate revocation list",
+	"证书签名请求":                     "certificate signing request",
+	"公钥":                         "public key",
+	"私钥 (":                       "private key (",
+	"不支持的目标格式: %s":               "unsupported target format: %s",
+	"生成证书签名请求失败: %v":             "failed to generate the certificate signing request: %v",
+	"不是证书签名请求: %s":               "not a certificate signing request: %s",
+	"证书签名请求的签名无效: %v":            "invalid certificate signing request signature: %v",
+	"签名者证书不是CA证书":                "the signer certificate is not a CA certificate",
+	"不支持的证书用途: %s":               "unsupported certificate usage: %s",
+	"读取私钥文件失败: %v":               "failed to read the private key file: %v",
+	"解析私钥失败: %v":                 "failed to parse the private key: %v",
+	"私钥与证书不匹配":                   "the private key does not match the certificate",
+	"读取CA证书 %s 失败: %v":           "failed to read CA certificate %s: %v",
+	"编码PKCS#12失败: %v":            "failed to encode PKCS#12: %v",
+	"写入PKCS#12文件失败: %v":          "failed to write the PKCS#12 file: %v",
+	"读取PKCS#12文件失败: %v":          "failed to read the PKCS#12 file: %v",
+	"解析PKCS#12失败（密码是否正确？）: %v":   "failed to parse PKCS#12 (is the password correct?): %v",
+	"RSA密钥长度不能小于2048":            "RSA keys must be at least 2048 bits",
+	"ECDSA密钥长度只能为256、384或521":    "ECDSA key size must be 256, 384 or 521",
+	"编码公钥失败: %v":                 "failed to encode the public key: %v",
+	"写入公钥文件失败: %v":               "failed to write the public key file: %v",
+	"第 %d 行不是有效的公钥":              "line %d is not a valid public key",
+	"读取公钥失败: %v":                 "failed to read the public key: %v",
+	"未找到公钥":                      "no public key found",
+	"未获取到主机公钥":                   "no host key received",
+	"连接失败: %v":                   "connection failed: %v",
+	"已获取主机公钥":                    "host key received",
+
+	// pkg/process
+	"进程 %d 不存在":            "process %d does not exist",
+	"进程不存在或无法访问: %v":       "process does not exist or is not accessible: %v",
+	"找不到进程 PID=%d":         "process PID=%d not found",
+	"找不到进程 PID=%d: %v":     "process PID=%d not found: %v",
+	"无法终止进程 PID=%d: %v":    "cannot kill process PID=%d: %v",
+	"启动进程失败: %v":           "failed to start the process: %v",
+	"进程树为空":                "the process tree is empty",
+	"内存 (MB)":              "Memory (MB)",
+	"系统空闲":                 "System Idle",
+	"%s (PID=%d) [特殊系统进程]": "%s (PID=%d) [special system process]",
+	"进程列表为空":               "the process list is empty",
+	"未找到PID为 %d 的进程":       "no process with PID %d",
+
+	// pkg/remote
+	"登录 %s 失败: %v":  "failed to log in to %s: %v",
+	"连接 %s 失败: %v":  "failed to connect to %s: %v",
+	"创建SSH会话失败: %v": "failed to create an SSH session: %v",
+	"远程命令执行失败: %s":  "remote command failed: %s",
+	"读取私钥失败: %v":    "failed to read the private key: %v",
+	"解析私钥 %s 失败（暂不支持有密码的私钥，请使用ssh-agent）: %v":          "failed to parse private key %s (encrypted keys are not supported, use ssh-agent): %v",
+	"没有可用的SSH认证方式，请启动ssh-agent或在主机配置中指定 identity_file": "no SSH authentication method available, start ssh-agent or set identity_file in the host config",
+	"获取用户主目录失败: %v":                      "failed to get the home directory: %v",
+	"读取known_hosts失败（可先用ssh登录一次该主机）: %v": "failed to read known_hosts (log in to the host with ssh once first): %v",
+	"远程搜索暂不支持指定包含目录":                     "remote search does not support including directories",
+	"远程搜索暂不支持按内容筛选":                      "remote search does not support content filters",
+	"远程搜索暂不支持忽略文件":                       "remote search does not support ignore files",
+	"主机不能为空":                             "host must not be empty",
+	"无效的主机: %s":                          "invalid host: %s",
+	"主机配置 %s 未指定 address":                "host config %s has no address",
+	"获取当前用户失败: %v":                       "failed to get the current user: %v",
+
+	// pkg/service
+	"未找到systemctl，当前只支持systemd管理的服务": "systemctl not found, only systemd services are supported",
+	"系统没有使用systemd作为初始化系统，无法管理服务":    "the system does not use systemd as its init system, cannot manage services",
+	"服务不存在: %s":         "service does not exist: %s",
+	"无法解析时间 %q: %v":     "cannot parse time %q: %v",
+	"执行 journalctl %v":  "running journalctl %v",
+	"执行 systemctl %v":   "running systemctl %v",
+	"%s失败，需要root权限: %s": "%s failed, root privileges required: %s",
+	"%s失败: %s":          "%s failed: %s",
+	"不支持在%s上管理服务，目前只支持systemd和Windows服务": "managing services is not supported on %s, only systemd and Windows services are supported",
+	"连接服务控制管理器失败: %v":                    "failed to connect to the service control manager: %v",
+	"打开服务失败: %v":                         "failed to open the service: %v",
+	"查询服务状态失败: %v":                       "failed to query the service status: %v",
+	"启动服务失败: %v":                         "failed to start the service: %v",
+	"停止服务失败: %v":                         "failed to stop the service: %v",
+	"等待服务停止超时: %s":                       "timed out waiting for the service to stop: %s",
+	"Windows服务没有统一的日志，请在事件查看器（eventvwr）或服务自身的日志文件中查看": "Windows services have no common log, check Event Viewer (eventvwr) or the service's own log files",
+	"，请以管理员身份运行": ", run as administrator",
+
+	// pkg/smart
+	"执行 smartctl %v":       "running smartctl %v",
+	"执行smartctl失败: %v":     "smartctl failed: %v",
+	"解析smartctl输出失败: %v":   "failed to parse smartctl output: %v",
+	"smartctl退出码为%d":       "smartctl exited with code %d",
+	"已有%d个新增缺陷块":           "%d grown defects",
+	"已重映射%d个扇区":            "%d reallocated sectors",
+	"发生过%d次重映射":            "%d reallocation events",
+	"有%d个待重映射的扇区":          "%d pending sectors",
+	"有%d个无法修复的扇区":          "%d uncorrectable sectors",
+	"SMART整体评估未通过，请尽快备份数据": "SMART overall assessment failed, back up your data as soon as possible",
+	"温度过高: %d°C":           "temperature too high: %d°C",
+	"属性 %s 曾低于阈值":          "attribute %s has fallen below its threshold",
+	"NVMe严重警告: 0x%02x":     "NVMe critical warning: 0x%02x",
+	"备用空间不足: %d%%":         "available spare low: %d%%",
+	"已用寿命: %d%%":           "percentage used: %d%%",
+	"有%d个介质错误":             "%d media errors",
+	"读取/sys/block失败: %v":   "failed to read /sys/block: %v",
+	"未找到设备，未安装smartctl时只能检查 /sys/block 下的磁盘": "device not found, without smartctl only disks under /sys/block can be checked",
+	"未找到smartctl，请先安装smartmontools":          "smartctl not found, install smartmontools first",
+
+	// pkg/sysinfo
+	"获取%s失败: %v": "failed to get %s: %v",
+	"主机信息":       "host information",
+	"内存信息":       "memory information",
+	"交换空间信息":     "swap information",
+	"磁盘信息":       "disk information",
+	"CPU信息":      "CPU information",
+	"CPU使用率":     "CPU usage",
+
+	// pkg/tasks
+	"读取任务文件失败: %v":                  "failed to read the task file: %v",
+	"解析任务文件失败: %v":                  "failed to parse the task file: %v",
+	"任务文件中没有步骤":                     "the task file has no steps",
+	"第 %d 个步骤必须指定 run 或 shell 其中之一": "step %d must specify either run or shell",
+	"任务嵌套层数超过 %d 层，请检查任务文件是否递归调用自身": "tasks nested more than %d levels deep, check whether the task file calls itself",
+	"第 %d 个步骤: %v":                  "step %d: %v",
+	"环境变量 %s: %v":                   "environment variable %s: %v",
+	"步骤执行超过 %s":                     "step timed out after %s",
+	"未定义的变量: %s":                    "undefined variable: %s",
+	"命令中的引号不匹配: %s":                 "unbalanced quotes in command: %s",
+
+	// pkg/textproc
+	"行 %d: %v":                  "line %d: %v",
+	"应用打印模式时出错：%v":              "error applying the print mode: %v",
+	"读取输入时出错：%v":                "error reading input: %v",
+	"无效的长度比较值：%s":               "invalid length comparison value: %s",
+	"不支持的长度比较操作符：%s":            "unsupported length comparison operator: %s",
+	"无效的length表达式：%s":           "invalid length expression: %s",
+	"无效的正则表达式：%s":               "invalid regular expression: %s",
+	"无效的表达式：%s":                 "invalid expression: %s",
+	"不支持的操作符：%s":                "unsupported operator: %s",
+	"无效的字段索引：%s":                "invalid field index: %s",
+	"字段索引超出范围：%d（总字段数：%d）":      "field index out of range: %d (%d fields)",
+	"读取错误: %v":                  "read error: %v",
+	"无效的文件模式: %v":               "invalid file pattern: %v",
+	"警告: 访问 %s 时出错: %v\n":       "Warning: error accessing %s: %v\n",
+	"警告: 无法打开 %s: %v\n":         "Warning: cannot open %s: %v\n",
+	"警告: 处理 %s 时出错: %v\n":       "Warning: error processing %s: %v\n",
+	"遍历目录错误: %v":                "error walking the directory: %v",
+	"\n共找到 %d 个匹配项，在 %d 个文件中\n": "\nFound %d matches in %d files\n",
+	"不匹配任何内容":                   "matches nothing",
+	"空匹配":                       "empty match",
+	"字面量 ":                      "literal ",
+	"（忽略大小写）":                   " (case-insensitive)",
+	"除换行符外的任意字符":                "any character except newline",
+	"任意字符（包括换行符）":               "any character (including newline)",
+	"行首":                        "start of line",
+	"行尾":                        "end of line",
+	"文本开头":                      "start of text",
+	"文本结尾（$）":                   "end of text ($)",
+	"文本结尾":                      "end of text",
+	"单词边界":                      "word boundary",
+	"非单词边界":                     "non-word boundary",
+	"第%d个捕获组，名称为 %s":            "capture group %d, named %s",
+	"第%d个捕获组":                   "capture group %d",
+	"重复0次或多次":                   "repeat zero or more times",
+	"重复1次或多次":                   "repeat one or more times",
+	"可选，出现0次或1次":                "optional, zero or one time",
+	"重复至少%d次":                   "repeat at least %d times",
+	"重复%d次":                     "repeat %d times",
+	"重复%d到%d次":                  "repeat %d to %d times",
+	"依次匹配以下各部分":                 "match the following in sequence",
+	"匹配以下任一分支":                  "match any of the following alternatives",
+	"（非贪婪，尽量少）":                 " (lazy, as few as possible)",
+	"（贪婪，尽量多）":                  " (greedy, as many as possible)",
+	"数字":                        "digit",
+	"非数字":                       "non-digit",
+	"单词字符（字母、数字、下划线）":           "word character (letter, digit, underscore)",
+	"非单词字符":                     "non-word character",
+	"空白字符":                      "whitespace",
+	"非空白字符":                     "non-whitespace",
+	"英文字母":                      "letter",
+	"小写字母":                      "lowercase letter",
+	"大写字母":                      "uppercase letter",
+	"十六进制数字":                    "hex digit",
+	"以下字符之一: ":                  "one of: ",
+	"不是以下字符之一: ":                "none of: ",
+
+	// pkg/timeconv、totp、units、version、watch
+	"时间不能为空": "time must not be empty",
+	"无法识别的时间: %s（支持Unix时间戳、RFC3339、2006-01-02 15:04:05 等格式）": "unrecognized time: %s (supported: Unix timestamps, RFC3339, 2006-01-02 15:04:05 and similar formats)",
+	"带小数的时间戳只能以秒为单位: %s":                                     "fractional timestamps must be in seconds: %s",
+	"时间戳无效: %s":   "invalid timestamp: %s",
+	"时间戳超出范围: %s": "timestamp out of range: %s",
+	"不支持的时间戳单位: %s（支持 s、ms、us、ns）": "unsupported timestamp unit: %s (supported: s, ms, us, ns)",
+	"未知的时区: %s":                            "unknown time zone: %s",
+	"密钥不是有效的base32编码: %v":                  "the secret is not valid base32: %v",
+	"无效的URI: %v":                           "invalid URI: %v",
+	"URI应以 otpauth:// 开头":                  "the URI must start with otpauth://",
+	"只支持 otpauth://totp/，不支持 %s":           "only otpauth://totp/ is supported, not %s",
+	"无效的digits参数: %s":                      "invalid digits parameter: %s",
+	"无效的period参数: %s":                      "invalid period parameter: %s",
+	"位数必须在6到8之间: %d":                       "digits must be between 6 and 8: %d",
+	"时间步长必须大于0: %d":                        "period must be greater than 0: %d",
+	"不支持的算法 %q，可选 SHA1、SHA256、SHA512":      "unsupported algorithm %q, choices: SHA1, SHA256, SHA512",
+	"无效的大小 %q，应为数字加单位，如 512K、10M、1.5G":     "invalid size %q, expected a number with a unit such as 512K, 10M, 1.5G",
+	"未知的大小单位 %q（可用: B, K, M, G, T, P）":     "unknown size unit %q (available: B, K, M, G, T, P)",
+	"大小 %q 超出范围":                           "size %q is out of range",
+	"无效的时长 %q，应为数字加单位，如 300ms、30s、1.5h、2d": "invalid duration %q, expected a number with a unit such as 300ms, 30s, 1.5h, 2d",
+	"未知的时长单位 %q（可用: ms, s, m, h, d, w）":    "unknown duration unit %q (available: ms, s, m, h, d, w)",
+	"时长 %q 超出范围":                           "duration %q is out of range",
+	"Toolbox 是一款用go语言编写的多功能命令行工具，提供多种实用工具以完成各种任务。": "Toolbox is a versatile command line tool written in Go that bundles many utilities for everyday tasks.",
+	"间隔必须大于0": "interval must be greater than 0",
+}
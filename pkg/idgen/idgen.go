@@ -0,0 +1,288 @@
+// Package idgen 生成和解析 UUID、ULID 和 Snowflake ID
+//
+// UUID 支持 v4（随机）和 v7（时间有序），ULID 按规范使用毫秒时间戳加80位随机数，
+// Snowflake 使用 41 位毫秒时间戳、10 位节点号和 12 位序列号的经典布局。
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"toolbox/pkg/errs"
+)
+
+// UUID 128位的UUID
+type UUID [16]byte
+
+// NewUUIDv4 生成随机的v4 UUID
+func NewUUIDv4() (UUID, error) {
+	return NewUUIDv4From(rand.Reader)
+}
+
+// NewUUIDv4From 使用r提供的随机数生成v4 UUID，用于生成可复现的测试数据
+func NewUUIDv4From(r io.Reader) (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(r, u[:]); err != nil {
+		return u, err
+	}
+	u.setVersion(4)
+	return u, nil
+}
+
+// NewUUIDv7 生成以毫秒时间戳开头、按时间排序的v7 UUID
+func NewUUIDv7(t time.Time) (UUID, error) {
+	var u UUID
+	if _, err := rand.Read(u[6:]); err != nil {
+		return u, err
+	}
+	ms := uint64(t.UnixMilli())
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u.setVersion(7)
+	return u, nil
+}
+
+// setVersion 设置版本号和RFC 4122变体位
+func (u *UUID) setVersion(version byte) {
+	u[6] = (u[6] & 0x0f) | version<<4
+	u[8] = (u[8] & 0x3f) | 0x80
+}
+
+// String 返回标准的 8-4-4-4-12 格式
+func (u UUID) String() string {
+	s := hex.EncodeToString(u[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// Version 返回版本号
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Variant 返回变体的名称
+func (u UUID) Variant() string {
+	switch {
+	case u[8]&0x80 == 0:
+		return "NCS"
+	case u[8]&0xc0 == 0x80:
+		return "RFC 4122"
+	case u[8]&0xe0 == 0xc0:
+		return "Microsoft"
+	}
+	return "Future"
+}
+
+// gregorianOffset 1582-10-15 到 1970-01-01 之间的100纳秒数，v1/v6 的时间戳从前者开始
+const gregorianOffset = 122192928000000000
+
+// Time 返回v1、v6、v7 UUID中的时间戳，其他版本返回false
+func (u UUID) Time() (time.Time, bool) {
+	var ticks uint64
+	switch u.Version() {
+	case 1:
+		low := uint64(binary.BigEndian.Uint32(u[0:4]))
+		mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+		high := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+		ticks = high<<48 | mid<<32 | low
+	case 6:
+		high := uint64(binary.BigEndian.Uint32(u[0:4]))
+		mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+		low := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+		ticks = high<<28 | mid<<12 | low
+	case 7:
+		ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 | uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+		return time.UnixMilli(int64(ms)).UTC(), true
+	default:
+		return time.Time{}, false
+	}
+	if ticks < gregorianOffset {
+		return time.Time{}, false
+	}
+	ticks -= gregorianOffset
+	return time.Unix(int64(ticks/10000000), int64(ticks%10000000)*100).UTC(), true
+}
+
+// ParseUUID 解析UUID，允许不带连字符、带花括号或 urn:uuid: 前缀，不区分大小写
+func ParseUUID(text string) (UUID, error) {
+	var u UUID
+	s := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(text)), "urn:uuid:")
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	if len(s) == 36 {
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, errs.InvalidInput("UUID格式无效: %s", text)
+		}
+		s = strings.ReplaceAll(s, "-", "")
+	}
+	if len(s) != 32 {
+		return u, errs.InvalidInput("UUID格式无效: %s", text)
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, errs.InvalidInput("UUID格式无效: %s", text)
+	}
+	return u, nil
+}
+
+// crockford ULID 使用的 Crockford base32 字母表
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID 128位的ULID，前48位为毫秒时间戳，后80位为随机数
+type ULID [16]byte
+
+// NewULID 生成ULID
+func NewULID(t time.Time) (ULID, error) {
+	var u ULID
+	if _, err := rand.Read(u[6:]); err != nil {
+		return u, err
+	}
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+	return u, nil
+}
+
+// String 返回26个字符的Crockford base32编码
+func (u ULID) String() string {
+	// 128位按5位一组编码，最高位前补2个0位
+	out := make([]byte, 26)
+	var acc uint64
+	bits := 2
+	pos := 0
+	for _, b := range u {
+		acc = acc<<8 | uint64(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockford[(acc>>uint(bits))&0x1f]
+			pos++
+		}
+	}
+	return string(out)
+}
+
+// Time 返回ULID中的时间戳
+func (u ULID) Time() time.Time {
+	var ms uint64
+	for _, b := range u[:6] {
+		ms = ms<<8 | uint64(b)
+	}
+	return time.UnixMilli(int64(ms)).UTC()
+}
+
+// ParseULID 解析ULID，不区分大小写，按规范将 I、L 视为 1，O 视为 0
+func ParseULID(text string) (ULID, error) {
+	var u ULID
+	s := strings.ToUpper(strings.TrimSpace(text))
+	if len(s) != 26 || s[0] > '7' {
+		return u, errs.InvalidInput("ULID格式无效: %s", text)
+	}
+
+	var acc uint64
+	bits := -2 // 第一个字符的最高2位是填充
+	pos := 0
+	for _, c := range s {
+		switch c {
+		case 'I', 'L':
+			c = '1'
+		case 'O':
+			c = '0'
+		}
+		value := strings.IndexRune(crockford, c)
+		if value < 0 {
+			return u, errs.InvalidInput("ULID格式无效: %s", text)
+		}
+		acc = acc<<5 | uint64(value)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			u[pos] = byte(acc >> uint(bits))
+			pos++
+		}
+	}
+	return u, nil
+}
+
+// DefaultSnowflakeEpoch Twitter Snowflake 的起始时间（毫秒）
+const DefaultSnowflakeEpoch = 1288834974657
+
+// MaxSnowflakeNode 节点号的最大值
+const MaxSnowflakeNode = 1<<10 - 1
+
+// Snowflake Snowflake ID 生成器，同一毫秒内序列号递增，可并发使用
+type Snowflake struct {
+	Epoch int64 // 起始时间（毫秒）
+	Node  int64 // 节点号，0-1023
+
+	mutex    sync.Mutex
+	last     int64
+	sequence int64
+}
+
+// NewSnowflake 创建Snowflake生成器
+func NewSnowflake(epoch, node int64) (*Snowflake, error) {
+	if node < 0 || node > MaxSnowflakeNode {
+		return nil, errs.InvalidInput("节点号必须在 0-%d 之间", MaxSnowflakeNode)
+	}
+	if epoch < 0 || epoch > time.Now().UnixMilli() {
+		return nil, errs.InvalidInput("起始时间无效: %d", epoch)
+	}
+	return &Snowflake{Epoch: epoch, Node: node}, nil
+}
+
+// Next 生成下一个ID，同一毫秒内的序列号用完时等待下一毫秒
+func (s *Snowflake) Next() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now().UnixMilli() - s.Epoch
+	if now < s.last {
+		// 时钟回拨时沿用上次的时间，避免生成重复的ID
+		now = s.last
+	}
+	if now == s.last {
+		s.sequence = (s.sequence + 1) & 0xfff
+		if s.sequence == 0 {
+			for now <= s.last {
+				time.Sleep(100 * time.Microsecond)
+				now = time.Now().UnixMilli() - s.Epoch
+			}
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.last = now
+	return now<<22 | s.Node<<12 | s.sequence
+}
+
+// SnowflakeParts Snowflake ID 的各个组成部分
+type SnowflakeParts struct {
+	Time       time.Time
+	Node       int64 // 10位节点号
+	Datacenter int64 // 节点号的高5位（按数据中心+机器号划分时）
+	Worker     int64 // 节点号的低5位
+	Sequence   int64
+}
+
+// ParseSnowflake 按给定的起始时间拆分Snowflake ID
+func ParseSnowflake(id, epoch int64) (SnowflakeParts, error) {
+	if id < 0 {
+		return SnowflakeParts{}, errs.InvalidInput("Snowflake ID不能为负数: %d", id)
+	}
+	node := (id >> 12) & MaxSnowflakeNode
+	return SnowflakeParts{
+		Time:       time.UnixMilli((id >> 22) + epoch).UTC(),
+		Node:       node,
+		Datacenter: node >> 5,
+		Worker:     node & 0x1f,
+		Sequence:   id & 0xfff,
+	}, nil
+}
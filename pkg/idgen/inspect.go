@@ -0,0 +1,153 @@
+package idgen
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// ID 类型
+const (
+	TypeUUID      = "uuid"
+	TypeULID      = "ulid"
+	TypeSnowflake = "snowflake"
+)
+
+// Field 解析出的附加字段
+type Field struct {
+	Name  string `json:"name"`  // 字段名，如 node、sequence、note
+	Value string `json:"value"` // 字段值
+}
+
+// Info ID 的解析结果
+type Info struct {
+	Input     string     `json:"input"`             // 输入的ID
+	Type      string     `json:"type"`              // uuid、ulid 或 snowflake
+	Canonical string     `json:"canonical"`         // 规范写法
+	Version   int        `json:"version,omitempty"` // UUID版本号
+	Variant   string     `json:"variant,omitempty"` // UUID变体
+	Time      *time.Time `json:"time,omitempty"`    // ID中包含的时间戳
+	Fields    []Field    `json:"fields,omitempty"`  // 其他字段，如节点号、序列号
+}
+
+// Inspect 识别ID的类型并解析其中的版本、时间戳等信息
+//
+// 纯数字按Snowflake解析（epoch为起始时间，毫秒），26个字符按ULID解析，其余按UUID解析。
+func Inspect(text string, epoch int64) (Info, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Info{}, errs.InvalidInput("ID不能为空")
+	}
+
+	switch {
+	case isDigits(text):
+		return inspectSnowflake(text, epoch)
+	case len(text) == 26:
+		return inspectULID(text)
+	}
+	return inspectUUID(text)
+}
+
+// inspectUUID 解析UUID
+func inspectUUID(text string) (Info, error) {
+	u, err := ParseUUID(text)
+	if err != nil {
+		return Info{}, errs.InvalidInput("无法识别的ID: %s（支持 UUID、ULID 和 Snowflake）", text)
+	}
+
+	info := Info{
+		Input:     text,
+		Type:      TypeUUID,
+		Canonical: u.String(),
+		Version:   u.Version(),
+		Variant:   u.Variant(),
+	}
+	if u == (UUID{}) {
+		info.Fields = append(info.Fields, Field{"note", "Nil UUID"})
+		return info, nil
+	}
+	if t, ok := u.Time(); ok {
+		info.Time = &t
+	}
+
+	switch u.Version() {
+	case 1, 6:
+		clockSeq := int(u[8]&0x3f)<<8 | int(u[9])
+		node := net.HardwareAddr(u[10:16])
+		info.Fields = append(info.Fields,
+			Field{"clock_seq", strconv.Itoa(clockSeq)},
+			Field{"node", node.String()},
+		)
+		// 最低位为1表示节点是随机数而不是真实的MAC地址
+		if u[10]&0x01 != 0 {
+			info.Fields = append(info.Fields, Field{"note", i18n.T("节点为随机生成，不是MAC地址")})
+		}
+	case 3:
+		info.Fields = append(info.Fields, Field{"note", i18n.T("基于名称的MD5哈希，无法还原原始名称")})
+	case 4:
+		info.Fields = append(info.Fields, Field{"note", i18n.T("随机生成，不含时间信息")})
+	case 5:
+		info.Fields = append(info.Fields, Field{"note", i18n.T("基于名称的SHA-1哈希，无法还原原始名称")})
+	case 7:
+		info.Fields = append(info.Fields, Field{"random", hex.EncodeToString(u[6:])})
+	}
+	return info, nil
+}
+
+// inspectULID 解析ULID
+func inspectULID(text string) (Info, error) {
+	u, err := ParseULID(text)
+	if err != nil {
+		return Info{}, err
+	}
+	t := u.Time()
+	return Info{
+		Input:     text,
+		Type:      TypeULID,
+		Canonical: u.String(),
+		Time:      &t,
+		Fields: []Field{
+			{"random", hex.EncodeToString(u[6:])},
+			{"uuid", UUID(u).String()},
+		},
+	}, nil
+}
+
+// inspectSnowflake 按给定的起始时间解析Snowflake ID
+func inspectSnowflake(text string, epoch int64) (Info, error) {
+	id, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return Info{}, errs.InvalidInput("Snowflake ID超出范围: %s", text)
+	}
+	parts, err := ParseSnowflake(id, epoch)
+	if err != nil {
+		return Info{}, err
+	}
+	return Info{
+		Input:     text,
+		Type:      TypeSnowflake,
+		Canonical: strconv.FormatInt(id, 10),
+		Time:      &parts.Time,
+		Fields: []Field{
+			{"node", strconv.FormatInt(parts.Node, 10)},
+			{"datacenter_worker", fmt.Sprintf("%d/%d", parts.Datacenter, parts.Worker)},
+			{"sequence", strconv.FormatInt(parts.Sequence, 10)},
+			{"epoch", time.UnixMilli(epoch).UTC().Format(time.RFC3339)},
+		},
+	}, nil
+}
+
+// isDigits 判断字符串是否只包含数字
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+// Package logger 提供分级日志，供各工具包输出调试和诊断信息
+//
+// 默认只输出Info及以上级别的日志到标准错误，命令行通过 --verbose、--quiet 和 --log-file 调整。
+// 作为库使用时，可以调用 SetOutput(io.Discard) 关闭所有日志。
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"toolbox/pkg/i18n"
+)
+
+// Level 日志级别
+type Level int
+
+// 日志级别，数值越大越重要
+const (
+	LevelDebug Level = iota // 调试信息，例如每个端口的扫描过程
+	LevelInfo               // 一般信息
+	LevelWarn               // 警告
+	LevelError              // 错误
+)
+
+// String 返回级别名称
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// ParseLevel 解析日志级别名称
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, i18n.Errorf("无效的日志级别: %s（可选: debug, info, warn, error）", name)
+	}
+}
+
+// Logger 分级日志记录器
+type Logger struct {
+	mu    sync.Mutex
+	out   io.Writer
+	level Level
+}
+
+// New 创建日志记录器
+func New(out io.Writer, level Level) *Logger {
+	return &Logger{out: out, level: level}
+}
+
+// SetOutput 设置日志输出位置
+func (l *Logger) SetOutput(out io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out = out
+}
+
+// SetLevel 设置最低输出级别
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+// Enabled 指定级别的日志是否会被输出
+func (l *Logger) Enabled(level Level) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return level >= l.level && l.out != io.Discard
+}
+
+// Logf 以指定级别输出日志
+func (l *Logger) Logf(level Level, format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level < l.level || l.out == io.Discard {
+		return
+	}
+
+	msg := strings.TrimRight(i18n.Tf(format, args...), "\n")
+	fmt.Fprintf(l.out, "%s [%s] %s\n", time.Now().Format("2006-01-02 15:04:05"), level, msg)
+}
+
+// Debugf 输出调试日志
+func (l *Logger) Debugf(format string, args ...interface{}) { l.Logf(LevelDebug, format, args...) }
+
+// Infof 输出一般信息日志
+func (l *Logger) Infof(format string, args ...interface{}) { l.Logf(LevelInfo, format, args...) }
+
+// Warnf 输出警告日志
+func (l *Logger) Warnf(format string, args ...interface{}) { l.Logf(LevelWarn, format, args...) }
+
+// Errorf 输出错误日志
+func (l *Logger) Errorf(format string, args ...interface{}) { l.Logf(LevelError, format, args...) }
+
+// std 包级默认日志记录器
+var std = New(os.Stderr, LevelInfo)
+
+// Default 返回默认日志记录器
+func Default() *Logger { return std }
+
+// SetOutput 设置默认日志记录器的输出位置
+func SetOutput(out io.Writer) { std.SetOutput(out) }
+
+// SetLevel 设置默认日志记录器的最低输出级别
+func SetLevel(level Level) { std.SetLevel(level) }
+
+// Enabled 默认日志记录器是否会输出指定级别的日志
+func Enabled(level Level) bool { return std.Enabled(level) }
+
+// Debugf 使用默认日志记录器输出调试日志
+func Debugf(format string, args ...interface{}) { std.Logf(LevelDebug, format, args...) }
+
+// Infof 使用默认日志记录器输出一般信息日志
+func Infof(format string, args ...interface{}) { std.Logf(LevelInfo, format, args...) }
+
+// Warnf 使用默认日志记录器输出警告日志
+func Warnf(format string, args ...interface{}) { std.Logf(LevelWarn, format, args...) }
+
+// Errorf 使用默认日志记录器输出错误日志
+func Errorf(format string, args ...interface{}) { std.Logf(LevelError, format, args...) }
@@ -0,0 +1,283 @@
+// Package logtail 同时跟踪多个日志文件，按正则表达式过滤，并跳过时间早于指定时间的行
+//
+// 每行的时间由 timeconv.Extract 识别，识别不出时间的行（如异常堆栈的后续行）沿用上一行的时间，
+// 这样多行日志会整体保留或跳过。跟踪时按固定间隔轮询文件，能处理文件被截断和轮转（重命名后重新创建）。
+package logtail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/logger"
+	"toolbox/pkg/timeconv"
+)
+
+// DefaultPoll 跟踪文件时默认的轮询间隔
+const DefaultPoll = 250 * time.Millisecond
+
+// Options 定义了跟踪日志的选项
+type Options struct {
+	Lines    int              // 开始时每个文件显示的最后几行（过滤后），负数表示全部
+	Follow   bool             // 是否持续输出新写入的行
+	Include  []*regexp.Regexp // 只保留匹配其中任意一个的行，为空时不过滤
+	Exclude  []*regexp.Regexp // 丢弃匹配其中任意一个的行
+	Since    time.Time        // 跳过时间早于该值的行，零值表示不跳过
+	Location *time.Location   // 解释不含时区的时间，nil 表示本地时区
+	Poll     time.Duration    // 轮询间隔，0 表示 DefaultPoll
+}
+
+// Line 输出的一行日志
+type Line struct {
+	File  string    // 文件路径
+	Index int       // 文件在参数中的序号，从0开始
+	Text  string    // 行内容，不含换行符
+	Time  time.Time // 行中识别出的时间，零值表示未知
+}
+
+// filter 按选项过滤行，并记录上一行的时间
+type filter struct {
+	options  Options
+	lastTime time.Time
+}
+
+// keep 判断一行是否输出，同时返回该行的时间
+func (f *filter) keep(text string) (time.Time, bool) {
+	if t, ok := timeconv.Extract(text, f.options.Location); ok {
+		f.lastTime = t
+	}
+	if !f.options.Since.IsZero() && !f.lastTime.IsZero() && f.lastTime.Before(f.options.Since) {
+		return f.lastTime, false
+	}
+	return f.lastTime, matches(text, f.options.Include, f.options.Exclude)
+}
+
+// matches 判断一行是否满足包含和排除条件
+func matches(text string, include, exclude []*regexp.Regexp) bool {
+	for _, re := range exclude {
+		if re.MatchString(text) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, re := range include {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// tailFile 一个正在跟踪的文件
+type tailFile struct {
+	path    string
+	index   int
+	file    *os.File
+	offset  int64
+	partial []byte // 还没有读到换行符的内容
+	filter  filter
+}
+
+// Tail 输出各文件的最后几行，Follow 为真时继续输出新写入的行，直到ctx被取消
+//
+// 开始时任一文件无法打开都会返回错误；跟踪过程中文件暂时不存在（如正在轮转）时等待其重新出现。
+func Tail(ctx context.Context, paths []string, options Options, emit func(Line)) error {
+	if options.Location == nil {
+		options.Location = time.Local
+	}
+	if options.Poll <= 0 {
+		options.Poll = DefaultPoll
+	}
+
+	files := make([]*tailFile, 0, len(paths))
+	defer func() {
+		for _, tf := range files {
+			if tf.file != nil {
+				tf.file.Close()
+			}
+		}
+	}()
+	for i, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return errs.Wrap(err, "无法打开文件 %s: %v", path, err)
+		}
+		tf := &tailFile{path: path, index: i, file: file, filter: filter{options: options}}
+		files = append(files, tf)
+		if err := tf.readInitial(options, emit); err != nil {
+			return err
+		}
+	}
+	if !options.Follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(options.Poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		for _, tf := range files {
+			tf.poll(emit)
+		}
+	}
+}
+
+// readInitial 输出文件最后 Lines 行满足条件的内容，并把读取位置移到文件末尾
+//
+// 不按时间跳过时只需从文件末尾往前读，每次读取的范围加倍，直到收集到足够的行；
+// 按时间跳过时需要从头读取，以便正确得到每行沿用的时间。
+func (tf *tailFile) readInitial(options Options, emit func(Line)) error {
+	info, err := tf.file.Stat()
+	if err != nil {
+		return errs.Wrap(err, "读取文件信息失败 %s: %v", tf.path, err)
+	}
+	size := info.Size()
+	tf.offset = size
+	if options.Lines == 0 {
+		return nil
+	}
+
+	start := int64(0)
+	if options.Lines > 0 && options.Since.IsZero() {
+		start = size - 64*1024
+	}
+	for {
+		if start < 0 {
+			start = 0
+		}
+		lines, err := tf.collect(start, size, options.Lines)
+		if err != nil {
+			return err
+		}
+		// 从文件中间开始读时行数不够，再往前多读一些
+		if start > 0 && len(lines) < options.Lines {
+			start -= size - start
+			continue
+		}
+		for _, line := range lines {
+			emit(line)
+		}
+		return nil
+	}
+}
+
+// collect 读取 [start, end) 范围内满足条件的行，最多保留最后 n 行（n 为负数时全部保留）；
+// start 不在文件开头时丢弃第一行，因为它可能不完整
+func (tf *tailFile) collect(start, end int64, n int) ([]Line, error) {
+	tf.filter.lastTime = time.Time{}
+	reader := bufio.NewReader(io.NewSectionReader(tf.file, start, end-start))
+	if start > 0 {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, nil
+		}
+	}
+
+	var lines []Line
+	for {
+		text, err := reader.ReadString('\n')
+		if text != "" {
+			if err == io.EOF {
+				// 最后一行还没写完，留给跟踪时继续读取
+				tf.partial = []byte(text)
+			} else if t, ok := tf.filter.keep(trimNewline(text)); ok {
+				lines = append(lines, Line{File: tf.path, Index: tf.index, Text: trimNewline(text), Time: t})
+				if n > 0 && len(lines) > n {
+					lines = lines[1:]
+				}
+			}
+		}
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, errs.Wrap(err, "读取文件失败 %s: %v", tf.path, err)
+		}
+	}
+}
+
+// poll 读取文件新写入的内容，并处理截断和轮转
+func (tf *tailFile) poll(emit func(Line)) {
+	if tf.file == nil {
+		// 文件已被轮转，等待新文件出现
+		file, err := os.Open(tf.path)
+		if err != nil {
+			return
+		}
+		logger.Debugf("重新打开文件 %s", tf.path)
+		tf.file, tf.offset, tf.partial = file, 0, nil
+	}
+
+	info, err := tf.file.Stat()
+	if err != nil {
+		return
+	}
+	if info.Size() < tf.offset {
+		logger.Debugf("文件 %s 被截断，从头开始读取", tf.path)
+		tf.offset, tf.partial = 0, nil
+	}
+	if info.Size() > tf.offset {
+		tf.readNew(info.Size(), emit)
+	}
+
+	current, err := os.Stat(tf.path)
+	if err == nil && os.SameFile(info, current) {
+		return
+	}
+	// 路径已被删除或指向新文件，读完旧文件剩余的内容后关闭
+	if rest, err := tf.file.Stat(); err == nil {
+		tf.readNew(rest.Size(), emit)
+	}
+	if len(tf.partial) > 0 {
+		tf.emit(string(tf.partial), emit)
+	}
+	tf.file.Close()
+	tf.file, tf.partial = nil, nil
+}
+
+// readNew 读取从当前位置到 end 的内容，输出其中完整的行
+func (tf *tailFile) readNew(end int64, emit func(Line)) {
+	if end <= tf.offset {
+		return
+	}
+	data := make([]byte, end-tf.offset)
+	n, err := tf.file.ReadAt(data, tf.offset)
+	if err != nil && err != io.EOF {
+		logger.Debugf("读取文件 %s 失败: %v", tf.path, err)
+	}
+	tf.offset += int64(n)
+	data = append(tf.partial, data[:n]...)
+
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		tf.emit(string(data[:i]), emit)
+		data = data[i+1:]
+	}
+	tf.partial = append([]byte(nil), data...)
+}
+
+// emit 过滤并输出一行
+func (tf *tailFile) emit(text string, emit func(Line)) {
+	text = trimNewline(text)
+	if t, ok := tf.filter.keep(text); ok {
+		emit(Line{File: tf.path, Index: tf.index, Text: text, Time: t})
+	}
+}
+
+// trimNewline 去掉行尾的换行符
+func trimNewline(text string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+}
@@ -0,0 +1,133 @@
+// Package markdown 将Markdown文档渲染为带ANSI样式的终端文本或独立的HTML页面
+//
+// 使用 goldmark 解析，支持CommonMark和GitHub扩展（表格、删除线、任务列表、自动链接）。
+package markdown
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// HTMLOptions 定义了渲染HTML的选项
+type HTMLOptions struct {
+	Title     string // 页面标题，为空时使用第一个标题的文字
+	Fragment  bool   // 只输出正文片段，不包含 <html>、<head> 和样式
+	AllowHTML bool   // 保留文档中的原始HTML，默认将其替换为注释
+}
+
+// newMarkdown 创建解析器和HTML渲染器
+func newMarkdown(allowHTML bool) goldmark.Markdown {
+	var rendererOptions []goldmark.Option
+	if allowHTML {
+		rendererOptions = append(rendererOptions, goldmark.WithRendererOptions(html.WithUnsafe()))
+	}
+	return goldmark.New(append(rendererOptions,
+		goldmark.WithExtensions(extension.GFM, extension.CJK),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)...)
+}
+
+// parse 解析Markdown文档
+func parse(source []byte) ast.Node {
+	return newMarkdown(false).Parser().Parse(text.NewReader(source))
+}
+
+// RenderHTML 将Markdown渲染为HTML，默认输出包含样式的完整页面
+func RenderHTML(source []byte, options HTMLOptions) ([]byte, error) {
+	md := newMarkdown(options.AllowHTML)
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	var body bytes.Buffer
+	if err := md.Renderer().Render(&body, source, doc); err != nil {
+		return nil, err
+	}
+	if options.Fragment {
+		return body.Bytes(), nil
+	}
+
+	title := options.Title
+	if title == "" {
+		title = firstHeading(doc, source)
+	}
+	var page bytes.Buffer
+	err := pageTemplate.Execute(&page, struct {
+		Title string
+		Body  template.HTML
+	}{title, template.HTML(body.String())})
+	return page.Bytes(), err
+}
+
+// firstHeading 返回文档中第一个标题的文字
+func firstHeading(doc ast.Node, source []byte) string {
+	var title string
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if heading, ok := n.(*ast.Heading); ok && entering {
+			title = plainText(heading, source)
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return title
+}
+
+// plainText 返回节点中的纯文字
+func plainText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	ast.Walk(n, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := child.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		case *ast.AutoLink:
+			b.Write(t.Label(source))
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
+
+// pageTemplate 独立HTML页面，样式接近GitHub的Markdown显示效果
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 860px; margin: 2em auto; padding: 0 1.5em; font-family: -apple-system, "Segoe UI", "Noto Sans", "PingFang SC", "Microsoft YaHei", sans-serif; line-height: 1.6; color: #1f2328; }
+h1, h2 { border-bottom: 1px solid #d1d9e0; padding-bottom: .3em; }
+h1, h2, h3, h4, h5, h6 { margin: 1.5em 0 .8em; line-height: 1.25; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 85%; }
+code { background: #eff1f3; padding: .2em .4em; border-radius: 6px; }
+pre { background: #f6f8fa; padding: 1em; overflow: auto; border-radius: 6px; line-height: 1.45; }
+pre code { background: none; padding: 0; font-size: 100%; }
+blockquote { margin: 0; padding: 0 1em; color: #59636e; border-left: .25em solid #d1d9e0; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d1d9e0; padding: .4em .8em; }
+tr:nth-child(2n) { background: #f6f8fa; }
+img { max-width: 100%; }
+hr { border: 0; border-top: 1px solid #d1d9e0; margin: 1.5em 0; }
+li input[type=checkbox] { margin-right: .4em; }
+</style>
+</head>
+<body>
+{{.Body}}</body>
+</html>
+`))
@@ -0,0 +1,451 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+	"toolbox/pkg/i18n"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// TerminalOptions 定义了渲染终端文本的选项
+type TerminalOptions struct {
+	Width int // 自动换行的宽度（列数），0 表示80
+}
+
+// 各元素的样式，是否输出颜色由 color.NoColor 统一控制
+var (
+	styleH1     = []color.Attribute{color.Bold, color.FgMagenta}
+	styleH2     = []color.Attribute{color.Bold, color.FgCyan}
+	styleH3     = []color.Attribute{color.Bold}
+	styleCode   = []color.Attribute{color.FgYellow}
+	styleBlock  = []color.Attribute{color.FgGreen}
+	styleLink   = []color.Attribute{color.Underline, color.FgBlue}
+	styleDim    = []color.Attribute{color.Faint}
+	styleItalic = []color.Attribute{color.Italic}
+	styleBold   = []color.Attribute{color.Bold}
+	styleStrike = []color.Attribute{color.CrossedOut}
+)
+
+// bullets 各层无序列表的符号
+var bullets = []string{"•", "◦", "▪"}
+
+// RenderTerminal 将Markdown渲染为适合在终端阅读的文本，按宽度自动换行
+func RenderTerminal(source []byte, options TerminalOptions) string {
+	width := options.Width
+	if width <= 0 {
+		width = 80
+	}
+	r := &terminalRenderer{source: source}
+	lines := r.blocks(parse(source), width, 0)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// span 一段样式相同的文字
+type span struct {
+	text  string
+	attrs []color.Attribute
+}
+
+// terminalRenderer 将语法树渲染为终端文本行
+type terminalRenderer struct {
+	source []byte
+}
+
+// blocks 渲染容器中的各个块，块之间空一行；紧凑列表项中的文字块与后续块之间不空行
+func (r *terminalRenderer) blocks(parent ast.Node, width, depth int) []string {
+	var lines []string
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		block := r.block(n, width, depth)
+		if len(block) == 0 {
+			continue
+		}
+		if len(lines) > 0 && n.Kind() != ast.KindTextBlock && n.PreviousSibling().Kind() != ast.KindTextBlock {
+			lines = append(lines, "")
+		}
+		lines = append(lines, block...)
+	}
+	return lines
+}
+
+// block 渲染一个块
+func (r *terminalRenderer) block(n ast.Node, width, depth int) []string {
+	switch n := n.(type) {
+	case *ast.Heading:
+		return r.heading(n, width)
+	case *ast.Paragraph, *ast.TextBlock:
+		return wrap(r.inlines(n, nil), width)
+	case *ast.ThematicBreak:
+		return []string{paint(strings.Repeat("─", width), styleDim)}
+	case *ast.FencedCodeBlock:
+		lines := r.codeLines(n)
+		if lang := string(n.Language(r.source)); lang != "" {
+			lines = append([]string{paint("  "+lang, styleDim)}, lines...)
+		}
+		return lines
+	case *ast.CodeBlock:
+		return r.codeLines(n)
+	case *ast.HTMLBlock:
+		var lines []string
+		for i := 0; i < n.Lines().Len(); i++ {
+			line := n.Lines().At(i)
+			lines = append(lines, paint(strings.TrimRight(string(line.Value(r.source)), "\r\n"), styleDim))
+		}
+		return lines
+	case *ast.Blockquote:
+		lines := r.blocks(n, width-2, depth)
+		for i, line := range lines {
+			lines[i] = paint("│ ", styleDim) + line
+		}
+		return lines
+	case *ast.List:
+		return r.list(n, width, depth)
+	case *extast.Table:
+		return r.table(n)
+	}
+	return r.blocks(n, width, depth)
+}
+
+// heading 渲染标题，一级和二级标题下方加横线
+func (r *terminalRenderer) heading(n *ast.Heading, width int) []string {
+	style := styleH3
+	switch n.Level {
+	case 1:
+		style = styleH1
+	case 2:
+		style = styleH2
+	}
+	lines := wrap(r.inlines(n, style), width)
+	if n.Level <= 2 {
+		underline := "═"
+		if n.Level == 2 {
+			underline = "─"
+		}
+		lineWidth := 0
+		for _, line := range lines {
+			lineWidth = max(lineWidth, visibleWidth(line))
+		}
+		lines = append(lines, paint(strings.Repeat(underline, lineWidth), style))
+	}
+	return lines
+}
+
+// codeLines 渲染代码块，缩进两格，不自动换行
+func (r *terminalRenderer) codeLines(n ast.Node) []string {
+	var lines []string
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		text := strings.TrimRight(string(line.Value(r.source)), "\r\n")
+		lines = append(lines, "  "+paint(strings.ReplaceAll(text, "\t", "    "), styleBlock))
+	}
+	return lines
+}
+
+// list 渲染列表，列表项的后续行与第一行的文字对齐
+func (r *terminalRenderer) list(n *ast.List, width, depth int) []string {
+	var markers []string
+	markerWidth := 0
+	for i, item := 0, n.FirstChild(); item != nil; i, item = i+1, item.NextSibling() {
+		marker := bullets[depth%len(bullets)] + " "
+		if n.IsOrdered() {
+			marker = fmt.Sprintf("%d. ", n.Start+i)
+		}
+		markers = append(markers, marker)
+		markerWidth = max(markerWidth, runewidth.StringWidth(marker))
+	}
+
+	var lines []string
+	for i, item := 0, n.FirstChild(); item != nil; i, item = i+1, item.NextSibling() {
+		if i > 0 && !n.IsTight {
+			lines = append(lines, "")
+		}
+		itemLines := r.blocks(item, width-markerWidth, depth+1)
+		if len(itemLines) == 0 {
+			itemLines = []string{""}
+		}
+		marker := markers[i]
+		if n.IsOrdered() {
+			marker = strings.Repeat(" ", markerWidth-len(marker)) + marker
+		}
+		for j, line := range itemLines {
+			prefix := strings.Repeat(" ", markerWidth)
+			if j == 0 {
+				prefix = paint(marker, styleDim)
+			}
+			lines = append(lines, prefix+line)
+		}
+	}
+	return lines
+}
+
+// table 渲染表格，按各列的对齐方式填充空格
+func (r *terminalRenderer) table(n *extast.Table) []string {
+	var rows [][]string
+	var widths []int
+	for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for i, cell := 0, row.FirstChild(); cell != nil; i, cell = i+1, cell.NextSibling() {
+			var style []color.Attribute
+			if row.Kind() == extast.KindTableHeader {
+				style = styleBold
+			}
+			text := render(r.inlines(cell, style))
+			cells = append(cells, text)
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], visibleWidth(text))
+		}
+		rows = append(rows, cells)
+	}
+
+	border := func(left, middle, right string) string {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat("─", w+2)
+		}
+		return paint(left+strings.Join(parts, middle)+right, styleDim)
+	}
+	bar := paint("│", styleDim)
+
+	lines := []string{border("┌", "┬", "┐")}
+	for i, cells := range rows {
+		var b strings.Builder
+		b.WriteString(bar)
+		for j, w := range widths {
+			text := ""
+			if j < len(cells) {
+				text = cells[j]
+			}
+			align := extast.AlignNone
+			if j < len(n.Alignments) {
+				align = n.Alignments[j]
+			}
+			b.WriteString(" " + pad(text, w, align) + " " + bar)
+		}
+		lines = append(lines, b.String())
+		if i == 0 {
+			lines = append(lines, border("├", "┼", "┤"))
+		}
+	}
+	return append(lines, border("└", "┴", "┘"))
+}
+
+// pad 按对齐方式将文字填充到指定宽度
+func pad(text string, width int, align extast.Alignment) string {
+	space := width - visibleWidth(text)
+	switch align {
+	case extast.AlignRight:
+		return strings.Repeat(" ", space) + text
+	case extast.AlignCenter:
+		return strings.Repeat(" ", space/2) + text + strings.Repeat(" ", space-space/2)
+	}
+	return text + strings.Repeat(" ", space)
+}
+
+// inlines 将块中的行内元素转换为带样式的文字段
+func (r *terminalRenderer) inlines(parent ast.Node, attrs []color.Attribute) []span {
+	var spans []span
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		spans = append(spans, r.inline(n, attrs)...)
+	}
+	return spans
+}
+
+// inline 转换一个行内元素
+func (r *terminalRenderer) inline(n ast.Node, attrs []color.Attribute) []span {
+	with := func(extra []color.Attribute) []color.Attribute {
+		return append(append([]color.Attribute{}, attrs...), extra...)
+	}
+
+	switch n := n.(type) {
+	case *ast.Text:
+		value := string(n.Segment.Value(r.source))
+		spans := []span{{value, attrs}}
+		switch {
+		case n.HardLineBreak():
+			spans = append(spans, span{"\n", nil})
+		case n.SoftLineBreak():
+			// 中文等宽字符之间的换行不需要空格
+			if last, _ := utf8.DecodeLastRuneInString(value); runewidth.RuneWidth(last) < 2 {
+				spans = append(spans, span{" ", attrs})
+			}
+		}
+		return spans
+	case *ast.String:
+		return []span{{string(n.Value), attrs}}
+	case *ast.CodeSpan:
+		return []span{{plainText(n, r.source), with(styleCode)}}
+	case *ast.Emphasis:
+		if n.Level >= 2 {
+			return r.inlines(n, with(styleBold))
+		}
+		return r.inlines(n, with(styleItalic))
+	case *extast.Strikethrough:
+		return r.inlines(n, with(styleStrike))
+	case *ast.Link:
+		spans := r.inlines(n, with(styleLink))
+		if dest := string(n.Destination); dest != "" && dest != plainText(n, r.source) && !strings.HasPrefix(dest, "#") {
+			spans = append(spans, span{" (" + dest + ")", with(styleDim)})
+		}
+		return spans
+	case *ast.AutoLink:
+		return []span{{string(n.URL(r.source)), with(styleLink)}}
+	case *ast.Image:
+		label := i18n.T("[图片]")
+		if alt := plainText(n, r.source); alt != "" {
+			label = i18n.T("[图片: ") + alt + "]"
+		}
+		return []span{{label, attrs}, {" (" + string(n.Destination) + ")", with(styleDim)}}
+	case *ast.RawHTML:
+		var b strings.Builder
+		for i := 0; i < n.Segments.Len(); i++ {
+			segment := n.Segments.At(i)
+			b.Write(segment.Value(r.source))
+		}
+		return []span{{b.String(), with(styleDim)}}
+	case *extast.TaskCheckBox:
+		if n.IsChecked {
+			return []span{{"☑ ", with([]color.Attribute{color.FgGreen})}}
+		}
+		return []span{{"☐ ", attrs}}
+	}
+	return r.inlines(n, attrs)
+}
+
+// token 换行时不可拆分的最小单位：一个单词、一个宽字符或一段空白
+type token struct {
+	text  string
+	attrs []color.Attribute
+	width int
+	space bool
+}
+
+// tokenize 将文字段拆分为可以在其间换行的单元，中文等宽字符之间可以任意换行
+func tokenize(spans []span) []token {
+	var tokens []token
+	for _, s := range spans {
+		var word strings.Builder
+		flush := func() {
+			if word.Len() > 0 {
+				tokens = append(tokens, token{text: word.String(), attrs: s.attrs, width: runewidth.StringWidth(word.String())})
+				word.Reset()
+			}
+		}
+		for _, c := range s.text {
+			switch {
+			case c == '\n':
+				flush()
+				tokens = append(tokens, token{text: "\n"})
+			case c == ' ' || c == '\t':
+				flush()
+				tokens = append(tokens, token{text: " ", attrs: s.attrs, width: 1, space: true})
+			case runewidth.RuneWidth(c) >= 2:
+				flush()
+				tokens = append(tokens, token{text: string(c), attrs: s.attrs, width: 2})
+			default:
+				word.WriteRune(c)
+			}
+		}
+		flush()
+	}
+	return tokens
+}
+
+// wrap 将文字段按宽度换行，超长的单词（如URL）单独占一行
+func wrap(spans []span, width int) []string {
+	var lines []string
+	var line []token
+	lineWidth := 0
+	finish := func() {
+		for len(line) > 0 && line[len(line)-1].space {
+			line = line[:len(line)-1]
+		}
+		lines = append(lines, renderTokens(line))
+		line, lineWidth = nil, 0
+	}
+	for _, t := range tokenize(spans) {
+		switch {
+		case t.text == "\n":
+			finish()
+			continue
+		case t.space && len(line) == 0:
+			continue
+		case !t.space && lineWidth+t.width > width && len(line) > 0:
+			finish()
+		}
+		line = append(line, t)
+		lineWidth += t.width
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		finish()
+	}
+	return lines
+}
+
+// renderTokens 输出一行，相邻且样式相同的单元合并输出
+func renderTokens(tokens []token) string {
+	var b strings.Builder
+	for i := 0; i < len(tokens); {
+		j := i
+		var text strings.Builder
+		for j < len(tokens) && sameAttrs(tokens[j].attrs, tokens[i].attrs) {
+			text.WriteString(tokens[j].text)
+			j++
+		}
+		b.WriteString(paint(text.String(), tokens[i].attrs))
+		i = j
+	}
+	return b.String()
+}
+
+// render 输出不换行的文字段
+func render(spans []span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		b.WriteString(paint(strings.ReplaceAll(s.text, "\n", " "), s.attrs))
+	}
+	return b.String()
+}
+
+// paint 按样式输出文字
+func paint(text string, attrs []color.Attribute) string {
+	if len(attrs) == 0 || text == "" {
+		return text
+	}
+	return color.New(attrs...).Sprint(text)
+}
+
+// sameAttrs 两组样式是否相同
+func sameAttrs(a, b []color.Attribute) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleWidth 返回去掉颜色控制字符后的显示宽度
+func visibleWidth(text string) int {
+	width := 0
+	inEscape := false
+	for _, c := range text {
+		switch {
+		case inEscape:
+			inEscape = c != 'm'
+		case c == '\x1b':
+			inEscape = true
+		default:
+			width += runewidth.RuneWidth(c)
+		}
+	}
+	return width
+}
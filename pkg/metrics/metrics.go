@@ -0,0 +1,265 @@
+// Package metrics 记录每次执行命令的耗时和资源占用，用于找出需要优化的命令
+//
+// 指标记录默认关闭，设置 TOOLBOX_METRICS=1 或在配置文件中设置 metrics: true 后开启。
+// 记录以每行一个JSON对象的格式保存在配置目录下的 metrics.jsonl 中，只保存在本地。
+package metrics
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"toolbox/pkg/config"
+	"toolbox/pkg/errs"
+)
+
+const (
+	// EnableEnv 开启或关闭指标记录的环境变量，优先于配置文件
+	EnableEnv = "TOOLBOX_METRICS"
+	// PathEnv 指定指标文件路径的环境变量
+	PathEnv = "TOOLBOX_METRICS_FILE"
+)
+
+// MaxEntries 最多保留的记录数
+const MaxEntries = 5000
+
+// Usage 进程的资源占用
+type Usage struct {
+	UserCPU   time.Duration // 用户态CPU时间
+	SystemCPU time.Duration // 内核态CPU时间
+	MaxRSS    uint64        // 内存峰值（字节）
+}
+
+// Entry 一次命令执行的指标
+type Entry struct {
+	Time       time.Time `json:"time"`        // 开始执行的时间
+	Command    string    `json:"command"`     // 命令路径，如 "fs find"
+	Args       []string  `json:"args"`        // 完整的命令行参数（不含程序名）
+	DurationMs int64     `json:"duration_ms"` // 耗时（毫秒）
+	UserCPUMs  int64     `json:"user_cpu_ms"` // 用户态CPU时间（毫秒）
+	SysCPUMs   int64     `json:"sys_cpu_ms"`  // 内核态CPU时间（毫秒）
+	MaxRSS     uint64    `json:"max_rss"`     // 内存峰值（字节）
+	ExitCode   int       `json:"exit_code"`   // 退出码
+}
+
+// CPUMs 返回总的CPU时间（毫秒）
+func (e Entry) CPUMs() int64 {
+	return e.UserCPUMs + e.SysCPUMs
+}
+
+// CommandStats 单个命令的汇总指标
+type CommandStats struct {
+	Command  string `json:"command"`    // 命令路径
+	Runs     int    `json:"runs"`       // 执行次数
+	Failures int    `json:"failures"`   // 失败次数
+	AvgMs    int64  `json:"avg_ms"`     // 平均耗时（毫秒）
+	P95Ms    int64  `json:"p95_ms"`     // 95分位耗时（毫秒）
+	MaxMs    int64  `json:"max_ms"`     // 最长耗时（毫秒）
+	AvgCPUMs int64  `json:"avg_cpu_ms"` // 平均CPU时间（毫秒）
+	MaxRSS   uint64 `json:"max_rss"`    // 内存峰值（字节）
+}
+
+// settings 配置文件中与指标相关的部分
+type settings struct {
+	Metrics bool `yaml:"metrics"`
+}
+
+// Enabled 是否记录指标
+func Enabled() bool {
+	switch strings.ToLower(os.Getenv(EnableEnv)) {
+	case "1", "true", "on", "yes":
+		return true
+	case "0", "false", "off", "no":
+		return false
+	}
+	var s settings
+	if err := config.Load(&s); err != nil {
+		return false
+	}
+	return s.Metrics
+}
+
+// Path 返回指标文件路径
+func Path() (string, error) {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path, nil
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "metrics.jsonl"), nil
+}
+
+// Load 读取所有记录，按执行顺序排列，文件不存在时返回空列表
+func Load() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "读取指标记录失败: %v", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errs.Wrap(err, "读取指标记录失败: %v", err)
+	}
+	return entries, nil
+}
+
+// Record 根据开始时间和资源占用生成一条记录并追加到指标文件
+func Record(command string, args []string, start time.Time, usage Usage, exitCode int) error {
+	return Append(Entry{
+		Time:       start,
+		Command:    command,
+		Args:       args,
+		DurationMs: time.Since(start).Milliseconds(),
+		UserCPUMs:  usage.UserCPU.Milliseconds(),
+		SysCPUMs:   usage.SystemCPU.Milliseconds(),
+		MaxRSS:     usage.MaxRSS,
+		ExitCode:   exitCode,
+	})
+}
+
+// Append 追加一条记录，超过 MaxEntries 时只保留最近的记录
+func Append(entry Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errs.Wrap(err, "创建指标目录失败: %v", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errs.Wrap(err, "写入指标记录失败: %v", err)
+	}
+	_, err = file.Write(append(data, '\n'))
+	file.Close()
+	if err != nil {
+		return errs.Wrap(err, "写入指标记录失败: %v", err)
+	}
+
+	// 文件过大时重写，保留最近的记录；允许暂时超出一些，避免每次都重写
+	if info, err := os.Stat(path); err == nil && info.Size() > MaxEntries*400 {
+		entries, err := Load()
+		if err != nil || len(entries) <= MaxEntries {
+			return err
+		}
+		return write(path, entries[len(entries)-MaxEntries:])
+	}
+	return nil
+}
+
+// Clear 删除所有记录
+func Clear() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errs.Wrap(err, "清空指标记录失败: %v", err)
+	}
+	return nil
+}
+
+// Summarize 按命令汇总指标，按平均耗时从长到短排序
+func Summarize(entries []Entry) []CommandStats {
+	groups := make(map[string][]Entry)
+	for _, entry := range entries {
+		groups[entry.Command] = append(groups[entry.Command], entry)
+	}
+
+	stats := make([]CommandStats, 0, len(groups))
+	for command, group := range groups {
+		s := CommandStats{Command: command, Runs: len(group)}
+		durations := make([]int64, 0, len(group))
+		var totalMs, totalCPU int64
+		for _, entry := range group {
+			if entry.ExitCode != 0 {
+				s.Failures++
+			}
+			if entry.DurationMs > s.MaxMs {
+				s.MaxMs = entry.DurationMs
+			}
+			if entry.MaxRSS > s.MaxRSS {
+				s.MaxRSS = entry.MaxRSS
+			}
+			totalMs += entry.DurationMs
+			totalCPU += entry.CPUMs()
+			durations = append(durations, entry.DurationMs)
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		s.AvgMs = totalMs / int64(len(group))
+		s.AvgCPUMs = totalCPU / int64(len(group))
+		s.P95Ms = durations[(len(durations)*95+99)/100-1]
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].AvgMs != stats[j].AvgMs {
+			return stats[i].AvgMs > stats[j].AvgMs
+		}
+		return stats[i].Command < stats[j].Command
+	})
+	return stats
+}
+
+// Slowest 返回耗时最长的n条记录，n为0时返回全部
+func Slowest(entries []Entry, n int) []Entry {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].DurationMs > sorted[j].DurationMs
+	})
+	if n > 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// write 用指定的记录重写指标文件
+func write(path string, entries []Entry) error {
+	var b strings.Builder
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, []byte(b.String()), 0600); err != nil {
+		return errs.Wrap(err, "写入指标记录失败: %v", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		os.Remove(tempFile)
+		return errs.Wrap(err, "写入指标记录失败: %v", err)
+	}
+	return nil
+}
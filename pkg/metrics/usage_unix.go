@@ -0,0 +1,33 @@
+//go:build !windows
+// +build !windows
+
+package metrics
+
+import (
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// ReadUsage 返回当前进程及其已结束的子进程的资源占用
+func ReadUsage() Usage {
+	var usage Usage
+	for _, who := range []int{syscall.RUSAGE_SELF, syscall.RUSAGE_CHILDREN} {
+		var ru syscall.Rusage
+		if err := syscall.Getrusage(who, &ru); err != nil {
+			continue
+		}
+		usage.UserCPU += time.Duration(ru.Utime.Nano())
+		usage.SystemCPU += time.Duration(ru.Stime.Nano())
+
+		// macOS的ru_maxrss单位为字节，其他系统为KB
+		maxRSS := uint64(ru.Maxrss)
+		if runtime.GOOS != "darwin" {
+			maxRSS *= 1024
+		}
+		if maxRSS > usage.MaxRSS {
+			usage.MaxRSS = maxRSS
+		}
+	}
+	return usage
+}
@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+package metrics
+
+import (
+	"os"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ReadUsage 返回当前进程的资源占用
+//
+// Windows上不包括子进程，内存为当前的工作集大小而不是峰值。
+func ReadUsage() Usage {
+	var usage Usage
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return usage
+	}
+	if times, err := proc.Times(); err == nil {
+		usage.UserCPU = time.Duration(times.User * float64(time.Second))
+		usage.SystemCPU = time.Duration(times.System * float64(time.Second))
+	}
+	if mem, err := proc.MemoryInfo(); err == nil {
+		usage.MaxRSS = mem.RSS
+	}
+	return usage
+}
@@ -0,0 +1,240 @@
+package mockdata
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/idgen"
+	"toolbox/pkg/timeconv"
+)
+
+// 生成数据使用的词库
+var (
+	firstNames = []string{"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda",
+		"William", "Elizabeth", "David", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas",
+		"Sarah", "Daniel", "Karen", "Emma", "Olivia", "Liam", "Noah", "Sophia", "Lucas", "Mia", "Ethan"}
+	lastNames = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis",
+		"Rodriguez", "Martinez", "Wilson", "Anderson", "Taylor", "Thomas", "Moore", "Jackson", "Martin",
+		"Lee", "Thompson", "White", "Harris", "Clark", "Lewis", "Walker", "Young", "King"}
+	surnamesZh = []string{"王", "李", "张", "刘", "陈", "杨", "赵", "黄", "周", "吴", "徐", "孙", "胡",
+		"朱", "高", "林", "何", "郭", "马", "罗", "欧阳", "司马"}
+	givenZh = []string{"伟", "芳", "娜", "敏", "静", "丽", "强", "磊", "军", "洋", "勇", "艳", "杰",
+		"娟", "涛", "明", "超", "秀英", "华", "慧", "建国", "子涵", "宇轩", "梓萱", "浩然", "欣怡"}
+	words = []string{"alpha", "bravo", "cloud", "delta", "echo", "forest", "galaxy", "harbor", "island",
+		"jungle", "kernel", "lunar", "matrix", "nova", "orbit", "pixel", "quartz", "river", "solar",
+		"tiger", "urban", "vector", "willow", "xenon", "yellow", "zephyr", "amber", "breeze", "cedar",
+		"dune", "ember", "frost"}
+	emailDomains = []string{"example.com", "example.org", "example.net"}
+	tlds         = []string{"com", "net", "org", "io", "dev", "cn"}
+)
+
+// Generator 按schema逐条生成记录
+type Generator struct {
+	fields []compiledField
+	rand   *rand.Rand
+}
+
+// compiledField 预先解析过选项的字段
+type compiledField struct {
+	Field
+	min, max   float64
+	decimals   int
+	start, end time.Time
+	format     string
+	seq        int64
+}
+
+// NewGenerator 创建生成器，seed 相同时生成的数据相同（默认时间范围依赖当前时间的除外）
+func NewGenerator(schema *Schema, seed int64) (*Generator, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &Generator{rand: rand.New(rand.NewSource(seed))}
+	for _, f := range schema.Fields {
+		c, err := compile(f)
+		if err != nil {
+			return nil, err
+		}
+		g.fields = append(g.fields, c)
+	}
+	return g, nil
+}
+
+// compile 填充字段选项的默认值并解析时间范围
+func compile(f Field) (compiledField, error) {
+	c := compiledField{Field: f, format: f.Format}
+
+	switch f.Type {
+	case TypeSeq:
+		c.seq = int64(floatOr(f.Min, 1))
+	case TypeInt:
+		c.min, c.max = floatOr(f.Min, 0), floatOr(f.Max, 1000)
+	case TypeFloat:
+		c.min, c.max = floatOr(f.Min, 0), floatOr(f.Max, 1)
+		c.decimals = 2
+		if f.Decimals != nil {
+			c.decimals = *f.Decimals
+		}
+	case TypeTimestamp, TypeDate:
+		c.end = time.Now()
+		if f.End != "" {
+			t, _, err := timeconv.Parse(f.End, timeconv.UnitAuto, time.Local)
+			if err != nil {
+				return c, errs.InvalidInput("字段 %s 的 end 无效: %v", f.Name, err)
+			}
+			c.end = t
+		}
+		c.start = c.end.AddDate(0, 0, -30)
+		if f.Start != "" {
+			t, _, err := timeconv.Parse(f.Start, timeconv.UnitAuto, time.Local)
+			if err != nil {
+				return c, errs.InvalidInput("字段 %s 的 start 无效: %v", f.Name, err)
+			}
+			c.start = t
+		}
+		if c.start.After(c.end) {
+			return c, errs.InvalidInput("字段 %s 的 start 晚于 end", f.Name)
+		}
+		if c.format == "" {
+			c.format = "rfc3339"
+			if f.Type == TypeDate {
+				c.format = "2006-01-02"
+			}
+		}
+	}
+	return c, nil
+}
+
+// floatOr 返回 *v，v 为 nil 时返回默认值
+func floatOr(v *float64, def float64) float64 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+// Next 生成下一条记录，值的顺序与schema中字段的顺序一致
+func (g *Generator) Next() []interface{} {
+	values := make([]interface{}, len(g.fields))
+	for i := range g.fields {
+		f := &g.fields[i]
+		value := g.value(f)
+		if f.NullRate > 0 && g.rand.Float64() < f.NullRate {
+			value = nil
+		}
+		values[i] = value
+	}
+	return values
+}
+
+// value 生成单个字段的值
+func (g *Generator) value(f *compiledField) interface{} {
+	r := g.rand
+	switch f.Type {
+	case TypeSeq:
+		f.seq++
+		return f.seq - 1
+	case TypeInt:
+		low, high := int64(math.Ceil(f.min)), int64(math.Floor(f.max))
+		if high <= low {
+			return low
+		}
+		return low + r.Int63n(high-low+1)
+	case TypeFloat:
+		v := f.min + r.Float64()*(f.max-f.min)
+		scale := math.Pow(10, float64(f.decimals))
+		return math.Round(v*scale) / scale
+	case TypeBool:
+		return r.Intn(2) == 1
+	case TypeEnum:
+		return f.Values[r.Intn(len(f.Values))]
+	case TypeConst:
+		return f.Value
+	case TypeName:
+		return pick(r, firstNames) + " " + pick(r, lastNames)
+	case TypeFirstName:
+		return pick(r, firstNames)
+	case TypeLastName:
+		return pick(r, lastNames)
+	case TypeNameZh:
+		return pick(r, surnamesZh) + pick(r, givenZh)
+	case TypeUsername:
+		return strings.ToLower(pick(r, firstNames)) + "_" + pick(r, words) + strconv.Itoa(r.Intn(100))
+	case TypeEmail:
+		domain := f.Domain
+		if domain == "" {
+			domain = pick(r, emailDomains)
+		}
+		return fmt.Sprintf("%s.%s%d@%s", strings.ToLower(pick(r, firstNames)),
+			strings.ToLower(pick(r, lastNames)), r.Intn(1000), domain)
+	case TypePhone:
+		return fmt.Sprintf("1%d%09d", 3+r.Intn(7), r.Intn(1000000000))
+	case TypeIPv4:
+		// 首字节避开 0、127 和组播等保留地址段
+		first := 1 + r.Intn(223)
+		if first == 127 {
+			first = 128
+		}
+		return fmt.Sprintf("%d.%d.%d.%d", first, r.Intn(256), r.Intn(256), 1+r.Intn(254))
+	case TypeIPv6:
+		return fmt.Sprintf("2001:db8:%x:%x:%x:%x:%x:%x", r.Intn(65536), r.Intn(65536),
+			r.Intn(65536), r.Intn(65536), r.Intn(65536), r.Intn(65536))
+	case TypeMAC:
+		// 设置本地管理位并清除组播位，避免与真实厂商地址冲突
+		first := r.Intn(256)&^1 | 2
+		return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", first, r.Intn(256), r.Intn(256),
+			r.Intn(256), r.Intn(256), r.Intn(256))
+	case TypeDomain:
+		return pick(r, words) + "-" + pick(r, words) + "." + pick(r, tlds)
+	case TypeURL:
+		domain := f.Domain
+		if domain == "" {
+			domain = pick(r, words) + "." + pick(r, tlds)
+		}
+		return fmt.Sprintf("https://%s/%s/%s", domain, pick(r, words), pick(r, words))
+	case TypeUUID:
+		u, _ := idgen.NewUUIDv4From(r)
+		return u.String()
+	case TypeTimestamp, TypeDate:
+		span := f.end.Sub(f.start)
+		t := f.start
+		if span > 0 {
+			t = t.Add(time.Duration(r.Int63n(int64(span))))
+		}
+		return formatTime(t, f.format)
+	case TypeWord:
+		return pick(r, words)
+	case TypeSentence:
+		n := 4 + r.Intn(8)
+		parts := make([]string, n)
+		for i := range parts {
+			parts[i] = pick(r, words)
+		}
+		sentence := strings.Join(parts, " ")
+		return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+	}
+	return nil
+}
+
+// formatTime 按格式名称或Go时间格式输出时间，unix 和 unix_ms 输出为数字
+func formatTime(t time.Time, format string) interface{} {
+	switch format {
+	case "rfc3339":
+		return t.Format(time.RFC3339)
+	case "unix":
+		return t.Unix()
+	case "unix_ms":
+		return t.UnixMilli()
+	}
+	return t.Format(format)
+}
+
+// pick 随机选择一个元素
+func pick(r *rand.Rand, items []string) string {
+	return items[r.Intn(len(items))]
+}
@@ -0,0 +1,194 @@
+// Package mockdata 按schema生成用于测试的假数据
+//
+// schema 是一个JSON文件，按顺序描述每个字段的名称和类型，生成的记录
+// 可以输出为JSON Lines、JSON数组或CSV。
+package mockdata
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// 字段类型
+const (
+	TypeSeq       = "seq"
+	TypeInt       = "int"
+	TypeFloat     = "float"
+	TypeBool      = "bool"
+	TypeEnum      = "enum"
+	TypeConst     = "const"
+	TypeName      = "name"
+	TypeFirstName = "first_name"
+	TypeLastName  = "last_name"
+	TypeNameZh    = "name_zh"
+	TypeUsername  = "username"
+	TypeEmail     = "email"
+	TypePhone     = "phone"
+	TypeIPv4      = "ipv4"
+	TypeIPv6      = "ipv6"
+	TypeMAC       = "mac"
+	TypeDomain    = "domain"
+	TypeURL       = "url"
+	TypeUUID      = "uuid"
+	TypeTimestamp = "timestamp"
+	TypeDate      = "date"
+	TypeWord      = "word"
+	TypeSentence  = "sentence"
+)
+
+// TypeInfo 字段类型的说明
+type TypeInfo struct {
+	Name        string `json:"name"`        // 类型名称
+	Description string `json:"description"` // 说明
+	Options     string `json:"options"`     // 可用的选项
+}
+
+// types 支持的字段类型，按显示顺序排列
+var types = []TypeInfo{
+	{TypeSeq, "自增序号", "min（起始值，默认1）"},
+	{TypeInt, "随机整数", "min、max（默认0-1000）"},
+	{TypeFloat, "随机小数", "min、max（默认0-1）、decimals（默认2）"},
+	{TypeBool, "布尔值", ""},
+	{TypeEnum, "从候选值中随机选择", "values（必填）"},
+	{TypeConst, "固定值", "value"},
+	{TypeName, "英文姓名", ""},
+	{TypeFirstName, "英文名", ""},
+	{TypeLastName, "英文姓", ""},
+	{TypeNameZh, "中文姓名", ""},
+	{TypeUsername, "用户名", ""},
+	{TypeEmail, "邮箱地址", "domain（默认为 example.com 等保留域名）"},
+	{TypePhone, "手机号", ""},
+	{TypeIPv4, "IPv4地址", ""},
+	{TypeIPv6, "IPv6地址（2001:db8::/32 文档地址段）", ""},
+	{TypeMAC, "MAC地址（本地管理地址）", ""},
+	{TypeDomain, "域名", ""},
+	{TypeURL, "URL", "domain"},
+	{TypeUUID, "v4 UUID", ""},
+	{TypeTimestamp, "时间", "start、end（默认最近30天）、format（rfc3339、unix、unix_ms 或Go时间格式）"},
+	{TypeDate, "日期", "start、end、format（默认 2006-01-02）"},
+	{TypeWord, "单词", ""},
+	{TypeSentence, "句子", ""},
+}
+
+// Types 返回支持的字段类型，说明按当前界面语言翻译
+func Types() []TypeInfo {
+	translated := make([]TypeInfo, len(types))
+	for i, t := range types {
+		t.Description = i18n.T(t.Description)
+		t.Options = i18n.T(t.Options)
+		translated[i] = t
+	}
+	return translated
+}
+
+// Field 一个字段的定义
+type Field struct {
+	Name     string        `json:"name"`                // 字段名
+	Type     string        `json:"type"`                // 字段类型
+	Values   []interface{} `json:"values,omitempty"`    // enum 的候选值
+	Value    interface{}   `json:"value,omitempty"`     // const 的取值
+	Min      *float64      `json:"min,omitempty"`       // 数值下限，seq 的起始值
+	Max      *float64      `json:"max,omitempty"`       // 数值上限（包含）
+	Decimals *int          `json:"decimals,omitempty"`  // float 保留的小数位数
+	Start    string        `json:"start,omitempty"`     // timestamp/date 的起始时间
+	End      string        `json:"end,omitempty"`       // timestamp/date 的结束时间
+	Format   string        `json:"format,omitempty"`    // timestamp/date 的输出格式
+	Domain   string        `json:"domain,omitempty"`    // email/url 使用的域名
+	NullRate float64       `json:"null_rate,omitempty"` // 取值为null的比例，0-1
+}
+
+// Schema 记录的结构
+type Schema struct {
+	Fields []Field `json:"fields"`
+}
+
+// LoadSchema 从文件读取schema，path 为 - 时从标准输入读取
+func LoadSchema(path string) (*Schema, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "读取schema失败: %v", err)
+	}
+	return ParseSchema(data)
+}
+
+// ParseSchema 解析JSON格式的schema
+//
+// 支持 {"fields": [...]} 和直接以字段数组表示两种写法。
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	trimmed := strings.TrimSpace(string(data))
+	var err error
+	if strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(data, &schema.Fields)
+	} else {
+		err = json.Unmarshal(data, &schema)
+	}
+	if err != nil {
+		return nil, errs.InvalidInput("解析schema失败: %v", err)
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// Validate 检查字段定义是否完整
+func (s *Schema) Validate() error {
+	if len(s.Fields) == 0 {
+		return errs.InvalidInput("schema中没有定义字段")
+	}
+
+	seen := make(map[string]bool)
+	for i, f := range s.Fields {
+		if f.Name == "" {
+			return errs.InvalidInput("第%d个字段缺少名称", i+1)
+		}
+		if seen[f.Name] {
+			return errs.InvalidInput("字段名重复: %s", f.Name)
+		}
+		seen[f.Name] = true
+
+		if !isKnownType(f.Type) {
+			return errs.InvalidInput("字段 %s 的类型无效: %q（支持的类型见 mock types）", f.Name, f.Type)
+		}
+		if f.Type == TypeEnum && len(f.Values) == 0 {
+			return errs.InvalidInput("enum字段 %s 缺少 values", f.Name)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return errs.InvalidInput("字段 %s 的 min 大于 max", f.Name)
+		}
+		if f.NullRate < 0 || f.NullRate > 1 {
+			return errs.InvalidInput("字段 %s 的 null_rate 必须在0到1之间", f.Name)
+		}
+	}
+	return nil
+}
+
+// Names 返回按顺序排列的字段名
+func (s *Schema) Names() []string {
+	names := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// isKnownType 判断是否为支持的字段类型
+func isKnownType(name string) bool {
+	for _, t := range types {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package mockdata
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// Format 记录的输出格式
+type Format string
+
+// 支持的输出格式
+const (
+	FormatJSONLines Format = "jsonl" // 每行一个JSON对象
+	FormatJSON      Format = "json"  // JSON数组
+	FormatCSV       Format = "csv"   // 带表头的CSV
+)
+
+// ParseFormat 解析输出格式名称
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "jsonl", "ndjson":
+		return FormatJSONLines, nil
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	}
+	return "", errs.InvalidInput("不支持的格式: %s（可选: jsonl, json, csv）", name)
+}
+
+// Writer 将记录按指定格式写出
+type Writer struct {
+	w      io.Writer
+	format Format
+	names  []string
+	csv    *csv.Writer
+	count  int
+}
+
+// NewWriter 创建记录写入器，names 为字段名，决定JSON的键顺序和CSV的表头
+func NewWriter(w io.Writer, format Format, names []string) *Writer {
+	writer := &Writer{w: w, format: format, names: names}
+	if format == FormatCSV {
+		writer.csv = csv.NewWriter(w)
+	}
+	return writer
+}
+
+// Write 写出一条记录
+func (w *Writer) Write(values []interface{}) error {
+	defer func() { w.count++ }()
+
+	switch w.format {
+	case FormatCSV:
+		if w.count == 0 {
+			if err := w.csv.Write(w.names); err != nil {
+				return err
+			}
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = csvValue(v)
+		}
+		return w.csv.Write(row)
+
+	case FormatJSON:
+		prefix := ",\n"
+		if w.count == 0 {
+			prefix = "[\n"
+		}
+		line, err := w.object(values)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w.w, "%s%s", prefix, line)
+		return err
+
+	default:
+		line, err := w.object(values)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w.w, "%s\n", line)
+		return err
+	}
+}
+
+// Close 写出格式的结尾部分，不关闭底层的 io.Writer
+func (w *Writer) Close() error {
+	switch w.format {
+	case FormatCSV:
+		if w.count == 0 {
+			w.csv.Write(w.names)
+		}
+		w.csv.Flush()
+		return w.csv.Error()
+	case FormatJSON:
+		if w.count == 0 {
+			_, err := io.WriteString(w.w, "[]\n")
+			return err
+		}
+		_, err := io.WriteString(w.w, "\n]\n")
+		return err
+	}
+	return nil
+}
+
+// object 按字段顺序将一条记录编码为JSON对象
+func (w *Writer) object(values []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(w.names[i])
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, i18n.Errorf("编码字段 %s 失败: %v", w.names[i], err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// csvValue 将值转换为CSV单元格文本，null 输出为空
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	// 数组、对象等按JSON输出
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
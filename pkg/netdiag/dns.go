@@ -9,21 +9,23 @@ import (
 	"runtime"
 	"strings"
 	"time"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
 )
 
 // DNSRecord 表示DNS记录
 type DNSRecord struct {
-	Type  string
-	Value string
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
 
 // DNSQueryResult 表示DNS查询结果
 type DNSQueryResult struct {
-	Domain     string
-	Records    []DNSRecord
-	Error      string
-	Method     string // 查询方式: "host" 或 "dns"
-	ServerUsed string // 如果使用DNS服务器，记录使用的服务器
+	Domain     string      `json:"domain"`
+	Records    []DNSRecord `json:"records"`
+	Error      string      `json:"error,omitempty"`
+	Method     string      `json:"method"`      // 查询方式: "host" 或 "dns"
+	ServerUsed string      `json:"server_used"` // 如果使用DNS服务器，记录使用的服务器
 }
 
 // GetSystemDNSServers 获取系统当前使用的DNS服务器
@@ -39,7 +41,7 @@ func GetSystemDNSServers() []string {
 			return servers
 		}
 
-		fmt.Println("api方法失败, 回退到使用ipconfig命令")
+		logger.Debugf("api方法失败, 回退到使用ipconfig命令")
 		// 如果API方法失败，回退到使用ipconfig命令
 		cmd := exec.Command("ipconfig", "/all")
 		output, err := cmd.Output()
@@ -170,7 +172,7 @@ func LookupIP(domain string, dnsServer string) (DNSQueryResult, error) {
 
 	ips, err := resolver.LookupIP(ctx, "ip", domain)
 	if err != nil {
-		result.Error = fmt.Sprintf("查询失败: %v", err)
+		result.Error = i18n.Tf("查询失败: %v", err)
 		return result, err
 	}
 
@@ -210,7 +212,7 @@ func LookupMX(domain string, dnsServer string) (DNSQueryResult, error) {
 
 	mxs, err := resolver.LookupMX(ctx, domain)
 	if err != nil {
-		result.Error = fmt.Sprintf("查询失败: %v", err)
+		result.Error = i18n.Tf("查询失败: %v", err)
 		return result, err
 	}
 
@@ -246,7 +248,7 @@ func LookupNS(domain string, dnsServer string) (DNSQueryResult, error) {
 
 	nss, err := resolver.LookupNS(ctx, domain)
 	if err != nil {
-		result.Error = fmt.Sprintf("查询失败: %v", err)
+		result.Error = i18n.Tf("查询失败: %v", err)
 		return result, err
 	}
 
@@ -282,7 +284,7 @@ func LookupTXT(domain string, dnsServer string) (DNSQueryResult, error) {
 
 	txts, err := resolver.LookupTXT(ctx, domain)
 	if err != nil {
-		result.Error = fmt.Sprintf("查询失败: %v", err)
+		result.Error = i18n.Tf("查询失败: %v", err)
 		return result, err
 	}
 
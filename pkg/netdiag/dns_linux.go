@@ -3,10 +3,12 @@
 
 package netdiag
 
-import "fmt"
+import (
+	"toolbox/pkg/i18n"
+)
 
 // getWindowsDNSServers 在非Windows平台上的空实现
 // 这个函数是为了让编译通过，在Linux/macOS上不会被调用
 func getWindowsDNSServers() ([]string, error) {
-	return nil, fmt.Errorf("不支持的平台")
+	return nil, i18n.Errorf("不支持的平台")
 }
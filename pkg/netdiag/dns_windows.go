@@ -4,7 +4,7 @@
 package netdiag
 
 import (
-	"fmt"
+	"toolbox/pkg/i18n"
 
 	"github.com/StackExchange/wmi"
 )
@@ -25,7 +25,7 @@ func getWindowsDNSServers() ([]string, error) {
 
 	err := wmi.Query(query, &nacs)
 	if err != nil {
-		return nil, fmt.Errorf("WMI查询失败: %v", err)
+		return nil, i18n.Errorf("WMI查询失败: %v", err)
 	}
 
 	// 从查询结果中提取DNS服务器信息
@@ -40,7 +40,7 @@ func getWindowsDNSServers() ([]string, error) {
 	}
 
 	if len(dnsServers) == 0 {
-		return nil, fmt.Errorf("未找到DNS服务器信息")
+		return nil, i18n.Errorf("未找到DNS服务器信息")
 	}
 
 	return dnsServers, nil
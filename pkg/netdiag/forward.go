@@ -0,0 +1,479 @@
+package netdiag
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// ForwardOptions 端口转发选项
+type ForwardOptions struct {
+	Listen         string        // 本地监听地址，如 :8080
+	Target         string        // 转发目标地址，如 10.0.0.5:80
+	Protocol       string        // 协议（tcp, udp, both），默认tcp
+	MaxConnections int           // 最大并发连接数（UDP为会话数），0表示不限制
+	IdleTimeout    time.Duration // 空闲超时时间，0表示不超时；UDP会话没有连接状态，为0时使用 udpSessionTimeout
+	DialTimeout    time.Duration // 连接目标的超时时间
+	LogCallback    func(string)  // 连接日志回调
+}
+
+// ForwardStats 端口转发统计信息
+type ForwardStats struct {
+	TotalConnections  int64 // 累计连接数
+	ActiveConnections int64 // 当前活动连接数
+	RejectedCount     int64 // 因超过最大连接数被拒绝的连接数
+	BytesIn           int64 // 客户端发往目标的字节数
+	BytesOut          int64 // 目标返回客户端的字节数
+}
+
+// Forwarder 端口转发器
+type Forwarder struct {
+	options  ForwardOptions
+	stats    ForwardStats
+	sem      chan struct{}
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewForwarder 创建新的端口转发器
+func NewForwarder(options ForwardOptions) (*Forwarder, error) {
+	if options.Listen == "" {
+		return nil, errs.InvalidInput("必须指定监听地址")
+	}
+	if options.Target == "" {
+		return nil, errs.InvalidInput("必须指定转发目标地址")
+	}
+	if options.Protocol == "" {
+		options.Protocol = "tcp"
+	}
+	if options.DialTimeout <= 0 {
+		options.DialTimeout = 10 * time.Second
+	}
+	switch options.Protocol {
+	case "tcp", "udp", "both":
+	default:
+		return nil, errs.InvalidInput("不支持的协议: %s（支持 tcp, udp, both）", options.Protocol)
+	}
+
+	f := &Forwarder{
+		options:  options,
+		stopChan: make(chan struct{}),
+	}
+	if options.MaxConnections > 0 {
+		f.sem = make(chan struct{}, options.MaxConnections)
+	}
+	return f, nil
+}
+
+// Stats 返回当前统计信息的快照
+func (f *Forwarder) Stats() ForwardStats {
+	return ForwardStats{
+		TotalConnections:  atomic.LoadInt64(&f.stats.TotalConnections),
+		ActiveConnections: atomic.LoadInt64(&f.stats.ActiveConnections),
+		RejectedCount:     atomic.LoadInt64(&f.stats.RejectedCount),
+		BytesIn:           atomic.LoadInt64(&f.stats.BytesIn),
+		BytesOut:          atomic.LoadInt64(&f.stats.BytesOut),
+	}
+}
+
+// Stop 停止转发
+func (f *Forwarder) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stopChan)
+	})
+}
+
+// Run 启动转发并阻塞，直到调用Stop或发生错误
+func (f *Forwarder) Run() error {
+	var tcpListener net.Listener
+	var udpConn *net.UDPConn
+	var err error
+
+	if f.options.Protocol == "tcp" || f.options.Protocol == "both" {
+		tcpListener, err = net.Listen("tcp", f.options.Listen)
+		if err != nil {
+			return errs.Wrap(err, "监听TCP地址失败: %v", err)
+		}
+		f.logf(i18n.T("TCP转发已启动: %s -> %s"), tcpListener.Addr(), f.options.Target)
+	}
+
+	if f.options.Protocol == "udp" || f.options.Protocol == "both" {
+		addr, err := net.ResolveUDPAddr("udp", f.options.Listen)
+		if err != nil {
+			if tcpListener != nil {
+				tcpListener.Close()
+			}
+			return errs.InvalidInput("解析UDP监听地址失败: %v", err)
+		}
+		udpConn, err = net.ListenUDP("udp", addr)
+		if err != nil {
+			if tcpListener != nil {
+				tcpListener.Close()
+			}
+			return errs.Wrap(err, "监听UDP地址失败: %v", err)
+		}
+		f.logf(i18n.T("UDP转发已启动: %s -> %s"), udpConn.LocalAddr(), f.options.Target)
+	}
+
+	// 服务协程也计入等待组，保证其内部派生的协程在Wait之前完成登记
+	errChan := make(chan error, 2)
+	if tcpListener != nil {
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			errChan <- f.serveTCP(tcpListener)
+		}()
+	}
+	if udpConn != nil {
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			errChan <- f.serveUDP(udpConn)
+		}()
+	}
+
+	select {
+	case <-f.stopChan:
+	case err = <-errChan:
+	}
+
+	// 关闭监听器，等待所有连接处理结束
+	f.Stop()
+	if tcpListener != nil {
+		tcpListener.Close()
+	}
+	if udpConn != nil {
+		udpConn.Close()
+	}
+	f.wg.Wait()
+
+	return err
+}
+
+// serveTCP 接收并处理TCP连接
+func (f *Forwarder) serveTCP(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-f.stopChan:
+				return nil
+			default:
+			}
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				continue
+			}
+			return i18n.Errorf("接收TCP连接失败: %v", err)
+		}
+
+		if !f.acquire() {
+			atomic.AddInt64(&f.stats.RejectedCount, 1)
+			f.logf(i18n.T("[TCP] 拒绝连接 %s: 已达到最大连接数 %d"), conn.RemoteAddr(), f.options.MaxConnections)
+			conn.Close()
+			continue
+		}
+
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			defer f.release()
+			f.handleTCP(conn)
+		}()
+	}
+}
+
+// handleTCP 处理单个TCP连接
+func (f *Forwarder) handleTCP(client net.Conn) {
+	defer client.Close()
+
+	atomic.AddInt64(&f.stats.TotalConnections, 1)
+	atomic.AddInt64(&f.stats.ActiveConnections, 1)
+	defer atomic.AddInt64(&f.stats.ActiveConnections, -1)
+
+	start := time.Now()
+	clientAddr := client.RemoteAddr().String()
+
+	target, err := net.DialTimeout("tcp", f.options.Target, f.options.DialTimeout)
+	if err != nil {
+		f.logf(i18n.T("[TCP] %s 连接目标 %s 失败: %v"), clientAddr, f.options.Target, err)
+		return
+	}
+	defer target.Close()
+
+	f.logf(i18n.T("[TCP] 新连接 %s -> %s"), clientAddr, f.options.Target)
+
+	// 出错、空闲超时或停止转发时关闭两个连接，另一方向的复制随之结束
+	done := make(chan struct{})
+	var closeOnce sync.Once
+	closeBoth := func() {
+		closeOnce.Do(func() {
+			close(done)
+			client.Close()
+			target.Close()
+		})
+	}
+
+	// 两个方向共用最后活动时间，只有两个方向都没有数据时才算空闲，单向传输（如下载）不会被中断
+	lastActive := time.Now().UnixNano()
+	go func() {
+		var tick <-chan time.Time
+		if f.options.IdleTimeout > 0 {
+			ticker := time.NewTicker(idleCheckInterval(f.options.IdleTimeout))
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for {
+			select {
+			case <-f.stopChan:
+				closeBoth()
+				return
+			case <-done:
+				return
+			case <-tick:
+				if time.Duration(time.Now().UnixNano()-atomic.LoadInt64(&lastActive)) > f.options.IdleTimeout {
+					f.logf(i18n.T("[TCP] 连接 %s 空闲超时"), clientAddr)
+					closeBoth()
+					return
+				}
+			}
+		}
+	}()
+
+	var in, out int64
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		in = f.copyConn(target, client, &f.stats.BytesIn, &lastActive, closeBoth)
+	}()
+	go func() {
+		defer wg.Done()
+		out = f.copyConn(client, target, &f.stats.BytesOut, &lastActive, closeBoth)
+	}()
+	wg.Wait()
+	closeBoth()
+
+	f.logf(i18n.T("[TCP] 连接关闭 %s，发送 %d 字节，接收 %d 字节，持续 %s"),
+		clientAddr, in, out, time.Since(start).Round(time.Millisecond))
+}
+
+// copyConn 将src的数据复制到dst，每次读到数据时更新lastActive。
+// src正常结束时只关闭dst的写入方向（TCP半关闭），另一方向继续传输；出错时调用closeBoth关闭两个连接
+func (f *Forwarder) copyConn(dst, src net.Conn, counter, lastActive *int64, closeBoth func()) int64 {
+	buf := make([]byte, 32*1024)
+	var total int64
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			atomic.StoreInt64(lastActive, time.Now().UnixNano())
+			written, werr := dst.Write(buf[:n])
+			total += int64(written)
+			atomic.AddInt64(counter, int64(written))
+			if werr != nil {
+				closeBoth()
+				return total
+			}
+		}
+		if err == io.EOF {
+			if cw, ok := dst.(interface{ CloseWrite() error }); ok && cw.CloseWrite() == nil {
+				return total
+			}
+			closeBoth()
+			return total
+		}
+		if err != nil {
+			closeBoth()
+			return total
+		}
+	}
+}
+
+// idleCheckInterval 检查空闲连接的间隔，超时后最多延迟约四分之一的超时时间关闭
+func idleCheckInterval(timeout time.Duration) time.Duration {
+	interval := timeout / 4
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	if interval > time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// udpSessionTimeout 没有设置空闲超时时UDP会话的超时时间。UDP没有关闭连接的信号，
+// 与NAT和socat一样按空闲时间回收会话，否则会话数一直增长，限制会话数时新的客户端会一直被拒绝
+var udpSessionTimeout = 60 * time.Second
+
+// udpSession 表示一个UDP客户端会话
+type udpSession struct {
+	clientAddr *net.UDPAddr
+	targetConn *net.UDPConn
+	lastActive int64 // UnixNano
+}
+
+// serveUDP 处理UDP转发
+func (f *Forwarder) serveUDP(conn *net.UDPConn) error {
+	targetAddr, err := net.ResolveUDPAddr("udp", f.options.Target)
+	if err != nil {
+		return errs.Wrap(err, "解析UDP目标地址失败: %v", err)
+	}
+
+	sessions := make(map[string]*udpSession)
+	var mutex sync.Mutex
+
+	// 定期清理空闲会话
+	timeout := f.options.IdleTimeout
+	if timeout <= 0 {
+		timeout = udpSessionTimeout
+	}
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		ticker := time.NewTicker(idleCheckInterval(timeout))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-f.stopChan:
+				return
+			case <-ticker.C:
+				now := time.Now().UnixNano()
+				mutex.Lock()
+				for key, s := range sessions {
+					if time.Duration(now-atomic.LoadInt64(&s.lastActive)) > timeout {
+						f.logf(i18n.T("[UDP] 会话 %s 空闲超时"), key)
+						s.targetConn.Close()
+						delete(sessions, key)
+					}
+				}
+				mutex.Unlock()
+			}
+		}
+	}()
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, clientAddr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-f.stopChan:
+				mutex.Lock()
+				for _, s := range sessions {
+					s.targetConn.Close()
+				}
+				mutex.Unlock()
+				return nil
+			default:
+			}
+			return i18n.Errorf("读取UDP数据失败: %v", err)
+		}
+
+		key := clientAddr.String()
+		mutex.Lock()
+		session, ok := sessions[key]
+		if !ok {
+			if f.options.MaxConnections > 0 && len(sessions) >= f.options.MaxConnections {
+				mutex.Unlock()
+				atomic.AddInt64(&f.stats.RejectedCount, 1)
+				f.logf(i18n.T("[UDP] 丢弃来自 %s 的数据: 已达到最大会话数 %d"), key, f.options.MaxConnections)
+				continue
+			}
+
+			targetConn, err := net.DialUDP("udp", nil, targetAddr)
+			if err != nil {
+				mutex.Unlock()
+				f.logf(i18n.T("[UDP] %s 连接目标 %s 失败: %v"), key, f.options.Target, err)
+				continue
+			}
+			session = &udpSession{clientAddr: clientAddr, targetConn: targetConn}
+			sessions[key] = session
+			atomic.AddInt64(&f.stats.TotalConnections, 1)
+			atomic.AddInt64(&f.stats.ActiveConnections, 1)
+			f.logf(i18n.T("[UDP] 新会话 %s -> %s"), key, f.options.Target)
+
+			// 将目标的响应返回给客户端
+			f.wg.Add(1)
+			go func(s *udpSession) {
+				defer f.wg.Done()
+				defer atomic.AddInt64(&f.stats.ActiveConnections, -1)
+				reply := make([]byte, 64*1024)
+				for {
+					n, err := s.targetConn.Read(reply)
+					if err != nil {
+						return
+					}
+					atomic.StoreInt64(&s.lastActive, time.Now().UnixNano())
+					if written, err := conn.WriteToUDP(reply[:n], s.clientAddr); err == nil {
+						atomic.AddInt64(&f.stats.BytesOut, int64(written))
+					}
+				}
+			}(session)
+		}
+		mutex.Unlock()
+
+		atomic.StoreInt64(&session.lastActive, time.Now().UnixNano())
+		if written, err := session.targetConn.Write(buf[:n]); err == nil {
+			atomic.AddInt64(&f.stats.BytesIn, int64(written))
+		}
+	}
+}
+
+// acquire 获取一个连接名额
+func (f *Forwarder) acquire() bool {
+	if f.sem == nil {
+		return true
+	}
+	select {
+	case f.sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release 释放一个连接名额
+func (f *Forwarder) release() {
+	if f.sem != nil {
+		<-f.sem
+	}
+}
+
+// logf 输出连接日志
+func (f *Forwarder) logf(format string, args ...interface{}) {
+	if f.options.LogCallback != nil {
+		f.options.LogCallback(fmt.Sprintf(format, args...))
+	}
+}
+
+// StartForward 启动端口转发，收到中断信号时停止
+func StartForward(options ForwardOptions) (ForwardStats, error) {
+	forwarder, err := NewForwarder(options)
+	if err != nil {
+		return ForwardStats{}, err
+	}
+
+	// 捕获中断信号
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+
+	go func() {
+		select {
+		case <-signalChan:
+			forwarder.logf(i18n.T("收到中断信号，正在停止转发..."))
+			forwarder.Stop()
+		case <-forwarder.stopChan:
+		}
+	}()
+
+	err = forwarder.Run()
+	return forwarder.Stats(), err
+}
@@ -0,0 +1,94 @@
+package netdiag
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// 没有设置空闲超时时UDP会话也会回收，达到最大会话数后新的客户端在超时后可以建立会话
+func TestForwardUDPSessionTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { udpSessionTimeout = timeout }(udpSessionTimeout)
+	udpSessionTimeout = 200 * time.Millisecond
+
+	// 回显收到的数据的目标
+	target, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := target.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			target.WriteToUDP(buf[:n], addr)
+		}
+	}()
+
+	// 先占用一个端口再释放，作为转发的监听地址
+	probe, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listen := probe.LocalAddr().String()
+	probe.Close()
+
+	forwarder, err := NewForwarder(ForwardOptions{
+		Listen:         listen,
+		Target:         target.LocalAddr().String(),
+		Protocol:       "udp",
+		MaxConnections: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- forwarder.Run() }()
+	defer func() {
+		forwarder.Stop()
+		<-done
+	}()
+
+	// exchange 从一个新的客户端发送数据，返回是否收到回显
+	exchange := func(client *net.UDPConn) bool {
+		if _, err := client.Write([]byte("ping")); err != nil {
+			t.Fatal(err)
+		}
+		client.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		buf := make([]byte, 16)
+		n, err := client.Read(buf)
+		return err == nil && string(buf[:n]) == "ping"
+	}
+	dial := func() *net.UDPConn {
+		client, err := net.DialUDP("udp", nil, probe.LocalAddr().(*net.UDPAddr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	first := dial()
+	defer first.Close()
+	ok := false
+	for i := 0; i < 20 && !ok; i++ { // 等待转发开始监听
+		time.Sleep(10 * time.Millisecond)
+		ok = exchange(first)
+	}
+	if !ok {
+		t.Fatal("第一个客户端没有收到回显")
+	}
+
+	second := dial()
+	defer second.Close()
+	if exchange(second) {
+		t.Fatal("达到最大会话数时不应为第二个客户端建立会话")
+	}
+
+	time.Sleep(3 * udpSessionTimeout)
+	if !exchange(second) {
+		t.Fatal("第一个会话超时后第二个客户端应可以建立会话")
+	}
+}
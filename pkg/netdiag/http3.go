@@ -0,0 +1,196 @@
+package netdiag
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+)
+
+// HTTP3CheckOptions HTTP/3检查选项
+type HTTP3CheckOptions struct {
+	Timeout  time.Duration // 每个阶段的超时时间
+	Insecure bool          // 是否跳过证书校验
+}
+
+// HTTP3CheckResult HTTP/3检查结果
+type HTTP3CheckResult struct {
+	URL     string // 检查的URL
+	Address string // 实际连接的地址（host:port）
+
+	// Alt-Svc 广告信息（来自HTTP/1.1或HTTP/2响应）
+	AltSvc         string   // 原始Alt-Svc响应头
+	AltSvcH3       bool     // Alt-Svc中是否声明了h3
+	AltSvcEntries  []string // Alt-Svc中声明的协议列表，如 h3=":443"
+	AltSvcProtocol string   // 返回Alt-Svc的协议版本
+
+	// QUIC 握手信息
+	QUICSupported bool          // QUIC握手是否成功
+	QUICVersion   string        // 协商的QUIC版本
+	QUICALPN      string        // 协商的ALPN协议
+	TLSVersion    string        // TLS版本
+	HandshakeTime time.Duration // QUIC握手耗时
+	QUICError     string        // QUIC握手失败原因
+
+	// HTTP/3 请求
+	H3Status  int           // HTTP/3响应状态码
+	H3Latency time.Duration // HTTP/3请求耗时（含握手）
+	H3Error   string        // HTTP/3请求失败原因
+
+	// HTTP/2（TCP）请求
+	H2Protocol string        // TCP上实际使用的协议，如 HTTP/2.0
+	H2Status   int           // 响应状态码
+	H2Latency  time.Duration // 请求耗时（含TCP和TLS握手）
+	H2Error    string        // 请求失败原因
+}
+
+// CheckHTTP3 检查指定端点的HTTP/3与QUIC支持情况，并与TCP上的HTTP/2对比延迟
+func CheckHTTP3(rawURL string, options HTTP3CheckOptions) (HTTP3CheckResult, error) {
+	result := HTTP3CheckResult{}
+
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Second
+	}
+
+	// 未指定协议时默认使用https
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return result, errs.InvalidInput("无效的URL: %v", err)
+	}
+	if u.Scheme != "https" {
+		return result, errs.InvalidInput("HTTP/3仅支持https协议")
+	}
+	result.URL = u.String()
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	result.Address = net.JoinHostPort(u.Hostname(), port)
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: options.Insecure,
+	}
+
+	// 1. 通过TCP发起HTTP/2请求，同时获取Alt-Svc广告
+	checkHTTP2(u.String(), tlsConfig, options.Timeout, &result)
+
+	// 2. 单独进行QUIC握手，获取版本和ALPN信息
+	checkQUICHandshake(result.Address, tlsConfig, options.Timeout, &result)
+
+	// 3. 通过HTTP/3发起请求
+	if result.QUICSupported {
+		checkHTTP3Request(u.String(), tlsConfig, options.Timeout, &result)
+	}
+
+	return result, nil
+}
+
+// checkHTTP2 通过TCP发起请求，记录协议、耗时和Alt-Svc
+func checkHTTP2(target string, tlsConfig *tls.Config, timeout time.Duration, result *HTTP3CheckResult) {
+	transport := &http.Transport{
+		TLSClientConfig:   tlsConfig.Clone(),
+		ForceAttemptHTTP2: true,
+	}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Transport: transport, Timeout: timeout}
+
+	start := time.Now()
+	resp, err := client.Get(target)
+	if err != nil {
+		result.H2Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	result.H2Latency = time.Since(start)
+	result.H2Status = resp.StatusCode
+	result.H2Protocol = resp.Proto
+
+	// 解析Alt-Svc响应头
+	result.AltSvc = resp.Header.Get("Alt-Svc")
+	result.AltSvcProtocol = resp.Proto
+	result.AltSvcEntries = parseAltSvc(result.AltSvc)
+	for _, entry := range result.AltSvcEntries {
+		if strings.HasPrefix(entry, "h3=") || strings.HasPrefix(entry, "h3-") {
+			result.AltSvcH3 = true
+			break
+		}
+	}
+}
+
+// checkQUICHandshake 进行QUIC握手
+func checkQUICHandshake(address string, tlsConfig *tls.Config, timeout time.Duration, result *HTTP3CheckResult) {
+	quicTLS := tlsConfig.Clone()
+	quicTLS.NextProtos = []string{http3.NextProtoH3}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, err := quic.DialAddr(ctx, address, quicTLS, &quic.Config{HandshakeIdleTimeout: timeout})
+	if err != nil {
+		result.QUICError = err.Error()
+		return
+	}
+	defer conn.CloseWithError(0, "")
+
+	result.HandshakeTime = time.Since(start)
+	result.QUICSupported = true
+
+	state := conn.ConnectionState()
+	result.QUICVersion = state.Version.String()
+	result.QUICALPN = state.TLS.NegotiatedProtocol
+	result.TLSVersion = tls.VersionName(state.TLS.Version)
+}
+
+// checkHTTP3Request 通过HTTP/3发起请求
+func checkHTTP3Request(target string, tlsConfig *tls.Config, timeout time.Duration, result *HTTP3CheckResult) {
+	transport := &http3.Transport{
+		TLSClientConfig: tlsConfig.Clone(),
+		QUICConfig:      &quic.Config{HandshakeIdleTimeout: timeout},
+	}
+	defer transport.Close()
+	client := &http.Client{Transport: transport, Timeout: timeout}
+
+	start := time.Now()
+	resp, err := client.Get(target)
+	if err != nil {
+		result.H3Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	result.H3Latency = time.Since(start)
+	result.H3Status = resp.StatusCode
+}
+
+// parseAltSvc 解析Alt-Svc响应头中的协议声明
+func parseAltSvc(header string) []string {
+	var entries []string
+	if header == "" || header == "clear" {
+		return entries
+	}
+	for _, part := range strings.Split(header, ",") {
+		// 每项格式为 proto="host:port"; ma=86400，只保留协议与地址部分
+		entry := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
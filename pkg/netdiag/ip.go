@@ -6,6 +6,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // IPInfo 表示IP地址相关信息
@@ -22,11 +24,11 @@ type IPInfo struct {
 
 // LocalIPInfo 表示本地IP地址信息
 type LocalIPInfo struct {
-	InterfaceName string
-	IPAddress     string
-	MACAddress    string
-	IsIPv4        bool
-	IsUp          bool
+	InterfaceName string `json:"interface"`
+	IPAddress     string `json:"ip"`
+	MACAddress    string `json:"mac"`
+	IsIPv4        bool   `json:"ipv4"`
+	IsUp          bool   `json:"up"`
 }
 
 // GetPublicIP 获取公共IP地址
@@ -50,6 +52,9 @@ func GetIPInfo(ip string) (IPInfo, error) {
 	var info IPInfo
 
 	// 如果IP为空，则获取本机公网IP
+	if ip != "" && net.ParseIP(ip) == nil {
+		return info, errs.InvalidInput("无效的IP地址: %s", ip)
+	}
 	if ip == "" {
 		var err error
 		ip, err = GetPublicIP()
@@ -132,7 +137,7 @@ func GetLocalIPs() ([]LocalIPInfo, error) {
 func IsPrivateIP(ipStr string) (bool, error) {
 	ip := net.ParseIP(ipStr)
 	if ip == nil {
-		return false, fmt.Errorf("无效的IP地址: %s", ipStr)
+		return false, i18n.Errorf("无效的IP地址: %s", ipStr)
 	}
 
 	// 检查是否为私有IP地址
@@ -0,0 +1,241 @@
+package netdiag
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+const (
+	// ipv4HeaderLen IPv4头部长度（不含选项）
+	ipv4HeaderLen = 20
+	// icmpHeaderLen ICMP头部长度
+	icmpHeaderLen = 8
+	// minIPv4MTU IPv4规定的最小MTU
+	minIPv4MTU = 68
+)
+
+// MTUOptions 路径MTU探测选项
+type MTUOptions struct {
+	MinSize          int           // 探测的最小MTU
+	MaxSize          int           // 探测的最大MTU
+	Timeout          time.Duration // 每个探测包的超时时间
+	Retries          int           // 超时后的重试次数
+	ProgressCallback func(string)  // 每个探测包的实时输出
+}
+
+// MTUResult 路径MTU探测结果
+type MTUResult struct {
+	Host      string // 目标主机
+	TargetIP  string // 目标IP
+	PathMTU   int    // 路径MTU（IP包总长度）
+	MaxICMP   int    // 不分片时可携带的最大ICMP载荷
+	MSS       int    // 对应的TCP MSS
+	Probes    int    // 发送的探测包数量
+	FragHop   string // 报告需要分片的路由器地址，为空表示未收到分片通知
+	FragMTU   int    // 该路由器报告的下一跳MTU
+	LocalOnly bool   // 是否由本地接口MTU限制
+	Error     string // 错误信息
+}
+
+// mtuProbeStatus 单个探测包的结果
+type mtuProbeStatus int
+
+const (
+	mtuProbeOK      mtuProbeStatus = iota // 收到回复，可以不分片通过
+	mtuProbeTooBig                        // 收到"需要分片"或本地发送失败
+	mtuProbeTimeout                       // 超时未收到任何回复
+)
+
+// DiscoverMTU 通过设置DF位的ICMP探测包二分查找到达目标的路径MTU
+func DiscoverMTU(host string, options MTUOptions) (MTUResult, error) {
+	result := MTUResult{Host: host}
+
+	if options.MinSize < minIPv4MTU {
+		options.MinSize = minIPv4MTU
+	}
+	if options.MaxSize <= 0 {
+		options.MaxSize = 1500
+	}
+	if options.MaxSize > 65535 {
+		options.MaxSize = 65535
+	}
+	if options.MaxSize < options.MinSize {
+		result.Error = i18n.T("最大MTU不能小于最小MTU")
+		return result, errs.InvalidInput("%s", result.Error)
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 2 * time.Second
+	}
+	if options.Retries < 0 {
+		options.Retries = 0
+	}
+
+	ipAddr, err := net.ResolveIPAddr("ip4", host)
+	if err != nil {
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
+		return result, err
+	}
+	result.TargetIP = ipAddr.String()
+
+	packetConn, err := net.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		result.Error = i18n.Tf("创建ICMP套接字失败: %v", err)
+		return result, err
+	}
+	conn := packetConn.(*net.IPConn)
+	defer conn.Close()
+
+	// 设置DF位，禁止在路径上分片
+	if err := setDontFragment(conn); err != nil {
+		result.Error = i18n.Tf("设置DF标志失败: %v", err)
+		return result, err
+	}
+
+	id := os.Getpid() & 0xffff
+	seq := 0
+
+	// probe 发送指定大小的探测包，超时后重试
+	probe := func(size int) mtuProbeStatus {
+		status := mtuProbeTimeout
+		for attempt := 0; attempt <= options.Retries; attempt++ {
+			seq++
+			result.Probes++
+
+			var router string
+			var nextHopMTU int
+			var local bool
+			status, router, nextHopMTU, local = sendMTUProbe(conn, ipAddr, id, seq, size, options.Timeout)
+
+			if options.ProgressCallback != nil {
+				options.ProgressCallback(formatMTUProbe(size, status, router, nextHopMTU, local))
+			}
+
+			if status == mtuProbeTooBig {
+				// 收到路由器通知后内核会缓存路径MTU，之后的本地发送失败不再视为接口限制
+				if local && result.FragHop == "" {
+					result.LocalOnly = true
+				} else if router != "" {
+					result.FragHop = router
+					result.FragMTU = nextHopMTU
+					result.LocalOnly = false
+				}
+			}
+			if status != mtuProbeTimeout {
+				break
+			}
+		}
+		return status
+	}
+
+	// 先确认最小尺寸能够到达目标
+	if probe(options.MinSize) != mtuProbeOK {
+		result.Error = i18n.Tf("目标主机对 %d 字节的探测包无响应", options.MinSize)
+		return result, errs.Timeout("%s", result.Error)
+	}
+
+	// 二分查找：low总是可以通过的尺寸，high以上总是不能通过
+	low, high := options.MinSize, options.MaxSize
+	for low < high {
+		mid := (low + high + 1) / 2
+		switch probe(mid) {
+		case mtuProbeOK:
+			low = mid
+		default:
+			high = mid - 1
+			// 路由器报告了下一跳MTU时可以直接缩小范围
+			if result.FragMTU >= low && result.FragMTU < high {
+				high = result.FragMTU
+			}
+		}
+	}
+
+	result.PathMTU = low
+	result.MaxICMP = low - ipv4HeaderLen - icmpHeaderLen
+	result.MSS = low - 40
+	return result, nil
+}
+
+// sendMTUProbe 发送一个探测包并等待回复，返回探测状态、报告分片的路由器和下一跳MTU
+func sendMTUProbe(conn *net.IPConn, target *net.IPAddr, id, seq, size int, timeout time.Duration) (mtuProbeStatus, string, int, bool) {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Code: 0,
+		Body: &icmp.Echo{
+			ID:   id,
+			Seq:  seq,
+			Data: make([]byte, size-ipv4HeaderLen-icmpHeaderLen),
+		},
+	}
+	packet, err := msg.Marshal(nil)
+	if err != nil {
+		return mtuProbeTimeout, "", 0, false
+	}
+
+	if _, err := conn.WriteTo(packet, target); err != nil {
+		// 超过本地接口MTU或内核缓存的路径MTU时，发送直接失败
+		if isMessageTooLong(err) {
+			return mtuProbeTooBig, "", 0, true
+		}
+		return mtuProbeTimeout, "", 0, false
+	}
+
+	deadline := time.Now().Add(timeout)
+	reply := make([]byte, size+1500)
+	for {
+		conn.SetReadDeadline(deadline)
+		n, peer, err := conn.ReadFrom(reply)
+		if err != nil {
+			return mtuProbeTimeout, "", 0, false
+		}
+
+		rm, err := icmp.ParseMessage(1, reply[:n])
+		if err != nil {
+			continue
+		}
+
+		switch body := rm.Body.(type) {
+		case *icmp.Echo:
+			if rm.Type == ipv4.ICMPTypeEchoReply && body.ID == id && body.Seq == seq {
+				return mtuProbeOK, "", 0, false
+			}
+		case *icmp.DstUnreach:
+			// 代码4表示需要分片但设置了DF位，下一跳MTU位于ICMP头部的第6-7字节
+			if rm.Code == 4 && matchQuotedProbe(body.Data, id, seq) {
+				nextHopMTU := 0
+				if n >= icmpHeaderLen {
+					nextHopMTU = int(binary.BigEndian.Uint16(reply[6:8]))
+				}
+				router := peer.String()
+				if ipa, ok := peer.(*net.IPAddr); ok {
+					router = ipa.IP.String()
+				}
+				return mtuProbeTooBig, router, nextHopMTU, false
+			}
+		}
+	}
+}
+
+// formatMTUProbe 格式化单个探测包的输出
+func formatMTUProbe(size int, status mtuProbeStatus, router string, nextHopMTU int, local bool) string {
+	switch status {
+	case mtuProbeOK:
+		return i18n.Tf("%5d 字节: 成功", size)
+	case mtuProbeTooBig:
+		if local {
+			return i18n.Tf("%5d 字节: 超过本地接口MTU", size)
+		}
+		if nextHopMTU > 0 {
+			return i18n.Tf("%5d 字节: 需要分片 (来自 %s, 下一跳MTU %d)", size, router, nextHopMTU)
+		}
+		return i18n.Tf("%5d 字节: 需要分片 (来自 %s)", size, router)
+	default:
+		return i18n.Tf("%5d 字节: 超时", size)
+	}
+}
@@ -0,0 +1,31 @@
+//go:build linux
+// +build linux
+
+package netdiag
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// setDontFragment 在套接字上设置DF位（IP_PMTUDISC_DO）
+func setDontFragment(conn *net.IPConn) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	err = rawConn.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IP, syscall.IP_MTU_DISCOVER, syscall.IP_PMTUDISC_DO)
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// isMessageTooLong 判断发送错误是否因为超过MTU
+func isMessageTooLong(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE)
+}
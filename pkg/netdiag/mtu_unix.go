@@ -0,0 +1,23 @@
+//go:build darwin || freebsd || netbsd || openbsd
+// +build darwin freebsd netbsd openbsd
+
+package netdiag
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+	"syscall"
+	"toolbox/pkg/i18n"
+)
+
+// setDontFragment BSD系列平台的IP_DONTFRAG取值各不相同，暂不支持
+func setDontFragment(conn *net.IPConn) error {
+	return i18n.Errorf("%s 平台暂不支持设置DF标志", runtime.GOOS)
+}
+
+// isMessageTooLong 判断发送错误是否因为超过MTU
+func isMessageTooLong(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE)
+}
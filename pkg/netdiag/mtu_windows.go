@@ -0,0 +1,37 @@
+//go:build windows
+// +build windows
+
+package netdiag
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// Windows平台下的常量
+const (
+	IP_DONTFRAGMENT = 14
+	WSAEMSGSIZE     = syscall.Errno(10040)
+)
+
+// setDontFragment 在套接字上设置DF位（IP_DONTFRAGMENT）
+func setDontFragment(conn *net.IPConn) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	err = rawConn.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(syscall.Handle(fd), syscall.IPPROTO_IP, IP_DONTFRAGMENT, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// isMessageTooLong 判断发送错误是否因为超过MTU
+func isMessageTooLong(err error) bool {
+	return errors.Is(err, WSAEMSGSIZE) || errors.Is(err, syscall.EMSGSIZE)
+}
@@ -2,6 +2,7 @@ package netdiag
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"net"
 	"os/exec"
@@ -9,16 +10,18 @@ import (
 	"runtime"
 	"strings"
 	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // PingResult 表示ping操作的结果
 type PingResult struct {
-	Destination    string
-	Success        bool
-	AvgLatency     string
-	PacketLoss     string
-	Error          string
-	DetailedOutput []string // 每次ping的详细输出
+	Destination    string   `json:"destination"`
+	Success        bool     `json:"success"`
+	AvgLatency     string   `json:"avg_latency"`
+	PacketLoss     string   `json:"packet_loss"`
+	Error          string   `json:"error,omitempty"`
+	DetailedOutput []string `json:"output"` // 每次ping的详细输出
 }
 
 // PingOptions Ping操作的选项
@@ -36,7 +39,7 @@ func Ping(host string, options PingOptions, callback func(string)) (PingResult,
 	_, err := net.LookupHost(host)
 	if err != nil {
 		result.Success = false
-		result.Error = fmt.Sprintf("无法解析主机名: %v", err)
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
 		return result, err
 	}
 
@@ -63,14 +66,14 @@ func Ping(host string, options PingOptions, callback func(string)) (PingResult,
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		result.Success = false
-		result.Error = fmt.Sprintf("创建管道失败: %v", err)
+		result.Error = i18n.Tf("创建管道失败: %v", err)
 		return result, err
 	}
 
 	// 启动命令
 	if err = cmd.Start(); err != nil {
 		result.Success = false
-		result.Error = fmt.Sprintf("启动ping命令失败: %v", err)
+		result.Error = i18n.Tf("启动ping命令失败: %v", err)
 		return result, err
 	}
 
@@ -90,7 +93,12 @@ func Ping(host string, options PingOptions, callback func(string)) (PingResult,
 	err = cmd.Wait()
 	if err != nil {
 		result.Success = false
-		result.Error = fmt.Sprintf("ping命令执行失败: %v", err)
+		result.Error = i18n.Tf("ping命令执行失败: %v", err)
+		// ping在没有收到任何回复时以状态码1退出
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return result, errs.Timeout("%s 没有响应", host)
+		}
 		return result, err
 	}
 
@@ -99,7 +107,7 @@ func Ping(host string, options PingOptions, callback func(string)) (PingResult,
 	result.Success = true
 
 	// 提取平均延迟
-	if strings.Contains(output, "Average") || strings.Contains(output, "平均") {
+	if strings.Contains(output, "Average") || strings.Contains(output, "平均") || strings.Contains(output, "min/avg/max") {
 		lines := strings.Split(output, "\n")
 		for _, line := range lines {
 			if strings.Contains(line, "Average") || strings.Contains(line, "平均") || strings.Contains(line, "avg") {
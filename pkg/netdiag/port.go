@@ -2,25 +2,26 @@ package netdiag
 
 import (
 	"fmt"
-	"log"
 	"net"
 	"strings"
 	"sync"
 	"time"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
 )
 
 // PortStatus 表示端口状态
 type PortStatus struct {
-	Port    int
-	Open    bool
-	Service string
+	Port    int    `json:"port"`
+	Open    bool   `json:"open"`
+	Service string `json:"service"`
 }
 
 // PortScanResult 表示端口扫描结果
 type PortScanResult struct {
-	Host  string
-	Ports []PortStatus
-	Error string
+	Host  string       `json:"host"`
+	Ports []PortStatus `json:"ports"`
+	Error string       `json:"error,omitempty"`
 }
 
 // 常见端口及其服务
@@ -45,7 +46,7 @@ var commonPorts = map[int]string{
 
 // ScanPort 检测指定主机的单个端口是否开放
 func ScanPort(host string, port int, timeout time.Duration) PortStatus {
-	log.Printf("开始扫描主机 %s 的端口 %d", host, port)
+	logger.Debugf("开始扫描主机 %s 的端口 %d", host, port)
 	result := PortStatus{
 		Port: port,
 		Open: false,
@@ -63,7 +64,7 @@ func ScanPort(host string, port int, timeout time.Duration) PortStatus {
 	conn, err := net.DialTimeout("tcp", address, timeout)
 
 	if err != nil {
-		log.Printf("扫描主机 %s 的端口 %d 失败: %v", host, port, err)
+		logger.Debugf("扫描主机 %s 的端口 %d 失败: %v", host, port, err)
 		return result
 	}
 
@@ -74,7 +75,7 @@ func ScanPort(host string, port int, timeout time.Duration) PortStatus {
 	if service, ok := commonPorts[port]; ok {
 		result.Service = service
 	} else {
-		result.Service = "未知服务"
+		result.Service = i18n.T("未知服务")
 	}
 
 	return result
@@ -90,8 +91,8 @@ func ScanPorts(host string, startPort, endPort int, timeout time.Duration, concu
 	// 检查主机名是否有效
 	_, err := net.LookupHost(host)
 	if err != nil {
-		log.Printf("无法解析主机名 %s: %v", host, err)
-		result.Error = fmt.Sprintf("无法解析主机名: %v", err)
+		logger.Warnf("无法解析主机名 %s: %v", host, err)
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
 		return result
 	}
 
@@ -120,7 +121,7 @@ func ScanPorts(host string, startPort, endPort int, timeout time.Duration, concu
 		}
 	}
 
-	log.Printf("完成扫描主机 %s 从端口 %d 到 %d，共发现 %d 个开放端口", host, startPort, endPort, len(result.Ports))
+	logger.Debugf("完成扫描主机 %s 从端口 %d 到 %d，共发现 %d 个开放端口", host, startPort, endPort, len(result.Ports))
 	return result
 }
 
@@ -134,8 +135,8 @@ func ScanCommonPorts(host string, timeout time.Duration, concurrency int) PortSc
 	// 检查主机名是否有效
 	_, err := net.LookupHost(host)
 	if err != nil {
-		log.Printf("无法解析主机名 %s: %v", host, err)
-		result.Error = fmt.Sprintf("无法解析主机名: %v", err)
+		logger.Warnf("无法解析主机名 %s: %v", host, err)
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
 		return result
 	}
 
@@ -164,7 +165,7 @@ func ScanCommonPorts(host string, timeout time.Duration, concurrency int) PortSc
 		}
 	}
 
-	log.Printf("完成扫描主机 %s 的常用端口，共发现 %d 个开放端口", host, len(result.Ports))
+	logger.Debugf("完成扫描主机 %s 的常用端口，共发现 %d 个开放端口", host, len(result.Ports))
 	return result
 }
 
@@ -178,8 +179,8 @@ func ScanSpecificPorts(host string, ports []int, timeout time.Duration, concurre
 	// 检查主机名是否有效
 	_, err := net.LookupHost(host)
 	if err != nil {
-		log.Printf("无法解析主机名 %s: %v", host, err)
-		result.Error = fmt.Sprintf("无法解析主机名: %v", err)
+		logger.Warnf("无法解析主机名 %s: %v", host, err)
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
 		return result
 	}
 
@@ -208,6 +209,6 @@ func ScanSpecificPorts(host string, ports []int, timeout time.Duration, concurre
 		}
 	}
 
-	log.Printf("完成扫描主机 %s 的指定端口列表，共发现 %d 个开放端口", host, len(result.Ports))
+	logger.Debugf("完成扫描主机 %s 的指定端口列表，共发现 %d 个开放端口", host, len(result.Ports))
 	return result
 }
@@ -6,6 +6,8 @@ import (
 	"math/rand"
 	"net/http"
 	"time"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
 )
 
 // SpeedTestServer 表示速度测试服务器的配置
@@ -43,7 +45,7 @@ func StartSpeedTestServer(config *SpeedTestServer) error {
 
 	// 启动HTTP服务器
 	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
-	fmt.Printf("启动速度测试服务器在 %s\n", addr)
+	logger.Infof("启动速度测试服务器在 %s", addr)
 	return http.ListenAndServe(addr, nil)
 }
 
@@ -60,7 +62,7 @@ func handleUpload(w http.ResponseWriter, r *http.Request) {
 	limitedReader := http.MaxBytesReader(w, r.Body, 100*1024*1024) // 最大100MB
 	_, err := io.ReadAll(limitedReader)
 	if err != nil {
-		http.Error(w, "读取上传数据失败", http.StatusInternalServerError)
+		http.Error(w, i18n.T("读取上传数据失败"), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
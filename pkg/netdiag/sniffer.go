@@ -1,15 +1,18 @@
 package netdiag
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
-	"log"
 	"os"
 	"os/signal"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
@@ -102,32 +105,32 @@ func (ps *PacketStats) PrintStats() {
 	ps.EndTime = time.Now()
 	duration := ps.EndTime.Sub(ps.StartTime)
 
-	fmt.Println("\n==== 网络抓包统计信息 ====")
-	fmt.Printf("捕获时间: %s\n", duration.Round(time.Millisecond))
-	fmt.Printf("数据包总数: %d\n", ps.PacketCount)
-	fmt.Printf("总字节数: %d bytes\n", ps.TotalBytes)
+	fmt.Println(i18n.T("\n==== 网络抓包统计信息 ===="))
+	fmt.Print(i18n.Tf("捕获时间: %s\n", duration.Round(time.Millisecond)))
+	fmt.Print(i18n.Tf("数据包总数: %d\n", ps.PacketCount))
+	fmt.Print(i18n.Tf("总字节数: %d bytes\n", ps.TotalBytes))
 	if duration.Seconds() > 0 {
-		fmt.Printf("平均速率: %.2f 包/秒, %.2f bytes/秒\n",
+		fmt.Print(i18n.Tf("平均速率: %.2f 包/秒, %.2f bytes/秒\n",
 			float64(ps.PacketCount)/duration.Seconds(),
-			float64(ps.TotalBytes)/duration.Seconds())
+			float64(ps.TotalBytes)/duration.Seconds()))
 	}
 
 	// 打印协议分布
-	fmt.Println("\n协议分布:")
+	fmt.Println(i18n.T("\n协议分布:"))
 	for proto, count := range ps.ProtocolMap {
 		fmt.Printf("  %s: %d (%.1f%%)\n", proto, count, float64(count)*100/float64(ps.PacketCount))
 	}
 
 	// 打印最活跃的源IP (top 5)
-	fmt.Println("\n最活跃的源IP地址:")
+	fmt.Println(i18n.T("\n最活跃的源IP地址:"))
 	printTopItems(ps.SourceIPs, 5)
 
 	// 打印最活跃的目标IP (top 5)
-	fmt.Println("\n最活跃的目标IP地址:")
+	fmt.Println(i18n.T("\n最活跃的目标IP地址:"))
 	printTopItems(ps.DestIPs, 5)
 
 	// 打印最活跃的端口 (top 5)
-	fmt.Println("\n最活跃的端口:")
+	fmt.Println(i18n.T("\n最活跃的端口:"))
 	printTopItemsUint16(ps.SourcePorts, 5)
 }
 
@@ -193,8 +196,48 @@ func printTopItemsUint16(items map[uint16]int, n int) {
 	}
 }
 
-// StartSniffer 开始网络抓包
+// PacketCallback 每捕获一个数据包调用一次，在抓包循环中同步执行
+type PacketCallback func(packet gopacket.Packet)
+
+// StartSniffer 开始网络抓包，逐个输出数据包信息，收到中断信号时停止
 func StartSniffer(config SnifferConfig) error {
+	// 创建输出文件
+	var outFile *os.File
+	if config.Output != "" {
+		var err error
+		outFile, err = os.Create(config.Output)
+		if err != nil {
+			return errs.Wrap(err, "创建输出文件失败: %v", err)
+		}
+		defer outFile.Close()
+	}
+
+	// 收到中断信号时停止抓包
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stats, err := Capture(ctx, config, func(packet gopacket.Packet) {
+		printPacketInfo(packet, config.Verbose, outFile, config.PayloadLen)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 打印统计信息
+	if stats != nil {
+		stats.PrintStats()
+	}
+
+	return nil
+}
+
+// Capture 在指定接口上抓包并对每个数据包调用onPacket，直到ctx取消、
+// 达到Count或数据包来源结束
+//
+// 不输出任何内容，适合在其他界面中实时展示数据包；config.SavePcap 非空时同时写入
+// pcap文件，config.Statistics 为true时返回统计信息，否则返回nil。
+// config.Output、Verbose 和 PayloadLen 只用于 StartSniffer 的输出，这里忽略。
+func Capture(ctx context.Context, config SnifferConfig, onPacket PacketCallback) (*PacketStats, error) {
 	// 设置默认值
 	if config.Snaplen <= 0 {
 		config.Snaplen = 1600
@@ -203,25 +246,23 @@ func StartSniffer(config SnifferConfig) error {
 	// 打开网络接口
 	handle, err := pcap.OpenLive(config.Interface, int32(config.Snaplen), config.Promiscuous, config.Timeout)
 	if err != nil {
-		return fmt.Errorf("打开网络接口失败: %v", err)
+		// libpcap只返回错误文本，根据文本判断错误类别
+		message := strings.ToLower(err.Error())
+		switch {
+		case strings.Contains(message, "permission") || strings.Contains(message, "not permitted"):
+			return nil, errs.PermissionDenied("打开网络接口失败: %v", err)
+		case strings.Contains(message, "no such device"):
+			return nil, errs.NotFound("打开网络接口失败: %v", err)
+		}
+		return nil, i18n.Errorf("打开网络接口失败: %v", err)
 	}
 	defer handle.Close()
 
 	// 设置过滤器
 	if config.Filter != "" {
 		if err := handle.SetBPFFilter(config.Filter); err != nil {
-			return fmt.Errorf("设置过滤器失败: %v", err)
-		}
-	}
-
-	// 创建输出文件
-	var outFile *os.File
-	if config.Output != "" {
-		outFile, err = os.Create(config.Output)
-		if err != nil {
-			return fmt.Errorf("创建输出文件失败: %v", err)
+			return nil, errs.InvalidInput("设置过滤器失败: %v", err)
 		}
-		defer outFile.Close()
 	}
 
 	// 创建pcap文件写入器
@@ -229,13 +270,13 @@ func StartSniffer(config SnifferConfig) error {
 	if config.SavePcap != "" {
 		pcapFile, err := os.Create(config.SavePcap)
 		if err != nil {
-			return fmt.Errorf("创建pcap文件失败: %v", err)
+			return nil, errs.Wrap(err, "创建pcap文件失败: %v", err)
 		}
 		defer pcapFile.Close()
 
 		pcapWriter = pcapgo.NewWriter(pcapFile)
 		if err := pcapWriter.WriteFileHeader(uint32(config.Snaplen), handle.LinkType()); err != nil {
-			return fmt.Errorf("写入pcap文件头失败: %v", err)
+			return nil, i18n.Errorf("写入pcap文件头失败: %v", err)
 		}
 	}
 
@@ -245,25 +286,10 @@ func StartSniffer(config SnifferConfig) error {
 		stats = NewPacketStats()
 	}
 
-	// 创建信号通道，用于捕获中断信号
-	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
-
-	// 创建停止通道，用于通知抓包循环退出
-	stopChan := make(chan struct{})
-
 	// 开始抓包
 	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
 	packetChan := packetSource.Packets()
-	log.Printf("开始抓包，接口: %s, 过滤器: %s\n", config.Interface, config.Filter)
-
-	// 启动goroutine监听中断信号
-	go func() {
-		<-signalChan
-		log.Println("收到中断信号，正在停止抓包...")
-		close(stopChan) // 通知抓包循环退出
-		signal.Stop(signalChan)
-	}()
+	logger.Infof("开始抓包，接口: %s, 过滤器: %s", config.Interface, config.Filter)
 
 	count := 0
 	// 使用可中断的抓包循环
@@ -276,13 +302,14 @@ loop:
 				break loop
 			}
 
-			// 解析并显示数据包信息
-			printPacketInfo(packet, config.Verbose, outFile, config.PayloadLen)
+			if onPacket != nil {
+				onPacket(packet)
+			}
 
 			// 写入pcap文件
 			if pcapWriter != nil {
 				if err := pcapWriter.WritePacket(packet.Metadata().CaptureInfo, packet.Data()); err != nil {
-					log.Printf("写入pcap文件失败: %v", err)
+					logger.Errorf("写入pcap文件失败: %v", err)
 				}
 			}
 
@@ -296,23 +323,18 @@ loop:
 				break loop
 			}
 
-		case <-stopChan:
-			// 收到停止信号
-			log.Println("停止抓包...")
+		case <-ctx.Done():
+			// 调用方取消或收到中断信号
+			logger.Infof("停止抓包...")
 			break loop
 		}
 	}
 
-	// 打印统计信息
-	if stats != nil {
-		stats.PrintStats()
-	}
-
-	return nil
+	return stats, nil
 }
 
-// printPacketInfo 打印数据包信息
-func printPacketInfo(packet gopacket.Packet, verbose bool, outFile *os.File, payloadLen int) {
+// PacketSummary 返回数据包的单行摘要，与抓包时输出的格式相同，payloadLen 为显示的载荷长度
+func PacketSummary(packet gopacket.Packet, payloadLen int) string {
 	// 获取时间戳
 	timestamp := packet.Metadata().Timestamp.Format("15:04:05.000000")
 
@@ -425,6 +447,12 @@ func printPacketInfo(packet gopacket.Packet, verbose bool, outFile *os.File, pay
 		}
 	}
 
+	return output
+}
+
+// printPacketInfo 打印数据包信息
+func printPacketInfo(packet gopacket.Packet, verbose bool, outFile *os.File, payloadLen int) {
+	output := PacketSummary(packet, payloadLen)
 	fmt.Println(output)
 
 	// 如果详细模式，打印更多信息
@@ -435,12 +463,12 @@ func printPacketInfo(packet gopacket.Packet, verbose bool, outFile *os.File, pay
 	// 如果指定了输出文件，则写入文件
 	if outFile != nil {
 		if _, err := outFile.WriteString(output + "\n"); err != nil {
-			log.Printf("写入文件失败: %v", err)
+			logger.Errorf("写入文件失败: %v", err)
 		}
 
 		if verbose {
 			if _, err := outFile.WriteString(packet.Dump() + "\n"); err != nil {
-				log.Printf("写入详细信息到文件失败: %v", err)
+				logger.Errorf("写入详细信息到文件失败: %v", err)
 			}
 		}
 	}
@@ -475,14 +503,14 @@ func formatPayload(payload []byte) string {
 func ListInterfaces() ([]string, error) {
 	devices, err := pcap.FindAllDevs()
 	if err != nil {
-		return nil, fmt.Errorf("获取网络接口列表失败: %v", err)
+		return nil, i18n.Errorf("获取网络接口列表失败: %v", err)
 	}
 
 	var interfaces []string
 	for _, device := range devices {
 		desc := device.Description
 		if desc == "" {
-			desc = "无描述"
+			desc = i18n.T("无描述")
 		}
 		info := fmt.Sprintf("%s: %s", device.Name, desc)
 
@@ -496,3 +524,25 @@ func ListInterfaces() ([]string, error) {
 
 	return interfaces, nil
 }
+
+// InterfaceCompletions 返回可用于抓包的接口名称及描述（名称\t描述），用于命令行补全
+func InterfaceCompletions() ([]string, error) {
+	devices, err := pcap.FindAllDevs()
+	if err != nil {
+		return nil, i18n.Errorf("获取网络接口列表失败: %v", err)
+	}
+
+	completions := make([]string, 0, len(devices))
+	for _, device := range devices {
+		desc := device.Description
+		if desc == "" && len(device.Addresses) > 0 {
+			desc = device.Addresses[0].IP.String()
+		}
+		if desc != "" {
+			completions = append(completions, device.Name+"\t"+desc)
+		} else {
+			completions = append(completions, device.Name)
+		}
+	}
+	return completions, nil
+}
@@ -2,19 +2,20 @@ package netdiag
 
 import (
 	"bytes"
-	"fmt"
+	"context"
 	"io"
 	"net/http"
 	"time"
+	"toolbox/pkg/i18n"
 )
 
 // SpeedTestResult 表示网络速度测试结果
 type SpeedTestResult struct {
-	DownloadSpeed float64 // 单位: Mbps
-	UploadSpeed   float64 // 单位: Mbps
-	Latency       float64 // 单位: ms
-	ServerName    string
-	Error         string
+	DownloadSpeed float64 `json:"download_mbps"` // 单位: Mbps
+	UploadSpeed   float64 `json:"upload_mbps"`   // 单位: Mbps
+	Latency       float64 `json:"latency_ms"`    // 单位: ms
+	ServerName    string  `json:"server"`
+	Error         string  `json:"error,omitempty"`
 }
 
 // 默认测试服务器URL
@@ -24,38 +25,140 @@ const (
 	defaultPingURL     = "http://localhost:8080/ping"     // 本地Ping测试URL
 )
 
+// SpeedTestPhase 速度测试的阶段
+type SpeedTestPhase string
+
+const (
+	PhaseLatency  SpeedTestPhase = "latency"  // 测试延迟
+	PhaseDownload SpeedTestPhase = "download" // 测试下载速度
+	PhaseUpload   SpeedTestPhase = "upload"   // 测试上传速度
+)
+
+// SpeedSample 测试过程中的阶段性结果
+type SpeedSample struct {
+	Phase   SpeedTestPhase // 所处阶段
+	Bytes   int64          // 本阶段已传输的字节数
+	Elapsed time.Duration  // 本阶段已用时间
+	Speed   float64        // 下载/上传阶段为当前平均速度(Mbps)，延迟阶段为本次延迟(ms)
+}
+
+// SpeedSampleCallback 接收阶段性结果的回调
+type SpeedSampleCallback func(sample SpeedSample)
+
+// SpeedTestOptions 速度测试选项
+type SpeedTestOptions struct {
+	DownloadURL    string              // 下载测试URL，为空时使用本地测试服务器
+	UploadURL      string              // 上传测试URL，为空时使用本地测试服务器
+	PingURL        string              // 延迟测试URL，为空时使用本地测试服务器
+	UploadSizeMB   int                 // 上传的数据量(MB)，默认5
+	PingCount      int                 // 延迟测试次数，默认5
+	SampleInterval time.Duration       // 下载/上传阶段回调的间隔，默认200ms
+	OnSample       SpeedSampleCallback // 阶段性结果回调，可为nil
+}
+
+// speedCounter 统计传输的字节数，并按间隔回调当前速度
+type speedCounter struct {
+	phase    SpeedTestPhase
+	start    time.Time
+	last     time.Time
+	interval time.Duration
+	bytes    int64
+	onSample SpeedSampleCallback
+}
+
+// newSpeedCounter 创建字节计数器
+func newSpeedCounter(phase SpeedTestPhase, interval time.Duration, onSample SpeedSampleCallback) *speedCounter {
+	now := time.Now()
+	return &speedCounter{phase: phase, start: now, last: now, interval: interval, onSample: onSample}
+}
+
+// add 累加字节数，距上次回调超过间隔时回调一次
+func (c *speedCounter) add(n int) {
+	c.bytes += int64(n)
+	if c.onSample == nil {
+		return
+	}
+	if now := time.Now(); now.Sub(c.last) >= c.interval {
+		c.last = now
+		c.onSample(c.sample())
+	}
+}
+
+// sample 返回当前的阶段性结果
+func (c *speedCounter) sample() SpeedSample {
+	elapsed := time.Since(c.start)
+	return SpeedSample{Phase: c.phase, Bytes: c.bytes, Elapsed: elapsed, Speed: toMbps(c.bytes, elapsed)}
+}
+
+// Write 实现 io.Writer，用于统计下载的数据
+func (c *speedCounter) Write(p []byte) (int, error) {
+	c.add(len(p))
+	return len(p), nil
+}
+
+// countingReader 统计上传时读取的数据
+type countingReader struct {
+	reader  io.Reader
+	counter *speedCounter
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.counter.add(n)
+	return n, err
+}
+
+// toMbps 计算Mbps (兆比特每秒)
+// bytes * 8 为比特数，除以1000000为兆比特，除以耗时为每秒
+func toMbps(bytes int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return (float64(bytes) * 8) / 1000000 / elapsed.Seconds()
+}
+
 // TestDownloadSpeed 测试下载速度
 func TestDownloadSpeed(url string) (float64, error) {
+	return TestDownloadSpeedContext(context.Background(), url, 0, nil)
+}
+
+// TestDownloadSpeedContext 测试下载速度，ctx取消时立即停止，onSample不为nil时按interval回调当前速度
+func TestDownloadSpeedContext(ctx context.Context, url string, interval time.Duration, onSample SpeedSampleCallback) (float64, error) {
 	if url == "" {
 		url = defaultDownloadURL
 	}
 
-	start := time.Now()
-
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
 
-	// 将响应体读取到内存中以计算下载速度
-	// 使用io.Copy到io.Discard避免额外的内存分配
-	bytes, err := io.Copy(io.Discard, resp.Body)
+	counter := newSpeedCounter(PhaseDownload, interval, onSample)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return 0, err
 	}
+	defer resp.Body.Close()
 
-	duration := time.Since(start).Seconds()
-
-	// 计算Mbps (兆比特每秒)
-	// bytes * 8 为比特数，除以1000000为兆比特，除以耗时为每秒
-	mbps := (float64(bytes) * 8) / 1000000 / duration
+	// 只统计字节数，不保留数据，避免额外的内存分配
+	if _, err := io.Copy(counter, resp.Body); err != nil {
+		return 0, err
+	}
 
-	return mbps, nil
+	sample := counter.sample()
+	if onSample != nil {
+		onSample(sample)
+	}
+	return sample.Speed, nil
 }
 
 // TestUploadSpeed 测试上传速度
 func TestUploadSpeed(url string, sizeMB int) (float64, error) {
+	return TestUploadSpeedContext(context.Background(), url, sizeMB, 0, nil)
+}
+
+// TestUploadSpeedContext 测试上传速度，ctx取消时立即停止，onSample不为nil时按interval回调当前速度
+func TestUploadSpeedContext(ctx context.Context, url string, sizeMB int, interval time.Duration, onSample SpeedSampleCallback) (float64, error) {
 	if url == "" {
 		url = defaultUploadURL
 	}
@@ -67,13 +170,18 @@ func TestUploadSpeed(url string, sizeMB int) (float64, error) {
 	// 生成要上传的数据
 	data := make([]byte, sizeMB*1000000) // 生成sizeMB兆字节的数据
 
-	start := time.Now()
-
-	// 创建一个简单的Reader来读取数据
-	dataReader := io.NopCloser(bytes.NewReader(data))
+	counter := newSpeedCounter(PhaseUpload, interval, onSample)
+	body := &countingReader{reader: bytes.NewReader(data), counter: counter}
 
 	// 执行POST请求进行上传
-	resp, err := http.Post(url, "application/octet-stream", dataReader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return 0, err
 	}
@@ -85,16 +193,20 @@ func TestUploadSpeed(url string, sizeMB int) (float64, error) {
 		return 0, err
 	}
 
-	duration := time.Since(start).Seconds()
-
-	// 计算Mbps (兆比特每秒)
-	mbps := (float64(len(data)) * 8) / 1000000 / duration
-
-	return mbps, nil
+	sample := counter.sample()
+	if onSample != nil {
+		onSample(sample)
+	}
+	return sample.Speed, nil
 }
 
 // TestLatency 测试网络延迟
 func TestLatency(url string, count int) (float64, error) {
+	return TestLatencyContext(context.Background(), url, count, nil)
+}
+
+// TestLatencyContext 测试网络延迟，ctx取消时立即停止，onSample不为nil时每次请求后回调本次延迟
+func TestLatencyContext(ctx context.Context, url string, count int, onSample SpeedSampleCallback) (float64, error) {
 	if url == "" {
 		url = defaultPingURL
 	}
@@ -104,11 +216,17 @@ func TestLatency(url string, count int) (float64, error) {
 	}
 
 	var totalLatency float64
+	phaseStart := time.Now()
 
 	for i := 0; i < count; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return 0, err
+		}
+
 		start := time.Now()
 
-		resp, err := http.Get(url)
+		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			return 0, err
 		}
@@ -123,9 +241,16 @@ func TestLatency(url string, count int) (float64, error) {
 
 		latency := time.Since(start).Milliseconds()
 		totalLatency += float64(latency)
+		if onSample != nil {
+			onSample(SpeedSample{Phase: PhaseLatency, Elapsed: time.Since(phaseStart), Speed: float64(latency)})
+		}
 
 		// 等待一小段时间再进行下一次测试
-		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
 	}
 
 	// 计算平均延迟
@@ -136,31 +261,56 @@ func TestLatency(url string, count int) (float64, error) {
 
 // RunSpeedTest 执行完整的网络速度测试
 func RunSpeedTest() SpeedTestResult {
+	return RunSpeedTestContext(context.Background(), SpeedTestOptions{})
+}
+
+// RunSpeedTestContext 按选项执行完整的网络速度测试，依次测试延迟、下载和上传
+//
+// ctx取消时立即停止并在 Error 中说明已取消；OnSample 在测试过程中接收阶段性结果，
+// 可用于实时显示当前速度。
+func RunSpeedTestContext(ctx context.Context, options SpeedTestOptions) SpeedTestResult {
+	if options.UploadSizeMB <= 0 {
+		options.UploadSizeMB = 5
+	}
+	if options.SampleInterval <= 0 {
+		options.SampleInterval = 200 * time.Millisecond
+	}
+
 	result := SpeedTestResult{
-		ServerName: "本地测试服务器",
+		ServerName: i18n.T("本地测试服务器"),
+	}
+	if options.DownloadURL != "" || options.UploadURL != "" || options.PingURL != "" {
+		result.ServerName = i18n.T("自定义测试服务器")
+	}
+
+	// failed 记录失败原因，取消时不显示底层的网络错误
+	failed := func(stage string, err error) SpeedTestResult {
+		if ctx.Err() != nil {
+			result.Error = i18n.T("速度测试已取消")
+		} else {
+			result.Error = i18n.Tf("%s失败: %v", stage, err)
+		}
+		return result
 	}
 
 	// 测试延迟
-	latency, err := TestLatency("", 5)
+	latency, err := TestLatencyContext(ctx, options.PingURL, options.PingCount, options.OnSample)
 	if err != nil {
-		result.Error = fmt.Sprintf("测试延迟失败: %v", err)
-		return result
+		return failed(i18n.T("测试延迟"), err)
 	}
 	result.Latency = latency
 
 	// 测试下载速度
-	downloadSpeed, err := TestDownloadSpeed("")
+	downloadSpeed, err := TestDownloadSpeedContext(ctx, options.DownloadURL, options.SampleInterval, options.OnSample)
 	if err != nil {
-		result.Error = fmt.Sprintf("测试下载速度失败: %v", err)
-		return result
+		return failed(i18n.T("测试下载速度"), err)
 	}
 	result.DownloadSpeed = downloadSpeed
 
 	// 测试上传速度
-	uploadSpeed, err := TestUploadSpeed("", 5)
+	uploadSpeed, err := TestUploadSpeedContext(ctx, options.UploadURL, options.UploadSizeMB, options.SampleInterval, options.OnSample)
 	if err != nil {
-		result.Error = fmt.Sprintf("测试上传速度失败: %v", err)
-		return result
+		return failed(i18n.T("测试上传速度"), err)
 	}
 	result.UploadSpeed = uploadSpeed
 
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"syscall"
 	"time"
+	"toolbox/pkg/i18n"
 
 	"golang.org/x/net/icmp"
 	"golang.org/x/net/ipv4"
@@ -14,17 +15,18 @@ import (
 
 // TracerouteResult 表示路由跟踪的结果
 type TracerouteResult struct {
-	Hops     []HopInfo // 路由跳数
-	Error    string
-	TargetIP string // 目标IP地址
+	Hops     []HopInfo  `json:"hops"` // 路由跳数
+	Error    string     `json:"error,omitempty"`
+	TargetIP string     `json:"target_ip"`       // 目标IP地址
+	Flows    []FlowPath `json:"flows,omitempty"` // Paris模式下每个流标识探测到的路径
 }
 
 // HopInfo 表示路由中的一跳
 type HopInfo struct {
-	Number int      // 跳数
-	IP     string   // IP地址
-	Name   string   // 主机名
-	RTT    []string // 往返时间
+	Number int      `json:"number"` // 跳数
+	IP     string   `json:"ip"`     // IP地址
+	Name   string   `json:"name"`   // 主机名
+	RTT    []string `json:"rtt"`    // 往返时间
 }
 
 // RealTimeHopCallback 定义实时回调函数类型，用于在获取每一跳信息时立即返回结果
@@ -36,10 +38,18 @@ type TracerouteOptions struct {
 	Timeout          time.Duration       // 超时时间
 	PacketSize       int                 // 数据包大小
 	RealTimeCallback RealTimeHopCallback // 实时回调，每个hop有结果就立即调用
+	Paris            bool                // 是否使用Paris模式（保持流标识一致，避免负载均衡打乱路径）
+	FlowID           uint16              // Paris模式下使用的流标识，0表示自动生成
+	FlowCount        int                 // Paris模式下探测的流数量，大于1时用于枚举备选路径
 }
 
 // Traceroute 执行路由跟踪
 func Traceroute(host string, options TracerouteOptions) (TracerouteResult, error) {
+	// Paris模式在所有平台上使用同一实现
+	if options.Paris || options.FlowCount > 1 {
+		return parisTracerouteImpl(host, options)
+	}
+
 	// 根据平台选择不同的实现
 	if runtime.GOOS == "windows" {
 		return windowsTracerouteImpl(host, options)
@@ -57,7 +67,7 @@ func unixTracerouteImpl(host string, options TracerouteOptions) (TracerouteResul
 	// 解析目标主机
 	ipAddr, err := net.ResolveIPAddr("ip", host)
 	if err != nil {
-		result.Error = fmt.Sprintf("无法解析主机名: %v", err)
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
 		return result, err
 	}
 
@@ -78,7 +88,7 @@ func unixTracerouteImpl(host string, options TracerouteOptions) (TracerouteResul
 	// 创建原始套接字
 	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_RAW, IPPROTO_ICMP)
 	if err != nil {
-		result.Error = fmt.Sprintf("创建原始套接字失败: %v", err)
+		result.Error = i18n.Tf("创建原始套接字失败: %v", err)
 		return result, err
 	}
 	defer syscall.Close(fd)
@@ -86,7 +96,7 @@ func unixTracerouteImpl(host string, options TracerouteOptions) (TracerouteResul
 	// 设置TTL
 	err = syscall.SetsockoptInt(fd, syscall.IPPROTO_IP, syscall.IP_TTL, options.MaxHops)
 	if err != nil {
-		result.Error = fmt.Sprintf("设置TTL失败: %v", err)
+		result.Error = i18n.Tf("设置TTL失败: %v", err)
 		return result, err
 	}
 
@@ -111,7 +121,7 @@ func unixTracerouteImpl(host string, options TracerouteOptions) (TracerouteResul
 		// 设置TTL
 		err = syscall.SetsockoptInt(fd, syscall.IPPROTO_IP, syscall.IP_TTL, ttl)
 		if err != nil {
-			result.Error = fmt.Sprintf("设置TTL失败: %v", err)
+			result.Error = i18n.Tf("设置TTL失败: %v", err)
 			return result, err
 		}
 
@@ -125,7 +135,7 @@ func unixTracerouteImpl(host string, options TracerouteOptions) (TracerouteResul
 		}
 		err = syscall.Sendto(fd, msg, 0, addr)
 		if err != nil {
-			result.Error = fmt.Sprintf("发送ICMP包失败: %v", err)
+			result.Error = i18n.Tf("发送ICMP包失败: %v", err)
 			return result, err
 		}
 
@@ -133,7 +143,7 @@ func unixTracerouteImpl(host string, options TracerouteOptions) (TracerouteResul
 		tv := syscall.NsecToTimeval(options.Timeout.Nanoseconds())
 		err = syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, SO_RCVTIMEO, &tv)
 		if err != nil {
-			result.Error = fmt.Sprintf("设置超时失败: %v", err)
+			result.Error = i18n.Tf("设置超时失败: %v", err)
 			return result, err
 		}
 
@@ -205,7 +215,7 @@ func windowsTracerouteImpl(host string, options TracerouteOptions) (TracerouteRe
 	// 解析目标主机
 	ipAddr, err := net.ResolveIPAddr("ip4", host)
 	if err != nil {
-		result.Error = fmt.Sprintf("无法解析主机名: %v", err)
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
 		return result, err
 	}
 
@@ -226,7 +236,7 @@ func windowsTracerouteImpl(host string, options TracerouteOptions) (TracerouteRe
 	// 使用统一方法创建连接
 	conn, err := net.DialIP("ip4:icmp", nil, ipAddr)
 	if err != nil {
-		result.Error = fmt.Sprintf("创建连接失败: %v", err)
+		result.Error = i18n.Tf("创建连接失败: %v", err)
 		return result, err
 	}
 	defer conn.Close()
@@ -248,7 +258,7 @@ func windowsTracerouteImpl(host string, options TracerouteOptions) (TracerouteRe
 	// 将消息序列化为字节
 	wb, err := wm.Marshal(nil)
 	if err != nil {
-		result.Error = fmt.Sprintf("序列化ICMP消息失败: %v", err)
+		result.Error = i18n.Tf("序列化ICMP消息失败: %v", err)
 		return result, err
 	}
 
@@ -256,7 +266,7 @@ func windowsTracerouteImpl(host string, options TracerouteOptions) (TracerouteRe
 	for ttl := 1; ttl <= options.MaxHops; ttl++ {
 		// 设置当前TTL
 		if err := ipConn.SetTTL(ttl); err != nil {
-			result.Error = fmt.Sprintf("设置TTL失败: %v", err)
+			result.Error = i18n.Tf("设置TTL失败: %v", err)
 			return result, err
 		}
 
@@ -268,7 +278,7 @@ func windowsTracerouteImpl(host string, options TracerouteOptions) (TracerouteRe
 
 		// 发送ICMP包
 		if _, err := conn.Write(wb); err != nil {
-			result.Error = fmt.Sprintf("发送ICMP包失败: %v", err)
+			result.Error = i18n.Tf("发送ICMP包失败: %v", err)
 			return result, err
 		}
 
@@ -0,0 +1,231 @@
+package netdiag
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"time"
+	"toolbox/pkg/i18n"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// FlowPath 表示某个流标识下探测到的完整路径
+type FlowPath struct {
+	FlowID uint16    `json:"flow_id"` // 流标识（ICMP校验和）
+	Hops   []HopInfo `json:"hops"`    // 该流经过的路由跳
+}
+
+// parisTracerouteImpl 使用Paris-traceroute方式进行路由跟踪
+//
+// 负载均衡器通常根据五元组（对于ICMP为类型、代码和校验和）选择下一跳，
+// 普通traceroute每个探测包的校验和都不同，导致不同TTL的探测包可能走不同路径，
+// 得到的结果是多条路径拼凑出来的。Paris模式在改变序列号的同时调整载荷中的补偿字，
+// 使所有探测包的校验和保持一致，从而保证同一次跟踪中的探测包走同一条路径。
+func parisTracerouteImpl(host string, options TracerouteOptions) (TracerouteResult, error) {
+	result := TracerouteResult{
+		Hops: make([]HopInfo, 0),
+	}
+
+	// 解析目标主机
+	ipAddr, err := net.ResolveIPAddr("ip4", host)
+	if err != nil {
+		result.Error = i18n.Tf("无法解析主机名: %v", err)
+		return result, err
+	}
+	result.TargetIP = ipAddr.String()
+
+	// 设置默认选项
+	if options.MaxHops <= 0 {
+		options.MaxHops = 30
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 3 * time.Second
+	}
+	if options.PacketSize < 10 {
+		options.PacketSize = 60
+	}
+	// 校验和按16位计算，载荷长度需要为偶数
+	if options.PacketSize%2 != 0 {
+		options.PacketSize++
+	}
+	if options.FlowCount <= 0 {
+		options.FlowCount = 1
+	}
+
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		result.Error = i18n.Tf("创建ICMP套接字失败: %v", err)
+		return result, err
+	}
+	defer conn.Close()
+
+	baseFlow := options.FlowID
+	if baseFlow == 0 {
+		baseFlow = uint16(os.Getpid()) | 0x8000
+	}
+	id := os.Getpid() & 0xffff
+
+	// 依次使用不同的流标识进行探测，第一条流的结果作为主结果
+	for i := 0; i < options.FlowCount; i++ {
+		flowID := baseFlow + uint16(i)
+		callback := options.RealTimeCallback
+		if i > 0 {
+			callback = nil
+		}
+
+		hops, err := parisTraceFlow(conn, ipAddr, id, flowID, options, callback)
+		if err != nil {
+			result.Error = err.Error()
+			return result, err
+		}
+
+		if i == 0 {
+			result.Hops = hops
+		}
+		result.Flows = append(result.Flows, FlowPath{FlowID: flowID, Hops: hops})
+	}
+
+	return result, nil
+}
+
+// parisTraceFlow 使用固定的流标识完成一次路由跟踪
+func parisTraceFlow(conn *icmp.PacketConn, target *net.IPAddr, id int, flowID uint16, options TracerouteOptions, callback RealTimeHopCallback) ([]HopInfo, error) {
+	hops := make([]HopInfo, 0)
+	pconn := conn.IPv4PacketConn()
+
+	for ttl := 1; ttl <= options.MaxHops; ttl++ {
+		if err := pconn.SetTTL(ttl); err != nil {
+			return hops, i18n.Errorf("设置TTL失败: %v", err)
+		}
+
+		seq := ttl
+		packet := buildParisProbe(id, seq, flowID, options.PacketSize)
+
+		start := time.Now()
+		if _, err := conn.WriteTo(packet, target); err != nil {
+			return hops, i18n.Errorf("发送ICMP包失败: %v", err)
+		}
+
+		hop := HopInfo{
+			Number: ttl,
+			IP:     "*",
+			Name:   "*",
+			RTT:    []string{"*"},
+		}
+
+		// 读取响应，忽略不属于本次探测的ICMP报文
+		deadline := start.Add(options.Timeout)
+		reply := make([]byte, 1500)
+		for {
+			conn.SetReadDeadline(deadline)
+			n, peer, err := conn.ReadFrom(reply)
+			if err != nil {
+				break
+			}
+
+			if !matchParisReply(reply[:n], id, seq) {
+				continue
+			}
+
+			latency := time.Since(start)
+			hop.IP = peer.String()
+			if ipa, ok := peer.(*net.IPAddr); ok {
+				hop.IP = ipa.IP.String()
+			}
+			hop.RTT = []string{fmt.Sprintf("%.2f ms", float64(latency.Microseconds())/1000.0)}
+			if names, err := net.LookupAddr(hop.IP); err == nil && len(names) > 0 {
+				hop.Name = names[0]
+			}
+			break
+		}
+
+		if callback != nil {
+			callback(hop)
+		}
+		hops = append(hops, hop)
+
+		// 如果到达目标，结束
+		if hop.IP == target.String() {
+			break
+		}
+	}
+
+	return hops, nil
+}
+
+// buildParisProbe 构造校验和固定为flowID的ICMP Echo请求
+func buildParisProbe(id, seq int, flowID uint16, size int) []byte {
+	packet := make([]byte, size)
+	packet[0] = byte(ipv4.ICMPTypeEcho)
+	packet[1] = 0
+	binary.BigEndian.PutUint16(packet[4:], uint16(id))
+	binary.BigEndian.PutUint16(packet[6:], uint16(seq))
+
+	// 先计算补偿字为0时的校验和，再求出让校验和等于flowID所需的补偿字
+	current := checkSum(packet)
+	compensation := onesComplementAdd(^flowID, current)
+	if len(packet) >= 10 {
+		binary.BigEndian.PutUint16(packet[8:], compensation)
+	}
+
+	binary.BigEndian.PutUint16(packet[2:], checkSum(packet))
+	return packet
+}
+
+// onesComplementAdd 16位反码加法
+func onesComplementAdd(a, b uint16) uint16 {
+	sum := uint32(a) + uint32(b)
+	return uint16((sum & 0xffff) + (sum >> 16))
+}
+
+// matchParisReply 判断ICMP响应是否对应指定的探测包
+func matchParisReply(data []byte, id, seq int) bool {
+	msg, err := icmp.ParseMessage(1, data)
+	if err != nil {
+		return false
+	}
+
+	switch body := msg.Body.(type) {
+	case *icmp.Echo:
+		return msg.Type == ipv4.ICMPTypeEchoReply && body.ID == id && body.Seq == seq
+	case *icmp.TimeExceeded:
+		return matchQuotedProbe(body.Data, id, seq)
+	case *icmp.DstUnreach:
+		return matchQuotedProbe(body.Data, id, seq)
+	}
+	return false
+}
+
+// matchQuotedProbe 检查ICMP差错报文中引用的原始探测包
+func matchQuotedProbe(quoted []byte, id, seq int) bool {
+	if len(quoted) < 20 {
+		return false
+	}
+	ihl := int(quoted[0]&0x0f) * 4
+	if len(quoted) < ihl+8 {
+		return false
+	}
+	inner := quoted[ihl:]
+	return int(binary.BigEndian.Uint16(inner[4:])) == id && int(binary.BigEndian.Uint16(inner[6:])) == seq
+}
+
+// HopAlternatives 汇总多流探测中每一跳出现过的不同IP地址
+func (r TracerouteResult) HopAlternatives() map[int][]string {
+	alternatives := make(map[int][]string)
+	for _, flow := range r.Flows {
+		for _, hop := range flow.Hops {
+			if hop.IP == "*" || contains(alternatives[hop.Number], hop.IP) {
+				continue
+			}
+			alternatives[hop.Number] = append(alternatives[hop.Number], hop.IP)
+		}
+	}
+	for number := range alternatives {
+		sort.Strings(alternatives[number])
+	}
+	return alternatives
+}
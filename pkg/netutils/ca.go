@@ -0,0 +1,429 @@
+package netutils
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// CA目录中的文件名
+const (
+	caRootCertFile         = "root.crt"
+	caRootKeyFile          = "root.key"
+	caIntermediateCertFile = "intermediate.crt"
+	caIntermediateKeyFile  = "intermediate.key"
+	caIndexFile            = "index.json"
+	caCRLFile              = "crl.pem"
+	caCertsDir             = "certs"
+)
+
+// 证书状态
+const (
+	CertStatusValid   = "valid"   // 有效
+	CertStatusRevoked = "revoked" // 已吊销
+)
+
+// CAInitOptions 初始化CA的选项
+type CAInitOptions struct {
+	CommonName       string   // 根CA的通用名称
+	Organization     []string // 组织名称
+	Country          []string // 国家代码
+	KeyType          string   // 密钥类型
+	ValidDays        int      // 根CA有效期（天）
+	Intermediate     bool     // 是否同时创建中间CA
+	IntermediateDays int      // 中间CA有效期（天）
+}
+
+// CAIndexEntry CA签发记录
+type CAIndexEntry struct {
+	Serial       string    `json:"serial"`                  // 证书序列号（十六进制）
+	CommonName   string    `json:"common_name"`             // 通用名称
+	Subject      string    `json:"subject"`                 // 证书主体
+	Profile      string    `json:"profile"`                 // 证书用途
+	DNSNames     []string  `json:"dns_names,omitempty"`     // DNS名称
+	NotBefore    time.Time `json:"not_before"`              // 生效时间
+	NotAfter     time.Time `json:"not_after"`               // 过期时间
+	Status       string    `json:"status"`                  // 证书状态
+	RevokedAt    time.Time `json:"revoked_at"`              // 吊销时间
+	RevokeReason int       `json:"revoke_reason,omitempty"` // 吊销原因（RFC 5280 CRLReason）
+	CertFile     string    `json:"cert_file"`               // CA目录中保存的证书副本
+}
+
+// caIndex CA索引文件内容
+type caIndex struct {
+	CRLNumber int64          `json:"crl_number"` // 最近一次生成的CRL编号
+	Entries   []CAIndexEntry `json:"entries"`    // 签发记录
+}
+
+// CA 本地证书颁发机构
+type CA struct {
+	Dir string // CA目录
+
+	index      caIndex
+	signerCert *x509.Certificate // 用于签发证书的CA（存在中间CA时为中间CA）
+	signerKey  crypto.Signer
+}
+
+// InitCA 在指定目录中初始化CA，创建根CA以及可选的中间CA
+func InitCA(dir string, options CAInitOptions) (*CA, error) {
+	if _, err := os.Stat(filepath.Join(dir, caRootCertFile)); err == nil {
+		return nil, i18n.Errorf("目录 %s 中已存在CA", dir)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, caCertsDir), 0700); err != nil {
+		return nil, errs.Wrap(err, "创建CA目录失败: %v", err)
+	}
+
+	if options.CommonName == "" {
+		options.CommonName = "Toolbox Root CA"
+	}
+	if options.ValidDays <= 0 {
+		options.ValidDays = 3650
+	}
+	if options.IntermediateDays <= 0 {
+		options.IntermediateDays = 1825
+	}
+
+	// 创建自签名根CA
+	rootKey, err := generatePrivateKey(options.KeyType, 0)
+	if err != nil {
+		return nil, i18n.Errorf("生成根CA私钥失败: %v", err)
+	}
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, i18n.Errorf("生成序列号失败: %v", err)
+	}
+	now := time.Now()
+	rootTemplate := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   options.CommonName,
+			Organization: options.Organization,
+			Country:      options.Country,
+		},
+		NotBefore:             now,
+		NotAfter:              now.Add(time.Duration(options.ValidDays) * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	if err != nil {
+		return nil, i18n.Errorf("生成根CA证书失败: %v", err)
+	}
+	rootCert, err := x509.ParseCertificate(rootDER)
+	if err != nil {
+		return nil, i18n.Errorf("解析根CA证书失败: %v", err)
+	}
+	if err := writeCertificate(filepath.Join(dir, caRootCertFile), rootDER); err != nil {
+		return nil, err
+	}
+	if err := writePrivateKey(filepath.Join(dir, caRootKeyFile), rootKey); err != nil {
+		return nil, err
+	}
+
+	ca := &CA{Dir: dir, signerCert: rootCert, signerKey: rootKey}
+
+	// 由根CA签发中间CA，之后的证书都由中间CA签发
+	if options.Intermediate {
+		interKey, err := generatePrivateKey(options.KeyType, 0)
+		if err != nil {
+			return nil, i18n.Errorf("生成中间CA私钥失败: %v", err)
+		}
+		csrDER, err := createCertificateRequest(CertConfig{
+			CommonName:   strings.TrimSuffix(options.CommonName, " Root CA") + " Intermediate CA",
+			Organization: options.Organization,
+			Country:      options.Country,
+		}, interKey)
+		if err != nil {
+			return nil, err
+		}
+		csr, err := x509.ParseCertificateRequest(csrDER)
+		if err != nil {
+			return nil, i18n.Errorf("解析证书签名请求失败: %v", err)
+		}
+		interDER, err := signCertificateRequest(csr, rootCert, rootKey, SignProfile{
+			Usage:     ProfileCA,
+			ValidDays: options.IntermediateDays,
+			PathLen:   0,
+		})
+		if err != nil {
+			return nil, err
+		}
+		interCert, err := x509.ParseCertificate(interDER)
+		if err != nil {
+			return nil, i18n.Errorf("解析中间CA证书失败: %v", err)
+		}
+		if err := writeCertificate(filepath.Join(dir, caIntermediateCertFile), interDER); err != nil {
+			return nil, err
+		}
+		if err := writePrivateKey(filepath.Join(dir, caIntermediateKeyFile), interKey); err != nil {
+			return nil, err
+		}
+		ca.signerCert = interCert
+		ca.signerKey = interKey
+	}
+
+	if err := ca.saveIndex(); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// OpenCA 打开已初始化的CA目录
+func OpenCA(dir string) (*CA, error) {
+	certFile := filepath.Join(dir, caIntermediateCertFile)
+	keyFile := filepath.Join(dir, caIntermediateKeyFile)
+	if _, err := os.Stat(certFile); err != nil {
+		certFile = filepath.Join(dir, caRootCertFile)
+		keyFile = filepath.Join(dir, caRootKeyFile)
+	}
+	if _, err := os.Stat(certFile); err != nil {
+		return nil, i18n.Errorf("目录 %s 中没有CA，请先执行初始化", dir)
+	}
+
+	signerCert, signerKey, err := loadSigner(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &CA{Dir: dir, signerCert: signerCert, signerKey: signerKey}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, caIndexFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errs.Wrap(err, "读取CA索引失败: %v", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &ca.index); err != nil {
+			return nil, i18n.Errorf("解析CA索引失败: %v", err)
+		}
+	}
+	return ca, nil
+}
+
+// SignerCertificate 返回用于签发证书的CA证书
+func (ca *CA) SignerCertificate() *x509.Certificate {
+	return ca.signerCert
+}
+
+// ChainFile 返回CA证书链文件路径（存在中间CA时为中间CA证书）
+func (ca *CA) ChainFile() string {
+	if _, err := os.Stat(filepath.Join(ca.Dir, caIntermediateCertFile)); err == nil {
+		return filepath.Join(ca.Dir, caIntermediateCertFile)
+	}
+	return filepath.Join(ca.Dir, caRootCertFile)
+}
+
+// Issue 生成私钥并签发证书，证书和私钥写入指定文件，同时在CA目录保存证书副本并记录索引
+func (ca *CA) Issue(config CertConfig, profile SignProfile, certFile, keyFile string) (*CAIndexEntry, error) {
+	priv, err := generatePrivateKey(config.KeyType, config.KeySize)
+	if err != nil {
+		return nil, i18n.Errorf("生成私钥失败: %v", err)
+	}
+
+	csrDER, err := createCertificateRequest(config, priv)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, i18n.Errorf("解析证书签名请求失败: %v", err)
+	}
+
+	entry, der, err := ca.sign(csr, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeCertificate(certFile, der); err != nil {
+		return nil, err
+	}
+	if err := writePrivateKey(keyFile, priv); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// SignCSR 签发外部提交的证书签名请求并记录索引，返回PEM格式的证书
+func (ca *CA) SignCSR(csrData []byte, profile SignProfile) ([]byte, *CAIndexEntry, error) {
+	csr, err := ParseCSR(csrData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, der, err := ca.sign(csr, profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), entry, nil
+}
+
+// sign 签发证书，保存副本并更新索引
+func (ca *CA) sign(csr *x509.CertificateRequest, profile SignProfile) (*CAIndexEntry, []byte, error) {
+	if profile.Usage == "" {
+		profile.Usage = ProfileServer
+	}
+
+	der, err := signCertificateRequest(csr, ca.signerCert, ca.signerKey, profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, i18n.Errorf("解析签发的证书失败: %v", err)
+	}
+
+	serial := fmt.Sprintf("%X", cert.SerialNumber)
+	copyFile := filepath.Join(caCertsDir, serial+".crt")
+	if err := writeCertificate(filepath.Join(ca.Dir, copyFile), der); err != nil {
+		return nil, nil, err
+	}
+
+	entry := CAIndexEntry{
+		Serial:     serial,
+		CommonName: cert.Subject.CommonName,
+		Subject:    formatName(cert.Subject.String()),
+		Profile:    profile.Usage,
+		DNSNames:   cert.DNSNames,
+		NotBefore:  cert.NotBefore,
+		NotAfter:   cert.NotAfter,
+		Status:     CertStatusValid,
+		CertFile:   copyFile,
+	}
+	ca.index.Entries = append(ca.index.Entries, entry)
+	if err := ca.saveIndex(); err != nil {
+		return nil, nil, err
+	}
+	return &entry, der, nil
+}
+
+// List 返回按签发时间排序的签发记录
+func (ca *CA) List() []CAIndexEntry {
+	entries := make([]CAIndexEntry, len(ca.index.Entries))
+	copy(entries, ca.index.Entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].NotBefore.Before(entries[j].NotBefore)
+	})
+	return entries
+}
+
+// Revoke 吊销指定序列号的证书，序列号完全相同时直接匹配，否则要求是唯一的前缀
+func (ca *CA) Revoke(serial string, reason int) (*CAIndexEntry, error) {
+	serial = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(serial), ":", ""))
+	if serial == "" {
+		return nil, errs.InvalidInput("序列号不能为空")
+	}
+
+	match, matches := -1, 0
+	for i, entry := range ca.index.Entries {
+		if entry.Serial == serial {
+			match, matches = i, 1
+			break
+		}
+		if strings.HasPrefix(entry.Serial, serial) {
+			match = i
+			matches++
+		}
+	}
+	if matches > 1 {
+		return nil, i18n.Errorf("序列号前缀 %s 匹配到多个证书", serial)
+	}
+	if match < 0 {
+		return nil, i18n.Errorf("未找到序列号为 %s 的证书", serial)
+	}
+
+	entry := &ca.index.Entries[match]
+	if entry.Status == CertStatusRevoked {
+		return nil, i18n.Errorf("证书 %s 已被吊销", entry.Serial)
+	}
+	entry.Status = CertStatusRevoked
+	entry.RevokedAt = time.Now()
+	entry.RevokeReason = reason
+
+	if err := ca.saveIndex(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GenerateCRL 根据索引中的吊销记录生成CRL，写入CA目录并返回PEM内容
+func (ca *CA) GenerateCRL(validDays int) ([]byte, error) {
+	if validDays <= 0 {
+		validDays = 30
+	}
+
+	var revoked []x509.RevocationListEntry
+	for _, entry := range ca.index.Entries {
+		if entry.Status != CertStatusRevoked {
+			continue
+		}
+		serialNumber, ok := new(big.Int).SetString(entry.Serial, 16)
+		if !ok {
+			return nil, errs.InvalidInput("无效的序列号: %s", entry.Serial)
+		}
+		revoked = append(revoked, x509.RevocationListEntry{
+			SerialNumber:   serialNumber,
+			RevocationTime: entry.RevokedAt,
+			ReasonCode:     entry.RevokeReason,
+		})
+	}
+
+	ca.index.CRLNumber++
+	now := time.Now()
+	template := &x509.RevocationList{
+		Number:                    big.NewInt(ca.index.CRLNumber),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(time.Duration(validDays) * 24 * time.Hour),
+		RevokedCertificateEntries: revoked,
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.signerCert, ca.signerKey)
+	if err != nil {
+		return nil, i18n.Errorf("生成CRL失败: %v", err)
+	}
+
+	crlPEM := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+	if err := ioutil.WriteFile(filepath.Join(ca.Dir, caCRLFile), crlPEM, 0644); err != nil {
+		return nil, errs.Wrap(err, "写入CRL文件失败: %v", err)
+	}
+	if err := ca.saveIndex(); err != nil {
+		return nil, err
+	}
+	return crlPEM, nil
+}
+
+// saveIndex 保存CA索引
+func (ca *CA) saveIndex() error {
+	if ca.index.Entries == nil {
+		ca.index.Entries = []CAIndexEntry{}
+	}
+	data, err := json.MarshalIndent(ca.index, "", "  ")
+	if err != nil {
+		return i18n.Errorf("编码CA索引失败: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(ca.Dir, caIndexFile), data, 0600); err != nil {
+		return errs.Wrap(err, "写入CA索引失败: %v", err)
+	}
+	return nil
+}
+
+// writeCertificate 将DER格式的证书以PEM格式写入文件
+func writeCertificate(certFile string, der []byte) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := ioutil.WriteFile(certFile, data, 0644); err != nil {
+		return errs.Wrap(err, "写入证书文件失败: %v", err)
+	}
+	return nil
+}
@@ -2,8 +2,12 @@ package netutils
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
@@ -14,27 +18,50 @@ import (
 	"os"
 	"strings"
 	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // CertInfo 存储证书的详细信息
 type CertInfo struct {
-	Subject          string    // 证书主体
-	Issuer           string    // 颁发者
-	NotBefore        time.Time // 生效时间
-	NotAfter         time.Time // 过期时间
-	DNSNames         []string  // DNS名称列表
-	SerialNumber     string    // 序列号
-	SignatureAlg     string    // 签名算法
-	PublicKeyAlg     string    // 公钥算法
-	Version          int       // 证书版本
-	IsCA             bool      // 是否为CA证书
-	RemainingDays    int       // 剩余有效天数
-	HasTrustedIssuer bool      // 是否由受信任的CA颁发
+	Subject          string    `json:"subject"`            // 证书主体
+	Issuer           string    `json:"issuer"`             // 颁发者
+	NotBefore        time.Time `json:"not_before"`         // 生效时间
+	NotAfter         time.Time `json:"not_after"`          // 过期时间
+	DNSNames         []string  `json:"dns_names"`          // DNS名称列表
+	SerialNumber     string    `json:"serial_number"`      // 序列号
+	SignatureAlg     string    `json:"signature_alg"`      // 签名算法
+	PublicKeyAlg     string    `json:"public_key_alg"`     // 公钥算法
+	Version          int       `json:"version"`            // 证书版本
+	IsCA             bool      `json:"is_ca"`              // 是否为CA证书
+	RemainingDays    int       `json:"remaining_days"`     // 剩余有效天数
+	HasTrustedIssuer bool      `json:"has_trusted_issuer"` // 是否由受信任的CA颁发
 }
 
 // CertChecker 证书检查器
 type CertChecker struct {
-	FilePath string // 证书文件路径
+	FilePath   string        // 证书文件路径
+	Address    string        // 远程主机地址（host:port），不为空时从远程获取证书链
+	ServerName string        // TLS握手使用的SNI，为空时使用主机名
+	ALPN       []string      // TLS握手时声明的ALPN协议
+	Timeout    time.Duration // 连接超时时间
+
+	RootFiles         []string // 额外信任的根证书文件（PEM）
+	IntermediateFiles []string // 额外的中间证书文件（PEM）
+	StrictRoots       bool     // 只信任RootFiles中的根证书，不使用系统根证书
+
+	Connection *TLSConnectionInfo  // 远程检查时的TLS连接信息
+	chain      []*x509.Certificate // 已获取的远程证书链
+}
+
+// TLSConnectionInfo 远程TLS连接信息
+type TLSConnectionInfo struct {
+	Address       string `json:"address"`                  // 连接地址
+	ServerName    string `json:"server_name"`              // 使用的SNI
+	TLSVersion    string `json:"tls_version"`              // 协商的TLS版本
+	CipherSuite   string `json:"cipher_suite"`             // 协商的密码套件
+	ALPN          string `json:"alpn,omitempty"`           // 协商的ALPN协议
+	HostnameError string `json:"hostname_error,omitempty"` // 主机名校验失败的原因，为空表示匹配
 }
 
 // NewCertChecker 创建新的证书检查器
@@ -44,38 +71,39 @@ func NewCertChecker(filePath string) *CertChecker {
 	}
 }
 
-// CheckCertificate 检查证书文件
+// NewRemoteCertChecker 创建检查远程主机证书的检查器
+func NewRemoteCertChecker(address string) *CertChecker {
+	// 未指定端口时默认使用443
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(strings.Trim(address, "[]"), "443")
+	}
+	return &CertChecker{
+		Address: address,
+		Timeout: 10 * time.Second,
+	}
+}
+
+// CheckCertificate 检查证书文件或远程主机提供的证书链
 func (c *CertChecker) CheckCertificate() ([]*CertInfo, error) {
-	// 读取证书文件
-	certData, err := ioutil.ReadFile(c.FilePath)
+	var chain []*x509.Certificate
+	var err error
+	if c.Address != "" {
+		chain, err = c.fetchRemoteChain()
+	} else {
+		chain, err = c.readCertFile()
+	}
 	if err != nil {
-		return nil, fmt.Errorf("无法读取证书文件: %v", err)
+		return nil, err
 	}
 
-	var certs []*CertInfo
-	var block *pem.Block
-	var rest []byte = certData
-
-	// 解析证书链中的所有证书
-	for {
-		block, rest = pem.Decode(rest)
-		if block == nil {
-			break
-		}
-
-		if block.Type != "CERTIFICATE" {
-			continue
-		}
-
-		cert, err := x509.ParseCertificate(block.Bytes)
-		if err != nil {
-			return nil, fmt.Errorf("解析证书失败: %v", err)
-		}
+	opts, err := c.verifyOptions(chain)
+	if err != nil {
+		return nil, err
+	}
 
+	var certs []*CertInfo
+	for _, cert := range chain {
 		// 验证证书链
-		opts := x509.VerifyOptions{
-			Roots: nil, // 使用系统根证书
-		}
 		_, err = cert.Verify(opts)
 		hasTrustedIssuer := err == nil
 
@@ -99,17 +127,156 @@ func (c *CertChecker) CheckCertificate() ([]*CertInfo, error) {
 		}
 
 		certs = append(certs, certInfo)
+	}
+
+	return certs, nil
+}
+
+// verifyOptions 构造证书验证选项，包含自定义的根证书和中间证书
+func (c *CertChecker) verifyOptions(chain []*x509.Certificate) (x509.VerifyOptions, error) {
+	opts := x509.VerifyOptions{
+		Roots:         nil, // 使用系统根证书
+		Intermediates: x509.NewCertPool(),
+	}
+
+	// 证书链中的其余证书作为中间证书参与验证
+	for _, cert := range chain[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	for _, file := range c.IntermediateFiles {
+		certs, err := loadCertificates(file)
+		if err != nil {
+			return opts, errs.Wrap(err, "读取中间证书 %s 失败: %v", file, err)
+		}
+		for _, cert := range certs {
+			opts.Intermediates.AddCert(cert)
+		}
+	}
+
+	if c.StrictRoots && len(c.RootFiles) == 0 {
+		return opts, i18n.Errorf("严格模式下必须指定根证书文件")
+	}
+	if len(c.RootFiles) == 0 {
+		return opts, nil
+	}
+
+	// 在系统根证书的基础上追加，严格模式下只使用指定的根证书
+	roots := x509.NewCertPool()
+	if !c.StrictRoots {
+		if systemRoots, err := x509.SystemCertPool(); err == nil {
+			roots = systemRoots
+		}
+	}
+	for _, file := range c.RootFiles {
+		certs, err := loadCertificates(file)
+		if err != nil {
+			return opts, errs.Wrap(err, "读取根证书 %s 失败: %v", file, err)
+		}
+		for _, cert := range certs {
+			roots.AddCert(cert)
+		}
+	}
+	opts.Roots = roots
+	return opts, nil
+}
+
+// readCertFile 读取并解析证书文件中的所有证书
+func (c *CertChecker) readCertFile() ([]*x509.Certificate, error) {
+	return loadCertificates(c.FilePath)
+}
+
+// loadCertificates 读取并解析PEM文件中的所有证书
+func loadCertificates(path string) ([]*x509.Certificate, error) {
+	// 读取证书文件
+	certData, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("无法读取证书文件: %v", err)
+	}
+
+	var chain []*x509.Certificate
+	var block *pem.Block
+	var rest []byte = certData
+
+	// 解析证书链中的所有证书
+	for {
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, i18n.Errorf("解析证书失败: %v", err)
+		}
+		chain = append(chain, cert)
 
 		if len(rest) == 0 {
 			break
 		}
 	}
 
-	if len(certs) == 0 {
-		return nil, fmt.Errorf("未在文件中找到有效的证书")
+	if len(chain) == 0 {
+		return nil, i18n.Errorf("未在文件中找到有效的证书")
 	}
 
-	return certs, nil
+	return chain, nil
+}
+
+// fetchRemoteChain 通过TLS握手获取远程主机提供的证书链
+func (c *CertChecker) fetchRemoteChain() ([]*x509.Certificate, error) {
+	if c.chain != nil {
+		return c.chain, nil
+	}
+
+	host, _, err := net.SplitHostPort(c.Address)
+	if err != nil {
+		return nil, errs.InvalidInput("无效的地址: %v", err)
+	}
+	serverName := c.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	// 跳过内置校验，以便获取不受信任或已过期的证书，校验在之后单独进行
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", c.Address, &tls.Config{
+		ServerName:         serverName,
+		NextProtos:         c.ALPN,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, i18n.Errorf("TLS连接失败: %v", err)
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return nil, i18n.Errorf("远程主机未提供证书")
+	}
+
+	info := &TLSConnectionInfo{
+		Address:     c.Address,
+		ServerName:  serverName,
+		TLSVersion:  tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		ALPN:        state.NegotiatedProtocol,
+	}
+	if err := state.PeerCertificates[0].VerifyHostname(serverName); err != nil {
+		info.HostnameError = err.Error()
+	}
+
+	c.Connection = info
+	c.chain = state.PeerCertificates
+	return c.chain, nil
 }
 
 // ValidateCertificate 验证证书的有效性
@@ -124,30 +291,35 @@ func (c *CertChecker) ValidateCertificate() ([]string, error) {
 	for i, cert := range certs {
 		certNum := ""
 		if len(certs) > 1 {
-			certNum = fmt.Sprintf("证书 #%d: ", i+1)
+			certNum = i18n.Tf("证书 #%d: ", i+1)
 		}
 
 		// 检查证书是否已经过期
 		if time.Now().After(cert.NotAfter) {
-			issues = append(issues, fmt.Sprintf("%s已过期，过期时间：%v", certNum, cert.NotAfter.Format("2006-01-02")))
+			issues = append(issues, i18n.Tf("%s已过期，过期时间：%v", certNum, cert.NotAfter.Format("2006-01-02")))
 		}
 
 		// 检查证书是否还未生效
 		if time.Now().Before(cert.NotBefore) {
-			issues = append(issues, fmt.Sprintf("%s尚未生效，生效时间：%v", certNum, cert.NotBefore.Format("2006-01-02")))
+			issues = append(issues, i18n.Tf("%s尚未生效，生效时间：%v", certNum, cert.NotBefore.Format("2006-01-02")))
 		}
 
 		// 检查证书剩余有效期
 		if cert.RemainingDays < 30 {
-			issues = append(issues, fmt.Sprintf("%s即将过期，剩余 %d 天", certNum, cert.RemainingDays))
+			issues = append(issues, i18n.Tf("%s即将过期，剩余 %d 天", certNum, cert.RemainingDays))
 		}
 
 		// 检查证书是否由受信任的CA颁发
 		if !cert.HasTrustedIssuer && !cert.IsCA {
-			issues = append(issues, fmt.Sprintf("%s不是由受信任的CA颁发", certNum))
+			issues = append(issues, i18n.Tf("%s不是由受信任的CA颁发", certNum))
 		}
 	}
 
+	// 检查远程证书是否与主机名匹配
+	if c.Connection != nil && c.Connection.HostnameError != "" {
+		issues = append(issues, i18n.Tf("证书与主机名 %s 不匹配: %s", c.Connection.ServerName, c.Connection.HostnameError))
+	}
+
 	return issues, nil
 }
 
@@ -170,6 +342,7 @@ type CertConfig struct {
 	IPAddresses  []string // IP地址
 	ValidDays    int      // 有效期（天）
 	IsCA         bool     // 是否为CA证书
+	KeyType      string   // 密钥类型（rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519），为空时使用RSA和KeySize
 	KeySize      int      // RSA密钥长度
 	SignerCert   string   // 签名者证书文件（可选）
 	SignerKey    string   // 签名者私钥文件（可选）
@@ -178,27 +351,21 @@ type CertConfig struct {
 // GenerateCertificate 生成证书和私钥
 func GenerateCertificate(config CertConfig, certFile, keyFile string) error {
 	// 生成私钥
-	priv, err := generatePrivateKey(config.KeySize)
+	priv, err := generatePrivateKey(config.KeyType, config.KeySize)
 	if err != nil {
-		return fmt.Errorf("生成私钥失败: %v", err)
+		return i18n.Errorf("生成私钥失败: %v", err)
 	}
 
 	// 解析IP地址
-	var ips []net.IP
-	for _, ip := range config.IPAddresses {
-		if parsedIP := net.ParseIP(ip); parsedIP != nil {
-			ips = append(ips, parsedIP)
-		}
-	}
+	ips := parseIPAddresses(config.IPAddresses)
 
 	// 准备证书模板
 	notBefore := time.Now()
 	notAfter := notBefore.Add(time.Duration(config.ValidDays) * 24 * time.Hour)
 
-	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	serialNumber, err := newSerialNumber()
 	if err != nil {
-		return fmt.Errorf("生成序列号失败: %v", err)
+		return i18n.Errorf("生成序列号失败: %v", err)
 	}
 
 	template := &x509.Certificate{
@@ -212,13 +379,18 @@ func GenerateCertificate(config CertConfig, certFile, keyFile string) error {
 		},
 		NotBefore:             notBefore,
 		NotAfter:              notAfter,
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		BasicConstraintsValid: true,
 		DNSNames:              config.DNSNames,
 		IPAddresses:           ips,
 	}
 
+	// 只有RSA密钥可以用于密钥交换加密
+	if _, ok := priv.(*rsa.PrivateKey); ok {
+		template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
 	if config.IsCA {
 		template.IsCA = true
 		template.KeyUsage |= x509.KeyUsageCertSign
@@ -227,38 +399,13 @@ func GenerateCertificate(config CertConfig, certFile, keyFile string) error {
 
 	// 确定签名者证书和私钥
 	var signerCert *x509.Certificate
-	var signerKey crypto.PrivateKey
+	var signerKey crypto.Signer
 
 	if config.SignerCert != "" && config.SignerKey != "" {
 		// 使用提供的CA证书签名
-		signerCertData, err := ioutil.ReadFile(config.SignerCert)
+		signerCert, signerKey, err = loadSigner(config.SignerCert, config.SignerKey)
 		if err != nil {
-			return fmt.Errorf("读取签名者证书失败: %v", err)
-		}
-
-		block, _ := pem.Decode(signerCertData)
-		if block == nil {
-			return fmt.Errorf("解析签名者证书失败")
-		}
-
-		signerCert, err = x509.ParseCertificate(block.Bytes)
-		if err != nil {
-			return fmt.Errorf("解析签名者证书失败: %v", err)
-		}
-
-		signerKeyData, err := ioutil.ReadFile(config.SignerKey)
-		if err != nil {
-			return fmt.Errorf("读取签名者私钥失败: %v", err)
-		}
-
-		block, _ = pem.Decode(signerKeyData)
-		if block == nil {
-			return fmt.Errorf("解析签名者私钥失败")
-		}
-
-		signerKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
-		if err != nil {
-			return fmt.Errorf("解析签名者私钥失败: %v", err)
+			return err
 		}
 	} else {
 		// 自签名
@@ -267,43 +414,154 @@ func GenerateCertificate(config CertConfig, certFile, keyFile string) error {
 	}
 
 	// 创建证书
-	derBytes, err := x509.CreateCertificate(rand.Reader, template, signerCert, &priv.PublicKey, signerKey)
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, signerCert, priv.Public(), signerKey)
 	if err != nil {
-		return fmt.Errorf("生成证书失败: %v", err)
+		return i18n.Errorf("生成证书失败: %v", err)
 	}
 
 	// 保存证书
 	certOut, err := os.Create(certFile)
 	if err != nil {
-		return fmt.Errorf("创建证书文件失败: %v", err)
+		return errs.Wrap(err, "创建证书文件失败: %v", err)
 	}
 	defer certOut.Close()
 
 	err = pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
 	if err != nil {
-		return fmt.Errorf("写入证书文件失败: %v", err)
+		return errs.Wrap(err, "写入证书文件失败: %v", err)
 	}
 
 	// 保存私钥
+	return writePrivateKey(keyFile, priv)
+}
+
+// writePrivateKey 将私钥以PEM格式写入文件
+func writePrivateKey(keyFile string, priv crypto.Signer) error {
 	keyOut, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
-		return fmt.Errorf("创建私钥文件失败: %v", err)
+		return errs.Wrap(err, "创建私钥文件失败: %v", err)
 	}
 	defer keyOut.Close()
 
-	privBytes := x509.MarshalPKCS1PrivateKey(priv)
-	err = pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: privBytes})
+	keyBlock, err := marshalPrivateKeyPEM(priv)
 	if err != nil {
-		return fmt.Errorf("写入私钥文件失败: %v", err)
+		return i18n.Errorf("编码私钥失败: %v", err)
+	}
+	err = pem.Encode(keyOut, keyBlock)
+	if err != nil {
+		return errs.Wrap(err, "写入私钥文件失败: %v", err)
 	}
 
 	return nil
 }
 
-// generatePrivateKey 生成RSA私钥
-func generatePrivateKey(bits int) (*rsa.PrivateKey, error) {
-	if bits == 0 {
-		bits = 2048 // 默认密钥长度
+// loadSigner 读取签名者（CA）的证书和私钥
+func loadSigner(certFile, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	signerCertData, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, nil, errs.Wrap(err, "读取签名者证书失败: %v", err)
+	}
+
+	block, _ := pem.Decode(signerCertData)
+	if block == nil {
+		return nil, nil, i18n.Errorf("解析签名者证书失败")
+	}
+
+	signerCert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, i18n.Errorf("解析签名者证书失败: %v", err)
+	}
+
+	signerKeyData, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, nil, errs.Wrap(err, "读取签名者私钥失败: %v", err)
+	}
+
+	signerKey, err := parsePrivateKeyPEM(signerKeyData)
+	if err != nil {
+		return nil, nil, i18n.Errorf("解析签名者私钥失败: %v", err)
+	}
+
+	return signerCert, signerKey, nil
+}
+
+// newSerialNumber 生成128位随机证书序列号
+func newSerialNumber() (*big.Int, error) {
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	return rand.Int(rand.Reader, serialNumberLimit)
+}
+
+// parseIPAddresses 解析IP地址列表，忽略无效的地址
+func parseIPAddresses(addresses []string) []net.IP {
+	var ips []net.IP
+	for _, ip := range addresses {
+		if parsedIP := net.ParseIP(ip); parsedIP != nil {
+			ips = append(ips, parsedIP)
+		}
+	}
+	return ips
+}
+
+// generatePrivateKey 根据密钥类型生成私钥
+func generatePrivateKey(keyType string, bits int) (crypto.Signer, error) {
+	switch strings.ToLower(keyType) {
+	case "", "rsa":
+		if bits == 0 {
+			bits = 2048 // 默认密钥长度
+		}
+		return rsa.GenerateKey(rand.Reader, bits)
+	case "rsa2048":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "rsa4096":
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case "ecdsa-p256", "ecdsa":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ecdsa-p384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "ed25519":
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	default:
+		return nil, errs.InvalidInput("不支持的密钥类型: %s", keyType)
+	}
+}
+
+// marshalPrivateKeyPEM 将私钥编码为PEM块，RSA密钥保持PKCS#1格式，其他密钥使用PKCS#8格式
+func marshalPrivateKeyPEM(key crypto.Signer) (*pem.Block, error) {
+	if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}, nil
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &pem.Block{Type: "PRIVATE KEY", Bytes: der}, nil
+}
+
+// parsePrivateKeyPEM 解析PEM格式的私钥，支持PKCS#1、SEC1（EC）和PKCS#8编码
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, i18n.Errorf("未找到PEM格式的私钥")
+		}
+
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, errs.InvalidInput("不支持的私钥类型: %T", key)
+			}
+			return signer, nil
+		}
 	}
-	return rsa.GenerateKey(rand.Reader, bits)
 }
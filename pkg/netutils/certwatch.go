@@ -0,0 +1,206 @@
+package netutils
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"toolbox/pkg/errs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 证书监控状态
+const (
+	WatchStatusOK      = "ok"      // 正常
+	WatchStatusWarning = "warning" // 低于告警阈值
+	WatchStatusExpired = "expired" // 已过期
+	WatchStatusError   = "error"   // 检查失败
+)
+
+// WatchTarget 证书监控目标，File和Address二选一
+type WatchTarget struct {
+	Name    string   `yaml:"name"`    // 显示名称，为空时使用文件路径或地址
+	File    string   `yaml:"file"`    // 本地证书文件
+	Address string   `yaml:"address"` // 远程主机地址（host:port）
+	SNI     string   `yaml:"sni"`     // 远程检查时使用的SNI
+	ALPN    []string `yaml:"alpn"`    // 远程检查时声明的ALPN协议
+
+	CABundle      []string `yaml:"ca_bundle"`     // 额外信任的根证书文件
+	Intermediates []string `yaml:"intermediates"` // 额外的中间证书文件
+	StrictCA      bool     `yaml:"strict_ca"`     // 只信任CABundle中的根证书
+}
+
+// UnmarshalYAML 支持直接使用字符串表示目标：存在的文件视为证书文件，否则视为远程地址
+func (t *WatchTarget) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		value := strings.TrimSpace(node.Value)
+		if _, err := os.Stat(value); err == nil {
+			t.File = value
+		} else {
+			t.Address = value
+		}
+		return nil
+	}
+
+	type plain WatchTarget
+	return node.Decode((*plain)(t))
+}
+
+// Label 返回目标的显示名称
+func (t WatchTarget) Label() string {
+	switch {
+	case t.Name != "":
+		return t.Name
+	case t.File != "":
+		return t.File
+	default:
+		return t.Address
+	}
+}
+
+// WatchConfig 证书监控配置文件
+type WatchConfig struct {
+	Threshold int           `yaml:"threshold"` // 告警阈值（天）
+	Targets   []WatchTarget `yaml:"targets"`   // 监控目标
+}
+
+// WatchOptions 证书监控选项
+type WatchOptions struct {
+	Threshold   int           // 剩余天数低于该值时告警
+	Concurrency int           // 并发检查数量
+	Timeout     time.Duration // 远程连接超时时间
+}
+
+// WatchResult 单个目标的检查结果
+type WatchResult struct {
+	Target        string    `json:"target"`            // 目标名称
+	Source        string    `json:"source"`            // 文件路径或远程地址
+	Remote        bool      `json:"remote"`            // 是否为远程目标
+	Subject       string    `json:"subject,omitempty"` // 最先过期证书的主体
+	Issuer        string    `json:"issuer,omitempty"`  // 最先过期证书的颁发者
+	NotAfter      time.Time `json:"not_after"`         // 最先过期证书的过期时间
+	RemainingDays int       `json:"remaining_days"`    // 剩余天数
+	Status        string    `json:"status"`            // 状态
+	Issues        []string  `json:"issues,omitempty"`  // 发现的问题
+	Error         string    `json:"error,omitempty"`   // 错误信息
+}
+
+// LoadWatchConfig 读取YAML格式的证书监控配置
+//
+// 配置文件可以是包含threshold和targets的对象，也可以直接是目标列表。
+func LoadWatchConfig(path string) (*WatchConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取目标文件失败: %v", err)
+	}
+
+	config := &WatchConfig{}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, errs.InvalidInput("解析目标文件失败: %v", err)
+	}
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+		err = node.Content[0].Decode(&config.Targets)
+	} else {
+		err = node.Decode(config)
+	}
+	if err != nil {
+		return nil, errs.InvalidInput("解析目标文件失败: %v", err)
+	}
+
+	for i, target := range config.Targets {
+		if target.File == "" && target.Address == "" {
+			return nil, errs.InvalidInput("第 %d 个目标未指定 file 或 address", i+1)
+		}
+	}
+	return config, nil
+}
+
+// WatchCertificates 并发检查多个目标的证书，结果按剩余天数升序排列，检查失败的目标排在最前
+func WatchCertificates(targets []WatchTarget, options WatchOptions) []WatchResult {
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+
+	results := make([]WatchResult, len(targets))
+	sem := make(chan struct{}, options.Concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target WatchTarget) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = checkWatchTarget(target, options)
+		}(i, target)
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		iErr := results[i].Status == WatchStatusError
+		jErr := results[j].Status == WatchStatusError
+		if iErr != jErr {
+			return iErr
+		}
+		return results[i].RemainingDays < results[j].RemainingDays
+	})
+	return results
+}
+
+// checkWatchTarget 检查单个目标
+func checkWatchTarget(target WatchTarget, options WatchOptions) WatchResult {
+	result := WatchResult{Target: target.Label()}
+
+	var checker *CertChecker
+	if target.File != "" {
+		result.Source = target.File
+		checker = NewCertChecker(target.File)
+	} else {
+		result.Remote = true
+		checker = NewRemoteCertChecker(target.Address)
+		checker.ServerName = target.SNI
+		checker.ALPN = target.ALPN
+		if options.Timeout > 0 {
+			checker.Timeout = options.Timeout
+		}
+		result.Source = checker.Address
+	}
+	checker.RootFiles = target.CABundle
+	checker.IntermediateFiles = target.Intermediates
+	checker.StrictRoots = target.StrictCA
+
+	certs, err := checker.CheckCertificate()
+	if err != nil {
+		result.Status = WatchStatusError
+		result.Error = err.Error()
+		return result
+	}
+
+	// 以证书链中最先过期的证书为准
+	earliest := certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(earliest.NotAfter) {
+			earliest = cert
+		}
+	}
+	result.Subject = earliest.Subject
+	result.Issuer = earliest.Issuer
+	result.NotAfter = earliest.NotAfter
+	result.RemainingDays = earliest.RemainingDays
+
+	result.Issues, _ = checker.ValidateCertificate()
+
+	switch {
+	case time.Now().After(earliest.NotAfter):
+		result.Status = WatchStatusExpired
+	case earliest.RemainingDays < options.Threshold:
+		result.Status = WatchStatusWarning
+	default:
+		result.Status = WatchStatusOK
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+package netutils
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// 证书文件格式
+const (
+	FormatPEM = "pem"
+	FormatDER = "der"
+)
+
+// oidSignedData PKCS#7 SignedData 的对象标识符
+var oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// CertObject 证书文件中的一个对象（证书或私钥）
+type CertObject struct {
+	PEMType string // PEM块类型，如 CERTIFICATE、PRIVATE KEY
+	DER     []byte // DER编码内容
+	Summary string // 对象描述
+}
+
+// pkcs7ContentInfo PKCS#7 ContentInfo 结构
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// pkcs7SignedData PKCS#7 SignedData 结构，只解析证书部分
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+// ParseCertObjects 解析PEM或DER格式的证书文件内容，PKCS#7证书链会被展开为单独的证书
+func ParseCertObjects(data []byte) ([]CertObject, string, error) {
+	if bytes.Contains(data, []byte("-----BEGIN ")) {
+		objects, err := parsePEMObjects(data)
+		return objects, FormatPEM, err
+	}
+	objects, err := parseDERObject(data)
+	return objects, FormatDER, err
+}
+
+// parsePEMObjects 解析PEM文件中的所有对象
+func parsePEMObjects(data []byte) ([]CertObject, error) {
+	var objects []CertObject
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "PKCS7", "CMS":
+			certs, err := parsePKCS7Certificates(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, certs...)
+		case "CERTIFICATE", "X509 CRL", "CERTIFICATE REQUEST", "NEW CERTIFICATE REQUEST",
+			"RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY", "ENCRYPTED PRIVATE KEY", "PUBLIC KEY":
+			objects = append(objects, newCertObject(block.Type, block.Bytes))
+		default:
+			return nil, errs.InvalidInput("不支持的PEM类型: %s", block.Type)
+		}
+	}
+
+	if len(objects) == 0 {
+		return nil, i18n.Errorf("未找到有效的PEM内容")
+	}
+	return objects, nil
+}
+
+// parseDERObject 依次尝试将DER数据解析为证书、PKCS#7、私钥等格式
+func parseDERObject(data []byte) ([]CertObject, error) {
+	if _, err := x509.ParseCertificate(data); err == nil {
+		return []CertObject{newCertObject("CERTIFICATE", data)}, nil
+	}
+	if certs, err := parsePKCS7Certificates(data); err == nil {
+		return certs, nil
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(data); err == nil {
+		return []CertObject{newCertObject("PRIVATE KEY", data)}, nil
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(data); err == nil {
+		return []CertObject{newCertObject("RSA PRIVATE KEY", data)}, nil
+	}
+	if _, err := x509.ParseECPrivateKey(data); err == nil {
+		return []CertObject{newCertObject("EC PRIVATE KEY", data)}, nil
+	}
+	if _, err := x509.ParsePKIXPublicKey(data); err == nil {
+		return []CertObject{newCertObject("PUBLIC KEY", data)}, nil
+	}
+	if _, err := x509.ParseCertificateRequest(data); err == nil {
+		return []CertObject{newCertObject("CERTIFICATE REQUEST", data)}, nil
+	}
+	if _, err := x509.ParseRevocationList(data); err == nil {
+		return []CertObject{newCertObject("X509 CRL", data)}, nil
+	}
+	return nil, i18n.Errorf("无法识别的DER内容")
+}
+
+// parsePKCS7Certificates 从PKCS#7 SignedData中提取证书
+func parsePKCS7Certificates(data []byte) ([]CertObject, error) {
+	var info pkcs7ContentInfo
+	if _, err := asn1.Unmarshal(data, &info); err != nil {
+		return nil, i18n.Errorf("解析PKCS#7失败: %v", err)
+	}
+	if !info.ContentType.Equal(oidSignedData) {
+		return nil, errs.InvalidInput("不支持的PKCS#7内容类型: %v", info.ContentType)
+	}
+
+	var signedData pkcs7SignedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &signedData); err != nil {
+		return nil, i18n.Errorf("解析PKCS#7 SignedData失败: %v", err)
+	}
+
+	certs, err := x509.ParseCertificates(signedData.Certificates.Bytes)
+	if err != nil {
+		return nil, i18n.Errorf("解析PKCS#7中的证书失败: %v", err)
+	}
+	if len(certs) == 0 {
+		return nil, i18n.Errorf("PKCS#7中不包含证书")
+	}
+
+	objects := make([]CertObject, 0, len(certs))
+	for _, cert := range certs {
+		objects = append(objects, newCertObject("CERTIFICATE", cert.Raw))
+	}
+	return objects, nil
+}
+
+// newCertObject 创建证书对象并生成描述
+func newCertObject(pemType string, der []byte) CertObject {
+	obj := CertObject{PEMType: pemType, DER: der}
+	switch pemType {
+	case "CERTIFICATE":
+		obj.Summary = i18n.T("证书")
+		if cert, err := x509.ParseCertificate(der); err == nil {
+			obj.Summary = i18n.T("证书 ") + formatName(cert.Subject.String())
+		}
+	case "X509 CRL":
+		obj.Summary = i18n.T("证书吊销列表")
+	case "CERTIFICATE REQUEST", "NEW CERTIFICATE REQUEST":
+		obj.Summary = i18n.T("证书签名请求")
+	case "PUBLIC KEY":
+		obj.Summary = i18n.T("公钥")
+	default:
+		obj.Summary = i18n.T("私钥 (") + pemType + ")"
+	}
+	return obj
+}
+
+// ConvertCertFile 在PEM和DER格式之间转换证书、私钥和PKCS#7证书链，返回写入的文件列表
+//
+// DER格式每个文件只能保存一个对象，包含多个对象时按序号写入多个文件。
+func ConvertCertFile(inFile, outFile, to string) ([]string, error) {
+	data, err := ioutil.ReadFile(inFile)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取文件失败: %v", err)
+	}
+
+	objects, _, err := ParseCertObjects(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(to) {
+	case FormatPEM:
+		var buf bytes.Buffer
+		for _, obj := range objects {
+			pem.Encode(&buf, &pem.Block{Type: obj.PEMType, Bytes: obj.DER})
+		}
+		if err := ioutil.WriteFile(outFile, buf.Bytes(), objectFileMode(objects)); err != nil {
+			return nil, errs.Wrap(err, "写入文件失败: %v", err)
+		}
+		return []string{outFile}, nil
+
+	case FormatDER:
+		if len(objects) == 1 {
+			if err := ioutil.WriteFile(outFile, objects[0].DER, objectFileMode(objects)); err != nil {
+				return nil, errs.Wrap(err, "写入文件失败: %v", err)
+			}
+			return []string{outFile}, nil
+		}
+
+		ext := filepath.Ext(outFile)
+		base := strings.TrimSuffix(outFile, ext)
+		var written []string
+		for i, obj := range objects {
+			name := fmt.Sprintf("%s-%d%s", base, i+1, ext)
+			if err := ioutil.WriteFile(name, obj.DER, objectFileMode([]CertObject{obj})); err != nil {
+				return written, errs.Wrap(err, "写入文件失败: %v", err)
+			}
+			written = append(written, name)
+		}
+		return written, nil
+
+	default:
+		return nil, errs.InvalidInput("不支持的目标格式: %s", to)
+	}
+}
+
+// objectFileMode 包含私钥时使用0600权限
+func objectFileMode(objects []CertObject) os.FileMode {
+	for _, obj := range objects {
+		if strings.HasSuffix(obj.PEMType, "PRIVATE KEY") {
+			return 0600
+		}
+	}
+	return 0644
+}
@@ -0,0 +1,194 @@
+package netutils
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// 证书签发用途
+const (
+	ProfileServer = "server" // 服务器证书
+	ProfileClient = "client" // 客户端证书
+	ProfileBoth   = "both"   // 同时用于服务器和客户端
+	ProfileCA     = "ca"     // 中间CA证书
+)
+
+// SignProfile 签发证书时使用的配置
+type SignProfile struct {
+	Usage     string // 证书用途（server, client, both, ca）
+	ValidDays int    // 有效期（天）
+	PathLen   int    // CA证书允许的下级CA层数，仅在Usage为ca时生效，-1表示不限制
+}
+
+// GenerateCSR 生成私钥和证书签名请求，私钥写入keyFile，返回PEM格式的CSR
+func GenerateCSR(config CertConfig, keyFile string) ([]byte, error) {
+	priv, err := generatePrivateKey(config.KeyType, config.KeySize)
+	if err != nil {
+		return nil, i18n.Errorf("生成私钥失败: %v", err)
+	}
+
+	der, err := createCertificateRequest(config, priv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writePrivateKey(keyFile, priv); err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// createCertificateRequest 使用指定私钥创建DER格式的证书签名请求
+func createCertificateRequest(config CertConfig, priv crypto.Signer) ([]byte, error) {
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   config.CommonName,
+			Organization: config.Organization,
+			Country:      config.Country,
+			Province:     config.Province,
+			Locality:     config.Locality,
+		},
+		DNSNames:    config.DNSNames,
+		IPAddresses: parseIPAddresses(config.IPAddresses),
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, priv)
+	if err != nil {
+		return nil, i18n.Errorf("生成证书签名请求失败: %v", err)
+	}
+	return der, nil
+}
+
+// ParseCSR 解析PEM或DER格式的证书签名请求并校验签名
+func ParseCSR(data []byte) (*x509.CertificateRequest, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+			return nil, i18n.Errorf("不是证书签名请求: %s", block.Type)
+		}
+		data = block.Bytes
+	}
+
+	csr, err := x509.ParseCertificateRequest(data)
+	if err != nil {
+		return nil, i18n.Errorf("解析证书签名请求失败: %v", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, i18n.Errorf("证书签名请求的签名无效: %v", err)
+	}
+	return csr, nil
+}
+
+// SignCSR 使用CA证书和私钥签发证书签名请求，返回PEM格式的证书
+func SignCSR(csrData []byte, caCertFile, caKeyFile string, profile SignProfile) ([]byte, error) {
+	csr, err := ParseCSR(csrData)
+	if err != nil {
+		return nil, err
+	}
+
+	caCert, caKey, err := loadSigner(caCertFile, caKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := signCertificateRequest(csr, caCert, caKey, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+// signCertificateRequest 根据签发配置使用CA签发证书，返回DER格式的证书
+func signCertificateRequest(csr *x509.CertificateRequest, caCert *x509.Certificate, caKey crypto.Signer, profile SignProfile) ([]byte, error) {
+	if !caCert.IsCA {
+		return nil, i18n.Errorf("签名者证书不是CA证书")
+	}
+
+	if profile.ValidDays <= 0 {
+		profile.ValidDays = 365
+	}
+	if profile.Usage == "" {
+		profile.Usage = ProfileServer
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, i18n.Errorf("生成序列号失败: %v", err)
+	}
+
+	notBefore := time.Now()
+	notAfter := notBefore.Add(time.Duration(profile.ValidDays) * 24 * time.Hour)
+	// 签发的证书有效期不能超过CA证书
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               csr.Subject,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		DNSNames:              csr.DNSNames,
+		IPAddresses:           csr.IPAddresses,
+		EmailAddresses:        csr.EmailAddresses,
+		URIs:                  csr.URIs,
+	}
+
+	// 只有RSA密钥可以用于密钥交换加密
+	if _, ok := csr.PublicKey.(*rsa.PublicKey); ok {
+		template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	switch profile.Usage {
+	case ProfileServer:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	case ProfileClient:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	case ProfileBoth:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	case ProfileCA:
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+		if profile.PathLen >= 0 {
+			template.MaxPathLen = profile.PathLen
+			template.MaxPathLenZero = profile.PathLen == 0
+		}
+	default:
+		return nil, errs.InvalidInput("不支持的证书用途: %s", profile.Usage)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, i18n.Errorf("签发证书失败: %v", err)
+	}
+	return der, nil
+}
+
+// SignCSRFile 读取CSR文件并签发证书，将证书写入certFile
+func SignCSRFile(csrFile, caCertFile, caKeyFile string, profile SignProfile, certFile string) error {
+	csrData, err := ioutil.ReadFile(csrFile)
+	if err != nil {
+		return errs.Wrap(err, "读取证书签名请求失败: %v", err)
+	}
+
+	certPEM, err := SignCSR(csrData, caCertFile, caKeyFile, profile)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+		return errs.Wrap(err, "写入证书文件失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package netutils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// PKCS12ExportOptions 导出PKCS#12文件的选项
+type PKCS12ExportOptions struct {
+	Password string   // 保护密码
+	Legacy   bool     // 使用旧版加密算法（3DES），兼容Windows 7/Server 2012及旧版Java
+	CAFiles  []string // 额外加入的CA证书文件
+}
+
+// PKCS12Info PKCS#12文件内容摘要
+type PKCS12Info struct {
+	Subject  string // 证书主体
+	Issuer   string // 颁发者
+	KeyType  string // 私钥类型
+	CACount  int    // 包含的CA证书数量
+	NotAfter string // 证书过期时间
+}
+
+// ExportPKCS12 将PEM格式的证书和私钥打包为PKCS#12文件
+//
+// 证书文件中如果包含完整证书链，第一张证书作为终端证书，其余证书与CAFiles一起作为CA证书写入。
+func ExportPKCS12(certFile, keyFile, outFile string, options PKCS12ExportOptions) (*PKCS12Info, error) {
+	chain, err := loadCertificates(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	keyData, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取私钥文件失败: %v", err)
+	}
+	key, err := parsePrivateKeyPEM(keyData)
+	if err != nil {
+		return nil, i18n.Errorf("解析私钥失败: %v", err)
+	}
+
+	leaf := chain[0]
+	if !publicKeyMatches(leaf, key) {
+		return nil, i18n.Errorf("私钥与证书不匹配")
+	}
+
+	caCerts := chain[1:]
+	for _, caFile := range options.CAFiles {
+		certs, err := loadCertificates(caFile)
+		if err != nil {
+			return nil, errs.Wrap(err, "读取CA证书 %s 失败: %v", caFile, err)
+		}
+		caCerts = append(caCerts, certs...)
+	}
+
+	encoder := pkcs12.Modern
+	if options.Legacy {
+		encoder = pkcs12.Legacy
+	}
+	pfxData, err := encoder.Encode(key, leaf, caCerts, options.Password)
+	if err != nil {
+		return nil, i18n.Errorf("编码PKCS#12失败: %v", err)
+	}
+
+	if err := ioutil.WriteFile(outFile, pfxData, 0600); err != nil {
+		return nil, errs.Wrap(err, "写入PKCS#12文件失败: %v", err)
+	}
+
+	return newPKCS12Info(leaf, key, caCerts), nil
+}
+
+// ImportPKCS12 将PKCS#12文件拆分为PEM格式的证书和私钥
+//
+// includeChain为true时CA证书追加在证书文件中，否则只写入终端证书。
+func ImportPKCS12(pfxFile, password, certFile, keyFile string, includeChain bool) (*PKCS12Info, error) {
+	pfxData, err := ioutil.ReadFile(pfxFile)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取PKCS#12文件失败: %v", err)
+	}
+
+	privateKey, leaf, caCerts, err := pkcs12.DecodeChain(pfxData, password)
+	if err != nil {
+		return nil, i18n.Errorf("解析PKCS#12失败（密码是否正确？）: %v", err)
+	}
+	key, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, errs.InvalidInput("不支持的私钥类型: %T", privateKey)
+	}
+
+	var certPEM bytes.Buffer
+	pem.Encode(&certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+	if includeChain {
+		for _, caCert := range caCerts {
+			pem.Encode(&certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})
+		}
+	}
+	if err := ioutil.WriteFile(certFile, certPEM.Bytes(), 0644); err != nil {
+		return nil, errs.Wrap(err, "写入证书文件失败: %v", err)
+	}
+
+	if err := writePrivateKey(keyFile, key); err != nil {
+		return nil, err
+	}
+
+	return newPKCS12Info(leaf, key, caCerts), nil
+}
+
+// newPKCS12Info 生成PKCS#12内容摘要
+func newPKCS12Info(leaf *x509.Certificate, key crypto.Signer, caCerts []*x509.Certificate) *PKCS12Info {
+	return &PKCS12Info{
+		Subject:  formatName(leaf.Subject.String()),
+		Issuer:   formatName(leaf.Issuer.String()),
+		KeyType:  keyTypeName(key),
+		CACount:  len(caCerts),
+		NotAfter: leaf.NotAfter.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// publicKeyMatches 检查私钥是否与证书中的公钥对应
+func publicKeyMatches(cert *x509.Certificate, key crypto.Signer) bool {
+	type equaler interface {
+		Equal(crypto.PublicKey) bool
+	}
+	pub, ok := key.Public().(equaler)
+	return ok && pub.Equal(cert.PublicKey)
+}
+
+// keyTypeName 返回私钥类型的可读名称
+func keyTypeName(key crypto.Signer) string {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return fmt.Sprintf("RSA %d", k.N.BitLen())
+	case *ecdsa.PrivateKey:
+		return "ECDSA " + k.Curve.Params().Name
+	case ed25519.PrivateKey:
+		return "Ed25519"
+	default:
+		return fmt.Sprintf("%T", key)
+	}
+}
@@ -0,0 +1,257 @@
+package netutils
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHKeyOptions SSH密钥生成选项
+type SSHKeyOptions struct {
+	Type       string // 密钥类型（ed25519, rsa, ecdsa）
+	Bits       int    // RSA密钥长度或ECDSA曲线大小（256, 384, 521）
+	Comment    string // 公钥注释
+	Passphrase string // 私钥保护密码，为空表示不加密
+}
+
+// SSHFingerprint SSH公钥指纹信息
+type SSHFingerprint struct {
+	Type    string // 密钥类型
+	Bits    int    // 密钥长度
+	SHA256  string // SHA256指纹
+	MD5     string // MD5指纹
+	Comment string // 注释或主机名
+}
+
+// SSHHostKey 远程主机提供的公钥
+type SSHHostKey struct {
+	Fingerprint    SSHFingerprint // 指纹信息
+	KnownHostsLine string         // 可直接写入known_hosts的行
+}
+
+// scanHostKeyAlgorithms 扫描主机公钥时依次请求的算法
+var scanHostKeyAlgorithms = []string{
+	ssh.KeyAlgoED25519,
+	ssh.KeyAlgoECDSA256,
+	ssh.KeyAlgoECDSA384,
+	ssh.KeyAlgoECDSA521,
+	ssh.KeyAlgoRSASHA512,
+}
+
+// GenerateSSHKey 生成SSH密钥对，私钥以OpenSSH格式写入privateFile，公钥写入privateFile.pub
+func GenerateSSHKey(options SSHKeyOptions, privateFile string) (*SSHFingerprint, error) {
+	var priv crypto.Signer
+	var err error
+
+	switch strings.ToLower(options.Type) {
+	case "", "ed25519":
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	case "rsa":
+		bits := options.Bits
+		if bits == 0 {
+			bits = 3072
+		}
+		if bits < 2048 {
+			return nil, i18n.Errorf("RSA密钥长度不能小于2048")
+		}
+		priv, err = rsa.GenerateKey(rand.Reader, bits)
+	case "ecdsa":
+		var curve elliptic.Curve
+		switch options.Bits {
+		case 0, 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, i18n.Errorf("ECDSA密钥长度只能为256、384或521")
+		}
+		priv, err = ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return nil, errs.InvalidInput("不支持的密钥类型: %s", options.Type)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("生成私钥失败: %v", err)
+	}
+
+	// 编码私钥
+	var block *pem.Block
+	if options.Passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(priv, options.Comment, []byte(options.Passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(priv, options.Comment)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("编码私钥失败: %v", err)
+	}
+	if err := ioutil.WriteFile(privateFile, pem.EncodeToMemory(block), 0600); err != nil {
+		return nil, errs.Wrap(err, "写入私钥文件失败: %v", err)
+	}
+
+	// 编码公钥
+	pub, err := ssh.NewPublicKey(priv.Public())
+	if err != nil {
+		return nil, i18n.Errorf("编码公钥失败: %v", err)
+	}
+	pubLine := bytes.TrimSpace(ssh.MarshalAuthorizedKey(pub))
+	if options.Comment != "" {
+		pubLine = append(pubLine, ' ')
+		pubLine = append(pubLine, options.Comment...)
+	}
+	pubLine = append(pubLine, '\n')
+	if err := ioutil.WriteFile(privateFile+".pub", pubLine, 0644); err != nil {
+		return nil, errs.Wrap(err, "写入公钥文件失败: %v", err)
+	}
+
+	fingerprint := newSSHFingerprint(pub, options.Comment)
+	return &fingerprint, nil
+}
+
+// FingerprintSSHKeys 计算文件内容中所有公钥的指纹
+//
+// 支持单个公钥文件、authorized_keys和known_hosts格式，也支持OpenSSH私钥（未加密时）。
+func FingerprintSSHKeys(data []byte) ([]SSHFingerprint, error) {
+	// OpenSSH或PEM格式的私钥
+	if bytes.Contains(data, []byte("PRIVATE KEY-----")) {
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			return nil, i18n.Errorf("解析私钥失败: %v", err)
+		}
+		return []SSHFingerprint{newSSHFingerprint(signer.PublicKey(), "")}, nil
+	}
+
+	var fingerprints []SSHFingerprint
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		// 先按authorized_keys格式解析，失败后再按known_hosts格式解析
+		if pub, comment, _, _, err := ssh.ParseAuthorizedKey(line); err == nil {
+			fingerprints = append(fingerprints, newSSHFingerprint(pub, comment))
+			continue
+		}
+		if _, hosts, pub, comment, _, err := ssh.ParseKnownHosts(line); err == nil {
+			name := strings.Join(hosts, ",")
+			if comment != "" {
+				name += " " + comment
+			}
+			fingerprints = append(fingerprints, newSSHFingerprint(pub, name))
+			continue
+		}
+		return nil, i18n.Errorf("第 %d 行不是有效的公钥", lineNum)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errs.Wrap(err, "读取公钥失败: %v", err)
+	}
+
+	if len(fingerprints) == 0 {
+		return nil, i18n.Errorf("未找到公钥")
+	}
+	return fingerprints, nil
+}
+
+// ScanSSHHostKeys 连接远程主机，获取其提供的各类型主机公钥（类似ssh-keyscan）
+func ScanSSHHostKeys(address string, timeout time.Duration) ([]SSHHostKey, error) {
+	// 未指定端口时默认使用22
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(strings.Trim(address, "[]"), "22")
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	var keys []SSHHostKey
+	var lastErr error
+	for _, algorithm := range scanHostKeyAlgorithms {
+		key, err := fetchSSHHostKey(address, algorithm, timeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if key == nil {
+			continue
+		}
+		keys = append(keys, SSHHostKey{
+			Fingerprint:    newSSHFingerprint(key, address),
+			KnownHostsLine: knownhosts.Line([]string{knownhosts.Normalize(address)}, key),
+		})
+	}
+
+	if len(keys) == 0 {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, i18n.Errorf("未获取到主机公钥")
+	}
+	return keys, nil
+}
+
+// fetchSSHHostKey 使用指定的主机公钥算法握手，获取主机公钥，服务器不支持该算法时返回nil
+func fetchSSHHostKey(address, algorithm string, timeout time.Duration) (ssh.PublicKey, error) {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, i18n.Errorf("连接失败: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	var hostKey ssh.PublicKey
+	config := &ssh.ClientConfig{
+		User:              "toolbox-keyscan",
+		HostKeyAlgorithms: []string{algorithm},
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			// 拿到公钥后中止握手，不进行认证
+			return i18n.Errorf("已获取主机公钥")
+		},
+		Timeout: timeout,
+	}
+
+	// 握手必然失败，只关心是否拿到了公钥
+	ssh.NewClientConn(conn, address, config)
+	return hostKey, nil
+}
+
+// newSSHFingerprint 计算公钥指纹
+func newSSHFingerprint(pub ssh.PublicKey, comment string) SSHFingerprint {
+	fingerprint := SSHFingerprint{
+		Type:    pub.Type(),
+		SHA256:  ssh.FingerprintSHA256(pub),
+		MD5:     ssh.FingerprintLegacyMD5(pub),
+		Comment: comment,
+	}
+
+	if cryptoKey, ok := pub.(ssh.CryptoPublicKey); ok {
+		switch key := cryptoKey.CryptoPublicKey().(type) {
+		case *rsa.PublicKey:
+			fingerprint.Bits = key.N.BitLen()
+		case *ecdsa.PublicKey:
+			fingerprint.Bits = key.Curve.Params().BitSize
+		case ed25519.PublicKey:
+			fingerprint.Bits = 256
+		}
+	}
+	return fingerprint
+}
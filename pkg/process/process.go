@@ -1,6 +1,7 @@
 package process
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,29 +10,31 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 
 	"github.com/shirou/gopsutil/v3/process"
 )
 
 // ProcessInfo 表示进程信息
 type ProcessInfo struct {
-	PID        int32     // 进程ID
-	PPID       int32     // 父进程ID
-	Name       string    // 进程名称
-	Executable string    // 可执行文件路径
-	Username   string    // 用户名
-	Status     string    // 状态
-	CreateTime time.Time // 创建时间
-	CPU        float64   // CPU使用率
-	Memory     float32   // 内存使用率(百分比)
+	PID        int32     `json:"pid"`         // 进程ID
+	PPID       int32     `json:"ppid"`        // 父进程ID
+	Name       string    `json:"name"`        // 进程名称
+	Executable string    `json:"executable"`  // 可执行文件路径
+	Username   string    `json:"username"`    // 用户名
+	Status     string    `json:"status"`      // 状态
+	CreateTime time.Time `json:"create_time"` // 创建时间
+	CPU        float64   `json:"cpu"`         // CPU使用率
+	Memory     float32   `json:"memory"`      // 内存使用率(百分比)
 	MemoryInfo struct {
-		RSS  uint64 // 常驻集大小(RSS)，单位字节
-		VMS  uint64 // 虚拟内存大小，单位字节
-		Swap uint64 // 交换空间大小，单位字节
-	} // 内存使用详情
-	CmdLine   []string // 命令行
-	Threads   int32    // 线程数
-	OpenFiles []string // 打开的文件
+		RSS  uint64 `json:"rss"`  // 常驻集大小(RSS)，单位字节
+		VMS  uint64 `json:"vms"`  // 虚拟内存大小，单位字节
+		Swap uint64 `json:"swap"` // 交换空间大小，单位字节
+	} `json:"memory_info"` // 内存使用详情
+	CmdLine   []string `json:"cmdline"`              // 命令行
+	Threads   int32    `json:"threads"`              // 线程数
+	OpenFiles []string `json:"open_files,omitempty"` // 打开的文件
 }
 
 // getNumWorkers 根据系统CPU核心数和进程数量计算最优的工作线程数
@@ -69,7 +72,7 @@ func GetProcessList() ([]ProcessInfo, error) {
 	// 获取所有进程
 	processes, err := process.Processes()
 	if err != nil {
-		return nil, fmt.Errorf("获取进程列表失败: %v", err)
+		return nil, i18n.Errorf("获取进程列表失败: %v", err)
 	}
 
 	// 设置较低的并发数，确保稳定性
@@ -214,7 +217,10 @@ func GetProcessList() ([]ProcessInfo, error) {
 func GetProcessByPID(pid int32) (ProcessInfo, error) {
 	p, err := process.NewProcess(pid)
 	if err != nil {
-		return ProcessInfo{}, fmt.Errorf("进程不存在或无法访问: %v", err)
+		if errors.Is(err, process.ErrorProcessNotRunning) {
+			return ProcessInfo{}, errs.NotFound("进程 %d 不存在", pid)
+		}
+		return ProcessInfo{}, errs.Wrap(err, "进程不存在或无法访问: %v", err)
 	}
 
 	// 创建进程信息
@@ -306,14 +312,17 @@ func GetProcessByPID(pid int32) (ProcessInfo, error) {
 func KillProcess(pid int32) error {
 	p, err := process.NewProcess(pid)
 	if err != nil {
-		return fmt.Errorf("找不到进程 PID=%d: %v", pid, err)
+		if errors.Is(err, process.ErrorProcessNotRunning) {
+			return errs.NotFound("找不到进程 PID=%d", pid)
+		}
+		return errs.Wrap(err, "找不到进程 PID=%d: %v", pid, err)
 	}
 
 	// 尝试正常终止进程
 	if err := p.Terminate(); err != nil {
 		// 如果正常终止失败，尝试强制结束
 		if killErr := p.Kill(); killErr != nil {
-			return fmt.Errorf("无法终止进程 PID=%d: %v", pid, killErr)
+			return errs.Wrap(killErr, "无法终止进程 PID=%d: %v", pid, killErr)
 		}
 	}
 
@@ -324,7 +333,7 @@ func KillProcess(pid int32) error {
 func GetChildProcesses(pid int32) ([]ProcessInfo, error) {
 	allProcesses, err := process.Processes()
 	if err != nil {
-		return nil, fmt.Errorf("获取进程列表失败: %v", err)
+		return nil, i18n.Errorf("获取进程列表失败: %v", err)
 	}
 
 	// 先过滤出所有可能的子进程，减少需要并发处理的数量
@@ -532,7 +541,7 @@ func StartProcess(executable string, args []string) (int32, error) {
 		},
 	)
 	if err != nil {
-		return 0, fmt.Errorf("启动进程失败: %v", err)
+		return 0, i18n.Errorf("启动进程失败: %v", err)
 	}
 
 	// 返回新进程的PID
@@ -544,7 +553,7 @@ func FilterProcessesByName(name string) ([]ProcessInfo, error) {
 	// 获取所有进程
 	processes, err := process.Processes()
 	if err != nil {
-		return nil, fmt.Errorf("获取进程列表失败: %v", err)
+		return nil, i18n.Errorf("获取进程列表失败: %v", err)
 	}
 
 	// 设置较低的并发数，确保稳定性
@@ -715,3 +724,28 @@ func containsIgnoreCase(s, substr string) bool {
 	s, substr = strings.ToLower(s), strings.ToLower(substr)
 	return strings.Contains(s, substr)
 }
+
+// ProcessName 进程ID与名称
+type ProcessName struct {
+	PID  int32  // 进程ID
+	Name string // 进程名称
+}
+
+// ListProcessNames 快速获取所有进程的PID和名称，不采集CPU、内存等信息
+func ListProcessNames() ([]ProcessName, error) {
+	processes, err := process.Processes()
+	if err != nil {
+		return nil, i18n.Errorf("获取进程列表失败: %v", err)
+	}
+
+	result := make([]ProcessName, 0, len(processes))
+	for _, p := range processes {
+		name, _ := p.Name()
+		result = append(result, ProcessName{PID: p.Pid, Name: name})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PID < result[j].PID
+	})
+	return result, nil
+}
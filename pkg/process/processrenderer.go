@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"strings"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/termcolor"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
@@ -44,19 +46,19 @@ func NewBasicRenderer(showDetail bool, noColor bool) *BasicProcessTreeRenderer {
 		Writer:     os.Stdout,
 		ShowDetail: showDetail,
 		NoColor:    noColor,
-		Title:      "系统进程树:",
+		Title:      i18n.T("系统进程树:"),
 	}
 }
 
 // Render 渲染进程树
 func (r *BasicProcessTreeRenderer) Render(tree *ProcessTreeNode) error {
 	if tree == nil {
-		return fmt.Errorf("进程树为空")
+		return i18n.Errorf("进程树为空")
 	}
 
 	// 如果请求禁用颜色
 	if r.NoColor {
-		color.NoColor = true
+		termcolor.SetEnabled(false)
 	}
 
 	// 打印标题
@@ -68,7 +70,7 @@ func (r *BasicProcessTreeRenderer) Render(tree *ProcessTreeNode) error {
 	// 创建表格
 	table := tablewriter.NewWriter(r.Writer)
 	if r.ShowDetail {
-		table.SetHeader([]string{"进程", "PID", "内存 (MB)", "CPU (%)", "状态"})
+		table.SetHeader([]string{i18n.T("进程"), "PID", i18n.T("内存 (MB)"), "CPU (%)", i18n.T("状态")})
 	} else {
 		table.SetHeader([]string{""})
 	}
@@ -139,7 +141,7 @@ func (r *BasicProcessTreeRenderer) formatNodeAsRow(node *ProcessTreeNode, depth
 
 		// 为System Idle Process添加特殊状态
 		if node.IsSpecial && proc.PID == 0 {
-			status = "系统空闲"
+			status = i18n.T("系统空闲")
 		}
 
 		return []string{
@@ -173,7 +175,7 @@ func (r *BasicProcessTreeRenderer) formatProcessName(p ProcessInfo, symbol strin
 
 	if isSpecial && p.PID == 0 {
 		// System Idle Process 特殊标记
-		nameWithPid = systemColor.Sprintf("%s (PID=%d) [特殊系统进程]", p.Name, p.PID)
+		nameWithPid = systemColor.Sprintf(i18n.T("%s (PID=%d) [特殊系统进程]"), p.Name, p.PID)
 	} else {
 		// 其他进程根据类型和PID选择颜色
 		var procNameColor *color.Color
@@ -1,15 +1,16 @@
 package process
 
 import (
-	"fmt"
 	"sort"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // ProcessTreeNode 表示进程树节点
 type ProcessTreeNode struct {
-	Process   ProcessInfo        // 当前进程信息
-	Children  []*ProcessTreeNode // 子进程列表
-	IsSpecial bool               // 是否为特殊进程
+	Process   ProcessInfo        `json:"process"`            // 当前进程信息
+	Children  []*ProcessTreeNode `json:"children,omitempty"` // 子进程列表
+	IsSpecial bool               `json:"special,omitempty"`  // 是否为特殊进程
 }
 
 // ProcessTreeOptions 表示构建进程树的选项
@@ -22,7 +23,7 @@ type ProcessTreeOptions struct {
 // BuildProcessTree 构建进程树
 func BuildProcessTree(processList []ProcessInfo, options ProcessTreeOptions) (*ProcessTreeNode, error) {
 	if len(processList) == 0 {
-		return nil, fmt.Errorf("进程列表为空")
+		return nil, i18n.Errorf("进程列表为空")
 	}
 
 	// 构建进程ID到进程信息的映射
@@ -127,7 +128,7 @@ func BuildProcessTree(processList []ProcessInfo, options ProcessTreeOptions) (*P
 		// 指定进程的子树
 		proc, exists := pidMap[options.RootPID]
 		if !exists {
-			return nil, fmt.Errorf("未找到PID为 %d 的进程", options.RootPID)
+			return nil, errs.NotFound("未找到PID为 %d 的进程", options.RootPID)
 		}
 
 		rootNode = &ProcessTreeNode{
@@ -0,0 +1,172 @@
+package remote
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// defaultIdentityFiles 未指定私钥时尝试的私钥文件
+var defaultIdentityFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// Client 到远程主机的SSH连接
+type Client struct {
+	Profile Profile
+	conn    *ssh.Client
+}
+
+// Dial 连接远程主机
+func Dial(profile Profile) (*Client, error) {
+	auth, err := authMethods(profile)
+	if err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := hostKeyCallback(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ssh.Dial("tcp", profile.HostPort(), &ssh.ClientConfig{
+		User:            profile.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         profile.Timeout,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "unable to authenticate") {
+			return nil, errs.PermissionDenied("登录 %s 失败: %v", profile.Label(), err)
+		}
+		return nil, errs.Wrap(err, "连接 %s 失败: %v", profile.Label(), err)
+	}
+	return &Client{Profile: profile, conn: conn}, nil
+}
+
+// Close 关闭连接
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Run 在远程主机上执行命令并返回标准输出，命令以非零状态退出时错误中包含标准错误的内容
+func (c *Client) Run(command string) ([]byte, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return nil, i18n.Errorf("创建SSH会话失败: %v", err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if err := session.Run(command); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return stdout.Bytes(), i18n.Errorf("远程命令执行失败: %s", message)
+	}
+	return stdout.Bytes(), nil
+}
+
+// authMethods 返回可用的认证方式：ssh-agent和私钥文件
+func authMethods(profile Profile) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	files := []string{profile.IdentityFile}
+	if profile.IdentityFile == "" {
+		files = nil
+		if home, err := os.UserHomeDir(); err == nil {
+			for _, name := range defaultIdentityFiles {
+				files = append(files, filepath.Join(home, ".ssh", name))
+			}
+		}
+	}
+
+	var signers []ssh.Signer
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			if profile.IdentityFile != "" {
+				return nil, errs.Wrap(err, "读取私钥失败: %v", err)
+			}
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			if profile.IdentityFile != "" {
+				return nil, errs.InvalidInput("解析私钥 %s 失败（暂不支持有密码的私钥，请使用ssh-agent）: %v", file, err)
+			}
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if len(methods) == 0 {
+		return nil, errs.InvalidInput("没有可用的SSH认证方式，请启动ssh-agent或在主机配置中指定 identity_file")
+	}
+	return methods, nil
+}
+
+// hostKeyCallback 返回主机密钥校验方式
+func hostKeyCallback(profile Profile) (ssh.HostKeyCallback, error) {
+	if profile.InsecureIgnoreHostKey {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	file := profile.KnownHostsFile
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, i18n.Errorf("获取用户主目录失败: %v", err)
+		}
+		file = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	callback, err := knownhosts.New(file)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取known_hosts失败（可先用ssh登录一次该主机）: %v", err)
+	}
+	return callback, nil
+}
+
+// ForEach 并发连接每台主机并调用fn，返回与profiles一一对应的错误
+func ForEach(profiles []Profile, fn func(index int, client *Client) error) []error {
+	results := make([]error, len(profiles))
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func(i int, profile Profile) {
+			defer wg.Done()
+			client, err := Dial(profile)
+			if err != nil {
+				results[i] = err
+				return
+			}
+			defer client.Close()
+			results[i] = fn(i, client)
+		}(i, profile)
+	}
+	wg.Wait()
+	return results
+}
+
+// shellQuote 使用单引号转义shell参数
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,82 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"toolbox/pkg/process"
+)
+
+// psCommand 远程获取进程列表的命令，Linux和macOS的ps都支持这些字段
+const psCommand = "LC_ALL=C ps -eo pid=,ppid=,user=,pcpu=,pmem=,rss=,vsz=,stat=,args="
+
+// psStatus ps状态码与本地进程状态名称的对应关系
+var psStatus = map[byte]string{
+	'R': "running",
+	'S': "sleep",
+	'D': "wait",
+	'I': "idle",
+	'T': "stop",
+	'Z': "zombie",
+}
+
+// ProcessList 获取远程主机的进程列表
+func ProcessList(client *Client) ([]process.ProcessInfo, error) {
+	out, err := client.Run(psCommand)
+	if err != nil {
+		return nil, err
+	}
+	return parsePS(out), nil
+}
+
+// parsePS 解析ps的输出，无法解析的行被忽略
+func parsePS(out []byte) []process.ProcessInfo {
+	var processes []process.ProcessInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 9 {
+			continue
+		}
+
+		pid, err := strconv.ParseInt(fields[0], 10, 32)
+		if err != nil {
+			continue
+		}
+		ppid, _ := strconv.ParseInt(fields[1], 10, 32)
+		cpu, _ := strconv.ParseFloat(fields[3], 64)
+		mem, _ := strconv.ParseFloat(fields[4], 32)
+		rss, _ := strconv.ParseUint(fields[5], 10, 64)
+		vsz, _ := strconv.ParseUint(fields[6], 10, 64)
+
+		info := process.ProcessInfo{
+			PID:      int32(pid),
+			PPID:     int32(ppid),
+			Username: fields[2],
+			CPU:      cpu,
+			Memory:   float32(mem),
+			CmdLine:  fields[8:],
+			Name:     processName(fields[8]),
+		}
+		info.MemoryInfo.RSS = rss * 1024
+		info.MemoryInfo.VMS = vsz * 1024
+		if status, ok := psStatus[fields[7][0]]; ok {
+			info.Status = status
+		} else {
+			info.Status = fields[7]
+		}
+		processes = append(processes, info)
+	}
+	return processes
+}
+
+// processName 根据命令行的第一个参数推断进程名称，内核线程显示为方括号中的名称
+func processName(arg0 string) string {
+	if strings.HasPrefix(arg0, "[") && strings.HasSuffix(arg0, "]") {
+		return strings.Trim(arg0, "[]")
+	}
+	return strings.TrimSuffix(filepath.Base(arg0), ":")
+}
@@ -0,0 +1,128 @@
+// Package remote 通过SSH在远程主机上执行工具箱支持的查询，并把结果解析为本地使用的数据结构
+//
+// 远程主机不需要安装工具箱，查询通过系统自带的ps、find等命令完成，因此目前只支持类Unix主机。
+package remote
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/config"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// DefaultTimeout 默认的连接超时时间
+const DefaultTimeout = 10 * time.Second
+
+// Profile 远程主机配置
+type Profile struct {
+	Name                  string        `yaml:"-"`                        // 配置名称，直接使用user@host时为空
+	Address               string        `yaml:"address"`                  // 主机名或IP，可以带端口
+	User                  string        `yaml:"user"`                     // 登录用户，默认为当前用户
+	Port                  int           `yaml:"port"`                     // SSH端口，默认为22
+	IdentityFile          string        `yaml:"identity_file"`            // 私钥文件，默认尝试ssh-agent和 ~/.ssh 下的常用私钥
+	KnownHostsFile        string        `yaml:"known_hosts"`              // known_hosts文件，默认为 ~/.ssh/known_hosts
+	InsecureIgnoreHostKey bool          `yaml:"insecure_ignore_host_key"` // 不校验主机密钥（仅用于测试环境）
+	Timeout               time.Duration `yaml:"timeout"`                  // 连接超时时间
+}
+
+// Label 返回主机的显示名称
+func (p Profile) Label() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.User + "@" + p.Address
+}
+
+// HostPort 返回连接使用的 host:port
+func (p Profile) HostPort() string {
+	if _, _, err := net.SplitHostPort(p.Address); err == nil {
+		return p.Address
+	}
+	port := p.Port
+	if port == 0 {
+		port = 22
+	}
+	return net.JoinHostPort(p.Address, strconv.Itoa(port))
+}
+
+// fileConfig 配置文件中与远程主机相关的部分
+type fileConfig struct {
+	Hosts map[string]Profile `yaml:"hosts"`
+}
+
+// LoadProfiles 读取配置文件中的主机配置
+//
+// 配置文件格式:
+//
+//	hosts:
+//	  web1:
+//	    address: 10.0.0.5
+//	    user: deploy
+//	    identity_file: ~/.ssh/id_ed25519
+func LoadProfiles() (map[string]Profile, error) {
+	var cfg fileConfig
+	if err := config.Load(&cfg); err != nil {
+		return nil, err
+	}
+	for name, profile := range cfg.Hosts {
+		profile.Name = name
+		cfg.Hosts[name] = profile
+	}
+	return cfg.Hosts, nil
+}
+
+// Resolve 将主机参数解析为主机配置
+//
+// spec 可以是配置文件中的名称，也可以是 [user@]host[:port] 形式的地址。
+func Resolve(spec string, profiles map[string]Profile) (Profile, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return Profile{}, errs.InvalidInput("主机不能为空")
+	}
+
+	profile, ok := profiles[spec]
+	if !ok {
+		profile = Profile{Address: spec}
+		if at := strings.LastIndex(spec, "@"); at >= 0 {
+			profile.User, profile.Address = spec[:at], spec[at+1:]
+		}
+		if profile.Address == "" {
+			return Profile{}, errs.InvalidInput("无效的主机: %s", spec)
+		}
+	}
+	if profile.Address == "" {
+		return Profile{}, errs.InvalidInput("主机配置 %s 未指定 address", spec)
+	}
+
+	if profile.User == "" {
+		current, err := user.Current()
+		if err != nil {
+			return Profile{}, i18n.Errorf("获取当前用户失败: %v", err)
+		}
+		profile.User = current.Username
+	}
+	if profile.Timeout <= 0 {
+		profile.Timeout = DefaultTimeout
+	}
+	profile.IdentityFile = expandHome(profile.IdentityFile)
+	profile.KnownHostsFile = expandHome(profile.KnownHostsFile)
+	return profile, nil
+}
+
+// expandHome 将路径开头的 ~ 替换为用户主目录
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,54 @@
+// Package service 查询和控制系统服务，屏蔽不同平台服务管理器的差异
+//
+// Linux上通过 systemctl 和 journalctl 操作systemd，Windows上通过服务控制管理器（SCM），
+// 其他平台暂不支持。
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Status 服务的状态
+type Status struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	State       string     `json:"state"`               // 运行状态，systemd为 active、inactive、failed 等，Windows为 running、stopped 等
+	SubState    string     `json:"sub_state,omitempty"` // systemd的细分状态，如 running、exited、dead
+	Enabled     string     `json:"enabled,omitempty"`   // 启动方式，如 enabled、disabled、auto、manual
+	PID         int        `json:"pid,omitempty"`       // 主进程PID，未运行时为0
+	Since       *time.Time `json:"since,omitempty"`     // 进入当前状态的时间
+	Memory      uint64     `json:"memory,omitempty"`    // 占用的内存（字节），无法获取时为0
+	Restarts    int        `json:"restarts,omitempty"`  // 自动重启的次数
+	Path        string     `json:"path,omitempty"`      // 单元文件或可执行文件路径
+	Manager     string     `json:"manager"`             // 服务管理器: systemd 或 scm
+}
+
+// Running 服务是否正在运行
+func (s Status) Running() bool {
+	return s.State == "active" || s.State == "running"
+}
+
+// LogOptions 定义了查看服务日志的选项
+type LogOptions struct {
+	Lines  int       // 显示最后几行，0 表示使用服务管理器的默认值
+	Since  time.Time // 只显示该时间之后的日志，零值表示不限制
+	Follow bool      // 持续输出新的日志，直到 ctx 取消
+}
+
+// Manager 服务管理器
+type Manager interface {
+	// Name 返回服务管理器的名称
+	Name() string
+	// Status 查询服务状态，服务不存在时返回 errs.ErrNotFound 类别的错误
+	Status(name string) (Status, error)
+	// Start 启动服务
+	Start(name string) error
+	// Stop 停止服务
+	Stop(name string) error
+	// Restart 重启服务
+	Restart(name string) error
+	// Logs 将服务日志写入 w
+	Logs(ctx context.Context, name string, options LogOptions, w io.Writer) error
+}
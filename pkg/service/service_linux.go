@@ -0,0 +1,176 @@
+//go:build linux
+// +build linux
+
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
+)
+
+// NewManager 返回当前系统的服务管理器
+func NewManager() (Manager, error) {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return nil, errs.NotFound("未找到systemctl，当前只支持systemd管理的服务")
+	}
+	// systemd运行时会创建该目录，容器等没有使用systemd的环境中不存在
+	if _, err := os.Stat("/run/systemd/system"); err != nil {
+		return nil, i18n.Errorf("系统没有使用systemd作为初始化系统，无法管理服务")
+	}
+	return systemd{}, nil
+}
+
+// systemd 通过 systemctl 和 journalctl 管理服务
+type systemd struct{}
+
+// showProperties 查询状态时读取的单元属性
+var showProperties = []string{
+	"Id", "Description", "LoadState", "ActiveState", "SubState", "UnitFileState",
+	"MainPID", "ActiveEnterTimestamp", "InactiveEnterTimestamp", "MemoryCurrent", "NRestarts", "FragmentPath",
+}
+
+// Name 返回服务管理器的名称
+func (systemd) Name() string {
+	return "systemd"
+}
+
+// Status 通过 systemctl show 查询服务状态
+func (systemd) Status(name string) (Status, error) {
+	out, err := systemctl("show", name, "--no-pager", "--property="+strings.Join(showProperties, ","))
+	if err != nil {
+		return Status{}, err
+	}
+
+	props := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			props[key] = value
+		}
+	}
+	if props["LoadState"] == "not-found" {
+		return Status{}, errs.NotFound("服务不存在: %s", name)
+	}
+
+	status := Status{
+		Name:        props["Id"],
+		Description: props["Description"],
+		State:       props["ActiveState"],
+		SubState:    props["SubState"],
+		Enabled:     props["UnitFileState"],
+		Path:        props["FragmentPath"],
+		Manager:     "systemd",
+	}
+	status.PID, _ = strconv.Atoi(props["MainPID"])
+	status.Restarts, _ = strconv.Atoi(props["NRestarts"])
+	// 未启用内存统计时为 [not set]，无上限时为 2^64-1
+	if memory, err := strconv.ParseUint(props["MemoryCurrent"], 10, 64); err == nil && memory != ^uint64(0) {
+		status.Memory = memory
+	}
+	since := props["ActiveEnterTimestamp"]
+	if !status.Running() {
+		since = props["InactiveEnterTimestamp"]
+	}
+	if t, ok := parseTimestamp(since); ok {
+		status.Since = &t
+	}
+	return status, nil
+}
+
+// parseTimestamp 解析 systemctl show 输出的时间，如 "Thu 2024-05-16 10:20:30 CST"
+func parseTimestamp(text string) (time.Time, bool) {
+	if text == "" || text == "n/a" {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation("Mon 2006-01-02 15:04:05 MST", text, time.Local)
+	if err != nil {
+		logger.Debugf("无法解析时间 %q: %v", text, err)
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Start 启动服务
+func (systemd) Start(name string) error {
+	_, err := systemctl("start", name)
+	return err
+}
+
+// Stop 停止服务
+func (systemd) Stop(name string) error {
+	_, err := systemctl("stop", name)
+	return err
+}
+
+// Restart 重启服务
+func (systemd) Restart(name string) error {
+	_, err := systemctl("restart", name)
+	return err
+}
+
+// Logs 通过 journalctl 输出服务日志
+func (systemd) Logs(ctx context.Context, name string, options LogOptions, w io.Writer) error {
+	args := []string{"--unit", name, "--no-pager", "--output", "short-iso"}
+	if options.Lines > 0 {
+		args = append(args, "--lines", strconv.Itoa(options.Lines))
+	}
+	if !options.Since.IsZero() {
+		args = append(args, "--since", options.Since.Format("2006-01-02 15:04:05"))
+	}
+	if options.Follow {
+		args = append(args, "--follow")
+	}
+
+	logger.Debugf("执行 journalctl %v", args)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "journalctl", args...)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return commandError("journalctl", err, stderr.Bytes())
+	}
+	return nil
+}
+
+// systemctl 执行 systemctl 并返回标准输出
+func systemctl(args ...string) ([]byte, error) {
+	logger.Debugf("执行 systemctl %v", args)
+	var stderr bytes.Buffer
+	cmd := exec.Command("systemctl", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, commandError("systemctl", err, stderr.Bytes())
+	}
+	return out, nil
+}
+
+// commandError 根据命令的错误输出判断错误类别
+func commandError(command string, err error, stderr []byte) error {
+	message := strings.TrimSpace(string(stderr))
+	if message == "" {
+		message = err.Error()
+	}
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "access denied") || strings.Contains(lower, "authentication is required") ||
+		strings.Contains(lower, "interactive authentication required"):
+		return errs.PermissionDenied("%s失败，需要root权限: %s", command, message)
+	case strings.Contains(lower, "not found") || strings.Contains(lower, "not loaded"):
+		return errs.NotFound("%s失败: %s", command, message)
+	}
+	return i18n.Errorf("%s失败: %s", command, message)
+}
@@ -0,0 +1,14 @@
+//go:build !linux && !windows
+// +build !linux,!windows
+
+package service
+
+import (
+	"runtime"
+	"toolbox/pkg/i18n"
+)
+
+// NewManager 当前系统不支持服务管理
+func NewManager() (Manager, error) {
+	return nil, i18n.Errorf("不支持在%s上管理服务，目前只支持systemd和Windows服务", runtime.GOOS)
+}
@@ -0,0 +1,168 @@
+//go:build windows
+// +build windows
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// stopTimeout 停止服务时等待其进入停止状态的最长时间
+const stopTimeout = 30 * time.Second
+
+// NewManager 返回当前系统的服务管理器
+func NewManager() (Manager, error) {
+	return scm{}, nil
+}
+
+// scm 通过Windows服务控制管理器管理服务
+type scm struct{}
+
+// Name 返回服务管理器的名称
+func (scm) Name() string {
+	return "scm"
+}
+
+// stateNames 服务运行状态的名称
+var stateNames = map[svc.State]string{
+	svc.Stopped:         "stopped",
+	svc.StartPending:    "start-pending",
+	svc.StopPending:     "stop-pending",
+	svc.Running:         "running",
+	svc.ContinuePending: "continue-pending",
+	svc.PausePending:    "pause-pending",
+	svc.Paused:          "paused",
+}
+
+// startTypeNames 服务启动方式的名称
+var startTypeNames = map[uint32]string{
+	mgr.StartAutomatic:           "auto",
+	mgr.StartManual:              "manual",
+	mgr.StartDisabled:            "disabled",
+	windows.SERVICE_BOOT_START:   "boot",
+	windows.SERVICE_SYSTEM_START: "system",
+}
+
+// openService 连接服务控制管理器并打开服务，调用方负责关闭两者
+func openService(name string) (*mgr.Mgr, *mgr.Service, error) {
+	m, err := mgr.Connect()
+	if err != nil {
+		return nil, nil, serviceError(err, i18n.T("连接服务控制管理器失败: %v"), err)
+	}
+	s, err := m.OpenService(name)
+	if err != nil {
+		m.Disconnect()
+		if errors.Is(err, windows.ERROR_SERVICE_DOES_NOT_EXIST) {
+			return nil, nil, errs.NotFound("服务不存在: %s", name)
+		}
+		return nil, nil, serviceError(err, i18n.T("打开服务失败: %v"), err)
+	}
+	return m, s, nil
+}
+
+// Status 查询服务状态
+func (scm) Status(name string) (Status, error) {
+	m, s, err := openService(name)
+	if err != nil {
+		return Status{}, err
+	}
+	defer m.Disconnect()
+	defer s.Close()
+
+	q, err := s.Query()
+	if err != nil {
+		return Status{}, serviceError(err, i18n.T("查询服务状态失败: %v"), err)
+	}
+	status := Status{
+		Name:    name,
+		State:   stateNames[q.State],
+		PID:     int(q.ProcessId),
+		Manager: "scm",
+	}
+	if cfg, err := s.Config(); err == nil {
+		status.Description = cfg.DisplayName
+		status.Enabled = startTypeNames[cfg.StartType]
+		if cfg.DelayedAutoStart && cfg.StartType == mgr.StartAutomatic {
+			status.Enabled = "auto-delayed"
+		}
+		status.Path = cfg.BinaryPathName
+	}
+	return status, nil
+}
+
+// Start 启动服务
+func (scm) Start(name string) error {
+	m, s, err := openService(name)
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+	defer s.Close()
+
+	if err := s.Start(); err != nil {
+		if errors.Is(err, windows.ERROR_SERVICE_ALREADY_RUNNING) {
+			return nil
+		}
+		return serviceError(err, i18n.T("启动服务失败: %v"), err)
+	}
+	return nil
+}
+
+// Stop 停止服务并等待其进入停止状态
+func (scm) Stop(name string) error {
+	m, s, err := openService(name)
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+	defer s.Close()
+
+	q, err := s.Control(svc.Stop)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_SERVICE_NOT_ACTIVE) {
+			return nil
+		}
+		return serviceError(err, i18n.T("停止服务失败: %v"), err)
+	}
+	deadline := time.Now().Add(stopTimeout)
+	for q.State != svc.Stopped {
+		if time.Now().After(deadline) {
+			return errs.Timeout("等待服务停止超时: %s", name)
+		}
+		time.Sleep(300 * time.Millisecond)
+		if q, err = s.Query(); err != nil {
+			return serviceError(err, i18n.T("查询服务状态失败: %v"), err)
+		}
+	}
+	return nil
+}
+
+// Restart 停止后重新启动服务
+func (m scm) Restart(name string) error {
+	if err := m.Stop(name); err != nil {
+		return err
+	}
+	return m.Start(name)
+}
+
+// Logs Windows服务没有统一的日志，输出位置由服务自行决定
+func (scm) Logs(ctx context.Context, name string, options LogOptions, w io.Writer) error {
+	return i18n.Errorf("Windows服务没有统一的日志，请在事件查看器（eventvwr）或服务自身的日志文件中查看")
+}
+
+// serviceError 将访问被拒绝转换为权限不足类别的错误
+func serviceError(err error, format string, args ...interface{}) error {
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		return errs.PermissionDenied(format+i18n.T("，请以管理员身份运行"), args...)
+	}
+	return errs.Wrap(err, format, args...)
+}
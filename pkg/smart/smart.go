@@ -0,0 +1,306 @@
+// Package smart 读取磁盘的SMART健康信息
+//
+// 优先调用 smartctl（smartmontools 7.0 及以上，支持 --json）获取完整的属性和健康状态；
+// 未安装 smartctl 时在Linux上退回到读取 /sys/block，只能得到型号、容量和部分设备的温度。
+package smart
+
+import (
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"toolbox/pkg/logger"
+)
+
+// 信息来源
+const (
+	SourceSmartctl = "smartctl"
+	SourceSysfs    = "sysfs"
+)
+
+// Options 定义了检查磁盘健康的选项
+type Options struct {
+	DeviceType string // 传给 smartctl -d 的设备类型，如 sat、nvme，空表示自动识别
+	TempWarn   int    // 温度达到该值（摄氏度）时给出警告，0 表示不检查
+}
+
+// Device 一个可检查的磁盘设备
+type Device struct {
+	Name string `json:"name"`           // 设备路径，如 /dev/sda
+	Type string `json:"type,omitempty"` // smartctl 识别的设备类型
+}
+
+// Attribute 一个ATA SMART属性
+type Attribute struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Value     int    `json:"value"`     // 归一化的当前值
+	Worst     int    `json:"worst"`     // 归一化的历史最差值
+	Threshold int    `json:"threshold"` // 归一化的阈值，当前值低于该值时判定为失败
+	Raw       int64  `json:"raw"`       // 原始值
+	RawString string `json:"raw_string"`
+	Failing   bool   `json:"failing"` // 当前或曾经低于阈值
+}
+
+// Drive 一块磁盘的健康信息
+type Drive struct {
+	Device       string      `json:"device"`
+	Type         string      `json:"type,omitempty"` // 接口协议，如 ATA、NVMe、SCSI
+	Model        string      `json:"model,omitempty"`
+	Serial       string      `json:"serial,omitempty"`
+	Firmware     string      `json:"firmware,omitempty"`
+	Capacity     uint64      `json:"capacity"`              // 字节
+	Passed       *bool       `json:"passed"`                // SMART整体评估是否通过，无法获取时为 null
+	Temperature  int         `json:"temperature"`           // 摄氏度，0 表示未知
+	PowerOnHours int64       `json:"power_on_hours"`        // 通电时间（小时），-1 表示未知
+	PowerCycles  int64       `json:"power_cycles"`          // 通电次数，-1 表示未知
+	Attributes   []Attribute `json:"attributes,omitempty"`  // ATA SMART属性
+	Warnings     []string    `json:"warnings"`              // 需要关注的问题
+	Error        string      `json:"error,omitempty"`       // 读取失败的原因
+	Source       string      `json:"source"`                // 信息来源: smartctl 或 sysfs
+	NVMe         *NVMeHealth `json:"nvme_health,omitempty"` // NVMe健康日志
+}
+
+// NVMeHealth NVMe SMART/健康信息日志中的主要字段
+type NVMeHealth struct {
+	CriticalWarning         int   `json:"critical_warning"`
+	AvailableSpare          int   `json:"available_spare"`           // 剩余备用空间（百分比）
+	AvailableSpareThreshold int   `json:"available_spare_threshold"` // 备用空间阈值（百分比）
+	PercentageUsed          int   `json:"percentage_used"`           // 已用寿命（百分比），可能超过100
+	MediaErrors             int64 `json:"media_errors"`              // 不可恢复的数据完整性错误数
+	UnsafeShutdowns         int64 `json:"unsafe_shutdowns"`
+}
+
+// Healthy 是否没有任何警告和错误
+func (d Drive) Healthy() bool {
+	return d.Error == "" && len(d.Warnings) == 0 && (d.Passed == nil || *d.Passed)
+}
+
+// HasSmartctl 是否安装了 smartctl
+func HasSmartctl() bool {
+	_, err := exec.LookPath("smartctl")
+	return err == nil
+}
+
+// Scan 列出可检查的磁盘
+func Scan() ([]Device, error) {
+	if !HasSmartctl() {
+		return scanSysfs()
+	}
+	var out struct {
+		Devices []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"devices"`
+	}
+	if err := runSmartctl(&out, "--scan", "--json"); err != nil {
+		return nil, err
+	}
+	devices := make([]Device, 0, len(out.Devices))
+	for _, d := range out.Devices {
+		devices = append(devices, Device{Name: d.Name, Type: d.Type})
+	}
+	return devices, nil
+}
+
+// Check 读取一块磁盘的健康信息并给出警告，读取失败时记录在 Drive.Error 中
+func Check(device Device, options Options) Drive {
+	var drive Drive
+	if HasSmartctl() {
+		drive = readSmartctl(device, options.DeviceType)
+	} else {
+		drive = readSysfs(device)
+	}
+	if drive.Error == "" {
+		drive.Warnings = append(drive.Warnings, assess(drive, options.TempWarn)...)
+	}
+	return drive
+}
+
+// smartctlOutput smartctl --json 输出中用到的字段
+type smartctlOutput struct {
+	Smartctl struct {
+		ExitStatus int `json:"exit_status"`
+		Messages   []struct {
+			String   string `json:"string"`
+			Severity string `json:"severity"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	Device struct {
+		Name     string `json:"name"`
+		Protocol string `json:"protocol"`
+	} `json:"device"`
+	ModelName       string `json:"model_name"`
+	SerialNumber    string `json:"serial_number"`
+	FirmwareVersion string `json:"firmware_version"`
+	UserCapacity    struct {
+		Bytes uint64 `json:"bytes"`
+	} `json:"user_capacity"`
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature struct {
+		Current int `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+	PowerCycleCount    *int64 `json:"power_cycle_count"`
+	ATASmartAttributes struct {
+		Table []struct {
+			ID         int    `json:"id"`
+			Name       string `json:"name"`
+			Value      int    `json:"value"`
+			Worst      int    `json:"worst"`
+			Thresh     int    `json:"thresh"`
+			WhenFailed string `json:"when_failed"`
+			Raw        struct {
+				Value  int64  `json:"value"`
+				String string `json:"string"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeLog *struct {
+		CriticalWarning         int   `json:"critical_warning"`
+		AvailableSpare          int   `json:"available_spare"`
+		AvailableSpareThreshold int   `json:"available_spare_threshold"`
+		PercentageUsed          int   `json:"percentage_used"`
+		MediaErrors             int64 `json:"media_errors"`
+		UnsafeShutdowns         int64 `json:"unsafe_shutdowns"`
+	} `json:"nvme_smart_health_information_log"`
+	SCSIGrownDefectList *int64 `json:"scsi_grown_defect_list"`
+}
+
+// runSmartctl 执行 smartctl 并解析JSON输出
+//
+// smartctl 的退出码是位掩码，第0、1位表示命令行错误或无法打开设备，
+// 更高的位表示磁盘存在问题，此时输出仍然完整，不作为错误处理。
+func runSmartctl(out interface{}, args ...string) error {
+	logger.Debugf("执行 smartctl %v", args)
+	data, err := exec.Command("smartctl", args...).Output()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return errs.Wrap(err, "执行smartctl失败: %v", err)
+	}
+	if jsonErr := json.Unmarshal(data, out); jsonErr != nil {
+		if err != nil {
+			return errs.Wrap(err, "执行smartctl失败: %v", err)
+		}
+		return i18n.Errorf("解析smartctl输出失败: %v", jsonErr)
+	}
+	return nil
+}
+
+// readSmartctl 通过 smartctl 读取磁盘信息
+func readSmartctl(device Device, deviceType string) Drive {
+	drive := Drive{Device: device.Name, Source: SourceSmartctl, PowerOnHours: -1, PowerCycles: -1, Warnings: []string{}}
+	args := []string{"--all", "--json"}
+	if deviceType == "" {
+		deviceType = device.Type
+	}
+	if deviceType != "" {
+		args = append(args, "-d", deviceType)
+	}
+
+	var out smartctlOutput
+	if err := runSmartctl(&out, append(args, device.Name)...); err != nil {
+		drive.Error = err.Error()
+		return drive
+	}
+	if out.Smartctl.ExitStatus&0x3 != 0 {
+		drive.Error = i18n.Tf("smartctl退出码为%d", out.Smartctl.ExitStatus)
+		for _, msg := range out.Smartctl.Messages {
+			if msg.Severity == "error" {
+				drive.Error = msg.String
+				break
+			}
+		}
+		return drive
+	}
+
+	drive.Type = out.Device.Protocol
+	drive.Model = out.ModelName
+	drive.Serial = out.SerialNumber
+	drive.Firmware = out.FirmwareVersion
+	drive.Capacity = out.UserCapacity.Bytes
+	drive.Temperature = out.Temperature.Current
+	if out.SmartStatus != nil {
+		passed := out.SmartStatus.Passed
+		drive.Passed = &passed
+	}
+	if out.PowerOnTime != nil {
+		drive.PowerOnHours = out.PowerOnTime.Hours
+	}
+	if out.PowerCycleCount != nil {
+		drive.PowerCycles = *out.PowerCycleCount
+	}
+	for _, a := range out.ATASmartAttributes.Table {
+		drive.Attributes = append(drive.Attributes, Attribute{
+			ID:        a.ID,
+			Name:      a.Name,
+			Value:     a.Value,
+			Worst:     a.Worst,
+			Threshold: a.Thresh,
+			Raw:       a.Raw.Value,
+			RawString: a.Raw.String,
+			Failing:   a.WhenFailed != "",
+		})
+	}
+	if log := out.NVMeLog; log != nil {
+		drive.NVMe = &NVMeHealth{
+			CriticalWarning:         log.CriticalWarning,
+			AvailableSpare:          log.AvailableSpare,
+			AvailableSpareThreshold: log.AvailableSpareThreshold,
+			PercentageUsed:          log.PercentageUsed,
+			MediaErrors:             log.MediaErrors,
+			UnsafeShutdowns:         log.UnsafeShutdowns,
+		}
+	}
+	if out.SCSIGrownDefectList != nil && *out.SCSIGrownDefectList > 0 {
+		drive.Warnings = append(drive.Warnings, i18n.Tf("已有%d个新增缺陷块", *out.SCSIGrownDefectList))
+	}
+	return drive
+}
+
+// sectorAttributes 原始值大于0即表示介质出现问题的ATA属性
+var sectorAttributes = map[int]string{
+	5:   "已重映射%d个扇区",
+	196: "发生过%d次重映射",
+	197: "有%d个待重映射的扇区",
+	198: "有%d个无法修复的扇区",
+}
+
+// assess 根据SMART信息给出警告
+func assess(drive Drive, tempWarn int) []string {
+	var warnings []string
+	if drive.Passed != nil && !*drive.Passed {
+		warnings = append(warnings, i18n.T("SMART整体评估未通过，请尽快备份数据"))
+	}
+	if tempWarn > 0 && drive.Temperature >= tempWarn {
+		warnings = append(warnings, i18n.Tf("温度过高: %d°C", drive.Temperature))
+	}
+	for _, a := range drive.Attributes {
+		if format, ok := sectorAttributes[a.ID]; ok && a.Raw > 0 {
+			warnings = append(warnings, i18n.Tf(format, a.Raw))
+		}
+		if a.Failing {
+			warnings = append(warnings, i18n.Tf("属性 %s 曾低于阈值", a.Name))
+		}
+	}
+	if n := drive.NVMe; n != nil {
+		if n.CriticalWarning != 0 {
+			warnings = append(warnings, i18n.Tf("NVMe严重警告: 0x%02x", n.CriticalWarning))
+		}
+		if n.AvailableSpareThreshold > 0 && n.AvailableSpare <= n.AvailableSpareThreshold {
+			warnings = append(warnings, i18n.Tf("备用空间不足: %d%%", n.AvailableSpare))
+		}
+		if n.PercentageUsed >= 90 {
+			warnings = append(warnings, i18n.Tf("已用寿命: %d%%", n.PercentageUsed))
+		}
+		if n.MediaErrors > 0 {
+			warnings = append(warnings, i18n.Tf("有%d个介质错误", n.MediaErrors))
+		}
+	}
+	return warnings
+}
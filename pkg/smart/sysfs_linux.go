@@ -0,0 +1,86 @@
+//go:build linux
+// +build linux
+
+package smart
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// virtualBlockPrefixes 不对应物理磁盘的块设备
+var virtualBlockPrefixes = []string{"loop", "ram", "zram", "dm-", "md", "sr", "fd", "nbd"}
+
+// scanSysfs 从 /sys/block 列出物理磁盘
+func scanSysfs() ([]Device, error) {
+	entries, err := os.ReadDir("/sys/block")
+	if err != nil {
+		return nil, errs.Wrap(err, "读取/sys/block失败: %v", err)
+	}
+	devices := []Device{}
+	for _, entry := range entries {
+		virtual := false
+		for _, prefix := range virtualBlockPrefixes {
+			if strings.HasPrefix(entry.Name(), prefix) {
+				virtual = true
+				break
+			}
+		}
+		if !virtual {
+			devices = append(devices, Device{Name: "/dev/" + entry.Name()})
+		}
+	}
+	return devices, nil
+}
+
+// readSysfs 从 /sys/block 读取型号、容量和温度，无法得到SMART属性和健康状态
+func readSysfs(device Device) Drive {
+	drive := Drive{Device: device.Name, Source: SourceSysfs, PowerOnHours: -1, PowerCycles: -1, Warnings: []string{}}
+	dir := filepath.Join("/sys/block", filepath.Base(device.Name))
+	if _, err := os.Stat(dir); err != nil {
+		drive.Error = i18n.T("未找到设备，未安装smartctl时只能检查 /sys/block 下的磁盘")
+		return drive
+	}
+
+	drive.Model = readSysfsString(filepath.Join(dir, "device", "model"))
+	drive.Serial = readSysfsString(filepath.Join(dir, "device", "serial"))
+	drive.Firmware = readSysfsString(filepath.Join(dir, "device", "firmware_rev"))
+	if sectors, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, "size")), 10, 64); err == nil {
+		// size 总是以512字节为单位，与实际扇区大小无关
+		drive.Capacity = sectors * 512
+	}
+	switch vendor := readSysfsString(filepath.Join(dir, "device", "vendor")); {
+	case strings.HasPrefix(filepath.Base(device.Name), "nvme"):
+		drive.Type = "NVMe"
+	case vendor == "ATA":
+		drive.Type = "ATA"
+	case vendor != "":
+		drive.Type = "SCSI"
+	}
+
+	// NVMe控制器和加载了drivetemp模块的SATA磁盘会提供hwmon温度，单位为千分之一摄氏度
+	for _, pattern := range []string{"device/hwmon*/temp1_input", "device/hwmon/hwmon*/temp1_input"} {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+		if len(matches) == 0 {
+			continue
+		}
+		if milli, err := strconv.Atoi(readSysfsString(matches[0])); err == nil {
+			drive.Temperature = milli / 1000
+			break
+		}
+	}
+	return drive
+}
+
+// readSysfsString 读取sysfs文件并去掉首尾空白，失败时返回空字符串
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
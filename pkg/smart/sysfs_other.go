@@ -0,0 +1,26 @@
+//go:build !linux
+// +build !linux
+
+package smart
+
+import (
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+)
+
+// scanSysfs 非Linux系统没有 /sys/block，需要安装 smartctl
+func scanSysfs() ([]Device, error) {
+	return nil, errs.NotFound("未找到smartctl，请先安装smartmontools")
+}
+
+// readSysfs 非Linux系统没有 /sys/block，需要安装 smartctl
+func readSysfs(device Device) Drive {
+	return Drive{
+		Device:       device.Name,
+		Source:       SourceSysfs,
+		PowerOnHours: -1,
+		PowerCycles:  -1,
+		Warnings:     []string{},
+		Error:        i18n.T("未找到smartctl，请先安装smartmontools"),
+	}
+}
@@ -0,0 +1,240 @@
+// Package sysinfo 汇总操作系统、CPU、内存、磁盘和网络接口等本机信息
+//
+// 各部分独立采集，某一部分在当前平台上不可用或采集失败时留空，并记录到 Warnings 中，
+// 不影响其他部分。
+package sysinfo
+
+import (
+	"runtime"
+	"sort"
+	"time"
+	"toolbox/pkg/i18n"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// Options 定义了采集系统信息的选项
+type Options struct {
+	CPUSample time.Duration // 采样CPU使用率的时长，0 表示不采样
+	AllDisks  bool          // 包括 tmpfs、overlay 等虚拟文件系统
+}
+
+// HostInfo 操作系统和主机信息
+type HostInfo struct {
+	Hostname        string    `json:"hostname"`
+	OS              string    `json:"os"`               // 如 linux、darwin、windows
+	Platform        string    `json:"platform"`         // 发行版，如 ubuntu
+	PlatformVersion string    `json:"platform_version"` // 发行版版本
+	KernelVersion   string    `json:"kernel_version"`
+	Arch            string    `json:"arch"`                     // 内核报告的架构，如 x86_64
+	Virtualization  string    `json:"virtualization,omitempty"` // 虚拟化或容器类型，如 kvm、docker
+	BootTime        time.Time `json:"boot_time"`
+	Uptime          uint64    `json:"uptime"` // 已运行的秒数
+	Procs           uint64    `json:"procs"`  // 进程数
+}
+
+// CPUInfo CPU信息
+type CPUInfo struct {
+	Model         string  `json:"model"`
+	PhysicalCores int     `json:"physical_cores"`
+	LogicalCores  int     `json:"logical_cores"`
+	Mhz           float64 `json:"mhz"`
+	Usage         float64 `json:"usage"` // 采样期间的总使用率（百分比），未采样时为 -1
+}
+
+// LoadInfo 系统负载，Windows上不可用
+type LoadInfo struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// MemoryInfo 内存或交换空间的使用情况（字节）
+type MemoryInfo struct {
+	Total       uint64  `json:"total"`
+	Used        uint64  `json:"used"`
+	Available   uint64  `json:"available"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+// DiskInfo 一个已挂载文件系统的使用情况（字节）
+type DiskInfo struct {
+	Mountpoint  string  `json:"mountpoint"`
+	Device      string  `json:"device"`
+	Fstype      string  `json:"fstype"`
+	Total       uint64  `json:"total"`
+	Used        uint64  `json:"used"`
+	Free        uint64  `json:"free"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+// InterfaceInfo 网络接口
+type InterfaceInfo struct {
+	Name  string   `json:"name"`
+	MAC   string   `json:"mac,omitempty"`
+	MTU   int      `json:"mtu"`
+	Up    bool     `json:"up"`
+	Addrs []string `json:"addrs"` // CIDR形式的地址，如 192.168.1.10/24
+}
+
+// Info 系统信息
+type Info struct {
+	Host       HostInfo        `json:"host"`
+	CPU        CPUInfo         `json:"cpu"`
+	Load       *LoadInfo       `json:"load,omitempty"`
+	Memory     MemoryInfo      `json:"memory"`
+	Swap       MemoryInfo      `json:"swap"`
+	Disks      []DiskInfo      `json:"disks"`
+	Interfaces []InterfaceInfo `json:"interfaces"`
+	Warnings   []string        `json:"warnings,omitempty"` // 采集失败的部分
+}
+
+// Collect 采集系统信息
+func Collect(options Options) Info {
+	info := Info{Disks: []DiskInfo{}, Interfaces: []InterfaceInfo{}}
+	warn := func(part string, err error) {
+		info.Warnings = append(info.Warnings, i18n.Tf("获取%s失败: %v", part, err))
+	}
+
+	if h, err := host.Info(); err != nil {
+		warn(i18n.T("主机信息"), err)
+		info.Host.OS = runtime.GOOS
+		info.Host.Arch = runtime.GOARCH
+	} else {
+		info.Host = HostInfo{
+			Hostname:        h.Hostname,
+			OS:              h.OS,
+			Platform:        h.Platform,
+			PlatformVersion: h.PlatformVersion,
+			KernelVersion:   h.KernelVersion,
+			Arch:            h.KernelArch,
+			BootTime:        time.Unix(int64(h.BootTime), 0),
+			Uptime:          h.Uptime,
+			Procs:           h.Procs,
+		}
+		if h.VirtualizationRole == "guest" {
+			info.Host.Virtualization = h.VirtualizationSystem
+		}
+	}
+
+	info.CPU = collectCPU(options.CPUSample, warn)
+
+	if l, err := load.Avg(); err == nil && runtime.GOOS != "windows" {
+		info.Load = &LoadInfo{Load1: l.Load1, Load5: l.Load5, Load15: l.Load15}
+	}
+
+	if v, err := mem.VirtualMemory(); err != nil {
+		warn(i18n.T("内存信息"), err)
+	} else {
+		info.Memory = MemoryInfo{Total: v.Total, Used: v.Used, Available: v.Available, UsedPercent: v.UsedPercent}
+	}
+	if s, err := mem.SwapMemory(); err != nil {
+		warn(i18n.T("交换空间信息"), err)
+	} else {
+		info.Swap = MemoryInfo{Total: s.Total, Used: s.Used, Available: s.Free, UsedPercent: s.UsedPercent}
+	}
+
+	if disks, err := collectDisks(options.AllDisks); err != nil {
+		warn(i18n.T("磁盘信息"), err)
+	} else {
+		info.Disks = disks
+	}
+
+	if interfaces, err := collectInterfaces(); err != nil {
+		warn(i18n.T("网络接口"), err)
+	} else {
+		info.Interfaces = interfaces
+	}
+	return info
+}
+
+// collectCPU 采集CPU型号、核数和使用率
+func collectCPU(sample time.Duration, warn func(string, error)) CPUInfo {
+	info := CPUInfo{Usage: -1}
+	if infos, err := cpu.Info(); err != nil {
+		warn(i18n.T("CPU信息"), err)
+	} else if len(infos) > 0 {
+		info.Model = infos[0].ModelName
+		info.Mhz = infos[0].Mhz
+	}
+	if n, err := cpu.Counts(false); err == nil {
+		info.PhysicalCores = n
+	}
+	if n, err := cpu.Counts(true); err == nil {
+		info.LogicalCores = n
+	} else {
+		info.LogicalCores = runtime.NumCPU()
+	}
+	if sample > 0 {
+		if percents, err := cpu.Percent(sample, false); err != nil {
+			warn(i18n.T("CPU使用率"), err)
+		} else if len(percents) > 0 {
+			info.Usage = percents[0]
+		}
+	}
+	return info
+}
+
+// collectDisks 采集已挂载文件系统的使用情况，按挂载点排序；
+// all 为假时只包括物理设备，并忽略容量为0的文件系统
+func collectDisks(all bool) ([]DiskInfo, error) {
+	partitions, err := disk.Partitions(all)
+	if err != nil {
+		return nil, err
+	}
+	disks := []DiskInfo{}
+	seen := make(map[string]bool)
+	for _, p := range partitions {
+		if seen[p.Mountpoint] {
+			continue
+		}
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil || (!all && usage.Total == 0) {
+			continue
+		}
+		seen[p.Mountpoint] = true
+		disks = append(disks, DiskInfo{
+			Mountpoint:  p.Mountpoint,
+			Device:      p.Device,
+			Fstype:      p.Fstype,
+			Total:       usage.Total,
+			Used:        usage.Used,
+			Free:        usage.Free,
+			UsedPercent: usage.UsedPercent,
+		})
+	}
+	sort.Slice(disks, func(i, j int) bool { return disks[i].Mountpoint < disks[j].Mountpoint })
+	return disks, nil
+}
+
+// collectInterfaces 采集网络接口及其地址
+func collectInterfaces() ([]InterfaceInfo, error) {
+	stats, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	interfaces := make([]InterfaceInfo, 0, len(stats))
+	for _, s := range stats {
+		iface := InterfaceInfo{
+			Name:  s.Name,
+			MAC:   s.HardwareAddr,
+			MTU:   s.MTU,
+			Addrs: []string{},
+		}
+		for _, flag := range s.Flags {
+			if flag == "up" {
+				iface.Up = true
+			}
+		}
+		for _, addr := range s.Addrs {
+			iface.Addrs = append(iface.Addrs, addr.Addr)
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces, nil
+}
@@ -0,0 +1,454 @@
+// Package tasks 按任务文件依次执行一组工具箱命令和外部命令
+//
+// 任务文件使用YAML格式，每个步骤可以是工具箱子命令（run），也可以是通过系统shell
+// 执行的外部命令（shell），用于把压缩、分割、上传等重复操作固化为一个文件。
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 步骤执行状态
+const (
+	StepStatusOK      = "ok"      // 成功
+	StepStatusFailed  = "failed"  // 失败
+	StepStatusSkipped = "skipped" // 因前面的步骤失败而未执行
+)
+
+// 步骤类型
+const (
+	StepKindToolbox = "toolbox" // 工具箱子命令
+	StepKindShell   = "shell"   // 外部shell命令
+)
+
+// DepthEnv 记录任务嵌套深度的环境变量，防止任务文件递归调用自身
+const DepthEnv = "TOOLBOX_TASK_DEPTH"
+
+// maxDepth 允许的最大嵌套深度
+const maxDepth = 5
+
+// varPattern 匹配 ${name} 形式的变量引用，$name 形式留给shell处理
+var varPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Step 任务中的一个步骤，Run/Args 与 Shell 二选一
+type Step struct {
+	Name            string            `yaml:"name"`              // 步骤名称，为空时使用命令
+	Run             string            `yaml:"run"`               // 工具箱子命令及参数，如 "fs compress ./data backup.tar.gz"
+	Args            []string          `yaml:"args"`              // 追加到Run之后的参数，每项作为一个参数，不需要转义
+	Shell           string            `yaml:"shell"`             // 通过系统shell执行的外部命令
+	Dir             string            `yaml:"dir"`               // 工作目录
+	Env             map[string]string `yaml:"env"`               // 额外的环境变量
+	Timeout         time.Duration     `yaml:"timeout"`           // 超时时间，0表示不限制
+	ContinueOnError *bool             `yaml:"continue_on_error"` // 失败后是否继续，未设置时使用任务文件的设置
+}
+
+// UnmarshalYAML 支持直接使用字符串表示工具箱子命令
+func (s *Step) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		s.Run = strings.TrimSpace(node.Value)
+		return nil
+	}
+
+	type plain Step
+	return node.Decode((*plain)(s))
+}
+
+// Kind 返回步骤类型
+func (s Step) Kind() string {
+	if s.Shell != "" {
+		return StepKindShell
+	}
+	return StepKindToolbox
+}
+
+// Label 返回步骤的显示名称
+func (s Step) Label() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.Shell != "":
+		return s.Shell
+	default:
+		return strings.TrimSpace(s.Run + " " + strings.Join(s.Args, " "))
+	}
+}
+
+// TaskFile 任务文件
+type TaskFile struct {
+	Name            string            `yaml:"name"`              // 任务名称
+	Vars            map[string]string `yaml:"vars"`              // 变量，在命令中以 ${name} 引用
+	Env             map[string]string `yaml:"env"`               // 所有步骤共用的环境变量
+	ContinueOnError bool              `yaml:"continue_on_error"` // 步骤失败后是否继续执行后续步骤
+	Steps           []Step            `yaml:"steps"`             // 步骤列表
+}
+
+// LoadFile 读取YAML格式的任务文件
+//
+// 任务文件可以是包含name、vars和steps的对象，也可以直接是步骤列表。
+func LoadFile(path string) (*TaskFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err, "读取任务文件失败: %v", err)
+	}
+
+	file := &TaskFile{}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, errs.InvalidInput("解析任务文件失败: %v", err)
+	}
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+		err = node.Content[0].Decode(&file.Steps)
+	} else {
+		err = node.Decode(file)
+	}
+	if err != nil {
+		return nil, errs.InvalidInput("解析任务文件失败: %v", err)
+	}
+
+	if len(file.Steps) == 0 {
+		return nil, errs.InvalidInput("任务文件中没有步骤")
+	}
+	for i, step := range file.Steps {
+		hasRun := step.Run != "" || len(step.Args) > 0
+		if hasRun == (step.Shell != "") {
+			return nil, errs.InvalidInput("第 %d 个步骤必须指定 run 或 shell 其中之一", i+1)
+		}
+	}
+	return file, nil
+}
+
+// RunOptions 任务执行选项
+type RunOptions struct {
+	Executable      string            // 执行工具箱步骤的可执行文件，通常为当前程序
+	GlobalArgs      []string          // 传给每个工具箱步骤的全局参数，如 --no-color
+	Vars            map[string]string // 覆盖任务文件中的变量
+	ContinueOnError bool              // 所有步骤失败后都继续执行
+	Stdout          io.Writer         // 步骤的标准输出，为nil时使用os.Stdout
+	Stderr          io.Writer         // 步骤的标准错误，为nil时使用os.Stderr
+
+	// OnStepStart 每个步骤开始前调用，command为展开变量后的命令
+	OnStepStart func(index int, step Step, command string)
+	// OnStepDone 每个步骤结束后调用
+	OnStepDone func(result StepResult)
+}
+
+// StepResult 单个步骤的执行结果
+type StepResult struct {
+	Index      int           `json:"index"`           // 步骤序号，从1开始
+	Name       string        `json:"name"`            // 步骤名称
+	Kind       string        `json:"kind"`            // 步骤类型
+	Command    string        `json:"command"`         // 展开变量后的命令
+	Status     string        `json:"status"`          // 执行状态
+	ExitCode   int           `json:"exit_code"`       // 退出码
+	Duration   time.Duration `json:"-"`               // 耗时
+	DurationMs int64         `json:"duration_ms"`     // 耗时（毫秒）
+	Error      string        `json:"error,omitempty"` // 错误信息
+}
+
+// RunSummary 任务执行汇总
+type RunSummary struct {
+	Name       string        `json:"name"`        // 任务名称
+	Steps      []StepResult  `json:"steps"`       // 各步骤结果
+	Succeeded  int           `json:"succeeded"`   // 成功的步骤数
+	Failed     int           `json:"failed"`      // 失败的步骤数
+	Skipped    int           `json:"skipped"`     // 跳过的步骤数
+	Duration   time.Duration `json:"-"`           // 总耗时
+	DurationMs int64         `json:"duration_ms"` // 总耗时（毫秒）
+}
+
+// FirstFailure 返回第一个失败的步骤，全部成功时返回nil
+func (s *RunSummary) FirstFailure() *StepResult {
+	for i := range s.Steps {
+		if s.Steps[i].Status == StepStatusFailed {
+			return &s.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Run 依次执行任务文件中的步骤
+//
+// 步骤失败时，除非该步骤或任务文件设置了continue_on_error，后续步骤都标记为跳过。
+// 返回的错误只表示任务无法开始执行（例如变量未定义），步骤失败记录在汇总中。
+func Run(file *TaskFile, options RunOptions) (*RunSummary, error) {
+	depth, _ := strconv.Atoi(os.Getenv(DepthEnv))
+	if depth >= maxDepth {
+		return nil, errs.InvalidInput("任务嵌套层数超过 %d 层，请检查任务文件是否递归调用自身", maxDepth)
+	}
+	if options.Stdout == nil {
+		options.Stdout = os.Stdout
+	}
+	if options.Stderr == nil {
+		options.Stderr = os.Stderr
+	}
+
+	vars := make(map[string]string)
+	for name, value := range file.Vars {
+		vars[name] = value
+	}
+	for name, value := range options.Vars {
+		vars[name] = value
+	}
+
+	// 执行前展开所有步骤的变量，未定义的变量在开始前就报告
+	steps := make([]Step, len(file.Steps))
+	for i, step := range file.Steps {
+		expanded, err := expandStep(step, vars)
+		if err != nil {
+			return nil, errs.InvalidInput("第 %d 个步骤: %v", i+1, err)
+		}
+		steps[i] = expanded
+	}
+	env := os.Environ()
+	for name, value := range file.Env {
+		expanded, err := expand(value, vars)
+		if err != nil {
+			return nil, errs.InvalidInput("环境变量 %s: %v", name, err)
+		}
+		env = append(env, name+"="+expanded)
+	}
+	env = append(env, fmt.Sprintf("%s=%d", DepthEnv, depth+1))
+
+	summary := &RunSummary{Name: file.Name}
+	startTime := time.Now()
+	stopped := false
+
+	for i, step := range steps {
+		result := StepResult{
+			Index:   i + 1,
+			Name:    step.Label(),
+			Kind:    step.Kind(),
+			Command: commandLine(step),
+		}
+
+		if stopped {
+			result.Status = StepStatusSkipped
+			summary.Skipped++
+			summary.Steps = append(summary.Steps, result)
+			continue
+		}
+
+		if options.OnStepStart != nil {
+			options.OnStepStart(result.Index, step, result.Command)
+		}
+
+		stepStart := time.Now()
+		err := runStep(step, env, options)
+		result.Duration = time.Since(stepStart)
+		result.DurationMs = result.Duration.Milliseconds()
+
+		if err != nil {
+			result.Status = StepStatusFailed
+			result.ExitCode = errs.ExitCode(err)
+			result.Error = err.Error()
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+				result.ExitCode = exitErr.ExitCode()
+				result.Error = i18n.Tf("退出码 %d", result.ExitCode)
+			}
+			summary.Failed++
+
+			continueOnError := file.ContinueOnError || options.ContinueOnError
+			if step.ContinueOnError != nil {
+				continueOnError = *step.ContinueOnError || options.ContinueOnError
+			}
+			stopped = !continueOnError
+		} else {
+			result.Status = StepStatusOK
+			summary.Succeeded++
+		}
+
+		if options.OnStepDone != nil {
+			options.OnStepDone(result)
+		}
+		summary.Steps = append(summary.Steps, result)
+	}
+
+	summary.Duration = time.Since(startTime)
+	summary.DurationMs = summary.Duration.Milliseconds()
+	return summary, nil
+}
+
+// runStep 执行单个步骤
+func runStep(step Step, env []string, options RunOptions) error {
+	ctx := context.Background()
+	if step.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, step.Timeout)
+		defer cancel()
+	}
+
+	var cmd *exec.Cmd
+	if step.Kind() == StepKindShell {
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", step.Shell)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", step.Shell)
+		}
+	} else {
+		args, err := splitArgs(step.Run)
+		if err != nil {
+			return err
+		}
+		args = append(append(append([]string{}, options.GlobalArgs...), args...), step.Args...)
+		cmd = exec.CommandContext(ctx, options.Executable, args...)
+	}
+
+	cmd.Dir = step.Dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = options.Stdout
+	cmd.Stderr = options.Stderr
+	cmd.Env = env
+	for name, value := range step.Env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return errs.Timeout("步骤执行超过 %s", step.Timeout)
+	}
+	return err
+}
+
+// expandStep 展开步骤中引用的变量
+func expandStep(step Step, vars map[string]string) (Step, error) {
+	var err error
+	expandField := func(value string) string {
+		if err != nil {
+			return value
+		}
+		var expanded string
+		expanded, err = expand(value, vars)
+		return expanded
+	}
+
+	step.Name = expandField(step.Name)
+	step.Run = expandField(step.Run)
+	step.Shell = expandField(step.Shell)
+	step.Dir = expandField(step.Dir)
+
+	args := make([]string, len(step.Args))
+	for i, arg := range step.Args {
+		args[i] = expandField(arg)
+	}
+	step.Args = args
+
+	env := make(map[string]string, len(step.Env))
+	for name, value := range step.Env {
+		env[name] = expandField(value)
+	}
+	step.Env = env
+
+	return step, err
+}
+
+// expand 将 ${name} 替换为变量值，变量未定义时使用同名环境变量
+func expand(value string, vars map[string]string) (string, error) {
+	var missing string
+	expanded := varPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := varPattern.FindStringSubmatch(ref)[1]
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		if missing == "" {
+			missing = name
+		}
+		return ref
+	})
+	if missing != "" {
+		return "", i18n.Errorf("未定义的变量: %s", missing)
+	}
+	return expanded, nil
+}
+
+// commandLine 返回步骤的命令行，用于显示
+func commandLine(step Step) string {
+	if step.Kind() == StepKindShell {
+		return step.Shell
+	}
+	parts := []string{strings.TrimSpace(step.Run)}
+	for _, arg := range step.Args {
+		parts = append(parts, quoteArg(arg))
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// quoteArg 为包含空白或引号的参数加上引号
+func quoteArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"'\\") {
+		return arg
+	}
+	return strconv.Quote(arg)
+}
+
+// splitArgs 按shell规则拆分命令行，支持单引号、双引号和反斜杠转义
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`, runes[i+1]) {
+				i++
+				current.WriteRune(runes[i])
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errs.InvalidInput("命令中的引号不匹配: %s", line)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,44 @@
+// Package termcolor 管理终端彩色输出的全局开关
+//
+// 各工具包在输出颜色前通过 Enabled 判断是否启用颜色，命令行根据 --no-color 标志
+// 和 NO_COLOR、CLICOLOR、CLICOLOR_FORCE 环境变量统一设置，避免每个命令各自处理。
+// 底层使用 github.com/fatih/color 的全局开关，因此直接使用该库输出的颜色同样受控。
+package termcolor
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// Enabled 返回是否输出ANSI颜色
+func Enabled() bool {
+	return !color.NoColor
+}
+
+// SetEnabled 启用或禁用彩色输出
+func SetEnabled(enabled bool) {
+	color.NoColor = !enabled
+}
+
+// FromEnv 根据环境变量和标准输出类型判断是否应启用颜色
+//
+// 判断顺序：
+//  1. NO_COLOR 非空时禁用（https://no-color.org）
+//  2. CLICOLOR_FORCE 非空且不为0时启用，即使输出不是终端
+//  3. CLICOLOR=0 或 TERM=dumb 时禁用
+//  4. 其余情况仅在标准输出为终端时启用
+func FromEnv() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	if os.Getenv("CLICOLOR") == "0" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
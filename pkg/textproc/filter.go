@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // FilterOptions 定义文本过滤的配置选项
@@ -42,7 +44,7 @@ const (
 // ExecuteFilter 执行文本过滤操作
 func ExecuteFilter(input io.Reader, output io.Writer, options FilterOptions) (FilterResult, error) {
 	if options.Expression == "" {
-		return FilterResult{}, errors.New("必须指定过滤表达式")
+		return FilterResult{}, errors.New(i18n.T("必须指定过滤表达式"))
 	}
 
 	scanner := bufio.NewScanner(input)
@@ -56,7 +58,7 @@ func ExecuteFilter(input io.Reader, output io.Writer, options FilterOptions) (Fi
 		fields := parseFields(line, options.FieldSep)
 		match, err := evaluateExpression(options.Expression, line, fields)
 		if err != nil {
-			return result, fmt.Errorf("行 %d: %v", result.LinesProcessed, err)
+			return result, i18n.Errorf("行 %d: %v", result.LinesProcessed, err)
 		}
 
 		if match {
@@ -65,7 +67,7 @@ func ExecuteFilter(input io.Reader, output io.Writer, options FilterOptions) (Fi
 				// 应用打印模式
 				formattedOutput, err := applyPrintPattern(options.PrintPattern, line, fields)
 				if err != nil {
-					return result, fmt.Errorf("应用打印模式时出错：%v", err)
+					return result, i18n.Errorf("应用打印模式时出错：%v", err)
 				}
 				fmt.Fprintln(output, formattedOutput)
 			} else {
@@ -75,7 +77,7 @@ func ExecuteFilter(input io.Reader, output io.Writer, options FilterOptions) (Fi
 	}
 
 	if err := scanner.Err(); err != nil {
-		return result, fmt.Errorf("读取输入时出错：%v", err)
+		return result, errs.Wrap(err, "读取输入时出错：%v", err)
 	}
 
 	return result, nil
@@ -142,7 +144,7 @@ func evaluateExpression(expr, line string, fields []string) (bool, error) {
 			length := len(fieldValue)
 			value, err := strconv.Atoi(valueStr)
 			if err != nil {
-				return false, fmt.Errorf("无效的长度比较值：%s", valueStr)
+				return false, errs.InvalidInput("无效的长度比较值：%s", valueStr)
 			}
 
 			switch op {
@@ -159,10 +161,10 @@ func evaluateExpression(expr, line string, fields []string) (bool, error) {
 			case opLessEq:
 				return length <= value, nil
 			default:
-				return false, fmt.Errorf("不支持的长度比较操作符：%s", op)
+				return false, errs.InvalidInput("不支持的长度比较操作符：%s", op)
 			}
 		}
-		return false, fmt.Errorf("无效的length表达式：%s", expr)
+		return false, errs.InvalidInput("无效的length表达式：%s", expr)
 	}
 
 	// 解析基本的比较表达式
@@ -181,7 +183,7 @@ func evaluateExpression(expr, line string, fields []string) (bool, error) {
 
 		regex, err := regexp.Compile(valueExpr)
 		if err != nil {
-			return false, fmt.Errorf("无效的正则表达式：%s", valueExpr)
+			return false, errs.InvalidInput("无效的正则表达式：%s", valueExpr)
 		}
 
 		if op == opMatches {
@@ -202,7 +204,7 @@ func evaluateExpression(expr, line string, fields []string) (bool, error) {
 	}
 
 	if op == "" {
-		return false, fmt.Errorf("无效的表达式：%s", expr)
+		return false, errs.InvalidInput("无效的表达式：%s", expr)
 	}
 
 	fieldValue, err := getFieldValue(fieldExpr, line, fields)
@@ -266,7 +268,7 @@ func evaluateExpression(expr, line string, fields []string) (bool, error) {
 		}
 	}
 
-	return false, fmt.Errorf("不支持的操作符：%s", op)
+	return false, errs.InvalidInput("不支持的操作符：%s", op)
 }
 
 // getFieldValue 从字段列表中获取指定字段的值
@@ -278,11 +280,11 @@ func getFieldValue(fieldExpr, line string, fields []string) (string, error) {
 	if strings.HasPrefix(fieldExpr, "$") {
 		fieldIndex, err := strconv.Atoi(fieldExpr[1:])
 		if err != nil {
-			return "", fmt.Errorf("无效的字段索引：%s", fieldExpr)
+			return "", errs.InvalidInput("无效的字段索引：%s", fieldExpr)
 		}
 
 		if fieldIndex < 1 || fieldIndex > len(fields) {
-			return "", fmt.Errorf("字段索引超出范围：%d（总字段数：%d）", fieldIndex, len(fields))
+			return "", i18n.Errorf("字段索引超出范围：%d（总字段数：%d）", fieldIndex, len(fields))
 		}
 
 		return fields[fieldIndex-1], nil
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 
 	"github.com/fatih/color"
 )
@@ -49,7 +51,7 @@ func ExecuteGrep(input io.Reader, output io.Writer, options GrepOptions, sourceN
 	}
 	re, err := regexp.Compile(regexpOpt + options.Pattern)
 	if err != nil {
-		return result, fmt.Errorf("无效的正则表达式: %v", err)
+		return result, errs.InvalidInput("无效的正则表达式: %v", err)
 	}
 
 	// 用于存储匹配结果的行和上下文
@@ -80,7 +82,7 @@ func ExecuteGrep(input io.Reader, output io.Writer, options GrepOptions, sourceN
 	}
 
 	if scanner.Err() != nil {
-		return result, fmt.Errorf("读取错误: %v", scanner.Err())
+		return result, i18n.Errorf("读取错误: %v", scanner.Err())
 	}
 
 	result.TotalLines = lineNum
@@ -155,7 +157,7 @@ func GrepDirectory(dir string, output io.Writer, options GrepOptions) (GrepResul
 	if options.FilePattern != "" {
 		fileRe, err = regexp.Compile(options.FilePattern)
 		if err != nil {
-			return result, fmt.Errorf("无效的文件模式: %v", err)
+			return result, errs.InvalidInput("无效的文件模式: %v", err)
 		}
 	}
 
@@ -165,7 +167,7 @@ func GrepDirectory(dir string, output io.Writer, options GrepOptions) (GrepResul
 	// 遍历目录
 	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			fmt.Fprintf(output, "警告: 访问 %s 时出错: %v\n", path, err)
+			fmt.Fprint(output, i18n.Tf("警告: 访问 %s 时出错: %v\n", path, err))
 			return nil // 继续处理其他文件
 		}
 
@@ -186,7 +188,7 @@ func GrepDirectory(dir string, output io.Writer, options GrepOptions) (GrepResul
 		// 打开文件
 		file, err := os.Open(path)
 		if err != nil {
-			fmt.Fprintf(output, "警告: 无法打开 %s: %v\n", path, err)
+			fmt.Fprint(output, i18n.Tf("警告: 无法打开 %s: %v\n", path, err))
 			return nil // 继续处理其他文件
 		}
 		defer file.Close()
@@ -199,7 +201,7 @@ func GrepDirectory(dir string, output io.Writer, options GrepOptions) (GrepResul
 		}
 		fileResult, err := ExecuteGrep(file, output, tempOptions, path)
 		if err != nil {
-			fmt.Fprintf(output, "警告: 处理 %s 时出错: %v\n", path, err)
+			fmt.Fprint(output, i18n.Tf("警告: 处理 %s 时出错: %v\n", path, err))
 			return nil
 		}
 
@@ -218,12 +220,12 @@ func GrepDirectory(dir string, output io.Writer, options GrepOptions) (GrepResul
 	})
 
 	if err != nil {
-		return result, fmt.Errorf("遍历目录错误: %v", err)
+		return result, i18n.Errorf("遍历目录错误: %v", err)
 	}
 
 	// 打印总结
 	if options.OnlyCount {
-		fmt.Fprintf(output, "\n共找到 %d 个匹配项，在 %d 个文件中\n", result.Matches, result.MatchedFiles)
+		fmt.Fprint(output, i18n.Tf("\n共找到 %d 个匹配项，在 %d 个文件中\n", result.Matches, result.MatchedFiles))
 	}
 
 	return result, nil
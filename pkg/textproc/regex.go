@@ -0,0 +1,315 @@
+package textproc
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
+	"unicode/utf8"
+)
+
+// RegexOptions 定义了正则测试的选项
+type RegexOptions struct {
+	Pattern    string
+	IgnoreCase bool // 忽略大小写，(?i)
+	Multiline  bool // ^ 和 $ 匹配每行的开头和结尾，(?m)
+	DotAll     bool // . 匹配换行符，(?s)
+	MaxMatches int  // 最多返回的匹配数，0 表示不限制
+}
+
+// RegexGroup 一个捕获组的匹配结果
+type RegexGroup struct {
+	Index   int    `json:"index"`          // 捕获组序号，从1开始
+	Name    string `json:"name,omitempty"` // 命名捕获组的名称
+	Matched bool   `json:"matched"`        // 是否参与了匹配
+	Text    string `json:"text"`           // 匹配的文本
+	Start   int    `json:"start"`          // 起始字节偏移
+	End     int    `json:"end"`            // 结束字节偏移
+}
+
+// RegexMatch 一次匹配的结果
+type RegexMatch struct {
+	Line   int          `json:"line"`   // 匹配开始的行号，从1开始
+	Column int          `json:"column"` // 匹配开始的列号（按字符计），从1开始
+	Start  int          `json:"start"`  // 起始字节偏移
+	End    int          `json:"end"`    // 结束字节偏移
+	Text   string       `json:"text"`   // 匹配的文本
+	Groups []RegexGroup `json:"groups"` // 捕获组
+}
+
+// RegexTestResult 存储正则测试的结果
+type RegexTestResult struct {
+	Pattern   string       `json:"pattern"`   // 实际使用的正则表达式（包含标志）
+	Matches   []RegexMatch `json:"matches"`   // 匹配结果
+	Truncated bool         `json:"truncated"` // 是否因达到 MaxMatches 而截断
+}
+
+// RegexPart 正则表达式中的一个组成部分
+type RegexPart struct {
+	Depth       int    `json:"depth"`       // 在语法树中的深度
+	Pattern     string `json:"pattern"`     // 该部分对应的正则表达式
+	Description string `json:"description"` // 说明
+}
+
+// CompileRegex 按选项添加标志并编译正则表达式
+func CompileRegex(options RegexOptions) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(regexFlags(options) + options.Pattern)
+	if err != nil {
+		return nil, errs.InvalidInput("无效的正则表达式: %v", err)
+	}
+	return re, nil
+}
+
+// regexFlags 返回选项对应的标志前缀，如 (?im)
+func regexFlags(options RegexOptions) string {
+	var flags string
+	if options.IgnoreCase {
+		flags += "i"
+	}
+	if options.Multiline {
+		flags += "m"
+	}
+	if options.DotAll {
+		flags += "s"
+	}
+	if flags == "" {
+		return ""
+	}
+	return "(?" + flags + ")"
+}
+
+// TestRegex 在整段文本中查找所有匹配，返回位置和捕获组
+func TestRegex(input string, options RegexOptions) (RegexTestResult, error) {
+	re, err := CompileRegex(options)
+	if err != nil {
+		return RegexTestResult{}, err
+	}
+	result := RegexTestResult{Pattern: re.String(), Matches: []RegexMatch{}}
+
+	limit := -1
+	if options.MaxMatches > 0 {
+		// 多查找一个以判断是否截断
+		limit = options.MaxMatches + 1
+	}
+	names := re.SubexpNames()
+
+	// 逐步计算行列号，避免每次匹配都从头扫描
+	line, lineStart, scanned := 1, 0, 0
+	for _, loc := range re.FindAllStringSubmatchIndex(input, limit) {
+		if options.MaxMatches > 0 && len(result.Matches) == options.MaxMatches {
+			result.Truncated = true
+			break
+		}
+
+		start, end := loc[0], loc[1]
+		for i := scanned; i < start; i++ {
+			if input[i] == '\n' {
+				line++
+				lineStart = i + 1
+			}
+		}
+		scanned = start
+
+		match := RegexMatch{
+			Line:   line,
+			Column: utf8.RuneCountInString(input[lineStart:start]) + 1,
+			Start:  start,
+			End:    end,
+			Text:   input[start:end],
+			Groups: make([]RegexGroup, 0, len(names)-1),
+		}
+		for i := 1; i < len(names); i++ {
+			group := RegexGroup{Index: i, Name: names[i], Start: loc[2*i], End: loc[2*i+1]}
+			if group.Start >= 0 {
+				group.Matched = true
+				group.Text = input[group.Start:group.End]
+			}
+			match.Groups = append(match.Groups, group)
+		}
+		result.Matches = append(result.Matches, match)
+	}
+	return result, nil
+}
+
+// ExplainRegex 将正则表达式拆分为带说明的组成部分，按语法树的先序排列
+func ExplainRegex(options RegexOptions) ([]RegexPart, error) {
+	re, err := syntax.Parse(regexFlags(options)+options.Pattern, syntax.Perl)
+	if err != nil {
+		return nil, errs.InvalidInput("无效的正则表达式: %v", err)
+	}
+
+	var parts []RegexPart
+	var walk func(node *syntax.Regexp, depth int)
+	walk = func(node *syntax.Regexp, depth int) {
+		parts = append(parts, RegexPart{
+			Depth:       depth,
+			Pattern:     plainPattern(node),
+			Description: describeNode(node),
+		})
+		for _, sub := range node.Sub {
+			walk(sub, depth+1)
+		}
+	}
+	walk(re, 0)
+	return parts, nil
+}
+
+// plainPattern 返回节点对应的正则表达式，省略 String 自动附加的 (?i:...) 等标志，
+// 忽略大小写等信息已在说明中体现
+func plainPattern(node *syntax.Regexp) string {
+	var clear func(n *syntax.Regexp) *syntax.Regexp
+	clear = func(n *syntax.Regexp) *syntax.Regexp {
+		c := *n
+		c.Flags &= syntax.NonGreedy
+		c.Sub = make([]*syntax.Regexp, len(n.Sub))
+		for i, sub := range n.Sub {
+			c.Sub[i] = clear(sub)
+		}
+		return &c
+	}
+	pattern := clear(node).String()
+	// 含有 . 的部分仍会被包在 (?-s:...) 中
+	if strings.HasPrefix(pattern, "(?-s:") && strings.HasSuffix(pattern, ")") {
+		pattern = pattern[len("(?-s:") : len(pattern)-1]
+	}
+	return pattern
+}
+
+// describeNode 返回语法树节点的中文说明
+func describeNode(node *syntax.Regexp) string {
+	switch node.Op {
+	case syntax.OpNoMatch:
+		return i18n.T("不匹配任何内容")
+	case syntax.OpEmptyMatch:
+		return i18n.T("空匹配")
+	case syntax.OpLiteral:
+		desc := i18n.T("字面量 ") + strconv.Quote(string(node.Rune))
+		if node.Flags&syntax.FoldCase != 0 {
+			desc += i18n.T("（忽略大小写）")
+		}
+		return desc
+	case syntax.OpCharClass:
+		return describeCharClass(node.Rune)
+	case syntax.OpAnyCharNotNL:
+		return i18n.T("除换行符外的任意字符")
+	case syntax.OpAnyChar:
+		return i18n.T("任意字符（包括换行符）")
+	case syntax.OpBeginLine:
+		return i18n.T("行首")
+	case syntax.OpEndLine:
+		return i18n.T("行尾")
+	case syntax.OpBeginText:
+		return i18n.T("文本开头")
+	case syntax.OpEndText:
+		if node.Flags&syntax.WasDollar != 0 {
+			return i18n.T("文本结尾（$）")
+		}
+		return i18n.T("文本结尾")
+	case syntax.OpWordBoundary:
+		return i18n.T("单词边界")
+	case syntax.OpNoWordBoundary:
+		return i18n.T("非单词边界")
+	case syntax.OpCapture:
+		if node.Name != "" {
+			return i18n.Tf("第%d个捕获组，名称为 %s", node.Cap, node.Name)
+		}
+		return i18n.Tf("第%d个捕获组", node.Cap)
+	case syntax.OpStar:
+		return i18n.T("重复0次或多次") + greediness(node)
+	case syntax.OpPlus:
+		return i18n.T("重复1次或多次") + greediness(node)
+	case syntax.OpQuest:
+		return i18n.T("可选，出现0次或1次") + greediness(node)
+	case syntax.OpRepeat:
+		switch {
+		case node.Max == -1:
+			return i18n.Tf("重复至少%d次", node.Min) + greediness(node)
+		case node.Min == node.Max:
+			return i18n.Tf("重复%d次", node.Min)
+		default:
+			return i18n.Tf("重复%d到%d次", node.Min, node.Max) + greediness(node)
+		}
+	case syntax.OpConcat:
+		return i18n.T("依次匹配以下各部分")
+	case syntax.OpAlternate:
+		return i18n.T("匹配以下任一分支")
+	}
+	return node.Op.String()
+}
+
+// greediness 返回重复的匹配方式
+func greediness(node *syntax.Regexp) string {
+	if node.Flags&syntax.NonGreedy != 0 {
+		return i18n.T("（非贪婪，尽量少）")
+	}
+	return i18n.T("（贪婪，尽量多）")
+}
+
+// knownClasses 常见字符类的说明，键为字符范围的写法，取反的字符类以 ^ 开头
+var knownClasses = map[string]string{
+	"0-9":          "数字",
+	"^0-9":         "非数字",
+	"0-9A-Z_a-z":   "单词字符（字母、数字、下划线）",
+	"^0-9A-Z_a-z":  "非单词字符",
+	"\t-\n\f-\r ":  "空白字符",
+	"^\t-\n\f-\r ": "非空白字符",
+	"A-Za-z":       "英文字母",
+	"a-z":          "小写字母",
+	"A-Z":          "大写字母",
+	"0-9A-Fa-f":    "十六进制数字",
+}
+
+// describeCharClass 说明字符类，ranges 为成对的起止字符
+//
+// 取反的字符类在语法树中表示为补集，这里还原后按“不是…”说明。
+func describeCharClass(ranges []rune) string {
+	negated := len(ranges) > 0 && ranges[0] == 0 && ranges[len(ranges)-1] == utf8.MaxRune
+	shown, prefix := ranges, i18n.T("以下字符之一: ")
+	if negated {
+		shown, prefix = complement(ranges), i18n.T("不是以下字符之一: ")
+	}
+
+	var key strings.Builder
+	if negated {
+		key.WriteByte('^')
+	}
+	list := make([]string, 0, len(shown)/2)
+	for i := 0; i+1 < len(shown); i += 2 {
+		lo, hi := shown[i], shown[i+1]
+		key.WriteRune(lo)
+		if hi != lo {
+			key.WriteByte('-')
+			key.WriteRune(hi)
+		}
+		list = append(list, quoteRange(lo, hi))
+	}
+	if desc, ok := knownClasses[key.String()]; ok {
+		return i18n.T(desc)
+	}
+	return prefix + strings.Join(list, " ")
+}
+
+// complement 返回覆盖全部字符的取反字符类中被排除的范围
+func complement(ranges []rune) []rune {
+	var gaps []rune
+	for i := 1; i+1 < len(ranges); i += 2 {
+		gaps = append(gaps, ranges[i]+1, ranges[i+1]-1)
+	}
+	return gaps
+}
+
+// quoteRange 以易读的形式输出字符范围，如 a-z 或单个字符
+func quoteRange(lo, hi rune) string {
+	if lo == hi {
+		return printableRune(lo)
+	}
+	return printableRune(lo) + "-" + printableRune(hi)
+}
+
+// printableRune 将不可见字符转义显示
+func printableRune(r rune) string {
+	quoted := strconv.QuoteRune(r)
+	return quoted[1 : len(quoted)-1]
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"toolbox/pkg/errs"
+	"toolbox/pkg/i18n"
 )
 
 // ReplaceOptions 定义了replace命令的选项
@@ -34,7 +36,7 @@ func ExecuteReplace(input io.Reader, output io.Writer, options ReplaceOptions) (
 	}
 	re, err := regexp.Compile(regexpOpt + options.Pattern)
 	if err != nil {
-		return result, fmt.Errorf("无效的正则表达式: %v", err)
+		return result, errs.InvalidInput("无效的正则表达式: %v", err)
 	}
 
 	for scanner.Scan() {
@@ -64,7 +66,7 @@ func ExecuteReplace(input io.Reader, output io.Writer, options ReplaceOptions) (
 	}
 
 	if scanner.Err() != nil {
-		return result, fmt.Errorf("读取错误: %v", scanner.Err())
+		return result, i18n.Errorf("读取错误: %v", scanner.Err())
 	}
 
 	return result, nil
@@ -92,4 +94,4 @@ func CopyFile(src, dst string) error {
 
 	_, err = io.Copy(dstFile, srcFile)
 	return err
-} 
\ No newline at end of file
+}
@@ -0,0 +1,82 @@
+package timeconv
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	// isoPattern 形如 2024-01-02T15:04:05.123+08:00、2024/01/02 15:04:05,123 的时间
+	isoPattern = regexp.MustCompile(`(\d{4})[-/](\d{2})[-/](\d{2})[T ](\d{2}):(\d{2}):(\d{2})(?:[.,](\d{1,9}))?(?: ?(Z|[+-]\d{2}:?\d{2})\b)?`)
+	// clfPattern Apache/Nginx访问日志中的时间，如 02/Jan/2006:15:04:05 -0700
+	clfPattern = regexp.MustCompile(`\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`)
+	// syslogPattern 行首的syslog时间，如 Jan  2 15:04:05
+	syslogPattern = regexp.MustCompile(`^[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}`)
+)
+
+// Extract 在一行日志中查找时间，有多处时取最靠前的一处
+//
+// 识别ISO 8601及其常见变体、访问日志格式和syslog格式，不含时区的时间按loc解释。
+// syslog时间不含年份，晚于当前时间一天以上时视为去年的日志。
+func Extract(line string, loc *time.Location) (time.Time, bool) {
+	var best time.Time
+	pos := -1
+	if m := isoPattern.FindStringSubmatchIndex(line); m != nil {
+		if t, ok := parseISO(line, m, loc); ok {
+			best, pos = t, m[0]
+		}
+	}
+	if m := clfPattern.FindStringIndex(line); m != nil && (pos < 0 || m[0] < pos) {
+		if t, err := time.Parse("02/Jan/2006:15:04:05 -0700", line[m[0]:m[1]]); err == nil {
+			best, pos = t, m[0]
+		}
+	}
+	if m := syslogPattern.FindString(line); m != "" {
+		if t, err := time.ParseInLocation(time.Stamp, m, loc); err == nil {
+			now := time.Now().In(loc)
+			t = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+			if t.After(now.Add(24 * time.Hour)) {
+				t = t.AddDate(-1, 0, 0)
+			}
+			best, pos = t, 0
+		}
+	}
+	return best, pos >= 0
+}
+
+// parseISO 根据 isoPattern 的子匹配位置构造时间
+func parseISO(line string, m []int, loc *time.Location) (time.Time, bool) {
+	group := func(i int) string {
+		if m[2*i] < 0 {
+			return ""
+		}
+		return line[m[2*i]:m[2*i+1]]
+	}
+	var v [6]int
+	for i := range v {
+		v[i], _ = strconv.Atoi(group(i + 1))
+	}
+	if v[1] < 1 || v[1] > 12 || v[2] < 1 || v[2] > 31 || v[3] > 23 || v[4] > 59 || v[5] > 60 {
+		return time.Time{}, false
+	}
+
+	nanos := 0
+	if frac := group(7); frac != "" {
+		nanos, _ = strconv.Atoi(frac)
+		for i := len(frac); i < 9; i++ {
+			nanos *= 10
+		}
+	}
+	switch zone := group(8); {
+	case zone == "Z":
+		loc = time.UTC
+	case zone != "":
+		seconds, ok := parseOffset(zone)
+		if !ok {
+			return time.Time{}, false
+		}
+		loc = time.FixedZone("", seconds)
+	}
+	return time.Date(v[0], time.Month(v[1]), v[2], v[3], v[4], v[5], nanos, loc), true
+}
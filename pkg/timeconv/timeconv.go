@@ -0,0 +1,220 @@
+// Package timeconv 在时间戳、常见时间格式和时区之间转换
+//
+// 主要用于阅读日志：识别秒/毫秒/微秒/纳秒时间戳和日志中常见的时间写法，
+// 并按多种格式和时区输出。
+package timeconv
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+)
+
+// 时间戳单位
+const (
+	UnitAuto        = "auto"
+	UnitSecond      = "s"
+	UnitMillisecond = "ms"
+	UnitMicrosecond = "us"
+	UnitNanosecond  = "ns"
+)
+
+// Formatted 一种格式下的时间文本
+type Formatted struct {
+	Name  string `json:"name"`  // 格式名称，如 rfc3339、unix_ms
+	Value string `json:"value"` // 格式化后的文本
+}
+
+// layout 可识别的时间格式
+type layout struct {
+	name   string
+	layout string
+}
+
+// layouts 按顺序尝试的时间格式
+var layouts = []layout{
+	{"rfc3339", time.RFC3339Nano},
+	{"datetime", "2006-01-02 15:04:05.999999999Z07:00"},
+	{"datetime", "2006-01-02 15:04:05.999999999 -0700"},
+	{"datetime", "2006-01-02 15:04:05.999999999 MST"},
+	{"datetime", "2006-01-02T15:04:05.999999999"},
+	{"datetime", "2006-01-02 15:04:05.999999999"},
+	{"datetime", "2006-01-02 15:04:05,999999999"},
+	{"datetime", "2006/01/02 15:04:05.999999999"},
+	{"datetime", "2006-01-02 15:04"},
+	{"date", "2006-01-02"},
+	{"date", "2006/01/02"},
+	{"rfc1123", time.RFC1123Z},
+	{"rfc1123", time.RFC1123},
+	{"rfc850", time.RFC850},
+	{"rfc822", time.RFC822Z},
+	{"rfc822", time.RFC822},
+	{"ansic", time.ANSIC},
+	{"unixdate", time.UnixDate},
+	{"rubydate", time.RubyDate},
+	{"clf", "02/Jan/2006:15:04:05 -0700"},
+	{"syslog", time.Stamp},
+}
+
+var epochPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Parse 解析时间文本，返回时间和识别出的格式名称
+//
+// 支持 now、Unix时间戳（unit 为 auto 时按位数判断单位：11位以内为秒，12-14位为毫秒，
+// 15-17位为微秒，更长为纳秒；带小数时按秒）以及RFC3339、常见日志格式等。
+// 不含时区的时间按loc解释；syslog格式不含年份，使用当前年份。
+func Parse(text, unit string, loc *time.Location) (time.Time, string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return time.Time{}, "", errs.InvalidInput("时间不能为空")
+	}
+	if strings.EqualFold(text, "now") {
+		return time.Now().In(loc), "now", nil
+	}
+
+	if epochPattern.MatchString(text) {
+		t, name, err := parseEpoch(text, unit)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		return t.In(loc), name, nil
+	}
+
+	for _, l := range layouts {
+		t, err := time.ParseInLocation(l.layout, text, loc)
+		if err != nil {
+			continue
+		}
+		if l.name == "syslog" {
+			t = time.Date(time.Now().In(loc).Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+		}
+		return t, l.name, nil
+	}
+	return time.Time{}, "", errs.InvalidInput("无法识别的时间: %s（支持Unix时间戳、RFC3339、2006-01-02 15:04:05 等格式）", text)
+}
+
+// parseEpoch 解析Unix时间戳
+func parseEpoch(text, unit string) (time.Time, string, error) {
+	if strings.Contains(text, ".") {
+		if unit != UnitAuto && unit != UnitSecond {
+			return time.Time{}, "", errs.InvalidInput("带小数的时间戳只能以秒为单位: %s", text)
+		}
+		seconds, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return time.Time{}, "", errs.InvalidInput("时间戳无效: %s", text)
+		}
+		whole, frac := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(math.Round(frac*1e9))), "unix", nil
+	}
+
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return time.Time{}, "", errs.InvalidInput("时间戳超出范围: %s", text)
+	}
+	if unit == UnitAuto {
+		unit = detectUnit(strings.TrimPrefix(text, "-"))
+	}
+	switch unit {
+	case UnitSecond:
+		return time.Unix(value, 0), "unix", nil
+	case UnitMillisecond:
+		return time.UnixMilli(value), "unix_ms", nil
+	case UnitMicrosecond:
+		return time.UnixMicro(value), "unix_us", nil
+	case UnitNanosecond:
+		return time.Unix(0, value), "unix_ns", nil
+	}
+	return time.Time{}, "", errs.InvalidInput("不支持的时间戳单位: %s（支持 s、ms、us、ns）", unit)
+}
+
+// detectUnit 按位数判断时间戳的单位
+func detectUnit(digits string) string {
+	switch n := len(digits); {
+	case n <= 11:
+		return UnitSecond
+	case n <= 14:
+		return UnitMillisecond
+	case n <= 17:
+		return UnitMicrosecond
+	}
+	return UnitNanosecond
+}
+
+// LoadLocation 加载时区，支持 local、utc、IANA名称（如 Asia/Shanghai）
+// 以及固定偏移（如 +08:00、-0530、UTC+8）
+func LoadLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc", "z", "gmt":
+		return time.UTC, nil
+	}
+
+	offset := strings.TrimPrefix(strings.TrimPrefix(strings.ToUpper(name), "UTC"), "GMT")
+	if offset != "" && (offset[0] == '+' || offset[0] == '-') {
+		if seconds, ok := parseOffset(offset); ok {
+			return time.FixedZone(name, seconds), nil
+		}
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errs.InvalidInput("未知的时区: %s", name)
+	}
+	return loc, nil
+}
+
+// parseOffset 解析 +8、+08、+0800、+08:00 形式的时区偏移，返回秒数
+func parseOffset(text string) (int, bool) {
+	sign := 1
+	if text[0] == '-' {
+		sign = -1
+	}
+	digits := strings.ReplaceAll(text[1:], ":", "")
+	var hours, minutes int
+	var err error
+	switch len(digits) {
+	case 1, 2:
+		hours, err = strconv.Atoi(digits)
+	case 3, 4:
+		hours, err = strconv.Atoi(digits[:len(digits)-2])
+		if err == nil {
+			minutes, err = strconv.Atoi(digits[len(digits)-2:])
+		}
+	default:
+		return 0, false
+	}
+	if err != nil || hours > 14 || minutes > 59 {
+		return 0, false
+	}
+	return sign * (hours*3600 + minutes*60), true
+}
+
+// Format 按常用格式输出时间，时区相关的格式使用t自带的时区
+func Format(t time.Time) []Formatted {
+	return []Formatted{
+		{"rfc3339", t.Format(time.RFC3339Nano)},
+		{"datetime", t.Format("2006-01-02 15:04:05.000 MST")},
+		{"utc", t.UTC().Format(time.RFC3339Nano)},
+		{"unix", strconv.FormatInt(t.Unix(), 10)},
+		{"unix_ms", strconv.FormatInt(t.UnixMilli(), 10)},
+		{"unix_us", strconv.FormatInt(t.UnixMicro(), 10)},
+		{"unix_ns", strconv.FormatInt(t.UnixNano(), 10)},
+		{"rfc1123", t.Format(time.RFC1123Z)},
+		{"iso_week", isoWeek(t)},
+	}
+}
+
+// isoWeek 返回ISO周，如 2024-W03-2
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	return fmt.Sprintf("%d-W%02d-%d", year, week, weekday)
+}
@@ -0,0 +1,203 @@
+// Package totp 生成基于时间的一次性密码（TOTP，RFC 6238），与 Google Authenticator 等验证器应用兼容
+//
+// 密钥可以是base32编码的字符串，也可以是验证器应用使用的 otpauth://totp/ URI，
+// 后者还包含发行方、账号、算法、位数和时间步长。
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+)
+
+// 默认参数，与大多数验证器应用一致
+const (
+	DefaultAlgorithm = "SHA1"
+	DefaultDigits    = 6
+	DefaultPeriod    = 30
+)
+
+// Key TOTP密钥及其参数
+type Key struct {
+	Secret    []byte
+	Issuer    string // 发行方，如 GitHub
+	Account   string // 账号，如 user@example.com
+	Algorithm string // SHA1、SHA256 或 SHA512
+	Digits    int    // 6-8
+	Period    int    // 时间步长（秒）
+}
+
+// NewKey 使用base32编码的密钥和默认参数创建Key
+func NewKey(secret string) (Key, error) {
+	raw, err := DecodeSecret(secret)
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{Secret: raw, Algorithm: DefaultAlgorithm, Digits: DefaultDigits, Period: DefaultPeriod}, nil
+}
+
+// DecodeSecret 解码base32密钥，忽略空格、连字符、大小写和末尾的填充
+func DecodeSecret(secret string) ([]byte, error) {
+	cleaned := strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "\t", "").Replace(secret))
+	cleaned = strings.TrimRight(cleaned, "=")
+	if cleaned == "" {
+		return nil, errs.InvalidInput("密钥不能为空")
+	}
+	raw, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(cleaned)
+	if err != nil {
+		return nil, errs.InvalidInput("密钥不是有效的base32编码: %v", err)
+	}
+	return raw, nil
+}
+
+// EncodeSecret 将密钥编码为不带填充的base32字符串
+func EncodeSecret(secret []byte) string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret)
+}
+
+// ParseURL 解析验证器应用使用的 otpauth://totp/发行方:账号?secret=...&issuer=... URI，
+// 未指定的参数使用默认值
+func ParseURL(rawURL string) (Key, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return Key{}, errs.InvalidInput("无效的URI: %v", err)
+	}
+	if u.Scheme != "otpauth" {
+		return Key{}, errs.InvalidInput("URI应以 otpauth:// 开头")
+	}
+	if u.Host != "totp" {
+		return Key{}, errs.InvalidInput("只支持 otpauth://totp/，不支持 %s", u.Host)
+	}
+
+	query := u.Query()
+	key, err := NewKey(query.Get("secret"))
+	if err != nil {
+		return Key{}, err
+	}
+
+	// 标签为 发行方:账号 或 账号
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		key.Issuer, key.Account = strings.TrimSpace(issuer), strings.TrimSpace(account)
+	} else {
+		key.Account = label
+	}
+	if issuer := query.Get("issuer"); issuer != "" {
+		key.Issuer = issuer
+	}
+	if algorithm := query.Get("algorithm"); algorithm != "" {
+		key.Algorithm = strings.ToUpper(algorithm)
+	}
+	if digits := query.Get("digits"); digits != "" {
+		if key.Digits, err = strconv.Atoi(digits); err != nil {
+			return Key{}, errs.InvalidInput("无效的digits参数: %s", digits)
+		}
+	}
+	if period := query.Get("period"); period != "" {
+		if key.Period, err = strconv.Atoi(period); err != nil {
+			return Key{}, errs.InvalidInput("无效的period参数: %s", period)
+		}
+	}
+	return key, key.Validate()
+}
+
+// Validate 检查算法、位数和时间步长
+func (k Key) Validate() error {
+	if _, err := k.hash(); err != nil {
+		return err
+	}
+	if k.Digits < 6 || k.Digits > 8 {
+		return errs.InvalidInput("位数必须在6到8之间: %d", k.Digits)
+	}
+	if k.Period <= 0 {
+		return errs.InvalidInput("时间步长必须大于0: %d", k.Period)
+	}
+	return nil
+}
+
+// hash 返回算法对应的哈希函数
+func (k Key) hash() (func() hash.Hash, error) {
+	switch strings.ToUpper(k.Algorithm) {
+	case "SHA1", "":
+		return sha1.New, nil
+	case "SHA256":
+		return sha256.New, nil
+	case "SHA512":
+		return sha512.New, nil
+	}
+	return nil, errs.InvalidInput("不支持的算法 %q，可选 SHA1、SHA256、SHA512", k.Algorithm)
+}
+
+// Counter 返回时间t所在的时间步序号
+func (k Key) Counter(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(k.Period)
+}
+
+// Remaining 返回时间t所在的时间步还剩多久结束
+func (k Key) Remaining(t time.Time) time.Duration {
+	period := time.Duration(k.Period) * time.Second
+	elapsed := time.Duration(t.UnixNano()) % period
+	return period - elapsed
+}
+
+// Code 返回时间t的验证码
+func (k Key) Code(t time.Time) (string, error) {
+	return k.CodeAt(k.Counter(t))
+}
+
+// CodeAt 返回第counter个时间步的验证码（RFC 4226 HOTP）
+func (k Key) CodeAt(counter uint64) (string, error) {
+	newHash, err := k.hash()
+	if err != nil {
+		return "", err
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(newHash, k.Secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// 动态截断：取最后一个字节的低4位作为偏移，读取31位整数
+	offset := sum[len(sum)-1] & 0x0F
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7FFFFFFF
+	mod := uint32(1)
+	for i := 0; i < k.Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", k.Digits, value%mod), nil
+}
+
+// URL 返回可以导入验证器应用的 otpauth URI，默认参数不写入URI
+func (k Key) URL() string {
+	label := k.Account
+	if k.Issuer != "" {
+		label = k.Issuer + ":" + k.Account
+	}
+	query := url.Values{}
+	query.Set("secret", EncodeSecret(k.Secret))
+	if k.Issuer != "" {
+		query.Set("issuer", k.Issuer)
+	}
+	if algorithm := strings.ToUpper(k.Algorithm); algorithm != "" && algorithm != DefaultAlgorithm {
+		query.Set("algorithm", algorithm)
+	}
+	if k.Digits != DefaultDigits {
+		query.Set("digits", strconv.Itoa(k.Digits))
+	}
+	if k.Period != DefaultPeriod {
+		query.Set("period", strconv.Itoa(k.Period))
+	}
+	// 部分验证器应用不把查询参数中的 + 解码为空格
+	u := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: strings.ReplaceAll(query.Encode(), "+", "%20")}
+	return u.String()
+}
@@ -0,0 +1,183 @@
+// Package units 解析和格式化大小、时长等带单位的数值
+//
+// 各命令的大小和时长参数统一使用这里的规则：大小按1024进制，支持 512K、10MB、1.5G、2GiB；
+// 时长支持 300ms、30s、1.5h、1h30m、2d、1w，纯数字按调用方指定的默认单位解释。
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"toolbox/pkg/errs"
+)
+
+// 大小单位
+const (
+	Byte     int64 = 1
+	KiloByte       = 1024 * Byte
+	MegaByte       = 1024 * KiloByte
+	GigaByte       = 1024 * MegaByte
+	TeraByte       = 1024 * GigaByte
+	PetaByte       = 1024 * TeraByte
+)
+
+// 时长单位，Go的time包没有天和周
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// sizeUnits 大小单位名称（小写）与字节数的对应关系
+var sizeUnits = map[string]int64{
+	"b": Byte,
+	"k": KiloByte, "kb": KiloByte, "kib": KiloByte,
+	"m": MegaByte, "mb": MegaByte, "mib": MegaByte,
+	"g": GigaByte, "gb": GigaByte, "gib": GigaByte,
+	"t": TeraByte, "tb": TeraByte, "tib": TeraByte,
+	"p": PetaByte, "pb": PetaByte, "pib": PetaByte,
+}
+
+// durationUnits 时长单位名称与时长的对应关系
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond, "µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  Day,
+	"w":  Week,
+}
+
+// ParseSize 解析大小，如 512K、10MB、1.5G，纯数字按defaultUnit解释
+func ParseSize(text string, defaultUnit int64) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(text))
+	number, unit := splitNumber(s)
+	if number == "" {
+		return 0, errs.InvalidInput("无效的大小 %q，应为数字加单位，如 512K、10M、1.5G", text)
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, errs.InvalidInput("无效的大小 %q，应为数字加单位，如 512K、10M、1.5G", text)
+	}
+
+	multiplier := defaultUnit
+	if unit = strings.TrimSpace(unit); unit != "" {
+		var ok bool
+		if multiplier, ok = sizeUnits[unit]; !ok {
+			return 0, errs.InvalidInput("未知的大小单位 %q（可用: B, K, M, G, T, P）", unit)
+		}
+	}
+
+	size := value * float64(multiplier)
+	if size > math.MaxInt64 {
+		return 0, errs.InvalidInput("大小 %q 超出范围", text)
+	}
+	return int64(size), nil
+}
+
+// FormatSize 将字节数格式化为能被 ParseSize 解析的紧凑形式，如 100M、1.5G
+func FormatSize(size int64) string {
+	units := []struct {
+		name  string
+		value int64
+	}{{"P", PetaByte}, {"T", TeraByte}, {"G", GigaByte}, {"M", MegaByte}, {"K", KiloByte}}
+	for _, u := range units {
+		if size >= u.value || -size >= u.value {
+			return strconv.FormatFloat(float64(size)/float64(u.value), 'f', -1, 64) + u.name
+		}
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// ParseDuration 解析时长，如 300ms、30s、1h30m、2d，纯数字按defaultUnit解释，允许前导符号
+func ParseDuration(text string, defaultUnit time.Duration) (time.Duration, error) {
+	invalid := errs.InvalidInput("无效的时长 %q，应为数字加单位，如 300ms、30s、1.5h、2d", text)
+
+	s := strings.TrimSpace(text)
+	sign := 1.0
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	if s == "" {
+		return 0, invalid
+	}
+
+	// 纯数字使用默认单位
+	if value, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(sign * value * float64(defaultUnit)), nil
+	}
+
+	var total float64
+	for s != "" {
+		number, rest := splitNumber(s)
+		if number == "" {
+			return 0, invalid
+		}
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, invalid
+		}
+
+		i := 0
+		for i < len(rest) && !(rest[i] == '.' || rest[i] >= '0' && rest[i] <= '9') {
+			i++
+		}
+		unit, ok := durationUnits[strings.ToLower(rest[:i])]
+		if !ok {
+			if rest[:i] == "" {
+				return 0, invalid
+			}
+			return 0, errs.InvalidInput("未知的时长单位 %q（可用: ms, s, m, h, d, w）", rest[:i])
+		}
+		total += value * float64(unit)
+		s = rest[i:]
+	}
+
+	if total > math.MaxInt64 {
+		return 0, errs.InvalidInput("时长 %q 超出范围", text)
+	}
+	return time.Duration(sign * total), nil
+}
+
+// FormatDuration 将时长格式化为能被 ParseDuration 解析的紧凑形式，如 1h30m、2d、300ms
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+	if d < time.Minute {
+		return d.String()
+	}
+
+	var b strings.Builder
+	for _, u := range []struct {
+		name  string
+		value time.Duration
+	}{{"d", Day}, {"h", time.Hour}, {"m", time.Minute}} {
+		if d >= u.value {
+			fmt.Fprintf(&b, "%d%s", d/u.value, u.name)
+			d %= u.value
+		}
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s")
+	}
+	return b.String()
+}
+
+// splitNumber 将字符串拆分为开头的数字部分和其余部分
+func splitNumber(s string) (string, string) {
+	i := 0
+	for i < len(s) && (s[i] == '.' || s[i] >= '0' && s[i] <= '9') {
+		i++
+	}
+	return s[:i], s[i:]
+}
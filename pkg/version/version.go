@@ -2,6 +2,7 @@ package version
 
 import (
 	"fmt"
+	"toolbox/pkg/i18n"
 )
 
 // 硬编码的版本信息
@@ -17,5 +18,5 @@ func GetVersionInfo() string {
 Version:    %s
 Developer:  %s
 About:      %s
-`, version, developer, about)
+`, version, developer, i18n.T(about))
 }
@@ -0,0 +1,166 @@
+// Package watch 周期性地执行命令，比较前后两次的输出并在满足条件时停止
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+	"toolbox/pkg/i18n"
+)
+
+// 停止原因
+const (
+	ReasonExitCode = "exit-code" // 退出码满足条件
+	ReasonMatch    = "match"     // 输出匹配正则表达式
+	ReasonCount    = "count"     // 达到执行次数
+	ReasonCanceled = "canceled"  // 被中断
+)
+
+// Options 监视选项
+type Options struct {
+	Executable string         // 要执行的程序
+	Args       []string       // 程序参数
+	Env        []string       // 额外的环境变量
+	Interval   time.Duration  // 两次执行之间的间隔
+	Count      int            // 最多执行的次数，0表示不限制
+	UntilExit  *int           // 退出码等于该值时停止
+	UntilMatch *regexp.Regexp // 输出匹配时停止
+
+	// OnRun 每次执行结束后调用
+	OnRun func(run Run)
+}
+
+// Run 一次执行的结果
+type Run struct {
+	Index    int           // 第几次执行，从1开始
+	Time     time.Time     // 开始执行的时间
+	Duration time.Duration // 耗时
+	Output   string        // 标准输出和标准错误的内容
+	Previous string        // 上一次执行的输出，第一次执行时为空
+	ExitCode int           // 退出码
+	Changed  bool          // 输出是否与上一次不同
+}
+
+// Summary 监视结束时的汇总
+type Summary struct {
+	Runs         int    `json:"runs"`           // 执行次数
+	Changes      int    `json:"changes"`        // 输出发生变化的次数
+	LastExitCode int    `json:"last_exit_code"` // 最后一次执行的退出码
+	Reason       string `json:"reason"`         // 停止原因
+}
+
+// Watch 按间隔重复执行命令，直到满足停止条件或ctx被取消
+func Watch(ctx context.Context, options Options) (*Summary, error) {
+	if options.Interval <= 0 {
+		return nil, i18n.Errorf("间隔必须大于0")
+	}
+
+	summary := &Summary{}
+	var previous string
+	for {
+		run := Run{Index: summary.Runs + 1, Time: time.Now(), Previous: previous}
+		output, exitCode, err := execute(ctx, options)
+		if ctx.Err() != nil {
+			summary.Reason = ReasonCanceled
+			return summary, nil
+		}
+		if err != nil {
+			return summary, err
+		}
+		run.Duration = time.Since(run.Time)
+		run.Output = output
+		run.ExitCode = exitCode
+		run.Changed = run.Index > 1 && output != previous
+
+		summary.Runs++
+		summary.LastExitCode = exitCode
+		if run.Changed {
+			summary.Changes++
+		}
+		if options.OnRun != nil {
+			options.OnRun(run)
+		}
+		previous = output
+
+		switch {
+		case options.UntilExit != nil && exitCode == *options.UntilExit:
+			summary.Reason = ReasonExitCode
+			return summary, nil
+		case options.UntilMatch != nil && options.UntilMatch.MatchString(output):
+			summary.Reason = ReasonMatch
+			return summary, nil
+		case options.Count > 0 && summary.Runs >= options.Count:
+			summary.Reason = ReasonCount
+			return summary, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			summary.Reason = ReasonCanceled
+			return summary, nil
+		case <-time.After(options.Interval):
+		}
+	}
+}
+
+// execute 执行一次命令，返回合并后的输出和退出码
+func execute(ctx context.Context, options Options) (string, int, error) {
+	var buf bytes.Buffer
+	cmd := exec.CommandContext(ctx, options.Executable, options.Args...)
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	cmd.Env = append(os.Environ(), options.Env...)
+
+	err := cmd.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+			return buf.String(), exitErr.ExitCode(), nil
+		}
+		return buf.String(), -1, i18n.Errorf("执行命令失败: %v", err)
+	}
+	return buf.String(), 0, nil
+}
+
+// Diff 返回cur的内容，其中与prev相同位置不同的字符用highlight包装
+//
+// 与 watch -d 一样逐行逐字符比较，连续变化的字符合并为一段。
+func Diff(prev, cur string, highlight func(string) string) string {
+	prevLines := strings.Split(prev, "\n")
+	curLines := strings.Split(cur, "\n")
+
+	var b strings.Builder
+	for i, line := range curLines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		var old []rune
+		if i < len(prevLines) {
+			old = []rune(prevLines[i])
+		}
+
+		runes := []rune(line)
+		start := -1
+		for j, r := range runes {
+			changed := j >= len(old) || old[j] != r
+			if changed && start < 0 {
+				start = j
+			} else if !changed && start >= 0 {
+				b.WriteString(highlight(string(runes[start:j])))
+				start = -1
+			}
+			if !changed {
+				b.WriteRune(r)
+			}
+		}
+		if start >= 0 {
+			b.WriteString(highlight(string(runes[start:])))
+		}
+	}
+	return b.String()
+}